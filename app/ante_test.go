@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestNewAnteHandlerFeeGrantKeeper(t *testing.T) {
+	wasmApp := Setup(t)
+	specs := map[string]struct {
+		feegrantKeeper ante.FeegrantKeeper
+	}{
+		"with grant allowance support": {
+			feegrantKeeper: wasmApp.FeeGrantKeeper,
+		},
+		"without grant allowance support": {
+			feegrantKeeper: useGrantedFeesOnly{},
+		},
+		"without feegrant keeper": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewAnteHandler(HandlerOptions{
+				HandlerOptions: ante.HandlerOptions{
+					AccountKeeper:   wasmApp.AccountKeeper,
+					BankKeeper:      wasmApp.BankKeeper,
+					SignModeHandler: wasmApp.TxConfig().SignModeHandler(),
+					FeegrantKeeper:  spec.feegrantKeeper,
+				},
+				IBCKeeper:             wasmApp.IBCKeeper,
+				NodeConfig:            &wasmtypes.NodeConfig{},
+				WasmKeeper:            &wasmApp.WasmKeeper,
+				TXCounterStoreService: runtime.NewKVStoreService(storetypes.NewKVStoreKey("tx_counter")),
+				CircuitKeeper:         &wasmApp.CircuitKeeper,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+// useGrantedFeesOnly is a feegrant keeper that can not grant allowances
+type useGrantedFeesOnly struct{}
+
+func (useGrantedFeesOnly) UseGrantedFees(context.Context, sdk.AccAddress, sdk.AccAddress, sdk.Coins, []sdk.Msg) error {
+	return nil
+}
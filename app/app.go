@@ -8,10 +8,12 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	dbm "github.com/cosmos/cosmos-db"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
 	"github.com/cosmos/gogoproto/proto"
 	ica "github.com/cosmos/ibc-go/v10/modules/apps/27-interchain-accounts"
 	icacontroller "github.com/cosmos/ibc-go/v10/modules/apps/27-interchain-accounts/controller"
@@ -219,6 +221,11 @@ type WasmApp struct {
 	TransferKeeper      ibctransferkeeper.Keeper
 	WasmKeeper          wasmkeeper.Keeper
 
+	// node local streaming of contract state changes, nil when not enabled
+	stateWatcher *wasmkeeper.StateWatcher
+	// node local cache of aliased smart query responses of the REST API, nil when not enabled
+	aliasQueryCache *wasm.AliasQueryCache
+
 	// the module manager
 	ModuleManager      *module.Manager
 	BasicModuleManager module.BasicManager
@@ -312,7 +319,7 @@ func NewWasmApp(
 		icacontrollertypes.StoreKey,
 	)
 
-	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey, wasmtypes.TStoreKey)
 
 	// register streaming services
 	if err := bApp.RegisterStreamingServices(appOpts, keys); err != nil {
@@ -582,6 +589,48 @@ func NewWasmApp(
 		panic(fmt.Sprintf("error while reading wasm config: %s", err))
 	}
 
+	// contracts can read the params of the modules that are allowed in the wasm params,
+	// the upload and instantiate permissions of the wasm module
+	// and resolve ibc denoms via the transfer keeper.
+	// The historical state of the commit multistore is used by the node local state diff query.
+	// The transient store collects the wasm activity for the block summary event.
+	wasmOpts = append([]wasmkeeper.Option{
+		wasmkeeper.WithParamsQueryRoutes(wasmkeeper.DefaultParamsQueryRoutes()),
+		wasmkeeper.WithWasmParamsQuerier(),
+		wasmkeeper.WithDenomTraceQuerier(app.TransferKeeper),
+		wasmkeeper.WithVersionedMultiStore(app.CommitMultiStore(), keys[wasmtypes.StoreKey]),
+		wasmkeeper.WithTransientStoreService(runtime.NewTransientStoreService(tkeys[wasmtypes.TStoreKey])),
+	}, wasmOpts...)
+	// contract store writes are logged for consensus failure forensics when configured
+	if nodeConfig.StoreWriteLogDir != "" {
+		logDir := nodeConfig.StoreWriteLogDir
+		if !filepath.IsAbs(logDir) {
+			logDir = filepath.Join(homePath, logDir)
+		}
+		writeLog, err := wasmkeeper.NewFileStoreWriteLog(logDir, wasmkeeper.DefaultStoreWriteLogMaxFileSize, wasmkeeper.DefaultStoreWriteLogMaxBackups, logger.With("module", "x/wasm"))
+		if err != nil {
+			panic(fmt.Sprintf("error while opening wasm store write log: %s", err))
+		}
+		wasmOpts = append(wasmOpts, wasmkeeper.WithStoreWriteLog(writeLog))
+	}
+
+	// contract state changes are streamed to node local subscribers when enabled
+	if nodeConfig.EnableStateWatch {
+		pluginKey := fmt.Sprintf("%s.%s.%s", baseapp.StreamingTomlKey, baseapp.StreamingABCITomlKey, baseapp.StreamingABCIPluginTomlKey)
+		if strings.TrimSpace(cast.ToString(appOpts.Get(pluginKey))) != "" {
+			panic("wasm state watch can not be combined with an abci streaming plugin")
+		}
+		app.stateWatcher = wasmkeeper.NewStateWatcher(app.CommitMultiStore(), keys[wasmtypes.StoreKey], wasmkeeper.DefaultStateWatchBufferSize)
+		app.CommitMultiStore().AddListeners([]storetypes.StoreKey{keys[wasmtypes.StoreKey]})
+		app.SetStreamingManager(storetypes.StreamingManager{ABCIListeners: []storetypes.ABCIListener{app.stateWatcher}})
+	}
+
+	if nodeConfig.AliasQueryCacheSize != 0 {
+		if app.aliasQueryCache, err = wasm.NewAliasQueryCache(int(nodeConfig.AliasQueryCacheSize)); err != nil {
+			panic(fmt.Sprintf("error while creating the alias query cache: %s", err))
+		}
+	}
+
 	// The last arguments can contain custom message handlers, and custom query handlers,
 	// if we want to allow any custom callbacks
 	app.WasmKeeper = wasmkeeper.NewKeeper(
@@ -867,10 +916,18 @@ func NewWasmApp(
 		}
 		ctx := app.BaseApp.NewUncachedContext(true, tmproto.Header{})
 
+		// Ensure the wasm cache matches the stored codes before they are pinned
+		if err := app.WasmKeeper.ValidateVMCache(ctx, nodeConfig.StrictVMCacheCheck); err != nil {
+			panic(fmt.Sprintf("failed to validate wasm cache %s", err))
+		}
 		// Initialize pinned codes in wasmvm as they are not persisted there
 		if err := app.WasmKeeper.InitializePinnedCodes(ctx); err != nil {
 			panic(fmt.Sprintf("failed initialize pinned codes %s", err))
 		}
+		// Compile all codes before the node is ready, within the time budget
+		if nodeConfig.PrecompileOnStart {
+			app.WasmKeeper.PrecompileCodes(ctx, int(nodeConfig.PrecompileWorkers), nodeConfig.PrecompileTimeBudget)
+		}
 	}
 
 	return app
@@ -1057,6 +1114,11 @@ func (app *WasmApp) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APICo
 	// Register node gRPC service for grpc-gateway.
 	nodeservice.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
 
+	// Register the cached aliased smart query before the module routes as the first matching route is served.
+	if app.aliasQueryCache != nil {
+		wasm.RegisterAliasQueryCacheRoute(apiSvr.GRPCGatewayRouter, wasmtypes.NewQueryClient(clientCtx), app.aliasQueryCache)
+	}
+
 	// Register grpc-gateway routes for all modules.
 	app.BasicModuleManager.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
 
@@ -1082,6 +1144,14 @@ func (app *WasmApp) RegisterTendermintService(clientCtx client.Context) {
 	)
 }
 
+// RegisterGRPCServer registers the gRPC services of the app and the node local wasm state watch service when enabled.
+func (app *WasmApp) RegisterGRPCServer(server gogogrpc.Server) {
+	app.BaseApp.RegisterGRPCServer(server)
+	if app.stateWatcher != nil {
+		wasmtypes.RegisterStateWatchServer(server, wasmkeeper.NewStateWatchServer(app.stateWatcher))
+	}
+}
+
 func (app *WasmApp) RegisterNodeService(clientCtx client.Context, cfg config.Config) {
 	nodeservice.RegisterNodeService(clientCtx, app.GRPCQueryRouter(), cfg)
 }
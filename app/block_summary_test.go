@@ -0,0 +1,136 @@
+package app
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/testutil/mock"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestBlockWasmSummaryEvent(t *testing.T) {
+	privVal := mock.NewPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	valSet := cmttypes.NewValidatorSet([]*cmttypes.Validator{cmttypes.NewValidator(pubKey, 1)})
+	senderPrivKey := secp256k1.GenPrivKey()
+	sender := sdk.AccAddress(senderPrivKey.PubKey().Address())
+	acc := authtypes.NewBaseAccount(sender, senderPrivKey.PubKey(), 0, 0)
+	balance := banktypes.Balance{
+		Address: sender.String(),
+		Coins:   sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdkmath.NewInt(100000000000000))),
+	}
+
+	appOpts := simtestutil.AppOptionsMap{flags.FlagHome: t.TempDir()}
+	wasmApp := NewWasmApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, appOpts, nil)
+	genesisState, err := GenesisStateWithValSet(wasmApp.AppCodec(), wasmApp.DefaultGenesis(), valSet, []authtypes.GenesisAccount{acc}, balance)
+	require.NoError(t, err)
+	stateBytes, err := cmtjson.MarshalIndent(genesisState, "", " ")
+	require.NoError(t, err)
+	_, err = wasmApp.InitChain(&abci.RequestInitChain{
+		Validators:      []abci.ValidatorUpdate{},
+		ConsensusParams: simtestutil.DefaultConsensusParams,
+		AppStateBytes:   stateBytes,
+	})
+	require.NoError(t, err)
+
+	var seq uint64
+	signTx := func(msg sdk.Msg) []byte {
+		t.Helper()
+		tx, err := simtestutil.GenSignedMockTx(rand.New(rand.NewSource(time.Now().UnixNano())), wasmApp.TxConfig(), []sdk.Msg{msg}, nil, simtestutil.DefaultGenTxGas, "", []uint64{0}, []uint64{seq}, senderPrivKey)
+		require.NoError(t, err)
+		bz, err := wasmApp.TxConfig().TxEncoder()(tx)
+		require.NoError(t, err)
+		seq++
+		return bz
+	}
+	deliverBlock := func(txs ...[]byte) *abci.ResponseFinalizeBlock {
+		t.Helper()
+		res, err := wasmApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: wasmApp.LastBlockHeight() + 1, Time: time.Now(), Txs: txs})
+		require.NoError(t, err)
+		_, err = wasmApp.Commit()
+		require.NoError(t, err)
+		return res
+	}
+	blockSummary := func(res *abci.ResponseFinalizeBlock) *wasmtypes.EventBlockWasmSummary {
+		t.Helper()
+		var result *wasmtypes.EventBlockWasmSummary
+		for _, e := range res.Events {
+			if e.Type != "cosmwasm.wasm.v1.EventBlockWasmSummary" {
+				continue
+			}
+			require.Nil(t, result, "only one summary per block")
+			// drop the mode attribute that is added to the block events
+			attrs := make([]abci.EventAttribute, 0, len(e.Attributes))
+			for _, a := range e.Attributes {
+				if a.Key != "mode" {
+					attrs = append(attrs, a)
+				}
+			}
+			msg, err := sdk.ParseTypedEvent(abci.Event{Type: e.Type, Attributes: attrs})
+			require.NoError(t, err)
+			result = msg.(*wasmtypes.EventBlockWasmSummary)
+		}
+		require.NotNil(t, result)
+		return result
+	}
+
+	// a block without wasm activity
+	res := deliverBlock(signTx(&wasmtypes.MsgStoreCode{Sender: sender.String(), WASMByteCode: testdata.ReflectContractWasm()}))
+	require.Equal(t, uint32(0), res.TxResults[0].Code, res.TxResults[0].Log)
+	assert.Equal(t, wasmtypes.EventBlockWasmSummary{Height: wasmApp.LastBlockHeight()}, *blockSummary(res))
+
+	res = deliverBlock(signTx(&wasmtypes.MsgInstantiateContract{Sender: sender.String(), CodeID: 1, Label: "first", Msg: []byte("{}")}))
+	require.Equal(t, uint32(0), res.TxResults[0].Code, res.TxResults[0].Log)
+	contractAddr := wasmkeeper.BuildContractAddressClassic(1, 1)
+
+	// when a block with multiple txs is executed
+	execMsg, err := json.Marshal(testdata.ReflectHandleMsg{ChangeOwner: &testdata.OwnerPayload{Owner: sender}})
+	require.NoError(t, err)
+	res = deliverBlock(
+		signTx(&wasmtypes.MsgExecuteContract{Sender: sender.String(), Contract: contractAddr.String(), Msg: execMsg}),
+		signTx(&wasmtypes.MsgInstantiateContract{Sender: sender.String(), CodeID: 1, Label: "second", Msg: []byte("{}")}),
+		signTx(&wasmtypes.MsgExecuteContract{Sender: sender.String(), Contract: contractAddr.String(), Msg: execMsg}),
+		// a failed tx is not counted
+		signTx(&wasmtypes.MsgExecuteContract{Sender: sender.String(), Contract: contractAddr.String(), Msg: []byte(`{"unknown":{}}`)}),
+	)
+
+	// then
+	require.Len(t, res.TxResults, 4)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, uint32(0), res.TxResults[i].Code, res.TxResults[i].Log)
+	}
+	require.NotEqual(t, uint32(0), res.TxResults[3].Code)
+	got := blockSummary(res)
+	assert.Equal(t, wasmApp.LastBlockHeight(), got.Height)
+	assert.Equal(t, uint64(1), got.Instantiates)
+	assert.Equal(t, uint64(2), got.Executes)
+	assert.Equal(t, uint64(0), got.Migrates)
+	assert.Equal(t, uint64(2), got.ContractsTouched)
+	assert.NotZero(t, got.VMGasUsed)
+
+	// and the activity is reset for the next block
+	res = deliverBlock()
+	assert.Equal(t, wasmtypes.EventBlockWasmSummary{Height: wasmApp.LastBlockHeight()}, *blockSummary(res))
+}
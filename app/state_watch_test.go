@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"cosmossdk.io/log"
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/testutil/mock"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestWatchContractState(t *testing.T) {
+	privVal := mock.NewPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	valSet := cmttypes.NewValidatorSet([]*cmttypes.Validator{cmttypes.NewValidator(pubKey, 1)})
+	senderPrivKey := secp256k1.GenPrivKey()
+	sender := sdk.AccAddress(senderPrivKey.PubKey().Address())
+	acc := authtypes.NewBaseAccount(sender, senderPrivKey.PubKey(), 0, 0)
+	balance := banktypes.Balance{
+		Address: sender.String(),
+		Coins:   sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdkmath.NewInt(100000000000000))),
+	}
+
+	appOpts := simtestutil.AppOptionsMap{flags.FlagHome: t.TempDir(), "wasm.enable_state_watch": true}
+	wasmApp := NewWasmApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, appOpts, nil)
+	genesisState, err := GenesisStateWithValSet(wasmApp.AppCodec(), wasmApp.DefaultGenesis(), valSet, []authtypes.GenesisAccount{acc}, balance)
+	require.NoError(t, err)
+	stateBytes, err := cmtjson.MarshalIndent(genesisState, "", " ")
+	require.NoError(t, err)
+	_, err = wasmApp.InitChain(&abci.RequestInitChain{
+		Validators:      []abci.ValidatorUpdate{},
+		ConsensusParams: simtestutil.DefaultConsensusParams,
+		AppStateBytes:   stateBytes,
+	})
+	require.NoError(t, err)
+	var seq uint64
+	deliver := func(msgs ...sdk.Msg) {
+		t.Helper()
+		res, err := SignAndDeliverWithoutCommit(t, wasmApp.TxConfig(), wasmApp.BaseApp, msgs, nil, "", []uint64{0}, []uint64{seq}, time.Now(), senderPrivKey)
+		require.NoError(t, err)
+		require.Len(t, res.TxResults, 1)
+		require.Equal(t, uint32(0), res.TxResults[0].Code, res.TxResults[0].Log)
+		_, err = wasmApp.Commit()
+		require.NoError(t, err)
+		seq++
+	}
+
+	// setup the grpc server with the node local services
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	wasmApp.RegisterGRPCServer(srv)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	// store and instantiate a reflect contract
+	deliver(
+		&wasmtypes.MsgStoreCode{Sender: sender.String(), WASMByteCode: testdata.ReflectContractWasm()},
+		&wasmtypes.MsgInstantiateContract{Sender: sender.String(), CodeID: 1, Label: "reflect", Msg: []byte("{}")},
+	)
+	contractAddr := wasmkeeper.BuildContractAddressClassic(1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := wasmtypes.NewStateWatchClient(conn).WatchContractState(ctx, &wasmtypes.WatchContractStateRequest{Address: contractAddr.String()})
+	require.NoError(t, err)
+	_, err = stream.Header() // wait for the subscription
+	require.NoError(t, err)
+	configKey := []byte("\x00\x06config") // namespaced singleton of the reflect contract
+	oldConfig := wasmApp.WasmKeeper.QueryRaw(wasmApp.NewContext(true), contractAddr, configKey)
+	require.NotEmpty(t, oldConfig)
+
+	// when the contract is executed
+	newOwner := sdk.AccAddress(make([]byte, 20))
+	execMsg, err := json.Marshal(testdata.ReflectHandleMsg{ChangeOwner: &testdata.OwnerPayload{Owner: newOwner}})
+	require.NoError(t, err)
+	deliver(&wasmtypes.MsgExecuteContract{Sender: sender.String(), Contract: contractAddr.String(), Msg: execMsg})
+
+	// then the state change is streamed
+	got, err := stream.Recv()
+	require.NoError(t, err)
+	oldHash := sha256.Sum256(oldConfig)
+	assert.Equal(t, wasmApp.LastBlockHeight(), got.Height)
+	assert.Equal(t, configKey, []byte(got.Key))
+	assert.Equal(t, oldHash[:], got.OldValueHash)
+	assert.Contains(t, string(got.NewValue), newOwner.String())
+	assert.False(t, got.Deleted)
+}
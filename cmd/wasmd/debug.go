@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/store"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/debug"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/app"
+	"github.com/CosmWasm/wasmd/x/wasm"
+	wasmcli "github.com/CosmWasm/wasmd/x/wasm/client/cli"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// debugCmd extends the sdk debug command with wasm tooling
+func debugCmd() *cobra.Command {
+	cmd := debug.Cmd()
+	wasmCmd := &cobra.Command{
+		Use:                        "wasm",
+		Short:                      "Wasm debugging subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	wasmCmd.AddCommand(orderSensitivityCmd(), revalidateCodesCmd(), wasmcli.GetCmdDevSnapshot())
+	cmd.AddCommand(wasmCmd)
+	return cmd
+}
+
+// orderSensitivityCmd replays the contract executions of a block in two orders against the local node data
+func orderSensitivityCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "order-sensitivity [height]",
+		Short: "Report contracts whose state depends on the tx order within a block",
+		Long: `Executes the wasm execute messages of the block at the given height on top of the state of the
+previous height, once in block order and once with all independent messages in reverse order. Messages are
+independent when they touch no account or contract that another message touches. The contract state diffs
+between both runs are reported as json. Other messages and begin/end block logic are not replayed.
+This requires the block and the application state of the previous height in the local node home.
+Nothing is written to the node data. The node should be stopped while this command runs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			if height < 2 {
+				return fmt.Errorf("height must be greater than 1")
+			}
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			cfg := serverCtx.Config
+
+			blockStoreDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: cfg})
+			if err != nil {
+				return err
+			}
+			defer blockStoreDB.Close()
+			block := store.NewBlockStore(blockStoreDB).LoadBlock(height)
+			if block == nil {
+				return fmt.Errorf("block %d not found", height)
+			}
+
+			db, err := dbm.NewDB("application", server.GetAppDBBackend(serverCtx.Viper), cfg.DBDir())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			wasmApp := app.NewWasmApp(log.NewNopLogger(), db, nil, true, serverCtx.Viper, nil)
+			cms, err := wasmApp.CommitMultiStore().CacheMultiStoreWithVersion(height - 1)
+			if err != nil {
+				return fmt.Errorf("state of height %d: %w", height-1, err)
+			}
+
+			var msgs []wasmkeeper.BlockExecuteMsg
+			txDecoder := wasmApp.TxConfig().TxDecoder()
+			for i, txBz := range block.Txs {
+				tx, err := txDecoder(txBz)
+				if err != nil {
+					return fmt.Errorf("decode tx %d: %w", i, err)
+				}
+				for _, msg := range tx.GetMsgs() {
+					if m, ok := msg.(*wasmtypes.MsgExecuteContract); ok {
+						msgs = append(msgs, wasmkeeper.BlockExecuteMsg{TxIndex: uint32(i), Msg: m})
+					}
+				}
+			}
+
+			ctx := sdk.NewContext(cms, *block.Header.ToProto(), false, wasmApp.Logger())
+			report, err := wasmApp.WasmKeeper.OrderSensitivity(ctx, msgs)
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			cmd.Println(string(bz))
+			return nil
+		},
+	}
+}
+
+const (
+	flagPinnedOnly = "pinned-only"
+	flagSample     = "sample"
+	flagSeed       = "seed"
+
+	// revalidateMemoryLimit is the contract memory limit of the wasm keeper in MiB
+	revalidateMemoryLimit = 32
+)
+
+// revalidateCodesCmd runs the wasmvm validation of this binary over the stored codes of the local node data
+func revalidateCodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revalidate-codes",
+		Short: "Verify that the stored codes pass the validation of the wasmvm of this binary",
+		Long: `Runs the wasmvm validation and analysis of this binary over the wasm code of the stored codes of the local
+node data and reports the result per code as json. Pinned codes are also pinned. Run this with the new binary
+before an upgrade that changes the wasmvm version to find codes that the new version rejects.
+The wasmvm cache of the node is only read, the codes are compiled into a temporary directory. Nothing is written
+to the node data. The node should be stopped while this command runs. The command fails when a code fails.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			pinnedOnly, err := cmd.Flags().GetBool(flagPinnedOnly)
+			if err != nil {
+				return err
+			}
+			sample, err := cmd.Flags().GetInt(flagSample)
+			if err != nil {
+				return err
+			}
+			seed, err := cmd.Flags().GetInt64(flagSeed)
+			if err != nil {
+				return err
+			}
+			if sample < 0 {
+				return fmt.Errorf("sample must not be negative")
+			}
+			if !cmd.Flags().Changed(flagSeed) {
+				seed = time.Now().UnixNano()
+			}
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			cfg := serverCtx.Config
+
+			db, err := dbm.NewDB("application", server.GetAppDBBackend(serverCtx.Viper), cfg.DBDir())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			// load without the start up tasks of the wasm keeper that write to the wasmvm cache
+			wasmApp := app.NewWasmApp(log.NewNopLogger(), db, nil, false, serverCtx.Viper, nil)
+			if err := wasmApp.LoadLatestVersion(); err != nil {
+				return err
+			}
+
+			tmpDir, err := os.MkdirTemp("", "wasm-revalidate")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmpDir)
+			nodeConfig, err := wasm.ReadNodeConfig(serverCtx.Viper)
+			if err != nil {
+				return err
+			}
+			wasmVM, err := wasmvm.NewVMWithConfig(wasmvmtypes.VMConfig{
+				Cache: wasmvmtypes.CacheOptions{
+					BaseDir:                  tmpDir,
+					AvailableCapabilities:    wasmkeeper.BuiltInCapabilities(),
+					MemoryCacheSizeBytes:     wasmvmtypes.NewSizeMebi(nodeConfig.MemoryCacheSize),
+					InstanceMemoryLimitBytes: wasmvmtypes.NewSizeMebi(revalidateMemoryLimit),
+				},
+			}, false)
+			if err != nil {
+				return err
+			}
+			defer wasmVM.Cleanup()
+
+			ctx := sdk.NewContext(wasmApp.CommitMultiStore().CacheMultiStore(), cmtproto.Header{}, false, wasmApp.Logger())
+			report, err := wasmApp.WasmKeeper.RevalidateCodes(ctx, wasmVM, wasmkeeper.CodeRevalidationFilter{
+				PinnedOnly: pinnedOnly,
+				Sample:     sample,
+				Rand:       rand.New(rand.NewSource(seed)), //nolint:gosec
+			})
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			cmd.Println(string(bz))
+			if report.Failed != 0 {
+				return fmt.Errorf("%d of %d codes failed", report.Failed, report.Checked)
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagPinnedOnly, false, "Check the pinned codes only")
+	cmd.Flags().Int(flagSample, 0, "Check a random sample of the given number of codes. 0 checks all codes")
+	cmd.Flags().Int64(flagSeed, 0, "Seed of the random sample. A random seed is used when not set")
+	return cmd
+}
@@ -43,7 +43,7 @@ RESP=$(wasmd tx wasm instantiate2 "$CODE_ID" "$INIT" $(echo -n "testing" | xxd -
 sleep 6
 wasmd q tx $(echo "$RESP"| jq -r '.txhash') -o json | jq
 
-predictedAddress=$(wasmd q wasm build-address "$CODE_HASH" $(wasmd keys show validator -a --keyring-backend=test) $(echo -n "testing" | xxd -ps) "$INIT")
+predictedAddress=$(wasmd q wasm build-address "$CODE_HASH" $(wasmd keys show validator -a --keyring-backend=test) "testing" "$INIT" --ascii | sed -n 's/^address: //p')
 wasmd q wasm contract "$predictedAddress" -o json | jq
 
 echo "### Query all"
@@ -0,0 +1,224 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/x/feegrant"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/CosmWasm/wasmd/app"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractFeeSponsorship(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Height: 10, Time: time.Now()})
+	addrs := app.AddTestAddrsIncremental(wasmApp, ctx, 2, sdkmath.NewInt(1_000_000))
+	creator, user := addrs[0], addrs[1]
+	msgRouter := wasmApp.MsgServiceRouter()
+
+	storeMsg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = wasmContract
+		m.Sender = creator.String()
+	})
+	rsp, err := msgRouter.Handler(storeMsg)(ctx, storeMsg)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+
+	instantiate := func(t *testing.T) sdk.AccAddress {
+		msg := &types.MsgInstantiateContract{
+			Sender: creator.String(),
+			Admin:  creator.String(),
+			CodeID: storeCodeResponse.CodeID,
+			Label:  "sponsor",
+			Msg:    []byte(`{}`),
+			Funds:  sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000)),
+		}
+		rsp, err := msgRouter.Handler(msg)(ctx, msg)
+		require.NoError(t, err)
+		var instantiateResponse types.MsgInstantiateContractResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+		return sdk.MustAccAddressFromBech32(instantiateResponse.Address)
+	}
+	sponsor, otherContract := instantiate(t), instantiate(t)
+	setSponsorshipMsg := &types.MsgSetContractFeeSponsorship{
+		Sender:   creator.String(),
+		Contract: sponsor.String(),
+		Sponsorship: &types.FeeSponsorship{
+			MaxFeePerTx:    sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)),
+			MaxFeePerBlock: sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 150)),
+		},
+	}
+	_, err = msgRouter.Handler(setSponsorshipMsg)(ctx, setSponsorshipMsg)
+	require.NoError(t, err)
+
+	anteHandler := sdk.ChainAnteDecorators(
+		keeper.NewContractFeeSponsorshipDecorator(&wasmApp.WasmKeeper, wasmApp.FeeGrantKeeper),
+		ante.NewDeductFeeDecorator(wasmApp.AccountKeeper, wasmApp.BankKeeper, wasmApp.FeeGrantKeeper, nil),
+	)
+	executeMsg := func(contract sdk.AccAddress) sdk.Msg {
+		return &types.MsgExecuteContract{Sender: user.String(), Contract: contract.String(), Msg: []byte(`{}`)}
+	}
+	buildTx := func(t *testing.T, fee sdk.Coins, granter sdk.AccAddress, msgs ...sdk.Msg) sdk.Tx {
+		txBuilder := wasmApp.TxConfig().NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(msgs...))
+		txBuilder.SetFeeAmount(fee)
+		txBuilder.SetFeeGranter(granter)
+		txBuilder.SetGasLimit(200_000)
+		return txBuilder.GetTx()
+	}
+	myFee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 60))
+
+	specs := map[string]struct {
+		setup      func(t *testing.T, ctx sdk.Context)
+		tx         sdk.Tx
+		expErr     bool
+		expPaidBy  sdk.AccAddress
+		expUsed    sdk.Coins
+		expEvent   bool
+		expAllowed bool
+	}{
+		"sponsored": {
+			tx:        buildTx(t, myFee, sponsor, executeMsg(sponsor), executeMsg(sponsor)),
+			expPaidBy: sponsor,
+			expUsed:   myFee,
+			expEvent:  true,
+		},
+		"sponsored within remaining block budget": {
+			setup: func(t *testing.T, ctx sdk.Context) {
+				_, err := anteHandler(ctx, buildTx(t, myFee, sponsor, executeMsg(sponsor)), false)
+				require.NoError(t, err)
+			},
+			tx:        buildTx(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 90)), sponsor, executeMsg(sponsor)),
+			expPaidBy: sponsor,
+			expUsed:   sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 150)),
+			expEvent:  true,
+		},
+		"block budget exceeded": {
+			setup: func(t *testing.T, ctx sdk.Context) {
+				_, err := anteHandler(ctx, buildTx(t, myFee, sponsor, executeMsg(sponsor)), false)
+				require.NoError(t, err)
+			},
+			tx:      buildTx(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 91)), sponsor, executeMsg(sponsor)),
+			expErr:  true,
+			expUsed: myFee,
+		},
+		"block budget of previous block not counted": {
+			setup: func(t *testing.T, ctx sdk.Context) {
+				_, err := anteHandler(ctx.WithBlockHeight(ctx.BlockHeight()-1), buildTx(t, myFee, sponsor, executeMsg(sponsor)), false)
+				require.NoError(t, err)
+			},
+			tx:        buildTx(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 91)), sponsor, executeMsg(sponsor)),
+			expPaidBy: sponsor,
+			expUsed:   sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 91)),
+			expEvent:  true,
+		},
+		"tx budget exceeded": {
+			tx:      buildTx(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 101)), sponsor, executeMsg(sponsor)),
+			expErr:  true,
+			expUsed: sdk.NewCoins(),
+		},
+		"fee in other denom": {
+			tx:      buildTx(t, sdk.NewCoins(sdk.NewInt64Coin("other", 1)), sponsor, executeMsg(sponsor)),
+			expErr:  true,
+			expUsed: sdk.NewCoins(),
+		},
+		"other message type": {
+			tx: buildTx(t, myFee, sponsor, executeMsg(sponsor), &banktypes.MsgSend{
+				FromAddress: user.String(),
+				ToAddress:   creator.String(),
+				Amount:      sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)),
+			}),
+			expErr:  true,
+			expUsed: sdk.NewCoins(),
+		},
+		"other contract executed": {
+			tx:      buildTx(t, myFee, sponsor, executeMsg(sponsor), executeMsg(otherContract)),
+			expErr:  true,
+			expUsed: sdk.NewCoins(),
+		},
+		"contract without sponsorship": {
+			tx:      buildTx(t, myFee, otherContract, executeMsg(otherContract)),
+			expErr:  true,
+			expUsed: sdk.NewCoins(),
+		},
+		"no fee granter": {
+			tx:        buildTx(t, myFee, nil, executeMsg(sponsor)),
+			expPaidBy: user,
+			expUsed:   sdk.NewCoins(),
+		},
+		"zero fee": {
+			tx:      buildTx(t, sdk.NewCoins(), sponsor, executeMsg(sponsor)),
+			expErr:  true,
+			expUsed: sdk.NewCoins(),
+		},
+		"existing allowance used": {
+			setup: func(t *testing.T, ctx sdk.Context) {
+				allowance := &feegrant.BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500))}
+				require.NoError(t, wasmApp.FeeGrantKeeper.GrantAllowance(ctx, sponsor, user, allowance))
+			},
+			tx:         buildTx(t, myFee, sponsor, executeMsg(sponsor)),
+			expPaidBy:  sponsor,
+			expUsed:    sdk.NewCoins(),
+			expAllowed: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(t, ctx)
+			}
+			sponsorBalance := wasmApp.BankKeeper.GetAllBalances(ctx, sponsor)
+			userBalance := wasmApp.BankKeeper.GetAllBalances(ctx, user)
+			em := sdk.NewEventManager()
+
+			// when
+			_, gotErr := anteHandler(ctx.WithEventManager(em), spec.tx, false)
+
+			// then
+			assert.Equal(t, spec.expUsed, wasmApp.WasmKeeper.GetFeeSponsorshipUsedInBlock(ctx, sponsor))
+			_, err := wasmApp.FeeGrantKeeper.GetAllowance(ctx, sponsor, user)
+			if spec.expAllowed {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			var gotEvent bool
+			for _, e := range em.Events() {
+				gotEvent = gotEvent || e.Type == types.EventTypeFeeSponsored
+			}
+			assert.Equal(t, spec.expEvent, gotEvent)
+			if spec.expErr {
+				require.ErrorContains(t, gotErr, "fee-grant not found")
+				assert.Equal(t, sponsorBalance, wasmApp.BankKeeper.GetAllBalances(ctx, sponsor))
+				assert.Equal(t, userBalance, wasmApp.BankKeeper.GetAllBalances(ctx, user))
+				return
+			}
+			require.NoError(t, gotErr)
+			fee := spec.tx.(sdk.FeeTx).GetFee()
+			switch {
+			case spec.expPaidBy.Equals(sponsor):
+				assert.Equal(t, sponsorBalance.Sub(fee...), wasmApp.BankKeeper.GetAllBalances(ctx, sponsor))
+				assert.Equal(t, userBalance, wasmApp.BankKeeper.GetAllBalances(ctx, user))
+			case spec.expPaidBy.Equals(user):
+				assert.Equal(t, sponsorBalance, wasmApp.BankKeeper.GetAllBalances(ctx, sponsor))
+				assert.Equal(t, userBalance.Sub(fee...), wasmApp.BankKeeper.GetAllBalances(ctx, user))
+			default:
+				assert.Equal(t, sponsorBalance, wasmApp.BankKeeper.GetAllBalances(ctx, sponsor))
+				assert.Equal(t, userBalance, wasmApp.BankKeeper.GetAllBalances(ctx, user))
+			}
+		})
+	}
+}
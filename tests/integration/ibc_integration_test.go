@@ -116,6 +116,86 @@ func TestIBCReflectContract(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestContractChannelStatusOrdered(t *testing.T) {
+	// scenario: given an ordered channel between "ibc_reflect_send" on chain A and "ibc_reflect" on chain B
+	//           with the "whoami" packet sent by chain A on channel connect
+	//           then the status shows the unrelayed packet until it is relayed
+
+	var (
+		coordinator = wasmibctesting.NewCoordinator(t, 2)
+		chainA      = wasmibctesting.NewWasmTestChain(coordinator.GetChain(ibctesting.GetChainID(1)))
+		chainB      = wasmibctesting.NewWasmTestChain(coordinator.GetChain(ibctesting.GetChainID(2)))
+	)
+	coordinator.CommitBlock(chainA.TestChain, chainB.TestChain)
+	sendContractAddr := chainA.InstantiateContract(chainA.StoreCodeFile("./testdata/ibc_reflect_send.wasm").CodeID, []byte(`{}`))
+	reflectID := chainB.StoreCodeFile("./testdata/reflect_1_5.wasm").CodeID
+	initMsg := wasmkeeper.IBCReflectInitMsg{ReflectCodeID: reflectID}.GetBytes(t)
+	reflectContractAddr := chainB.InstantiateContract(chainB.StoreCodeFile("./testdata/ibc_reflect.wasm").CodeID, initMsg)
+	coordinator.CommitBlock(chainA.TestChain, chainB.TestChain)
+	coordinator.UpdateTime()
+
+	path := wasmibctesting.NewWasmPath(chainA, chainB)
+	path.EndpointA.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  chainA.ContractInfo(sendContractAddr).IBCPortID,
+		Version: "ibc-reflect-v1",
+		Order:   channeltypes.ORDERED,
+	}
+	path.EndpointB.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  chainB.ContractInfo(reflectContractAddr).IBCPortID,
+		Version: "ibc-reflect-v1",
+		Order:   channeltypes.ORDERED,
+	}
+	coordinator.SetupConnections(&path.Path)
+	coordinator.CreateChannels(&path.Path)
+	require.Equal(t, 1, len(*chainA.PendingSendPackets))
+
+	queryStatus := func(chain *wasmibctesting.WasmTestChain, contractAddr sdk.AccAddress, channelID string) *types.QueryContractChannelStatusResponse {
+		t.Helper()
+		res, err := wasmkeeper.Querier(&chain.GetWasmApp().WasmKeeper).ContractChannelStatus(chain.GetContext(), &types.QueryContractChannelStatusRequest{
+			Address:   contractAddr.String(),
+			ChannelID: channelID,
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	// when the packet is not relayed
+	gotA := queryStatus(chainA, sendContractAddr, path.EndpointA.ChannelID)
+	gotB := queryStatus(chainB, reflectContractAddr, path.EndpointB.ChannelID)
+	// then
+	assert.Equal(t, &types.QueryContractChannelStatusResponse{
+		State:              channeltypes.OPEN.String(),
+		Ordering:           channeltypes.ORDERED.String(),
+		NextSequenceSend:   2,
+		NextSequenceRecv:   1,
+		NextSequenceAck:    1,
+		PendingCommitments: 1,
+	}, gotA)
+	assert.Equal(t, &types.QueryContractChannelStatusResponse{
+		State:            channeltypes.OPEN.String(),
+		Ordering:         channeltypes.ORDERED.String(),
+		NextSequenceSend: 1,
+		NextSequenceRecv: 1,
+		NextSequenceAck:  1,
+	}, gotB)
+
+	// when relayed
+	require.NoError(t, wasmibctesting.RelayAndAckPendingPackets(path))
+	gotA = queryStatus(chainA, sendContractAddr, path.EndpointA.ChannelID)
+	gotB = queryStatus(chainB, reflectContractAddr, path.EndpointB.ChannelID)
+	// then
+	assert.Equal(t, uint64(2), gotA.NextSequenceAck)
+	assert.Zero(t, gotA.PendingCommitments)
+	assert.Equal(t, uint64(2), gotB.NextSequenceRecv)
+
+	// and an unknown channel is not found
+	_, err := wasmkeeper.Querier(&chainA.GetWasmApp().WasmKeeper).ContractChannelStatus(chainA.GetContext(), &types.QueryContractChannelStatusRequest{
+		Address:   sendContractAddr.String(),
+		ChannelID: "channel-99",
+	})
+	require.ErrorIs(t, err, types.ErrNotFound)
+}
+
 type ReflectSendQueryMsg struct {
 	Admin        *struct{}     `json:"admin,omitempty"`
 	ListAccounts *struct{}     `json:"list_accounts,omitempty"`
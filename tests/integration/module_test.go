@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/crypto/ed25519"
@@ -239,11 +240,12 @@ func TestHandleInstantiate(t *testing.T) {
 	contractBech32Addr := parseInitResponse(t, res.Data)
 
 	require.Equal(t, "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr", contractBech32Addr)
-	// this should be standard x/wasm init event, nothing from contract
-	require.Equal(t, 2, len(res.Events), prettyEvents(res.Events))
+	// this should be standard x/wasm init event and the trace event, nothing from contract
+	require.Equal(t, 3, len(res.Events), prettyEvents(res.Events))
 	require.Equal(t, "instantiate", res.Events[0].Type)
 	require.Equal(t, "wasm", res.Events[1].Type)
 	assertAttribute(t, "_contract_address", contractBech32Addr, res.Events[1].Attributes[0])
+	require.Equal(t, "exec_trace", res.Events[2].Type)
 
 	assertCodeList(t, q, data.ctx, 1, data.encConf.Codec)
 	assertCodeBytes(t, q, data.ctx, 1, testContract, data.encConf.Codec)
@@ -297,8 +299,8 @@ func TestHandleExecute(t *testing.T) {
 	contractBech32Addr := parseInitResponse(t, res.Data)
 
 	require.Equal(t, "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr", contractBech32Addr)
-	// this should be standard x/wasm message event,  init event, plus a bank send event (2), with no custom contract events
-	require.Equal(t, 5, len(res.Events), prettyEvents(res.Events))
+	// this should be standard x/wasm message event,  init event, plus a bank send event (2) and the trace event, with no custom contract events
+	require.Equal(t, 6, len(res.Events), prettyEvents(res.Events))
 	require.Equal(t, "coin_spent", res.Events[0].Type)
 	require.Equal(t, "coin_received", res.Events[1].Type)
 	require.Equal(t, "transfer", res.Events[2].Type)
@@ -334,8 +336,8 @@ func TestHandleExecute(t *testing.T) {
 	// from https://github.com/CosmWasm/cosmwasm/blob/master/contracts/hackatom/src/contract.rs#L167
 	assertExecuteResponse(t, res.Data, []byte{0xf0, 0x0b, 0xaa})
 
-	// this should be standard message event, plus x/wasm init event, plus 2 bank send event, plus a special event from the contract
-	require.Equal(t, 9, len(res.Events), prettyEvents(res.Events))
+	// this should be standard message event, plus x/wasm init event, plus 2 bank send event, plus a special event from the contract, plus the trace event
+	require.Equal(t, 10, len(res.Events), prettyEvents(res.Events))
 
 	assert.Equal(t, "coin_spent", res.Events[0].Type)
 	assert.Equal(t, "coin_received", res.Events[1].Type)
@@ -365,6 +367,7 @@ func TestHandleExecute(t *testing.T) {
 	assertAttribute(t, "sender", contractBech32Addr, res.Events[8].Attributes[1])
 	assertAttribute(t, "amount", "105000denom", res.Events[8].Attributes[2])
 	// finally, standard x/wasm tag
+	assert.Equal(t, "exec_trace", res.Events[9].Type)
 
 	// ensure bob now exists and got both payments released
 	bobAcct = data.acctKeeper.GetAccount(data.ctx, bob)
@@ -480,8 +483,9 @@ func TestReadNodeConfig(t *testing.T) {
 				"wasm.query_gas_limit": 1,
 			},
 			exp: types.NodeConfig{
-				SmartQueryGasLimit: 1,
-				MemoryCacheSize:    defaults.MemoryCacheSize,
+				PrecompileTimeBudget: defaults.PrecompileTimeBudget,
+				SmartQueryGasLimit:   1,
+				MemoryCacheSize:      defaults.MemoryCacheSize,
 			},
 		},
 		"set cache via opts": {
@@ -489,8 +493,9 @@ func TestReadNodeConfig(t *testing.T) {
 				"wasm.memory_cache_size": 2,
 			},
 			exp: types.NodeConfig{
-				MemoryCacheSize:    2,
-				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				PrecompileTimeBudget: defaults.PrecompileTimeBudget,
+				MemoryCacheSize:      2,
+				SmartQueryGasLimit:   defaults.SmartQueryGasLimit,
 			},
 		},
 		"set debug via opts": {
@@ -498,9 +503,101 @@ func TestReadNodeConfig(t *testing.T) {
 				"trace": true,
 			},
 			exp: types.NodeConfig{
-				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
-				MemoryCacheSize:    defaults.MemoryCacheSize,
-				ContractDebugMode:  true,
+				PrecompileTimeBudget: defaults.PrecompileTimeBudget,
+				SmartQueryGasLimit:   defaults.SmartQueryGasLimit,
+				MemoryCacheSize:      defaults.MemoryCacheSize,
+				ContractDebugMode:    true,
+			},
+		},
+		"set strict vm cache check via opts": {
+			src: AppOptionsMock{
+				"wasm.strict_vm_cache_check": true,
+			},
+			exp: types.NodeConfig{
+				PrecompileTimeBudget: defaults.PrecompileTimeBudget,
+				SmartQueryGasLimit:   defaults.SmartQueryGasLimit,
+				MemoryCacheSize:      defaults.MemoryCacheSize,
+				StrictVMCacheCheck:   true,
+			},
+		},
+		"set query depth gas percent via opts": {
+			src: AppOptionsMock{
+				"wasm.query_depth_gas_percent": 50,
+			},
+			exp: types.NodeConfig{
+				PrecompileTimeBudget:      defaults.PrecompileTimeBudget,
+				SmartQueryGasLimit:        defaults.SmartQueryGasLimit,
+				MemoryCacheSize:           defaults.MemoryCacheSize,
+				SmartQueryDepthGasPercent: 50,
+			},
+		},
+		"enable state watch via opts": {
+			src: AppOptionsMock{
+				"wasm.enable_state_watch": true,
+			},
+			exp: types.NodeConfig{
+				PrecompileTimeBudget: defaults.PrecompileTimeBudget,
+				SmartQueryGasLimit:   defaults.SmartQueryGasLimit,
+				MemoryCacheSize:      defaults.MemoryCacheSize,
+				EnableStateWatch:     true,
+			},
+		},
+		"set signed query block window via opts": {
+			src: AppOptionsMock{
+				"wasm.signed_query_block_window": 20,
+			},
+			exp: types.NodeConfig{
+				PrecompileTimeBudget:   defaults.PrecompileTimeBudget,
+				SmartQueryGasLimit:     defaults.SmartQueryGasLimit,
+				MemoryCacheSize:        defaults.MemoryCacheSize,
+				SignedQueryBlockWindow: 20,
+			},
+		},
+		"set store write log dir via opts": {
+			src: AppOptionsMock{
+				"wasm.store_write_log_dir": "wasm/writes",
+			},
+			exp: types.NodeConfig{
+				PrecompileTimeBudget: defaults.PrecompileTimeBudget,
+				SmartQueryGasLimit:   defaults.SmartQueryGasLimit,
+				MemoryCacheSize:      defaults.MemoryCacheSize,
+				StoreWriteLogDir:     "wasm/writes",
+			},
+		},
+		"set execution log level via opts": {
+			src: AppOptionsMock{
+				"wasm.execution_log_level": "info",
+			},
+			exp: types.NodeConfig{
+				PrecompileTimeBudget: defaults.PrecompileTimeBudget,
+				SmartQueryGasLimit:   defaults.SmartQueryGasLimit,
+				MemoryCacheSize:      defaults.MemoryCacheSize,
+				ExecutionLogLevel:    types.ExecutionLogLevelInfo,
+			},
+		},
+		"set precompile on start via opts": {
+			src: AppOptionsMock{
+				"wasm.precompile_on_start":    true,
+				"wasm.precompile_workers":     4,
+				"wasm.precompile_time_budget": "30s",
+			},
+			exp: types.NodeConfig{
+				SmartQueryGasLimit:   defaults.SmartQueryGasLimit,
+				MemoryCacheSize:      defaults.MemoryCacheSize,
+				PrecompileOnStart:    true,
+				PrecompileWorkers:    4,
+				PrecompileTimeBudget: 30 * time.Second,
+			},
+		},
+		"set alias query cache size via opts": {
+			src: AppOptionsMock{
+				"wasm.alias_query_cache_size": 1000,
+			},
+			exp: types.NodeConfig{
+				SmartQueryGasLimit:   defaults.SmartQueryGasLimit,
+				MemoryCacheSize:      defaults.MemoryCacheSize,
+				PrecompileTimeBudget: defaults.PrecompileTimeBudget,
+				AliasQueryCacheSize:  1000,
 			},
 		},
 		"all defaults when no options set": {
@@ -513,15 +610,18 @@ func TestReadNodeConfig(t *testing.T) {
 		},
 		"custom config template values": {
 			src: withViper(types.ConfigTemplate(types.NodeConfig{
-				SimulationGasLimit: &one,
-				SmartQueryGasLimit: 2,
-				MemoryCacheSize:    3,
+				SimulationGasLimit:        &one,
+				SmartQueryGasLimit:        2,
+				MemoryCacheSize:           3,
+				SmartQueryDepthGasPercent: 4,
 			})),
 			exp: types.NodeConfig{
-				SimulationGasLimit: &one,
-				SmartQueryGasLimit: 2,
-				MemoryCacheSize:    3,
-				ContractDebugMode:  false,
+				PrecompileTimeBudget:      defaults.PrecompileTimeBudget,
+				SimulationGasLimit:        &one,
+				SmartQueryGasLimit:        2,
+				MemoryCacheSize:           3,
+				ContractDebugMode:         false,
+				SmartQueryDepthGasPercent: 4,
 			},
 		},
 	}
@@ -532,6 +632,15 @@ func TestReadNodeConfig(t *testing.T) {
 			assert.Equal(t, spec.exp, got)
 		})
 	}
+	// and reject a query depth gas percent out of range
+	_, err := wasm.ReadNodeConfig(AppOptionsMock{"wasm.query_depth_gas_percent": 101})
+	require.Error(t, err)
+	// and an unknown execution log level
+	_, err = wasm.ReadNodeConfig(AppOptionsMock{"wasm.execution_log_level": "trace"})
+	require.Error(t, err)
+	// and a negative precompile time budget
+	_, err = wasm.ReadNodeConfig(AppOptionsMock{"wasm.precompile_time_budget": "-1s"})
+	require.Error(t, err)
 }
 
 type AppOptionsMock map[string]interface{}
@@ -55,6 +55,80 @@ func TestStoreCode(t *testing.T) {
 	assert.Equal(t, types.DefaultParams().InstantiateDefaultPermission.With(sender), info.InstantiateConfig)
 }
 
+func TestStoreCodeWithProvenance(t *testing.T) {
+	const mySource, myBuilder = "https://example.com/", "cosmwasm/optimizer:0.16.0"
+	checksum, err := wasmvm.CreateChecksum(wasmContract)
+	require.NoError(t, err)
+	privKey, pubKey, sender := testdata.KeyTestPubAddr()
+	validSig, err := privKey.Sign(types.CodeProvenanceSignBytes(checksum, mySource, myBuilder))
+	require.NoError(t, err)
+	otherSig, err := privKey.Sign(types.CodeProvenanceSignBytes(checksum, mySource, "cosmwasm/optimizer:0.15.0"))
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		signature     []byte
+		withoutPubKey bool
+		expErr        bool
+		expProvenance *types.CodeProvenance
+	}{
+		"valid signature": {
+			signature:     validSig,
+			expProvenance: &types.CodeProvenance{Source: mySource, Builder: myBuilder, Signature: validSig},
+		},
+		"signature over other builder": {
+			signature: otherSig,
+			expErr:    true,
+		},
+		"invalid signature": {
+			signature: []byte("invalid"),
+			expErr:    true,
+		},
+		"creator without public key": {
+			signature:     validSig,
+			withoutPubKey: true,
+			expErr:        true,
+		},
+		"without provenance": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			wasmApp := app.Setup(t)
+			ctx := wasmApp.BaseApp.NewContext(false)
+			if !spec.withoutPubKey {
+				acc := wasmApp.AccountKeeper.NewAccountWithAddress(ctx, sender)
+				require.NoError(t, acc.SetPubKey(pubKey))
+				wasmApp.AccountKeeper.SetAccount(ctx, acc)
+			}
+			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+				m.WASMByteCode = wasmContract
+				m.Sender = sender.String()
+				if spec.signature != nil {
+					m.Source, m.Builder, m.ProvenanceSignature = mySource, myBuilder, spec.signature
+				}
+			})
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, err, types.ErrInvalidProvenance)
+				return
+			}
+			require.NoError(t, err)
+			var result types.MsgStoreCodeResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			info := wasmApp.WasmKeeper.GetCodeInfo(ctx, result.CodeID)
+			require.NotNil(t, info)
+			assert.Equal(t, spec.expProvenance, info.Provenance)
+			// and exposed in queries
+			res, err := keeper.Querier(&wasmApp.WasmKeeper).Code(ctx, &types.QueryCodeRequest{CodeId: result.CodeID})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expProvenance, res.Provenance)
+		})
+	}
+}
+
 func TestUpdateParams(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContext(false)
@@ -610,6 +684,102 @@ func TestStoreAndInstantiateContract(t *testing.T) {
 	}
 }
 
+//go:embed testdata/ibc_reflect.wasm
+var ibcReflectContract []byte
+
+func TestStoreAndInstantiateContractWithIBCPort(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+	authority := wasmApp.WasmKeeper.GetAuthority()
+
+	storeMsg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = wasmContract
+		m.Sender = authority
+	})
+	rsp, err := wasmApp.MsgServiceRouter().Handler(storeMsg)(ctx, storeMsg)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+	reflectCodeID := storeCodeResponse.CodeID
+
+	specs := map[string]struct {
+		wasmCode  []byte
+		initMsg   []byte
+		unpin     bool
+		expPinned bool
+		expPort   bool
+	}{
+		"ibc contract pinned": {
+			wasmCode:  ibcReflectContract,
+			initMsg:   keeper.IBCReflectInitMsg{ReflectCodeID: reflectCodeID}.GetBytes(t),
+			expPinned: true,
+			expPort:   true,
+		},
+		"ibc contract unpinned": {
+			wasmCode: ibcReflectContract,
+			initMsg:  keeper.IBCReflectInitMsg{ReflectCodeID: reflectCodeID}.GetBytes(t),
+			unpin:    true,
+			expPort:  true,
+		},
+		"non ibc contract": {
+			wasmCode:  wasmContract,
+			initMsg:   []byte(`{}`),
+			expPinned: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			msg := &types.MsgStoreAndInstantiateContract{
+				Authority:    authority,
+				WASMByteCode: spec.wasmCode,
+				UnpinCode:    spec.unpin,
+				Label:        "test",
+				Msg:          spec.initMsg,
+				Funds:        sdk.Coins{},
+			}
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			require.NoError(t, err)
+			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+			contractAddr, err := sdk.AccAddressFromBech32(storeAndInstantiateResponse.Address)
+			require.NoError(t, err)
+			contractInfo := wasmApp.WasmKeeper.GetContractInfo(ctx, contractAddr)
+			require.NotNil(t, contractInfo)
+			assert.Equal(t, spec.expPinned, wasmApp.WasmKeeper.IsPinnedCode(ctx, contractInfo.CodeID))
+			assert.Equal(t, contractInfo.IBCPortID, storeAndInstantiateResponse.IBCPortID)
+
+			var portAttr string
+			var found bool
+			for _, e := range rsp.Events {
+				if e.Type != types.EventTypeInstantiate {
+					continue
+				}
+				for _, a := range e.Attributes {
+					if a.Key == types.AttributeKeyIBCPortID {
+						portAttr, found = a.Value, true
+					}
+				}
+			}
+			if !spec.expPort {
+				assert.Empty(t, storeAndInstantiateResponse.IBCPortID)
+				assert.False(t, found)
+				return
+			}
+			assert.Equal(t, keeper.PortIDForContract(contractAddr), storeAndInstantiateResponse.IBCPortID)
+			require.True(t, found)
+			assert.Equal(t, storeAndInstantiateResponse.IBCPortID, portAttr)
+			// and the port is routed to the wasm module
+			_, ok := wasmApp.IBCKeeper.PortKeeper.Route(storeAndInstantiateResponse.IBCPortID)
+			assert.True(t, ok)
+		})
+	}
+}
+
 func TestUpdateAdmin(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
@@ -675,6 +845,79 @@ func TestUpdateAdmin(t *testing.T) {
 	}
 }
 
+func TestUpdateContractAdmins(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress       sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                      = wasmApp.WasmKeeper.GetAuthority()
+		_, _, otherAddr                = testdata.KeyTestPubAddr()
+	)
+
+	specs := map[string]struct {
+		addr   string
+		expErr bool
+	}{
+		"authority can update admins": {
+			addr:   authority,
+			expErr: false,
+		},
+		"admin cannot update admins": {
+			addr:   myAddress.String(),
+			expErr: true,
+		},
+		"other address cannot update admins": {
+			addr:   otherAddr.String(),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, _, newAdmin := testdata.KeyTestPubAddr()
+
+			// setup
+			var updates []types.ContractAdminUpdate
+			for i := 0; i < 2; i++ {
+				msg := &types.MsgStoreAndInstantiateContract{
+					Authority:             authority,
+					WASMByteCode:          wasmContract,
+					InstantiatePermission: &types.AllowEverybody,
+					Admin:                 myAddress.String(),
+					Label:                 "test",
+					Msg:                   []byte(`{}`),
+					Funds:                 sdk.Coins{},
+				}
+				rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+				require.NoError(t, err)
+				var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+				require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+				updates = append(updates, types.ContractAdminUpdate{Contract: storeAndInstantiateResponse.Address, NewAdmin: newAdmin.String()})
+			}
+
+			// when
+			msgUpdateAdmins := &types.MsgUpdateContractAdmins{
+				Authority: spec.addr,
+				Updates:   updates,
+			}
+			_, err := wasmApp.MsgServiceRouter().Handler(msgUpdateAdmins)(ctx, msgUpdateAdmins)
+
+			// then
+			expAdmin := newAdmin.String()
+			if spec.expErr {
+				require.Error(t, err)
+				expAdmin = myAddress.String()
+			} else {
+				require.NoError(t, err)
+			}
+			for _, u := range updates {
+				info := wasmApp.WasmKeeper.GetContractInfo(ctx, sdk.MustAccAddressFromBech32(u.Contract))
+				assert.Equal(t, expAdmin, info.Admin)
+			}
+		})
+	}
+}
+
 func TestClearAdmin(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
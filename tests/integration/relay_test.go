@@ -389,6 +389,242 @@ func TestContractCanEmulateIBCTransferMessageWithTimeout(t *testing.T) {
 	assert.Equal(t, initialSenderBalance.String(), newSenderBalance.String())
 }
 
+func TestContractIBCSendWithDefaultTimeout(t *testing.T) {
+	// scenario: given two chains,
+	//           with a contract on chain A that has a default ibc send timeout set by the admin
+	//           then the contract can send an ibc packet without timeout
+	//           which is not received on chain B and times out with the default
+
+	myContract := &sendEmulatedIBCTransferContract{t: t}
+
+	var (
+		chainAOpts = []wasmkeeper.Option{
+			wasmkeeper.WithWasmEngine(
+				wasmtesting.NewIBCContractMockWasmEngine(myContract)),
+		}
+		coordinator = wasmibctesting.NewCoordinator(t, 2, chainAOpts)
+
+		chainA = wasmibctesting.NewWasmTestChain(coordinator.GetChain(ibctesting.GetChainID(1)))
+		chainB = wasmibctesting.NewWasmTestChain(coordinator.GetChain(ibctesting.GetChainID(2)))
+	)
+	coordinator.CommitBlock(chainA.TestChain, chainB.TestChain)
+	myContractAddr := chainA.SeedNewContractInstance()
+	myContract.contractAddr = myContractAddr.String()
+
+	path := wasmibctesting.NewWasmPath(chainA, chainB)
+	path.EndpointA.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  chainA.ContractInfo(myContractAddr).IBCPortID,
+		Version: ibctransfertypes.V1,
+		Order:   channeltypes.UNORDERED,
+	}
+	path.EndpointB.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  ibctransfertypes.PortID,
+		Version: ibctransfertypes.V1,
+		Order:   channeltypes.UNORDERED,
+	}
+	coordinator.SetupConnections(&path.Path)
+	coordinator.CreateChannels(&path.Path)
+	coordinator.UpdateTime()
+
+	receiverAddress := chainB.SenderAccount.GetAddress()
+	coinToSendToB := sdk.NewCoin(sdk.DefaultBondDenom, sdkmath.NewInt(100))
+	initialContractBalance := chainA.Balance(myContractAddr, sdk.DefaultBondDenom)
+	initialSenderBalance := chainA.Balance(chainA.SenderAccount.GetAddress(), sdk.DefaultBondDenom)
+
+	// packet without timeout
+	startMsg := &types.MsgExecuteContract{
+		Sender:   chainA.SenderAccount.GetAddress().String(),
+		Contract: myContractAddr.String(),
+		Msg: startTransfer{
+			ChannelID:       path.EndpointA.ChannelID,
+			CoinsToSend:     coinToSendToB,
+			ReceiverAddr:    receiverAddress.String(),
+			ContractIBCPort: chainA.ContractInfo(myContractAddr).IBCPortID,
+		}.GetBytes(),
+		Funds: sdk.NewCoins(coinToSendToB),
+	}
+	// when sent without default timeout
+	_, err := chainA.SendMsgs(startMsg)
+	// then
+	require.Error(t, err)
+	require.Equal(t, 0, len(*chainA.PendingSendPackets))
+
+	// when the admin sets a default timeout
+	_, err = chainA.SendMsgs(&types.MsgSetIBCSendTimeout{
+		Sender:           chainA.SenderAccount.GetAddress().String(),
+		Contract:         myContractAddr.String(),
+		TimeoutTimestamp: uint64(time.Nanosecond), // will timeout
+	})
+	require.NoError(t, err)
+	// and the packet is sent without timeout
+	_, err = chainA.SendMsgs(startMsg)
+	require.NoError(t, err)
+	coordinator.CommitBlock(chainA.TestChain, chainB.TestChain)
+
+	// then the default timeout was applied
+	require.Equal(t, 1, len(*chainA.PendingSendPackets))
+	assert.NotZero(t, (*chainA.PendingSendPackets)[0].TimeoutTimestamp)
+	newContractBalance := chainA.Balance(myContractAddr, sdk.DefaultBondDenom)
+	assert.Equal(t, initialContractBalance.Add(coinToSendToB), newContractBalance) // hold in escrow
+
+	// when timeout packet send (by the relayer)
+	err = wasmibctesting.TimeoutPendingPackets(coordinator, path)
+	require.NoError(t, err)
+	coordinator.CommitBlock(chainA.TestChain)
+
+	// then
+	require.Equal(t, 0, len(*chainA.PendingSendPackets))
+	require.Equal(t, 0, len(*chainB.PendingSendPackets))
+
+	// and then verify account balances restored
+	newContractBalance = chainA.Balance(myContractAddr, sdk.DefaultBondDenom)
+	assert.Equal(t, initialContractBalance.String(), newContractBalance.String())
+	newSenderBalance := chainA.Balance(chainA.SenderAccount.GetAddress(), sdk.DefaultBondDenom)
+	assert.Equal(t, initialSenderBalance.String(), newSenderBalance.String())
+}
+
+func TestContractUsageTracksIBCEntryPoints(t *testing.T) {
+	// scenario: given two chains,
+	//           with a contract on chain A that opens a channel to chain B
+	//           and sends an ibc packet that times out
+	//           then the ibc entry point invocations are counted
+
+	myContract := &sendEmulatedIBCTransferContract{t: t}
+
+	var (
+		chainAOpts = []wasmkeeper.Option{
+			wasmkeeper.WithWasmEngine(
+				wasmtesting.NewIBCContractMockWasmEngine(myContract)),
+		}
+		coordinator = wasmibctesting.NewCoordinator(t, 2, chainAOpts)
+
+		chainA = wasmibctesting.NewWasmTestChain(coordinator.GetChain(ibctesting.GetChainID(1)))
+		chainB = wasmibctesting.NewWasmTestChain(coordinator.GetChain(ibctesting.GetChainID(2)))
+	)
+	coordinator.CommitBlock(chainA.TestChain, chainB.TestChain)
+	myContractAddr := chainA.SeedNewContractInstance()
+	myContract.contractAddr = myContractAddr.String()
+
+	path := wasmibctesting.NewWasmPath(chainA, chainB)
+	path.EndpointA.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  chainA.ContractInfo(myContractAddr).IBCPortID,
+		Version: ibctransfertypes.V1,
+		Order:   channeltypes.UNORDERED,
+	}
+	path.EndpointB.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  ibctransfertypes.PortID,
+		Version: ibctransfertypes.V1,
+		Order:   channeltypes.UNORDERED,
+	}
+	coordinator.SetupConnections(&path.Path)
+	coordinator.CreateChannels(&path.Path)
+	coordinator.UpdateTime()
+
+	// when contract is triggered to send an ibc packet that times out
+	timeout := uint64(chainB.LatestCommittedHeader.Header.Time.Add(time.Nanosecond).UnixNano())
+	coinToSendToB := sdk.NewCoin(sdk.DefaultBondDenom, sdkmath.NewInt(100))
+	_, err := chainA.SendMsgs(&types.MsgExecuteContract{
+		Sender:   chainA.SenderAccount.GetAddress().String(),
+		Contract: myContractAddr.String(),
+		Msg: startTransfer{
+			ChannelID:       path.EndpointA.ChannelID,
+			CoinsToSend:     coinToSendToB,
+			ReceiverAddr:    chainB.SenderAccount.GetAddress().String(),
+			ContractIBCPort: chainA.ContractInfo(myContractAddr).IBCPortID,
+			Timeout:         timeout,
+		}.GetBytes(),
+		Funds: sdk.NewCoins(coinToSendToB),
+	})
+	require.NoError(t, err)
+	coordinator.CommitBlock(chainA.TestChain, chainB.TestChain)
+	require.NoError(t, wasmibctesting.TimeoutPendingPackets(coordinator, path))
+	coordinator.CommitBlock(chainA.TestChain)
+
+	// then
+	res, err := wasmkeeper.Querier(&chainA.GetWasmApp().WasmKeeper).ContractUsage(chainA.GetContext(), &types.QueryContractUsageRequest{Address: myContractAddr.String()})
+	require.NoError(t, err)
+	exp := []types.EntryPointUsage{
+		{EntryPoint: "execute", Count: 1},
+		{EntryPoint: "ibc_channel_connect", Count: 1},
+		{EntryPoint: "ibc_channel_open", Count: 1},
+		{EntryPoint: "ibc_packet_timeout", Count: 1},
+		{EntryPoint: "instantiate", Count: 1},
+	}
+	assert.Equal(t, exp, res.EntryPoints)
+}
+
+func TestContractChannelStatusUnordered(t *testing.T) {
+	// scenario: given two chains,
+	//           with a contract on chain A that opens an unordered channel to chain B
+	//           and sends two ibc packets that are not relayed
+	//           then the status shows the pending commitments without receive and ack sequences
+
+	myContract := &sendEmulatedIBCTransferContract{t: t}
+
+	var (
+		chainAOpts = []wasmkeeper.Option{
+			wasmkeeper.WithWasmEngine(
+				wasmtesting.NewIBCContractMockWasmEngine(myContract)),
+		}
+		coordinator = wasmibctesting.NewCoordinator(t, 2, chainAOpts)
+
+		chainA = wasmibctesting.NewWasmTestChain(coordinator.GetChain(ibctesting.GetChainID(1)))
+		chainB = wasmibctesting.NewWasmTestChain(coordinator.GetChain(ibctesting.GetChainID(2)))
+	)
+	coordinator.CommitBlock(chainA.TestChain, chainB.TestChain)
+	myContractAddr := chainA.SeedNewContractInstance()
+	myContract.contractAddr = myContractAddr.String()
+
+	path := wasmibctesting.NewWasmPath(chainA, chainB)
+	path.EndpointA.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  chainA.ContractInfo(myContractAddr).IBCPortID,
+		Version: ibctransfertypes.V1,
+		Order:   channeltypes.UNORDERED,
+	}
+	path.EndpointB.ChannelConfig = &ibctesting.ChannelConfig{
+		PortID:  ibctransfertypes.PortID,
+		Version: ibctransfertypes.V1,
+		Order:   channeltypes.UNORDERED,
+	}
+	coordinator.SetupConnections(&path.Path)
+	coordinator.CreateChannels(&path.Path)
+	coordinator.UpdateTime()
+
+	// when contract sends two packets that are not relayed
+	timeout := uint64(chainB.LatestCommittedHeader.Header.Time.Add(time.Hour).UnixNano())
+	coinToSendToB := sdk.NewCoin(sdk.DefaultBondDenom, sdkmath.NewInt(100))
+	for range 2 {
+		_, err := chainA.SendMsgs(&types.MsgExecuteContract{
+			Sender:   chainA.SenderAccount.GetAddress().String(),
+			Contract: myContractAddr.String(),
+			Msg: startTransfer{
+				ChannelID:       path.EndpointA.ChannelID,
+				CoinsToSend:     coinToSendToB,
+				ReceiverAddr:    chainB.SenderAccount.GetAddress().String(),
+				ContractIBCPort: chainA.ContractInfo(myContractAddr).IBCPortID,
+				Timeout:         timeout,
+			}.GetBytes(),
+			Funds: sdk.NewCoins(coinToSendToB),
+		})
+		require.NoError(t, err)
+	}
+	coordinator.CommitBlock(chainA.TestChain, chainB.TestChain)
+
+	// then
+	res, err := wasmkeeper.Querier(&chainA.GetWasmApp().WasmKeeper).ContractChannelStatus(chainA.GetContext(), &types.QueryContractChannelStatusRequest{
+		Address:   myContractAddr.String(),
+		ChannelID: path.EndpointA.ChannelID,
+	})
+	require.NoError(t, err)
+	exp := &types.QueryContractChannelStatusResponse{
+		State:              channeltypes.OPEN.String(),
+		Ordering:           channeltypes.UNORDERED.String(),
+		NextSequenceSend:   3,
+		PendingCommitments: 2,
+	}
+	assert.Equal(t, exp, res)
+}
+
 func TestContractEmulateIBCTransferMessageOnDiffContractIBCChannel(t *testing.T) {
 	// scenario: given two chains, A and B
 	//           with 2 contract A1 and A2 on chain A
@@ -0,0 +1,116 @@
+package wasm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"google.golang.org/grpc"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// aliasedSmartQueryPattern is the REST path of the Query/AliasedSmartContractState endpoint:
+// /cosmwasm/wasm/v1/contract/{address}/smart-alias/{name}
+var aliasedSmartQueryPattern = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3, 1, 0, 4, 1, 5, 4, 2, 5, 1, 0, 4, 1, 5, 6}, []string{"cosmwasm", "wasm", "v1", "contract", "address", "smart-alias", "name"}, "", runtime.AssumeColonVerbOpt(false)))
+
+// AliasQueryCache is a node local LRU cache of aliased smart query responses of the REST API. A response is
+// cached for the cache ttl that the contract admin set for the query alias. Responses without a cache ttl
+// and queries via gRPC are not cached.
+type AliasQueryCache struct {
+	entries *lru.Cache[aliasQueryCacheKey, aliasQueryCacheEntry]
+	now     func() time.Time
+}
+
+// aliasQueryCacheKey identifies a cached response. Queries without a height share the bucket of the latest height.
+type aliasQueryCacheKey struct {
+	contract string
+	alias    string
+	height   string
+}
+
+type aliasQueryCacheEntry struct {
+	rsp     *types.QueryAliasedSmartContractStateResponse
+	md      runtime.ServerMetadata
+	expires time.Time
+}
+
+// NewAliasQueryCache constructor. The size is the max number of cached responses.
+func NewAliasQueryCache(size int) (*AliasQueryCache, error) {
+	entries, err := lru.New[aliasQueryCacheKey, aliasQueryCacheEntry](size)
+	if err != nil {
+		return nil, err
+	}
+	return &AliasQueryCache{entries: entries, now: time.Now}, nil
+}
+
+// get returns the cached response when not expired
+func (c *AliasQueryCache) get(key aliasQueryCacheKey) (aliasQueryCacheEntry, bool) {
+	e, ok := c.entries.Get(key)
+	if !ok {
+		return e, false
+	}
+	if !c.now().Before(e.expires) {
+		c.entries.Remove(key)
+		return e, false
+	}
+	return e, true
+}
+
+// add caches the response for its cache ttl
+func (c *AliasQueryCache) add(key aliasQueryCacheKey, rsp *types.QueryAliasedSmartContractStateResponse, md runtime.ServerMetadata) aliasQueryCacheEntry {
+	e := aliasQueryCacheEntry{
+		rsp:     rsp,
+		md:      md,
+		expires: c.now().Add(time.Duration(rsp.CacheTTLSeconds) * time.Second),
+	}
+	c.entries.Add(key, e)
+	return e
+}
+
+// RegisterAliasQueryCacheRoute registers the aliased smart query endpoint of the REST API with the cache. The
+// responses are served from the cache within the cache ttl and have a Cache-Control header for HTTP caches.
+// It must be registered before the module routes as the mux serves the first matching route.
+func RegisterAliasQueryCacheRoute(mux *runtime.ServeMux, queryClient types.QueryClient, cache *AliasQueryCache) {
+	mux.Handle(http.MethodGet, aliasedSmartQueryPattern, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+
+		key := aliasQueryCacheKey{
+			contract: pathParams["address"],
+			alias:    pathParams["name"],
+			height:   req.Header.Get(grpctypes.GRPCBlockHeightHeader),
+		}
+		entry, ok := cache.get(key)
+		if !ok {
+			rctx, err := runtime.AnnotateContext(ctx, mux, req)
+			if err != nil {
+				runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+				return
+			}
+			var md runtime.ServerMetadata
+			rsp, err := queryClient.AliasedSmartContractState(rctx,
+				&types.QueryAliasedSmartContractStateRequest{Address: key.contract, Name: key.alias},
+				grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD),
+			)
+			if err != nil {
+				runtime.HTTPError(runtime.NewServerMetadataContext(ctx, md), mux, outboundMarshaler, w, req, err)
+				return
+			}
+			if rsp.CacheTTLSeconds == 0 {
+				runtime.ForwardResponseMessage(runtime.NewServerMetadataContext(ctx, md), mux, outboundMarshaler, w, req, rsp, mux.GetForwardResponseOptions()...)
+				return
+			}
+			entry = cache.add(key, rsp, md)
+		}
+		maxAge := entry.expires.Sub(cache.now()) / time.Second
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+		runtime.ForwardResponseMessage(runtime.NewServerMetadataContext(ctx, entry.md), mux, outboundMarshaler, w, req, entry.rsp, mux.GetForwardResponseOptions()...)
+	})
+}
@@ -0,0 +1,94 @@
+package wasm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gateway "github.com/cosmos/gogogateway"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestAliasQueryCache(t *testing.T) {
+	const contractPath = "/cosmwasm/wasm/v1/contract/cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr/smart-alias/"
+	queryClient := &aliasQueryClientMock{
+		state: `{"owner":"alice"}`,
+		ttls:  map[string]uint32{"config": 60},
+	}
+	cache, err := NewAliasQueryCache(10)
+	require.NoError(t, err)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &gateway.JSONPb{OrigName: true, EmitDefaults: true}))
+	RegisterAliasQueryCacheRoute(mux, queryClient, cache)
+
+	doRequest := func(path string, height string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if height != "" {
+			req.Header.Set(grpctypes.GRPCBlockHeightHeader, height)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		return rec
+	}
+
+	// when
+	first := doRequest(contractPath+"config", "")
+	// then
+	assert.Equal(t, 1, queryClient.calls)
+	assert.Equal(t, "public, max-age=60", first.Header().Get("Cache-Control"))
+
+	// when state changes within the ttl
+	queryClient.state = `{"owner":"bob"}`
+	now = now.Add(30 * time.Second)
+	cached := doRequest(contractPath+"config", "")
+	// then the response is served from the cache
+	assert.Equal(t, 1, queryClient.calls)
+	assert.Equal(t, first.Body.String(), cached.Body.String())
+	assert.Equal(t, "public, max-age=30", cached.Header().Get("Cache-Control"))
+
+	// when queried at a height
+	doRequest(contractPath+"config", "7")
+	// then the height has its own bucket
+	assert.Equal(t, 2, queryClient.calls)
+
+	// when the ttl lapsed
+	now = now.Add(30 * time.Second)
+	refreshed := doRequest(contractPath+"config", "")
+	// then the new state is returned
+	assert.Equal(t, 3, queryClient.calls)
+	assert.NotEqual(t, first.Body.String(), refreshed.Body.String())
+	assert.Equal(t, "public, max-age=60", refreshed.Header().Get("Cache-Control"))
+
+	// when the alias has no cache ttl
+	doRequest(contractPath+"balance", "")
+	uncached := doRequest(contractPath+"balance", "")
+	// then every request is forwarded
+	assert.Equal(t, 5, queryClient.calls)
+	assert.Empty(t, uncached.Header().Get("Cache-Control"))
+}
+
+type aliasQueryClientMock struct {
+	types.QueryClient
+	state string
+	ttls  map[string]uint32
+	calls int
+}
+
+func (m *aliasQueryClientMock) AliasedSmartContractState(_ context.Context, req *types.QueryAliasedSmartContractStateRequest, _ ...grpc.CallOption) (*types.QueryAliasedSmartContractStateResponse, error) {
+	m.calls++
+	return &types.QueryAliasedSmartContractStateResponse{
+		Data:            types.RawContractMessage(m.state),
+		CacheTTLSeconds: m.ttls[req.Name],
+	}, nil
+}
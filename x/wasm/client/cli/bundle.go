@@ -0,0 +1,429 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+	v1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	bundleUnsignedTxFile = "unsigned_tx.json"
+	bundleContextFile    = "context.json"
+	bundleReviewFile     = "REVIEW.md"
+)
+
+// bundle operations
+const (
+	bundleOpMigrate      = "migrate"
+	bundleOpUpdateAdmin  = "update-admin"
+	bundleOpSudoProposal = "sudo-proposal"
+)
+
+// BundleCmd groups the commands that write an offline signing bundle for multisig signers
+func BundleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Write an offline signing bundle for a contract admin operation",
+		Long: `Write a directory with the unsigned tx, the chain context of the contract at the latest height and a
+plain text review of the operation. The signers review and sign the unsigned tx offline. Use verify-bundle
+to check that the context did not change before the signed tx is broadcasted.`,
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+		SilenceUsage:               true,
+	}
+	cmd.AddCommand(
+		BundleMigrateCmd(),
+		BundleUpdateAdminCmd(),
+		BundleSudoProposalCmd(),
+	)
+	return cmd
+}
+
+// BundleMigrateCmd writes the signing bundle for a contract migration
+func BundleMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate [contract_addr_bech32] [new_code_id_int64] [json_encoded_migration_args] [output_dir]",
+		Short: "Write the signing bundle for a contract migration",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getBundleClientContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg, err := parseMigrateContractArgs(args[:3], clientCtx.GetFromAddress().String())
+			if err != nil {
+				return err
+			}
+			spec := bundleContext{
+				Operation:    bundleOpMigrate,
+				Contract:     msg.Contract,
+				TargetCodeID: msg.CodeID,
+				Msg:          json.RawMessage(msg.Msg),
+			}
+			return writeBundle(cmd.Context(), clientCtx, cmd.Flags(), args[3], spec, &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// BundleUpdateAdminCmd writes the signing bundle for a contract admin update
+func BundleUpdateAdminCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-admin [contract_addr_bech32] [new_admin_addr_bech32] [output_dir]",
+		Short: "Write the signing bundle for a contract admin update",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getBundleClientContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg, err := parseUpdateContractAdminArgs(args[:2], clientCtx.GetFromAddress().String())
+			if err != nil {
+				return err
+			}
+			spec := bundleContext{
+				Operation: bundleOpUpdateAdmin,
+				Contract:  msg.Contract,
+				NewAdmin:  msg.NewAdmin,
+			}
+			return writeBundle(cmd.Context(), clientCtx, cmd.Flags(), args[2], spec, &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// BundleSudoProposalCmd writes the signing bundle for a gov proposal that calls the sudo entry point of a contract
+func BundleSudoProposalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sudo-proposal [contract_addr_bech32] [json_encoded_sudo_args] [output_dir] --title [text] --summary [text] --authority [address]",
+		Short: "Write the signing bundle for a sudo contract proposal",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmd.Flags().Set(flags.FlagGenerateOnly, "true"); err != nil {
+				return err
+			}
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+			msg := types.MsgSudoContract{
+				Authority: authority,
+				Contract:  args[0],
+				Msg:       []byte(args[1]),
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+			spec := bundleContext{
+				Operation: bundleOpSudoProposal,
+				Contract:  msg.Contract,
+				Msg:       json.RawMessage(msg.Msg),
+			}
+			return writeBundle(cmd.Context(), clientCtx, cmd.Flags(), args[2], spec, proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+// VerifyBundleCmd checks that the context of a signing bundle did not change and broadcasts the signed tx
+func VerifyBundleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-bundle [bundle_dir] [signed_tx_file]",
+		Short: "Verify that the context of a signing bundle did not change and broadcast the signed tx",
+		Long: `Verify that the contract admin, code and target code of a signing bundle did not change since the bundle
+was written. When a signed tx file is given, its messages must match the unsigned tx of the bundle and the tx is
+broadcasted after the verification.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			bundled, err := readBundleContext(args[0])
+			if err != nil {
+				return err
+			}
+			if bundled.ChainID != clientCtx.ChainID {
+				return fmt.Errorf("bundle is for chain %q, not %q", bundled.ChainID, clientCtx.ChainID)
+			}
+			queryClientCtx, queryCtx, err := pinQueryHeight(cmd.Context(), clientCtx)
+			if err != nil {
+				return err
+			}
+			current, err := queryBundleContext(queryCtx, types.NewQueryClient(queryClientCtx), bundled)
+			if err != nil {
+				return err
+			}
+			if drift := bundleContextDrift(bundled, current); len(drift) != 0 {
+				return fmt.Errorf("context changed since height %d: %s", bundled.Height, strings.Join(drift, "; "))
+			}
+			if len(args) == 1 {
+				return clientCtx.PrintString(fmt.Sprintf("context did not change since height %d\n", bundled.Height))
+			}
+
+			unsignedTx, err := authclient.ReadTxFromFile(clientCtx, filepath.Join(args[0], bundleUnsignedTxFile))
+			if err != nil {
+				return err
+			}
+			signedTx, err := authclient.ReadTxFromFile(clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+			if err := assertSameMsgs(clientCtx.Codec, unsignedTx.GetMsgs(), signedTx.GetMsgs()); err != nil {
+				return err
+			}
+			txBytes, err := clientCtx.TxConfig.TxEncoder()(signedTx)
+			if err != nil {
+				return err
+			}
+			res, err := clientCtx.BroadcastTx(txBytes)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// bundleContext is the chain context of a bundled operation that the signers review. The operation fields are
+// set by the command, the state fields are queried from the chain.
+type bundleContext struct {
+	Operation string `json:"operation"`
+	ChainID   string `json:"chain_id"`
+	Height    int64  `json:"height,string"`
+	Contract  string `json:"contract"`
+	// state of the contract
+	Label        string `json:"label"`
+	Admin        string `json:"admin"`
+	CodeID       uint64 `json:"code_id,string"`
+	CodeChecksum string `json:"code_checksum"`
+	// operation specific
+	TargetCodeID       uint64          `json:"target_code_id,string,omitempty"`
+	TargetCodeChecksum string          `json:"target_code_checksum,omitempty"`
+	NewAdmin           string          `json:"new_admin,omitempty"`
+	Msg                json.RawMessage `json:"msg,omitempty"`
+}
+
+// getBundleClientContext returns the tx client context in generate only mode, so that the from address
+// can be a multisig address that is not in the keyring
+func getBundleClientContext(cmd *cobra.Command) (client.Context, error) {
+	if err := cmd.Flags().Set(flags.FlagGenerateOnly, "true"); err != nil {
+		return client.Context{}, err
+	}
+	return client.GetClientTxContext(cmd)
+}
+
+// writeBundle queries the context at the latest height and writes the bundle files to the new directory
+func writeBundle(ctx context.Context, clientCtx client.Context, flagSet *flag.FlagSet, dir string, spec bundleContext, msgs ...sdk.Msg) error {
+	if _, err := os.Stat(filepath.Join(dir, bundleContextFile)); err == nil {
+		return fmt.Errorf("bundle exists already in %s", dir)
+	}
+	queryClientCtx, queryCtx, err := pinQueryHeight(ctx, clientCtx)
+	if err != nil {
+		return err
+	}
+	height := queryClientCtx.Height
+	bc, err := queryBundleContext(queryCtx, types.NewQueryClient(queryClientCtx), spec)
+	if err != nil {
+		return err
+	}
+	bc.ChainID = clientCtx.ChainID
+	bc.Height = height
+
+	txf, err := tx.NewFactoryCLI(clientCtx, flagSet)
+	if err != nil {
+		return err
+	}
+	if txf.SimulateAndExecute() {
+		_, adjusted, err := tx.CalculateGas(clientCtx, txf, msgs...)
+		if err != nil {
+			return err
+		}
+		txf = txf.WithGas(adjusted)
+	}
+	unsignedTx, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return err
+	}
+	txBz, err := clientCtx.TxConfig.TxJSONEncoder()(unsignedTx.GetTx())
+	if err != nil {
+		return err
+	}
+	if err := writeBundleFiles(dir, bc, txBz); err != nil {
+		return err
+	}
+	return clientCtx.PrintString(fmt.Sprintf("bundle written to %s at height %d\n", dir, height))
+}
+
+// queryBundleContext returns the operation of the spec with the current state of the contract and codes
+func queryBundleContext(ctx context.Context, queryClient types.QueryClient, spec bundleContext) (bundleContext, error) {
+	result := spec
+	contractRes, err := queryClient.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: spec.Contract})
+	if err != nil {
+		return result, fmt.Errorf("contract: %w", err)
+	}
+	result.Label = contractRes.Label
+	result.Admin = contractRes.Admin
+	result.CodeID = contractRes.CodeID
+	if result.CodeChecksum, err = queryCodeChecksum(ctx, queryClient, contractRes.CodeID); err != nil {
+		return result, err
+	}
+	if spec.TargetCodeID != 0 {
+		if result.TargetCodeChecksum, err = queryCodeChecksum(ctx, queryClient, spec.TargetCodeID); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func queryCodeChecksum(ctx context.Context, queryClient types.QueryClient, codeID uint64) (string, error) {
+	res, err := queryClient.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: codeID})
+	if err != nil {
+		return "", fmt.Errorf("code %d: %w", codeID, err)
+	}
+	return res.Checksum.String(), nil
+}
+
+// bundleContextDrift returns a description of each state field that differs between the bundled and the current context
+func bundleContextDrift(bundled, current bundleContext) []string {
+	var drift []string
+	check := func(name, bundledValue, currentValue string) {
+		if bundledValue != currentValue {
+			drift = append(drift, fmt.Sprintf("%s was %q and is %q", name, bundledValue, currentValue))
+		}
+	}
+	check("admin", bundled.Admin, current.Admin)
+	check("code id", strconv.FormatUint(bundled.CodeID, 10), strconv.FormatUint(current.CodeID, 10))
+	check("code checksum", bundled.CodeChecksum, current.CodeChecksum)
+	check("target code checksum", bundled.TargetCodeChecksum, current.TargetCodeChecksum)
+	return drift
+}
+
+// assertSameMsgs returns an error when the signed messages differ from the bundled ones
+func assertSameMsgs(cdc codec.JSONCodec, bundled, signed []sdk.Msg) error {
+	if len(bundled) != len(signed) {
+		return fmt.Errorf("signed tx has %d messages, bundle has %d", len(signed), len(bundled))
+	}
+	for i := range bundled {
+		bundledBz, err := cdc.MarshalInterfaceJSON(bundled[i])
+		if err != nil {
+			return err
+		}
+		signedBz, err := cdc.MarshalInterfaceJSON(signed[i])
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bundledBz, signedBz) {
+			return fmt.Errorf("signed tx message %d differs from the bundle", i)
+		}
+	}
+	return nil
+}
+
+// writeBundleFiles writes the unsigned tx, the context and the review into the directory
+func writeBundleFiles(dir string, bc bundleContext, unsignedTx []byte) error {
+	contextBz, err := json.MarshalIndent(bc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	files := []struct {
+		name string
+		bz   []byte
+	}{
+		{bundleUnsignedTxFile, unsignedTx},
+		{bundleContextFile, contextBz},
+		{bundleReviewFile, []byte(renderBundleReview(bc))},
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.name), f.bz, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readBundleContext(dir string) (bundleContext, error) {
+	var bc bundleContext
+	bz, err := os.ReadFile(filepath.Join(dir, bundleContextFile))
+	if err != nil {
+		return bc, err
+	}
+	return bc, json.Unmarshal(bz, &bc)
+}
+
+// renderBundleReview returns the plain text review of the bundled operation
+func renderBundleReview(bc bundleContext) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Review: %s\n\n", bc.Operation)
+	fmt.Fprintf(&b, "Chain ID: %s\n", bc.ChainID)
+	fmt.Fprintf(&b, "Height: %d\n\n", bc.Height)
+	fmt.Fprintf(&b, "## Contract\n\n")
+	fmt.Fprintf(&b, "Address: %s\n", bc.Contract)
+	fmt.Fprintf(&b, "Label: %s\n", bc.Label)
+	fmt.Fprintf(&b, "Admin: %s\n", bc.Admin)
+	fmt.Fprintf(&b, "Code ID: %d\n", bc.CodeID)
+	fmt.Fprintf(&b, "Code checksum: %s\n", bc.CodeChecksum)
+	fmt.Fprintf(&b, "\n## Operation\n\n")
+	switch bc.Operation {
+	case bundleOpMigrate:
+		fmt.Fprintf(&b, "Migrate to code ID: %d\n", bc.TargetCodeID)
+		fmt.Fprintf(&b, "Target code checksum: %s\n", bc.TargetCodeChecksum)
+	case bundleOpUpdateAdmin:
+		fmt.Fprintf(&b, "New admin: %s\n", bc.NewAdmin)
+	case bundleOpSudoProposal:
+		fmt.Fprintf(&b, "Gov proposal to call the sudo entry point of the contract\n")
+	}
+	if len(bc.Msg) != 0 {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, bc.Msg, "", "  "); err != nil {
+			pretty.Reset()
+			pretty.Write(bc.Msg)
+		}
+		fmt.Fprintf(&b, "\nContract message:\n\n```json\n%s\n```\n", pretty.String())
+	}
+	fmt.Fprintf(&b, "\nSign %s and run verify-bundle before the signed tx is broadcasted.\n", bundleUnsignedTxFile)
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+type mockWasmQueryClient struct {
+	types.QueryClient
+	contracts map[string]types.ContractInfo
+	checksums map[uint64][]byte
+}
+
+func (m mockWasmQueryClient) ContractInfo(_ context.Context, in *types.QueryContractInfoRequest, _ ...grpc.CallOption) (*types.QueryContractInfoResponse, error) {
+	info, ok := m.contracts[in.Address]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "contract")
+	}
+	return &types.QueryContractInfoResponse{Address: in.Address, ContractInfo: info}, nil
+}
+
+func (m mockWasmQueryClient) CodeInfo(_ context.Context, in *types.QueryCodeInfoRequest, _ ...grpc.CallOption) (*types.QueryCodeInfoResponse, error) {
+	checksum, ok := m.checksums[in.CodeId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "code")
+	}
+	return &types.QueryCodeInfoResponse{CodeID: in.CodeId, Checksum: checksum}, nil
+}
+
+func TestBundleContextDrift(t *testing.T) {
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	queryClient := mockWasmQueryClient{
+		contracts: map[string]types.ContractInfo{myContract: {CodeID: 1, Admin: "my-multisig", Label: "my label"}},
+		checksums: map[uint64][]byte{1: {0x1}, 2: {0x2}, 3: {0x3}},
+	}
+	spec := bundleContext{Operation: bundleOpMigrate, Contract: myContract, TargetCodeID: 2, Msg: []byte(`{"foo":"bar"}`)}
+	bundled, err := queryBundleContext(context.Background(), queryClient, spec)
+	require.NoError(t, err)
+	bundled.ChainID, bundled.Height = "testing", 10
+	assert.Equal(t, bundleContext{
+		Operation:          bundleOpMigrate,
+		ChainID:            "testing",
+		Height:             10,
+		Contract:           myContract,
+		Label:              "my label",
+		Admin:              "my-multisig",
+		CodeID:             1,
+		CodeChecksum:       "01",
+		TargetCodeID:       2,
+		TargetCodeChecksum: "02",
+		Msg:                []byte(`{"foo":"bar"}`),
+	}, bundled)
+
+	dir := filepath.Join(t.TempDir(), "bundle")
+	require.NoError(t, writeBundleFiles(dir, bundled, []byte(`{"body":{}}`)))
+	for _, f := range []string{bundleUnsignedTxFile, bundleContextFile, bundleReviewFile} {
+		assert.FileExists(t, filepath.Join(dir, f))
+	}
+	gotTx, err := os.ReadFile(filepath.Join(dir, bundleUnsignedTxFile))
+	require.NoError(t, err)
+	assert.Equal(t, `{"body":{}}`, string(gotTx))
+	stored, err := readBundleContext(dir)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(bundled.Msg), string(stored.Msg))
+	stored.Msg = bundled.Msg
+	require.Equal(t, bundled, stored)
+
+	specs := map[string]struct {
+		setup    func(c *mockWasmQueryClient)
+		expDrift []string
+	}{
+		"unchanged": {
+			setup: func(c *mockWasmQueryClient) {},
+		},
+		"label changed": {
+			setup: func(c *mockWasmQueryClient) {
+				c.contracts[myContract] = types.ContractInfo{CodeID: 1, Admin: "my-multisig", Label: "other"}
+			},
+		},
+		"contract migrated": {
+			setup: func(c *mockWasmQueryClient) {
+				c.contracts[myContract] = types.ContractInfo{CodeID: 3, Admin: "my-multisig", Label: "my label"}
+			},
+			expDrift: []string{`code id was "1" and is "3"`, `code checksum was "01" and is "03"`},
+		},
+		"admin changed": {
+			setup: func(c *mockWasmQueryClient) {
+				c.contracts[myContract] = types.ContractInfo{CodeID: 1, Admin: "other", Label: "my label"}
+			},
+			expDrift: []string{`admin was "my-multisig" and is "other"`},
+		},
+		"target code replaced": {
+			setup: func(c *mockWasmQueryClient) {
+				c.checksums[2] = []byte{0xff}
+			},
+			expDrift: []string{`target code checksum was "02" and is "FF"`},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			current := mockWasmQueryClient{
+				contracts: map[string]types.ContractInfo{myContract: queryClient.contracts[myContract]},
+				checksums: map[uint64][]byte{1: {0x1}, 2: {0x2}, 3: {0x3}},
+			}
+			spec.setup(&current)
+
+			// when
+			got, err := queryBundleContext(context.Background(), current, stored)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, spec.expDrift, bundleContextDrift(stored, got))
+		})
+	}
+}
+
+func TestRenderBundleReview(t *testing.T) {
+	bc := bundleContext{
+		Operation:    bundleOpUpdateAdmin,
+		ChainID:      "testing",
+		Height:       10,
+		Contract:     "my-contract",
+		Label:        "my label",
+		Admin:        "my-multisig",
+		CodeID:       1,
+		CodeChecksum: "01",
+		NewAdmin:     "new-admin",
+	}
+	exp := "# Review: update-admin\n\n" +
+		"Chain ID: testing\nHeight: 10\n\n" +
+		"## Contract\n\nAddress: my-contract\nLabel: my label\nAdmin: my-multisig\nCode ID: 1\nCode checksum: 01\n\n" +
+		"## Operation\n\nNew admin: new-admin\n\n" +
+		"Sign unsigned_tx.json and run verify-bundle before the signed tx is broadcasted.\n"
+	assert.Equal(t, exp, renderBundleReview(bc))
+
+	bc.Operation, bc.NewAdmin, bc.Msg = bundleOpSudoProposal, "", []byte(`{"foo":"bar"}`)
+	assert.Contains(t, renderBundleReview(bc), "```json\n{\n  \"foo\": \"bar\"\n}\n```")
+}
@@ -2,9 +2,12 @@ package cli
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 
@@ -14,9 +17,12 @@ import (
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
+	errorsmod "cosmossdk.io/errors"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
 	"github.com/cosmos/cosmos-sdk/version"
@@ -43,16 +49,23 @@ func SubmitProposalCmd() *cobra.Command {
 		ProposalInstantiateContract2Cmd(),
 		ProposalStoreAndInstantiateContractCmd(),
 		ProposalMigrateContractCmd(),
+		ProposalForceMigrateContractCmd(),
 		ProposalExecuteContractCmd(),
 		ProposalSudoContractCmd(),
 		ProposalUpdateContractAdminCmd(),
 		ProposalClearContractAdminCmd(),
+		ProposalUpdateContractAdminsCmd(),
 		ProposalPinCodesCmd(),
 		ProposalUnpinCodesCmd(),
+		ProposalSetLegacyReverseIteratorCmd(),
 		ProposalUpdateInstantiateConfigCmd(),
 		ProposalAddCodeUploadParamsAddresses(),
 		ProposalRemoveCodeUploadParamsAddresses(),
+		ProposalUpdateParamsCmd(),
+		ProposalAddCodeUploadApprovalCmd(),
+		ProposalRemoveCodeUploadApprovalCmd(),
 		ProposalStoreAndMigrateContractCmd(),
+		ProposalImportContractCmd(),
 	)
 	return cmd
 }
@@ -202,9 +215,9 @@ func ProposalInstantiateContract2Cmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			salt, err := decoder.DecodeString(args[2])
+			salt, err := decodeSalt(decoder, args[2])
 			if err != nil {
-				return fmt.Errorf("salt: %w", err)
+				return err
 			}
 			fixMsg, err := cmd.Flags().GetBool(flagFixMsg)
 			if err != nil {
@@ -422,6 +435,57 @@ func ProposalMigrateContractCmd() *cobra.Command {
 	return cmd
 }
 
+func ProposalForceMigrateContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "force-migrate-contract [contract_addr_bech32] [new_code_id_int64] [json_encoded_migration_args] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to migrate a wasm contract to a new code version without the admin",
+		Long: `Submit a proposal to migrate a wasm contract to a new code version without the admin, for example when
+the admin key was lost. Contracts with an admin set can be force migrated, too. The migration is recorded in
+the contract history with the force migrate operation.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			migrateMsg, err := parseMigrateContractArgs(args, authority)
+			if err != nil {
+				return err
+			}
+			msg := types.MsgForceMigrateContract{
+				Authority: authority,
+				Contract:  migrateMsg.Contract,
+				CodeID:    migrateMsg.CodeID,
+				Msg:       migrateMsg.Msg,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
 func ProposalExecuteContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "execute-contract [contract_addr_bech32] [json_encoded_execution_args] --title [text] --summary [text] --authority [address]",
@@ -481,9 +545,17 @@ func ProposalExecuteContractCmd() *cobra.Command {
 
 func ProposalSudoContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "sudo-contract [contract_addr_bech32] [json_encoded_migration_args] --title [text] --summary [text] --authority [address]",
+		Use:   "sudo-contract [contract_addr_bech32] [json_encoded_sudo_args] --title [text] --summary [text] --authority [address]",
 		Short: "Submit a sudo wasm contract proposal (to call privileged commands)",
-		Args:  cobra.ExactArgs(2),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a sudo wasm contract proposal (to call privileged commands). The sudo message can be read
+from a file or from stdin with --msg-file instead of the argument. With --generate-only the unsigned proposal tx
+is printed so that it can be routed through the flow of a DAO.
+
+Example:
+$ %s tx wasm submit-proposal sudo-contract [contract_addr_bech32] --msg-file sudo.json --title "Sudo" --summary "..." --deposit 10000000stake --from mykey
+`, version.AppName)),
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
 			if err != nil {
@@ -499,10 +571,15 @@ func ProposalSudoContractCmd() *cobra.Command {
 				return errors.New("authority address is required")
 			}
 
+			sudoMsg, err := readMsgArg(cmd, args, 1)
+			if err != nil {
+				return err
+			}
+
 			msg := types.MsgSudoContract{
 				Authority: authority,
 				Contract:  args[0],
-				Msg:       []byte(args[1]),
+				Msg:       []byte(sudoMsg),
 			}
 			if err = msg.ValidateBasic(); err != nil {
 				return err
@@ -517,7 +594,8 @@ func ProposalSudoContractCmd() *cobra.Command {
 		},
 		SilenceUsage: true,
 	}
-	// proposal flagsExecute
+	// proposal flags
+	addMsgFileFlag(cmd)
 	addCommonProposalFlags(cmd)
 	return cmd
 }
@@ -603,11 +681,99 @@ func ProposalClearContractAdminCmd() *cobra.Command {
 	return cmd
 }
 
+func ProposalUpdateContractAdminsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-contract-admins [file] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a new admin for multiple contracts proposal",
+		Long: `Submit a proposal to set new admins for multiple contracts at once. Either all or none of the updates are applied.
+The file contains the contract and new admin pairs either as JSON or as CSV, one pair per line.
+Example JSON: [{"contract":"wasm1...","new_admin":"wasm1..."}]
+Example CSV: wasm1...,wasm1...`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			src, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			updates, err := parseContractAdminUpdates(src)
+			if err != nil {
+				return err
+			}
+			msg := types.MsgUpdateContractAdmins{
+				Authority: authority,
+				Updates:   updates,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+// parseContractAdminUpdates parses the contract and new admin pairs from a JSON array or from CSV lines
+func parseContractAdminUpdates(src []byte) ([]types.ContractAdminUpdate, error) {
+	src = bytes.TrimSpace(src)
+	if bytes.HasPrefix(src, []byte("[")) {
+		var updates []types.ContractAdminUpdate
+		if err := json.Unmarshal(src, &updates); err != nil {
+			return nil, fmt.Errorf("json: %s", err)
+		}
+		return updates, nil
+	}
+	records, err := csv.NewReader(bytes.NewReader(src)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("csv: %s", err)
+	}
+	updates := make([]types.ContractAdminUpdate, len(records))
+	for i, r := range records {
+		if len(r) != 2 {
+			return nil, fmt.Errorf("line %d: expected contract and new admin, got %d fields", i+1, len(r))
+		}
+		updates[i] = types.ContractAdminUpdate{
+			Contract: strings.TrimSpace(r[0]),
+			NewAdmin: strings.TrimSpace(r[1]),
+		}
+	}
+	return updates, nil
+}
+
 func ProposalPinCodesCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pin-codes [code-ids] --title [text] --summary [text] --authority [address]",
 		Short: "Submit a pin code proposal for pinning a code to cache",
-		Args:  cobra.MinimumNArgs(1),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal with a pin codes message of the authority. The authority is the gov module account
+by default and can be set for chains with a different admin authority. The code ids must exist on chain.
+
+Example:
+$ %s tx wasm submit-proposal pin-codes 1 2 3 --title "Pin codes" --summary "..." --deposit 10000000stake --from mykey
+`, version.AppName)),
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
 			if err != nil {
@@ -627,6 +793,11 @@ func ProposalPinCodesCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if !clientCtx.Offline {
+				if err := ensureCodesExist(cmd.Context(), types.NewQueryClient(clientCtx), codeIds); err != nil {
+					return err
+				}
+			}
 
 			msg := types.MsgPinCodes{
 				Authority: authority,
@@ -666,7 +837,14 @@ func ProposalUnpinCodesCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "unpin-codes [code-ids] --title [text] --summary [text] --authority [address]",
 		Short: "Submit a unpin code proposal for unpinning a code to cache",
-		Args:  cobra.MinimumNArgs(1),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal with a unpin codes message of the authority. The authority is the gov module account
+by default and can be set for chains with a different admin authority. The code ids must exist on chain.
+
+Example:
+$ %s tx wasm submit-proposal unpin-codes 1 2 3 --title "Unpin codes" --summary "..." --deposit 10000000stake --from mykey
+`, version.AppName)),
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
 			if err != nil {
@@ -685,6 +863,11 @@ func ProposalUnpinCodesCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if !clientCtx.Offline {
+				if err := ensureCodesExist(cmd.Context(), types.NewQueryClient(clientCtx), codeIds); err != nil {
+					return err
+				}
+			}
 
 			msg := types.MsgUnpinCodes{
 				Authority: authority,
@@ -761,6 +944,60 @@ func parseAccessConfigUpdates(args []string) ([]types.AccessConfigUpdate, error)
 	return updates, nil
 }
 
+func ProposalSetLegacyReverseIteratorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-legacy-reverse-iterator [code-id] [true|false] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to enable or disable the deprecated legacy reverse iterator mode of a code",
+		Long: `Submit a proposal to enable or disable the deprecated legacy reverse iterator mode of a code.
+When enabled, reverse iterators of the contracts include the entry at the exclusive end key, like an old wasmd fork did.
+Only use it for contracts that depend on this behavior.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("code id: %s", err)
+			}
+			enabled, err := strconv.ParseBool(args[1])
+			if err != nil {
+				return fmt.Errorf("enabled: %s", err)
+			}
+
+			msg := types.MsgSetLegacyReverseIterator{
+				Authority: authority,
+				CodeID:    codeID,
+				Enabled:   enabled,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
 func ProposalUpdateInstantiateConfigCmd() *cobra.Command {
 	bech32Prefix := sdk.GetConfig().GetBech32AccountAddrPrefix()
 	cmd := &cobra.Command{
@@ -899,6 +1136,219 @@ func ProposalRemoveCodeUploadParamsAddresses() *cobra.Command {
 	return cmd
 }
 
+const flagSetParam = "set"
+
+func ProposalUpdateParamsCmd() *cobra.Command {
+	bech32Prefix := sdk.GetConfig().GetBech32AccountAddrPrefix()
+	cmd := &cobra.Command{
+		Use:   "update-params --set [field=value]... --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to update selected wasm params",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal that updates only the given params fields. The other params keep their
+current value at execution time. Field names are the proto names of the params. Values are json; plain text
+is used as a json string. The code upload access also accepts nobody, everybody or a comma separated address list.
+
+Example:
+$ %s tx wasm submit-proposal update-params --set code_upload_access=%s1l2rsakp388kuv9k8qzq6lrm9taddae7fpx59wm --set max_response_events=100
+`, version.AppName, bech32Prefix)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			sets, err := cmd.Flags().GetStringArray(flagSetParam)
+			if err != nil {
+				return fmt.Errorf("set: %s", err)
+			}
+			params, mask, err := parseParamsPatch(clientCtx.Codec, sets)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgUpdateParams{
+				Authority: authority,
+				Params:    params,
+				FieldMask: mask,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringArray(flagSetParam, []string{}, "Params field to update in the format field=value, repeatable")
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+// parseParamsPatch returns the params with the fields of the `field=value` arguments set and the field mask for them
+func parseParamsPatch(cdc codec.JSONCodec, sets []string) (types.Params, []string, error) {
+	if len(sets) == 0 {
+		return types.Params{}, nil, errors.New("at least one params field must be set")
+	}
+	mask := make([]string, len(sets))
+	fields := make(map[string]json.RawMessage, len(sets))
+	for i, v := range sets {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok {
+			return types.Params{}, nil, fmt.Errorf("invalid format %q, expected field=value", v)
+		}
+		name = strings.TrimSpace(name)
+		mask[i] = name
+		raw, err := paramsFieldJSON(cdc, name, value)
+		if err != nil {
+			return types.Params{}, nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		fields[name] = raw
+	}
+	if err := types.ValidateParamsFieldMask(mask); err != nil {
+		return types.Params{}, nil, err
+	}
+	bz, err := json.Marshal(fields)
+	if err != nil {
+		return types.Params{}, nil, err
+	}
+	var params types.Params
+	if err := cdc.UnmarshalJSON(bz, &params); err != nil {
+		return types.Params{}, nil, err
+	}
+	return params, mask, nil
+}
+
+func paramsFieldJSON(cdc codec.JSONCodec, name, value string) (json.RawMessage, error) {
+	if json.Valid([]byte(value)) {
+		return json.RawMessage(value), nil
+	}
+	if name == "code_upload_access" {
+		cfg, err := parseAccessConfig(value)
+		if err != nil {
+			return nil, err
+		}
+		return cdc.MarshalJSON(&cfg)
+	}
+	return json.Marshal(value)
+}
+
+func ProposalAddCodeUploadApprovalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-code-upload-approval [checksum-hex] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to pre-approve a code checksum that anybody can upload",
+		Long: `Submit a proposal to pre-approve a code checksum that anybody can upload.
+The code with the sha256 checksum of the uncompressed wasm can then be stored even when the upload access
+would deny the sender. With --consume-once the approval is removed with the first upload.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			checksum, err := hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("checksum: %s", err)
+			}
+			consumeOnce, err := cmd.Flags().GetBool(flagConsumeOnce)
+			if err != nil {
+				return fmt.Errorf("consume once: %s", err)
+			}
+
+			msg := types.MsgAddCodeUploadApproval{
+				Authority:   authority,
+				Checksum:    checksum,
+				ConsumeOnce: consumeOnce,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagConsumeOnce, false, "Remove the approval with the first upload of the code")
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalRemoveCodeUploadApprovalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-code-upload-approval [checksum-hex] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to remove a pre-approved code checksum",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			checksum, err := hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("checksum: %s", err)
+			}
+
+			msg := types.MsgRemoveCodeUploadApproval{
+				Authority: authority,
+				Checksum:  checksum,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
 func addCommonProposalFlags(cmd *cobra.Command) {
 	flags.AddTxFlagsToCmd(cmd)
 	cmd.Flags().String(cli.FlagTitle, "", "Title of proposal")
@@ -906,6 +1356,13 @@ func addCommonProposalFlags(cmd *cobra.Command) {
 	cmd.Flags().String(cli.FlagDeposit, "", "Deposit of proposal")
 	cmd.Flags().String(flagAuthority, DefaultGovAuthority.String(), "The address of the governance account. Default is the sdk gov module account")
 	cmd.Flags().Bool(flagExpedite, false, "Expedite proposals have shorter voting period but require higher voting threshold")
+	// accept --expedited as used by the gov module
+	cmd.Flags().SetNormalizeFunc(func(_ *flag.FlagSet, name string) flag.NormalizedName {
+		if name == "expedited" {
+			name = flagExpedite
+		}
+		return flag.NormalizedName(name)
+	})
 }
 
 func getProposalInfo(cmd *cobra.Command) (client.Context, string, string, sdk.Coins, bool, error) {
@@ -991,3 +1448,163 @@ func ProposalStoreAndMigrateContractCmd() *cobra.Command {
 	addCommonProposalFlags(cmd)
 	return cmd
 }
+
+// draftProposal has the format of the `draft_proposal.json` file that is generated by the gov `draft-proposal`
+// command and accepted by the gov `submit-proposal` command
+type draftProposal struct {
+	Messages  []json.RawMessage `json:"messages"`
+	Metadata  string            `json:"metadata"`
+	Deposit   string            `json:"deposit"`
+	Title     string            `json:"title"`
+	Summary   string            `json:"summary"`
+	Expedited bool              `json:"expedited"`
+}
+
+func addAsProposalFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagAsProposal, false, "Print the messages as draft gov proposal with the authority as signer instead of generating or broadcasting a tx")
+	cmd.Flags().String(flagAuthority, DefaultGovAuthority.String(), "The address of the governance account that is set as signer with --as-proposal. Default is the sdk gov module account")
+}
+
+// txSender returns the authority when the messages are printed as draft proposal, otherwise the from address
+func txSender(clientCtx client.Context, flagSet *flag.FlagSet) (string, error) {
+	asProposal, err := flagSet.GetBool(flagAsProposal)
+	if err != nil || !asProposal {
+		return clientCtx.GetFromAddress().String(), err
+	}
+	return flagSet.GetString(flagAuthority)
+}
+
+// generateOrBroadcastOrDraftProposal prints the messages as draft proposal when --as-proposal is set,
+// otherwise the tx is generated or broadcasted
+func generateOrBroadcastOrDraftProposal(clientCtx client.Context, flagSet *flag.FlagSet, msgs ...sdk.Msg) error {
+	asProposal, err := flagSet.GetBool(flagAsProposal)
+	if err != nil {
+		return err
+	}
+	if !asProposal {
+		return tx.GenerateOrBroadcastTxCLI(clientCtx, flagSet, msgs...)
+	}
+	authority, err := flagSet.GetString(flagAuthority)
+	if err != nil {
+		return err
+	}
+	bz, err := buildDraftProposal(clientCtx.Codec, authority, msgs...)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintRaw(bz)
+}
+
+// buildDraftProposal returns the draft proposal json for the messages with the authority set as signer.
+// Title, summary and metadata are placeholders to be filled in before submission.
+func buildDraftProposal(cdc codec.JSONCodec, authority string, msgs ...sdk.Msg) (json.RawMessage, error) {
+	if _, err := sdk.AccAddressFromBech32(authority); err != nil {
+		return nil, errorsmod.Wrap(err, "authority")
+	}
+	p := draftProposal{
+		Messages: make([]json.RawMessage, len(msgs)),
+		Metadata: "ipfs://CID",
+		Title:    "TODO: title",
+		Summary:  "TODO: summary",
+	}
+	for i, msg := range msgs {
+		govMsg, err := asAuthorityMsg(msg, authority)
+		if err != nil {
+			return nil, err
+		}
+		if p.Messages[i], err = cdc.MarshalInterfaceJSON(govMsg); err != nil {
+			return nil, err
+		}
+	}
+	return json.MarshalIndent(p, "", " ")
+}
+
+// asAuthorityMsg returns a copy of the message with the authority as signer. Messages without
+// an authority-gated variant are rejected.
+func asAuthorityMsg(msg sdk.Msg, authority string) (sdk.Msg, error) {
+	switch m := msg.(type) {
+	case *types.MsgStoreCode:
+		c := *m
+		c.Sender = authority
+		return &c, c.ValidateBasic()
+	case *types.MsgMigrateContract:
+		c := *m
+		c.Sender = authority
+		return &c, c.ValidateBasic()
+	case *types.MsgStoreAndInstantiateContract:
+		c := *m
+		c.Authority = authority
+		return &c, c.ValidateBasic()
+	case *types.MsgUpdateInstantiateConfig:
+		c := *m
+		c.Sender = authority
+		return &c, c.ValidateBasic()
+	case *types.MsgSudoContract:
+		c := *m
+		c.Authority = authority
+		return &c, c.ValidateBasic()
+	case *types.MsgPinCodes:
+		c := *m
+		c.Authority = authority
+		return &c, c.ValidateBasic()
+	case *types.MsgUnpinCodes:
+		c := *m
+		c.Authority = authority
+		return &c, c.ValidateBasic()
+	case *types.MsgUpdateContractAdmins:
+		c := *m
+		c.Authority = authority
+		return &c, c.ValidateBasic()
+	default:
+		return nil, fmt.Errorf("message %s has no authority-gated variant", sdk.MsgTypeURL(msg))
+	}
+}
+
+func ProposalImportContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-contract [import_json_file] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to import a contract with its state",
+		Long: `Submit a proposal to import a contract with its state at an explicit address.
+The import json file is the output of "query wasm export-contract". The authority is set from the flag.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var msg types.MsgImportContract
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &msg); err != nil {
+				return errors.Wrap(err, "import json file")
+			}
+			msg.Authority = authority
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"os"
 	"strings"
 	"testing"
@@ -8,6 +9,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -99,6 +104,50 @@ func TestParseAccessConfigUpdates(t *testing.T) {
 	}
 }
 
+func TestParseContractAdminUpdates(t *testing.T) {
+	exp := []types.ContractAdminUpdate{
+		{Contract: "contract1", NewAdmin: "admin1"},
+		{Contract: "contract2", NewAdmin: "admin2"},
+	}
+	specs := map[string]struct {
+		src    string
+		exp    []types.ContractAdminUpdate
+		expErr bool
+	}{
+		"json": {
+			src: `[{"contract":"contract1","new_admin":"admin1"},{"contract":"contract2","new_admin":"admin2"}]`,
+			exp: exp,
+		},
+		"csv": {
+			src: "contract1, admin1\ncontract2,admin2\n",
+			exp: exp,
+		},
+		"invalid json": {
+			src:    `[{"contract":"contract1"`,
+			expErr: true,
+		},
+		"csv with missing admin": {
+			src:    "contract1\n",
+			expErr: true,
+		},
+		"csv with extra field": {
+			src:    "contract1,admin1,other\n",
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseContractAdminUpdates([]byte(spec.src))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
 func TestParseCodeInfoFlags(t *testing.T) {
 	correctSource := "https://github.com/CosmWasm/wasmd/blob/main/x/wasm/keeper/testdata/hackatom.wasm"
 	correctBuilderRef := "cosmwasm/workspace-optimizer:0.12.9"
@@ -158,3 +207,134 @@ func TestParseCodeInfoFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildDraftProposal(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	types.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	authority := DefaultGovAuthority.String()
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+	contractAddr := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+
+	specs := map[string]struct {
+		src    sdk.Msg
+		exp    string
+		expErr bool
+	}{
+		"store code": {
+			src: &types.MsgStoreCode{Sender: myAddr, WASMByteCode: []byte{0x1, 0x2}, InstantiatePermission: &types.AllowNobody},
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgStoreCode","sender":"` + authority + `","wasm_byte_code":"AQI=","instantiate_permission":{"permission":"Nobody","addresses":[]},"source":"","builder":"","provenance_signature":null}`,
+		},
+		"migrate": {
+			src: &types.MsgMigrateContract{Sender: myAddr, Contract: contractAddr, CodeID: 2, Msg: []byte(`{}`)},
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgMigrateContract","sender":"` + authority + `","contract":"` + contractAddr + `","code_id":"2","msg":{}}`,
+		},
+		"update instantiate config": {
+			src: &types.MsgUpdateInstantiateConfig{Sender: myAddr, CodeID: 1, NewInstantiatePermission: &types.AllowEverybody},
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgUpdateInstantiateConfig","sender":"` + authority + `","code_id":"1","new_instantiate_permission":{"permission":"Everybody","addresses":[]}}`,
+		},
+		"sudo": {
+			src: &types.MsgSudoContract{Contract: contractAddr, Msg: []byte(`{}`)},
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgSudoContract","authority":"` + authority + `","contract":"` + contractAddr + `","msg":{}}`,
+		},
+		"pin": {
+			src: &types.MsgPinCodes{CodeIDs: []uint64{1, 2}},
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgPinCodes","authority":"` + authority + `","code_ids":["1","2"]}`,
+		},
+		"no authority-gated variant": {
+			src:    &types.MsgStoreCodeChunk{Sender: myAddr, UploadID: "1", Total: 1},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := buildDraftProposal(cdc, authority, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			exp := `{"messages":[` + spec.exp + `],"metadata":"ipfs://CID","deposit":"","title":"TODO: title","summary":"TODO: summary","expedited":false}`
+			assert.JSONEq(t, exp, string(got))
+		})
+	}
+}
+
+func TestProposalExpeditedFlag(t *testing.T) {
+	cmd := ProposalSudoContractCmd()
+	// when
+	require.NoError(t, cmd.Flags().Set("expedited", "true"))
+	// then
+	got, err := cmd.Flags().GetBool(flagExpedite)
+	require.NoError(t, err)
+	assert.True(t, got)
+}
+
+func TestParseParamsPatch(t *testing.T) {
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+
+	specs := map[string]struct {
+		src       []string
+		expParams types.Params
+		expMask   []string
+		expErr    bool
+	}{
+		"access config shorthand": {
+			src:       []string{"code_upload_access=" + myAddr.String()},
+			expParams: types.Params{CodeUploadAccess: types.AccessTypeAnyOfAddresses.With(myAddr)},
+			expMask:   []string{"code_upload_access"},
+		},
+		"access config json": {
+			src:       []string{`code_upload_access={"permission":"Nobody"}`},
+			expParams: types.Params{CodeUploadAccess: types.AllowNobody},
+			expMask:   []string{"code_upload_access"},
+		},
+		"multiple fields": {
+			src:       []string{"max_response_events=100", "instantiate_default_permission=Everybody", "disable_usage_tracking=true"},
+			expParams: types.Params{MaxResponseEvents: 100, InstantiateDefaultPermission: types.AccessTypeEverybody, DisableUsageTracking: true},
+			expMask:   []string{"max_response_events", "instantiate_default_permission", "disable_usage_tracking"},
+		},
+		"list field": {
+			src:       []string{`params_query_modules=["bank","staking"]`},
+			expParams: types.Params{ParamsQueryModules: []string{"bank", "staking"}},
+			expMask:   []string{"params_query_modules"},
+		},
+		"none": {
+			expErr: true,
+		},
+		"unknown field": {
+			src:    []string{"not_a_param=1"},
+			expErr: true,
+		},
+		"duplicate field": {
+			src:    []string{"max_response_events=1", "max_response_events=2"},
+			expErr: true,
+		},
+		"missing value": {
+			src:    []string{"max_response_events"},
+			expErr: true,
+		},
+		"invalid value": {
+			src:    []string{"max_response_events=many"},
+			expErr: true,
+		},
+		"invalid access config": {
+			src:    []string{"code_upload_access=foo"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotParams, gotMask, gotErr := parseParamsPatch(cdc, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expMask, gotMask)
+			assert.Equal(t, spec.expParams, gotParams)
+		})
+	}
+}
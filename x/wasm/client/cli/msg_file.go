@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const flagMsgFile = "msg-file"
+
+// addMsgFileFlag registers the --msg-file flag as an alternative to the positional json message argument
+func addMsgFileFlag(cmd *cobra.Command) {
+	cmd.Flags().String(flagMsgFile, "", "Read the json message from the file instead of the argument, - reads from stdin")
+}
+
+// readMsgArg returns the json message of the command. It is either the positional argument at the index or the
+// content of the file of the --msg-file flag. Both can not be combined. The file content must be valid json.
+func readMsgArg(cmd *cobra.Command, args []string, idx int) (string, error) {
+	msgFile, err := cmd.Flags().GetString(flagMsgFile)
+	if err != nil {
+		return "", fmt.Errorf("msg file: %w", err)
+	}
+	hasArg := len(args) > idx
+	switch {
+	case hasArg && msgFile != "":
+		return "", errors.New("json message argument and --msg-file can not be combined")
+	case hasArg:
+		return args[idx], nil
+	case msgFile == "":
+		return "", errors.New("json message argument or --msg-file is required")
+	}
+
+	var bz []byte
+	if msgFile == "-" {
+		bz, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		bz, err = os.ReadFile(msgFile)
+	}
+	if err != nil {
+		return "", fmt.Errorf("msg file: %w", err)
+	}
+	if !json.Valid(bz) {
+		return "", fmt.Errorf("msg file %s: invalid json", msgFile)
+	}
+	return string(bz), nil
+}
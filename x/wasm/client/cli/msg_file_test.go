@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadMsgArg(t *testing.T) {
+	myFile := filepath.Join(t.TempDir(), "msg.json")
+	require.NoError(t, os.WriteFile(myFile, []byte(`{"transfer":{"amount":"1"}}`), 0o600))
+	invalidFile := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte(`{"transfer":`), 0o600))
+
+	specs := map[string]struct {
+		args    []string
+		msgFile string
+		stdin   string
+		exp     string
+		expErr  bool
+	}{
+		"positional argument": {
+			args: []string{"contract", `{"foo":"bar"}`},
+			exp:  `{"foo":"bar"}`,
+		},
+		"msg file": {
+			args:    []string{"contract"},
+			msgFile: myFile,
+			exp:     `{"transfer":{"amount":"1"}}`,
+		},
+		"stdin": {
+			args:    []string{"contract"},
+			msgFile: "-",
+			stdin:   `{"from":"jq"}`,
+			exp:     `{"from":"jq"}`,
+		},
+		"argument and msg file": {
+			args:    []string{"contract", `{"foo":"bar"}`},
+			msgFile: myFile,
+			expErr:  true,
+		},
+		"neither argument nor msg file": {
+			args:   []string{"contract"},
+			expErr: true,
+		},
+		"invalid json in file": {
+			args:    []string{"contract"},
+			msgFile: invalidFile,
+			expErr:  true,
+		},
+		"invalid json from stdin": {
+			args:    []string{"contract"},
+			msgFile: "-",
+			stdin:   "not json",
+			expErr:  true,
+		},
+		"unknown file": {
+			args:    []string{"contract"},
+			msgFile: filepath.Join(t.TempDir(), "unknown.json"),
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			addMsgFileFlag(cmd)
+			require.NoError(t, cmd.Flags().Set(flagMsgFile, spec.msgFile))
+			cmd.SetIn(strings.NewReader(spec.stdin))
+			// when
+			got, gotErr := readMsgArg(cmd, spec.args, 1)
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MultiExecuteCmd executes the contract calls of a json file in a single tx
+func MultiExecuteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multi-execute [batch.json]",
+		Short: "Execute multiple contract calls in a single transaction",
+		Long: fmt.Sprintf(`Execute multiple contract calls in a single transaction. All calls are sent by the sender and
+either all succeed or the whole tx fails. The file contains an array of
+{"contract": "<bech32_address>", "msg": {<execute_msg>}, "funds": "<coins,optional>"} objects, - reads it from stdin.
+Use --generate-only to build an unsigned tx for a multisig.
+Example:
+$ %s tx wasm multi-execute batch.json --from mykey
+`, version.AppName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			var bz []byte
+			if args[0] == "-" {
+				bz, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				bz, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("batch file: %w", err)
+			}
+			msgs, err := parseMultiExecuteBatch(bz, clientCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// multiExecuteEntry is an entry of the batch file
+type multiExecuteEntry struct {
+	Contract string          `json:"contract"`
+	Msg      json.RawMessage `json:"msg"`
+	Funds    string          `json:"funds,omitempty"`
+}
+
+// parseMultiExecuteBatch returns an execute message for each entry of the batch file in the order of the file.
+// All entries are validated so that no tx is signed with an invalid message.
+func parseMultiExecuteBatch(bz []byte, sender sdk.AccAddress) ([]sdk.Msg, error) {
+	var entries []multiExecuteEntry
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	// reject typos like "fund" that would silently drop the funds
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode batch: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("batch must not be empty")
+	}
+	msgs := make([]sdk.Msg, len(entries))
+	for i, e := range entries {
+		if _, err := sdk.AccAddressFromBech32(e.Contract); err != nil {
+			return nil, fmt.Errorf("entry %d: contract %q: %w", i, e.Contract, err)
+		}
+		if len(e.Msg) == 0 || !json.Valid(e.Msg) {
+			return nil, fmt.Errorf("entry %d: msg must be json", i)
+		}
+		funds, err := sdk.ParseCoinsNormalized(e.Funds)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: funds: %w", i, err)
+		}
+		msg := &types.MsgExecuteContract{
+			Sender:   sender.String(),
+			Contract: e.Contract,
+			Msg:      types.RawContractMessage(e.Msg),
+			Funds:    funds,
+		}
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		msgs[i] = msg
+	}
+	return msgs, nil
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestParseMultiExecuteBatch(t *testing.T) {
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	const otherContract = "cosmos1w09vr7rpe2agu0kg2zlpkdckce865l3zps8mxjurxthfh3m7035qe5hh7f"
+	mySender := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+
+	specs := map[string]struct {
+		src    string
+		exp    []sdk.Msg
+		expErr bool
+	}{
+		"multiple calls": {
+			src: `[{"contract":"` + myContract + `","msg":{"increment":{}}},
+				{"contract":"` + otherContract + `","msg":{"transfer":{}},"funds":"100stake,1atom"}]`,
+			exp: []sdk.Msg{
+				&types.MsgExecuteContract{Sender: mySender.String(), Contract: myContract, Msg: []byte(`{"increment":{}}`)},
+				&types.MsgExecuteContract{
+					Sender:   mySender.String(),
+					Contract: otherContract,
+					Msg:      []byte(`{"transfer":{}}`),
+					Funds:    sdk.NewCoins(sdk.NewInt64Coin("atom", 1), sdk.NewInt64Coin("stake", 100)),
+				},
+			},
+		},
+		"empty batch": {
+			src:    `[]`,
+			expErr: true,
+		},
+		"not an array": {
+			src:    `{"contract":"` + myContract + `","msg":{}}`,
+			expErr: true,
+		},
+		"unknown field": {
+			src:    `[{"contract":"` + myContract + `","msg":{},"fund":"1stake"}]`,
+			expErr: true,
+		},
+		"invalid contract address": {
+			src:    `[{"contract":"` + myContract + `","msg":{}},{"contract":"invalid","msg":{}}]`,
+			expErr: true,
+		},
+		"missing msg": {
+			src:    `[{"contract":"` + myContract + `"}]`,
+			expErr: true,
+		},
+		"invalid funds": {
+			src:    `[{"contract":"` + myContract + `","msg":{},"funds":"1"}]`,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// when
+			got, gotErr := parseMultiExecuteBatch([]byte(spec.src), mySender)
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -1,15 +1,21 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	errorsmod "cosmossdk.io/errors"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -19,22 +25,33 @@ func MigrateContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "migrate [contract_addr_bech32] [new_code_id_int64] [json_encoded_migration_args]",
 		Short:   "Migrate a wasm contract to a new code version",
+		Long:    "Migrate a wasm contract to a new code version. The migration message can be read from a file with --msg-file instead of the argument",
 		Aliases: []string{"update", "mig", "m"},
-		Args:    cobra.ExactArgs(3),
+		Args:    cobra.RangeArgs(2, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			msg, err := parseMigrateContractArgs(args, clientCtx.GetFromAddress().String())
+			sender, err := txSender(clientCtx, cmd.Flags())
 			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			migrateMsg, err := readMsgArg(cmd, args, 2)
+			if err != nil {
+				return err
+			}
+			msg, err := parseMigrateContractArgs([]string{args[0], args[1], migrateMsg}, sender)
+			if err != nil {
+				return err
+			}
+			return generateOrBroadcastOrDraftProposal(clientCtx, cmd.Flags(), &msg)
 		},
 		SilenceUsage: true,
 	}
+	addMsgFileFlag(cmd)
+	addAsProposalFlags(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -122,8 +139,16 @@ func ClearContractAdminCmd() *cobra.Command {
 // UpdateInstantiateConfigCmd updates instantiate config for a smart contract.
 func UpdateInstantiateConfigCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "update-instantiate-config [code_id_int64]",
-		Short:   "Update instantiate config for a codeID",
+		Use:   "update-instantiate-config [code_id_int64]",
+		Short: "Update instantiate config for a codeID",
+		Long: fmt.Sprintf(`Update instantiate config for a codeID. The tx is signed by the code creator.
+Exactly one of --%s, --%s or --%s must be set.
+With the sync broadcast mode, the command waits for the inclusion of the tx and prints the new
+instantiate permission of the code.
+
+Example:
+$ %s tx wasm update-instantiate-config 1 --%s=cosmos1...,cosmos1... --from creator
+`, flagInstantiateByEverybody, flagInstantiateNobody, flagInstantiateByAnyOfAddress, version.AppName, flagInstantiateByAnyOfAddress),
 		Aliases: []string{"update-instantiate-config"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -135,29 +160,71 @@ func UpdateInstantiateConfigCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			perm, err := parseAccessConfigFlags(cmd.Flags())
+			perm, err := parseSingleAccessConfigFlag(cmd.Flags())
 			if err != nil {
 				return err
 			}
 
+			sender, err := txSender(clientCtx, cmd.Flags())
+			if err != nil {
+				return err
+			}
 			msg := types.MsgUpdateInstantiateConfig{
-				Sender:                   clientCtx.GetFromAddress().String(),
+				Sender:                   sender,
 				CodeID:                   codeID,
 				NewInstantiatePermission: perm,
 			}
 			if err = msg.ValidateBasic(); err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			asProposal, err := cmd.Flags().GetBool(flagAsProposal)
+			if err != nil {
+				return err
+			}
+			if asProposal || clientCtx.GenerateOnly || clientCtx.Simulate || clientCtx.BroadcastMode != flags.BroadcastSync {
+				return generateOrBroadcastOrDraftProposal(clientCtx, cmd.Flags(), &msg)
+			}
+			included, err := broadcastAndWait(cmd.Context(), clientCtx, cmd.Flags(), &msg)
+			if err != nil || included == nil {
+				return err
+			}
+			res, err := types.NewQueryClient(clientCtx).CodeInfo(cmd.Context(), &types.QueryCodeInfoRequest{CodeId: codeID})
+			if err != nil {
+				return fmt.Errorf("code info: %w", err)
+			}
+			return clientCtx.PrintProto(&res.InstantiatePermission)
 		},
 		SilenceUsage: true,
 	}
 
 	addInstantiatePermissionFlags(cmd)
+	addAsProposalFlags(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+// parseSingleAccessConfigFlag returns the access config of the instantiate permission flags. Exactly one
+// permission flag must be set.
+func parseSingleAccessConfigFlag(flagSet *flag.FlagSet) (*types.AccessConfig, error) {
+	var set int
+	for _, name := range []string{flagInstantiateByEverybody, flagInstantiateNobody, flagInstantiateByAnyOfAddress} {
+		if flagSet.Changed(name) {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("exactly one of --%s, --%s or --%s must be set", flagInstantiateByEverybody, flagInstantiateNobody, flagInstantiateByAnyOfAddress)
+	}
+	perm, err := parseAccessConfigFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+	if perm == nil {
+		return nil, errors.New("instantiate permission must not be disabled")
+	}
+	return perm, nil
+}
+
 // UpdateContractLabelCmd sets an new label for a contract
 func UpdateContractLabelCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -185,3 +252,556 @@ func UpdateContractLabelCmd() *cobra.Command {
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
+
+// SetQueryAliasCmd registers or updates a named smart query for a contract
+func SetQueryAliasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-query-alias [contract_addr_bech32] [name] [json_encoded_query] --cache-ttl [duration,optional]",
+		Short: "Register a named smart query for a contract",
+		Long: `Register or update a named smart query for a contract. Only the contract admin can set query aliases.
+The cache ttl is a hint for API nodes how long the query responses can be cached, i.e. "5m". It is not
+set by default so that responses are not cached.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			cacheTTL, err := cmd.Flags().GetDuration(flagCacheTTL)
+			if err != nil {
+				return fmt.Errorf("cache ttl: %w", err)
+			}
+			cacheTTLSeconds, err := parseQueryAliasCacheTTL(cacheTTL)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgSetQueryAlias{
+				Sender:          clientCtx.GetFromAddress().String(),
+				Contract:        args[0],
+				Name:            args[1],
+				QueryData:       []byte(args[2]),
+				CacheTTLSeconds: cacheTTLSeconds,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Duration(flagCacheTTL, 0, "Time that API nodes can cache the query responses, i.e. \"5m\"")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseQueryAliasCacheTTL returns the cache ttl in whole seconds
+func parseQueryAliasCacheTTL(d time.Duration) (uint32, error) {
+	switch {
+	case d < 0:
+		return 0, errors.New("cache ttl must not be negative")
+	case d%time.Second != 0:
+		return 0, errors.New("cache ttl must be whole seconds")
+	case d > time.Duration(types.MaxQueryAliasCacheTTLSeconds)*time.Second:
+		return 0, fmt.Errorf("cache ttl must not be longer than %s", time.Duration(types.MaxQueryAliasCacheTTLSeconds)*time.Second)
+	}
+	return uint32(d / time.Second), nil
+}
+
+// RemoveQueryAliasCmd removes a named smart query of a contract
+func RemoveQueryAliasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-query-alias [contract_addr_bech32] [name]",
+		Short: "Remove a named smart query of a contract",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgSetQueryAlias{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Name:     args[1],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// SetIBCSendTimeoutCmd sets the default timeout for IBC packets sent by a contract
+func SetIBCSendTimeoutCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-ibc-send-timeout [contract_addr_bech32] [timeout]",
+		Short: "Set the default timeout for IBC packets sent by a contract",
+		Long: `Set the default timeout for IBC packets that a contract sends without timeout height and timestamp.
+The timeout is a duration relative to the block time, i.e. "10m". Use "0" to remove the default.
+Only the contract admin can set it.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			timeout, err := time.ParseDuration(args[1])
+			if err != nil {
+				return errorsmod.Wrap(err, "timeout")
+			}
+			if timeout < 0 {
+				return errors.New("timeout must not be negative")
+			}
+
+			msg := types.MsgSetIBCSendTimeout{
+				Sender:           clientCtx.GetFromAddress().String(),
+				Contract:         args[0],
+				TimeoutTimestamp: uint64(timeout.Nanoseconds()),
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// SetAdminChangeNotificationCmd enables or disables the sudo notification of a contract on admin changes
+func SetAdminChangeNotificationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-admin-change-notification [contract_addr_bech32] [true|false]",
+		Short: "Enable or disable the sudo notification of a contract on admin changes",
+		Long: `Enable or disable the sudo notification of a contract when the admin is updated or cleared.
+The contract receives {"admin_changed":{"old":..., "new":...}} and can reject the change by returning an error.
+Only the contract admin can set it.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			enabled, err := strconv.ParseBool(args[1])
+			if err != nil {
+				return errorsmod.Wrap(err, "enabled")
+			}
+
+			msg := types.MsgSetAdminChangeNotification{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Enabled:  enabled,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// SetContractTagsCmd replaces the tags of a contract
+func SetContractTagsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-contract-tags [contract_addr_bech32] [tag]...",
+		Short: "Replace the tags of a contract",
+		Long: `Replace the tags of a contract. Without tags, all tags are removed.
+Tags contain lower case letters, digits, '-', '_' and '.' only. Only the contract admin can set tags.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgSetContractTags{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Tags:     args[1:],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// ScheduleContractSunsetCmd announces the end-of-life height of a contract
+func ScheduleContractSunsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule-contract-sunset [contract_addr_bech32] [height]",
+		Short: "Announce the end-of-life height of a contract",
+		Long: `Announce the end-of-life height of a contract. The contract is deactivated at the end of the block with the
+given height, executions fail afterwards. A scheduled sunset is replaced. Only the contract admin can schedule a sunset.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			height, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return errorsmod.Wrap(err, "height")
+			}
+
+			msg := types.MsgScheduleContractSunset{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Height:   height,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CancelContractSunsetCmd removes the scheduled sunset of a contract
+func CancelContractSunsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-contract-sunset [contract_addr_bech32]",
+		Short: "Remove the scheduled sunset of a contract",
+		Long:  "Remove the scheduled sunset of a contract before the height was reached. Only the contract admin can cancel.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgCancelContractSunset{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// SetContractFeeSponsorshipCmd sets the budgets for the fees that a contract pays for txs that execute it
+func SetContractFeeSponsorshipCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-contract-fee-sponsorship [contract_addr_bech32] --max-fee-per-tx [coins] --max-fee-per-block [coins]",
+		Short: "Set the budgets for the fees that a contract pays",
+		Long: `Set the budgets for the fees that a contract pays from its balance for txs that execute only this contract
+and set it as fee granter. Without budgets, the contract stops paying fees. Only the contract admin can set the budgets.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			maxPerTxStr, err := cmd.Flags().GetString(flagMaxFeePerTx)
+			if err != nil {
+				return fmt.Errorf("max fee per tx: %s", err)
+			}
+			maxPerBlockStr, err := cmd.Flags().GetString(flagMaxFeePerBlock)
+			if err != nil {
+				return fmt.Errorf("max fee per block: %s", err)
+			}
+
+			msg := types.MsgSetContractFeeSponsorship{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+			}
+			if maxPerTxStr != "" || maxPerBlockStr != "" {
+				maxPerTx, err := sdk.ParseCoinsNormalized(maxPerTxStr)
+				if err != nil {
+					return errorsmod.Wrap(err, "max fee per tx")
+				}
+				maxPerBlock, err := sdk.ParseCoinsNormalized(maxPerBlockStr)
+				if err != nil {
+					return errorsmod.Wrap(err, "max fee per block")
+				}
+				msg.Sponsorship = &types.FeeSponsorship{MaxFeePerTx: maxPerTx, MaxFeePerBlock: maxPerBlock}
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagMaxFeePerTx, "", "Maximal fee that the contract pays for a single tx")
+	cmd.Flags().String(flagMaxFeePerBlock, "", "Maximal total of fees that the contract pays within a block")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// AttestCodeCmd records a build attestation of the sender for a code
+func AttestCodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attest-code [code_id] [repo_url] [commit] [builder_image]",
+		Short: "Attest that a code was built from a source repository commit",
+		Long: `Attest that a code was built from a source repository commit with the given builder image.
+Attestations are unverified claims of the sender. Anyone can attest a code, a new attestation of the same sender replaces the old one.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return errorsmod.Wrap(err, "code id")
+			}
+
+			msg := types.MsgAttestCode{
+				Attester:     clientCtx.GetFromAddress().String(),
+				CodeID:       codeID,
+				RepoURL:      args[1],
+				Commit:       args[2],
+				BuilderImage: args[3],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// VoteCodeVerificationCmd records the verdict of a code verifier on the build reproducibility of a code
+func VoteCodeVerificationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-code-verification [code_id] [reproducible|not-reproducible]",
+		Short: "Vote on the build reproducibility of a code",
+		Long: `Vote on whether a code could be reproduced from its source. Only the code verifiers in the params can vote.
+A new vote of the same verifier replaces the old one.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return errorsmod.Wrap(err, "code id")
+			}
+			var verdict types.CodeVerificationVerdict
+			switch args[1] {
+			case "reproducible":
+				verdict = types.CodeVerificationVerdictReproducible
+			case "not-reproducible":
+				verdict = types.CodeVerificationVerdictNotReproducible
+			default:
+				return fmt.Errorf("unknown verdict %q, expected reproducible or not-reproducible", args[1])
+			}
+
+			msg := types.MsgVoteCodeVerification{
+				Sender:  clientCtx.GetFromAddress().String(),
+				CodeID:  codeID,
+				Verdict: verdict,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// ProposeAdminTransferCmd proposes a new admin for a contract
+func ProposeAdminTransferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-admin-transfer [contract_addr_bech32] [new_admin_addr_bech32]",
+		Short: "Propose a new admin for a contract",
+		Long: `Propose a new admin for a contract. The admin changes when the new admin accepts the transfer with
+accept-admin-transfer. A proposed transfer is replaced. Only the contract admin can propose.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgProposeAdminTransfer{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				NewAdmin: args[1],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// AcceptAdminTransferCmd completes a proposed admin transfer
+func AcceptAdminTransferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accept-admin-transfer [contract_addr_bech32]",
+		Short: "Accept the proposed admin transfer of a contract",
+		Long:  "Accept the proposed admin transfer of a contract. Must be signed by the proposed admin.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgAcceptAdminTransfer{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CancelAdminTransferCmd removes the pending admin of a contract
+func CancelAdminTransferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-admin-transfer [contract_addr_bech32]",
+		Short: "Remove the proposed admin transfer of a contract",
+		Long:  "Remove the proposed admin transfer of a contract before it was accepted. Only the contract admin can cancel.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgCancelAdminTransfer{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func SuspendContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suspend-contract [contract_addr_bech32]",
+		Short: "Suspend the executions of a contract",
+		Long:  "Freeze a contract. Executions, sudo calls of other modules and incoming IBC packets fail until the contract is resumed. Queries, migrations, admin operations and governance sudo calls still work. Only the contract admin can suspend.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgSuspendContract{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func ResumeContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume-contract [contract_addr_bech32]",
+		Short: "Resume the executions of a suspended contract",
+		Long:  "Lift the suspension of a contract. Only the contract admin can resume.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgResumeContract{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// SetIgnoreReplyErrorCmd enables or disables the ignore reply error policy of a contract
+func SetIgnoreReplyErrorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-ignore-reply-error [contract_addr_bech32] [true|false]",
+		Short: "Enable or disable the ignore reply error policy of a contract",
+		Long: `Enable or disable the ignore reply error policy of a contract.
+With the policy, a failing reply to a successful submessage with reply on success is reverted and reported
+by a reply_failed event, while the state changes of the submessage are kept.
+Only the contract admin can set it.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			enabled, err := strconv.ParseBool(args[1])
+			if err != nil {
+				return errorsmod.Wrap(err, "enabled")
+			}
+
+			msg := types.MsgSetIgnoreReplyError{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Enabled:  enabled,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
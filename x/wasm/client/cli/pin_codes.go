@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/version"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// maxCodeIDRange is the max number of code ids in a single range to prevent typos like 1-100000
+const maxCodeIDRange = 1000
+
+// PinCodesCmd pins codes in the wasmvm cache with a non-gov authority
+func PinCodesCmd() *cobra.Command {
+	return pinCodesCmd(true)
+}
+
+// UnpinCodesCmd unpins codes from the wasmvm cache with a non-gov authority
+func UnpinCodesCmd() *cobra.Command {
+	return pinCodesCmd(false)
+}
+
+func pinCodesCmd(pin bool) *cobra.Command {
+	use, short := "pin-codes", "Pin codes in the wasmvm cache"
+	if !pin {
+		use, short = "unpin-codes", "Unpin codes from the wasmvm cache"
+	}
+	cmd := &cobra.Command{
+		Use:   use + " [code_ids] --authority [address,optional]",
+		Short: short,
+		Long: fmt.Sprintf(`%s. The tx must be signed by the authority of the wasm keeper, which is the sender by default.
+This is for chains where the authority is an operations account instead of the gov module.
+Code ids can be ranges and comma separated lists like 5-10,42. Codes whose pin state would not change are skipped.
+Example:
+$ %s tx wasm %s 5-10,42 --from ops
+`, short, version.AppName, use),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %w", err)
+			}
+			if authority == "" {
+				authority = clientCtx.GetFromAddress().String()
+			}
+			codeIDs, err := parseCodeIDRanges(args)
+			if err != nil {
+				return err
+			}
+			if !clientCtx.Offline {
+				pinned, err := queryPinnedCodeIDs(cmd.Context(), types.NewQueryClient(clientCtx))
+				if err != nil {
+					return fmt.Errorf("query pinned codes: %w", err)
+				}
+				if codeIDs = skipNoopCodeIDs(cmd.ErrOrStderr(), codeIDs, pinned, pin); len(codeIDs) == 0 {
+					return errors.New("no code changes its pin state")
+				}
+			}
+
+			if pin {
+				msg := types.MsgPinCodes{Authority: authority, CodeIDs: codeIDs}
+				if err := msg.ValidateBasic(); err != nil {
+					return err
+				}
+				return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			}
+			msg := types.MsgUnpinCodes{Authority: authority, CodeIDs: codeIDs}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagAuthority, "", "The authority address of the wasm keeper. Default is the sender")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseCodeIDRanges returns the sorted and deduplicated code ids of the arguments. Each argument is a comma
+// separated list of code ids and inclusive ranges like 5-10.
+func parseCodeIDRanges(args []string) ([]uint64, error) {
+	var codeIDs []uint64
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			from, to, isRange := strings.Cut(part, "-")
+			start, err := parseCodeID(from)
+			if err != nil {
+				return nil, err
+			}
+			end := start
+			if isRange {
+				if end, err = parseCodeID(to); err != nil {
+					return nil, err
+				}
+			}
+			switch {
+			case end < start:
+				return nil, fmt.Errorf("code id range %s: end before start", part)
+			case end-start >= maxCodeIDRange:
+				return nil, fmt.Errorf("code id range %s: exceeds %d codes", part, maxCodeIDRange)
+			}
+			for i := uint64(0); i <= end-start; i++ {
+				codeIDs = append(codeIDs, start+i)
+			}
+		}
+	}
+	if len(codeIDs) == 0 {
+		return nil, errors.New("code ids required")
+	}
+	slices.Sort(codeIDs)
+	return slices.Compact(codeIDs), nil
+}
+
+func parseCodeID(s string) (uint64, error) {
+	codeID, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("code id %q: %w", s, err)
+	}
+	if codeID == 0 {
+		return 0, errors.New("code id must be positive")
+	}
+	return codeID, nil
+}
+
+// queryPinnedCodeIDs returns the ids of all pinned codes by following the pagination
+func queryPinnedCodeIDs(ctx context.Context, queryClient types.QueryClient) (map[uint64]struct{}, error) {
+	pinned := make(map[uint64]struct{})
+	var nextKey []byte
+	for {
+		res, err := queryClient.PinnedCodes(ctx, &types.QueryPinnedCodesRequest{
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, codeID := range res.CodeIDs {
+			pinned[codeID] = struct{}{}
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return pinned, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// ensureCodesExist queries the code info of each code id and fails on the first code that can not be queried
+func ensureCodesExist(ctx context.Context, queryClient types.QueryClient, codeIDs []uint64) error {
+	for _, codeID := range codeIDs {
+		if _, err := queryClient.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: codeID}); err != nil {
+			return fmt.Errorf("code id %d: %w", codeID, err)
+		}
+	}
+	return nil
+}
+
+// skipNoopCodeIDs returns the code ids whose pin state changes. The skipped code ids are printed to out.
+// With pin, pinned codes are skipped. Otherwise codes that are not pinned are skipped.
+func skipNoopCodeIDs(out io.Writer, codeIDs []uint64, pinned map[uint64]struct{}, pin bool) []uint64 {
+	var result, skipped []uint64
+	for _, codeID := range codeIDs {
+		if _, isPinned := pinned[codeID]; isPinned == pin {
+			skipped = append(skipped, codeID)
+			continue
+		}
+		result = append(result, codeID)
+	}
+	if len(skipped) != 0 {
+		state := "already pinned"
+		if !pin {
+			state = "not pinned"
+		}
+		fmt.Fprintf(out, "skipping codes that are %s: %v\n", state, skipped)
+	}
+	return result
+}
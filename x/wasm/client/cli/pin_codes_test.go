@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestParseCodeIDRanges(t *testing.T) {
+	specs := map[string]struct {
+		src    []string
+		exp    []uint64
+		expErr bool
+	}{
+		"single id": {
+			src: []string{"42"},
+			exp: []uint64{42},
+		},
+		"range and list": {
+			src: []string{"5-8,42"},
+			exp: []uint64{5, 6, 7, 8, 42},
+		},
+		"multiple args": {
+			src: []string{"42", "1-2"},
+			exp: []uint64{1, 2, 42},
+		},
+		"duplicates and overlaps": {
+			src: []string{"3-5,4,5-6", "3"},
+			exp: []uint64{3, 4, 5, 6},
+		},
+		"single element range": {
+			src: []string{"7-7"},
+			exp: []uint64{7},
+		},
+		"empty parts ignored": {
+			src: []string{"1,,2,"},
+			exp: []uint64{1, 2},
+		},
+		"zero id": {
+			src:    []string{"0"},
+			expErr: true,
+		},
+		"zero in range": {
+			src:    []string{"0-3"},
+			expErr: true,
+		},
+		"negative": {
+			src:    []string{"-1"},
+			expErr: true,
+		},
+		"reversed range": {
+			src:    []string{"10-5"},
+			expErr: true,
+		},
+		"range too big": {
+			src:    []string{"1-1001"},
+			expErr: true,
+		},
+		"not a number": {
+			src:    []string{"a"},
+			expErr: true,
+		},
+		"nothing": {
+			src:    []string{","},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseCodeIDRanges(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestSkipNoopCodeIDs(t *testing.T) {
+	pinned := map[uint64]struct{}{2: {}, 3: {}}
+	specs := map[string]struct {
+		src        []uint64
+		pin        bool
+		exp        []uint64
+		expSkipped string
+	}{
+		"pin skips pinned": {
+			src:        []uint64{1, 2, 3, 4},
+			pin:        true,
+			exp:        []uint64{1, 4},
+			expSkipped: "skipping codes that are already pinned: [2 3]\n",
+		},
+		"unpin skips not pinned": {
+			src:        []uint64{1, 2, 3, 4},
+			exp:        []uint64{2, 3},
+			expSkipped: "skipping codes that are not pinned: [1 4]\n",
+		},
+		"nothing skipped": {
+			src: []uint64{2},
+			exp: []uint64{2},
+		},
+		"all skipped": {
+			src:        []uint64{2},
+			pin:        true,
+			expSkipped: "skipping codes that are already pinned: [2]\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			got := skipNoopCodeIDs(&out, spec.src, pinned, spec.pin)
+			assert.Equal(t, spec.exp, got)
+			assert.Equal(t, spec.expSkipped, out.String())
+		})
+	}
+}
+
+// pinnedCodesQueryClient returns the pinned codes in pages of 2
+type pinnedCodesQueryClient struct {
+	types.QueryClient
+	codeIDs []uint64
+}
+
+func (m pinnedCodesQueryClient) PinnedCodes(_ context.Context, in *types.QueryPinnedCodesRequest, _ ...grpc.CallOption) (*types.QueryPinnedCodesResponse, error) {
+	var start int
+	if in.Pagination != nil && len(in.Pagination.Key) != 0 {
+		start = int(in.Pagination.Key[0])
+	}
+	end := min(start+2, len(m.codeIDs))
+	rsp := &types.QueryPinnedCodesResponse{CodeIDs: m.codeIDs[start:end], Pagination: &query.PageResponse{}}
+	if end < len(m.codeIDs) {
+		rsp.Pagination.NextKey = []byte{byte(end)}
+	}
+	return rsp, nil
+}
+
+func TestQueryPinnedCodeIDs(t *testing.T) {
+	got, err := queryPinnedCodeIDs(context.Background(), pinnedCodesQueryClient{codeIDs: []uint64{1, 5, 7, 9, 11}})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]struct{}{1: {}, 5: {}, 7: {}, 9: {}, 11: {}}, got)
+}
+
+// codeInfoQueryClient returns the code info of the existing codes only
+type codeInfoQueryClient struct {
+	types.QueryClient
+	codeIDs []uint64
+}
+
+func (m codeInfoQueryClient) CodeInfo(_ context.Context, in *types.QueryCodeInfoRequest, _ ...grpc.CallOption) (*types.QueryCodeInfoResponse, error) {
+	if !slices.Contains(m.codeIDs, in.CodeId) {
+		return nil, types.ErrNoSuchCodeFn(in.CodeId)
+	}
+	return &types.QueryCodeInfoResponse{CodeID: in.CodeId}, nil
+}
+
+func TestEnsureCodesExist(t *testing.T) {
+	queryClient := codeInfoQueryClient{codeIDs: []uint64{1, 2, 3}}
+	specs := map[string]struct {
+		src    []uint64
+		expErr bool
+	}{
+		"all exist": {
+			src: []uint64{1, 3},
+		},
+		"one missing": {
+			src:    []uint64{1, 4},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := ensureCodesExist(context.Background(), queryClient, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
@@ -1,22 +1,34 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -36,15 +48,41 @@ func GetQueryCmd() *cobra.Command {
 		GetCmdListContractByCode(),
 		GetCmdQueryCode(),
 		GetCmdQueryCodeInfo(),
+		GetCmdQueryCodeByChecksum(),
 		GetCmdGetContractInfo(),
 		GetCmdGetContractHistory(),
 		GetCmdGetContractState(),
 		GetCmdListPinnedCode(),
+		GetCmdListCodeUploadApprovals(),
 		GetCmdLibVersion(),
 		GetCmdQueryParams(),
+		GetCmdWasmStats(),
 		GetCmdBuildAddress(),
 		GetCmdListContractsByCreator(),
+		GetCmdListContractsByAdmin(),
+		GetCmdListContractsByLabel(),
+		GetCmdContractChildren(),
+		GetCmdContractParent(),
+		GetCmdQueryAliasedSmart(),
+		GetCmdListQueryAliases(),
+		GetCmdContractUsage(),
+		GetCmdContractFeeSponsorship(),
+		GetCmdContractExecutionReceipt(),
+		GetCmdScheduledSudos(),
+		GetCmdContractStateDiff(),
+		GetCmdIBCPackets(),
+		GetCmdContractChannelStatus(),
+		GetCmdGasConstants(),
+		GetCmdAddressType(),
+		GetCmdCodeAttestations(),
+		GetCmdCodeVerification(),
+		GetCmdExportContract(),
+		GetCmdListContractsByTag(),
+		GetCmdListLightClients(),
+		GetCmdExplainTx(),
 	)
+	queryCmd.PersistentFlags().String(flagBech32Prefix, "", "Bech32 prefix of the address arguments, when different from the chain prefix. The addresses are translated to the chain prefix")
+	queryCmd.PersistentFlags().Bool(flagAtLatestCommitted, false, "Run all queries at the latest committed height, resolved once. The height is printed to stderr. Can not be combined with --height")
 	return queryCmd
 }
 
@@ -73,8 +111,13 @@ func GetCmdLibVersion() *cobra.Command {
 func GetCmdBuildAddress() *cobra.Command {
 	decoder := newArgDecoder(hex.DecodeString)
 	cmd := &cobra.Command{
-		Use:     "build-address [code-hash] [creator-address] [salt-hex-encoded] [json_encoded_init_args (required when set as fixed)]",
-		Short:   "build contract address",
+		Use:   "build-address [code-hash] [creator-address] [salt] [json_encoded_init_args (required when set as fixed)]",
+		Short: "build contract address",
+		Long: fmt.Sprintf(`Build the address of a contract instantiated with instantiate2.
+
+The salt is hex encoded by default. Use --ascii or --b64 for ascii or base64 encoded salts.
+It must have 1 to %d bytes. The address is printed together with the hex encoded salt
+that was used.`, types.MaxSaltSize),
 		Aliases: []string{"address"},
 		Args:    cobra.RangeArgs(3, 4),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -83,36 +126,71 @@ func GetCmdBuildAddress() *cobra.Command {
 				initArgs = types.RawContractMessage(args[3])
 			}
 
+			creator, err := translateAddress(cmd, args[1])
+			if err != nil {
+				return err
+			}
+			salt, err := decodeSalt(decoder, args[2])
+			if err != nil {
+				return err
+			}
+			saltHex := hex.EncodeToString(salt)
+
+			predictPort, err := cmd.Flags().GetBool(flagPredictPort)
+			if err != nil {
+				return err
+			}
+			if predictPort {
+				res, err := keeper.PredictPortID(
+					&types.QueryPredictPortIDRequest{
+						CodeHash:       args[0],
+						CreatorAddress: creator,
+						Salt:           saltHex,
+						InitArgs:       initArgs,
+					},
+				)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "address: %s\nport_id: %s\nsalt: %s\n", res.Address, res.PortID, saltHex)
+				return nil
+			}
 			res, err := keeper.BuildAddressPredictable(
 				&types.QueryBuildAddressRequest{
 					CodeHash:       args[0],
-					CreatorAddress: args[1],
-					Salt:           args[2],
+					CreatorAddress: creator,
+					Salt:           saltHex,
 					InitArgs:       initArgs,
 				},
 			)
 			if err != nil {
 				return err
 			}
-			fmt.Println(res.Address)
+			fmt.Fprintf(cmd.OutOrStdout(), "address: %s\nsalt: %s\n", res.Address, saltHex)
 			return nil
 		},
 		SilenceUsage: true,
 	}
 	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
+	cmd.Flags().Bool(flagPredictPort, false, "Print the IBC port id the contract gets in addition to the address")
 	return cmd
 }
 
 // GetCmdListCode lists all wasm code uploaded
 func GetCmdListCode() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list-code",
-		Short:   "List all wasm bytecode on the chain",
-		Long:    "List all wasm bytecode on the chain",
+		Use:   "list-code",
+		Short: "List all wasm bytecode on the chain",
+		Long: `List all wasm bytecode on the chain.
+
+Use --creator to only list codes uploaded by the given address and
+--instantiate-permission (everybody|nobody|any-of) to only list codes with
+that instantiate permission type. Both filters are applied on the node so
+pagination stays correct.`,
 		Aliases: []string{"list-codes", "codes", "lco"},
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
@@ -121,11 +199,30 @@ func GetCmdListCode() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			creator, err := cmd.Flags().GetString(flagCreator)
+			if err != nil {
+				return err
+			}
+			if creator != "" {
+				if creator, err = translateAddress(cmd, creator); err != nil {
+					return err
+				}
+			}
+			rawPermission, err := cmd.Flags().GetString(flagInstantiatePermission)
+			if err != nil {
+				return err
+			}
+			permission, err := parseAccessTypeFilter(rawPermission)
+			if err != nil {
+				return err
+			}
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.Codes(
 				context.Background(),
 				&types.QueryCodesRequest{
-					Pagination: pageReq,
+					Pagination:            pageReq,
+					Creator:               creator,
+					InstantiatePermission: permission,
 				},
 			)
 			if err != nil {
@@ -137,9 +234,28 @@ func GetCmdListCode() *cobra.Command {
 	}
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "list codes")
+	cmd.Flags().String(flagCreator, "", "Only list codes uploaded by this address")
+	cmd.Flags().String(flagInstantiatePermission, "", "Only list codes with this instantiate permission: everybody|nobody|any-of")
 	return cmd
 }
 
+// parseAccessTypeFilter maps the instantiate permission filter flag to an access type.
+// An empty value disables the filter.
+func parseAccessTypeFilter(raw string) (types.AccessType, error) {
+	switch raw {
+	case "":
+		return types.AccessTypeUnspecified, nil
+	case "everybody":
+		return types.AccessTypeEverybody, nil
+	case "nobody":
+		return types.AccessTypeNobody, nil
+	case "any-of":
+		return types.AccessTypeAnyOfAddresses, nil
+	default:
+		return types.AccessTypeUnspecified, fmt.Errorf("unknown instantiate permission %q: expected everybody|nobody|any-of", raw)
+	}
+}
+
 // GetCmdListContractByCode lists all wasm code uploaded for given code id
 func GetCmdListContractByCode() *cobra.Command {
 	cmd := &cobra.Command{
@@ -149,7 +265,7 @@ func GetCmdListContractByCode() *cobra.Command {
 		Aliases: []string{"list-contracts-by-code", "list-contracts", "contracts", "lca"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
@@ -166,12 +282,27 @@ func GetCmdListContractByCode() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			startAfter, err := cmd.Flags().GetString(flagStartAfter)
+			if err != nil {
+				return err
+			}
+			suspendedOnly, err := cmd.Flags().GetBool(flagSuspendedOnly)
+			if err != nil {
+				return err
+			}
+			if startAfter != "" {
+				if startAfter, err = translateAddress(cmd, startAfter); err != nil {
+					return err
+				}
+			}
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.ContractsByCode(
 				context.Background(),
 				&types.QueryContractsByCodeRequest{
-					CodeId:     codeID,
-					Pagination: pageReq,
+					CodeId:            codeID,
+					Pagination:        pageReq,
+					StartAfterAddress: startAfter,
+					SuspendedOnly:     suspendedOnly,
 				},
 			)
 			if err != nil {
@@ -181,6 +312,8 @@ func GetCmdListContractByCode() *cobra.Command {
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().String(flagStartAfter, "", "Resume the listing after the given contract address. Can not be combined with --page-key")
+	cmd.Flags().Bool(flagSuspendedOnly, false, "List suspended contracts only")
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "list contracts by code")
 	return cmd
@@ -191,11 +324,11 @@ func GetCmdQueryCode() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "code [code_id] [output filename]",
 		Short:   "Downloads wasm bytecode for given code id",
-		Long:    "Downloads wasm bytecode for given code id",
+		Long:    "Downloads wasm bytecode for given code id. The sha256 checksum of the bytecode is verified against the checksum of the code info. Use - as filename to write the bytecode to stdout",
 		Aliases: []string{"source-code", "source"},
 		Args:    cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
@@ -204,39 +337,63 @@ func GetCmdQueryCode() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			noVerify, err := cmd.Flags().GetBool(flagNoVerify)
+			if err != nil {
+				return err
+			}
 
 			queryClient := types.NewQueryClient(clientCtx)
-			res, err := queryClient.Code(
-				context.Background(),
-				&types.QueryCodeRequest{
-					CodeId: codeID,
-				},
-			)
+			data, err := queryCodeVerified(context.Background(), queryClient, codeID, !noVerify)
 			if err != nil {
 				return err
 			}
-			if len(res.Data) == 0 {
-				return errors.New("contract not found")
+			if args[1] == "-" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
 			}
-
 			fmt.Printf("Downloading wasm code to %s\n", args[1])
-			return os.WriteFile(args[1], res.Data, 0o600)
+			return os.WriteFile(args[1], data, 0o600)
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().Bool(flagNoVerify, false, "Skip the checksum verification of the downloaded bytecode")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
+// queryCodeVerified returns the bytecode of the code. With verify the sha256 checksum of the bytecode must match the
+// checksum of the code info.
+func queryCodeVerified(ctx context.Context, queryClient types.QueryClient, codeID uint64, verify bool) ([]byte, error) {
+	res, err := queryClient.Code(ctx, &types.QueryCodeRequest{CodeId: codeID})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Data) == 0 {
+		return nil, errors.New("contract not found")
+	}
+	if !verify {
+		return res.Data, nil
+	}
+	info, err := queryClient.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: codeID})
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(res.Data)
+	if !bytes.Equal(checksum[:], info.Checksum) {
+		return nil, fmt.Errorf("checksum mismatch for code %d: downloaded bytecode has %X but the code info has %X", codeID, checksum[:], []byte(info.Checksum))
+	}
+	return res.Data, nil
+}
+
 // GetCmdQueryCodeInfo returns the code info for a given code id
 func GetCmdQueryCodeInfo() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "code-info [code_id]",
 		Short: "Prints out metadata of a code id",
-		Long:  "Prints out metadata of a code id",
+		Long:  "Prints out metadata of a code id, including the instantiate permission and the origin the code was uploaded through",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
@@ -265,6 +422,38 @@ func GetCmdQueryCodeInfo() *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryCodeByChecksum returns the code info for a given checksum
+func GetCmdQueryCodeByChecksum() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "code-by-checksum [hex_checksum]",
+		Short: "Prints out metadata of the code with the sha256 checksum",
+		Long:  "Prints out metadata of the code with the sha256 checksum and the ids of all codes with the same checksum",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeByChecksum(
+				context.Background(),
+				&types.QueryCodeByChecksumRequest{
+					Checksum: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdGetContractInfo gets details about a given contract
 func GetCmdGetContractInfo() *cobra.Command {
 	cmd := &cobra.Command{
@@ -274,33 +463,84 @@ func GetCmdGetContractInfo() *cobra.Command {
 		Aliases: []string{"meta", "c"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			_, err = sdk.AccAddressFromBech32(args[0])
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			full, err := cmd.Flags().GetBool(flagFull)
 			if err != nil {
 				return err
 			}
+			ctx := context.Background()
+			if full {
+				if clientCtx, ctx, err = pinQueryHeight(cmd.Context(), clientCtx); err != nil {
+					return err
+				}
+			}
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.ContractInfo(
-				context.Background(),
+				ctx,
 				&types.QueryContractInfoRequest{
-					Address: args[0],
+					Address: addr,
 				},
 			)
 			if err != nil {
 				return err
 			}
-			return clientCtx.PrintProto(res)
+			if !full {
+				return clientCtx.PrintProto(res)
+			}
+			receiptRes, err := queryClient.ContractExecutionReceipt(
+				ctx,
+				&types.QueryContractExecutionReceiptRequest{
+					Address: addr,
+				},
+			)
+			var receipt *types.ExecutionReceipt
+			switch {
+			case err == nil:
+				receipt = &receiptRes.Receipt
+			case status.Code(err) != codes.NotFound:
+				return err
+			}
+			bz, err := buildFullContractInfo(clientCtx.Codec, res, receipt)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintRaw(bz)
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().Bool(flagFull, false, "Include the receipt of the last contract execution in the output")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
+// buildFullContractInfo returns the contract info json with the execution receipt added. The receipt is
+// null when none was recorded.
+func buildFullContractInfo(cdc codec.JSONCodec, res *types.QueryContractInfoResponse, receipt *types.ExecutionReceipt) (json.RawMessage, error) {
+	infoBz, err := cdc.MarshalJSON(res)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]json.RawMessage{}
+	if err := json.Unmarshal(infoBz, &result); err != nil {
+		return nil, err
+	}
+	result["execution_receipt"] = json.RawMessage("null")
+	if receipt != nil {
+		if result["execution_receipt"], err = cdc.MarshalJSON(receipt); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(result)
+}
+
 // GetCmdGetContractState dumps full internal state of a given contract
 func GetCmdGetContractState() *cobra.Command {
 	cmd := &cobra.Command{
@@ -316,23 +556,26 @@ func GetCmdGetContractState() *cobra.Command {
 		GetCmdGetContractStateAll(),
 		GetCmdGetContractStateRaw(),
 		GetCmdGetContractStateSmart(),
+		GetCmdGetContractStateSmartBatch(),
 	)
 	return cmd
 }
 
 func GetCmdGetContractStateAll() *cobra.Command {
+	decoder := newArgDecoder(hex.DecodeString)
 	cmd := &cobra.Command{
 		Use:   "all [bech32_address]",
 		Short: "Prints out all internal state of a contract given its address",
-		Long:  "Prints out all internal state of a contract given its address",
-		Args:  cobra.ExactArgs(1),
+		Long: `Prints out all internal state of a contract given its address. With --prefix only the keys that start
+with the prefix are returned, for example all entries of a cw-storage-plus map.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			_, err = sdk.AccAddressFromBech32(args[0])
+			addr, err := translateAddress(cmd, args[0])
 			if err != nil {
 				return err
 			}
@@ -341,26 +584,63 @@ func GetCmdGetContractStateAll() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			keysOnly, err := cmd.Flags().GetBool(flagKeysOnly)
+			if err != nil {
+				return err
+			}
+			prefixArg, err := cmd.Flags().GetString(flagPrefix)
+			if err != nil {
+				return err
+			}
+			var keyPrefix []byte
+			if prefixArg != "" {
+				if keyPrefix, err = decoder.DecodeString(prefixArg); err != nil {
+					return fmt.Errorf("decode prefix: %s", err)
+				}
+			}
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.AllContractState(
 				context.Background(),
 				&types.QueryAllContractStateRequest{
-					Address:    args[0],
+					Address:    addr,
 					Pagination: pageReq,
+					KeysOnly:   keysOnly,
+					Prefix:     keyPrefix,
 				},
 			)
 			if err != nil {
 				return err
 			}
+			if keysOnly && clientCtx.OutputFormat == flags.OutputFormatText {
+				return printStateKeys(cmd.OutOrStdout(), cmd.ErrOrStderr(), res)
+			}
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().Bool(flagKeysOnly, false, "Print only the keys in hex, one per line")
+	cmd.Flags().String(flagPrefix, "", "Return only the keys that start with this prefix")
+	decoder.RegisterFlags(cmd.PersistentFlags(), "prefix")
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "contract state")
 	return cmd
 }
 
+// printStateKeys prints the hex encoded keys of the response one per line. The base64 encoded key of the
+// next page is printed to the error output so that the key list can be processed as is.
+func printStateKeys(out, errOut io.Writer, res *types.QueryAllContractStateResponse) error {
+	for _, m := range res.Models {
+		if _, err := fmt.Fprintln(out, hex.EncodeToString(m.Key)); err != nil {
+			return err
+		}
+	}
+	if res.Pagination != nil && len(res.Pagination.NextKey) != 0 {
+		_, err := fmt.Fprintf(errOut, "next page key: %s\n", base64.StdEncoding.EncodeToString(res.Pagination.NextKey))
+		return err
+	}
+	return nil
+}
+
 func GetCmdGetContractStateRaw() *cobra.Command {
 	decoder := newArgDecoder(hex.DecodeString)
 	cmd := &cobra.Command{
@@ -369,12 +649,12 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 		Long:  "Prints out internal state for of a contract given its address",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			_, err = sdk.AccAddressFromBech32(args[0])
+			addr, err := translateAddress(cmd, args[0])
 			if err != nil {
 				return err
 			}
@@ -383,26 +663,60 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 				return err
 			}
 
+			decode, err := cmd.Flags().GetBool(flagDecode)
+			if err != nil {
+				return err
+			}
+
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.RawContractState(
 				context.Background(),
 				&types.QueryRawContractStateRequest{
-					Address:   args[0],
+					Address:   addr,
 					QueryData: queryData,
 				},
 			)
 			if err != nil {
 				return err
 			}
+			if decode && isJSONData(res.Data) {
+				return printDecodedData(cmd.OutOrStdout(), clientCtx.OutputFormat, res.Data)
+			}
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
 	decoder.RegisterFlags(cmd.PersistentFlags(), "key argument")
+	cmd.Flags().Bool(flagDecode, false, "Print the result data as indented json instead of base64. Falls back to base64 when the data is not json")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
+// isJSONData returns true when the data is valid utf-8 encoded json
+func isJSONData(data []byte) bool {
+	return len(data) != 0 && utf8.Valid(data) && json.Valid(data)
+}
+
+// printDecodedData prints the json data indented. With json output, the data is embedded as object in the response.
+func printDecodedData(out io.Writer, outputFormat string, data []byte) error {
+	if outputFormat == flags.OutputFormatJSON {
+		bz, err := json.Marshal(struct {
+			Data json.RawMessage `json:"data"`
+		}{Data: data})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(bz))
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(out, buf.String())
+	return err
+}
+
 func GetCmdGetContractStateSmart() *cobra.Command {
 	decoder := newArgDecoder(asciiDecodeString)
 	cmd := &cobra.Command{
@@ -411,12 +725,12 @@ func GetCmdGetContractStateSmart() *cobra.Command {
 		Long:  "Calls contract with given address with query data and prints the returned result",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			_, err = sdk.AccAddressFromBech32(args[0])
+			addr, err := translateAddress(cmd, args[0])
 			if err != nil {
 				return err
 			}
@@ -432,41 +746,70 @@ func GetCmdGetContractStateSmart() *cobra.Command {
 				return errors.New("query data must be json")
 			}
 
+			decode, err := cmd.Flags().GetBool(flagDecode)
+			if err != nil {
+				return err
+			}
+			withDiagnostics, err := cmd.Flags().GetBool(flagDiagnostics)
+			if err != nil {
+				return err
+			}
+
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.SmartContractState(
 				context.Background(),
 				&types.QuerySmartContractStateRequest{
-					Address:   args[0],
-					QueryData: queryData,
+					Address:         addr,
+					QueryData:       queryData,
+					WithDiagnostics: withDiagnostics,
 				},
 			)
 			if err != nil {
 				return err
 			}
+			if res.Diagnostics != nil {
+				if err := printSmartQueryDiagnostics(cmd.ErrOrStderr(), res.Diagnostics); err != nil {
+					return err
+				}
+				res.Diagnostics = nil
+			}
+			if decode && isJSONData(res.Data) {
+				return printDecodedData(cmd.OutOrStdout(), clientCtx.OutputFormat, res.Data)
+			}
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
 	decoder.RegisterFlags(cmd.PersistentFlags(), "query argument")
+	cmd.Flags().Bool(flagDecode, false, "Print the result data as indented json instead of base64. Falls back to base64 when the data is not json")
+	cmd.Flags().Bool(flagDiagnostics, false, "Print node local diagnostics of the query execution to stderr")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
+// printSmartQueryDiagnostics prints the diagnostics separate from the query result so that the output stays parsable
+func printSmartQueryDiagnostics(out io.Writer, d *types.SmartQueryDiagnostics) error {
+	_, err := fmt.Fprintf(out, "pinned: %t\ngas used: %d\nwall time: %s\n", d.Pinned, d.GasUsed, time.Duration(d.WallTimeMicros)*time.Microsecond)
+	return err
+}
+
 // GetCmdGetContractHistory prints the code history for a given contract
 func GetCmdGetContractHistory() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "contract-history [bech32_address]",
-		Short:   "Prints out the code history for a contract given its address",
-		Long:    "Prints out the code history for a contract given its address",
+		Use:   "contract-history [bech32_address]",
+		Short: "Prints out the code history for a contract given its address",
+		Long: `Prints out the code history for a contract given its address.
+The msg of each entry is printed as json. Legacy entries with a msg that is not json are printed base64 encoded.
+Use --raw to print the msgs of the proto output unchanged.`,
 		Aliases: []string{"history", "hist", "ch"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			_, err = sdk.AccAddressFromBech32(args[0])
+			addr, err := translateAddress(cmd, args[0])
 			if err != nil {
 				return err
 			}
@@ -479,33 +822,55 @@ func GetCmdGetContractHistory() *cobra.Command {
 			res, err := queryClient.ContractHistory(
 				context.Background(),
 				&types.QueryContractHistoryRequest{
-					Address:    args[0],
+					Address:    addr,
 					Pagination: pageReq,
 				},
 			)
 			if err != nil {
 				return err
 			}
-
+			raw, err := cmd.Flags().GetBool(flagRaw)
+			if err != nil {
+				return err
+			}
+			if !raw {
+				encodeLegacyHistoryMsgs(res.Entries)
+			}
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
 
+	cmd.Flags().Bool(flagRaw, false, "Print the msgs of the proto output unchanged, without encoding legacy msgs that are not json")
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "contract history")
 	return cmd
 }
 
+// encodeLegacyHistoryMsgs replaces the msgs of the history entries that are not json, like legacy entries stored
+// before the validation, with the base64 encoded msg as json string. All other msgs are printed as json.
+func encodeLegacyHistoryMsgs(entries []types.ContractCodeHistoryEntry) {
+	for i, e := range entries {
+		if len(e.Msg) == 0 || isJSONData(e.Msg) {
+			continue
+		}
+		bz, err := json.Marshal([]byte(e.Msg))
+		if err != nil {
+			panic(err) // can not happen for bytes
+		}
+		entries[i].Msg = bz
+	}
+}
+
 // GetCmdListPinnedCode lists all wasm code ids that are pinned
 func GetCmdListPinnedCode() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pinned",
-		Short: "List all pinned code ids",
-		Long:  "List all pinned code ids",
+		Short: "List all pinned code ids with their code info",
+		Long:  "List all pinned code ids with their code info. The code info is null when it does not exist for a pinned code id",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
@@ -519,6 +884,7 @@ func GetCmdListPinnedCode() *cobra.Command {
 				context.Background(),
 				&types.QueryPinnedCodesRequest{
 					Pagination: pageReq,
+					Verbose:    true,
 				},
 			)
 			if err != nil {
@@ -533,6 +899,43 @@ func GetCmdListPinnedCode() *cobra.Command {
 	return cmd
 }
 
+// GetCmdListCodeUploadApprovals lists the code checksums pre-approved by governance
+func GetCmdListCodeUploadApprovals() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "code-upload-approvals",
+		Short:   "List all code checksums pre-approved for upload by governance",
+		Long:    "List all code checksums pre-approved by governance that anybody can upload, even when the upload access would deny the sender",
+		Aliases: []string{"upload-approvals"},
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeUploadApprovals(
+				context.Background(),
+				&types.QueryCodeUploadApprovalsRequest{
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list code upload approvals")
+	return cmd
+}
+
 // GetCmdListContractsByCreator lists all contracts by creator
 func GetCmdListContractsByCreator() *cobra.Command {
 	cmd := &cobra.Command{
@@ -541,11 +944,11 @@ func GetCmdListContractsByCreator() *cobra.Command {
 		Long:  "List all contracts by creator",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
-			_, err = sdk.AccAddressFromBech32(args[0])
+			addr, err := translateAddress(cmd, args[0])
 			if err != nil {
 				return err
 			}
@@ -553,114 +956,652 @@ func GetCmdListContractsByCreator() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			withInfo, err := cmd.Flags().GetBool(flagWithInfo)
+			if err != nil {
+				return err
+			}
+			suspendedOnly, err := cmd.Flags().GetBool(flagSuspendedOnly)
+			if err != nil {
+				return err
+			}
 
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.ContractsByCreator(
 				context.Background(),
 				&types.QueryContractsByCreatorRequest{
-					CreatorAddress: args[0],
+					CreatorAddress: addr,
 					Pagination:     pageReq,
+					WithInfo:       withInfo,
+					SuspendedOnly:  suspendedOnly,
 				},
 			)
 			if err != nil {
 				return err
 			}
+			if withInfo && clientCtx.OutputFormat == flags.OutputFormatText {
+				return printContractInfoTable(cmd.OutOrStdout(), res)
+			}
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().Bool(flagWithInfo, false, "Print a table with the label, code id and creation height of every contract")
+	cmd.Flags().Bool(flagSuspendedOnly, false, "List suspended contracts only")
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "list contracts by creator")
 	return cmd
 }
 
-type argumentDecoder struct {
-	// dec is the default decoder
-	dec                func(string) ([]byte, error)
-	asciiF, hexF, b64F bool
-}
-
-func newArgDecoder(def func(string) ([]byte, error)) *argumentDecoder {
-	return &argumentDecoder{dec: def}
-}
-
-func (a *argumentDecoder) RegisterFlags(f *flag.FlagSet, argName string) {
-	f.BoolVar(&a.asciiF, "ascii", false, "ascii encoded "+argName)
-	f.BoolVar(&a.hexF, "hex", false, "hex encoded "+argName)
-	f.BoolVar(&a.b64F, "b64", false, "base64 encoded "+argName)
-}
-
-func (a *argumentDecoder) DecodeString(s string) ([]byte, error) {
-	found := -1
-	for i, v := range []*bool{&a.asciiF, &a.hexF, &a.b64F} {
-		if !*v {
-			continue
-		}
-		if found != -1 {
-			return nil, errors.New("multiple decoding flags used")
-		}
-		found = i
-	}
-	switch found {
-	case 0:
-		return asciiDecodeString(s)
-	case 1:
-		return hex.DecodeString(s)
-	case 2:
-		return base64.StdEncoding.DecodeString(s)
-	default:
-		return a.dec(s)
-	}
-}
-
-func asciiDecodeString(s string) ([]byte, error) {
-	return []byte(s), nil
-}
-
-// sdk ReadPageRequest expects binary but we encoded to base64 in our marshaller
-func withPageKeyDecoded(flagSet *flag.FlagSet) *flag.FlagSet {
-	encoded, err := flagSet.GetString(flags.FlagPageKey)
-	if err != nil {
-		panic(err.Error())
-	}
-	raw, err := base64.StdEncoding.DecodeString(encoded)
-	if err != nil {
-		panic(err.Error())
-	}
-	err = flagSet.Set(flags.FlagPageKey, string(raw))
-	if err != nil {
-		panic(err.Error())
-	}
-	return flagSet
-}
-
-// GetCmdQueryParams implements a command to return the current wasm
-// parameters.
-func GetCmdQueryParams() *cobra.Command {
+// GetCmdListContractsByAdmin lists all contracts by admin
+func GetCmdListContractsByAdmin() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "params",
-		Short: "Query the current wasm parameters",
-		Args:  cobra.NoArgs,
+		Use:   "list-contracts-by-admin [bech32_address]",
+		Short: "List all contracts by admin",
+		Long:  "List all contracts with the given admin ordered by contract address. Contracts without an admin are never listed",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := getClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
-			queryClient := types.NewQueryClient(clientCtx)
-
-			params := &types.QueryParamsRequest{}
-			res, err := queryClient.Params(cmd.Context(), params)
+			addr, err := translateAddress(cmd, args[0])
 			if err != nil {
 				return err
 			}
-
-			return clientCtx.PrintProto(&res.Params)
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			suspendedOnly, err := cmd.Flags().GetBool(flagSuspendedOnly)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByAdmin(
+				context.Background(),
+				&types.QueryContractsByAdminRequest{
+					AdminAddress:  addr,
+					Pagination:    pageReq,
+					SuspendedOnly: suspendedOnly,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagSuspendedOnly, false, "List suspended contracts only")
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list contracts by admin")
+	return cmd
+}
+
+// GetCmdScheduledSudos lists the pending scheduled sudo calls of a contract
+func GetCmdScheduledSudos() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "scheduled-sudos [bech32_address]",
+		Short:   "List the pending scheduled sudo calls of a contract",
+		Long:    "List the sudo calls that the contract scheduled on itself for a future block ordered by id",
+		Aliases: []string{"scheduled"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ScheduledSudos(
+				context.Background(),
+				&types.QueryScheduledSudosRequest{
+					Address:    addr,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "scheduled sudo calls")
+	return cmd
+}
+
+// GetCmdContractChannelStatus shows the packet sequences and the state of a channel of a contract
+func GetCmdContractChannelStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "channel-status [bech32_address] [channel_id]",
+		Short: "Show the packet sequences and the state of a channel of a contract",
+		Long: `Show the state, the next packet sequences and the number of pending packet commitments of a channel
+of the contract port. The next receive and acknowledgement sequences are only set for ordered channels.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractChannelStatus(
+				context.Background(),
+				&types.QueryContractChannelStatusRequest{
+					Address:   addr,
+					ChannelID: args[1],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdListContractsByLabel lists all contracts with the label
+func GetCmdListContractsByLabel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-contracts-by-label [label]",
+		Short: "List all contracts with the label",
+		Long:  "List all contracts with the label ordered by label and address. With --prefix all contracts with a label that starts with the given label are listed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			prefixMatch, err := cmd.Flags().GetBool(flagPrefix)
+			if err != nil {
+				return err
+			}
+			suspendedOnly, err := cmd.Flags().GetBool(flagSuspendedOnly)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByLabel(
+				context.Background(),
+				&types.QueryContractsByLabelRequest{
+					Label:         args[0],
+					Pagination:    pageReq,
+					Prefix:        prefixMatch,
+					SuspendedOnly: suspendedOnly,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagPrefix, false, "Match all labels that start with the given label")
+	cmd.Flags().Bool(flagSuspendedOnly, false, "List suspended contracts only")
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list contracts by label")
+	return cmd
+}
+
+// GetCmdListContractsByTag lists all contracts with the tag
+func GetCmdListContractsByTag() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-contracts-by-tag [tag]",
+		Short: "List all contracts with the tag",
+		Long:  "List all contracts with the tag ordered by address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			suspendedOnly, err := cmd.Flags().GetBool(flagSuspendedOnly)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByTag(
+				context.Background(),
+				&types.QueryContractsByTagRequest{
+					Tag:           args[0],
+					Pagination:    pageReq,
+					SuspendedOnly: suspendedOnly,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagSuspendedOnly, false, "List suspended contracts only")
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list contracts by tag")
+	return cmd
+}
+
+// printContractInfoTable prints the contract infos of the response as a table, followed by the
+// base64 encoded key of the next page when there is one
+func printContractInfoTable(out io.Writer, res *types.QueryContractsByCreatorResponse) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ADDRESS\tLABEL\tCODE_ID\tCREATED_HEIGHT")
+	for _, c := range res.ContractInfos {
+		var height uint64
+		if c.Created != nil {
+			height = c.Created.BlockHeight
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", c.Address, c.Label, c.CodeID, height)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if res.Pagination != nil && len(res.Pagination.NextKey) != 0 {
+		_, err := fmt.Fprintf(out, "next page key: %s\n", base64.StdEncoding.EncodeToString(res.Pagination.NextKey))
+		return err
+	}
+	return nil
+}
+
+// GetCmdContractChildren lists all contracts instantiated by a contract
+func GetCmdContractChildren() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-children [bech32_address]",
+		Short: "List all contracts instantiated by the contract",
+		Long:  "List all contracts instantiated by the contract. Only contracts created via contract dispatch are tracked",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractChildren(
+				context.Background(),
+				&types.QueryContractChildrenRequest{
+					Address:    addr,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "contract children")
+	return cmd
+}
+
+// GetCmdContractParent gets the contract that instantiated a contract
+func GetCmdContractParent() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-parent [bech32_address]",
+		Short: "Get the contract that instantiated the contract",
+		Long:  "Get the contract that instantiated the contract. The parent is empty for contracts that were not created via contract dispatch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractParent(
+				context.Background(),
+				&types.QueryContractParentRequest{
+					Address: addr,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAliasedSmart calls the contract with the smart query registered under the alias name
+func GetCmdQueryAliasedSmart() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "smart-alias [bech32_address] [name]",
+		Short: "Calls contract with the query registered under the alias name and prints the returned result",
+		Long:  "Calls contract with the query registered under the alias name and prints the returned result",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.AliasedSmartContractState(
+				context.Background(),
+				&types.QueryAliasedSmartContractStateRequest{
+					Address: addr,
+					Name:    args[1],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdListQueryAliases lists the query aliases of a contract
+func GetCmdListQueryAliases() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-aliases [bech32_address]",
+		Short: "List the query aliases of a contract",
+		Long:  "List the query aliases of a contract",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.QueryAliases(
+				context.Background(),
+				&types.QueryQueryAliasesRequest{
+					Address:    addr,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "query aliases")
+	return cmd
+}
+
+// getClientQueryContext returns the query client context. With the --at-latest-committed flag, the
+// height is pinned to the latest committed height.
+func getClientQueryContext(cmd *cobra.Command) (client.Context, error) {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return clientCtx, err
+	}
+	// the flag is not registered when the command is used outside the wasm query command
+	if f := cmd.Flags().Lookup(flagAtLatestCommitted); f == nil || f.Value.String() != "true" {
+		return clientCtx, nil
+	}
+	if cmd.Flags().Changed(flags.FlagHeight) {
+		return clientCtx, fmt.Errorf("--%s can not be combined with --%s", flagAtLatestCommitted, flags.FlagHeight)
+	}
+	clientCtx, _, err = pinQueryHeight(cmd.Context(), clientCtx)
+	if err != nil {
+		return clientCtx, err
+	}
+	cmd.PrintErrf("querying at height %d\n", clientCtx.Height)
+	return clientCtx, nil
+}
+
+// pinQueryHeight returns the client context and go context that all queries of a command invocation must use,
+// so that commands with multiple queries read a consistent state. An explicitly set height is kept, otherwise
+// the latest committed height is queried from the node once. The go context carries the height header, which
+// the gRPC client requires as it does not read the height of the client context.
+func pinQueryHeight(ctx context.Context, clientCtx client.Context) (client.Context, context.Context, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	height := clientCtx.Height
+	if height == 0 {
+		node, err := clientCtx.GetNode()
+		if err != nil {
+			return clientCtx, ctx, err
+		}
+		status, err := node.Status(ctx)
+		if err != nil {
+			return clientCtx, ctx, err
+		}
+		height = status.SyncInfo.LatestBlockHeight
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+	return clientCtx.WithHeight(height), ctx, nil
+}
+
+// translateAddress returns the bech32 address with the chain prefix. When the --bech32-prefix flag is set,
+// the address is decoded with the given prefix and re-encoded with the chain prefix. The address bytes are not modified.
+func translateAddress(cmd *cobra.Command, addr string) (string, error) {
+	// the flag is not registered when the command is used outside the wasm query command
+	prefix, err := cmd.Flags().GetString(flagBech32Prefix)
+	if err != nil || prefix == "" {
+		_, err := sdk.AccAddressFromBech32(addr)
+		return addr, err
+	}
+	bz, err := sdk.GetFromBech32(addr, prefix)
+	if err != nil {
+		return "", err
+	}
+	if len(bz) != types.SDKAddrLen && len(bz) != types.ContractAddrLen {
+		return "", fmt.Errorf("unexpected address length %d", len(bz))
+	}
+	translated := sdk.AccAddress(bz).String()
+	if translated != addr {
+		cmd.PrintErrf("translated address %s to %s\n", addr, translated)
+	}
+	return translated, nil
+}
+
+type argumentDecoder struct {
+	// dec is the default decoder
+	dec                func(string) ([]byte, error)
+	asciiF, hexF, b64F bool
+}
+
+func newArgDecoder(def func(string) ([]byte, error)) *argumentDecoder {
+	return &argumentDecoder{dec: def}
+}
+
+func (a *argumentDecoder) RegisterFlags(f *flag.FlagSet, argName string) {
+	f.BoolVar(&a.asciiF, "ascii", false, "ascii encoded "+argName)
+	f.BoolVar(&a.hexF, "hex", false, "hex encoded "+argName)
+	f.BoolVar(&a.b64F, "b64", false, "base64 encoded "+argName)
+}
+
+// decodeSalt decodes the salt argument and ensures that it has a valid length
+func decodeSalt(decoder *argumentDecoder, s string) ([]byte, error) {
+	salt, err := decoder.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("salt: %w (use --hex, --ascii or --b64 to set the encoding)", err)
+	}
+	if err := types.ValidateSalt(salt); err != nil {
+		return nil, fmt.Errorf("salt %q: %w", s, err)
+	}
+	return salt, nil
+}
+
+func (a *argumentDecoder) DecodeString(s string) ([]byte, error) {
+	found := -1
+	for i, v := range []*bool{&a.asciiF, &a.hexF, &a.b64F} {
+		if !*v {
+			continue
+		}
+		if found != -1 {
+			return nil, errors.New("multiple decoding flags used")
+		}
+		found = i
+	}
+	switch found {
+	case 0:
+		return asciiDecodeString(s)
+	case 1:
+		return hex.DecodeString(s)
+	case 2:
+		return base64.StdEncoding.DecodeString(s)
+	default:
+		return a.dec(s)
+	}
+}
+
+func asciiDecodeString(s string) ([]byte, error) {
+	return []byte(s), nil
+}
+
+// sdk ReadPageRequest expects binary but we encoded to base64 in our marshaller
+func withPageKeyDecoded(flagSet *flag.FlagSet) *flag.FlagSet {
+	encoded, err := flagSet.GetString(flags.FlagPageKey)
+	if err != nil {
+		panic(err.Error())
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		panic(err.Error())
+	}
+	err = flagSet.Set(flags.FlagPageKey, string(raw))
+	if err != nil {
+		panic(err.Error())
+	}
+	return flagSet
+}
+
+// GetCmdQueryParams implements a command to return the current wasm
+// parameters.
+func GetCmdQueryParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the current wasm parameters",
+		Long:  "Query the current wasm parameters. With --changelog, the latest params changes with the old and new values are returned instead, the oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+
+			changelog, err := cmd.Flags().GetBool(flagChangelog)
+			if err != nil {
+				return err
+			}
+			if changelog {
+				res, err := queryClient.ParamsHistory(cmd.Context(), &types.QueryParamsHistoryRequest{})
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintProto(res)
+			}
+
+			params := &types.QueryParamsRequest{}
+			res, err := queryClient.Params(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(&res.Params)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().Bool(flagChangelog, false, "Query the history of the params changes")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdWasmStats gets the number of stored codes and instantiated contracts
+func GetCmdWasmStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats [code_id]",
+		Short: "Query the number of stored codes and instantiated contracts",
+		Long:  "Query the number of stored codes and instantiated contracts. With a code id, the number of contracts that currently run the code is returned, too",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			var codeID uint64
+			if len(args) == 1 {
+				codeID, err = strconv.ParseUint(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.WasmStats(
+				context.Background(),
+				&types.QueryWasmStatsRequest{CodeID: codeID},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
-
 	flags.AddQueryFlagsToCmd(cmd)
-
 	return cmd
 }
 
@@ -670,3 +1611,360 @@ func addPaginationFlags(cmd *cobra.Command, query string) {
 	cmd.Flags().Uint64(flags.FlagLimit, 100, fmt.Sprintf("pagination limit of %s to query for", query))
 	cmd.Flags().Bool(flags.FlagReverse, false, "results are sorted in descending order")
 }
+
+// GetCmdContractUsage gets the invocation counters of the contract entry points
+func GetCmdContractUsage() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage [bech32_address]",
+		Short: "Get the invocation counters of the contract entry points",
+		Long:  "Get the number of invocations of each contract entry point since the contract was created",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractUsage(
+				context.Background(),
+				&types.QueryContractUsageRequest{
+					Address: addr,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdContractFeeSponsorship gets the fee sponsorship budgets of a contract
+func GetCmdContractFeeSponsorship() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-sponsorship [bech32_address]",
+		Short: "Get the fee sponsorship budgets of a contract",
+		Long:  "Get the budgets for the fees that a contract pays and the fees that it paid in the current block",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractFeeSponsorship(
+				context.Background(),
+				&types.QueryContractFeeSponsorshipRequest{
+					Address: addr,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdAddressType gets whether the address is a contract, a module account, another account or not found
+func GetCmdAddressType() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "address-type [bech32_address]",
+		Short: "Get whether the address is a contract, a module account, another account or not found",
+		Long:  "Get whether the address is a contract, a module account, another account or not found. The code id is returned for contracts",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.AddressType(
+				context.Background(),
+				&types.QueryAddressTypeRequest{
+					Address: addr,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdContractExecutionReceipt gets the receipt of the last execution of the contract
+func GetCmdContractExecutionReceipt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execution-receipt [bech32_address]",
+		Short: "Get the receipt of the last execution of the contract",
+		Long:  "Get the height, kind, gas used and result of the last execution of the contract. Receipts are only recorded when enabled in the module params",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractExecutionReceipt(
+				context.Background(),
+				&types.QueryContractExecutionReceiptRequest{
+					Address: addr,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdContractStateDiff lists the changed keys of the contract state between two heights
+func GetCmdContractStateDiff() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff [bech32_address] [height_a] [height_b]",
+		Short: "Lists the changed keys of the contract state between two heights",
+		Long: `Lists the added, removed and changed keys of the contract state between two heights with the value sizes.
+The node must have the state of both heights available, for example an archive node.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			heightA, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("height a: %w", err)
+			}
+			heightB, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("height b: %w", err)
+			}
+			includeValues, err := cmd.Flags().GetBool(flagIncludeValues)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractStateDiff(
+				context.Background(),
+				&types.QueryContractStateDiffRequest{
+					Address:       addr,
+					HeightA:       heightA,
+					HeightB:       heightB,
+					IncludeValues: includeValues,
+					Pagination:    pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagIncludeValues, false, "Include the values of both heights in the output")
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "contract state diff")
+	return cmd
+}
+
+// GetCmdGasConstants gets the constants of the event gas costs and estimates the gas for events of the given sizes
+func GetCmdGasConstants() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gas-constants",
+		Short: "Get the constants of the event gas costs and estimate the gas for the events of a contract response",
+		Long: `Get the constants that the gas for the events of a contract response is calculated with. The gas is
+estimated for the attributes and custom events given by the flags. Custom events are given as
+type_length:attribute_count:attribute_bytes where attribute_bytes is the total length of keys and values,
+for example: --attribute-count 2 --attribute-bytes 40 --event 8:3:120`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			attributeCount, err := cmd.Flags().GetUint64(flagAttributeCount)
+			if err != nil {
+				return err
+			}
+			attributeBytes, err := cmd.Flags().GetUint64(flagAttributeBytes)
+			if err != nil {
+				return err
+			}
+			rawEvents, err := cmd.Flags().GetStringArray(flagEvent)
+			if err != nil {
+				return err
+			}
+			events, err := parseEventSizes(rawEvents)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.EstimateEventGas(
+				context.Background(),
+				&types.QueryEstimateEventGasRequest{
+					AttributeCount: attributeCount,
+					AttributeBytes: attributeBytes,
+					Events:         events,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagAttributeCount, 0, "Number of attributes of the contract response")
+	cmd.Flags().Uint64(flagAttributeBytes, 0, "Total length of the keys and values of the attributes of the contract response")
+	cmd.Flags().StringArray(flagEvent, []string{}, "Custom event as type_length:attribute_count:attribute_bytes, can be repeated")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseEventSizes parses the type_length:attribute_count:attribute_bytes event sizes
+func parseEventSizes(src []string) ([]types.EventSize, error) {
+	result := make([]types.EventSize, len(src))
+	for i, v := range src {
+		parts := strings.Split(v, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("event %q: expected type_length:attribute_count:attribute_bytes", v)
+		}
+		var values [3]uint64
+		for j, p := range parts {
+			var err error
+			if values[j], err = strconv.ParseUint(p, 10, 64); err != nil {
+				return nil, fmt.Errorf("event %q: %w", v, err)
+			}
+		}
+		result[i] = types.EventSize{TypeLength: values[0], AttributeCount: values[1], AttributeBytes: values[2]}
+	}
+	return result, nil
+}
+
+// GetCmdCodeAttestations lists the build attestations of a code
+func GetCmdCodeAttestations() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attestations [code_id]",
+		Short: "List the build attestations of a code",
+		Long:  "List the build attestations of a code. Attestations are unverified claims of the attesters",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			if codeID == 0 {
+				return errors.New("empty code id")
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeAttestations(
+				context.Background(),
+				&types.QueryCodeAttestationsRequest{
+					CodeId:     codeID,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "code attestations")
+	return cmd
+}
+
+// GetCmdCodeVerification shows the verification status and the verifier votes of a code
+func GetCmdCodeVerification() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "code-verification [code_id]",
+		Short: "Show the build reproducibility verification status of a code",
+		Long:  "Show the build reproducibility verification status of a code with the votes of the code verifiers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			if codeID == 0 {
+				return errors.New("empty code id")
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeVerification(
+				context.Background(),
+				&types.QueryCodeVerificationRequest{
+					CodeID: codeID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetCmdDevSnapshot writes the wasm genesis fragment with the listed contracts, their codes and full state
+func GetCmdDevSnapshot() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev-snapshot [contracts.txt] [out.json]",
+		Short: "Writes a wasm genesis fragment with the listed contracts of a node for local testing",
+		Long: `Downloads the code, contract info, history and full state of the listed contracts from the node and writes
+them as wasm genesis fragment. All queries run at a single height. The code checksums are verified.
+The contracts file contains one bech32 address per line. Empty lines and lines starting with # are ignored.
+The fragment can be imported into a fresh local chain with the --wasm-genesis-fragment flag of init or collect-gentxs.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			addrs, err := parseContractList(bz)
+			if err != nil {
+				return err
+			}
+			clientCtx, ctx, err := pinQueryHeight(cmd.Context(), clientCtx)
+			if err != nil {
+				return err
+			}
+			fragment, err := exportDevSnapshot(ctx, types.NewQueryClient(clientCtx), addrs)
+			if err != nil {
+				return err
+			}
+			if bz, err = clientCtx.Codec.MarshalJSON(fragment); err != nil {
+				return err
+			}
+			if err := os.WriteFile(args[1], bz, 0o600); err != nil {
+				return err
+			}
+			cmd.Printf("Wrote %d codes and %d contracts of height %d\n", len(fragment.Codes), len(fragment.Contracts), clientCtx.Height)
+			return nil
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseContractList returns the bech32 addresses of the contracts file. Empty lines and comments are skipped.
+func parseContractList(bz []byte) ([]string, error) {
+	var addrs []string
+	seen := make(map[string]struct{})
+	scanner := bufio.NewScanner(bytes.NewReader(bz))
+	for i := 1; scanner.Scan(); i++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := sdk.AccAddressFromBech32(line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i, err)
+		}
+		if _, ok := seen[line]; ok {
+			continue
+		}
+		seen[line] = struct{}{}
+		addrs = append(addrs, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New("no contracts")
+	}
+	return addrs, nil
+}
+
+// exportDevSnapshot builds a wasm genesis fragment with the contracts and the codes they use. The code sequence is
+// set after the highest code id. The params are not set.
+func exportDevSnapshot(ctx context.Context, queryClient types.QueryClient, addrs []string) (*types.GenesisState, error) {
+	var fragment types.GenesisState
+	codes := make(map[uint64]struct{})
+	for _, addr := range addrs {
+		infoRes, err := queryClient.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: addr})
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", addr, err)
+		}
+		contract := types.Contract{ContractAddress: addr, ContractInfo: infoRes.ContractInfo}
+		if contract.ContractState, err = queryFullContractState(ctx, queryClient, addr); err != nil {
+			return nil, fmt.Errorf("state of contract %s: %w", addr, err)
+		}
+		if contract.ContractCodeHistory, err = queryFullContractHistory(ctx, queryClient, addr); err != nil {
+			return nil, fmt.Errorf("history of contract %s: %w", addr, err)
+		}
+		fragment.Contracts = append(fragment.Contracts, contract)
+
+		if _, ok := codes[infoRes.CodeID]; ok {
+			continue
+		}
+		code, err := downloadVerifiedCode(ctx, queryClient, infoRes.CodeID)
+		if err != nil {
+			return nil, fmt.Errorf("code %d: %w", infoRes.CodeID, err)
+		}
+		codes[infoRes.CodeID] = struct{}{}
+		fragment.Codes = append(fragment.Codes, code)
+	}
+	sort.Slice(fragment.Codes, func(i, j int) bool { return fragment.Codes[i].CodeID < fragment.Codes[j].CodeID })
+	fragment.Sequences = []types.Sequence{
+		{IDKey: types.KeySequenceCodeID, Value: fragment.Codes[len(fragment.Codes)-1].CodeID + 1},
+		{IDKey: types.KeySequenceInstanceID, Value: nextFreeInstanceID(fragment)},
+	}
+	return &fragment, nil
+}
+
+// nextFreeInstanceID returns the lowest instance sequence value where the classic addresses of the next
+// instantiation with any of the codes do not collide with the contracts of the fragment.
+func nextFreeInstanceID(fragment types.GenesisState) uint64 {
+	contracts := make(map[string]struct{}, len(fragment.Contracts))
+	for _, c := range fragment.Contracts {
+		contracts[c.ContractAddress] = struct{}{}
+	}
+	collides := func(codeID, instanceID uint64) bool {
+		_, ok := contracts[keeper.BuildContractAddressClassic(codeID, instanceID).String()]
+		return ok
+	}
+	for instanceID := uint64(1); ; instanceID++ {
+		// genesis import checks the address of the instance id as code id
+		free := !collides(instanceID, instanceID)
+		for i := 0; free && i < len(fragment.Codes); i++ {
+			free = !collides(fragment.Codes[i].CodeID, instanceID)
+		}
+		if free {
+			return instanceID
+		}
+	}
+}
+
+// downloadVerifiedCode returns the code with the wasm byte code. The checksum of the byte code must match the code info.
+func downloadVerifiedCode(ctx context.Context, queryClient types.QueryClient, codeID uint64) (types.Code, error) {
+	codeRes, err := queryClient.Code(ctx, &types.QueryCodeRequest{CodeId: codeID})
+	if err != nil {
+		return types.Code{}, err
+	}
+	if codeRes.CodeInfoResponse == nil || len(codeRes.Data) == 0 {
+		return types.Code{}, errors.New("empty code")
+	}
+	checksum := sha256.Sum256(codeRes.Data)
+	if !bytes.Equal(checksum[:], codeRes.DataHash) {
+		return types.Code{}, fmt.Errorf("checksum mismatch: expected %s but got %X", codeRes.DataHash, checksum)
+	}
+	return types.Code{
+		CodeID: codeID,
+		CodeInfo: types.CodeInfo{
+			CodeHash:          codeRes.DataHash,
+			Creator:           codeRes.Creator,
+			InstantiateConfig: codeRes.InstantiatePermission,
+			Provenance:        codeRes.Provenance,
+			Origin:            codeRes.Origin,
+		},
+		CodeBytes: codeRes.Data,
+	}, nil
+}
+
+// queryFullContractHistory returns all code history entries of the contract by following the pagination
+func queryFullContractHistory(ctx context.Context, queryClient types.QueryClient, addr string) ([]types.ContractCodeHistoryEntry, error) {
+	var (
+		entries []types.ContractCodeHistoryEntry
+		nextKey []byte
+	)
+	for {
+		historyRes, err := queryClient.ContractHistory(ctx, &types.QueryContractHistoryRequest{
+			Address:    addr,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, historyRes.Entries...)
+		if historyRes.Pagination == nil || len(historyRes.Pagination.NextKey) == 0 {
+			return entries, nil
+		}
+		nextKey = historyRes.Pagination.NextKey
+	}
+}
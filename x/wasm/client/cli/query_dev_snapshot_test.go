@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestDevSnapshotRoundTrip(t *testing.T) {
+	// setup source chain with two contracts
+	srcCtx, srcKeepers := keeper.CreateTestInput(t, false, keeper.BuiltInCapabilities())
+	hackatom := keeper.InstantiateHackatomExampleContract(t, srcCtx, srcKeepers)
+	reflect := keeper.InstantiateReflectExampleContract(t, srcCtx, srcKeepers)
+	queryHelper := baseapp.NewQueryServerTestHelper(srcCtx, srcKeepers.EncodingConfig.InterfaceRegistry)
+	types.RegisterQueryServer(queryHelper, keeper.NewGrpcQuerier(srcKeepers.EncodingConfig.Codec, runtime.NewKVStoreService(srcKeepers.WasmStoreKey), srcKeepers.WasmKeeper, 3_000_000))
+	queryClient := types.NewQueryClient(queryHelper)
+
+	// when exported
+	addrs, err := parseContractList([]byte("# my contracts\n" + hackatom.Contract.String() + "\n\n" + reflect.Contract.String() + "\n" + hackatom.Contract.String() + "\n"))
+	require.NoError(t, err)
+	fragment, err := exportDevSnapshot(context.Background(), queryClient, addrs)
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, fragment.Codes, 2)
+	require.Len(t, fragment.Contracts, 2)
+	assert.Equal(t, []types.Sequence{
+		{IDKey: types.KeySequenceCodeID, Value: 3},
+		{IDKey: types.KeySequenceInstanceID, Value: 3},
+	}, fragment.Sequences)
+
+	// and when imported into a fresh local chain genesis
+	cdc := srcKeepers.EncodingConfig.Codec
+	dir := t.TempDir()
+	fragmentFile := filepath.Join(dir, "fragment.json")
+	bz, err := cdc.MarshalJSON(fragment)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(fragmentFile, bz, 0o600))
+	genFile := filepath.Join(dir, "genesis.json")
+	wasmGenesis, err := cdc.MarshalJSON(&types.GenesisState{Params: types.DefaultParams()})
+	require.NoError(t, err)
+	appState, err := json.Marshal(map[string]json.RawMessage{types.ModuleName: wasmGenesis})
+	require.NoError(t, err)
+	require.NoError(t, genutiltypes.NewAppGenesisWithVersion("testing", appState).SaveAs(genFile))
+	require.NoError(t, mergeGenesisFragmentFile(cdc, genFile, fragmentFile))
+
+	appGenesis, err := genutiltypes.AppGenesisFromFile(genFile)
+	require.NoError(t, err)
+	var gotAppState map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(appGenesis.AppState, &gotAppState))
+	var gotGenesis types.GenesisState
+	require.NoError(t, cdc.UnmarshalJSON(gotAppState[types.ModuleName], &gotGenesis))
+	dstCtx, dstKeepers := keeper.CreateTestInput(t, false, keeper.BuiltInCapabilities())
+	_, err = keeper.InitGenesis(dstCtx, dstKeepers.WasmKeeper, gotGenesis)
+	require.NoError(t, err)
+
+	// then the contracts and their state are the same
+	for _, addr := range []sdk.AccAddress{hackatom.Contract, reflect.Contract} {
+		assert.Equal(t, srcKeepers.WasmKeeper.GetContractInfo(srcCtx, addr), dstKeepers.WasmKeeper.GetContractInfo(dstCtx, addr))
+		var srcState, dstState []types.Model
+		srcKeepers.WasmKeeper.IterateContractState(srcCtx, addr, func(key, value []byte) bool {
+			srcState = append(srcState, types.Model{Key: key, Value: value})
+			return false
+		})
+		dstKeepers.WasmKeeper.IterateContractState(dstCtx, addr, func(key, value []byte) bool {
+			dstState = append(dstState, types.Model{Key: key, Value: value})
+			return false
+		})
+		assert.NotEmpty(t, dstState)
+		assert.Equal(t, srcState, dstState)
+	}
+	// and the contract can be executed
+	deposit := sdk.NewCoins(sdk.NewCoin("denom", math.NewInt(100)))
+	dstKeepers.Faucet.Fund(dstCtx, hackatom.Contract, deposit...)
+	_, err = dstKeepers.ContractKeeper.Execute(dstCtx, hackatom.Contract, hackatom.VerifierAddr, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, deposit, dstKeepers.BankKeeper.GetAllBalances(dstCtx, hackatom.BeneficiaryAddr))
+	// and new contracts can be instantiated
+	_, _, err = dstKeepers.ContractKeeper.Instantiate(dstCtx, reflect.CodeID, reflect.CreatorAddr, nil, []byte("{}"), "new", nil)
+	require.NoError(t, err)
+
+	// and a fragment with existing contracts is rejected
+	require.Error(t, mergeGenesisFragmentFile(cdc, genFile, fragmentFile))
+}
+
+// tamperedCodeClient returns code bytes that do not match the checksum
+type tamperedCodeClient struct {
+	mockWasmQueryClient
+}
+
+func (m tamperedCodeClient) Code(_ context.Context, in *types.QueryCodeRequest, _ ...grpc.CallOption) (*types.QueryCodeResponse, error) {
+	return &types.QueryCodeResponse{
+		CodeInfoResponse: &types.CodeInfoResponse{CodeID: in.CodeId, DataHash: make([]byte, 32)},
+		Data:             []byte("\x00\x61\x73\x6D\x01\x00\x00\x00"),
+	}, nil
+}
+
+func (m tamperedCodeClient) AllContractState(_ context.Context, _ *types.QueryAllContractStateRequest, _ ...grpc.CallOption) (*types.QueryAllContractStateResponse, error) {
+	return &types.QueryAllContractStateResponse{}, nil
+}
+
+func (m tamperedCodeClient) ContractHistory(_ context.Context, _ *types.QueryContractHistoryRequest, _ ...grpc.CallOption) (*types.QueryContractHistoryResponse, error) {
+	return &types.QueryContractHistoryResponse{}, nil
+}
+
+func TestDevSnapshotChecksumMismatch(t *testing.T) {
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	queryClient := tamperedCodeClient{mockWasmQueryClient{contracts: map[string]types.ContractInfo{myContract: {CodeID: 1}}}}
+	_, err := exportDevSnapshot(context.Background(), queryClient, []string{myContract})
+	require.ErrorContains(t, err, "checksum mismatch")
+}
+
+func TestParseContractList(t *testing.T) {
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	specs := map[string]struct {
+		src    string
+		exp    []string
+		expErr bool
+	}{
+		"comments and empty lines skipped": {
+			src: "# contracts\n\n " + myContract + " \n",
+			exp: []string{myContract},
+		},
+		"duplicates skipped": {
+			src: myContract + "\n" + myContract,
+			exp: []string{myContract},
+		},
+		"invalid address": {
+			src:    "invalid",
+			expErr: true,
+		},
+		"empty": {
+			src:    "# nothing\n",
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseContractList([]byte(spec.src))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/spf13/cobra"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// error classes of a failed tx
+const (
+	txErrorClassOutOfGas      = "out_of_gas"
+	txErrorClassContractError = "contract_error"
+	txErrorClassVMError       = "vm_error"
+	txErrorClassWasmError     = "wasm_error"
+	txErrorClassOther         = "other"
+)
+
+// failedMsgIndexPattern matches the index of the failed message in the log of a failed tx
+var failedMsgIndexPattern = regexp.MustCompile(`message index: (\d+)`)
+
+// GetCmdExplainTx prints a report of the wasm messages of a tx with the error classification and the wasm events
+func GetCmdExplainTx() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain-tx [hash]",
+		Short: "Explain the wasm messages of a tx with the error, events and gas used",
+		Long: `Explain the wasm messages of a tx. For every message the contract and the decoded msg are printed with the
+wasm events of the message. For failed txs, the error is classified as out of gas, contract error, wasmvm error,
+other wasm error or other error. Works for failed and successful txs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := authtx.QueryTx(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			report, err := explainTx(clientCtx.Codec, res)
+			if err != nil {
+				return err
+			}
+			if clientCtx.OutputFormat == flags.OutputFormatText {
+				return printTxExplanation(cmd.OutOrStdout(), report)
+			}
+			bz, err := json.Marshal(report)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintRaw(bz)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// txExplanation is the report of a tx
+type txExplanation struct {
+	TxHash    string               `json:"txhash"`
+	Height    int64                `json:"height,string"`
+	Success   bool                 `json:"success"`
+	GasWanted int64                `json:"gas_wanted,string"`
+	GasUsed   int64                `json:"gas_used,string"`
+	Error     *txErrorExplanation  `json:"error,omitempty"`
+	Messages  []txMsgExplanation   `json:"messages"`
+	TxEvents  []txEventExplanation `json:"tx_events,omitempty"`
+}
+
+// txErrorExplanation classifies the error of a failed tx
+type txErrorExplanation struct {
+	Class       string `json:"class"`
+	Codespace   string `json:"codespace"`
+	Code        uint32 `json:"code"`
+	Description string `json:"description"`
+	// MsgIndex is the index of the failed message when known
+	MsgIndex *int   `json:"msg_index,omitempty"`
+	Log      string `json:"log"`
+}
+
+// txMsgExplanation is the report of a message of the tx. Contract, code id and msg are set for wasm messages only.
+type txMsgExplanation struct {
+	Index    int                  `json:"index"`
+	Type     string               `json:"type"`
+	Wasm     bool                 `json:"wasm"`
+	Contract string               `json:"contract,omitempty"`
+	CodeID   uint64               `json:"code_id,omitempty,string"`
+	Msg      json.RawMessage      `json:"msg,omitempty"`
+	Failed   bool                 `json:"failed"`
+	Events   []txEventExplanation `json:"events,omitempty"`
+}
+
+type txEventExplanation struct {
+	Type       string                   `json:"type"`
+	Attributes []txAttributeExplanation `json:"attributes"`
+}
+
+type txAttributeExplanation struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// explainTx decodes the messages of the tx and assigns the wasm events to the messages by the msg_index attribute
+func explainTx(cdc codec.Codec, res *sdk.TxResponse) (*txExplanation, error) {
+	if res.Tx == nil {
+		return nil, errors.New("tx response without tx")
+	}
+	var tx txtypes.Tx
+	if err := cdc.Unmarshal(res.Tx.Value, &tx); err != nil {
+		return nil, fmt.Errorf("decode tx: %w", err)
+	}
+	if tx.Body == nil {
+		return nil, errors.New("tx without body")
+	}
+	report := &txExplanation{
+		TxHash:    res.TxHash,
+		Height:    res.Height,
+		Success:   res.Code == 0,
+		GasWanted: res.GasWanted,
+		GasUsed:   res.GasUsed,
+		Messages:  make([]txMsgExplanation, len(tx.Body.Messages)),
+	}
+	if !report.Success {
+		report.Error = classifyTxError(res)
+	}
+	for i, msgAny := range tx.Body.Messages {
+		m := txMsgExplanation{Index: i, Type: msgAny.TypeUrl}
+		if report.Error != nil && report.Error.MsgIndex != nil && *report.Error.MsgIndex == i {
+			m.Failed = true
+		}
+		var msg sdk.Msg
+		if err := cdc.UnpackAny(msgAny, &msg); err != nil {
+			return nil, fmt.Errorf("decode message %d: %w", i, err)
+		}
+		m.Wasm = strings.HasPrefix(msgAny.TypeUrl, "/cosmwasm.wasm.")
+		switch msg := msg.(type) {
+		case *types.MsgExecuteContract:
+			m.Contract, m.Msg = msg.Contract, json.RawMessage(msg.Msg)
+		case *types.MsgInstantiateContract:
+			m.CodeID, m.Msg = msg.CodeID, json.RawMessage(msg.Msg)
+		case *types.MsgInstantiateContract2:
+			m.CodeID, m.Msg = msg.CodeID, json.RawMessage(msg.Msg)
+		case *types.MsgMigrateContract:
+			m.Contract, m.CodeID, m.Msg = msg.Contract, msg.CodeID, json.RawMessage(msg.Msg)
+		case *types.MsgSudoContract:
+			m.Contract, m.Msg = msg.Contract, json.RawMessage(msg.Msg)
+		}
+		report.Messages[i] = m
+	}
+	for _, e := range res.Events {
+		if !isWasmEventType(e.Type) {
+			continue
+		}
+		event, msgIndex := explainEvent(e)
+		if msgIndex < 0 || msgIndex >= len(report.Messages) {
+			report.TxEvents = append(report.TxEvents, event)
+			continue
+		}
+		report.Messages[msgIndex].Events = append(report.Messages[msgIndex].Events, event)
+	}
+	return report, nil
+}
+
+// classifyTxError classifies the error of a failed tx by the codespace and code
+func classifyTxError(res *sdk.TxResponse) *txErrorExplanation {
+	r := &txErrorExplanation{Codespace: res.Codespace, Code: res.Code, Log: res.RawLog, Class: txErrorClassOther}
+	var registered *errorsmod.Error
+	if errors.As(errorsmod.ABCIError(res.Codespace, res.Code, ""), &registered) {
+		r.Description = registered.Error()
+	}
+	switch {
+	case res.Codespace == sdkerrors.ErrOutOfGas.Codespace() && res.Code == sdkerrors.ErrOutOfGas.ABCICode():
+		r.Class = txErrorClassOutOfGas
+	case res.Codespace == types.DefaultCodespace:
+		switch res.Code {
+		case types.ErrInstantiateFailed.ABCICode(), types.ErrExecuteFailed.ABCICode(), types.ErrMigrationFailed.ABCICode():
+			r.Class = txErrorClassContractError
+		case types.ErrVMError.ABCICode():
+			r.Class = txErrorClassVMError
+		default:
+			r.Class = txErrorClassWasmError
+		}
+	}
+	if m := failedMsgIndexPattern.FindStringSubmatch(res.RawLog); m != nil {
+		if i, err := strconv.Atoi(m[1]); err == nil {
+			r.MsgIndex = &i
+		}
+	}
+	return r
+}
+
+// isWasmEventType returns true for the events that are emitted by the wasm module or the contracts
+func isWasmEventType(t string) bool {
+	switch t {
+	case types.WasmModuleEventType, types.EventTypeInstantiate, types.EventTypeExecute, types.EventTypeMigrate,
+		types.EventTypeSudo, types.EventTypeReply, types.EventTypeStoreCode, types.EventTypeExecTrace:
+		return true
+	}
+	return strings.HasPrefix(t, types.CustomContractEventPrefix)
+}
+
+// explainEvent returns the event without the msg_index attribute and the msg index or -1 when not set
+func explainEvent(e abci.Event) (txEventExplanation, int) {
+	r := txEventExplanation{Type: e.Type, Attributes: make([]txAttributeExplanation, 0, len(e.Attributes))}
+	msgIndex := -1
+	for _, a := range e.Attributes {
+		if a.Key == "msg_index" {
+			if i, err := strconv.Atoi(a.Value); err == nil {
+				msgIndex = i
+				continue
+			}
+		}
+		r.Attributes = append(r.Attributes, txAttributeExplanation{Key: a.Key, Value: a.Value})
+	}
+	return r, msgIndex
+}
+
+// printTxExplanation prints the report in a human readable format
+func printTxExplanation(out io.Writer, r *txExplanation) error {
+	status := "success"
+	if !r.Success {
+		status = "failed"
+	}
+	fmt.Fprintf(out, "tx %s at height %d: %s\n", r.TxHash, r.Height, status)
+	fmt.Fprintf(out, "gas used %d of %d wanted\n", r.GasUsed, r.GasWanted)
+	if r.Error != nil {
+		fmt.Fprintf(out, "error: %s (codespace %s, code %d", r.Error.Class, r.Error.Codespace, r.Error.Code)
+		if r.Error.Description != "" {
+			fmt.Fprintf(out, ": %s", r.Error.Description)
+		}
+		fmt.Fprintln(out, ")")
+		fmt.Fprintf(out, "  log: %s\n", r.Error.Log)
+	}
+	for _, m := range r.Messages {
+		fmt.Fprintf(out, "message %d: %s", m.Index, m.Type)
+		if m.Failed {
+			fmt.Fprint(out, " (failed)")
+		}
+		fmt.Fprintln(out)
+		if m.Contract != "" {
+			fmt.Fprintf(out, "  contract: %s\n", m.Contract)
+		}
+		if m.CodeID != 0 {
+			fmt.Fprintf(out, "  code id: %d\n", m.CodeID)
+		}
+		if len(m.Msg) != 0 {
+			fmt.Fprintf(out, "  msg: %s\n", m.Msg)
+		}
+		printEventExplanations(out, m.Events)
+	}
+	if len(r.TxEvents) != 0 {
+		fmt.Fprintln(out, "tx events:")
+		printEventExplanations(out, r.TxEvents)
+	}
+	return nil
+}
+
+func printEventExplanations(out io.Writer, events []txEventExplanation) {
+	for _, e := range events {
+		fmt.Fprintf(out, "  event %s\n", e.Type)
+		for _, a := range e.Attributes {
+			fmt.Fprintf(out, "    %s: %s\n", a.Key, a.Value)
+		}
+	}
+}
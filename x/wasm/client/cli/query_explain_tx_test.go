@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestExplainTx(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	types.RegisterInterfaces(interfaceRegistry)
+	banktypes.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	const mySender = "cosmos1fl48vsnmsdzcv85q5d2q4z5ajdha8yu34mf0eh"
+	txAny := func(t *testing.T, msgs ...sdk.Msg) *codectypes.Any {
+		anys := make([]*codectypes.Any, len(msgs))
+		for i, msg := range msgs {
+			var err error
+			anys[i], err = codectypes.NewAnyWithValue(msg)
+			require.NoError(t, err)
+		}
+		a, err := codectypes.NewAnyWithValue(&txtypes.Tx{Body: &txtypes.TxBody{Messages: anys}})
+		require.NoError(t, err)
+		return a
+	}
+	executeMsg := &types.MsgExecuteContract{Sender: mySender, Contract: myContract, Msg: []byte(`{"release":{}}`)}
+	sendMsg := &banktypes.MsgSend{FromAddress: mySender, ToAddress: myContract}
+	event := func(typ string, attrs ...string) abci.Event {
+		e := abci.Event{Type: typ}
+		for i := 0; i < len(attrs); i += 2 {
+			e.Attributes = append(e.Attributes, abci.EventAttribute{Key: attrs[i], Value: attrs[i+1]})
+		}
+		return e
+	}
+
+	specs := map[string]struct {
+		res    *sdk.TxResponse
+		exp    txExplanation
+		expErr bool
+	}{
+		"success": {
+			res: &sdk.TxResponse{
+				TxHash: "ABCD", Height: 7, GasWanted: 200_000, GasUsed: 150_000,
+				Tx: txAny(t, sendMsg, executeMsg),
+				Events: []abci.Event{
+					event("tx", "fee", "10stake"),
+					event("transfer", "recipient", myContract, "msg_index", "0"),
+					event("execute", "_contract_address", myContract, "msg_index", "1"),
+					event("wasm", "_contract_address", myContract, "action", "release", "msg_index", "1"),
+				},
+			},
+			exp: txExplanation{
+				TxHash: "ABCD", Height: 7, Success: true, GasWanted: 200_000, GasUsed: 150_000,
+				Messages: []txMsgExplanation{
+					{Index: 0, Type: "/cosmos.bank.v1beta1.MsgSend"},
+					{
+						Index: 1, Type: "/cosmwasm.wasm.v1.MsgExecuteContract", Wasm: true, Contract: myContract, Msg: json.RawMessage(`{"release":{}}`),
+						Events: []txEventExplanation{
+							{Type: "execute", Attributes: []txAttributeExplanation{{Key: "_contract_address", Value: myContract}}},
+							{Type: "wasm", Attributes: []txAttributeExplanation{{Key: "_contract_address", Value: myContract}, {Key: "action", Value: "release"}}},
+						},
+					},
+				},
+			},
+		},
+		"contract error": {
+			res: &sdk.TxResponse{
+				TxHash: "ABCD", Height: 7, GasWanted: 200_000, GasUsed: 120_000,
+				Codespace: types.DefaultCodespace, Code: types.ErrExecuteFailed.ABCICode(),
+				RawLog: "failed to execute message; message index: 0: Unauthorized: execute wasm contract failed",
+				Tx:     txAny(t, executeMsg),
+			},
+			exp: txExplanation{
+				TxHash: "ABCD", Height: 7, GasWanted: 200_000, GasUsed: 120_000,
+				Error: &txErrorExplanation{
+					Class: txErrorClassContractError, Codespace: types.DefaultCodespace, Code: types.ErrExecuteFailed.ABCICode(),
+					Description: "execute wasm contract failed", MsgIndex: ptr(0),
+					Log: "failed to execute message; message index: 0: Unauthorized: execute wasm contract failed",
+				},
+				Messages: []txMsgExplanation{
+					{Index: 0, Type: "/cosmwasm.wasm.v1.MsgExecuteContract", Wasm: true, Contract: myContract, Msg: json.RawMessage(`{"release":{}}`), Failed: true},
+				},
+			},
+		},
+		"out of gas": {
+			res: &sdk.TxResponse{
+				TxHash: "ABCD", Height: 7, GasWanted: 100_000, GasUsed: 100_412,
+				Codespace: "sdk", Code: 11,
+				RawLog: "out of gas in location: wasm contract; gasWanted: 100000, gasUsed: 100412: out of gas",
+				Tx:     txAny(t, executeMsg),
+			},
+			exp: txExplanation{
+				TxHash: "ABCD", Height: 7, GasWanted: 100_000, GasUsed: 100_412,
+				Error: &txErrorExplanation{
+					Class: txErrorClassOutOfGas, Codespace: "sdk", Code: 11, Description: "out of gas",
+					Log: "out of gas in location: wasm contract; gasWanted: 100000, gasUsed: 100412: out of gas",
+				},
+				Messages: []txMsgExplanation{
+					{Index: 0, Type: "/cosmwasm.wasm.v1.MsgExecuteContract", Wasm: true, Contract: myContract, Msg: json.RawMessage(`{"release":{}}`)},
+				},
+			},
+		},
+		"other wasm error": {
+			res: &sdk.TxResponse{
+				Codespace: types.DefaultCodespace, Code: types.ErrInvalid.ABCICode(),
+				RawLog: "failed to execute message; message index: 0: invalid",
+				Tx:     txAny(t, executeMsg),
+			},
+			exp: txExplanation{
+				Error: &txErrorExplanation{
+					Class: txErrorClassWasmError, Codespace: types.DefaultCodespace, Code: types.ErrInvalid.ABCICode(),
+					Description: "invalid", MsgIndex: ptr(0),
+					Log: "failed to execute message; message index: 0: invalid",
+				},
+				Messages: []txMsgExplanation{
+					{Index: 0, Type: "/cosmwasm.wasm.v1.MsgExecuteContract", Wasm: true, Contract: myContract, Msg: json.RawMessage(`{"release":{}}`), Failed: true},
+				},
+			},
+		},
+		"without tx": {
+			res:    &sdk.TxResponse{},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// when
+			got, gotErr := explainTx(cdc, spec.res)
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, *got)
+
+			// and the text report contains the classification and the messages
+			var out bytes.Buffer
+			require.NoError(t, printTxExplanation(&out, got))
+			if spec.exp.Error != nil {
+				assert.Contains(t, out.String(), "error: "+spec.exp.Error.Class)
+			}
+			assert.Contains(t, out.String(), "message 0: "+spec.exp.Messages[0].Type)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetCmdExportContract prints the import contract message for the contract with its full state
+func GetCmdExportContract() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-contract [bech32_address]",
+		Short: "Prints out the import contract message for a contract with its full state",
+		Long: `Prints out the import contract message for a contract with its full state. All queries run at a single height.
+The output can be submitted on another chain with "tx wasm submit-proposal import-contract".
+Without --target-code-id, the contract code is embedded gzipped. Otherwise the code id on the target chain is referenced.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			targetCodeID, err := cmd.Flags().GetUint64(flagTargetCodeID)
+			if err != nil {
+				return err
+			}
+			importAddr, err := cmd.Flags().GetString(flagImportAddress)
+			if err != nil {
+				return err
+			}
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return err
+			}
+			clientCtx, ctx, err := pinQueryHeight(cmd.Context(), clientCtx)
+			if err != nil {
+				return err
+			}
+			msg, err := exportContract(ctx, types.NewQueryClient(clientCtx), addr, targetCodeID)
+			if err != nil {
+				return err
+			}
+			msg.Authority = authority
+			if importAddr != "" {
+				msg.Address = importAddr
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(msg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagTargetCodeID, 0, "Code id on the target chain. The code is embedded when not set")
+	cmd.Flags().String(flagImportAddress, "", "Contract address on the target chain. Defaults to the exported address")
+	cmd.Flags().String(flagAuthority, DefaultGovAuthority.String(), "The address of the governance account on the target chain. Default is the sdk gov module account")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// exportContract builds the import contract message from the contract info and all state entries.
+// The code is embedded gzipped when no target code id is given. The authority is not set.
+func exportContract(ctx context.Context, queryClient types.QueryClient, addr string, targetCodeID uint64) (*types.MsgImportContract, error) {
+	infoRes, err := queryClient.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	msg := &types.MsgImportContract{
+		Address: addr,
+		CodeID:  targetCodeID,
+		Creator: infoRes.Creator,
+		Admin:   infoRes.Admin,
+		Label:   infoRes.Label,
+	}
+	if targetCodeID == 0 {
+		codeRes, err := queryClient.Code(ctx, &types.QueryCodeRequest{CodeId: infoRes.CodeID})
+		if err != nil {
+			return nil, err
+		}
+		if len(codeRes.Data) == 0 {
+			return nil, errors.New("empty code")
+		}
+		msg.WASMByteCode = codeRes.Data
+		if ioutils.IsWasm(codeRes.Data) {
+			if msg.WASMByteCode, err = ioutils.GzipIt(codeRes.Data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if msg.State, err = queryFullContractState(ctx, queryClient, addr); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// queryFullContractState returns all state entries of the contract by following the pagination
+func queryFullContractState(ctx context.Context, queryClient types.QueryClient, addr string) ([]types.Model, error) {
+	var (
+		models  []types.Model
+		nextKey []byte
+	)
+	for {
+		stateRes, err := queryClient.AllContractState(ctx, &types.QueryAllContractStateRequest{
+			Address:    addr,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, stateRes.Models...)
+		if stateRes.Pagination == nil || len(stateRes.Pagination.NextKey) == 0 {
+			return models, nil
+		}
+		nextKey = stateRes.Pagination.NextKey
+	}
+}
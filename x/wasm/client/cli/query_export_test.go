@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// exportQueryClient returns the contract state in pages of 2
+type exportQueryClient struct {
+	mockWasmQueryClient
+	code  []byte
+	state []types.Model
+}
+
+func (m exportQueryClient) Code(_ context.Context, _ *types.QueryCodeRequest, _ ...grpc.CallOption) (*types.QueryCodeResponse, error) {
+	return &types.QueryCodeResponse{Data: m.code}, nil
+}
+
+func (m exportQueryClient) AllContractState(_ context.Context, in *types.QueryAllContractStateRequest, _ ...grpc.CallOption) (*types.QueryAllContractStateResponse, error) {
+	var start int
+	if in.Pagination != nil && len(in.Pagination.Key) != 0 {
+		start = int(in.Pagination.Key[0])
+	}
+	end := min(start+2, len(m.state))
+	rsp := &types.QueryAllContractStateResponse{Models: m.state[start:end], Pagination: &query.PageResponse{}}
+	if end < len(m.state) {
+		rsp.Pagination.NextKey = []byte{byte(end)}
+	}
+	return rsp, nil
+}
+
+func TestExportContract(t *testing.T) {
+	const (
+		myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+		myCreator  = "cosmos1ve557a5g9yw2g2z57js3pdmcvd5my6g8ze20np"
+	)
+	myCode := []byte("\x00\x61\x73\x6D\x01\x00\x00\x00")
+	myState := []types.Model{
+		{Key: []byte("a"), Value: []byte("1")},
+		{Key: []byte("b"), Value: []byte("2")},
+		{Key: []byte("c"), Value: []byte("3")},
+	}
+	queryClient := exportQueryClient{
+		mockWasmQueryClient: mockWasmQueryClient{
+			contracts: map[string]types.ContractInfo{myContract: {CodeID: 1, Creator: myCreator, Admin: myCreator, Label: "my label"}},
+		},
+		code:  myCode,
+		state: myState,
+	}
+	gzippedCode, err := ioutils.GzipIt(myCode)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		targetCodeID uint64
+		expCode      []byte
+	}{
+		"embedded code": {
+			expCode: gzippedCode,
+		},
+		"target code id": {
+			targetCodeID: 7,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// when
+			got, err := exportContract(context.Background(), queryClient, myContract, spec.targetCodeID)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, &types.MsgImportContract{
+				Address:      myContract,
+				CodeID:       spec.targetCodeID,
+				WASMByteCode: spec.expCode,
+				Creator:      myCreator,
+				Admin:        myCreator,
+				Label:        "my label",
+				State:        myState,
+			}, got)
+
+			// and the json output can be read by the import proposal command
+			got.Authority = DefaultGovAuthority.String()
+			require.NoError(t, got.ValidateBasic())
+			interfaceRegistry := codectypes.NewInterfaceRegistry()
+			types.RegisterInterfaces(interfaceRegistry)
+			cdc := codec.NewProtoCodec(interfaceRegistry)
+			bz, err := cdc.MarshalJSON(got)
+			require.NoError(t, err)
+			var read types.MsgImportContract
+			require.NoError(t, cdc.UnmarshalJSON(bz, &read))
+			assert.Equal(t, *got, read)
+		})
+	}
+
+	// when unknown contract
+	_, err = exportContract(context.Background(), queryClient, myCreator, 0)
+	// then
+	require.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+)
+
+// GetCmdIBCPackets lists the packets that the contract sent on the channel and that are not acknowledged
+// or timed out, yet
+func GetCmdIBCPackets() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ibc-packets [bech32_address] [channel_id]",
+		Short: "List the pending IBC packets that the contract sent on the channel",
+		Long: `List the packets that the contract sent on the channel and that are not acknowledged or timed out, yet.
+The packet data and timeouts are looked up in the send_packet events of the txs. This requires a node
+with tx indexing. When a tx can not be found, the packet is listed with the sequence only.
+In text output, JSON packet data is pretty printed and other data is base64 encoded.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := translateAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			contractAddr, err := sdk.AccAddressFromBech32(addr)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			searchTxs := func(query string) (*sdk.SearchTxsResult, error) {
+				return authtx.QueryTxsByEvents(clientCtx, 1, 1, query, "")
+			}
+			packets, pageRes, err := pendingIBCPackets(
+				context.Background(),
+				channeltypes.NewQueryClient(clientCtx),
+				searchTxs,
+				keeper.PortIDForContract(contractAddr),
+				args[1],
+				pageReq,
+			)
+			if err != nil {
+				return err
+			}
+			if clientCtx.OutputFormat == flags.OutputFormatText {
+				return printIBCPackets(cmd.OutOrStdout(), packets, pageRes)
+			}
+			bz, err := json.Marshal(map[string]any{"packets": packets, "pagination": pageRes})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintRaw(bz)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "ibc packets")
+	return cmd
+}
+
+// ibcPacketInfo is a pending packet with the details from the send_packet event when available
+type ibcPacketInfo struct {
+	Sequence         uint64 `json:"sequence,string"`
+	TxHash           string `json:"tx_hash,omitempty"`
+	TimeoutHeight    string `json:"timeout_height,omitempty"`
+	TimeoutTimestamp uint64 `json:"timeout_timestamp,string,omitempty"`
+	Data             []byte `json:"data,omitempty"`
+	// Unavailable is the reason why the details are missing
+	Unavailable string `json:"unavailable,omitempty"`
+}
+
+// pendingIBCPackets lists the packet commitments of the channel and looks up the send_packet event of each
+func pendingIBCPackets(
+	ctx context.Context,
+	queryClient channeltypes.QueryClient,
+	searchTxs func(query string) (*sdk.SearchTxsResult, error),
+	portID, channelID string,
+	pageReq *query.PageRequest,
+) ([]ibcPacketInfo, *query.PageResponse, error) {
+	res, err := queryClient.PacketCommitments(ctx, &channeltypes.QueryPacketCommitmentsRequest{
+		PortId:     portID,
+		ChannelId:  channelID,
+		Pagination: pageReq,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	packets := make([]ibcPacketInfo, len(res.Commitments))
+	for i, c := range res.Commitments {
+		packets[i] = findSentPacket(searchTxs, portID, channelID, c.Sequence)
+	}
+	return packets, res.Pagination, nil
+}
+
+// findSentPacket returns the packet details from the send_packet event of the tx. The lookup is best effort,
+// the reason is kept when the details are not available.
+func findSentPacket(searchTxs func(query string) (*sdk.SearchTxsResult, error), portID, channelID string, sequence uint64) ibcPacketInfo {
+	result := ibcPacketInfo{Sequence: sequence}
+	res, err := searchTxs(fmt.Sprintf("%s.%s='%s' AND %s.%s='%s' AND %s.%s='%d'",
+		channeltypes.EventTypeSendPacket, channeltypes.AttributeKeySrcPort, portID,
+		channeltypes.EventTypeSendPacket, channeltypes.AttributeKeySrcChannel, channelID,
+		channeltypes.EventTypeSendPacket, channeltypes.AttributeKeySequence, sequence,
+	))
+	switch {
+	case err != nil:
+		result.Unavailable = err.Error()
+		return result
+	case len(res.Txs) == 0:
+		result.Unavailable = "tx not found"
+		return result
+	}
+	tx := res.Txs[0]
+	result.TxHash = tx.TxHash
+	for _, e := range tx.Events {
+		if e.Type != channeltypes.EventTypeSendPacket {
+			continue
+		}
+		attrs := make(map[string]string, len(e.Attributes))
+		for _, a := range e.Attributes {
+			attrs[a.Key] = a.Value
+		}
+		if attrs[channeltypes.AttributeKeySrcPort] != portID ||
+			attrs[channeltypes.AttributeKeySrcChannel] != channelID ||
+			attrs[channeltypes.AttributeKeySequence] != strconv.FormatUint(sequence, 10) {
+			continue
+		}
+		data, err := hex.DecodeString(attrs[channeltypes.AttributeKeyDataHex])
+		if err != nil {
+			result.Unavailable = fmt.Sprintf("invalid packet data: %s", err)
+			return result
+		}
+		result.Data = data
+		result.TimeoutHeight = attrs[channeltypes.AttributeKeyTimeoutHeight]
+		result.TimeoutTimestamp, _ = strconv.ParseUint(attrs[channeltypes.AttributeKeyTimeoutTimestamp], 10, 64)
+		return result
+	}
+	result.Unavailable = "send_packet event not found in tx"
+	return result
+}
+
+// printIBCPackets prints the packets with the pretty printed JSON data
+func printIBCPackets(out io.Writer, packets []ibcPacketInfo, pageRes *query.PageResponse) error {
+	for i, p := range packets {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "sequence: %d\n", p.Sequence)
+		if p.Unavailable != "" {
+			fmt.Fprintf(out, "details unavailable: %s\n", p.Unavailable)
+			continue
+		}
+		fmt.Fprintf(out, "tx: %s\n", p.TxHash)
+		fmt.Fprintf(out, "timeout height: %s\n", p.TimeoutHeight)
+		timeout := strconv.FormatUint(p.TimeoutTimestamp, 10)
+		if p.TimeoutTimestamp != 0 {
+			timeout += " (" + time.Unix(0, int64(p.TimeoutTimestamp)).UTC().Format(time.RFC3339) + ")"
+		}
+		fmt.Fprintf(out, "timeout timestamp: %s\n", timeout)
+		var pretty bytes.Buffer
+		if json.Valid(p.Data) && json.Indent(&pretty, p.Data, "", "  ") == nil {
+			fmt.Fprintf(out, "data:\n%s\n", pretty.String())
+		} else {
+			fmt.Fprintf(out, "data (base64): %s\n", base64.StdEncoding.EncodeToString(p.Data))
+		}
+	}
+	if pageRes != nil && len(pageRes.NextKey) != 0 {
+		fmt.Fprintf(out, "\nnext page key: %s\n", base64.StdEncoding.EncodeToString(pageRes.NextKey))
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+type mockChannelQueryClient struct {
+	channeltypes.QueryClient
+	PacketCommitmentsFn func(ctx context.Context, in *channeltypes.QueryPacketCommitmentsRequest, opts ...grpc.CallOption) (*channeltypes.QueryPacketCommitmentsResponse, error)
+}
+
+func (m mockChannelQueryClient) PacketCommitments(ctx context.Context, in *channeltypes.QueryPacketCommitmentsRequest, opts ...grpc.CallOption) (*channeltypes.QueryPacketCommitmentsResponse, error) {
+	return m.PacketCommitmentsFn(ctx, in, opts...)
+}
+
+func TestPendingIBCPackets(t *testing.T) {
+	const portID, channelID = "wasm.mycontract", "channel-1"
+	sendPacketTx := func(sequence, dataHex string) *sdk.TxResponse {
+		return &sdk.TxResponse{TxHash: "ABCD", Events: []abci.Event{
+			{Type: "message", Attributes: []abci.EventAttribute{{Key: "module", Value: "wasm"}}},
+			{Type: channeltypes.EventTypeSendPacket, Attributes: []abci.EventAttribute{
+				{Key: channeltypes.AttributeKeySrcPort, Value: portID},
+				{Key: channeltypes.AttributeKeySrcChannel, Value: channelID},
+				{Key: channeltypes.AttributeKeySequence, Value: sequence},
+				{Key: channeltypes.AttributeKeyDataHex, Value: dataHex},
+				{Key: channeltypes.AttributeKeyTimeoutHeight, Value: "1-100"},
+				{Key: channeltypes.AttributeKeyTimeoutTimestamp, Value: "1700000000000000000"},
+			}},
+		}}
+	}
+	queryClient := mockChannelQueryClient{
+		PacketCommitmentsFn: func(_ context.Context, in *channeltypes.QueryPacketCommitmentsRequest, _ ...grpc.CallOption) (*channeltypes.QueryPacketCommitmentsResponse, error) {
+			require.Equal(t, portID, in.PortId)
+			require.Equal(t, channelID, in.ChannelId)
+			return &channeltypes.QueryPacketCommitmentsResponse{
+				Commitments: []*channeltypes.PacketState{{Sequence: 1}, {Sequence: 2}, {Sequence: 3}, {Sequence: 4}},
+				Pagination:  &query.PageResponse{NextKey: []byte{0x1}},
+			}, nil
+		},
+	}
+	var queries []string
+	searchTxs := func(query string) (*sdk.SearchTxsResult, error) {
+		queries = append(queries, query)
+		switch len(queries) {
+		case 1:
+			return &sdk.SearchTxsResult{Txs: []*sdk.TxResponse{sendPacketTx("1", hex.EncodeToString([]byte(`{"foo":"bar"}`)))}}, nil
+		case 2:
+			return &sdk.SearchTxsResult{}, nil
+		case 3:
+			return nil, errors.New("transaction indexing is disabled")
+		default:
+			return &sdk.SearchTxsResult{Txs: []*sdk.TxResponse{sendPacketTx("1", "")}}, nil
+		}
+	}
+
+	// when
+	packets, pageRes, err := pendingIBCPackets(context.Background(), queryClient, searchTxs, portID, channelID, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "send_packet.packet_src_port='wasm.mycontract' AND send_packet.packet_src_channel='channel-1' AND send_packet.packet_sequence='1'", queries[0])
+	exp := []ibcPacketInfo{
+		{Sequence: 1, TxHash: "ABCD", TimeoutHeight: "1-100", TimeoutTimestamp: 1700000000000000000, Data: []byte(`{"foo":"bar"}`)},
+		{Sequence: 2, Unavailable: "tx not found"},
+		{Sequence: 3, Unavailable: "transaction indexing is disabled"},
+		{Sequence: 4, TxHash: "ABCD", Unavailable: "send_packet event not found in tx"},
+	}
+	assert.Equal(t, exp, packets)
+	assert.Equal(t, []byte{0x1}, pageRes.NextKey)
+
+	// and when
+	var buf bytes.Buffer
+	require.NoError(t, printIBCPackets(&buf, packets, pageRes))
+
+	// then
+	expOut := `sequence: 1
+tx: ABCD
+timeout height: 1-100
+timeout timestamp: 1700000000000000000 (2023-11-14T22:13:20Z)
+data:
+{
+  "foo": "bar"
+}
+
+sequence: 2
+details unavailable: tx not found
+
+sequence: 3
+details unavailable: transaction indexing is disabled
+
+sequence: 4
+details unavailable: send_packet event not found in tx
+
+next page key: AQ==
+`
+	assert.Equal(t, expOut, buf.String())
+}
+
+func TestPendingIBCPacketsQueryError(t *testing.T) {
+	queryClient := mockChannelQueryClient{
+		PacketCommitmentsFn: func(context.Context, *channeltypes.QueryPacketCommitmentsRequest, ...grpc.CallOption) (*channeltypes.QueryPacketCommitmentsResponse, error) {
+			return nil, errors.New("channel not found")
+		},
+	}
+	_, _, err := pendingIBCPackets(context.Background(), queryClient, nil, "wasm.mycontract", "channel-1", nil)
+	require.Error(t, err)
+}
+
+func TestPrintIBCPacketsBinaryData(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, printIBCPackets(&buf, []ibcPacketInfo{{Sequence: 7, TxHash: "ABCD", TimeoutHeight: "0-0", Data: []byte{0x0, 0x1}}}, nil))
+	exp := `sequence: 7
+tx: ABCD
+timeout height: 0-0
+timeout timestamp: 0
+data (base64): AAE=
+`
+	assert.Equal(t, exp, buf.String())
+}
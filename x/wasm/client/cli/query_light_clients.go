@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// lightClientChecksumsPath is the grpc query of the ibc-go 08-wasm light client module
+const lightClientChecksumsPath = "/ibc.lightclients.wasm.v1.Query/Checksums"
+
+// GetCmdListLightClients lists the code checksums of the wasm module and of the 08-wasm light client module
+func GetCmdListLightClients() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "light-clients",
+		Short: "List the 08-wasm light client code checksums next to the wasm module code checksums",
+		Long: `List the checksums of the light client code stored by the ibc-go 08-wasm module and the checksums of
+the wasm module codes. Both are queried at the same height. The 08-wasm light client code is stored separately
+from the wasm module codes. When the chain does not run the 08-wasm module, the light clients are reported as
+not available.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, ctx, err := pinQueryHeight(cmd.Context(), clientCtx)
+			if err != nil {
+				return err
+			}
+			report, err := queryLightClients(ctx, types.NewQueryClient(clientCtx), clientCtx.QueryABCI, clientCtx.Height)
+			if err != nil {
+				return err
+			}
+			if clientCtx.OutputFormat == flags.OutputFormatText {
+				return printLightClients(cmd.OutOrStdout(), report)
+			}
+			bz, err := json.Marshal(report)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintRaw(bz)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// lightClientsReport contains the code checksums of both modules at the same height. The checksums are hex encoded.
+type lightClientsReport struct {
+	Height       int64                  `json:"height,string"`
+	WasmCodes    []wasmCodeChecksum     `json:"wasm_codes"`
+	LightClients lightClientCodeSummary `json:"light_clients"`
+}
+
+type wasmCodeChecksum struct {
+	CodeID   uint64 `json:"code_id,string"`
+	Checksum string `json:"checksum"`
+}
+
+type lightClientCodeSummary struct {
+	// Available is false when the chain does not run the 08-wasm module
+	Available bool     `json:"available"`
+	Checksums []string `json:"checksums"`
+}
+
+// queryLightClients pages through the codes of the wasm module and the checksums of the 08-wasm module
+func queryLightClients(
+	ctx context.Context,
+	queryClient types.QueryClient,
+	abciQuery func(abci.RequestQuery) (abci.ResponseQuery, error),
+	height int64,
+) (*lightClientsReport, error) {
+	report := lightClientsReport{
+		Height:       height,
+		WasmCodes:    []wasmCodeChecksum{},
+		LightClients: lightClientCodeSummary{Available: true, Checksums: []string{}},
+	}
+	pageReq := &query.PageRequest{}
+	for {
+		res, err := queryClient.Codes(ctx, &types.QueryCodesRequest{Pagination: pageReq})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range res.CodeInfos {
+			report.WasmCodes = append(report.WasmCodes, wasmCodeChecksum{CodeID: c.CodeID, Checksum: hex.EncodeToString(c.DataHash)})
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		pageReq = &query.PageRequest{Key: res.Pagination.NextKey}
+	}
+
+	pageReq = &query.PageRequest{}
+	for {
+		reqBz, err := proto.Marshal(&lightClientChecksumsRequest{Pagination: pageReq})
+		if err != nil {
+			return nil, err
+		}
+		res, err := abciQuery(abci.RequestQuery{Path: lightClientChecksumsPath, Data: reqBz, Height: height})
+		switch {
+		case err != nil && strings.Contains(err.Error(), "unknown query path"):
+			report.LightClients = lightClientCodeSummary{Checksums: []string{}}
+			return &report, nil
+		case err != nil:
+			return nil, err
+		}
+		var checksums lightClientChecksumsResponse
+		if err := proto.Unmarshal(res.Value, &checksums); err != nil {
+			return nil, fmt.Errorf("decode light client checksums: %w", err)
+		}
+		report.LightClients.Checksums = append(report.LightClients.Checksums, checksums.Checksums...)
+		if checksums.Pagination == nil || len(checksums.Pagination.NextKey) == 0 {
+			break
+		}
+		pageReq = &query.PageRequest{Key: checksums.Pagination.NextKey}
+	}
+	return &report, nil
+}
+
+// printLightClients prints both checksum lists with labels
+func printLightClients(out io.Writer, report *lightClientsReport) error {
+	fmt.Fprintf(out, "height: %d\n", report.Height)
+	fmt.Fprintf(out, "wasm module codes (%d):\n", len(report.WasmCodes))
+	for _, c := range report.WasmCodes {
+		fmt.Fprintf(out, "  code %d: %s\n", c.CodeID, c.Checksum)
+	}
+	if !report.LightClients.Available {
+		fmt.Fprintln(out, "08-wasm light client codes: not available on this chain")
+		return nil
+	}
+	fmt.Fprintf(out, "08-wasm light client codes (%d):\n", len(report.LightClients.Checksums))
+	for _, c := range report.LightClients.Checksums {
+		fmt.Fprintf(out, "  %s\n", c)
+	}
+	return nil
+}
+
+// lightClientChecksumsRequest is the wire compatible QueryChecksumsRequest of the 08-wasm module.
+// It is declared here so that wasmd does not depend on the light client module.
+type lightClientChecksumsRequest struct {
+	Pagination *query.PageRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *lightClientChecksumsRequest) Reset()         { *m = lightClientChecksumsRequest{} }
+func (m *lightClientChecksumsRequest) String() string { return proto.CompactTextString(m) }
+func (*lightClientChecksumsRequest) ProtoMessage()    {}
+
+// lightClientChecksumsResponse is the wire compatible QueryChecksumsResponse of the 08-wasm module
+type lightClientChecksumsResponse struct {
+	Checksums  []string            `protobuf:"bytes,1,rep,name=checksums,proto3" json:"checksums,omitempty"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *lightClientChecksumsResponse) Reset()         { *m = lightClientChecksumsResponse{} }
+func (m *lightClientChecksumsResponse) String() string { return proto.CompactTextString(m) }
+func (*lightClientChecksumsResponse) ProtoMessage()    {}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// codesQueryClient returns the codes in pages of 1
+type codesQueryClient struct {
+	mockWasmQueryClient
+	codes []types.CodeInfoResponse
+}
+
+func (m codesQueryClient) Codes(_ context.Context, in *types.QueryCodesRequest, _ ...grpc.CallOption) (*types.QueryCodesResponse, error) {
+	var start int
+	if in.Pagination != nil && len(in.Pagination.Key) != 0 {
+		start = int(in.Pagination.Key[0])
+	}
+	rsp := &types.QueryCodesResponse{CodeInfos: m.codes[start : start+1], Pagination: &query.PageResponse{}}
+	if start+1 < len(m.codes) {
+		rsp.Pagination.NextKey = []byte{byte(start + 1)}
+	}
+	return rsp, nil
+}
+
+func TestQueryLightClients(t *testing.T) {
+	myChecksums := []string{bytes32Hex(0x1), bytes32Hex(0x2), bytes32Hex(0x3)}
+	queryClient := codesQueryClient{codes: []types.CodeInfoResponse{
+		{CodeID: 1, DataHash: bytes.Repeat([]byte{0xa}, 32)},
+		{CodeID: 2, DataHash: bytes.Repeat([]byte{0xb}, 32)},
+	}}
+	// mocked 08-wasm query service that returns the checksums in pages of 2
+	lightClientService := func(t *testing.T) func(req abci.RequestQuery) (abci.ResponseQuery, error) {
+		return func(req abci.RequestQuery) (abci.ResponseQuery, error) {
+			require.Equal(t, lightClientChecksumsPath, req.Path)
+			assert.Equal(t, int64(7), req.Height)
+			var in lightClientChecksumsRequest
+			require.NoError(t, proto.Unmarshal(req.Data, &in))
+			var start int
+			if in.Pagination != nil && len(in.Pagination.Key) != 0 {
+				start = int(in.Pagination.Key[0])
+			}
+			end := min(start+2, len(myChecksums))
+			rsp := lightClientChecksumsResponse{Checksums: myChecksums[start:end], Pagination: &query.PageResponse{}}
+			if end < len(myChecksums) {
+				rsp.Pagination.NextKey = []byte{byte(end)}
+			}
+			bz, err := proto.Marshal(&rsp)
+			require.NoError(t, err)
+			return abci.ResponseQuery{Value: bz}, nil
+		}
+	}
+	expWasmCodes := []wasmCodeChecksum{{CodeID: 1, Checksum: bytes32Hex(0xa)}, {CodeID: 2, Checksum: bytes32Hex(0xb)}}
+
+	specs := map[string]struct {
+		abciQuery func(t *testing.T) func(req abci.RequestQuery) (abci.ResponseQuery, error)
+		exp       lightClientCodeSummary
+		expErr    bool
+	}{
+		"with 08-wasm module": {
+			abciQuery: lightClientService,
+			exp:       lightClientCodeSummary{Available: true, Checksums: myChecksums},
+		},
+		"without 08-wasm module": {
+			abciQuery: func(t *testing.T) func(req abci.RequestQuery) (abci.ResponseQuery, error) {
+				return func(req abci.RequestQuery) (abci.ResponseQuery, error) {
+					return abci.ResponseQuery{}, status.Error(codes.Unknown, "unknown query path: unknown request")
+				}
+			},
+			exp: lightClientCodeSummary{Checksums: []string{}},
+		},
+		"other error": {
+			abciQuery: func(t *testing.T) func(req abci.RequestQuery) (abci.ResponseQuery, error) {
+				return func(req abci.RequestQuery) (abci.ResponseQuery, error) {
+					return abci.ResponseQuery{}, status.Error(codes.Unavailable, "connection refused")
+				}
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// when
+			got, gotErr := queryLightClients(context.Background(), queryClient, spec.abciQuery(t), 7)
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, int64(7), got.Height)
+			assert.Equal(t, expWasmCodes, got.WasmCodes)
+			assert.Equal(t, spec.exp, got.LightClients)
+
+			// and the text output labels both lists
+			var out bytes.Buffer
+			require.NoError(t, printLightClients(&out, got))
+			assert.Contains(t, out.String(), "wasm module codes (2):\n  code 1: "+bytes32Hex(0xa))
+			if spec.exp.Available {
+				assert.Contains(t, out.String(), "08-wasm light client codes (3):\n  "+myChecksums[0])
+			} else {
+				assert.Contains(t, out.String(), "08-wasm light client codes: not available on this chain")
+			}
+		})
+	}
+}
+
+func bytes32Hex(b byte) string {
+	return hex.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetCmdGetContractStateSmartBatch runs the smart queries of a json file and prints the results
+func GetCmdGetContractStateSmartBatch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "smart-batch [file.json]",
+		Short: "Calls the contracts with the smart queries of a json file and prints the results",
+		Long: `Calls the contracts with the smart queries of a json file and prints the results as a json array.
+The file contains an array of {"address": "<bech32_address>", "query": {<query>}} objects. The results have
+the index of the query in the file. Failed queries are reported with the error of the entry unless --fail-fast is set.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := getClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var queries []smartBatchQuery
+			if err := json.Unmarshal(bz, &queries); err != nil {
+				return fmt.Errorf("decode queries: %w", err)
+			}
+			concurrency, err := cmd.Flags().GetInt(flagConcurrency)
+			if err != nil {
+				return err
+			}
+			if concurrency < 1 {
+				return errors.New("concurrency must be at least 1")
+			}
+			failFast, err := cmd.Flags().GetBool(flagFailFast)
+			if err != nil {
+				return err
+			}
+			for i, q := range queries {
+				// invalid addresses are reported with the result of the query
+				if addr, err := translateAddress(cmd, q.Address); err == nil {
+					queries[i].Address = addr
+				}
+			}
+			results, err := runSmartBatch(cmd.Context(), types.NewQueryClient(clientCtx), queries, concurrency, failFast)
+			if err != nil {
+				return err
+			}
+			bz, err = json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintRaw(bz)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Int(flagConcurrency, 1, "Number of queries that are run in parallel")
+	cmd.Flags().Bool(flagFailFast, false, "Abort with an error on the first failed query")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// smartBatchQuery is an entry of the batch file
+type smartBatchQuery struct {
+	Address string          `json:"address"`
+	Query   json.RawMessage `json:"query"`
+}
+
+// smartBatchResult is the result of a query. Either the data or the error is set.
+type smartBatchResult struct {
+	Index   int             `json:"index"`
+	Address string          `json:"address"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// runSmartBatch runs the queries with the given number of parallel queries. The results are in the order of the
+// queries. With fail fast, the error of the first failed query is returned and the remaining queries are skipped.
+func runSmartBatch(ctx context.Context, queryClient types.QueryClient, queries []smartBatchQuery, concurrency int, failFast bool) ([]smartBatchResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	results := make([]smartBatchResult, len(queries))
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for i, q := range queries {
+		g.Go(func() error {
+			results[i] = smartBatchResult{Index: i, Address: q.Address}
+			data, err := querySmartBatchEntry(gCtx, queryClient, q)
+			if err != nil {
+				if failFast {
+					return fmt.Errorf("query %d: %w", i, err)
+				}
+				results[i].Error = err.Error()
+				return nil
+			}
+			results[i].Data = data
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func querySmartBatchEntry(ctx context.Context, queryClient types.QueryClient, q smartBatchQuery) (json.RawMessage, error) {
+	if q.Address == "" {
+		return nil, errors.New("address must not be empty")
+	}
+	if len(q.Query) == 0 || !json.Valid(q.Query) {
+		return nil, errors.New("query data must be json")
+	}
+	// skip the remaining queries when one failed with fail fast
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	res, err := queryClient.SmartContractState(ctx, &types.QuerySmartContractStateRequest{
+		Address:   q.Address,
+		QueryData: types.RawContractMessage(q.Query),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isJSONData(res.Data) {
+		// embed other data base64 encoded
+		return json.Marshal([]byte(res.Data))
+	}
+	return json.RawMessage(res.Data), nil
+}
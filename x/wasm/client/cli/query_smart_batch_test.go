@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+type smartQueryClient struct {
+	types.QueryClient
+	calls   *atomic.Int32
+	results map[string][]byte
+}
+
+func (m smartQueryClient) SmartContractState(_ context.Context, in *types.QuerySmartContractStateRequest, _ ...grpc.CallOption) (*types.QuerySmartContractStateResponse, error) {
+	m.calls.Add(1)
+	data, ok := m.results[in.Address]
+	if !ok {
+		return nil, errors.New("unknown contract")
+	}
+	return &types.QuerySmartContractStateResponse{Data: data}, nil
+}
+
+func TestRunSmartBatch(t *testing.T) {
+	const myContract, otherContract = "contract1", "contract2"
+	queryClient := smartQueryClient{results: map[string][]byte{
+		myContract:    []byte(`{"count":1}`),
+		otherContract: []byte("not json"),
+	}}
+	query := json.RawMessage(`{"get_count":{}}`)
+
+	specs := map[string]struct {
+		queries     []smartBatchQuery
+		concurrency int
+		failFast    bool
+		exp         []smartBatchResult
+		expErr      bool
+		expCalls    int32
+	}{
+		"all succeed": {
+			queries:     []smartBatchQuery{{Address: myContract, Query: query}, {Address: otherContract, Query: query}},
+			concurrency: 1,
+			exp: []smartBatchResult{
+				{Index: 0, Address: myContract, Data: json.RawMessage(`{"count":1}`)},
+				{Index: 1, Address: otherContract, Data: json.RawMessage(`"bm90IGpzb24="`)},
+			},
+			expCalls: 2,
+		},
+		"failures reported inline": {
+			queries: []smartBatchQuery{
+				{Address: "unknown", Query: query},
+				{Address: myContract, Query: json.RawMessage(`{invalid`)},
+				{Query: query},
+				{Address: myContract, Query: query},
+			},
+			concurrency: 1,
+			exp: []smartBatchResult{
+				{Index: 0, Address: "unknown", Error: "unknown contract"},
+				{Index: 1, Address: myContract, Error: "query data must be json"},
+				{Index: 2, Error: "address must not be empty"},
+				{Index: 3, Address: myContract, Data: json.RawMessage(`{"count":1}`)},
+			},
+			expCalls: 2,
+		},
+		"results in order with concurrency": {
+			queries:     []smartBatchQuery{{Address: myContract, Query: query}, {Address: "unknown", Query: query}, {Address: myContract, Query: query}},
+			concurrency: 3,
+			exp: []smartBatchResult{
+				{Index: 0, Address: myContract, Data: json.RawMessage(`{"count":1}`)},
+				{Index: 1, Address: "unknown", Error: "unknown contract"},
+				{Index: 2, Address: myContract, Data: json.RawMessage(`{"count":1}`)},
+			},
+			expCalls: 3,
+		},
+		"fail fast": {
+			queries:     []smartBatchQuery{{Address: "unknown", Query: query}, {Address: myContract, Query: query}},
+			concurrency: 1,
+			failFast:    true,
+			expErr:      true,
+			expCalls:    1,
+		},
+		"empty batch": {
+			concurrency: 1,
+			exp:         []smartBatchResult{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient.calls = &atomic.Int32{}
+			// when
+			got, gotErr := runSmartBatch(context.Background(), queryClient, spec.queries, spec.concurrency, spec.failFast)
+			// then
+			assert.Equal(t, spec.expCalls, queryClient.calls.Load())
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
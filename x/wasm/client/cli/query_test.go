@@ -0,0 +1,626 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strconv"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestTranslateAddress(t *testing.T) {
+	addr := bytes.Repeat([]byte{1}, 20)
+	contractAddr := bytes.Repeat([]byte{2}, 32)
+	mustEncode := func(prefix string, bz []byte) string {
+		s, err := bech32.ConvertAndEncode(prefix, bz)
+		require.NoError(t, err)
+		return s
+	}
+
+	specs := map[string]struct {
+		prefix  string
+		src     string
+		exp     sdk.AccAddress
+		expWarn bool
+		expErr  bool
+	}{
+		"chain prefix without flag": {
+			src: sdk.AccAddress(addr).String(),
+			exp: addr,
+		},
+		"chain prefix with flag": {
+			prefix: sdk.GetConfig().GetBech32AccountAddrPrefix(),
+			src:    sdk.AccAddress(addr).String(),
+			exp:    addr,
+		},
+		"other prefix translated": {
+			prefix:  "osmo",
+			src:     mustEncode("osmo", addr),
+			exp:     addr,
+			expWarn: true,
+		},
+		"other prefix contract address translated": {
+			prefix:  "juno",
+			src:     mustEncode("juno", contractAddr),
+			exp:     contractAddr,
+			expWarn: true,
+		},
+		"other prefix without flag": {
+			src:    mustEncode("osmo", addr),
+			expErr: true,
+		},
+		"prefix does not match": {
+			prefix: "juno",
+			src:    mustEncode("osmo", addr),
+			expErr: true,
+		},
+		"unexpected length": {
+			prefix: "osmo",
+			src:    mustEncode("osmo", bytes.Repeat([]byte{1}, 21)),
+			expErr: true,
+		},
+		"invalid address": {
+			prefix: "osmo",
+			src:    "osmo1invalid",
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String(flagBech32Prefix, "", "")
+			require.NoError(t, cmd.Flags().Set(flagBech32Prefix, spec.prefix))
+			var stderr bytes.Buffer
+			cmd.SetErr(&stderr)
+
+			got, gotErr := translateAddress(cmd, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp.String(), got)
+			assert.Equal(t, spec.expWarn, stderr.Len() != 0)
+
+			if spec.prefix == "" {
+				return
+			}
+			// round trip back to the source
+			bz, err := sdk.GetFromBech32(got, sdk.GetConfig().GetBech32AccountAddrPrefix())
+			require.NoError(t, err)
+			back, err := bech32.ConvertAndEncode(spec.prefix, bz)
+			require.NoError(t, err)
+			assert.Equal(t, spec.src, back)
+		})
+	}
+}
+
+func TestSaltValidationConsistent(t *testing.T) {
+	creator := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	for n := 0; n <= 100; n++ {
+		salt := make([]byte, n)
+		_, err := rand.Read(salt)
+		require.NoError(t, err)
+		expErr := types.ValidateSalt(salt)
+		assert.Equal(t, n >= 1 && n <= types.MaxSaltSize, expErr == nil, "length %d", n)
+
+		// cli
+		_, cliErr := decodeSalt(newArgDecoder(hex.DecodeString), hex.EncodeToString(salt))
+		// msg
+		msgErr := types.MsgInstantiateContract2{
+			Sender: creator.String(),
+			CodeID: 1,
+			Label:  "foo",
+			Msg:    []byte(`{}`),
+			Salt:   salt,
+		}.ValidateBasic()
+		// query
+		_, queryErr := keeper.BuildAddressPredictable(&types.QueryBuildAddressRequest{
+			CodeHash:       "13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5",
+			CreatorAddress: creator.String(),
+			Salt:           hex.EncodeToString(salt),
+		})
+
+		for name, gotErr := range map[string]error{"cli": cliErr, "msg": msgErr, "query": queryErr} {
+			if expErr == nil {
+				assert.NoError(t, gotErr, "%s: length %d", name, n)
+				continue
+			}
+			assert.ErrorContains(t, gotErr, expErr.Error(), "%s: length %d", name, n)
+		}
+	}
+}
+
+func TestBuildAddressSaltEncodings(t *testing.T) {
+	const codeHash = "13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5"
+	creator := sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+	salt := []byte("my salt")
+	exp, err := keeper.BuildAddressPredictable(&types.QueryBuildAddressRequest{
+		CodeHash:       codeHash,
+		CreatorAddress: creator,
+		Salt:           hex.EncodeToString(salt),
+	})
+	require.NoError(t, err)
+	expOut := "address: " + exp.Address + "\nsalt: " + hex.EncodeToString(salt) + "\n"
+
+	specs := map[string]struct {
+		args      []string
+		expErrMsg string
+	}{
+		"hex by default": {
+			args: []string{hex.EncodeToString(salt)},
+		},
+		"hex": {
+			args: []string{hex.EncodeToString(salt), "--hex"},
+		},
+		"ascii": {
+			args: []string{string(salt), "--ascii"},
+		},
+		"base64": {
+			args: []string{base64.StdEncoding.EncodeToString(salt), "--b64"},
+		},
+		"ascii without flag": {
+			args:      []string{string(salt)},
+			expErrMsg: "use --hex, --ascii or --b64",
+		},
+		"multiple encodings": {
+			args:      []string{string(salt), "--ascii", "--b64"},
+			expErrMsg: "multiple decoding flags used",
+		},
+		"too long": {
+			args:      []string{string(bytes.Repeat([]byte{'a'}, types.MaxSaltSize+1)), "--ascii"},
+			expErrMsg: "salt must have 1 to 64 bytes",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := GetCmdBuildAddress()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append([]string{codeHash, creator}, spec.args...))
+
+			gotErr := cmd.Execute()
+			if spec.expErrMsg != "" {
+				require.ErrorContains(t, gotErr, spec.expErrMsg)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, expOut, out.String())
+		})
+	}
+}
+
+func TestBuildFullContractInfo(t *testing.T) {
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	res := &types.QueryContractInfoResponse{
+		Address:      "myAddress",
+		ContractInfo: types.ContractInfo{CodeID: 1, Label: "myLabel"},
+	}
+	specs := map[string]struct {
+		receipt    *types.ExecutionReceipt
+		expReceipt string
+	}{
+		"with receipt": {
+			receipt:    &types.ExecutionReceipt{Height: 7, Kind: types.ExecutionReceiptKindSudo, GasUsed: 100, Success: true},
+			expReceipt: `{"height":"7","kind":"EXECUTION_RECEIPT_KIND_SUDO","gas_used":"100","success":true}`,
+		},
+		"without receipt": {
+			expReceipt: `null`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := buildFullContractInfo(cdc, res, spec.receipt)
+			require.NoError(t, err)
+			var gotObj map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(got, &gotObj))
+			assert.JSONEq(t, `"myAddress"`, string(gotObj["address"]))
+			assert.Contains(t, string(gotObj["contract_info"]), `"label":"myLabel"`)
+			assert.JSONEq(t, spec.expReceipt, string(gotObj["execution_receipt"]))
+		})
+	}
+}
+
+func TestPrintContractInfoTable(t *testing.T) {
+	res := &types.QueryContractsByCreatorResponse{
+		ContractAddresses: []string{"first", "second"},
+		ContractInfos: []types.ContractInfoWithAddress{
+			{Address: "first", ContractInfo: types.ContractInfo{CodeID: 1, Label: "my label", Created: &types.AbsoluteTxPosition{BlockHeight: 10}}},
+			{Address: "second", ContractInfo: types.ContractInfo{CodeID: 12, Label: "other"}},
+		},
+		Pagination: &query.PageResponse{NextKey: []byte{0x1}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, printContractInfoTable(&buf, res))
+	exp := `ADDRESS  LABEL     CODE_ID  CREATED_HEIGHT
+first    my label  1        10
+second   other     12       0
+next page key: AQ==
+`
+	assert.Equal(t, exp, buf.String())
+}
+
+func TestPrintStateKeys(t *testing.T) {
+	res := &types.QueryAllContractStateResponse{
+		Models:     []types.Model{{Key: []byte{0x0, 0x1}}, {Key: []byte("foo")}},
+		Pagination: &query.PageResponse{NextKey: []byte{0x1}},
+	}
+	var out, errOut bytes.Buffer
+	require.NoError(t, printStateKeys(&out, &errOut, res))
+	assert.Equal(t, "0001\n666f6f\n", out.String())
+	assert.Equal(t, "next page key: AQ==\n", errOut.String())
+}
+
+func TestPrintDecodedData(t *testing.T) {
+	specs := map[string]struct {
+		src          []byte
+		outputFormat string
+		exp          string
+		expNotJSON   bool
+	}{
+		"text output indented": {
+			src:          []byte(`{"owner":"foo","count":1}`),
+			outputFormat: flags.OutputFormatText,
+			exp:          "{\n  \"owner\": \"foo\",\n  \"count\": 1\n}\n",
+		},
+		"json output embedded": {
+			src:          []byte(`{"owner":"foo"}`),
+			outputFormat: flags.OutputFormatJSON,
+			exp:          `{"data":{"owner":"foo"}}` + "\n",
+		},
+		"json string": {
+			src:          []byte(`"foo"`),
+			outputFormat: flags.OutputFormatJSON,
+			exp:          `{"data":"foo"}` + "\n",
+		},
+		"not json": {
+			src:        []byte("foo"),
+			expNotJSON: true,
+		},
+		"invalid utf-8": {
+			src:        []byte{'"', 0xff, '"'},
+			expNotJSON: true,
+		},
+		"empty": {
+			src:        []byte{},
+			expNotJSON: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expNotJSON {
+				assert.False(t, isJSONData(spec.src))
+				return
+			}
+			require.True(t, isJSONData(spec.src))
+			var out bytes.Buffer
+			require.NoError(t, printDecodedData(&out, spec.outputFormat, spec.src))
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
+
+func TestEncodeLegacyHistoryMsgs(t *testing.T) {
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	specs := map[string]struct {
+		src []types.ContractCodeHistoryEntry
+		exp string
+	}{
+		"json msgs inline": {
+			src: []types.ContractCodeHistoryEntry{
+				{Operation: types.ContractCodeHistoryOperationTypeInit, CodeID: 1, Msg: []byte(`{"foo":"bar"}`)},
+				{Operation: types.ContractCodeHistoryOperationTypeMigrate, CodeID: 2, Msg: []byte(`{}`)},
+			},
+			exp: `[{"foo":"bar"},{}]`,
+		},
+		"legacy non json msg base64 encoded": {
+			src: []types.ContractCodeHistoryEntry{
+				{Operation: types.ContractCodeHistoryOperationTypeInit, CodeID: 1, Msg: []byte("not json")},
+				{Operation: types.ContractCodeHistoryOperationTypeMigrate, CodeID: 2, Msg: []byte(`{}`)},
+			},
+			exp: `["bm90IGpzb24=",{}]`,
+		},
+		"invalid utf-8 base64 encoded": {
+			src: []types.ContractCodeHistoryEntry{
+				{Operation: types.ContractCodeHistoryOperationTypeInit, CodeID: 1, Msg: []byte{'"', 0xff, '"'}},
+			},
+			exp: `["Iv8i"]`,
+		},
+		"empty msg kept": {
+			src: []types.ContractCodeHistoryEntry{
+				{Operation: types.ContractCodeHistoryOperationTypeGenesis, CodeID: 1},
+			},
+			exp: `[null]`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// when
+			encodeLegacyHistoryMsgs(spec.src)
+			// then
+			bz, err := cdc.MarshalJSON(&types.QueryContractHistoryResponse{Entries: spec.src})
+			require.NoError(t, err)
+			var got struct {
+				Entries []struct {
+					Msg json.RawMessage `json:"msg"`
+				} `json:"entries"`
+			}
+			require.NoError(t, json.Unmarshal(bz, &got))
+			msgs := make([]json.RawMessage, len(got.Entries))
+			for i, e := range got.Entries {
+				msgs[i] = e.Msg
+			}
+			gotBz, err := json.Marshal(msgs)
+			require.NoError(t, err)
+			assert.JSONEq(t, spec.exp, string(gotBz))
+		})
+	}
+}
+
+func TestParseEventSizes(t *testing.T) {
+	specs := map[string]struct {
+		src    []string
+		exp    []types.EventSize
+		expErr bool
+	}{
+		"empty": {
+			src: []string{},
+			exp: []types.EventSize{},
+		},
+		"multiple": {
+			src: []string{"8:3:120", "2:0:0"},
+			exp: []types.EventSize{{TypeLength: 8, AttributeCount: 3, AttributeBytes: 120}, {TypeLength: 2}},
+		},
+		"missing part": {
+			src:    []string{"8:3"},
+			expErr: true,
+		},
+		"not a number": {
+			src:    []string{"8:x:120"},
+			expErr: true,
+		},
+		"negative": {
+			src:    []string{"8:-1:120"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseEventSizes(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestMultiQueryCommandPinsHeight(t *testing.T) {
+	contractAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	specs := map[string]struct {
+		args           []string
+		expHeight      int64
+		expStatusCalls int
+		expErr         bool
+	}{
+		"latest committed height resolved once": {
+			args:           []string{"contract", contractAddr, "--full"},
+			expHeight:      7,
+			expStatusCalls: 1,
+		},
+		"explicit height honored": {
+			args:      []string{"contract", contractAddr, "--full", "--height=3"},
+			expHeight: 3,
+		},
+		"at latest committed": {
+			args:           []string{"contract", contractAddr, "--full", "--at-latest-committed"},
+			expHeight:      7,
+			expStatusCalls: 1,
+		},
+		"at latest committed with single query": {
+			args:           []string{"contract", contractAddr, "--at-latest-committed"},
+			expHeight:      7,
+			expStatusCalls: 1,
+		},
+		"at latest committed with explicit height": {
+			args:   []string{"contract", contractAddr, "--at-latest-committed", "--height=3"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			node := &heightRecordingNode{latestHeight: 7}
+			clientCtx := client.Context{}.
+				WithClient(node).
+				WithCodec(codec.NewProtoCodec(codectypes.NewInterfaceRegistry())).
+				WithOutput(io.Discard)
+			cmd := GetQueryCmd()
+			cmd.SetArgs(spec.args)
+			cmd.SetErr(io.Discard)
+
+			// when
+			gotErr := cmd.ExecuteContext(context.WithValue(context.Background(), client.ClientContextKey, &clientCtx))
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			require.NotEmpty(t, node.queryHeights)
+			for _, h := range node.queryHeights {
+				assert.Equal(t, spec.expHeight, h)
+			}
+			assert.Equal(t, spec.expStatusCalls, node.statusCalls)
+		})
+	}
+}
+
+func TestPinQueryHeight(t *testing.T) {
+	specs := map[string]struct {
+		height         int64
+		expHeight      int64
+		expStatusCalls int
+	}{
+		"latest committed height": {
+			expHeight:      7,
+			expStatusCalls: 1,
+		},
+		"explicit height": {
+			height:    3,
+			expHeight: 3,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			node := &heightRecordingNode{latestHeight: 7}
+			clientCtx := client.Context{}.WithClient(node).WithHeight(spec.height)
+
+			// when
+			gotClientCtx, gotCtx, err := pinQueryHeight(context.Background(), clientCtx)
+			require.NoError(t, err)
+
+			// then the height header is carried by the go context
+			md, _ := metadata.FromOutgoingContext(gotCtx)
+			assert.Equal(t, []string{strconv.FormatInt(spec.expHeight, 10)}, md.Get(grpctypes.GRPCBlockHeightHeader))
+			assert.Equal(t, spec.expHeight, gotClientCtx.Height)
+			// and all queries of the bundle context run at the height, also when the client context is not pinned
+			_, err = queryBundleContext(gotCtx, types.NewQueryClient(clientCtx), bundleContext{Contract: "myContract", TargetCodeID: 2})
+			require.NoError(t, err)
+			assert.Equal(t, []int64{spec.expHeight, spec.expHeight, spec.expHeight}, node.queryHeights)
+			assert.Equal(t, spec.expStatusCalls, node.statusCalls)
+		})
+	}
+}
+
+// heightRecordingNode is a node mock that records the height of the ABCI queries and returns empty responses
+type heightRecordingNode struct {
+	client.CometRPC
+	latestHeight int64
+	statusCalls  int
+	queryHeights []int64
+}
+
+func (n *heightRecordingNode) Status(context.Context) (*coretypes.ResultStatus, error) {
+	n.statusCalls++
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: n.latestHeight}}, nil
+}
+
+func (n *heightRecordingNode) ABCIQueryWithOptions(_ context.Context, _ string, _ cmtbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	n.queryHeights = append(n.queryHeights, opts.Height)
+	return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Height: opts.Height}}, nil
+}
+
+// codeQueryClient returns the same bytecode for all code ids
+type codeQueryClient struct {
+	mockWasmQueryClient
+	byteCode []byte
+}
+
+func (m codeQueryClient) Code(_ context.Context, in *types.QueryCodeRequest, _ ...grpc.CallOption) (*types.QueryCodeResponse, error) {
+	return &types.QueryCodeResponse{CodeInfoResponse: &types.CodeInfoResponse{CodeID: in.CodeId}, Data: m.byteCode}, nil
+}
+
+func TestQueryCodeVerified(t *testing.T) {
+	myByteCode := []byte("my wasm code")
+	myChecksum := sha256.Sum256(myByteCode)
+	specs := map[string]struct {
+		byteCode []byte
+		checksum []byte
+		verify   bool
+		expErr   bool
+	}{
+		"checksum matches": {
+			byteCode: myByteCode,
+			checksum: myChecksum[:],
+			verify:   true,
+		},
+		"checksum mismatch": {
+			byteCode: myByteCode,
+			checksum: bytes.Repeat([]byte{1}, 32),
+			verify:   true,
+			expErr:   true,
+		},
+		"checksum mismatch not verified": {
+			byteCode: myByteCode,
+			checksum: bytes.Repeat([]byte{1}, 32),
+		},
+		"empty bytecode": {
+			checksum: myChecksum[:],
+			verify:   true,
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := codeQueryClient{
+				mockWasmQueryClient: mockWasmQueryClient{checksums: map[uint64][]byte{1: spec.checksum}},
+				byteCode:            spec.byteCode,
+			}
+			// when
+			got, gotErr := queryCodeVerified(context.Background(), queryClient, 1, spec.verify)
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.byteCode, got)
+		})
+	}
+}
+
+func TestParseAccessTypeFilter(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		exp    types.AccessType
+		expErr bool
+	}{
+		"empty":     {src: "", exp: types.AccessTypeUnspecified},
+		"everybody": {src: "everybody", exp: types.AccessTypeEverybody},
+		"nobody":    {src: "nobody", exp: types.AccessTypeNobody},
+		"any-of":    {src: "any-of", exp: types.AccessTypeAnyOfAddresses},
+		"unknown":   {src: "somebody", expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseAccessTypeFilter(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
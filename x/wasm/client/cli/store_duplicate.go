@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/client/input"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// confirmDuplicateCode prints the codes on chain with the same checksum and their instantiate permissions
+// compared to the permission of the upload. Unless skipConfirm is set, the user is asked to confirm the
+// duplicate upload. It returns false when the upload was not confirmed.
+func confirmDuplicateCode(ctx context.Context, queryClient types.QueryClient, checksum []byte, uploadPermission *types.AccessConfig, skipConfirm bool, in io.Reader, out io.Writer) (bool, error) {
+	res, err := queryClient.CodeByChecksum(ctx, &types.QueryCodeByChecksumRequest{Checksum: hex.EncodeToString(checksum)})
+	switch {
+	case errors.Is(err, types.ErrNotFound) || status.Code(err) == codes.NotFound:
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("code by checksum: %w", err)
+	}
+	if _, err := fmt.Fprintf(out, "code with checksum %x exists already:\n", checksum); err != nil {
+		return false, err
+	}
+	for _, codeID := range res.CodeIDs {
+		info, err := queryClient.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: codeID})
+		if err != nil {
+			return false, fmt.Errorf("code id %d: %w", codeID, err)
+		}
+		line := fmt.Sprintf("code id %d: %s", codeID, formatAccessConfig(info.InstantiatePermission))
+		if uploadPermission != nil {
+			if info.InstantiatePermission.Equals(*uploadPermission) {
+				line += " (same permission)"
+			} else {
+				line += fmt.Sprintf(" (differs from upload: %s)", formatAccessConfig(*uploadPermission))
+			}
+		}
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return false, err
+		}
+	}
+	if skipConfirm {
+		return true, nil
+	}
+	return input.GetConfirmation("upload duplicate code?", bufio.NewReader(in), out)
+}
+
+// formatAccessConfig returns the permission with the addresses when set
+func formatAccessConfig(c types.AccessConfig) string {
+	if len(c.Addresses) == 0 {
+		return c.Permission.String()
+	}
+	return fmt.Sprintf("%s %s", c.Permission, strings.Join(c.Addresses, ","))
+}
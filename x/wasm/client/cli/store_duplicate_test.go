@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestConfirmDuplicateCode(t *testing.T) {
+	checksum, err := hex.DecodeString(testdata.ChecksumHackatom)
+	require.NoError(t, err)
+	myAddr := "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	queryClient := codeByChecksumQueryClient{
+		checksum: testdata.ChecksumHackatom,
+		codes: map[uint64]types.AccessConfig{
+			1: types.AllowEverybody,
+			3: {Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{myAddr}},
+		},
+	}
+
+	specs := map[string]struct {
+		checksum    []byte
+		permission  *types.AccessConfig
+		skipConfirm bool
+		input       string
+		exp         bool
+		expOut      []string
+		expNoOut    bool
+	}{
+		"no duplicate": {
+			checksum: bytes.Repeat([]byte{1}, 32),
+			exp:      true,
+			expNoOut: true,
+		},
+		"duplicate confirmed": {
+			checksum: checksum,
+			input:    "y\n",
+			exp:      true,
+			expOut:   []string{"code id 1: Everybody\n", "code id 3: AnyOfAddresses " + myAddr + "\n"},
+		},
+		"duplicate declined": {
+			checksum: checksum,
+			input:    "n\n",
+			exp:      false,
+		},
+		"duplicate with skip confirm": {
+			checksum:    checksum,
+			skipConfirm: true,
+			exp:         true,
+		},
+		"permission diff": {
+			checksum:    checksum,
+			permission:  &types.AllowEverybody,
+			skipConfirm: true,
+			exp:         true,
+			expOut:      []string{"code id 1: Everybody (same permission)\n", "code id 3: AnyOfAddresses " + myAddr + " (differs from upload: Everybody)\n"},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			// when
+			got, gotErr := confirmDuplicateCode(context.Background(), queryClient, spec.checksum, spec.permission, spec.skipConfirm, strings.NewReader(spec.input), &out)
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+			if spec.expNoOut {
+				assert.Empty(t, out.String())
+			}
+			for _, exp := range spec.expOut {
+				assert.Contains(t, out.String(), exp)
+			}
+			if spec.skipConfirm {
+				assert.NotContains(t, out.String(), "upload duplicate code?")
+			}
+		})
+	}
+}
+
+// codeByChecksumQueryClient returns the codes for the checksum only
+type codeByChecksumQueryClient struct {
+	types.QueryClient
+	checksum string
+	codes    map[uint64]types.AccessConfig
+}
+
+func (m codeByChecksumQueryClient) CodeByChecksum(_ context.Context, in *types.QueryCodeByChecksumRequest, _ ...grpc.CallOption) (*types.QueryCodeByChecksumResponse, error) {
+	if in.Checksum != m.checksum {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "code with checksum %s", in.Checksum)
+	}
+	return &types.QueryCodeByChecksumResponse{CodeIDs: []uint64{1, 3}}, nil
+}
+
+func (m codeByChecksumQueryClient) CodeInfo(_ context.Context, in *types.QueryCodeInfoRequest, _ ...grpc.CallOption) (*types.QueryCodeInfoResponse, error) {
+	return &types.QueryCodeInfoResponse{CodeID: in.CodeId, InstantiatePermission: m.codes[in.CodeId]}, nil
+}
@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/version"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// txInclusionWaitTimeout is the max time to wait for the inclusion of a synchronously broadcasted tx
+const txInclusionWaitTimeout = time.Minute
+
+// StoreAndInstantiateContractCmd uploads a wasm binary and instantiates a contract from it in a single tx
+func StoreAndInstantiateContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store-instantiate [wasm file] [json_encoded_init_args] --label [text] --admin [address,optional] --amount [coins,optional]",
+		Short: "Upload a wasm binary and instantiate a contract from it in a single tx",
+		Long: fmt.Sprintf(`Upload a wasm binary and instantiate a contract from it in a single tx.
+The tx contains a store code message followed by an instantiate message for the code id that the upload gets,
+which is the id after the last code on chain. When another upload takes this code id first, the contract is
+instantiated from the other code. This is detected after the inclusion of the tx and reported as error.
+With the sync broadcast mode, the command waits for the inclusion of the tx and prints the code id and
+the contract address.
+
+With --as-proposal or --authority, a single store and instantiate message with the authority as signer is
+printed as draft proposal instead.
+
+Example:
+$ %s tx wasm store-instantiate contract.wasm '{"foo":"bar"}' --admin="$(%s keys show mykey -a)" \
+  --from mykey --amount="100ustake" --label "local0.1.0"
+`, version.AppName, version.AppName),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			asProposal, err := cmd.Flags().GetBool(flagAsProposal)
+			if err != nil {
+				return err
+			}
+			if asProposal || cmd.Flags().Changed(flagAuthority) {
+				return draftStoreAndInstantiateProposal(clientCtx, cmd.Flags(), args[0], args[1])
+			}
+
+			sender := clientCtx.GetFromAddress().String()
+			storeMsg, err := parseStoreCodeArgs(args[0], sender, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			lastCodeID, err := queryLastCodeID(cmd.Context(), types.NewQueryClient(clientCtx))
+			if err != nil {
+				return fmt.Errorf("last code id: %w", err)
+			}
+			instantiateMsg, err := parseInstantiateArgs(strconv.FormatUint(lastCodeID+1, 10), args[1], clientCtx.Keyring, sender, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			msgs := []sdk.Msg{&storeMsg, instantiateMsg}
+			if clientCtx.GenerateOnly || clientCtx.Simulate || clientCtx.BroadcastMode != flags.BroadcastSync {
+				return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+			}
+			return broadcastStoreAndInstantiate(cmd.Context(), clientCtx, cmd.Flags(), msgs)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
+	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
+	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
+	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagUnpinCode, false, "Unpin code on upload with --as-proposal, optional")
+	addInstantiatePermissionFlags(cmd)
+	addAsProposalFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// draftStoreAndInstantiateProposal prints the store and instantiate message with the authority as signer as draft proposal
+func draftStoreAndInstantiateProposal(clientCtx client.Context, flagSet *flag.FlagSet, file, initMsg string) error {
+	authority, err := flagSet.GetString(flagAuthority)
+	if err != nil {
+		return fmt.Errorf("authority: %s", err)
+	}
+	if len(authority) == 0 {
+		return errors.New("authority address is required")
+	}
+	storeMsg, err := parseStoreCodeArgs(file, authority, flagSet)
+	if err != nil {
+		return err
+	}
+	// The code id is not used. But this allows us to reuse parseInstantiateArgs.
+	instantiateMsg, err := parseInstantiateArgs("1", initMsg, clientCtx.Keyring, authority, flagSet)
+	if err != nil {
+		return err
+	}
+	unpinCode, err := flagSet.GetBool(flagUnpinCode)
+	if err != nil {
+		return err
+	}
+	msg := types.MsgStoreAndInstantiateContract{
+		Authority:             authority,
+		WASMByteCode:          storeMsg.WASMByteCode,
+		InstantiatePermission: storeMsg.InstantiatePermission,
+		UnpinCode:             unpinCode,
+		Admin:                 instantiateMsg.Admin,
+		Label:                 instantiateMsg.Label,
+		Msg:                   instantiateMsg.Msg,
+		Funds:                 instantiateMsg.Funds,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	bz, err := buildDraftProposal(clientCtx.Codec, authority, &msg)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintRaw(bz)
+}
+
+// queryLastCodeID returns the id of the last stored code or 0 when there is none
+func queryLastCodeID(ctx context.Context, queryClient types.QueryClient) (uint64, error) {
+	res, err := queryClient.Codes(ctx, &types.QueryCodesRequest{
+		Pagination: &query.PageRequest{Limit: 1, Reverse: true},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(res.CodeInfos) == 0 {
+		return 0, nil
+	}
+	return res.CodeInfos[0].CodeID, nil
+}
+
+// storeInstantiateResult is the outcome of an included store and instantiate tx
+type storeInstantiateResult struct {
+	TxHash          string `json:"txhash"`
+	Height          int64  `json:"height,string"`
+	CodeID          uint64 `json:"code_id,string"`
+	ContractAddress string `json:"contract_address"`
+}
+
+// broadcastStoreAndInstantiate broadcasts the tx, waits for its inclusion and prints the code id and contract address
+func broadcastStoreAndInstantiate(ctx context.Context, clientCtx client.Context, flagSet *flag.FlagSet, msgs []sdk.Msg) error {
+	included, err := broadcastAndWait(ctx, clientCtx, flagSet, msgs...)
+	if err != nil || included == nil {
+		return err
+	}
+	result, err := parseStoreInstantiateEvents(included.Events)
+	if err != nil {
+		return fmt.Errorf("tx %s: %w", included.TxHash, err)
+	}
+	result.TxHash, result.Height = included.TxHash, included.Height
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintRaw(bz)
+}
+
+// broadcastAndWait broadcasts the tx and waits for its inclusion. Nil is returned when the tx was canceled by
+// the user or failed. The response of a failed tx is printed.
+func broadcastAndWait(ctx context.Context, clientCtx client.Context, flagSet *flag.FlagSet, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	var out bytes.Buffer
+	if err := tx.GenerateOrBroadcastTxCLI(clientCtx.WithOutput(&out).WithOutputFormat(flags.OutputFormatJSON), flagSet, msgs...); err != nil {
+		return nil, err
+	}
+	if out.Len() == 0 { // canceled by the user
+		return nil, nil
+	}
+	var res sdk.TxResponse
+	if err := clientCtx.Codec.UnmarshalJSON(out.Bytes(), &res); err != nil {
+		return nil, err
+	}
+	if res.Code != 0 {
+		return nil, clientCtx.PrintProto(&res)
+	}
+	included, err := waitForTx(ctx, clientCtx, res.TxHash, txInclusionWaitTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if included.Code != 0 {
+		return nil, clientCtx.PrintProto(included)
+	}
+	return included, nil
+}
+
+// waitForTx polls the node until the tx is included in a block or the timeout is reached
+func waitForTx(ctx context.Context, clientCtx client.Context, hash string, timeout time.Duration) (*sdk.TxResponse, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		res, err := authtx.QueryTx(clientCtx, hash)
+		if err == nil {
+			return res, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("tx %s not included after %s: %w", hash, timeout, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// parseStoreInstantiateEvents returns the code id and contract address from the events of a store and instantiate tx.
+// An error is returned when the contract was not instantiated from the uploaded code.
+func parseStoreInstantiateEvents(events []abci.Event) (storeInstantiateResult, error) {
+	var (
+		result                       storeInstantiateResult
+		storedCodeID, instantiatedID string
+	)
+	for _, e := range events {
+		switch e.Type {
+		case types.EventTypeStoreCode:
+			if storedCodeID == "" {
+				storedCodeID = eventAttribute(e, types.AttributeKeyCodeID)
+			}
+		case types.EventTypeInstantiate:
+			if result.ContractAddress == "" {
+				result.ContractAddress = eventAttribute(e, types.AttributeKeyContractAddr)
+				instantiatedID = eventAttribute(e, types.AttributeKeyCodeID)
+			}
+		}
+	}
+	if storedCodeID == "" {
+		return result, errors.New("no store code event")
+	}
+	if result.ContractAddress == "" {
+		return result, errors.New("no instantiate event")
+	}
+	if storedCodeID != instantiatedID {
+		return result, fmt.Errorf("contract %s was instantiated from code %s instead of the uploaded code %s", result.ContractAddress, instantiatedID, storedCodeID)
+	}
+	codeID, err := strconv.ParseUint(storedCodeID, 10, 64)
+	if err != nil {
+		return result, fmt.Errorf("code id: %w", err)
+	}
+	result.CodeID = codeID
+	return result, nil
+}
+
+// eventAttribute returns the value of the first attribute with the key or an empty string
+func eventAttribute(e abci.Event, key string) string {
+	for _, a := range e.Attributes {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestParseStoreInstantiateEvents(t *testing.T) {
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	const otherContract = "cosmos1suhgf5svhu4usrurvxzlgn54ksxmn8gljarjtxqnapv8kjnp4nrsgl3t8n"
+	storeEvent := func(codeID string) abci.Event {
+		return abci.Event{Type: types.EventTypeStoreCode, Attributes: []abci.EventAttribute{
+			{Key: types.AttributeKeyChecksum, Value: "aa"},
+			{Key: types.AttributeKeyCodeID, Value: codeID},
+		}}
+	}
+	instantiateEvent := func(contract, codeID string) abci.Event {
+		return abci.Event{Type: types.EventTypeInstantiate, Attributes: []abci.EventAttribute{
+			{Key: types.AttributeKeyContractAddr, Value: contract},
+			{Key: types.AttributeKeyCodeID, Value: codeID},
+		}}
+	}
+	specs := map[string]struct {
+		src    []abci.Event
+		exp    storeInstantiateResult
+		expErr bool
+	}{
+		"uploaded code instantiated": {
+			src: []abci.Event{{Type: "message"}, storeEvent("7"), instantiateEvent(myContract, "7")},
+			exp: storeInstantiateResult{CodeID: 7, ContractAddress: myContract},
+		},
+		"sub-contract instantiated by the contract": {
+			src: []abci.Event{storeEvent("7"), instantiateEvent(myContract, "7"), instantiateEvent(otherContract, "1")},
+			exp: storeInstantiateResult{CodeID: 7, ContractAddress: myContract},
+		},
+		"other code instantiated": {
+			src:    []abci.Event{storeEvent("8"), instantiateEvent(myContract, "7")},
+			expErr: true,
+		},
+		"no store event": {
+			src:    []abci.Event{instantiateEvent(myContract, "7")},
+			expErr: true,
+		},
+		"no instantiate event": {
+			src:    []abci.Event{storeEvent("7")},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseStoreInstantiateEvents(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package cli
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -17,10 +20,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/authz"
 
 	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -33,6 +38,7 @@ const (
 	flagAdmin                     = "admin"
 	flagNoAdmin                   = "no-admin"
 	flagFixMsg                    = "fix-msg"
+	flagNotifyAdminChange         = "notify-admin-change"
 	flagRunAs                     = "run-as"
 	flagInstantiateByEverybody    = "instantiate-everybody"
 	flagInstantiateNobody         = "instantiate-nobody"
@@ -46,8 +52,44 @@ const (
 	flagMaxFunds                  = "max-funds"
 	flagAllowAllMsgs              = "allow-all-messages"
 	flagNoTokenTransfer           = "no-token-transfer"
+	flagAllowedCodeIDs            = "allowed-code-ids"
+	flagCacheTTL                  = "cache-ttl"
 	flagAuthority                 = "authority"
+	flagAsProposal                = "as-proposal"
+	flagIncludeValues             = "include-values"
 	flagExpedite                  = "expedite"
+	flagBech32Prefix              = "bech32-prefix"
+	flagChunkSize                 = "chunk-size"
+	flagUploadID                  = "upload-id"
+	flagAllowDuplicate            = "allow-duplicate"
+	flagFull                      = "full"
+	flagWithInfo                  = "with-info"
+	flagPrefix                    = "prefix"
+	flagStartAfter                = "start-after"
+	flagSuspendedOnly             = "suspended-only"
+	flagNoVerify                  = "no-verify"
+	flagKeysOnly                  = "keys-only"
+	flagAttributeCount            = "attribute-count"
+	flagAttributeBytes            = "attribute-bytes"
+	flagEvent                     = "event"
+	flagProvenanceSignature       = "provenance-signature"
+	flagAtLatestCommitted         = "at-latest-committed"
+	flagTargetCodeID              = "target-code-id"
+	flagImportAddress             = "import-address"
+	flagMaxFeePerTx               = "max-fee-per-tx"
+	flagMaxFeePerBlock            = "max-fee-per-block"
+	flagDecode                    = "decode"
+	flagConcurrency               = "concurrency"
+	flagFailFast                  = "fail-fast"
+	flagRaw                       = "raw"
+	flagCreator                   = "creator"
+	flagNoCompress                = "no-compress"
+	flagInstantiatePermission     = "instantiate-permission"
+	flagPredictPort               = "predict-port"
+	flagConsumeOnce               = "consume-once"
+	flagDiagnostics               = "diagnostics"
+	flagDryRunAddress             = "dry-run-address"
+	flagChangelog                 = "changelog"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -64,7 +106,9 @@ func GetTxCmd() *cobra.Command {
 		StoreCodeCmd(),
 		InstantiateContractCmd(),
 		InstantiateContract2Cmd(),
+		StoreAndInstantiateContractCmd(),
 		ExecuteContractCmd(),
+		MultiExecuteCmd(),
 		MigrateContractCmd(),
 		UpdateContractAdminCmd(),
 		ClearContractAdminCmd(),
@@ -72,6 +116,27 @@ func GetTxCmd() *cobra.Command {
 		UpdateInstantiateConfigCmd(),
 		SubmitProposalCmd(),
 		UpdateContractLabelCmd(),
+		SetQueryAliasCmd(),
+		RemoveQueryAliasCmd(),
+		SetIBCSendTimeoutCmd(),
+		SetAdminChangeNotificationCmd(),
+		SetContractTagsCmd(),
+		SetContractFeeSponsorshipCmd(),
+		ScheduleContractSunsetCmd(),
+		CancelContractSunsetCmd(),
+		ProposeAdminTransferCmd(),
+		AcceptAdminTransferCmd(),
+		CancelAdminTransferCmd(),
+		SuspendContractCmd(),
+		ResumeContractCmd(),
+		SetIgnoreReplyErrorCmd(),
+		BundleCmd(),
+		VerifyBundleCmd(),
+		SignProvenanceCmd(),
+		AttestCodeCmd(),
+		VoteCodeVerificationCmd(),
+		PinCodesCmd(),
+		UnpinCodesCmd(),
 	)
 	return txCmd
 }
@@ -79,8 +144,13 @@ func GetTxCmd() *cobra.Command {
 // StoreCodeCmd will upload code to be reused.
 func StoreCodeCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "store [wasm file]",
-		Short:   "Upload a wasm binary",
+		Use:   "store [wasm file]",
+		Short: "Upload a wasm binary",
+		Long: `Upload a wasm binary. Raw wasm is gzipped before the upload unless --no-compress is set, gzip files are
+uploaded as is. The sizes and the checksum of the uncompressed wasm, which is the code checksum on chain, are printed.
+When codes with the same checksum exist on chain, their code ids and instantiate permissions are printed and
+the duplicate upload must be confirmed, unless --yes or --allow-duplicate is set. The check is skipped
+in offline, generate only and dry run modes.`,
 		Aliases: []string{"upload", "st", "s"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -88,35 +158,150 @@ func StoreCodeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			msg, err := parseStoreCodeArgs(args[0], clientCtx.GetFromAddress().String(), cmd.Flags())
+			sender, err := txSender(clientCtx, cmd.Flags())
 			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			msg, err := parseStoreCodeArgs(args[0], sender, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			if err := parseProvenanceFlags(&msg, cmd.Flags()); err != nil {
+				return err
+			}
+			checksum, err := printStoreCodeSummary(cmd.ErrOrStderr(), msg.WASMByteCode)
+			if err != nil {
+				return err
+			}
+			asProposal, err := cmd.Flags().GetBool(flagAsProposal)
+			if err != nil {
+				return err
+			}
+			if !asProposal && !clientCtx.Offline && !clientCtx.GenerateOnly && !clientCtx.Simulate {
+				allowDuplicate, err := cmd.Flags().GetBool(flagAllowDuplicate)
+				if err != nil {
+					return err
+				}
+				ok, err := confirmDuplicateCode(cmd.Context(), types.NewQueryClient(clientCtx), checksum, msg.InstantiatePermission,
+					allowDuplicate || clientCtx.SkipConfirm, clientCtx.Input, cmd.ErrOrStderr())
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return errors.New("upload canceled")
+				}
+			}
+			chunkSize, err := cmd.Flags().GetInt(flagChunkSize)
+			if err != nil {
+				return err
+			}
+			if chunkSize > 0 && len(msg.ProvenanceSignature) != 0 {
+				return errors.New("provenance can not be set on chunked uploads")
+			}
+			if chunkSize <= 0 {
+				return generateOrBroadcastOrDraftProposal(clientCtx, cmd.Flags(), &msg)
+			}
+			uploadID, err := cmd.Flags().GetString(flagUploadID)
+			if err != nil {
+				return err
+			}
+			msgs, err := splitStoreCodeMsg(msg, chunkSize, uploadID)
+			if err != nil {
+				return err
+			}
+			if asProposal { // fails for the chunk messages that have no authority-gated variant
+				return generateOrBroadcastOrDraftProposal(clientCtx, cmd.Flags(), msgs...)
+			}
+			return generateOrBroadcastSequentially(clientCtx, cmd.Flags(), msgs)
 		},
 		SilenceUsage: true,
 	}
 
 	addInstantiatePermissionFlags(cmd)
+	cmd.Flags().Bool(flagNoCompress, false, "Upload a raw wasm file as is instead of gzip compressing it")
+	cmd.Flags().Int(flagChunkSize, 0, "Upload the code in chunks of the given size in bytes, one transaction per chunk. Use for codes that do not fit into a single transaction")
+	cmd.Flags().String(flagUploadID, "", "Upload id of a chunked upload, a random id is used when not set")
+	cmd.Flags().Bool(flagAllowDuplicate, false, "Upload the code without confirmation when codes with the same checksum exist on chain")
+	cmd.Flags().String(flagSource, "", "Code Source URL of the provenance, see sign-provenance")
+	cmd.Flags().String(flagBuilder, "", "Builder docker image of the provenance, see sign-provenance")
+	cmd.Flags().BytesHex(flagProvenanceSignature, nil, "Provenance signature of the sender, see sign-provenance")
+	addAsProposalFlags(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
-// Prepares MsgStoreCode object from flags with gzipped wasm byte code field
+// splitStoreCodeMsg splits the store code message into chunk messages and the final message that completes the upload
+func splitStoreCodeMsg(msg types.MsgStoreCode, chunkSize int, uploadID string) ([]sdk.Msg, error) {
+	wasm := msg.WASMByteCode
+	if ioutils.IsGzip(wasm) {
+		var err error
+		if wasm, err = ioutils.Uncompress(wasm, int64(types.MaxWasmSize)); err != nil {
+			return nil, err
+		}
+	}
+	checksum := sha256.Sum256(wasm)
+	if uploadID == "" {
+		uploadID = fmt.Sprintf("%x-%d", checksum[:8], time.Now().UnixNano())
+	}
+
+	code := msg.WASMByteCode
+	total := (len(code) + chunkSize - 1) / chunkSize
+	msgs := make([]sdk.Msg, 0, total+1)
+	for i := 0; i < total; i++ {
+		end := min((i+1)*chunkSize, len(code))
+		msgs = append(msgs, &types.MsgStoreCodeChunk{
+			Sender:   msg.Sender,
+			UploadID: uploadID,
+			Index:    uint32(i),
+			Total:    uint32(total),
+			Data:     code[i*chunkSize : end],
+		})
+	}
+	msgs = append(msgs, &types.MsgCompleteStoreCode{
+		Sender:                msg.Sender,
+		UploadID:              uploadID,
+		Checksum:              checksum[:],
+		InstantiatePermission: msg.InstantiatePermission,
+	})
+	for _, m := range msgs {
+		if err := m.(sdk.HasValidateBasic).ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
+
+// generateOrBroadcastSequentially generates or broadcasts a transaction for each message with increasing sequences
+func generateOrBroadcastSequentially(clientCtx client.Context, flagSet *flag.FlagSet, msgs []sdk.Msg) error {
+	txf, err := tx.NewFactoryCLI(clientCtx, flagSet)
+	if err != nil {
+		return err
+	}
+	if !clientCtx.GenerateOnly {
+		if txf, err = txf.Prepare(clientCtx); err != nil {
+			return err
+		}
+	}
+	for _, msg := range msgs {
+		if err := tx.GenerateOrBroadcastTxWithFactory(clientCtx, txf, msg); err != nil {
+			return err
+		}
+		txf = txf.WithSequence(txf.Sequence() + 1)
+	}
+	return nil
+}
+
+// Prepares MsgStoreCode object from flags with the wasm byte code field gzipped unless --no-compress is set
 func parseStoreCodeArgs(file, sender string, flags *flag.FlagSet) (types.MsgStoreCode, error) {
 	wasm, err := os.ReadFile(file)
 	if err != nil {
 		return types.MsgStoreCode{}, err
 	}
-
-	// gzip the wasm file
-	if ioutils.IsWasm(wasm) {
-		wasm, err = ioutils.GzipIt(wasm)
-		if err != nil {
-			return types.MsgStoreCode{}, err
-		}
-	} else if !ioutils.IsGzip(wasm) {
-		return types.MsgStoreCode{}, errors.New("invalid input file. Use wasm binary or gzip")
+	// the flag is not registered on the other commands that upload code
+	noCompress, _ := flags.GetBool(flagNoCompress)
+	wasm, err = prepareWasmUpload(wasm, !noCompress)
+	if err != nil {
+		return types.MsgStoreCode{}, fmt.Errorf("%s: %w", file, err)
 	}
 
 	perm, err := parseAccessConfigFlags(flags)
@@ -132,6 +317,109 @@ func parseStoreCodeArgs(file, sender string, flags *flag.FlagSet) (types.MsgStor
 	return msg, msg.ValidateBasic()
 }
 
+// prepareWasmUpload returns the code to upload. Gzip input is returned as is and raw wasm is gzipped when
+// compress is set. Any other input is rejected.
+func prepareWasmUpload(code []byte, compress bool) ([]byte, error) {
+	switch {
+	case ioutils.IsGzip(code):
+		return code, nil
+	case !ioutils.IsWasm(code):
+		return nil, errors.New("invalid input file: neither a wasm binary nor gzip compressed")
+	case !compress:
+		return code, nil
+	}
+	return ioutils.GzipIt(code)
+}
+
+// printStoreCodeSummary prints the sizes of the wasm and the uploaded code and returns the checksum. The checksum
+// is of the uncompressed wasm, which is the checksum of the code on chain.
+func printStoreCodeSummary(out io.Writer, code []byte) ([]byte, error) {
+	wasm := code
+	if ioutils.IsGzip(code) {
+		var err error
+		if wasm, err = ioutils.Uncompress(code, int64(types.MaxWasmSize)); err != nil {
+			return nil, err
+		}
+	}
+	checksum := sha256.Sum256(wasm)
+	_, err := fmt.Fprintf(out, "wasm size: %d bytes, upload size: %d bytes, checksum: %x\n", len(wasm), len(code), checksum)
+	return checksum[:], err
+}
+
+// parseProvenanceFlags sets the optional code provenance on the store code message
+func parseProvenanceFlags(msg *types.MsgStoreCode, flags *flag.FlagSet) error {
+	source, err := flags.GetString(flagSource)
+	if err != nil {
+		return fmt.Errorf("source: %s", err)
+	}
+	builder, err := flags.GetString(flagBuilder)
+	if err != nil {
+		return fmt.Errorf("builder: %s", err)
+	}
+	signature, err := flags.GetBytesHex(flagProvenanceSignature)
+	if err != nil {
+		return fmt.Errorf("provenance signature: %s", err)
+	}
+	msg.Source, msg.Builder, msg.ProvenanceSignature = source, builder, signature
+	return msg.ValidateBasic()
+}
+
+// SignProvenanceCmd signs the provenance of a wasm code with the key of the future uploader
+func SignProvenanceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-provenance [wasm file]",
+		Short: "Sign the source and builder of a wasm binary for the upload",
+		Long: fmt.Sprintf(`Sign the checksum, source and builder of a wasm binary with the key that uploads the code.
+The hex encoded signature is printed and can be passed to the store command with the same source and builder.
+
+Example:
+$ %s tx wasm sign-provenance contract.wasm --code-source-url https://github.com/org/repo/tree/v1.0.0 \
+  --builder cosmwasm/optimizer:0.16.0 --from mykey
+`, version.AppName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			wasm, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			if ioutils.IsGzip(wasm) {
+				if wasm, err = ioutils.Uncompress(wasm, int64(types.MaxWasmSize)); err != nil {
+					return err
+				}
+			} else if !ioutils.IsWasm(wasm) {
+				return errors.New("invalid input file. Use wasm binary or gzip")
+			}
+			source, err := cmd.Flags().GetString(flagSource)
+			if err != nil {
+				return fmt.Errorf("source: %s", err)
+			}
+			builder, err := cmd.Flags().GetString(flagBuilder)
+			if err != nil {
+				return fmt.Errorf("builder: %s", err)
+			}
+			// checked with a placeholder signature for early feedback on the source and builder
+			if err := types.ValidateProvenance(source, builder, []byte{0x1}); err != nil {
+				return err
+			}
+			checksum := sha256.Sum256(wasm)
+			signature, _, err := clientCtx.Keyring.Sign(clientCtx.FromName, types.CodeProvenanceSignBytes(checksum[:], source, builder), signing.SignMode_SIGN_MODE_DIRECT)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintString(hex.EncodeToString(signature) + "\n")
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagSource, "", "Code Source URL is a valid absolute HTTPS URI to the contract's source code")
+	cmd.Flags().String(flagBuilder, "", "Builder is a valid docker image name with tag, such as \"cosmwasm/optimizer:0.16.0\"")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 func parseAccessConfigFlags(flags *flag.FlagSet) (*types.AccessConfig, error) {
 	addrs, err := flags.GetStringSlice(flagInstantiateByAnyOfAddress)
 	if err != nil {
@@ -203,18 +491,28 @@ Each contract instance has a unique address assigned.
 Example:
 $ %s tx wasm instantiate 1 '{"foo":"bar"}' --admin="$(%s keys show mykey -a)" \
   --from mykey --amount="100ustake" --label "local0.1.0"
-`, version.AppName, version.AppName),
+
+The init message can be read from a file or from stdin with --msg-file instead of the argument:
+$ jq -c . init.json | %s tx wasm instantiate 1 --msg-file - --no-admin --from mykey --label "local0.1.0"
+`, version.AppName, version.AppName, version.AppName),
 		Aliases: []string{"start", "init", "inst", "i"},
-		Args:    cobra.ExactArgs(2),
+		Args:    cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			msg, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, clientCtx.GetFromAddress().String(), cmd.Flags())
+			initMsg, err := readMsgArg(cmd, args, 1)
 			if err != nil {
 				return err
 			}
+			msg, err := parseInstantiateArgs(args[0], initMsg, clientCtx.Keyring, clientCtx.GetFromAddress().String(), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			if msg.NotifyAdminChange, err = cmd.Flags().GetBool(flagNotifyAdminChange); err != nil {
+				return fmt.Errorf("notify admin change: %w", err)
+			}
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
 		},
 		SilenceUsage: true,
@@ -224,6 +522,8 @@ $ %s tx wasm instantiate 1 '{"foo":"bar"}' --admin="$(%s keys show mykey -a)" \
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
 	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagNotifyAdminChange, false, "Notify the contract via sudo when the admin is updated or cleared")
+	addMsgFileFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -243,6 +543,9 @@ Predictable address example (also see '%s query wasm build-address -h'):
 $ %s tx wasm instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --admin="$(%s keys show mykey -a)" \
   --from mykey --amount="100ustake" --label "local0.1.0" \
    --fix-msg
+
+With --dry-run-address, the address that the contract gets is printed without signing or broadcasting the tx.
+The checksum of the code is queried from the node.
 `, version.AppName, version.AppName, version.AppName),
 		Aliases: []string{"start", "init", "inst", "i"},
 		Args:    cobra.ExactArgs(3),
@@ -251,14 +554,18 @@ $ %s tx wasm instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --adm
 			if err != nil {
 				return err
 			}
-			salt, err := decoder.DecodeString(args[2])
+			salt, err := decodeSalt(decoder, args[2])
 			if err != nil {
-				return fmt.Errorf("salt: %w", err)
+				return err
 			}
 			fixMsg, err := cmd.Flags().GetBool(flagFixMsg)
 			if err != nil {
 				return fmt.Errorf("fix msg: %w", err)
 			}
+			notifyAdminChange, err := cmd.Flags().GetBool(flagNotifyAdminChange)
+			if err != nil {
+				return fmt.Errorf("notify admin change: %w", err)
+			}
 			data, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, clientCtx.GetFromAddress().String(), cmd.Flags())
 			if err != nil {
 				return err
@@ -272,6 +579,20 @@ $ %s tx wasm instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --adm
 				Funds:  data.Funds,
 				Salt:   salt,
 				FixMsg: fixMsg,
+
+				NotifyAdminChange: notifyAdminChange,
+			}
+			dryRunAddress, err := cmd.Flags().GetBool(flagDryRunAddress)
+			if err != nil {
+				return fmt.Errorf("dry run address: %w", err)
+			}
+			if dryRunAddress {
+				addr, err := predictInstantiate2Address(cmd.Context(), types.NewQueryClient(clientCtx), msg)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "address: %s\n", addr)
+				return nil
 			}
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
 		},
@@ -283,11 +604,33 @@ $ %s tx wasm instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --adm
 	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
 	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
 	cmd.Flags().Bool(flagFixMsg, false, "An optional flag to include the json_encoded_init_args for the predictable address generation mode")
+	cmd.Flags().Bool(flagNotifyAdminChange, false, "Notify the contract via sudo when the admin is updated or cleared")
+	cmd.Flags().Bool(flagDryRunAddress, false, "Print the address that the contract gets and exit without signing or broadcasting")
 	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+// predictInstantiate2Address returns the address that the chain assigns to the contract of the instantiate2 message.
+// The address generator of the msg server is used with the checksum of the code from the node.
+func predictInstantiate2Address(ctx context.Context, queryClient types.QueryClient, msg *types.MsgInstantiateContract2) (sdk.AccAddress, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, fmt.Errorf("sender: %w", err)
+	}
+	res, err := queryClient.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: msg.CodeID})
+	if err != nil {
+		return nil, fmt.Errorf("code %d: %w", msg.CodeID, err)
+	}
+	if len(res.Checksum) != 32 {
+		return nil, fmt.Errorf("code %d: invalid checksum %X", msg.CodeID, res.Checksum)
+	}
+	return keeper.PredictableAddressGenerator(sender, msg.Salt, msg.Msg, msg.FixMsg)(ctx, msg.CodeID, res.Checksum), nil
+}
+
 func parseInstantiateArgs(rawCodeID, initMsg string, kr keyring.Keyring, sender string, flags *flag.FlagSet) (*types.MsgInstantiateContract, error) {
 	// get the id of the code to instantiate
 	codeID, err := strconv.ParseUint(rawCodeID, 10, 64)
@@ -362,15 +705,20 @@ func ExecuteContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "execute [contract_addr_bech32] [json_encoded_send_args] --amount [coins,optional]",
 		Short:   "Execute a command on a wasm contract",
+		Long:    "Execute a command on a wasm contract. The message can be read from a file with --msg-file instead of the argument",
 		Aliases: []string{"run", "call", "exec", "ex", "e"},
-		Args:    cobra.ExactArgs(2),
+		Args:    cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			msg, err := parseExecuteArgs(args[0], args[1], clientCtx.GetFromAddress(), cmd.Flags())
+			execMsg, err := readMsgArg(cmd, args, 1)
+			if err != nil {
+				return err
+			}
+			msg, err := parseExecuteArgs(args[0], execMsg, clientCtx.GetFromAddress(), cmd.Flags())
 			if err != nil {
 				return err
 			}
@@ -380,6 +728,7 @@ func ExecuteContractCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract along with command")
+	addMsgFileFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -412,6 +761,7 @@ func GrantCmd() *cobra.Command {
 	}
 	txCmd.AddCommand(
 		GrantAuthorizationCmd(),
+		GrantMigrationAuthorizationCmd(),
 		GrantStoreCodeAuthorizationCmd(),
 	)
 	return txCmd
@@ -484,42 +834,14 @@ $ %s tx grant contract <grantee_addr> execution <contract_addr> --allow-all-mess
 				return err
 			}
 
-			var limit types.ContractAuthzLimitX
-			switch {
-			case maxFundsStr != "" && maxCalls != 0 && !noTokenTransfer:
-				maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
-				if err != nil {
-					return fmt.Errorf("max funds: %s", err)
-				}
-				limit = types.NewCombinedLimit(maxCalls, maxFunds...)
-			case maxFundsStr != "" && maxCalls == 0 && !noTokenTransfer:
-				maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
-				if err != nil {
-					return fmt.Errorf("max funds: %s", err)
-				}
-				limit = types.NewMaxFundsLimit(maxFunds...)
-			case maxCalls != 0 && noTokenTransfer && maxFundsStr == "":
-				limit = types.NewMaxCallsLimit(maxCalls)
-			default:
-				return errors.New("invalid limit setup")
-			}
-
-			var filter types.ContractAuthzFilterX
-			switch {
-			case allowAllMsgs && len(msgKeys) != 0 || allowAllMsgs && len(rawMsgs) != 0 || len(msgKeys) != 0 && len(rawMsgs) != 0:
-				return errors.New("cannot set more than one filter within one grant")
-			case allowAllMsgs:
-				filter = types.NewAllowAllMessagesFilter()
-			case len(msgKeys) != 0:
-				filter = types.NewAcceptedMessageKeysFilter(msgKeys...)
-			case len(rawMsgs) != 0:
-				msgs := make([]types.RawContractMessage, len(rawMsgs))
-				for i, msg := range rawMsgs {
-					msgs[i] = types.RawContractMessage(msg)
-				}
-				filter = types.NewAcceptedMessagesFilter(msgs...)
-			default:
-				return errors.New("invalid filter setup")
+			limit, err := parseContractAuthzLimit(maxCalls, maxFundsStr, noTokenTransfer)
+			if err != nil {
+				return err
+			}
+
+			filter, err := parseContractAuthzFilter(allowAllMsgs, msgKeys, rawMsgs)
+			if err != nil {
+				return err
 			}
 
 			grant, err := types.NewContractGrant(contract, limit, filter)
@@ -560,16 +882,131 @@ $ %s tx grant contract <grantee_addr> execution <contract_addr> --allow-all-mess
 	return cmd
 }
 
+func GrantMigrationAuthorizationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migration [grantee] [contract_addr_bech32] --allowed-code-ids [code_ids] --max-calls [n] --allow-raw-msgs [msg1,msg2,...] --allow-msg-keys [key1,key2,...] --allow-all-messages",
+		Short: "Grant authorization to migrate a contract on behalf of you",
+		Long: fmt.Sprintf(`Grant authorization to an address to migrate a contract that you are the admin of.
+The allowed code ids restrict the new code of the migration. They can be ranges and comma separated lists like 5-10,42.
+Without allowed code ids, the contract can be migrated to any code.
+Examples:
+$ %s tx wasm grant migration <grantee_addr> <contract_addr> --allowed-code-ids 5,7 --allow-all-messages --max-calls 1 --expiration 1667979596
+`, version.AppName),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			contract, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			msgKeys, err := cmd.Flags().GetStringSlice(flagAllowedMsgKeys)
+			if err != nil {
+				return err
+			}
+
+			rawMsgs, err := cmd.Flags().GetStringSlice(flagAllowedRawMsgs)
+			if err != nil {
+				return err
+			}
+
+			allowAllMsgs, err := cmd.Flags().GetBool(flagAllowAllMsgs)
+			if err != nil {
+				return err
+			}
+
+			maxCalls, err := cmd.Flags().GetUint64(flagMaxCalls)
+			if err != nil {
+				return err
+			}
+
+			codeIDsStr, err := cmd.Flags().GetString(flagAllowedCodeIDs)
+			if err != nil {
+				return err
+			}
+
+			exp, err := cmd.Flags().GetInt64(flagExpiration)
+			if err != nil {
+				return err
+			}
+			if exp == 0 {
+				return errors.New("expiration must be set")
+			}
+
+			// migrations can not transfer tokens
+			limit, err := parseContractAuthzLimit(maxCalls, "", true)
+			if err != nil {
+				return err
+			}
+
+			filter, err := parseContractAuthzFilter(allowAllMsgs, msgKeys, rawMsgs)
+			if err != nil {
+				return err
+			}
+
+			grant, err := types.NewContractGrant(contract, limit, filter)
+			if err != nil {
+				return err
+			}
+			if codeIDsStr != "" {
+				codeIDs, err := parseCodeIDRanges([]string{codeIDsStr})
+				if err != nil {
+					return fmt.Errorf("allowed code ids: %w", err)
+				}
+				grant = grant.WithAllowedCodeIDs(codeIDs...)
+			}
+
+			authorization := types.NewContractMigrationAuthorization(*grant)
+			if err := authorization.ValidateBasic(); err != nil {
+				return err
+			}
+
+			expire, err := getExpireTime(cmd)
+			if err != nil {
+				return err
+			}
+
+			grantMsg, err := authz.NewMsgGrant(clientCtx.GetFromAddress(), grantee, authorization, expire)
+			if err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), grantMsg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	cmd.Flags().String(flagAllowedCodeIDs, "", "Allowed code ids of the new contract code. Default is any code")
+	cmd.Flags().StringSlice(flagAllowedMsgKeys, []string{}, "Allowed msg keys")
+	cmd.Flags().StringSlice(flagAllowedRawMsgs, []string{}, "Allowed raw msgs")
+	cmd.Flags().Uint64(flagMaxCalls, 0, "Maximal number of migrations of the contract")
+	cmd.Flags().Int64(flagExpiration, 0, "The Unix timestamp.")
+	cmd.Flags().Bool(flagAllowAllMsgs, false, "Allow all messages")
+	return cmd
+}
+
 func GrantStoreCodeAuthorizationCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "store-code [grantee] [code_hash:permission]",
 		Short: "Grant authorization to upload contract code on behalf of you",
 		Long: fmt.Sprintf(`Grant authorization to an address.
+A grant is "code_hash:permission". The "*" code hash allows any code. The permission is "nobody", "everybody"
+or a comma separated list of addresses that the code must declare as instantiate permission or a subset of it.
+The "*" permission allows any instantiate permission.
 Examples:
 $ %s tx grant store-code <grantee_addr> 13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5:everybody  1wqrtry681b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5:nobody --expiration 1667979596
 
+$ %s tx grant store-code <grantee_addr> *:everybody
+
 $ %s tx grant store-code <grantee_addr> *:%s1l2rsakp388kuv9k8qzq6lrm9taddae7fpx59wm,%s1vx8knpllrj7n963p9ttd80w47kpacrhuts497x
-`, version.AppName, version.AppName, version.AppName, version.AppName),
+`, version.AppName, version.AppName, version.AppName, version.AppName, version.AppName),
 		Args: cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
@@ -606,6 +1043,49 @@ $ %s tx grant store-code <grantee_addr> *:%s1l2rsakp388kuv9k8qzq6lrm9taddae7fpx5
 	return cmd
 }
 
+// parseContractAuthzLimit returns the limit of a contract grant. Max calls and max funds together are a
+// combined limit that is removed when either is used up. Max calls alone requires no token transfer.
+func parseContractAuthzLimit(maxCalls uint64, maxFundsStr string, noTokenTransfer bool) (types.ContractAuthzLimitX, error) {
+	var limit types.ContractAuthzLimitX
+	switch {
+	case maxFundsStr != "" && !noTokenTransfer:
+		maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
+		if err != nil {
+			return nil, fmt.Errorf("max funds: %s", err)
+		}
+		if maxCalls != 0 {
+			limit = types.NewCombinedLimit(maxCalls, maxFunds...)
+		} else {
+			limit = types.NewMaxFundsLimit(maxFunds...)
+		}
+	case maxCalls != 0 && noTokenTransfer && maxFundsStr == "":
+		limit = types.NewMaxCallsLimit(maxCalls)
+	default:
+		return nil, errors.New("invalid limit setup")
+	}
+	return limit, limit.ValidateBasic()
+}
+
+// parseContractAuthzFilter returns the message filter of a contract grant. Exactly one filter must be set.
+func parseContractAuthzFilter(allowAllMsgs bool, msgKeys, rawMsgs []string) (types.ContractAuthzFilterX, error) {
+	switch {
+	case allowAllMsgs && len(msgKeys) != 0 || allowAllMsgs && len(rawMsgs) != 0 || len(msgKeys) != 0 && len(rawMsgs) != 0:
+		return nil, errors.New("cannot set more than one filter within one grant")
+	case allowAllMsgs:
+		return types.NewAllowAllMessagesFilter(), nil
+	case len(msgKeys) != 0:
+		return types.NewAcceptedMessageKeysFilter(msgKeys...), nil
+	case len(rawMsgs) != 0:
+		msgs := make([]types.RawContractMessage, len(rawMsgs))
+		for i, msg := range rawMsgs {
+			msgs[i] = types.RawContractMessage(msg)
+		}
+		return types.NewAcceptedMessagesFilter(msgs...), nil
+	default:
+		return nil, errors.New("invalid filter setup")
+	}
+}
+
 func getExpireTime(cmd *cobra.Command) (*time.Time, error) {
 	exp, err := cmd.Flags().GetInt64(flagExpiration)
 	if err != nil {
@@ -1,8 +1,13 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -10,6 +15,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -125,6 +131,56 @@ func TestParseAccessConfigFlags(t *testing.T) {
 	}
 }
 
+func TestParseSingleAccessConfigFlag(t *testing.T) {
+	specs := map[string]struct {
+		args   []string
+		expCfg *types.AccessConfig
+		expErr bool
+	}{
+		"nobody": {
+			args:   []string{"--instantiate-nobody=true"},
+			expCfg: &types.AccessConfig{Permission: types.AccessTypeNobody},
+		},
+		"everybody": {
+			args:   []string{"--instantiate-everybody=true"},
+			expCfg: &types.AccessConfig{Permission: types.AccessTypeEverybody},
+		},
+		"any of address": {
+			args:   []string{"--instantiate-anyof-addresses=cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"},
+			expCfg: &types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{"cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"}},
+		},
+		"any of address - invalid": {
+			args:   []string{"--instantiate-anyof-addresses=foo"},
+			expErr: true,
+		},
+		"not set": {
+			args:   []string{},
+			expErr: true,
+		},
+		"multiple set": {
+			args:   []string{"--instantiate-nobody=true", "--instantiate-anyof-addresses=cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"},
+			expErr: true,
+		},
+		"disabled": {
+			args:   []string{"--instantiate-everybody=false"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := UpdateInstantiateConfigCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+			gotCfg, gotErr := parseSingleAccessConfigFlag(flags)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCfg, gotCfg)
+		})
+	}
+}
+
 func TestParseStoreCodeGrants(t *testing.T) {
 	specs := map[string]struct {
 		src    []string
@@ -227,3 +283,318 @@ func TestParseStoreCodeGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestParseContractAuthzLimit(t *testing.T) {
+	oneToken := sdk.NewInt64Coin("stake", 1)
+	specs := map[string]struct {
+		maxCalls        uint64
+		maxFunds        string
+		noTokenTransfer bool
+		exp             types.ContractAuthzLimitX
+		expErr          bool
+	}{
+		"max calls and max funds combined": {
+			maxCalls: 10,
+			maxFunds: "1stake",
+			exp:      types.NewCombinedLimit(10, oneToken),
+		},
+		"max funds": {
+			maxFunds: "1stake",
+			exp:      types.NewMaxFundsLimit(oneToken),
+		},
+		"max calls without token transfer": {
+			maxCalls:        10,
+			noTokenTransfer: true,
+			exp:             types.NewMaxCallsLimit(10),
+		},
+		"max calls with token transfer": {
+			maxCalls: 10,
+			expErr:   true,
+		},
+		"max funds without token transfer": {
+			maxCalls:        10,
+			maxFunds:        "1stake",
+			noTokenTransfer: true,
+			expErr:          true,
+		},
+		"zero max funds": {
+			maxCalls: 10,
+			maxFunds: "0stake",
+			expErr:   true,
+		},
+		"invalid max funds": {
+			maxFunds: "1",
+			expErr:   true,
+		},
+		"no limit": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseContractAuthzLimit(spec.maxCalls, spec.maxFunds, spec.noTokenTransfer)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestParseContractAuthzFilter(t *testing.T) {
+	specs := map[string]struct {
+		allowAll bool
+		msgKeys  []string
+		rawMsgs  []string
+		exp      types.ContractAuthzFilterX
+		expErr   bool
+	}{
+		"allow all": {
+			allowAll: true,
+			exp:      types.NewAllowAllMessagesFilter(),
+		},
+		"msg keys": {
+			msgKeys: []string{"foo", "bar"},
+			exp:     types.NewAcceptedMessageKeysFilter("foo", "bar"),
+		},
+		"raw msgs": {
+			rawMsgs: []string{`{"foo":{}}`},
+			exp:     types.NewAcceptedMessagesFilter(types.RawContractMessage(`{"foo":{}}`)),
+		},
+		"allow all and msg keys": {
+			allowAll: true,
+			msgKeys:  []string{"foo"},
+			expErr:   true,
+		},
+		"msg keys and raw msgs": {
+			msgKeys: []string{"foo"},
+			rawMsgs: []string{`{"foo":{}}`},
+			expErr:  true,
+		},
+		"no filter": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseContractAuthzFilter(spec.allowAll, spec.msgKeys, spec.rawMsgs)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestParseQueryAliasCacheTTL(t *testing.T) {
+	specs := map[string]struct {
+		src    time.Duration
+		exp    uint32
+		expErr bool
+	}{
+		"not set":     {},
+		"minutes":     {src: 5 * time.Minute, exp: 300},
+		"max":         {src: 24 * time.Hour, exp: 86400},
+		"exceeds max": {src: 24*time.Hour + time.Second, expErr: true},
+		"fraction":    {src: 1500 * time.Millisecond, expErr: true},
+		"negative":    {src: -time.Second, expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseQueryAliasCacheTTL(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestPrepareWasmUpload(t *testing.T) {
+	wasmCode := testdata.HackatomContractWasm()
+	gzippedCode, err := ioutils.GzipIt(wasmCode)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src        []byte
+		compress   bool
+		exp        []byte
+		expGzipped bool
+		expErr     bool
+	}{
+		"raw wasm compressed": {
+			src:        wasmCode,
+			compress:   true,
+			expGzipped: true,
+		},
+		"raw wasm not compressed": {
+			src: wasmCode,
+			exp: wasmCode,
+		},
+		"gzip kept": {
+			src:      gzippedCode,
+			compress: true,
+			exp:      gzippedCode,
+		},
+		"gzip kept without compression": {
+			src: gzippedCode,
+			exp: gzippedCode,
+		},
+		"neither wasm nor gzip": {
+			src:      []byte("not a wasm file"),
+			compress: true,
+			expErr:   true,
+		},
+		"empty": {
+			compress: true,
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := prepareWasmUpload(spec.src, spec.compress)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			if !spec.expGzipped {
+				assert.Equal(t, spec.exp, got)
+				return
+			}
+			require.True(t, ioutils.IsGzip(got))
+			uncompressed, err := ioutils.Uncompress(got, int64(types.MaxWasmSize))
+			require.NoError(t, err)
+			assert.Equal(t, spec.src, uncompressed)
+		})
+	}
+}
+
+func TestPrintStoreCodeSummary(t *testing.T) {
+	wasmCode := testdata.HackatomContractWasm()
+	gzippedCode, err := ioutils.GzipIt(wasmCode)
+	require.NoError(t, err)
+
+	for name, code := range map[string][]byte{"raw": wasmCode, "gzipped": gzippedCode} {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			checksum, err := printStoreCodeSummary(&out, code)
+			require.NoError(t, err)
+			exp := fmt.Sprintf("wasm size: %d bytes, upload size: %d bytes, checksum: %s\n", len(wasmCode), len(code), testdata.ChecksumHackatom)
+			assert.Equal(t, exp, out.String())
+			assert.Equal(t, testdata.ChecksumHackatom, hex.EncodeToString(checksum))
+		})
+	}
+}
+
+func TestSplitStoreCodeMsg(t *testing.T) {
+	wasmCode := testdata.HackatomContractWasm()
+	gzippedCode, err := ioutils.GzipIt(wasmCode)
+	require.NoError(t, err)
+	checksum := sha256.Sum256(wasmCode)
+	sender := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		code      []byte
+		chunkSize int
+		expChunks int
+	}{
+		"raw code": {
+			code:      wasmCode,
+			chunkSize: 100_000,
+			expChunks: (len(wasmCode) + 99_999) / 100_000,
+		},
+		"gzipped code": {
+			code:      gzippedCode,
+			chunkSize: 10_000,
+			expChunks: (len(gzippedCode) + 9_999) / 10_000,
+		},
+		"single chunk": {
+			code:      gzippedCode,
+			chunkSize: len(gzippedCode),
+			expChunks: 1,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			msg := types.MsgStoreCode{Sender: sender, WASMByteCode: spec.code, InstantiatePermission: &types.AllowNobody}
+			msgs, err := splitStoreCodeMsg(msg, spec.chunkSize, "my-upload")
+			require.NoError(t, err)
+			require.Len(t, msgs, spec.expChunks+1)
+
+			var assembled []byte
+			for i, m := range msgs[:spec.expChunks] {
+				chunk, ok := m.(*types.MsgStoreCodeChunk)
+				require.True(t, ok)
+				assert.Equal(t, "my-upload", chunk.UploadID)
+				assert.Equal(t, uint32(i), chunk.Index)
+				assert.Equal(t, uint32(spec.expChunks), chunk.Total)
+				assert.LessOrEqual(t, len(chunk.Data), spec.chunkSize)
+				assembled = append(assembled, chunk.Data...)
+			}
+			assert.Equal(t, spec.code, assembled)
+			assert.Equal(t, &types.MsgCompleteStoreCode{
+				Sender:                sender,
+				UploadID:              "my-upload",
+				Checksum:              checksum[:],
+				InstantiatePermission: &types.AllowNobody,
+			}, msgs[spec.expChunks])
+		})
+	}
+}
+
+func TestPredictInstantiate2Address(t *testing.T) {
+	const codeHash = "13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5"
+	checksum, err := hex.DecodeString(codeHash)
+	require.NoError(t, err)
+	queryClient := mockWasmQueryClient{checksums: map[uint64][]byte{1: checksum}}
+	creator := sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+	salt := []byte("my salt")
+	initMsg := []byte(`{"foo":  "bar"}`)
+
+	specs := map[string]struct {
+		src         types.MsgInstantiateContract2
+		expInitArgs []byte
+		expErr      bool
+	}{
+		"without fix msg": {
+			src: types.MsgInstantiateContract2{Sender: creator, CodeID: 1, Label: "foo", Msg: initMsg, Salt: salt},
+		},
+		"with fix msg": {
+			src:         types.MsgInstantiateContract2{Sender: creator, CodeID: 1, Label: "foo", Msg: initMsg, Salt: salt, FixMsg: true},
+			expInitArgs: initMsg,
+		},
+		"unknown code": {
+			src:    types.MsgInstantiateContract2{Sender: creator, CodeID: 2, Label: "foo", Msg: initMsg, Salt: salt},
+			expErr: true,
+		},
+		"invalid msg": {
+			src:    types.MsgInstantiateContract2{Sender: creator, CodeID: 1, Label: "foo", Msg: initMsg},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := predictInstantiate2Address(context.Background(), queryClient, &spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			exp, err := keeper.BuildAddressPredictable(&types.QueryBuildAddressRequest{
+				CodeHash:       codeHash,
+				CreatorAddress: creator,
+				Salt:           hex.EncodeToString(salt),
+				InitArgs:       spec.expInitArgs,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, exp.Address, got.String())
+		})
+	}
+}
@@ -1,15 +1,24 @@
 package cli
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	tmcmd "github.com/cometbft/cometbft/cmd/cometbft/commands"
 	"github.com/spf13/cobra"
 
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/server"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
+const flagWasmGenesisFragment = "wasm-genesis-fragment"
+
 // ExtendUnsafeResetAllCmd - also clear wasm dir
 func ExtendUnsafeResetAllCmd(rootCmd *cobra.Command) {
 	unsafeResetCmd := tmcmd.ResetAllCmd.Use
@@ -32,3 +41,73 @@ func ExtendUnsafeResetAllCmd(rootCmd *cobra.Command) {
 		}
 	}
 }
+
+// ExtendGenesisFragmentCmds adds the --wasm-genesis-fragment flag to the init and genesis collect-gentxs commands.
+// The wasm genesis fragment of a dev snapshot is merged into the genesis file after the command ran.
+func ExtendGenesisFragmentCmds(rootCmd *cobra.Command) {
+	for _, cmd := range rootCmd.Commands() {
+		switch cmd.Name() {
+		case "init":
+			extendWithGenesisFragment(cmd)
+		case "genesis":
+			for _, subCmd := range cmd.Commands() {
+				if subCmd.Name() == "collect-gentxs" {
+					extendWithGenesisFragment(subCmd)
+				}
+			}
+		}
+	}
+}
+
+func extendWithGenesisFragment(cmd *cobra.Command) {
+	cmd.Flags().String(flagWasmGenesisFragment, "", "Merge the wasm genesis fragment of a dev snapshot file into the genesis")
+	serverRunE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := serverRunE(cmd, args); err != nil {
+			return err
+		}
+		fragmentFile, err := cmd.Flags().GetString(flagWasmGenesisFragment)
+		if err != nil || fragmentFile == "" {
+			return err
+		}
+		serverCtx := server.GetServerContextFromCmd(cmd)
+		return mergeGenesisFragmentFile(client.GetClientContextFromCmd(cmd).Codec, serverCtx.Config.GenesisFile(), fragmentFile)
+	}
+}
+
+// mergeGenesisFragmentFile merges the wasm genesis fragment file into the wasm state of the genesis file
+func mergeGenesisFragmentFile(cdc codec.JSONCodec, genFile, fragmentFile string) error {
+	bz, err := os.ReadFile(fragmentFile)
+	if err != nil {
+		return err
+	}
+	var fragment types.GenesisState
+	if err := cdc.UnmarshalJSON(bz, &fragment); err != nil {
+		return fmt.Errorf("decode genesis fragment: %w", err)
+	}
+	appGenesis, err := genutiltypes.AppGenesisFromFile(genFile)
+	if err != nil {
+		return err
+	}
+	var appState map[string]json.RawMessage
+	if err := json.Unmarshal(appGenesis.AppState, &appState); err != nil {
+		return err
+	}
+	var wasmState types.GenesisState
+	if err := cdc.UnmarshalJSON(appState[types.ModuleName], &wasmState); err != nil {
+		return fmt.Errorf("decode wasm genesis: %w", err)
+	}
+	if err := wasmState.MergeFragment(fragment); err != nil {
+		return err
+	}
+	if err := wasmState.ValidateBasic(); err != nil {
+		return err
+	}
+	if appState[types.ModuleName], err = cdc.MarshalJSON(&wasmState); err != nil {
+		return err
+	}
+	if appGenesis.AppState, err = json.Marshal(appState); err != nil {
+		return err
+	}
+	return appGenesis.SaveAs(genFile)
+}
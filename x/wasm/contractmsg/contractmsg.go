@@ -0,0 +1,58 @@
+// Package contractmsg constructs the json messages that the chain sends to contracts. These are the sudo
+// notifications, the IBC entry point messages, the replies and the query envelopes and custom query responses
+// of wasmd.
+//
+// The bytes that a contract receives are part of the contract API. A changed field name, field order or omitempty
+// tag breaks deployed contracts. The output of every message shape is therefore pinned by the golden files in
+// testdata. Messages that are passed to wasmvm as structs, like the IBC messages and replies, are encoded by wasmvm
+// the same way as by Encode.
+package contractmsg
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// Encode returns the json bytes of a message as the contract receives them. Struct fields are encoded in
+// declaration order, map keys sorted and html characters escaped.
+func Encode(msg any) ([]byte, error) {
+	return json.Marshal(msg)
+}
+
+// AdminChanged returns the sudo message that notifies a contract about an updated or cleared admin
+func AdminChanged(oldAdmin, newAdmin string) ([]byte, error) {
+	return Encode(types.AdminChangedSudoMsg{
+		AdminChanged: types.AdminChanged{Old: oldAdmin, New: newAdmin},
+	})
+}
+
+// ParamsChanged returns the sudo message that notifies a subscribed contract about new params of the subspace
+func ParamsChanged(subspace string, params json.RawMessage) ([]byte, error) {
+	return Encode(types.ParamsChangedSudoMsg{
+		ParamsChanged: types.ParamsChanged{Subspace: subspace, Params: params},
+	})
+}
+
+// AuthenticatedSmartQuery returns the smart query data wrapped in the authenticated query envelope with the
+// address of the verified signer
+func AuthenticatedSmartQuery(queryData types.RawContractMessage, signer sdk.AccAddress) ([]byte, error) {
+	return Encode(types.AuthenticatedQuery{
+		Authenticated: types.AuthenticatedQueryMsg{Sender: signer.String(), Msg: queryData},
+	})
+}
+
+// DenomTraceResponse returns the response of the denom trace custom query
+func DenomTraceResponse(path, baseDenom string) ([]byte, error) {
+	return Encode(types.DenomTraceResponse{Path: path, BaseDenom: baseDenom})
+}
+
+// WasmParamsResponse returns the response of the wasm params custom query
+func WasmParamsResponse(params types.Params) ([]byte, error) {
+	return Encode(types.WasmParamsResponse{
+		CodeUploadAccess:             params.CodeUploadAccess,
+		InstantiateDefaultPermission: params.InstantiateDefaultPermission,
+	})
+}
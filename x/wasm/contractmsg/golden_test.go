@@ -0,0 +1,166 @@
+package contractmsg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types" //nolint:staticcheck
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// TestGoldenMessages pins the exact bytes of every message shape that a contract receives.
+// Run with -update to rewrite the golden files after an intended change of the contract API.
+func TestGoldenMessages(t *testing.T) {
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, types.SDKAddrLen))
+	myPacket := channeltypes.Packet{
+		Sequence:           1,
+		SourcePort:         "srcPort",
+		SourceChannel:      "channel-1",
+		DestinationPort:    "destPort",
+		DestinationChannel: "channel-2",
+		Data:               []byte(`{"foo":"bar"}`),
+		TimeoutHeight:      clienttypes.Height{RevisionNumber: 2, RevisionHeight: 1},
+		TimeoutTimestamp:   1700000000000000000,
+	}
+	myTimestampOnlyPacket := myPacket
+	myTimestampOnlyPacket.TimeoutHeight = clienttypes.ZeroHeight()
+	myCounterparty := channeltypes.Counterparty{PortId: "counterpartyPort", ChannelId: "channel-7"}
+	myChannel := Channel("wasm.myContract", "channel-3", channeltypes.Channel{
+		State:          channeltypes.OPEN,
+		Ordering:       channeltypes.UNORDERED,
+		Counterparty:   myCounterparty,
+		ConnectionHops: []string{"connection-0"},
+		Version:        "ics20-1",
+	}, "my-app-v1")
+	myEvents := []sdk.Event{sdk.NewEvent("wasm",
+		sdk.NewAttribute("_contract_address", myAddr.String()),
+		sdk.NewAttribute("action", "transfer"),
+	)}
+	myMsgResponses := [][]*codectypes.Any{{{TypeUrl: "/cosmwasm.wasm.v1.MsgExecuteContractResponse", Value: []byte{0xa, 0x2, 0x7b, 0x7d}}}}
+	myParams := types.Params{
+		CodeUploadAccess:             types.AccessTypeAnyOfAddresses.With(myAddr),
+		InstantiateDefaultPermission: types.AccessTypeEverybody,
+	}
+
+	specs := map[string]struct {
+		msg any                    // encoded and decoded again
+		src func() ([]byte, error) // for messages that are constructed as bytes
+	}{
+		"ibc_channel_open_init": {
+			msg: ChannelOpenInit(channeltypes.ORDERED, []string{"connection-0"}, "wasm.myContract", "channel-3", myCounterparty, "my-app-v1"),
+		},
+		"ibc_channel_open_try": {
+			msg: ChannelOpenTry(channeltypes.UNORDERED, []string{"connection-0"}, "wasm.myContract", "channel-3", myCounterparty, "my-app-v1"),
+		},
+		"ibc_channel_open_ack": {
+			msg: ChannelOpenAck(myChannel, "my-app-v1"),
+		},
+		"ibc_channel_open_confirm": {
+			msg: ChannelOpenConfirm(myChannel),
+		},
+		"ibc_channel_close_init": {
+			msg: ChannelCloseInit(myChannel),
+		},
+		"ibc_channel_close_confirm": {
+			msg: ChannelCloseConfirm(myChannel),
+		},
+		"ibc_packet_receive": {
+			msg: PacketReceive(myPacket, myAddr),
+		},
+		"ibc_packet_receive_timestamp_timeout": {
+			msg: PacketReceive(myTimestampOnlyPacket, myAddr),
+		},
+		"ibc_packet_ack": {
+			msg: PacketAck(myPacket, []byte(`{"result":"AQ=="}`), myAddr),
+		},
+		"ibc_packet_timeout": {
+			msg: PacketTimeout(myPacket, myAddr),
+		},
+		"ibc_source_callback_ack": {
+			msg: SourceCallbackAck(myPacket, []byte(`{"result":"AQ=="}`), myAddr),
+		},
+		"ibc_source_callback_timeout": {
+			msg: SourceCallbackTimeout(myPacket, myAddr),
+		},
+		"ibc_destination_callback": {
+			msg: DestinationCallback(myPacket, testAck(`{"result":"AQ=="}`)),
+		},
+		"reply_ok": {
+			msg: Reply(1, SubMsgOk(myEvents, []byte("myData"), myMsgResponses), []byte(`{"step":1}`)),
+		},
+		"reply_ok_empty": {
+			msg: Reply(2, SubMsgOk(nil, nil, nil), nil),
+		},
+		"reply_err": {
+			msg: Reply(3, SubMsgErr(errors.New("codespace: wasm, code: 5")), nil),
+		},
+		"sudo_admin_changed": {
+			src: func() ([]byte, error) { return AdminChanged(myAddr.String(), "cosmos1newadmin") },
+		},
+		"sudo_admin_cleared": {
+			src: func() ([]byte, error) { return AdminChanged(myAddr.String(), "") },
+		},
+		"sudo_params_changed": {
+			src: func() ([]byte, error) { return ParamsChanged("staking", json.RawMessage(`{"max_validators":100}`)) },
+		},
+		"query_authenticated_smart": {
+			src: func() ([]byte, error) { return AuthenticatedSmartQuery([]byte(`{"orders":{"limit":10}}`), myAddr) },
+		},
+		"query_denom_trace_response": {
+			src: func() ([]byte, error) { return DenomTraceResponse("transfer/channel-0", "uatom") },
+		},
+		"query_wasm_params_response": {
+			src: func() ([]byte, error) { return WasmParamsResponse(myParams) },
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			golden := filepath.Join("testdata", name+".json")
+			src := spec.src
+			if src == nil {
+				src = func() ([]byte, error) { return Encode(spec.msg) }
+			}
+			// when
+			got, err := src()
+			require.NoError(t, err)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, got, 0o600))
+				return
+			}
+			// then
+			exp, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(exp), string(got))
+			if spec.msg == nil {
+				return
+			}
+			// and decoding is lossless
+			decoded := reflect.New(reflect.TypeOf(spec.msg))
+			require.NoError(t, json.Unmarshal(exp, decoded.Interface()))
+			reencoded, err := Encode(decoded.Elem().Interface())
+			require.NoError(t, err)
+			assert.Equal(t, string(exp), string(reencoded))
+		})
+	}
+}
+
+type testAck []byte
+
+func (a testAck) Success() bool { return true }
+
+func (a testAck) Acknowledgement() []byte { return a }
@@ -0,0 +1,148 @@
+package contractmsg
+
+import (
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v10/modules/core/exported"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Channel returns the contract view of an existing channel
+func Channel(portID, channelID string, channelInfo channeltypes.Channel, appVersion string) wasmvmtypes.IBCChannel {
+	return wasmvmtypes.IBCChannel{
+		Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: channelID},
+		CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: channelInfo.Counterparty.PortId, ChannelID: channelInfo.Counterparty.ChannelId},
+		Order:                channelInfo.Ordering.String(),
+		Version:              appVersion,
+		ConnectionID:         channelInfo.ConnectionHops[0], // At the moment this list must be of length 1. In the future multi-hop channels may be supported.
+	}
+}
+
+// Packet returns the contract view of a packet
+func Packet(packet ibcexported.PacketI) wasmvmtypes.IBCPacket {
+	timeout := wasmvmtypes.IBCTimeout{
+		Timestamp: packet.GetTimeoutTimestamp(),
+	}
+	timeoutHeight := packet.GetTimeoutHeight()
+	if !timeoutHeight.IsZero() {
+		timeout.Block = &wasmvmtypes.IBCTimeoutBlock{
+			Height:   timeoutHeight.GetRevisionHeight(),
+			Revision: timeoutHeight.GetRevisionNumber(),
+		}
+	}
+
+	return wasmvmtypes.IBCPacket{
+		Data:     packet.GetData(),
+		Src:      wasmvmtypes.IBCEndpoint{ChannelID: packet.GetSourceChannel(), PortID: packet.GetSourcePort()},
+		Dest:     wasmvmtypes.IBCEndpoint{ChannelID: packet.GetDestChannel(), PortID: packet.GetDestPort()},
+		Sequence: packet.GetSequence(),
+		Timeout:  timeout,
+	}
+}
+
+// ChannelOpenInit returns the message for the first step of the channel handshake on the initiating chain.
+// The channel does not exist in the channel keeper yet.
+func ChannelOpenInit(order channeltypes.Order, connectionHops []string, portID, channelID string, counterParty channeltypes.Counterparty, version string) wasmvmtypes.IBCChannelOpenMsg {
+	return wasmvmtypes.IBCChannelOpenMsg{
+		OpenInit: &wasmvmtypes.IBCOpenInit{
+			Channel: wasmvmtypes.IBCChannel{
+				Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: channelID},
+				CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: counterParty.PortId, ChannelID: counterParty.ChannelId},
+				Order:                order.String(),
+				// DESIGN V3: this may be "" ??
+				Version:      version,
+				ConnectionID: connectionHops[0], // At the moment this list must be of length 1. In the future multi-hop channels may be supported.
+			},
+		},
+	}
+}
+
+// ChannelOpenTry returns the message for the first step of the channel handshake on the counterparty chain.
+// The counterparty version is also the proposed channel version.
+func ChannelOpenTry(order channeltypes.Order, connectionHops []string, portID, channelID string, counterParty channeltypes.Counterparty, counterpartyVersion string) wasmvmtypes.IBCChannelOpenMsg {
+	return wasmvmtypes.IBCChannelOpenMsg{
+		OpenTry: &wasmvmtypes.IBCOpenTry{
+			Channel: wasmvmtypes.IBCChannel{
+				Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: channelID},
+				CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: counterParty.PortId, ChannelID: counterParty.ChannelId},
+				Order:                order.String(),
+				Version:              counterpartyVersion,
+				ConnectionID:         connectionHops[0], // At the moment this list must be of length 1. In the future multi-hop channels may be supported.
+			},
+			CounterpartyVersion: counterpartyVersion,
+		},
+	}
+}
+
+// ChannelOpenAck returns the message for the confirmation of the channel handshake on the initiating chain
+func ChannelOpenAck(channel wasmvmtypes.IBCChannel, counterpartyVersion string) wasmvmtypes.IBCChannelConnectMsg {
+	return wasmvmtypes.IBCChannelConnectMsg{
+		OpenAck: &wasmvmtypes.IBCOpenAck{Channel: channel, CounterpartyVersion: counterpartyVersion},
+	}
+}
+
+// ChannelOpenConfirm returns the message for the confirmation of the channel handshake on the counterparty chain
+func ChannelOpenConfirm(channel wasmvmtypes.IBCChannel) wasmvmtypes.IBCChannelConnectMsg {
+	return wasmvmtypes.IBCChannelConnectMsg{
+		OpenConfirm: &wasmvmtypes.IBCOpenConfirm{Channel: channel},
+	}
+}
+
+// ChannelCloseInit returns the message for a channel close started on this chain
+func ChannelCloseInit(channel wasmvmtypes.IBCChannel) wasmvmtypes.IBCChannelCloseMsg {
+	return wasmvmtypes.IBCChannelCloseMsg{CloseInit: &wasmvmtypes.IBCCloseInit{Channel: channel}}
+}
+
+// ChannelCloseConfirm returns the message for a channel close started by the counterparty
+func ChannelCloseConfirm(channel wasmvmtypes.IBCChannel) wasmvmtypes.IBCChannelCloseMsg {
+	return wasmvmtypes.IBCChannelCloseMsg{CloseConfirm: &wasmvmtypes.IBCCloseConfirm{Channel: channel}}
+}
+
+// PacketReceive returns the message for a packet received by the contract
+func PacketReceive(packet ibcexported.PacketI, relayer sdk.AccAddress) wasmvmtypes.IBCPacketReceiveMsg {
+	return wasmvmtypes.IBCPacketReceiveMsg{Packet: Packet(packet), Relayer: relayer.String()}
+}
+
+// PacketAck returns the message for the acknowledgement of a packet sent by the contract
+func PacketAck(packet ibcexported.PacketI, acknowledgement []byte, relayer sdk.AccAddress) wasmvmtypes.IBCPacketAckMsg {
+	return wasmvmtypes.IBCPacketAckMsg{
+		Acknowledgement: wasmvmtypes.IBCAcknowledgement{Data: acknowledgement},
+		OriginalPacket:  Packet(packet),
+		Relayer:         relayer.String(),
+	}
+}
+
+// PacketTimeout returns the message for the timeout of a packet sent by the contract
+func PacketTimeout(packet ibcexported.PacketI, relayer sdk.AccAddress) wasmvmtypes.IBCPacketTimeoutMsg {
+	return wasmvmtypes.IBCPacketTimeoutMsg{Packet: Packet(packet), Relayer: relayer.String()}
+}
+
+// SourceCallbackAck returns the callback message for the acknowledgement of a packet sent on behalf of the contract
+func SourceCallbackAck(packet ibcexported.PacketI, acknowledgement []byte, relayer sdk.AccAddress) wasmvmtypes.IBCSourceCallbackMsg {
+	return wasmvmtypes.IBCSourceCallbackMsg{
+		Acknowledgement: &wasmvmtypes.IBCAckCallbackMsg{
+			Acknowledgement: wasmvmtypes.IBCAcknowledgement{Data: acknowledgement},
+			OriginalPacket:  Packet(packet),
+			Relayer:         relayer.String(),
+		},
+	}
+}
+
+// SourceCallbackTimeout returns the callback message for the timeout of a packet sent on behalf of the contract
+func SourceCallbackTimeout(packet ibcexported.PacketI, relayer sdk.AccAddress) wasmvmtypes.IBCSourceCallbackMsg {
+	return wasmvmtypes.IBCSourceCallbackMsg{
+		Timeout: &wasmvmtypes.IBCTimeoutCallbackMsg{
+			Packet:  Packet(packet),
+			Relayer: relayer.String(),
+		},
+	}
+}
+
+// DestinationCallback returns the callback message for a packet received on behalf of the contract
+func DestinationCallback(packet ibcexported.PacketI, ack ibcexported.Acknowledgement) wasmvmtypes.IBCDestinationCallbackMsg {
+	return wasmvmtypes.IBCDestinationCallbackMsg{
+		Ack:    wasmvmtypes.IBCAcknowledgement{Data: ack.Acknowledgement()},
+		Packet: Packet(packet),
+	}
+}
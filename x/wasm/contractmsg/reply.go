@@ -0,0 +1,65 @@
+package contractmsg
+
+import (
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Reply returns the reply to the contract that dispatched the submessage
+func Reply(id uint64, result wasmvmtypes.SubMsgResult, payload []byte) wasmvmtypes.Reply {
+	return wasmvmtypes.Reply{
+		ID:      id,
+		Result:  result,
+		Payload: payload,
+	}
+}
+
+// SubMsgOk returns the result of a successful submessage. The responses of all sdk messages are flattened into
+// a single list. In the majority of cases we only expect one message to be emitted and one response per message.
+// But it might be possible to create multiple SDK messages from one CosmWasm message or we have multiple responses
+// for one message. See https://github.com/CosmWasm/cosmwasm/issues/2009 for more information.
+func SubMsgOk(events []sdk.Event, data []byte, msgResponses [][]*codectypes.Any) wasmvmtypes.SubMsgResult {
+	var msgResponsesFlattened []wasmvmtypes.MsgResponse
+	for _, singleMsgResponses := range msgResponses {
+		for _, singleMsgResponse := range singleMsgResponses {
+			msgResponsesFlattened = append(msgResponsesFlattened, wasmvmtypes.MsgResponse{
+				TypeURL: singleMsgResponse.TypeUrl,
+				Value:   singleMsgResponse.Value,
+			})
+		}
+	}
+	return wasmvmtypes.SubMsgResult{
+		Ok: &wasmvmtypes.SubMsgResponse{
+			Events:       Events(events),
+			Data:         data,
+			MsgResponses: msgResponsesFlattened,
+		},
+	}
+}
+
+// SubMsgErr returns the result of a failed submessage. The error must be redacted by the caller
+// as the full error string is not deterministic.
+func SubMsgErr(redactedErr error) wasmvmtypes.SubMsgResult {
+	return wasmvmtypes.SubMsgResult{Err: redactedErr.Error()}
+}
+
+// Events returns the contract view of sdk events
+func Events(events []sdk.Event) []wasmvmtypes.Event {
+	res := make([]wasmvmtypes.Event, len(events))
+	for i, ev := range events {
+		attrs := make([]wasmvmtypes.EventAttribute, len(ev.Attributes))
+		for j, attr := range ev.Attributes {
+			attrs[j] = wasmvmtypes.EventAttribute{
+				Key:   attr.Key,
+				Value: attr.Value,
+			}
+		}
+		res[i] = wasmvmtypes.Event{
+			Type:       ev.Type,
+			Attributes: attrs,
+		}
+	}
+	return res
+}
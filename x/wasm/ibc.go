@@ -3,7 +3,6 @@ package wasm
 import (
 	"math"
 
-	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
 	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 	porttypes "github.com/cosmos/ibc-go/v10/modules/core/05-port/types"
@@ -13,6 +12,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/CosmWasm/wasmd/x/wasm/contractmsg"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -60,18 +60,7 @@ func (i IBCHandler) OnChanOpenInit(
 		return "", errorsmod.Wrapf(err, "contract port id")
 	}
 
-	msg := wasmvmtypes.IBCChannelOpenMsg{
-		OpenInit: &wasmvmtypes.IBCOpenInit{
-			Channel: wasmvmtypes.IBCChannel{
-				Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: channelID},
-				CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: counterParty.PortId, ChannelID: counterParty.ChannelId},
-				Order:                order.String(),
-				// DESIGN V3: this may be "" ??
-				Version:      version,
-				ConnectionID: connectionHops[0], // At the moment this list must be of length 1. In the future multi-hop channels may be supported.
-			},
-		},
-	}
+	msg := contractmsg.ChannelOpenInit(order, connectionHops, portID, channelID, counterParty, version)
 
 	// Allow contracts to return a version (or default to proposed version if unset)
 	acceptedVersion, err := i.keeper.OnOpenChannel(ctx, contractAddr, msg)
@@ -107,18 +96,7 @@ func (i IBCHandler) OnChanOpenTry(
 		return "", errorsmod.Wrapf(err, "contract port id")
 	}
 
-	msg := wasmvmtypes.IBCChannelOpenMsg{
-		OpenTry: &wasmvmtypes.IBCOpenTry{
-			Channel: wasmvmtypes.IBCChannel{
-				Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: channelID},
-				CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: counterParty.PortId, ChannelID: counterParty.ChannelId},
-				Order:                order.String(),
-				Version:              counterpartyVersion,
-				ConnectionID:         connectionHops[0], // At the moment this list must be of length 1. In the future multi-hop channels may be supported.
-			},
-			CounterpartyVersion: counterpartyVersion,
-		},
-	}
+	msg := contractmsg.ChannelOpenTry(order, connectionHops, portID, channelID, counterParty, counterpartyVersion)
 
 	// Allow contracts to return a version (or default to counterpartyVersion if unset)
 	version, err := i.keeper.OnOpenChannel(ctx, contractAddr, msg)
@@ -154,12 +132,7 @@ func (i IBCHandler) OnChanOpenAck(
 		return errorsmod.Wrapf(channeltypes.ErrInvalidChannelVersion, "port ID (%s) channel ID (%s)", portID, channelID)
 	}
 
-	msg := wasmvmtypes.IBCChannelConnectMsg{
-		OpenAck: &wasmvmtypes.IBCOpenAck{
-			Channel:             toWasmVMChannel(portID, channelID, channelInfo, appVersion),
-			CounterpartyVersion: counterpartyVersion,
-		},
-	}
+	msg := contractmsg.ChannelOpenAck(contractmsg.Channel(portID, channelID, channelInfo, appVersion), counterpartyVersion)
 	return i.keeper.OnConnectChannel(ctx, contractAddr, msg)
 }
 
@@ -177,11 +150,7 @@ func (i IBCHandler) OnChanOpenConfirm(ctx sdk.Context, portID, channelID string)
 	if !ok {
 		return errorsmod.Wrapf(channeltypes.ErrInvalidChannelVersion, "port ID (%s) channel ID (%s)", portID, channelID)
 	}
-	msg := wasmvmtypes.IBCChannelConnectMsg{
-		OpenConfirm: &wasmvmtypes.IBCOpenConfirm{
-			Channel: toWasmVMChannel(portID, channelID, channelInfo, appVersion),
-		},
-	}
+	msg := contractmsg.ChannelOpenConfirm(contractmsg.Channel(portID, channelID, channelInfo, appVersion))
 	return i.keeper.OnConnectChannel(ctx, contractAddr, msg)
 }
 
@@ -200,9 +169,7 @@ func (i IBCHandler) OnChanCloseInit(ctx sdk.Context, portID, channelID string) e
 		return errorsmod.Wrapf(channeltypes.ErrInvalidChannelVersion, "port ID (%s) channel ID (%s)", portID, channelID)
 	}
 
-	msg := wasmvmtypes.IBCChannelCloseMsg{
-		CloseInit: &wasmvmtypes.IBCCloseInit{Channel: toWasmVMChannel(portID, channelID, channelInfo, appVersion)},
-	}
+	msg := contractmsg.ChannelCloseInit(contractmsg.Channel(portID, channelID, channelInfo, appVersion))
 	err = i.keeper.OnCloseChannel(ctx, contractAddr, msg)
 	if err != nil {
 		return err
@@ -228,9 +195,7 @@ func (i IBCHandler) OnChanCloseConfirm(ctx sdk.Context, portID, channelID string
 		return errorsmod.Wrapf(channeltypes.ErrInvalidChannelVersion, "port ID (%s) channel ID (%s)", portID, channelID)
 	}
 
-	msg := wasmvmtypes.IBCChannelCloseMsg{
-		CloseConfirm: &wasmvmtypes.IBCCloseConfirm{Channel: toWasmVMChannel(portID, channelID, channelInfo, appVersion)},
-	}
+	msg := contractmsg.ChannelCloseConfirm(contractmsg.Channel(portID, channelID, channelInfo, appVersion))
 	err = i.keeper.OnCloseChannel(ctx, contractAddr, msg)
 	if err != nil {
 		return err
@@ -240,16 +205,6 @@ func (i IBCHandler) OnChanCloseConfirm(ctx sdk.Context, portID, channelID string
 	return err
 }
 
-func toWasmVMChannel(portID, channelID string, channelInfo channeltypes.Channel, appVersion string) wasmvmtypes.IBCChannel {
-	return wasmvmtypes.IBCChannel{
-		Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: channelID},
-		CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: channelInfo.Counterparty.PortId, ChannelID: channelInfo.Counterparty.ChannelId},
-		Order:                channelInfo.Ordering.String(),
-		Version:              appVersion,
-		ConnectionID:         channelInfo.ConnectionHops[0], // At the moment this list must be of length 1. In the future multi-hop channels may be supported.
-	}
-}
-
 // OnRecvPacket implements the IBCModule interface
 func (i IBCHandler) OnRecvPacket(
 	ctx sdk.Context,
@@ -264,7 +219,7 @@ func (i IBCHandler) OnRecvPacket(
 	}
 
 	em := sdk.NewEventManager()
-	msg := wasmvmtypes.IBCPacketReceiveMsg{Packet: newIBCPacket(packet), Relayer: relayer.String()}
+	msg := contractmsg.PacketReceive(packet, relayer)
 	ack, err := i.keeper.OnRecvPacket(ctx.WithEventManager(em), contractAddr, msg)
 	if err != nil {
 		ack = CreateErrorAcknowledgement(err)
@@ -291,11 +246,7 @@ func (i IBCHandler) OnAcknowledgementPacket(
 		return errorsmod.Wrapf(err, "contract port id")
 	}
 
-	err = i.keeper.OnAckPacket(ctx, contractAddr, wasmvmtypes.IBCPacketAckMsg{
-		Acknowledgement: wasmvmtypes.IBCAcknowledgement{Data: acknowledgement},
-		OriginalPacket:  newIBCPacket(packet),
-		Relayer:         relayer.String(),
-	})
+	err = i.keeper.OnAckPacket(ctx, contractAddr, contractmsg.PacketAck(packet, acknowledgement, relayer))
 	if err != nil {
 		return errorsmod.Wrap(err, "on ack")
 	}
@@ -308,7 +259,7 @@ func (i IBCHandler) OnTimeoutPacket(ctx sdk.Context, channelVersion string, pack
 	if err != nil {
 		return errorsmod.Wrapf(err, "contract port id")
 	}
-	msg := wasmvmtypes.IBCPacketTimeoutMsg{Packet: newIBCPacket(packet), Relayer: relayer.String()}
+	msg := contractmsg.PacketTimeout(packet, relayer)
 	err = i.keeper.OnTimeoutPacket(ctx, contractAddr, msg)
 	if err != nil {
 		return errorsmod.Wrap(err, "on timeout")
@@ -354,13 +305,7 @@ func (i IBCHandler) IBCOnAcknowledgementPacketCallback(
 		return err
 	}
 
-	msg := wasmvmtypes.IBCSourceCallbackMsg{
-		Acknowledgement: &wasmvmtypes.IBCAckCallbackMsg{
-			Acknowledgement: wasmvmtypes.IBCAcknowledgement{Data: acknowledgement},
-			OriginalPacket:  newIBCPacket(packet),
-			Relayer:         relayer.String(),
-		},
-	}
+	msg := contractmsg.SourceCallbackAck(packet, acknowledgement, relayer)
 	err = i.keeper.IBCSourceCallback(cachedCtx, contractAddr, msg)
 	if err != nil {
 		return errorsmod.Wrap(err, "on source chain callback ack")
@@ -384,12 +329,7 @@ func (i IBCHandler) IBCOnTimeoutPacketCallback(
 		return err
 	}
 
-	msg := wasmvmtypes.IBCSourceCallbackMsg{
-		Timeout: &wasmvmtypes.IBCTimeoutCallbackMsg{
-			Packet:  newIBCPacket(packet),
-			Relayer: relayer.String(),
-		},
-	}
+	msg := contractmsg.SourceCallbackTimeout(packet, relayer)
 	err = i.keeper.IBCSourceCallback(cachedCtx, contractAddr, msg)
 	if err != nil {
 		return errorsmod.Wrap(err, "on source chain callback timeout")
@@ -412,10 +352,7 @@ func (i IBCHandler) IBCReceivePacketCallback(
 		return err
 	}
 
-	msg := wasmvmtypes.IBCDestinationCallbackMsg{
-		Ack:    wasmvmtypes.IBCAcknowledgement{Data: ack.Acknowledgement()},
-		Packet: newIBCPacket(packet),
-	}
+	msg := contractmsg.DestinationCallback(packet, ack)
 
 	err = i.keeper.IBCDestinationCallback(cachedCtx, contractAddr, msg)
 	if err != nil {
@@ -443,27 +380,6 @@ func validateSender(contractAddr, senderAddr string) (sdk.AccAddress, error) {
 	return contractAddress, nil
 }
 
-func newIBCPacket(packet ibcexported.PacketI) wasmvmtypes.IBCPacket {
-	timeout := wasmvmtypes.IBCTimeout{
-		Timestamp: packet.GetTimeoutTimestamp(),
-	}
-	timeoutHeight := packet.GetTimeoutHeight()
-	if !timeoutHeight.IsZero() {
-		timeout.Block = &wasmvmtypes.IBCTimeoutBlock{
-			Height:   timeoutHeight.GetRevisionHeight(),
-			Revision: timeoutHeight.GetRevisionNumber(),
-		}
-	}
-
-	return wasmvmtypes.IBCPacket{
-		Data:     packet.GetData(),
-		Src:      wasmvmtypes.IBCEndpoint{ChannelID: packet.GetSourceChannel(), PortID: packet.GetSourcePort()},
-		Dest:     wasmvmtypes.IBCEndpoint{ChannelID: packet.GetDestChannel(), PortID: packet.GetDestPort()},
-		Sequence: packet.GetSequence(),
-		Timeout:  timeout,
-	}
-}
-
 func ValidateChannelParams(channelID string) error {
 	// NOTE: for escrow address security only 2^32 channels are allowed to be created
 	// Issue: https://github.com/cosmos/cosmos-sdk/issues/7737
@@ -15,6 +15,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
 
+	"github.com/CosmWasm/wasmd/x/wasm/contractmsg"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -172,7 +173,7 @@ func TestMapToWasmVMIBCPacket(t *testing.T) {
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
-			got := newIBCPacket(spec.src)
+			got := contractmsg.Packet(spec.src)
 			assert.Equal(t, spec.exp, got)
 		})
 	}
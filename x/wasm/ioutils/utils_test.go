@@ -31,6 +31,9 @@ func TestIsWasm(t *testing.T) {
 	require.False(t, IsWasm(someRandomStr))
 	t.Log("should return false for gzip data")
 	require.False(t, IsWasm(gzipData))
+	t.Log("should return false for short data")
+	require.False(t, IsWasm(wasmCode[:3]))
+	require.False(t, IsWasm(nil))
 	t.Log("should return true for exact wasm")
 	require.True(t, IsWasm(wasmCode))
 }
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/contractmsg"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// setAdminChangeNotification enables or disables the sudo notification of the contract on admin changes
+func (k Keeper) setAdminChangeNotification(ctx context.Context, contractAddress, caller sdk.AccAddress, enabled bool, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if err := k.importAdminChangeNotification(ctx, contractAddress, enabled); err != nil {
+		return err
+	}
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetAdminChangeNotify,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyEnabled, strconv.FormatBool(enabled)),
+	))
+	return nil
+}
+
+// HasAdminChangeNotification returns true when the contract is notified on admin changes
+func (k Keeper) HasAdminChangeNotification(ctx context.Context, contractAddress sdk.AccAddress) bool {
+	ok, err := k.storeService.OpenKVStore(ctx).Has(types.GetAdminChangeNotificationKey(contractAddress))
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+func (k Keeper) importAdminChangeNotification(ctx context.Context, contractAddress sdk.AccAddress, enabled bool) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetAdminChangeNotificationKey(contractAddress)
+	if !enabled {
+		return store.Delete(key)
+	}
+	return store.Set(key, []byte{1})
+}
+
+// notifyAdminChange sends the admin changed sudo message to the contract when the notification is enabled.
+// Returns true when the contract was notified.
+func (k Keeper) notifyAdminChange(ctx context.Context, contractAddress sdk.AccAddress, oldAdmin, newAdmin string) (bool, error) {
+	if !k.HasAdminChangeNotification(ctx, contractAddress) {
+		return false, nil
+	}
+	msg, err := contractmsg.AdminChanged(oldAdmin, newAdmin)
+	if err != nil {
+		return false, errorsmod.Wrap(err, "admin changed msg")
+	}
+	if _, err := k.Sudo(ctx, contractAddress, msg); err != nil {
+		return false, errorsmod.Wrap(err, "admin change notification")
+	}
+	return true, nil
+}
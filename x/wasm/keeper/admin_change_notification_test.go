@@ -0,0 +1,221 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSetAdminChangeNotification(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	specs := map[string]struct {
+		setup      func(ctx sdk.Context)
+		src        types.MsgSetAdminChangeNotification
+		expErr     error
+		expEnabled bool
+	}{
+		"enable": {
+			src:        types.MsgSetAdminChangeNotification{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Enabled: true},
+			expEnabled: true,
+		},
+		"disable": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.importAdminChangeNotification(ctx, example.Contract, true))
+			},
+			src: types.MsgSetAdminChangeNotification{Sender: example.CreatorAddr.String(), Contract: example.Contract.String()},
+		},
+		"not admin": {
+			src:    types.MsgSetAdminChangeNotification{Sender: RandomBech32AccountAddress(t), Contract: example.Contract.String(), Enabled: true},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			src:    types.MsgSetAdminChangeNotification{Sender: example.CreatorAddr.String(), Contract: RandomBech32AccountAddress(t), Enabled: true},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+			_, gotErr := msgServer.SetAdminChangeNotification(ctx.WithEventManager(em), &spec.src)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expEnabled, k.HasAdminChangeNotification(ctx, example.Contract))
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeSetAdminChangeNotify, em.Events()[0].Type)
+		})
+	}
+}
+
+func TestAdminChangeNotification(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	newAdmin, rejectedAdmin := RandomAccountAddress(t), RandomAccountAddress(t)
+
+	var gotMsgs []types.AdminChangedSudoMsg
+	mock.SudoFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, sudoMsg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		var msg types.AdminChangedSudoMsg
+		require.NoError(t, json.Unmarshal(sudoMsg, &msg))
+		gotMsgs = append(gotMsgs, msg)
+		if msg.AdminChanged.New == rejectedAdmin.String() {
+			return &wasmvmtypes.ContractResult{Err: "admin rejected"}, 0, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+
+	specs := map[string]struct {
+		notify      bool
+		exec        func(ctx sdk.Context) error
+		expAdmin    string
+		expMsgs     []types.AdminChangedSudoMsg
+		expNotified string
+		expErr      bool
+	}{
+		"update admin with notification": {
+			notify: true,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.UpdateAdmin(ctx, &types.MsgUpdateAdmin{Sender: example.CreatorAddr.String(), NewAdmin: newAdmin.String(), Contract: example.Contract.String()})
+				return err
+			},
+			expAdmin:    newAdmin.String(),
+			expMsgs:     []types.AdminChangedSudoMsg{{AdminChanged: types.AdminChanged{Old: example.CreatorAddr.String(), New: newAdmin.String()}}},
+			expNotified: "true",
+		},
+		"clear admin with notification": {
+			notify: true,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ClearAdmin(ctx, &types.MsgClearAdmin{Sender: example.CreatorAddr.String(), Contract: example.Contract.String()})
+				return err
+			},
+			expMsgs:     []types.AdminChangedSudoMsg{{AdminChanged: types.AdminChanged{Old: example.CreatorAddr.String()}}},
+			expNotified: "true",
+		},
+		"update admin without notification": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.UpdateAdmin(ctx, &types.MsgUpdateAdmin{Sender: example.CreatorAddr.String(), NewAdmin: newAdmin.String(), Contract: example.Contract.String()})
+				return err
+			},
+			expAdmin:    newAdmin.String(),
+			expNotified: "false",
+		},
+		"admin change rejected by contract": {
+			notify: true,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.UpdateAdmin(ctx, &types.MsgUpdateAdmin{Sender: example.CreatorAddr.String(), NewAdmin: rejectedAdmin.String(), Contract: example.Contract.String()})
+				return err
+			},
+			expAdmin: example.CreatorAddr.String(),
+			expMsgs:  []types.AdminChangedSudoMsg{{AdminChanged: types.AdminChanged{Old: example.CreatorAddr.String(), New: rejectedAdmin.String()}}},
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotMsgs = nil
+			ctx, _ := parentCtx.CacheContext()
+			require.NoError(t, k.importAdminChangeNotification(ctx, example.Contract, spec.notify))
+			em := sdk.NewEventManager()
+			// when
+			gotErr := spec.exec(ctx.WithEventManager(em))
+			// then
+			assert.Equal(t, spec.expMsgs, gotMsgs)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				// the tx is reverted as a whole
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expAdmin, k.GetContractInfo(ctx, example.Contract).Admin)
+			var found bool
+			for _, e := range em.Events() {
+				if e.Type != types.EventTypeUpdateContractAdmin {
+					continue
+				}
+				found = true
+				attr, ok := e.GetAttribute(types.AttributeKeyAdminChangeNotified)
+				require.True(t, ok)
+				assert.Equal(t, spec.expNotified, attr.Value)
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
+func TestInstantiateWithAdminChangeNotification(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	specs := map[string]struct {
+		src types.MsgInstantiateContract
+		exp bool
+	}{
+		"enabled": {
+			src: types.MsgInstantiateContract{Sender: example.CreatorAddr.String(), Admin: example.CreatorAddr.String(), CodeID: example.CodeID, Label: "enabled", Msg: []byte(`{}`), NotifyAdminChange: true},
+			exp: true,
+		},
+		"disabled": {
+			src: types.MsgInstantiateContract{Sender: example.CreatorAddr.String(), Admin: example.CreatorAddr.String(), CodeID: example.CodeID, Label: "disabled", Msg: []byte(`{}`)},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			rsp, err := msgServer.InstantiateContract(ctx, &spec.src)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, k.HasAdminChangeNotification(ctx, sdk.MustAccAddressFromBech32(rsp.Address)))
+		})
+	}
+}
+
+func TestAdminChangeNotificationGenesis(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	withInMemoryCodeStore(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, k.setAdminChangeNotification(ctx, example.Contract, example.CreatorAddr, true, DefaultAuthorizationPolicy{}))
+
+	// when
+	genState := ExportGenesis(ctx, k)
+	// then
+	require.Len(t, genState.Contracts, 1)
+	assert.True(t, genState.Contracts[0].NotifyAdminChange)
+
+	// when imported again
+	importCtx, importKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	importKeepers.WasmKeeper.wasmVM = &mock
+	_, err := InitGenesis(importCtx, importKeepers.WasmKeeper, *genState)
+	// then
+	require.NoError(t, err)
+	assert.True(t, importKeepers.WasmKeeper.HasAdminChangeNotification(importCtx, example.Contract))
+}
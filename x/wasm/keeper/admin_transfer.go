@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// proposeAdminTransfer records the pending admin of the contract. The admin changes when the pending admin
+// accepts the transfer. A previously proposed transfer is replaced.
+func (k Keeper) proposeAdminTransfer(ctx context.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if newAdmin.Equals(contractInfo.AdminAddr()) {
+		return errorsmod.Wrap(types.ErrInvalid, "new admin is the current admin")
+	}
+	contractInfo.PendingAdmin = newAdmin.String()
+	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeProposeAdminTransfer,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyPendingAdmin, contractInfo.PendingAdmin),
+	))
+	return nil
+}
+
+// acceptAdminTransfer sets the pending admin as the new admin of the contract. The caller must be the pending admin.
+func (k Keeper) acceptAdminTransfer(ctx context.Context, contractAddress, caller sdk.AccAddress) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if contractInfo.PendingAdmin == "" {
+		return types.ErrNotFound.Wrapf("admin transfer of contract %s", contractAddress)
+	}
+	if contractInfo.PendingAdmin != caller.String() {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "not the pending admin")
+	}
+	return k.changeContractAdmin(sdkCtx, contractAddress, contractInfo, caller)
+}
+
+// cancelAdminTransfer removes the pending admin of the contract
+func (k Keeper) cancelAdminTransfer(ctx context.Context, contractAddress, caller sdk.AccAddress, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if contractInfo.PendingAdmin == "" {
+		return types.ErrNotFound.Wrapf("admin transfer of contract %s", contractAddress)
+	}
+	contractInfo.PendingAdmin = ""
+	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeCancelAdminTransfer,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+	))
+	return nil
+}
@@ -0,0 +1,165 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestAdminTransfer(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	admin, contract := example.CreatorAddr.String(), example.Contract.String()
+	newAdmin, otherAddr := RandomBech32AccountAddress(t), RandomBech32AccountAddress(t)
+	proposeTransfer := func(ctx sdk.Context) {
+		_, err := msgServer.ProposeAdminTransfer(ctx, &types.MsgProposeAdminTransfer{Sender: admin, Contract: contract, NewAdmin: newAdmin})
+		require.NoError(t, err)
+	}
+
+	specs := map[string]struct {
+		setup           func(ctx sdk.Context)
+		exec            func(ctx sdk.Context) error
+		expErr          error
+		expAdmin        string
+		expPendingAdmin string
+		expEvent        string
+	}{
+		"propose by admin": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ProposeAdminTransfer(ctx, &types.MsgProposeAdminTransfer{Sender: admin, Contract: contract, NewAdmin: newAdmin})
+				return err
+			},
+			expAdmin:        admin,
+			expPendingAdmin: newAdmin,
+			expEvent:        types.EventTypeProposeAdminTransfer,
+		},
+		"propose replaces pending admin": {
+			setup: proposeTransfer,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ProposeAdminTransfer(ctx, &types.MsgProposeAdminTransfer{Sender: admin, Contract: contract, NewAdmin: otherAddr})
+				return err
+			},
+			expAdmin:        admin,
+			expPendingAdmin: otherAddr,
+			expEvent:        types.EventTypeProposeAdminTransfer,
+		},
+		"propose by non admin": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ProposeAdminTransfer(ctx, &types.MsgProposeAdminTransfer{Sender: otherAddr, Contract: contract, NewAdmin: newAdmin})
+				return err
+			},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"propose current admin": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ProposeAdminTransfer(ctx, &types.MsgProposeAdminTransfer{Sender: admin, Contract: contract, NewAdmin: admin})
+				return err
+			},
+			expErr: types.ErrInvalid,
+		},
+		"accept by pending admin": {
+			setup: proposeTransfer,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.AcceptAdminTransfer(ctx, &types.MsgAcceptAdminTransfer{Sender: newAdmin, Contract: contract})
+				return err
+			},
+			expAdmin: newAdmin,
+			expEvent: types.EventTypeUpdateContractAdmin,
+		},
+		"accept by other": {
+			setup: proposeTransfer,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.AcceptAdminTransfer(ctx, &types.MsgAcceptAdminTransfer{Sender: admin, Contract: contract})
+				return err
+			},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"accept without proposal": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.AcceptAdminTransfer(ctx, &types.MsgAcceptAdminTransfer{Sender: newAdmin, Contract: contract})
+				return err
+			},
+			expErr: types.ErrNotFound,
+		},
+		"cancel by admin": {
+			setup: proposeTransfer,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.CancelAdminTransfer(ctx, &types.MsgCancelAdminTransfer{Sender: admin, Contract: contract})
+				return err
+			},
+			expAdmin: admin,
+			expEvent: types.EventTypeCancelAdminTransfer,
+		},
+		"cancel by pending admin": {
+			setup: proposeTransfer,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.CancelAdminTransfer(ctx, &types.MsgCancelAdminTransfer{Sender: newAdmin, Contract: contract})
+				return err
+			},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"cancel without proposal": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.CancelAdminTransfer(ctx, &types.MsgCancelAdminTransfer{Sender: admin, Contract: contract})
+				return err
+			},
+			expErr: types.ErrNotFound,
+		},
+		"direct update admin clears pending admin": {
+			setup: proposeTransfer,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.UpdateAdmin(ctx, &types.MsgUpdateAdmin{Sender: admin, Contract: contract, NewAdmin: otherAddr})
+				return err
+			},
+			expAdmin: otherAddr,
+			expEvent: types.EventTypeUpdateContractAdmin,
+		},
+		"clear admin clears pending admin": {
+			setup: proposeTransfer,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ClearAdmin(ctx, &types.MsgClearAdmin{Sender: admin, Contract: contract})
+				return err
+			},
+			expEvent: types.EventTypeUpdateContractAdmin,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			gotErr := spec.exec(ctx.WithEventManager(em))
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			info := k.GetContractInfo(ctx, example.Contract)
+			assert.Equal(t, spec.expAdmin, info.Admin)
+			assert.Equal(t, spec.expPendingAdmin, info.PendingAdmin)
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, spec.expEvent, em.Events()[0].Type)
+			// and the pending admin is returned by the contract info query
+			res, err := Querier(k).ContractInfo(ctx, &types.QueryContractInfoRequest{Address: contract})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expPendingAdmin, res.PendingAdmin)
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package keeper
 
 import (
+	"bytes"
+	"context"
 	"encoding/binary"
 
 	corestoretypes "cosmossdk.io/core/store"
 	errorsmod "cosmossdk.io/errors"
 	storetypes "cosmossdk.io/store/types"
+	"cosmossdk.io/x/feegrant"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -134,3 +138,103 @@ func (d TxContractsDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bo
 	txContracts := types.NewTxContracts()
 	return next(types.WithTxContracts(ctx, txContracts), tx, simulate)
 }
+
+// TxSignersDecorator implements an AnteHandler that stores the signers of the tx in the context. This allows
+// telling messages that are executed by a grantee on behalf of another account apart from the signed messages.
+type TxSignersDecorator struct{}
+
+// NewTxSignersDecorator constructor.
+func NewTxSignersDecorator() *TxSignersDecorator {
+	return &TxSignersDecorator{}
+}
+
+// AnteHandle stores the signers of the tx in the context. Txs without signer information are passed on unchanged.
+func (d TxSignersDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return next(ctx, tx, simulate)
+	}
+	signers, err := sigTx.GetSigners()
+	if err != nil {
+		return ctx, errorsmod.Wrap(err, "tx signers")
+	}
+	addrs := make([]sdk.AccAddress, len(signers))
+	for i, s := range signers {
+		addrs[i] = s
+	}
+	return next(types.WithTxSigners(ctx, addrs), tx, simulate)
+}
+
+// FeeGrantKeeper is the subset of the feegrant keeper that is used to pay sponsored fees
+type FeeGrantKeeper interface {
+	GrantAllowance(ctx context.Context, granter, grantee sdk.AccAddress, feeAllowance feegrant.FeeAllowanceI) error
+}
+
+// ContractFeeSponsorshipDecorator ante decorator to let contracts pay the fees of transactions that execute only them
+type ContractFeeSponsorshipDecorator struct {
+	keeper         *Keeper
+	feegrantKeeper FeeGrantKeeper
+}
+
+// NewContractFeeSponsorshipDecorator constructor. The decorator must run before the sdk DeductFeeDecorator.
+func NewContractFeeSponsorshipDecorator(k *Keeper, feegrantKeeper FeeGrantKeeper) *ContractFeeSponsorshipDecorator {
+	return &ContractFeeSponsorshipDecorator{keeper: k, feegrantKeeper: feegrantKeeper}
+}
+
+// AnteHandle grants a fee allowance of exactly the tx fee from the contract to the fee payer when
+//   - the tx sets the contract as fee granter
+//   - all messages of the tx are MsgExecuteContract for this contract
+//   - the contract opted in, is neither suspended nor inactive and the fee is within its per tx and per block budgets
+//
+// The sdk DeductFeeDecorator then deducts the fee from the contract balance and removes the used up allowance.
+// In all other cases the tx gets the normal fee handling.
+func (d ContractFeeSponsorshipDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return next(ctx, tx, simulate)
+	}
+	contractAddr := sponsoringContract(feeTx)
+	fee := feeTx.GetFee()
+	if contractAddr == nil || fee.IsZero() {
+		return next(ctx, tx, simulate)
+	}
+	cacheCtx, commit := ctx.CacheContext()
+	sponsored, err := d.keeper.useFeeSponsorship(cacheCtx, contractAddr, fee)
+	if err != nil {
+		return ctx, err
+	}
+	if !sponsored {
+		return next(ctx, tx, simulate)
+	}
+	// fails when an allowance exists already. It is used by the normal fee handling then.
+	if err := d.feegrantKeeper.GrantAllowance(cacheCtx, contractAddr, feeTx.FeePayer(), &feegrant.BasicAllowance{SpendLimit: fee}); err != nil {
+		return next(ctx, tx, simulate)
+	}
+	commit()
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeFeeSponsored,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(sdk.AttributeKeyFee, fee.String()),
+	))
+	return next(ctx, tx, simulate)
+}
+
+// sponsoringContract returns the fee granter when all messages execute this contract. Nil otherwise.
+func sponsoringContract(tx sdk.FeeTx) sdk.AccAddress {
+	granter := sdk.AccAddress(tx.FeeGranter())
+	msgs := tx.GetMsgs()
+	if len(granter) == 0 || len(msgs) == 0 {
+		return nil
+	}
+	for _, msg := range msgs {
+		execMsg, ok := msg.(*types.MsgExecuteContract)
+		if !ok {
+			return nil
+		}
+		contractAddr, err := sdk.AccAddressFromBech32(execMsg.Contract)
+		if err != nil || !bytes.Equal(contractAddr, granter) {
+			return nil
+		}
+	}
+	return granter
+}
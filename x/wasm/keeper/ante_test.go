@@ -16,6 +16,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
@@ -311,3 +312,50 @@ func TestTxContractsDecorator(t *testing.T) {
 		})
 	}
 }
+
+func TestTxSignersDecorator(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db, log.NewTestLogger(t), storemetrics.NewNoOpMetrics())
+	ctx := sdk.NewContext(ms, cmtproto.Header{Height: 100, Time: time.Now()}, false, log.NewNopLogger())
+	mySigner, otherSigner := keeper.RandomAccountAddress(t), keeper.RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		tx         sdk.Tx
+		expSigners []sdk.AccAddress
+		expStored  bool
+	}{
+		"signers stored": {
+			tx:         mockSigVerifiableTx{signers: [][]byte{mySigner, otherSigner}},
+			expSigners: []sdk.AccAddress{mySigner, otherSigner},
+			expStored:  true,
+		},
+		"tx without signer information": {
+			tx: nil,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotSigners []sdk.AccAddress
+			var gotStored bool
+			// when
+			ante := keeper.NewTxSignersDecorator()
+			_, gotErr := ante.AnteHandle(ctx, spec.tx, false, func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				gotSigners, gotStored = types.TxSignersFromContext(ctx)
+				return ctx, nil
+			})
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expStored, gotStored)
+			assert.Equal(t, spec.expSigners, gotSigners)
+		})
+	}
+}
+
+type mockSigVerifiableTx struct {
+	authsigning.SigVerifiableTx
+	signers [][]byte
+}
+
+func (m mockSigVerifiableTx) GetSigners() ([][]byte, error) {
+	return m.signers, nil
+}
@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-metrics"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// operation labels of the block execution time histogram
+const (
+	execOpStoreCode   = "store_code"
+	execOpInstantiate = "instantiate"
+	execOpExecute     = "execute"
+	execOpMigrate     = "migrate"
+	execOpSudo        = "sudo"
+)
+
+// blockExecTime accumulates the wall-clock time that the message server spends on wasm operations in the
+// current block. The totals are observed in the telemetry histogram at the end of the block and reset.
+// This is node local bookkeeping that never touches state.
+type blockExecTime struct {
+	mu     sync.Mutex
+	totals map[string]time.Duration
+}
+
+func newBlockExecTime() *blockExecTime {
+	return &blockExecTime{totals: make(map[string]time.Duration)}
+}
+
+// measureSince adds the time since start to the block total of the operation. The start is zero when
+// telemetry is disabled, see telemetry.Now. Only top level messages in FinalizeBlock are measured so that
+// simulations are ignored and the sub-messages of contracts are not counted twice.
+func (b *blockExecTime) measureSince(ctx context.Context, start time.Time, operation string) {
+	if b == nil || start.IsZero() {
+		return
+	}
+	if sdk.UnwrapSDKContext(ctx).ExecMode() != sdk.ExecModeFinalize {
+		return
+	}
+	if _, nested := types.CallDepth(ctx); nested {
+		return
+	}
+	elapsed := time.Since(start)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.totals[operation] += elapsed
+}
+
+// observe adds the block totals of the operations to the histogram in milliseconds and resets them
+func (b *blockExecTime) observe() {
+	if b == nil || !telemetry.IsTelemetryEnabled() {
+		return
+	}
+	b.mu.Lock()
+	totals := b.totals
+	b.totals = make(map[string]time.Duration, len(totals))
+	b.mu.Unlock()
+	for operation, total := range totals {
+		metrics.AddSampleWithLabels(
+			[]string{"wasm", "block", "execution_time"},
+			float32(total)/float32(time.Millisecond),
+			[]metrics.Label{telemetry.NewLabel("operation", operation)},
+		)
+	}
+}
+
+// ObserveBlockExecTime adds the wall-clock time of the wasm operations in the current block to the
+// telemetry histogram. To be called at the end of the block.
+func (k Keeper) ObserveBlockExecTime() {
+	k.blockExecTime.observe()
+}
+
+// countVMOutOfGas increments the counter of contract calls that ran out of gas in FinalizeBlock
+func countVMOutOfGas(ctx sdk.Context) {
+	if ctx.ExecMode() != sdk.ExecModeFinalize {
+		return
+	}
+	telemetry.IncrCounter(1, "wasm", "vm", "out_of_gas")
+}
@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestBlockExecTime(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = ctx.WithExecMode(sdk.ExecModeFinalize)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	var outOfGas bool
+	mock.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		if outOfGas {
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 2 * gasLimit, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	executeMsg := &types.MsgExecuteContract{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Msg: []byte(`{}`)}
+
+	// when telemetry is disabled
+	_, err := telemetry.New(telemetry.Config{Enabled: false})
+	require.NoError(t, err)
+	_, err = msgServer.ExecuteContract(ctx, executeMsg)
+	require.NoError(t, err)
+	// then nothing is recorded
+	assert.Empty(t, k.blockExecTime.totals)
+
+	m, err := telemetry.New(telemetry.Config{MetricsSink: telemetry.MetricSinkInMem, Enabled: true, ServiceName: "test"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = telemetry.New(telemetry.Config{Enabled: false})
+	})
+
+	// when a block with multiple messages is executed
+	for i := 0; i < 3; i++ {
+		_, err := msgServer.ExecuteContract(ctx, executeMsg)
+		require.NoError(t, err)
+	}
+	_, err = msgServer.InstantiateContract(ctx, &types.MsgInstantiateContract{Sender: example.CreatorAddr.String(), CodeID: example.CodeID, Label: "other", Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	// and simulated
+	_, err = msgServer.ExecuteContract(ctx.WithExecMode(sdk.ExecModeSimulate), executeMsg)
+	require.NoError(t, err)
+	k.ObserveBlockExecTime()
+
+	// then one observation per operation is recorded
+	got := gatherTelemetry(t, m)
+	assert.Equal(t, map[string]int{"execute": 1, "instantiate": 1}, got.samples("test.wasm.block.execution_time"))
+	// and the totals are reset
+	assert.Empty(t, k.blockExecTime.totals)
+
+	// when the next block has no wasm operations
+	k.ObserveBlockExecTime()
+	// then nothing is observed
+	got = gatherTelemetry(t, m)
+	assert.Equal(t, map[string]int{"execute": 1, "instantiate": 1}, got.samples("test.wasm.block.execution_time"))
+
+	// when the vm runs out of gas
+	outOfGas = true
+	require.Panics(t, func() {
+		_, _ = msgServer.ExecuteContract(ctx.WithGasMeter(storetypes.NewGasMeter(1_000_000)), executeMsg)
+	})
+	// then the out of gas counter is incremented
+	got = gatherTelemetry(t, m)
+	assert.Equal(t, 1, got.counter("test.wasm.vm.out_of_gas"))
+}
+
+// telemetrySummary is the json of the in memory telemetry sink
+type telemetrySummary struct {
+	Counters []telemetryValue
+	Samples  []telemetryValue
+}
+
+type telemetryValue struct {
+	Name   string
+	Count  int
+	Labels map[string]string
+}
+
+func gatherTelemetry(t *testing.T, m *telemetry.Metrics) telemetrySummary {
+	t.Helper()
+	gr, err := m.Gather(telemetry.FormatText)
+	require.NoError(t, err)
+	var result telemetrySummary
+	require.NoError(t, json.Unmarshal(gr.Metrics, &result))
+	return result
+}
+
+// samples returns the number of observations by operation label
+func (s telemetrySummary) samples(name string) map[string]int {
+	result := make(map[string]int)
+	for _, v := range s.Samples {
+		if v.Name == name {
+			result[v.Labels["operation"]] += v.Count
+		}
+	}
+	return result
+}
+
+func (s telemetrySummary) counter(name string) int {
+	var result int
+	for _, v := range s.Counters {
+		if v.Name == name {
+			result += v.Count
+		}
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// blockSummaryCountersLen is the size of the stored counters: instantiates, executes, migrates, VM gas
+// and contracts touched
+const blockSummaryCountersLen = 5 * 8
+
+// recordBlockSummary adds the contract call to the wasm activity of the current block. The activity is
+// kept in the transient store so that calls of reverted state are not counted.
+// The transient store bookkeeping is not charged to the caller so that contract gas costs are not affected.
+// This is a noop when no transient store is set or the summary events are disabled in the params that were
+// loaded for the call.
+func (k Keeper) recordBlockSummary(ctx sdk.Context, params types.Params, contractAddress sdk.AccAddress, entryPoint string, vmGasUsed uint64) {
+	if k.transientStoreService == nil || params.DisableBlockSummaryEvents {
+		return
+	}
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	summary := k.getBlockSummary(ctx)
+	switch entryPoint {
+	case "instantiate":
+		summary.Instantiates++
+	case "execute":
+		summary.Executes++
+	case "migrate":
+		summary.Migrates++
+	}
+	summary.VMGasUsed += k.gasRegister.FromWasmVMGas(vmGasUsed)
+
+	store := k.transientStoreService.OpenTransientStore(ctx)
+	contractKey := types.GetBlockSummaryContractKey(contractAddress)
+	touched, err := store.Has(contractKey)
+	if err != nil {
+		panic(err)
+	}
+	if !touched {
+		if err := store.Set(contractKey, []byte{}); err != nil {
+			panic(err)
+		}
+		summary.ContractsTouched++
+	}
+
+	bz := make([]byte, 0, blockSummaryCountersLen)
+	for _, v := range []uint64{summary.Instantiates, summary.Executes, summary.Migrates, summary.VMGasUsed, summary.ContractsTouched} {
+		bz = binary.BigEndian.AppendUint64(bz, v)
+	}
+	if err := store.Set(types.BlockSummaryCountersKey, bz); err != nil {
+		panic(err)
+	}
+}
+
+// getBlockSummary returns the wasm activity that was recorded in the current block
+func (k Keeper) getBlockSummary(ctx sdk.Context) types.EventBlockWasmSummary {
+	bz, err := k.transientStoreService.OpenTransientStore(ctx).Get(types.BlockSummaryCountersKey)
+	if err != nil {
+		panic(err)
+	}
+	summary := types.EventBlockWasmSummary{Height: ctx.BlockHeight()}
+	if len(bz) != blockSummaryCountersLen {
+		return summary
+	}
+	summary.Instantiates = binary.BigEndian.Uint64(bz[0:8])
+	summary.Executes = binary.BigEndian.Uint64(bz[8:16])
+	summary.Migrates = binary.BigEndian.Uint64(bz[16:24])
+	summary.VMGasUsed = binary.BigEndian.Uint64(bz[24:32])
+	summary.ContractsTouched = binary.BigEndian.Uint64(bz[32:40])
+	return summary
+}
+
+// EmitBlockSummary emits the EventBlockWasmSummary with the wasm activity of the current block.
+// This is a noop when no transient store is set or the summary events are disabled in the params.
+func (k Keeper) EmitBlockSummary(ctx sdk.Context) error {
+	if k.transientStoreService == nil {
+		return nil
+	}
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	if k.GetParams(ctx).DisableBlockSummaryEvents {
+		return nil
+	}
+	summary := k.getBlockSummary(ctx)
+	return ctx.EventManager().EmitTypedEvent(&summary)
+}
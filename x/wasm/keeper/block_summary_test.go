@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestEmitBlockSummary(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.InstantiateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, types.DefaultGasMultiplier, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	example := StoreRandomContract(t, parentCtx, keepers, &mock)
+
+	specs := map[string]struct {
+		disabled         bool
+		noTransientStore bool
+		reverted         bool
+		exp              *types.EventBlockWasmSummary
+	}{
+		"recorded": {
+			exp: &types.EventBlockWasmSummary{Height: parentCtx.BlockHeight(), Instantiates: 2, ContractsTouched: 2, VMGasUsed: 2},
+		},
+		"reverted calls": {
+			reverted: true,
+			exp:      &types.EventBlockWasmSummary{Height: parentCtx.BlockHeight()},
+		},
+		"disabled in params": {
+			disabled: true,
+		},
+		"no transient store": {
+			noTransientStore: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			parentCtx, _ := parentCtx.CacheContext()
+			k := *keepers.WasmKeeper
+			if spec.noTransientStore {
+				k.transientStoreService = nil
+			}
+			params := k.GetParams(parentCtx)
+			params.DisableBlockSummaryEvents = spec.disabled
+			require.NoError(t, k.SetParams(parentCtx, params))
+			ctx, commit := parentCtx.CacheContext()
+			for i := 0; i < 2; i++ {
+				_, _, err := NewDefaultPermissionKeeper(k).Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "test", nil)
+				require.NoError(t, err)
+			}
+			if !spec.reverted {
+				commit()
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			require.NoError(t, k.EmitBlockSummary(parentCtx.WithEventManager(em)))
+
+			// then
+			if spec.exp == nil {
+				assert.Empty(t, em.Events())
+				return
+			}
+			require.Len(t, em.Events(), 1)
+			got, err := sdk.ParseTypedEvent(em.Events().ToABCIEvents()[0])
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// attestCode stores the attestation of the attester for the code. An existing attestation of the same attester
+// is updated in place. Attestations are unverified claims, the chain only records them.
+func (k Keeper) attestCode(ctx context.Context, codeID uint64, attester sdk.AccAddress, repoURL, commit, builderImage string) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if k.GetCodeInfo(ctx, codeID) == nil {
+		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	sdkCtx.GasMeter().ConsumeGas(types.DefaultAttestCodeCost, "Attest code")
+
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetCodeAttestationKey(codeID, attester)
+	exists, err := store.Has(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		var count int
+		k.IterateCodeAttestations(ctx, codeID, func(types.CodeAttestation) bool {
+			count++
+			return false
+		})
+		if count >= types.MaxAttestationsPerCode {
+			return types.ErrLimit.Wrapf("max %d attestations per code", types.MaxAttestationsPerCode)
+		}
+	}
+	attestation := types.CodeAttestation{
+		Attester:     attester.String(),
+		RepoURL:      repoURL,
+		Commit:       commit,
+		BuilderImage: builderImage,
+		Height:       sdkCtx.BlockHeight(),
+	}
+	if err := store.Set(key, k.cdc.MustMarshal(&attestation)); err != nil {
+		return err
+	}
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeAttestCode,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAttester, attestation.Attester),
+		sdk.NewAttribute(types.AttributeKeyCommit, commit),
+	))
+	return nil
+}
+
+// IterateCodeAttestations iterates over all attestations of the code ordered by attester address
+func (k Keeper) IterateCodeAttestations(ctx context.Context, codeID uint64, cb func(types.CodeAttestation) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetCodeAttestationPrefix(codeID))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var a types.CodeAttestation
+		k.cdc.MustUnmarshal(iter.Value(), &a)
+		if cb(a) {
+			return
+		}
+	}
+}
+
+func (k Keeper) importCodeAttestations(ctx context.Context, codeID uint64, attestations []types.CodeAttestation) error {
+	store := k.storeService.OpenKVStore(ctx)
+	for _, a := range attestations {
+		attester, err := sdk.AccAddressFromBech32(a.Attester)
+		if err != nil {
+			return errorsmod.Wrap(err, "attester")
+		}
+		key := types.GetCodeAttestationKey(codeID, attester)
+		ok, err := store.Has(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errorsmod.Wrapf(types.ErrDuplicate, "attestation by %s", a.Attester)
+		}
+		if err := store.Set(key, k.cdc.MustMarshal(&a)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
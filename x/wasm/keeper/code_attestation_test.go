@@ -0,0 +1,157 @@
+package keeper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestAttestCode(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	parentCtx = parentCtx.WithBlockHeight(7)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, parentCtx, keepers)
+	msgServer := NewMsgServerImpl(k)
+
+	const (
+		myRepo  = "https://github.com/CosmWasm/cosmwasm"
+		myImage = "cosmwasm/optimizer:0.16.0"
+	)
+	myCommit, otherCommit := strings.Repeat("a", 40), strings.Repeat("b", 40)
+	myAttester := RandomAccountAddress(t)
+	specs := map[string]struct {
+		setup    func(ctx sdk.Context)
+		src      types.MsgAttestCode
+		expErr   error
+		expCount int
+	}{
+		"create": {
+			src:      types.MsgAttestCode{Attester: myAttester.String(), CodeID: example.CodeID, RepoURL: myRepo, Commit: myCommit, BuilderImage: myImage},
+			expCount: 1,
+		},
+		"update by same attester": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.attestCode(ctx.WithBlockHeight(1), example.CodeID, myAttester, myRepo, otherCommit, myImage))
+			},
+			src:      types.MsgAttestCode{Attester: myAttester.String(), CodeID: example.CodeID, RepoURL: myRepo, Commit: myCommit, BuilderImage: myImage},
+			expCount: 1,
+		},
+		"other attester": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.attestCode(ctx, example.CodeID, RandomAccountAddress(t), myRepo, otherCommit, myImage))
+			},
+			src:      types.MsgAttestCode{Attester: myAttester.String(), CodeID: example.CodeID, RepoURL: myRepo, Commit: myCommit, BuilderImage: myImage},
+			expCount: 2,
+		},
+		"max attestations reached": {
+			setup: func(ctx sdk.Context) {
+				for i := 0; i < types.MaxAttestationsPerCode; i++ {
+					require.NoError(t, k.attestCode(ctx, example.CodeID, RandomAccountAddress(t), myRepo, otherCommit, myImage))
+				}
+			},
+			src:    types.MsgAttestCode{Attester: myAttester.String(), CodeID: example.CodeID, RepoURL: myRepo, Commit: myCommit, BuilderImage: myImage},
+			expErr: types.ErrLimit,
+		},
+		"update with max attestations reached": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.attestCode(ctx, example.CodeID, myAttester, myRepo, otherCommit, myImage))
+				for i := 1; i < types.MaxAttestationsPerCode; i++ {
+					require.NoError(t, k.attestCode(ctx, example.CodeID, RandomAccountAddress(t), myRepo, otherCommit, myImage))
+				}
+			},
+			src:      types.MsgAttestCode{Attester: myAttester.String(), CodeID: example.CodeID, RepoURL: myRepo, Commit: myCommit, BuilderImage: myImage},
+			expCount: types.MaxAttestationsPerCode,
+		},
+		"unknown code": {
+			src:    types.MsgAttestCode{Attester: myAttester.String(), CodeID: 99, RepoURL: myRepo, Commit: myCommit, BuilderImage: myImage},
+			expErr: types.ErrNoSuchCodeFn(99),
+		},
+		"invalid commit": {
+			src:    types.MsgAttestCode{Attester: myAttester.String(), CodeID: example.CodeID, RepoURL: myRepo, Commit: "main", BuilderImage: myImage},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			gasBefore := ctx.GasMeter().GasConsumed()
+			em := sdk.NewEventManager()
+
+			// when
+			_, gotErr := msgServer.AttestCode(ctx.WithEventManager(em), &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed()-gasBefore, types.DefaultAttestCodeCost)
+			var count int
+			var got *types.CodeAttestation
+			k.IterateCodeAttestations(ctx, example.CodeID, func(a types.CodeAttestation) bool {
+				count++
+				if a.Attester == myAttester.String() {
+					got = &a
+				}
+				return false
+			})
+			assert.Equal(t, spec.expCount, count)
+			require.NotNil(t, got)
+			assert.Equal(t, types.CodeAttestation{
+				Attester:     myAttester.String(),
+				RepoURL:      myRepo,
+				Commit:       myCommit,
+				BuilderImage: myImage,
+				Height:       7,
+			}, *got)
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeAttestCode, em.Events()[0].Type)
+		})
+	}
+}
+
+func TestQueryCodeAttestations(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, k.attestCode(ctx, example.CodeID, RandomAccountAddress(t), "https://github.com/CosmWasm/cosmwasm", strings.Repeat("a", 40), "cosmwasm/optimizer:0.16.0"))
+	}
+	q := Querier(k)
+
+	// when
+	rsp, err := q.CodeAttestations(ctx, &types.QueryCodeAttestationsRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Limit: 2}})
+	// then
+	require.NoError(t, err)
+	assert.Len(t, rsp.Attestations, 2)
+	require.NotEmpty(t, rsp.Pagination.NextKey)
+
+	// when next page
+	rsp, err = q.CodeAttestations(ctx, &types.QueryCodeAttestationsRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Key: rsp.Pagination.NextKey}})
+	// then
+	require.NoError(t, err)
+	assert.Len(t, rsp.Attestations, 1)
+	assert.Empty(t, rsp.Pagination.NextKey)
+
+	// when other code
+	rsp, err = q.CodeAttestations(ctx, &types.QueryCodeAttestationsRequest{CodeId: example.CodeID + 1})
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, rsp.Attestations)
+
+	// when empty code id
+	_, err = q.CodeAttestations(ctx, &types.QueryCodeAttestationsRequest{})
+	// then
+	require.ErrorIs(t, err, types.ErrInvalid)
+}
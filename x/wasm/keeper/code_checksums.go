@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// addToCodeChecksumIndex adds element to the index for codes-by-checksum lookups
+func (k Keeper) addToCodeChecksumIndex(ctx context.Context, checksum []byte, codeID uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Set(types.GetCodeByChecksumKey(checksum, codeID), []byte{})
+}
+
+// IterateCodesByChecksum iterates over all codes with the checksum ordered by code id
+func (k Keeper) IterateCodesByChecksum(ctx context.Context, checksum []byte, cb func(codeID uint64) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetCodesByChecksumPrefix(checksum))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(sdk.BigEndianToUint64(iter.Key())) {
+			return
+		}
+	}
+}
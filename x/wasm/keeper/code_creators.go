@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// addToCodeCreatorIndex adds element to the index for codes-by-creator lookups
+func (k Keeper) addToCodeCreatorIndex(ctx context.Context, creator sdk.AccAddress, codeID uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Set(types.GetCodeByCreatorKey(creator, codeID), []byte{})
+}
+
+// IterateCodesByCreator iterates over all codes of the creator ordered by code id
+func (k Keeper) IterateCodesByCreator(ctx context.Context, creator sdk.AccAddress, cb func(codeID uint64) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetCodesByCreatorPrefix(creator))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(sdk.BigEndianToUint64(iter.Key())) {
+			return
+		}
+	}
+}
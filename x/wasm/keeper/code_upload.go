@@ -0,0 +1,195 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// DefaultCodeUploadTTL is the number of blocks a chunked code upload is kept in state before it is pruned
+const DefaultCodeUploadTTL int64 = 1000
+
+// storeCodeChunk stores a part of a chunked code upload. The upload is created with the first chunk
+// and expires after the configured TTL, independent of the chunks received later.
+func (k Keeper) storeCodeChunk(ctx context.Context, sender sdk.AccAddress, uploadID string, index, total uint32, data []byte) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	// fail early, the instantiate permission is checked when the upload is completed
+	if !k.getUploadAccessConfig(sdkCtx).Allowed(sender) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not upload code")
+	}
+	upload := k.GetCodeUpload(ctx, sender, uploadID)
+	isNew := upload == nil
+	if isNew {
+		upload = &types.CodeUpload{Total: total, ExpiresAt: sdkCtx.BlockHeight() + k.codeUploadTTL}
+	}
+	if upload.Total != total {
+		return errorsmod.Wrapf(types.ErrInvalid, "total %d does not match upload total %d", total, upload.Total)
+	}
+	if index >= upload.Total {
+		return errorsmod.Wrapf(types.ErrInvalid, "index %d must be lower than total %d", index, upload.Total)
+	}
+	if upload.ReceivedBytes+uint64(len(data)) > uint64(types.MaxWasmSize) {
+		return errorsmod.Wrapf(types.ErrLimit, "code upload cannot be larger than %d bytes", types.MaxWasmSize)
+	}
+
+	store := k.storeService.OpenKVStore(ctx)
+	chunkKey := types.GetCodeUploadChunkKey(sender, uploadID, index)
+	ok, err := store.Has(chunkKey)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return errorsmod.Wrapf(types.ErrDuplicate, "chunk %d", index)
+	}
+	if isNew {
+		// store 1 byte to not run into `nil` debugging issues
+		if err := store.Set(types.GetCodeUploadExpiryKey(upload.ExpiresAt, sender, uploadID), []byte{1}); err != nil {
+			return err
+		}
+	}
+	if err := store.Set(chunkKey, data); err != nil {
+		return err
+	}
+	upload.Received++
+	upload.ReceivedBytes += uint64(len(data))
+	if err := store.Set(types.GetCodeUploadKey(sender, uploadID), k.cdc.MustMarshal(upload)); err != nil {
+		return err
+	}
+
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeStoreCodeChunk,
+		sdk.NewAttribute(types.AttributeKeyUploadID, uploadID),
+		sdk.NewAttribute(types.AttributeKeyChunkIndex, strconv.FormatUint(uint64(index), 10)),
+	))
+	return nil
+}
+
+// completeStoreCode assembles all chunks of the upload, verifies the checksum and stores the code.
+// The upload is removed from state.
+func (k Keeper) completeStoreCode(
+	ctx context.Context,
+	sender sdk.AccAddress,
+	uploadID string,
+	expChecksum []byte,
+	instantiateAccess *types.AccessConfig,
+	origin types.CodeOrigin,
+	authZ types.AuthorizationPolicy,
+) (uint64, []byte, error) {
+	upload := k.GetCodeUpload(ctx, sender, uploadID)
+	if upload == nil {
+		return 0, nil, types.ErrNotFound.Wrapf("code upload %s", uploadID)
+	}
+	if upload.Received != upload.Total {
+		return 0, nil, errorsmod.Wrapf(types.ErrInvalid, "incomplete upload: received %d of %d chunks", upload.Received, upload.Total)
+	}
+
+	wasmCode := make([]byte, 0, upload.ReceivedBytes)
+	k.iterateCodeUploadChunks(ctx, sender, uploadID, func(data []byte) bool {
+		wasmCode = append(wasmCode, data...)
+		return false
+	})
+	k.deleteCodeUpload(ctx, sender, uploadID, upload.ExpiresAt)
+
+	// verify before compiling the code, ungzipping consumes gas
+	if ioutils.IsGzip(wasmCode) {
+		sdkCtx := sdk.UnwrapSDKContext(ctx)
+		sdkCtx.GasMeter().ConsumeGas(k.gasRegister.UncompressCosts(len(wasmCode)), "Uncompress gzip bytecode")
+		var err error
+		wasmCode, err = ioutils.Uncompress(wasmCode, int64(types.MaxWasmSize))
+		if err != nil {
+			return 0, nil, types.ErrCreateFailed.Wrap(errorsmod.Wrap(err, "uncompress wasm archive").Error())
+		}
+	}
+	if checksum := sha256.Sum256(wasmCode); !bytes.Equal(checksum[:], expChecksum) {
+		return 0, nil, errorsmod.Wrap(types.ErrInvalid, "checksum does not match assembled code")
+	}
+	return k.create(ctx, sender, wasmCode, instantiateAccess, origin, authZ)
+}
+
+// GetCodeUpload returns the state of a chunked code upload or nil when not found
+func (k Keeper) GetCodeUpload(ctx context.Context, sender sdk.AccAddress, uploadID string) *types.CodeUpload {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetCodeUploadKey(sender, uploadID))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return nil
+	}
+	var upload types.CodeUpload
+	k.cdc.MustUnmarshal(bz, &upload)
+	return &upload
+}
+
+// iterateCodeUploadChunks iterates over the chunk data of an upload ordered by index
+func (k Keeper) iterateCodeUploadChunks(ctx context.Context, sender sdk.AccAddress, uploadID string, cb func(data []byte) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetCodeUploadChunkPrefix(sender, uploadID))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Value()) {
+			return
+		}
+	}
+}
+
+// deleteCodeUpload removes the upload with all chunks and the expiry index entry
+func (k Keeper) deleteCodeUpload(ctx context.Context, sender sdk.AccAddress, uploadID string, expiresAt int64) {
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	prefixStore := prefix.NewStore(store, types.GetCodeUploadChunkPrefix(sender, uploadID))
+	iter := prefixStore.Iterator(nil, nil)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		prefixStore.Delete(key)
+	}
+	store.Delete(types.GetCodeUploadKey(sender, uploadID))
+	store.Delete(types.GetCodeUploadExpiryKey(expiresAt, sender, uploadID))
+}
+
+// PruneExpiredCodeUploads removes all chunked code uploads that expired at or before the current block height
+func (k Keeper) PruneExpiredCodeUploads(ctx sdk.Context) error {
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	iter := store.Iterator(types.CodeUploadExpiryPrefix, types.GetCodeUploadExpiryPrefix(ctx.BlockHeight()+1))
+	type expiredUpload struct {
+		sender    sdk.AccAddress
+		uploadID  string
+		expiresAt int64
+	}
+	var expired []expiredUpload
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()[len(types.CodeUploadExpiryPrefix):]
+		expiresAt := int64(sdk.BigEndianToUint64(key[:8]))
+		sender, uploadID, err := types.ParseCodeUploadExpiryKey(key[8:])
+		if err != nil {
+			iter.Close()
+			return err
+		}
+		expired = append(expired, expiredUpload{sender: sender, uploadID: uploadID, expiresAt: expiresAt})
+	}
+	iter.Close()
+
+	for _, e := range expired {
+		k.deleteCodeUpload(ctx, e.sender, e.uploadID, e.expiresAt)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeCodeUploadExpired,
+			sdk.NewAttribute(sdk.AttributeKeySender, e.sender.String()),
+			sdk.NewAttribute(types.AttributeKeyUploadID, e.uploadID),
+		))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// addCodeUploadApproval stores or updates the governance approval to upload the code with the checksum
+func (k Keeper) addCodeUploadApproval(ctx context.Context, approval types.CodeUploadApproval) error {
+	if err := k.storeCodeUploadApproval(ctx, approval); err != nil {
+		return err
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeAddUploadApproval,
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(approval.Checksum)),
+		sdk.NewAttribute(types.AttributeKeyConsumeOnce, strconv.FormatBool(approval.ConsumeOnce)),
+	))
+	return nil
+}
+
+// removeCodeUploadApproval deletes the approval to upload the code with the checksum
+func (k Keeper) removeCodeUploadApproval(ctx context.Context, checksum []byte) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetCodeUploadApprovalKey(checksum)
+	ok, err := store.Has(key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return types.ErrNotFound.Wrapf("code upload approval %X", checksum)
+	}
+	if err := store.Delete(key); err != nil {
+		return err
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRemoveUploadApproval,
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(checksum)),
+	))
+	return nil
+}
+
+func (k Keeper) storeCodeUploadApproval(ctx context.Context, approval types.CodeUploadApproval) error {
+	bz, err := k.cdc.Marshal(&approval)
+	if err != nil {
+		return err
+	}
+	return k.storeService.OpenKVStore(ctx).Set(types.GetCodeUploadApprovalKey(approval.Checksum), bz)
+}
+
+// GetCodeUploadApproval returns the approval to upload the code with the checksum or nil when not approved
+func (k Keeper) GetCodeUploadApproval(ctx context.Context, checksum []byte) *types.CodeUploadApproval {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetCodeUploadApprovalKey(checksum))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return nil
+	}
+	var approval types.CodeUploadApproval
+	k.cdc.MustUnmarshal(bz, &approval)
+	return &approval
+}
+
+// IterateCodeUploadApprovals iterates over all code upload approvals ordered by checksum
+func (k Keeper) IterateCodeUploadApprovals(ctx context.Context, cb func(types.CodeUploadApproval) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.CodeUploadApprovalPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var approval types.CodeUploadApproval
+		k.cdc.MustUnmarshal(iter.Value(), &approval)
+		if cb(approval) {
+			return
+		}
+	}
+}
+
+// useCodeUploadApproval returns true when the uncompressed wasm code was approved for upload by governance.
+// An approval that can only be consumed once is removed.
+func (k Keeper) useCodeUploadApproval(ctx context.Context, wasmCode []byte) (bool, error) {
+	checksum := sha256.Sum256(wasmCode)
+	approval := k.GetCodeUploadApproval(ctx, checksum[:])
+	if approval == nil {
+		return false, nil
+	}
+	if approval.ConsumeOnce {
+		if err := k.removeCodeUploadApproval(ctx, checksum[:]); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCodeUploadApproval(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	params := types.DefaultParams()
+	params.CodeUploadAccess = types.AllowNobody
+	require.NoError(t, k.SetParams(parentCtx, params))
+
+	gzippedWasm, err := os.ReadFile("./testdata/hackatom.wasm.gzip")
+	require.NoError(t, err)
+	checksum := sha256.Sum256(hackatomWasm)
+	creator := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		approval    *types.CodeUploadApproval
+		code        []byte
+		expUploads  int
+		expApproval bool
+	}{
+		"not approved": {
+			code: hackatomWasm,
+		},
+		"other checksum approved": {
+			approval:    &types.CodeUploadApproval{Checksum: bytes.Repeat([]byte{1}, sha256.Size)},
+			code:        hackatomWasm,
+			expApproval: false,
+		},
+		"consume once": {
+			approval:   &types.CodeUploadApproval{Checksum: checksum[:], ConsumeOnce: true},
+			code:       hackatomWasm,
+			expUploads: 1,
+		},
+		"reusable": {
+			approval:    &types.CodeUploadApproval{Checksum: checksum[:]},
+			code:        hackatomWasm,
+			expUploads:  2,
+			expApproval: true,
+		},
+		"gzipped code": {
+			approval:    &types.CodeUploadApproval{Checksum: checksum[:]},
+			code:        gzippedWasm,
+			expUploads:  2,
+			expApproval: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.approval != nil {
+				require.NoError(t, k.addCodeUploadApproval(ctx, *spec.approval))
+			}
+
+			// when
+			var uploads int
+			for i := 0; i < 2; i++ {
+				_, gotChecksum, err := keepers.ContractKeeper.Create(ctx, creator, spec.code, nil)
+				if err != nil {
+					require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+					continue
+				}
+				assert.Equal(t, checksum[:], gotChecksum)
+				uploads++
+			}
+
+			// then
+			assert.Equal(t, spec.expUploads, uploads)
+			assert.Equal(t, spec.expApproval, k.GetCodeUploadApproval(ctx, checksum[:]) != nil)
+		})
+	}
+}
+
+func TestCodeUploadApprovalMsgs(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+	myChecksum, otherChecksum := bytes.Repeat([]byte{2}, sha256.Size), bytes.Repeat([]byte{1}, sha256.Size)
+
+	// not authority
+	_, err := msgServer.AddCodeUploadApproval(ctx, &types.MsgAddCodeUploadApproval{Authority: RandomBech32AccountAddress(t), Checksum: myChecksum})
+	require.ErrorIs(t, err, types.ErrInvalid)
+
+	// add
+	em := sdk.NewEventManager()
+	_, err = msgServer.AddCodeUploadApproval(ctx.WithEventManager(em), &types.MsgAddCodeUploadApproval{Authority: k.GetAuthority(), Checksum: myChecksum, ConsumeOnce: true})
+	require.NoError(t, err)
+	require.Len(t, em.Events(), 1)
+	assert.Equal(t, types.EventTypeAddUploadApproval, em.Events()[0].Type)
+	_, err = msgServer.AddCodeUploadApproval(ctx, &types.MsgAddCodeUploadApproval{Authority: k.GetAuthority(), Checksum: otherChecksum})
+	require.NoError(t, err)
+
+	// query ordered by checksum
+	q := Querier(k)
+	res, err := q.CodeUploadApprovals(ctx, &types.QueryCodeUploadApprovalsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []types.CodeUploadApproval{{Checksum: otherChecksum}, {Checksum: myChecksum, ConsumeOnce: true}}, res.Approvals)
+	res, err = q.CodeUploadApprovals(ctx, &types.QueryCodeUploadApprovalsRequest{Pagination: &query.PageRequest{Limit: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []types.CodeUploadApproval{{Checksum: otherChecksum}}, res.Approvals)
+	require.NotNil(t, res.Pagination.NextKey)
+
+	// remove
+	_, err = msgServer.RemoveCodeUploadApproval(ctx, &types.MsgRemoveCodeUploadApproval{Authority: RandomBech32AccountAddress(t), Checksum: myChecksum})
+	require.ErrorIs(t, err, types.ErrInvalid)
+	_, err = msgServer.RemoveCodeUploadApproval(ctx, &types.MsgRemoveCodeUploadApproval{Authority: k.GetAuthority(), Checksum: myChecksum})
+	require.NoError(t, err)
+	assert.Nil(t, k.GetCodeUploadApproval(ctx, myChecksum))
+	_, err = msgServer.RemoveCodeUploadApproval(ctx, &types.MsgRemoveCodeUploadApproval{Authority: k.GetAuthority(), Checksum: myChecksum})
+	require.ErrorIs(t, err, types.ErrNotFound)
+}
@@ -0,0 +1,223 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestChunkedCodeUpload(t *testing.T) {
+	wasmCode := testdata.HackatomContractWasm()
+	gzippedCode, err := ioutils.GzipIt(wasmCode)
+	require.NoError(t, err)
+	checksum := sha256.Sum256(wasmCode)
+
+	specs := map[string]struct {
+		code     []byte
+		order    []int
+		checksum []byte
+		expErr   error
+	}{
+		"in order": {
+			code:     wasmCode,
+			order:    []int{0, 1, 2},
+			checksum: checksum[:],
+		},
+		"out of order": {
+			code:     wasmCode,
+			order:    []int{2, 0, 1},
+			checksum: checksum[:],
+		},
+		"gzipped": {
+			code:     gzippedCode,
+			order:    []int{1, 2, 0},
+			checksum: checksum[:],
+		},
+		"checksum mismatch": {
+			code:     wasmCode,
+			order:    []int{0, 1, 2},
+			checksum: make([]byte, sha256.Size),
+			expErr:   types.ErrInvalid,
+		},
+		"incomplete": {
+			code:     wasmCode,
+			order:    []int{0, 2},
+			checksum: checksum[:],
+			expErr:   types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+			k := keepers.WasmKeeper
+			msgServer := NewMsgServerImpl(k)
+			creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+			chunks := splitInChunks(spec.code, 3)
+
+			// when
+			for _, i := range spec.order {
+				_, err := msgServer.StoreCodeChunk(ctx, &types.MsgStoreCodeChunk{
+					Sender:   creator.String(),
+					UploadID: "my-upload",
+					Index:    uint32(i),
+					Total:    uint32(len(chunks)),
+					Data:     chunks[i],
+				})
+				require.NoError(t, err)
+			}
+			upload := k.GetCodeUpload(ctx, creator, "my-upload")
+			require.NotNil(t, upload)
+			assert.Equal(t, uint32(len(spec.order)), upload.Received)
+
+			ctx, _ = ctx.CacheContext()
+			rsp, gotErr := msgServer.CompleteStoreCode(ctx, &types.MsgCompleteStoreCode{
+				Sender:   creator.String(),
+				UploadID: "my-upload",
+				Checksum: spec.checksum,
+			})
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, checksum[:], rsp.Checksum)
+			storedCode, err := k.GetByteCode(ctx, rsp.CodeID)
+			require.NoError(t, err)
+			assert.Equal(t, wasmCode, storedCode)
+			assert.Equal(t, creator.String(), k.GetCodeInfo(ctx, rsp.CodeID).Creator)
+			// and the upload is removed
+			assert.Nil(t, k.GetCodeUpload(ctx, creator, "my-upload"))
+			var count int
+			k.iterateCodeUploadChunks(ctx, creator, "my-upload", func([]byte) bool {
+				count++
+				return false
+			})
+			assert.Zero(t, count)
+		})
+	}
+}
+
+func TestStoreCodeChunkRejected(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	_, err := msgServer.StoreCodeChunk(ctx, &types.MsgStoreCodeChunk{
+		Sender: creator.String(), UploadID: "my-upload", Index: 0, Total: 2, Data: []byte{1},
+	})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src    types.MsgStoreCodeChunk
+		setup  func(ctx sdk.Context)
+		expErr error
+	}{
+		"duplicate chunk": {
+			src:    types.MsgStoreCodeChunk{Sender: creator.String(), UploadID: "my-upload", Index: 0, Total: 2, Data: []byte{2}},
+			expErr: types.ErrDuplicate,
+		},
+		"total mismatch": {
+			src:    types.MsgStoreCodeChunk{Sender: creator.String(), UploadID: "my-upload", Index: 1, Total: 3, Data: []byte{2}},
+			expErr: types.ErrInvalid,
+		},
+		"exceeds max wasm size": {
+			src:    types.MsgStoreCodeChunk{Sender: creator.String(), UploadID: "my-upload", Index: 1, Total: 2, Data: make([]byte, types.MaxWasmSize)},
+			expErr: types.ErrLimit,
+		},
+		"upload not permitted": {
+			src: types.MsgStoreCodeChunk{Sender: creator.String(), UploadID: "other-upload", Index: 0, Total: 1, Data: []byte{1}},
+			setup: func(ctx sdk.Context) {
+				params := types.DefaultParams()
+				params.CodeUploadAccess = types.AllowNobody
+				require.NoError(t, k.SetParams(ctx, params))
+			},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			_, gotErr := msgServer.StoreCodeChunk(ctx, &spec.src)
+			require.ErrorIs(t, gotErr, spec.expErr)
+			upload := k.GetCodeUpload(ctx, creator, "my-upload")
+			assert.Equal(t, uint32(1), upload.Received)
+			assert.Equal(t, uint64(1), upload.ReceivedBytes)
+		})
+	}
+}
+
+func TestPruneExpiredCodeUploads(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	ctx = ctx.WithBlockHeight(100)
+
+	storeChunk := func(ctx sdk.Context, uploadID string, index uint32) {
+		_, err := msgServer.StoreCodeChunk(ctx, &types.MsgStoreCodeChunk{
+			Sender: creator.String(), UploadID: uploadID, Index: index, Total: 2, Data: []byte{1},
+		})
+		require.NoError(t, err)
+	}
+	storeChunk(ctx, "first", 0)
+	storeChunk(ctx.WithBlockHeight(101), "second", 0)
+	// later chunks do not extend the TTL
+	storeChunk(ctx.WithBlockHeight(150), "first", 1)
+
+	// when not expired
+	require.NoError(t, k.PruneExpiredCodeUploads(ctx.WithBlockHeight(100+DefaultCodeUploadTTL-1)))
+	// then
+	assert.NotNil(t, k.GetCodeUpload(ctx, creator, "first"))
+	assert.NotNil(t, k.GetCodeUpload(ctx, creator, "second"))
+
+	// when first expired
+	em := sdk.NewEventManager()
+	require.NoError(t, k.PruneExpiredCodeUploads(ctx.WithBlockHeight(100+DefaultCodeUploadTTL).WithEventManager(em)))
+	// then
+	assert.Nil(t, k.GetCodeUpload(ctx, creator, "first"))
+	assert.NotNil(t, k.GetCodeUpload(ctx, creator, "second"))
+	k.iterateCodeUploadChunks(ctx, creator, "first", func([]byte) bool {
+		t.Fatal("chunks not pruned")
+		return true
+	})
+	assert.Equal(t, sdk.Events{sdk.NewEvent(types.EventTypeCodeUploadExpired,
+		sdk.NewAttribute(sdk.AttributeKeySender, creator.String()),
+		sdk.NewAttribute(types.AttributeKeyUploadID, "first"),
+	)}, em.Events())
+	_, err := msgServer.CompleteStoreCode(ctx, &types.MsgCompleteStoreCode{
+		Sender: creator.String(), UploadID: "first", Checksum: make([]byte, sha256.Size),
+	})
+	require.ErrorIs(t, err, types.ErrNotFound)
+
+	// and the upload id can be reused
+	storeChunk(ctx.WithBlockHeight(100+DefaultCodeUploadTTL), "first", 0)
+
+	// when all expired
+	require.NoError(t, k.PruneExpiredCodeUploads(ctx.WithBlockHeight(100+2*DefaultCodeUploadTTL)))
+	// then
+	assert.Nil(t, k.GetCodeUpload(ctx, creator, "first"))
+	assert.Nil(t, k.GetCodeUpload(ctx, creator, "second"))
+}
+
+func splitInChunks(bz []byte, n int) [][]byte {
+	size := (len(bz) + n - 1) / n
+	chunks := make([][]byte, 0, n)
+	for i := 0; i < len(bz); i += size {
+		chunks = append(chunks, bz[i:min(i+size, len(bz))])
+	}
+	return chunks
+}
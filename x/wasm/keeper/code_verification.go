@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// voteCodeVerification stores the verdict of a code verifier on the build reproducibility of the code.
+// An existing vote of the same verifier is replaced.
+func (k Keeper) voteCodeVerification(ctx context.Context, codeID uint64, voter sdk.AccAddress, verdict types.CodeVerificationVerdict) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if k.GetCodeInfo(ctx, codeID) == nil {
+		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	if !k.GetParams(ctx).IsCodeVerifier(voter) {
+		return errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "%s is not a code verifier", voter)
+	}
+
+	vote := types.CodeVerificationVote{
+		Voter:   voter.String(),
+		Verdict: verdict,
+		Height:  sdkCtx.BlockHeight(),
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	if err := store.Set(types.GetCodeVerificationVoteKey(codeID, voter), k.cdc.MustMarshal(&vote)); err != nil {
+		return err
+	}
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeVoteCodeVerification,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyVoter, vote.Voter),
+		sdk.NewAttribute(types.AttributeKeyVerdict, verdict.String()),
+		sdk.NewAttribute(types.AttributeKeyVerificationStatus, k.GetCodeVerificationStatus(ctx, codeID).String()),
+	))
+	return nil
+}
+
+// IterateCodeVerificationVotes iterates over all verification votes of the code ordered by voter address
+func (k Keeper) IterateCodeVerificationVotes(ctx context.Context, codeID uint64, cb func(types.CodeVerificationVote) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetCodeVerificationVotePrefix(codeID))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var v types.CodeVerificationVote
+		k.cdc.MustUnmarshal(iter.Value(), &v)
+		if cb(v) {
+			return
+		}
+	}
+}
+
+// GetCodeVerificationTally returns the number of reproducible and not reproducible votes of the code
+func (k Keeper) GetCodeVerificationTally(ctx context.Context, codeID uint64) (reproducible, notReproducible uint32) {
+	k.IterateCodeVerificationVotes(ctx, codeID, func(v types.CodeVerificationVote) bool {
+		switch v.Verdict {
+		case types.CodeVerificationVerdictReproducible:
+			reproducible++
+		case types.CodeVerificationVerdictNotReproducible:
+			notReproducible++
+		}
+		return false
+	})
+	return
+}
+
+// GetCodeVerificationStatus returns the verification status of the code derived from the votes
+// of the code verifiers and the threshold in the params.
+func (k Keeper) GetCodeVerificationStatus(ctx context.Context, codeID uint64) types.CodeVerificationStatus {
+	return k.GetParams(ctx).CodeVerificationStatusFor(k.GetCodeVerificationTally(ctx, codeID))
+}
+
+// pruneCodeVerificationVotes deletes all votes of addresses that are not code verifiers in the given params
+func (k Keeper) pruneCodeVerificationVotes(ctx context.Context, params types.Params) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.CodeVerificationVotePrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	var stale [][]byte
+	for ; iter.Valid(); iter.Next() {
+		var v types.CodeVerificationVote
+		k.cdc.MustUnmarshal(iter.Value(), &v)
+		voter, err := sdk.AccAddressFromBech32(v.Voter)
+		if err != nil || !params.IsCodeVerifier(voter) {
+			stale = append(stale, iter.Key())
+		}
+	}
+	iter.Close()
+
+	for _, key := range stale {
+		prefixStore.Delete(key)
+	}
+}
+
+func (k Keeper) importCodeVerificationVotes(ctx context.Context, codeID uint64, votes []types.CodeVerificationVote) error {
+	store := k.storeService.OpenKVStore(ctx)
+	for _, v := range votes {
+		voter, err := sdk.AccAddressFromBech32(v.Voter)
+		if err != nil {
+			return errorsmod.Wrap(err, "voter")
+		}
+		key := types.GetCodeVerificationVoteKey(codeID, voter)
+		ok, err := store.Has(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errorsmod.Wrapf(types.ErrDuplicate, "verification vote by %s", v.Voter)
+		}
+		if err := store.Set(key, k.cdc.MustMarshal(&v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestVoteCodeVerification(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	parentCtx = parentCtx.WithBlockHeight(7)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, parentCtx, keepers)
+	msgServer := NewMsgServerImpl(k)
+
+	verifiers := []sdk.AccAddress{RandomAccountAddress(t), RandomAccountAddress(t), RandomAccountAddress(t)}
+	params := types.DefaultParams()
+	for _, v := range verifiers {
+		params.CodeVerifiers = append(params.CodeVerifiers, v.String())
+	}
+	params.CodeVerificationThreshold = 2
+	require.NoError(t, k.SetParams(parentCtx, params))
+
+	myVerifier := verifiers[0]
+	specs := map[string]struct {
+		setup     func(ctx sdk.Context)
+		src       types.MsgVoteCodeVerification
+		expErr    error
+		expStatus types.CodeVerificationStatus
+	}{
+		"first vote below threshold": {
+			src:       types.MsgVoteCodeVerification{Sender: myVerifier.String(), CodeID: example.CodeID, Verdict: types.CodeVerificationVerdictReproducible},
+			expStatus: types.CodeVerificationStatusUnverified,
+		},
+		"threshold reached": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, verifiers[1], types.CodeVerificationVerdictReproducible))
+			},
+			src:       types.MsgVoteCodeVerification{Sender: myVerifier.String(), CodeID: example.CodeID, Verdict: types.CodeVerificationVerdictReproducible},
+			expStatus: types.CodeVerificationStatusVerified,
+		},
+		"threshold reached with disagreement": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, verifiers[1], types.CodeVerificationVerdictReproducible))
+				require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, verifiers[2], types.CodeVerificationVerdictReproducible))
+			},
+			src:       types.MsgVoteCodeVerification{Sender: myVerifier.String(), CodeID: example.CodeID, Verdict: types.CodeVerificationVerdictNotReproducible},
+			expStatus: types.CodeVerificationStatusContested,
+		},
+		"vote replaced": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, myVerifier, types.CodeVerificationVerdictNotReproducible))
+				require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, verifiers[1], types.CodeVerificationVerdictReproducible))
+			},
+			src:       types.MsgVoteCodeVerification{Sender: myVerifier.String(), CodeID: example.CodeID, Verdict: types.CodeVerificationVerdictReproducible},
+			expStatus: types.CodeVerificationStatusVerified,
+		},
+		"not a code verifier": {
+			src:    types.MsgVoteCodeVerification{Sender: RandomBech32AccountAddress(t), CodeID: example.CodeID, Verdict: types.CodeVerificationVerdictReproducible},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"unknown code": {
+			src:    types.MsgVoteCodeVerification{Sender: myVerifier.String(), CodeID: 99, Verdict: types.CodeVerificationVerdictReproducible},
+			expErr: types.ErrNoSuchCodeFn(99),
+		},
+		"unspecified verdict": {
+			src:    types.MsgVoteCodeVerification{Sender: myVerifier.String(), CodeID: example.CodeID},
+			expErr: types.ErrEmpty,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			_, gotErr := msgServer.VoteCodeVerification(ctx.WithEventManager(em), &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expStatus, k.GetCodeVerificationStatus(ctx, example.CodeID))
+			var got *types.CodeVerificationVote
+			k.IterateCodeVerificationVotes(ctx, example.CodeID, func(v types.CodeVerificationVote) bool {
+				if v.Voter == myVerifier.String() {
+					got = &v
+				}
+				return false
+			})
+			require.NotNil(t, got)
+			assert.Equal(t, types.CodeVerificationVote{Voter: myVerifier.String(), Verdict: spec.src.Verdict, Height: 7}, *got)
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeVoteCodeVerification, em.Events()[0].Type)
+			status, ok := em.Events()[0].GetAttribute(types.AttributeKeyVerificationStatus)
+			require.True(t, ok)
+			assert.Equal(t, spec.expStatus.String(), status.Value)
+		})
+	}
+}
+
+func TestCodeVerificationVerifierSetChange(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+
+	myVerifier, otherVerifier := RandomAccountAddress(t), RandomAccountAddress(t)
+	params := types.DefaultParams()
+	params.CodeVerifiers = []string{myVerifier.String(), otherVerifier.String()}
+	params.CodeVerificationThreshold = 2
+	require.NoError(t, k.updateParams(ctx, k.GetAuthority(), params))
+	require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, myVerifier, types.CodeVerificationVerdictReproducible))
+	require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, otherVerifier, types.CodeVerificationVerdictReproducible))
+	require.Equal(t, types.CodeVerificationStatusVerified, k.GetCodeVerificationStatus(ctx, example.CodeID))
+
+	// when the other verifier is removed from the set
+	params.CodeVerifiers = []string{myVerifier.String()}
+	params.CodeVerificationThreshold = 1
+	require.NoError(t, k.updateParams(ctx, k.GetAuthority(), params))
+
+	// then its vote is dropped
+	reproducible, notReproducible := k.GetCodeVerificationTally(ctx, example.CodeID)
+	assert.Equal(t, uint32(1), reproducible)
+	assert.Equal(t, uint32(0), notReproducible)
+	assert.Equal(t, types.CodeVerificationStatusVerified, k.GetCodeVerificationStatus(ctx, example.CodeID))
+
+	// and when added again it has to vote again
+	params.CodeVerifiers = []string{myVerifier.String(), otherVerifier.String()}
+	params.CodeVerificationThreshold = 2
+	require.NoError(t, k.updateParams(ctx, k.GetAuthority(), params))
+	assert.Equal(t, types.CodeVerificationStatusUnverified, k.GetCodeVerificationStatus(ctx, example.CodeID))
+}
+
+func TestQueryCodeVerification(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+
+	myVerifier, otherVerifier := RandomAccountAddress(t), RandomAccountAddress(t)
+	params := types.DefaultParams()
+	params.CodeVerifiers = []string{myVerifier.String(), otherVerifier.String()}
+	params.CodeVerificationThreshold = 1
+	require.NoError(t, k.SetParams(ctx, params))
+	require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, myVerifier, types.CodeVerificationVerdictReproducible))
+	require.NoError(t, k.voteCodeVerification(ctx, example.CodeID, otherVerifier, types.CodeVerificationVerdictNotReproducible))
+	q := Querier(k)
+
+	// when
+	rsp, err := q.CodeVerification(ctx, &types.QueryCodeVerificationRequest{CodeID: example.CodeID})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, types.CodeVerificationStatusContested, rsp.Status)
+	assert.Equal(t, uint32(1), rsp.ReproducibleVotes)
+	assert.Equal(t, uint32(1), rsp.NotReproducibleVotes)
+	assert.Len(t, rsp.Votes, 2)
+
+	// when code info queried
+	infoRsp, err := q.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: example.CodeID})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, types.CodeVerificationStatusContested, infoRsp.VerificationStatus)
+
+	// when unknown code
+	_, err = q.CodeVerification(ctx, &types.QueryCodeVerificationRequest{CodeID: example.CodeID + 1})
+	// then
+	require.ErrorIs(t, err, types.ErrNoSuchCodeFn(example.CodeID+1))
+
+	// when empty code id
+	_, err = q.CodeVerification(ctx, &types.QueryCodeVerificationRequest{})
+	// then
+	require.ErrorIs(t, err, types.ErrInvalid)
+}
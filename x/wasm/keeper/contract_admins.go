@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// addToContractAdminIndex adds element to the index for contracts-by-admin queries.
+// Contracts without an admin are not indexed.
+func (k Keeper) addToContractAdminIndex(ctx context.Context, admin, contractAddress sdk.AccAddress) error {
+	if len(admin) == 0 {
+		return nil
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Set(types.GetContractByAdminKey(admin, contractAddress), []byte{})
+}
+
+// removeFromContractAdminIndex removes element from the index for contracts-by-admin queries
+func (k Keeper) removeFromContractAdminIndex(ctx context.Context, admin, contractAddress sdk.AccAddress) error {
+	if len(admin) == 0 {
+		return nil
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Delete(types.GetContractByAdminKey(admin, contractAddress))
+}
+
+// IterateContractsByAdmin iterates over all contracts of the admin ordered by contract address
+func (k Keeper) IterateContractsByAdmin(ctx context.Context, admin sdk.AccAddress, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractsByAdminPrefix(admin))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			return
+		}
+	}
+}
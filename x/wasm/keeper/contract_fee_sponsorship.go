@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// setContractFeeSponsorship sets the fee sponsorship budgets of the contract. A nil sponsorship removes them.
+func (k Keeper) setContractFeeSponsorship(ctx context.Context, contractAddress, caller sdk.AccAddress, sponsorship *types.FeeSponsorship, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	attrs := []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String())}
+	if sponsorship != nil {
+		if err := sponsorship.ValidateBasic(); err != nil {
+			return err
+		}
+		attrs = append(attrs,
+			sdk.NewAttribute(types.AttributeKeyMaxFeePerTx, sponsorship.MaxFeePerTx.String()),
+			sdk.NewAttribute(types.AttributeKeyMaxFeePerBlock, sponsorship.MaxFeePerBlock.String()),
+		)
+	}
+	contractInfo.FeeSponsorship = sponsorship
+	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeSetFeeSponsorship, attrs...))
+	return nil
+}
+
+// GetFeeSponsorshipUsedInBlock returns the total of fees that the contract paid in the current block
+func (k Keeper) GetFeeSponsorshipUsedInBlock(ctx context.Context, contractAddress sdk.AccAddress) sdk.Coins {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetFeeSponsorshipUsageKey(contractAddress))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+	var usage types.FeeSponsorshipUsage
+	k.cdc.MustUnmarshal(bz, &usage)
+	if usage.Height != sdk.UnwrapSDKContext(ctx).BlockHeight() {
+		return sdk.NewCoins()
+	}
+	return usage.Used
+}
+
+// useFeeSponsorship adds the fee to the total that the contract paid in the current block.
+// Returns false without changes when the contract does not pay fees, is suspended or inactive, or the fee
+// exceeds a budget.
+func (k Keeper) useFeeSponsorship(ctx context.Context, contractAddress sdk.AccAddress, fee sdk.Coins) (bool, error) {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil || contractInfo.FeeSponsorship == nil || contractInfo.Suspended || contractInfo.Inactive {
+		return false, nil
+	}
+	budget := contractInfo.FeeSponsorship
+	if !fee.IsAllLTE(budget.MaxFeePerTx) {
+		return false, nil
+	}
+	used := k.GetFeeSponsorshipUsedInBlock(ctx, contractAddress).Add(fee...)
+	if !used.IsAllLTE(budget.MaxFeePerBlock) {
+		return false, nil
+	}
+	usage := types.FeeSponsorshipUsage{Height: sdk.UnwrapSDKContext(ctx).BlockHeight(), Used: used}
+	if err := k.storeService.OpenKVStore(ctx).Set(types.GetFeeSponsorshipUsageKey(contractAddress), k.cdc.MustMarshal(&usage)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
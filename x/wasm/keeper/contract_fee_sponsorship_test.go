@@ -0,0 +1,170 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSetContractFeeSponsorship(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	mySponsorship := &types.FeeSponsorship{
+		MaxFeePerTx:    sdk.NewCoins(sdk.NewInt64Coin("denom", 10)),
+		MaxFeePerBlock: sdk.NewCoins(sdk.NewInt64Coin("denom", 100)),
+	}
+
+	specs := map[string]struct {
+		setup          func(ctx sdk.Context)
+		src            types.MsgSetContractFeeSponsorship
+		expErr         error
+		expSponsorship *types.FeeSponsorship
+	}{
+		"set by admin": {
+			src:            types.MsgSetContractFeeSponsorship{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Sponsorship: mySponsorship},
+			expSponsorship: mySponsorship,
+		},
+		"clear": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.setContractFeeSponsorship(ctx, example.Contract, example.CreatorAddr, mySponsorship, DefaultAuthorizationPolicy{}))
+			},
+			src: types.MsgSetContractFeeSponsorship{Sender: example.CreatorAddr.String(), Contract: example.Contract.String()},
+		},
+		"invalid sponsorship": {
+			src:    types.MsgSetContractFeeSponsorship{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Sponsorship: &types.FeeSponsorship{MaxFeePerTx: mySponsorship.MaxFeePerTx}},
+			expErr: types.ErrEmpty,
+		},
+		"not admin": {
+			src:    types.MsgSetContractFeeSponsorship{Sender: RandomBech32AccountAddress(t), Contract: example.Contract.String(), Sponsorship: mySponsorship},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			src:    types.MsgSetContractFeeSponsorship{Sender: example.CreatorAddr.String(), Contract: RandomBech32AccountAddress(t), Sponsorship: mySponsorship},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			_, gotErr := msgServer.SetContractFeeSponsorship(ctx.WithEventManager(em), &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expSponsorship, k.GetContractInfo(ctx, example.Contract).FeeSponsorship)
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeSetFeeSponsorship, em.Events()[0].Type)
+		})
+	}
+}
+
+func TestUseFeeSponsorship(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	other := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, k.setContractFeeSponsorship(ctx, example.Contract, example.CreatorAddr, &types.FeeSponsorship{
+		MaxFeePerTx:    sdk.NewCoins(sdk.NewInt64Coin("denom", 10)),
+		MaxFeePerBlock: sdk.NewCoins(sdk.NewInt64Coin("denom", 15)),
+	}, DefaultAuthorizationPolicy{}))
+	fee := func(amount int64) sdk.Coins {
+		return sdk.NewCoins(sdk.NewInt64Coin("denom", amount))
+	}
+
+	ok, err := k.useFeeSponsorship(ctx, other.Contract, fee(1))
+	require.NoError(t, err)
+	assert.False(t, ok, "not opted in")
+	ok, err = k.useFeeSponsorship(ctx, example.Contract, fee(11))
+	require.NoError(t, err)
+	assert.False(t, ok, "exceeds tx budget")
+	ok, err = k.useFeeSponsorship(ctx, example.Contract, fee(10))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = k.useFeeSponsorship(ctx, example.Contract, fee(6))
+	require.NoError(t, err)
+	assert.False(t, ok, "exceeds block budget")
+	ok, err = k.useFeeSponsorship(ctx, example.Contract, fee(5))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, fee(15), k.GetFeeSponsorshipUsedInBlock(ctx, example.Contract))
+
+	// and the next block starts with a new budget
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	assert.Equal(t, sdk.NewCoins(), k.GetFeeSponsorshipUsedInBlock(ctx, example.Contract))
+	ok, err = k.useFeeSponsorship(ctx, example.Contract, fee(10))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// and suspended contracts do not pay fees
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	require.NoError(t, k.suspendContract(ctx, example.Contract, example.CreatorAddr, DefaultAuthorizationPolicy{}))
+	ok, err = k.useFeeSponsorship(ctx, example.Contract, fee(1))
+	require.NoError(t, err)
+	assert.False(t, ok, "suspended")
+	require.NoError(t, k.resumeContract(ctx, example.Contract, example.CreatorAddr, DefaultAuthorizationPolicy{}))
+
+	// and inactive contracts do not pay fees
+	require.NoError(t, k.scheduleContractSunset(ctx, example.Contract, example.CreatorAddr, ctx.BlockHeight()+1, DefaultAuthorizationPolicy{}))
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	require.NoError(t, k.DeactivateSunsetContracts(ctx))
+	ok, err = k.useFeeSponsorship(ctx, example.Contract, fee(1))
+	require.NoError(t, err)
+	assert.False(t, ok, "inactive")
+	assert.Equal(t, sdk.NewCoins(), k.GetFeeSponsorshipUsedInBlock(ctx, example.Contract))
+}
+
+func TestQueryContractFeeSponsorship(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	mySponsorship := &types.FeeSponsorship{
+		MaxFeePerTx:    sdk.NewCoins(sdk.NewInt64Coin("denom", 10)),
+		MaxFeePerBlock: sdk.NewCoins(sdk.NewInt64Coin("denom", 100)),
+	}
+	q := Querier(k)
+
+	// without sponsorship
+	rsp, err := q.ContractFeeSponsorship(ctx, &types.QueryContractFeeSponsorshipRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.Nil(t, rsp.Sponsorship)
+	assert.Empty(t, rsp.UsedInBlock)
+
+	// with sponsorship
+	require.NoError(t, k.setContractFeeSponsorship(ctx, example.Contract, example.CreatorAddr, mySponsorship, DefaultAuthorizationPolicy{}))
+	ok, err := k.useFeeSponsorship(ctx, example.Contract, sdk.NewCoins(sdk.NewInt64Coin("denom", 7)))
+	require.NoError(t, err)
+	require.True(t, ok)
+	rsp, err = q.ContractFeeSponsorship(ctx, &types.QueryContractFeeSponsorshipRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.Equal(t, mySponsorship, rsp.Sponsorship)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("denom", 7)), rsp.UsedInBlock)
+
+	// unknown contract
+	randomAddr := RandomBech32AccountAddress(t)
+	_, err = q.ContractFeeSponsorship(ctx, &types.QueryContractFeeSponsorshipRequest{Address: randomAddr})
+	assert.EqualError(t, err, types.ErrNoSuchContractFn(randomAddr).Wrapf("address %s", randomAddr).Error())
+}
@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// importedContractHistoryMsg is the msg of the history entry of an imported contract
+var importedContractHistoryMsg = types.RawContractMessage(`{}`)
+
+// importContractAt creates the contract with the state at the explicit address without calling the contract.
+// The address must not be used by another contract or be claimed by an external account. A history entry of the
+// import operation is recorded.
+func (k Keeper) importContractAt(
+	ctx context.Context,
+	contractAddr sdk.AccAddress,
+	codeID uint64,
+	creator, admin sdk.AccAddress,
+	label string,
+	state []types.Model,
+) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil {
+		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	if k.HasContractInfo(ctx, contractAddr) {
+		return types.ErrDuplicate.Wrap("contract address already exists")
+	}
+	if err := k.checkUniqueLabel(ctx, creator, label, nil); err != nil {
+		return err
+	}
+	if err := k.claimContractAccount(sdkCtx, contractAddr); err != nil {
+		return err
+	}
+
+	createdAt := types.NewAbsoluteTxPosition(sdkCtx)
+	contractInfo := types.NewContractInfo(codeID, creator, admin, label, createdAt)
+	report, err := k.wasmVM.AnalyzeCode(codeInfo.CodeHash)
+	if err != nil {
+		return errorsmod.Wrap(types.ErrVMError, err.Error())
+	}
+	if report.HasIBCEntryPoints {
+		contractInfo.IBCPortID = types.PortIDForContract(contractAddr)
+	}
+	historyEntry := types.ContractCodeHistoryEntry{
+		Operation: types.ContractCodeHistoryOperationTypeImport,
+		CodeID:    codeID,
+		Updated:   createdAt,
+		Msg:       importedContractHistoryMsg,
+	}
+	if err := k.importContract(ctx, contractAddr, &contractInfo, state, []types.ContractCodeHistoryEntry{historyEntry}); err != nil {
+		return err
+	}
+
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeImportContract,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+	))
+	return nil
+}
@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestImportContract(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	// contract returns the value stored for the key in the execute msg
+	mock.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Data: store.Get(executeMsg)}}, 1, nil
+	}
+	existing := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	myAddr, myCreator, myAdmin := RandomAccountAddress(t), RandomAccountAddress(t), RandomAccountAddress(t)
+	myState := []types.Model{{Key: []byte(`"a"`), Value: []byte("1")}, {Key: []byte(`"b"`), Value: []byte("2")}}
+	validMsg := func(mutators ...func(*types.MsgImportContract)) types.MsgImportContract {
+		msg := types.MsgImportContract{
+			Authority: k.GetAuthority(),
+			Address:   myAddr.String(),
+			CodeID:    existing.CodeID,
+			Creator:   myCreator.String(),
+			Admin:     myAdmin.String(),
+			Label:     "imported",
+			State:     myState,
+		}
+		for _, m := range mutators {
+			m(&msg)
+		}
+		return msg
+	}
+	specs := map[string]struct {
+		setup     func(ctx sdk.Context)
+		src       types.MsgImportContract
+		expErr    error
+		expCodeID uint64
+	}{
+		"with existing code": {
+			src:       validMsg(),
+			expCodeID: existing.CodeID,
+		},
+		"with embedded wasm": {
+			src: validMsg(func(m *types.MsgImportContract) {
+				m.CodeID = 0
+				m.WASMByteCode = append(bytes.Clone(wasmIdent), []byte("other")...)
+			}),
+			expCodeID: existing.CodeID + 1,
+		},
+		"with empty account": {
+			setup: func(ctx sdk.Context) {
+				keepers.AccountKeeper.SetAccount(ctx, keepers.AccountKeeper.NewAccountWithAddress(ctx, myAddr))
+			},
+			src:       validMsg(),
+			expCodeID: existing.CodeID,
+		},
+		"address collision with contract": {
+			src: validMsg(func(m *types.MsgImportContract) {
+				m.Address = existing.Contract.String()
+			}),
+			expErr: types.ErrDuplicate,
+		},
+		"address claimed by external account": {
+			setup: func(ctx sdk.Context) {
+				acc := keepers.AccountKeeper.NewAccountWithAddress(ctx, myAddr)
+				require.NoError(t, acc.SetSequence(1))
+				keepers.AccountKeeper.SetAccount(ctx, acc)
+			},
+			src:    validMsg(),
+			expErr: types.ErrAccountExists,
+		},
+		"state exceeds limit": {
+			src: validMsg(func(m *types.MsgImportContract) {
+				m.State = []types.Model{{Key: []byte("a"), Value: bytes.Repeat([]byte{0x1}, types.MaxImportContractStateSize)}}
+			}),
+			expErr: types.ErrLimit,
+		},
+		"duplicate state key": {
+			src: validMsg(func(m *types.MsgImportContract) {
+				m.State = append(m.State, m.State[0])
+			}),
+			expErr: types.ErrDuplicate,
+		},
+		"unknown code": {
+			src: validMsg(func(m *types.MsgImportContract) {
+				m.CodeID = 99
+			}),
+			expErr: types.ErrNoSuchCodeFn(99),
+		},
+		"unauthorized": {
+			src: validMsg(func(m *types.MsgImportContract) {
+				m.Authority = RandomBech32AccountAddress(t)
+			}),
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			rsp, gotErr := msgServer.ImportContract(ctx.WithEventManager(em), &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.Nil(t, k.GetContractInfo(ctx, myAddr))
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCodeID, rsp.CodeID)
+
+			info := k.GetContractInfo(ctx, myAddr)
+			require.NotNil(t, info)
+			assert.Equal(t, spec.expCodeID, info.CodeID)
+			assert.Equal(t, myCreator.String(), info.Creator)
+			assert.Equal(t, myAdmin.String(), info.Admin)
+			assert.Equal(t, "imported", info.Label)
+			history := k.GetContractHistory(ctx, myAddr)
+			require.Len(t, history, 1)
+			assert.Equal(t, types.ContractCodeHistoryOperationTypeImport, history[0].Operation)
+			assert.Contains(t, eventTypes(em.Events()), types.EventTypeImportContract)
+
+			// and the imported state is used on execution
+			data, err := keepers.ContractKeeper.Execute(ctx, myAddr, myCreator, []byte(`"b"`), nil)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("2"), data)
+		})
+	}
+}
+
+func eventTypes(events sdk.Events) []string {
+	r := make([]string, len(events))
+	for i, e := range events {
+		r[i] = e.Type
+	}
+	return r
+}
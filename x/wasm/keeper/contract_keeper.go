@@ -12,7 +12,7 @@ var _ types.ContractOpsKeeper = PermissionedKeeper{}
 
 // decoratedKeeper contains a subset of the wasm keeper that are already or can be guarded by an authorization policy in the future
 type decoratedKeeper interface {
-	create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy) (codeID uint64, checksum []byte, err error)
+	create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, origin types.CodeOrigin, authZ types.AuthorizationPolicy) (codeID uint64, checksum []byte, err error)
 
 	instantiate(
 		ctx context.Context,
@@ -31,6 +31,7 @@ type decoratedKeeper interface {
 	unpinCode(ctx context.Context, codeID uint64) error
 	execute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
 	Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
+	GetContractInfo(ctx context.Context, contractAddress sdk.AccAddress) *types.ContractInfo
 	setContractInfoExtension(ctx context.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error
 	setAccessConfig(ctx context.Context, codeID uint64, caller sdk.AccAddress, newConfig types.AccessConfig, autz types.AuthorizationPolicy) error
 	ClassicAddressGenerator() AddressGenerator
@@ -54,7 +55,11 @@ func NewDefaultPermissionKeeper(nested decoratedKeeper) *PermissionedKeeper {
 }
 
 func (p PermissionedKeeper) Create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig) (codeID uint64, checksum []byte, err error) {
-	return p.nested.create(ctx, creator, wasmCode, instantiateAccess, p.authZPolicy)
+	origin := types.CodeOriginDirect
+	if _, ok := p.authZPolicy.(GovAuthorizationPolicy); ok {
+		origin = types.CodeOriginGovernance
+	}
+	return p.nested.create(ctx, creator, wasmCode, instantiateAccess, origin, p.authZPolicy)
 }
 
 // Instantiate creates an instance of a WASM contract using the classic sequence based address generator
@@ -101,7 +106,13 @@ func (p PermissionedKeeper) Migrate(ctx sdk.Context, contractAddress, caller sdk
 	return p.nested.migrate(ctx, contractAddress, caller, newCodeID, msg, p.authZPolicy)
 }
 
+// Sudo calls the contract's sudo entry point. Suspended contracts can only be called with the gov policy.
 func (p PermissionedKeeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
+	if _, ok := p.authZPolicy.(GovAuthorizationPolicy); !ok {
+		if info := p.nested.GetContractInfo(ctx, contractAddress); info != nil && info.Suspended {
+			return nil, types.ErrContractSuspended.Wrapf("contract %s", contractAddress)
+		}
+	}
 	return p.nested.Sudo(ctx, contractAddress, msg)
 }
 
@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// addToContractLabelIndex adds element to the index for contracts-by-label and contracts-by-creator-and-label lookups
+func (k Keeper) addToContractLabelIndex(ctx context.Context, creatorAddress sdk.AccAddress, label string, contractAddress sdk.AccAddress) error {
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Set(types.GetContractByLabelKey(label, creatorAddress, contractAddress), []byte{})
+}
+
+// removeFromContractLabelIndex removes element from the index for contracts-by-label and contracts-by-creator-and-label lookups
+func (k Keeper) removeFromContractLabelIndex(ctx context.Context, creatorAddress sdk.AccAddress, label string, contractAddress sdk.AccAddress) error {
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Delete(types.GetContractByLabelKey(label, creatorAddress, contractAddress))
+}
+
+// IterateContractsByCreatorLabel iterates over all contracts of the creator with the label
+func (k Keeper) IterateContractsByCreatorLabel(ctx context.Context, creator sdk.AccAddress, label string, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractsByCreatorLabelPrefix(creator, label))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			return
+		}
+	}
+}
+
+// checkUniqueLabel returns an error when unique labels per creator are enforced and another contract
+// of the creator has the label already
+func (k Keeper) checkUniqueLabel(ctx context.Context, creator sdk.AccAddress, label string, contractAddress sdk.AccAddress) error {
+	if !k.GetParams(ctx).EnforceUniqueLabelsPerCreator {
+		return nil
+	}
+	var existing sdk.AccAddress
+	k.IterateContractsByCreatorLabel(ctx, creator, label, func(addr sdk.AccAddress) bool {
+		if addr.Equals(contractAddress) {
+			return false
+		}
+		existing = addr
+		return true
+	})
+	if existing != nil {
+		return errorsmod.Wrapf(types.ErrDuplicate, "label %q is used by contract %s of the same creator", label, existing)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestInstantiateWithUniqueLabels(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	example := StoreRandomContract(t, parentCtx, keepers, &mock)
+	creator, otherCreator := RandomAccountAddress(t), RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		enforce bool
+		creator sdk.AccAddress
+		label   string
+		expErr  bool
+	}{
+		"same creator and label": {
+			enforce: true,
+			creator: creator,
+			label:   "my label",
+			expErr:  true,
+		},
+		"same creator and other label": {
+			enforce: true,
+			creator: creator,
+			label:   "other label",
+		},
+		"other creator and same label": {
+			enforce: true,
+			creator: otherCreator,
+			label:   "my label",
+		},
+		"same creator and label - not enforced": {
+			creator: creator,
+			label:   "my label",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			k := keepers.WasmKeeper
+			params := k.GetParams(ctx)
+			params.EnforceUniqueLabelsPerCreator = spec.enforce
+			require.NoError(t, k.SetParams(ctx, params))
+			_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, []byte(`{}`), "my label", nil)
+			require.NoError(t, err)
+
+			// when
+			_, _, gotErr := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, spec.creator, nil, []byte(`{}`), spec.label, nil)
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, gotErr, types.ErrDuplicate)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestUpdateContractLabelWithUniqueLabels(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	example := StoreRandomContract(t, parentCtx, keepers, &mock)
+	creator := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		enforce  bool
+		newLabel string
+		expErr   bool
+	}{
+		"label of other contract": {
+			enforce:  true,
+			newLabel: "first",
+			expErr:   true,
+		},
+		"unchanged label": {
+			enforce:  true,
+			newLabel: "second",
+		},
+		"free label": {
+			enforce:  true,
+			newLabel: "third",
+		},
+		"label of other contract - not enforced": {
+			newLabel: "first",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			k := keepers.WasmKeeper
+			params := k.GetParams(ctx)
+			params.EnforceUniqueLabelsPerCreator = spec.enforce
+			require.NoError(t, k.SetParams(ctx, params))
+			_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, creator, []byte(`{}`), "first", nil)
+			require.NoError(t, err)
+			contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, creator, []byte(`{}`), "second", nil)
+			require.NoError(t, err)
+
+			// when
+			gotErr := k.setContractLabel(ctx, contractAddr, creator, spec.newLabel, DefaultAuthorizationPolicy{})
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, gotErr, types.ErrDuplicate)
+				assert.Equal(t, "second", k.GetContractInfo(ctx, contractAddr).Label)
+				return
+			}
+			require.NoError(t, gotErr)
+			var gotAddrs []sdk.AccAddress
+			k.IterateContractsByCreatorLabel(ctx, creator, spec.newLabel, func(addr sdk.AccAddress) bool {
+				gotAddrs = append(gotAddrs, addr)
+				return false
+			})
+			assert.Contains(t, gotAddrs, contractAddr)
+			k.IterateContractsByCreatorLabel(ctx, creator, "second", func(addr sdk.AccAddress) bool {
+				assert.True(t, spec.newLabel == "second", "old label index entry not removed")
+				return false
+			})
+		})
+	}
+}
+
+func TestUniqueLabelsKeepExistingDuplicates(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	creator := RandomAccountAddress(t)
+	// duplicates created before the param was enabled
+	contractAddr1, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, creator, []byte(`{}`), "my label", nil)
+	require.NoError(t, err)
+	contractAddr2, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, creator, []byte(`{}`), "my label", nil)
+	require.NoError(t, err)
+
+	// when
+	params := k.GetParams(ctx)
+	params.EnforceUniqueLabelsPerCreator = true
+	require.NoError(t, k.SetParams(ctx, params))
+
+	// then both contracts keep their label
+	assert.Equal(t, "my label", k.GetContractInfo(ctx, contractAddr1).Label)
+	assert.Equal(t, "my label", k.GetContractInfo(ctx, contractAddr2).Label)
+	// and a new contract with this label is rejected
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, creator, []byte(`{}`), "my label", nil)
+	require.ErrorIs(t, err, types.ErrDuplicate)
+	// and the duplicates can move to a free label
+	require.NoError(t, k.setContractLabel(ctx, contractAddr2, creator, "other label", DefaultAuthorizationPolicy{}))
+}
+
+func TestQueryContractsByLabel(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	instantiate := func(label string) string {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, example.CreatorAddr, []byte(`{}`), label, nil)
+		require.NoError(t, err)
+		return addr.String()
+	}
+	swap1, swap2 := instantiate("swap"), instantiate("swap")
+	swapV2 := instantiate("swap v2")
+	renamed := instantiate("other")
+	q := Querier(k)
+
+	// when exact match
+	rsp, err := q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: "swap"})
+	// then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{swap1, swap2}, rsp.ContractAddresses)
+	assert.Equal(t, []string{"swap", "swap"}, rsp.Labels)
+
+	// when prefix match with pagination
+	rsp, err = q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: "sw", Prefix: true, Pagination: &query.PageRequest{Limit: 2}})
+	// then
+	require.NoError(t, err)
+	require.Len(t, rsp.ContractAddresses, 2)
+	require.NotEmpty(t, rsp.Pagination.NextKey)
+	got := rsp.ContractAddresses
+	rsp, err = q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: "sw", Prefix: true, Pagination: &query.PageRequest{Key: rsp.Pagination.NextKey}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{swapV2}, rsp.ContractAddresses)
+	assert.Equal(t, []string{"swap v2"}, rsp.Labels)
+	assert.Empty(t, rsp.Pagination.NextKey)
+	assert.ElementsMatch(t, []string{swap1, swap2}, got)
+
+	// when label updated
+	require.NoError(t, k.setContractLabel(ctx, sdk.MustAccAddressFromBech32(renamed), example.CreatorAddr, "swap v2", DefaultAuthorizationPolicy{}))
+	// then
+	rsp, err = q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: "swap v2"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{swapV2, renamed}, rsp.ContractAddresses)
+	rsp, err = q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: "other"})
+	require.NoError(t, err)
+	assert.Empty(t, rsp.ContractAddresses)
+
+	// when empty label
+	_, err = q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Prefix: true})
+	// then
+	require.ErrorIs(t, err, types.ErrEmpty)
+}
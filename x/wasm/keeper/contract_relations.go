@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// storeContractRelation records that the child contract was instantiated by the parent contract.
+func (k Keeper) storeContractRelation(ctx context.Context, parent, child sdk.AccAddress) error {
+	store := k.storeService.OpenKVStore(ctx)
+	// store 1 byte to not run into `nil` debugging issues
+	if err := store.Set(types.GetContractChildKey(parent, child), []byte{1}); err != nil {
+		return err
+	}
+	return store.Set(types.GetContractParentKey(child), parent)
+}
+
+// GetContractParent returns the address of the contract that instantiated the given contract.
+// Returns nil when the contract was not created via contract dispatch.
+func (k Keeper) GetContractParent(ctx context.Context, child sdk.AccAddress) sdk.AccAddress {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetContractParentKey(child))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return nil
+	}
+	return bz
+}
+
+// IterateContractChildren iterates over all contracts instantiated by the parent contract ordered by address.
+func (k Keeper) IterateContractChildren(ctx context.Context, parent sdk.AccAddress, cb func(child sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractChildrenPrefix(parent))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			return
+		}
+	}
+}
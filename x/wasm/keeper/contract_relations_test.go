@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractRelations(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+
+	// factory contract that instantiates two children on execute
+	var childCodeID uint64
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	withInMemoryCodeStore(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		var msgs []wasmvmtypes.SubMsg
+		for _, label := range []string{"child1", "child2"} {
+			msgs = append(msgs, wasmvmtypes.SubMsg{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Instantiate: &wasmvmtypes.InstantiateMsg{
+					CodeID: childCodeID,
+					Msg:    []byte(`{}`),
+					Label:  label,
+				}}},
+			})
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Messages: msgs}}, 1, nil
+	}
+	factory := SeedNewContractInstance(t, ctx, keepers, &mock)
+	childCodeID = factory.CodeID
+	external := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	// when
+	_, err := keepers.ContractKeeper.Execute(ctx, factory.Contract, factory.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	// then both children are indexed
+	var children []sdk.AccAddress
+	k.IterateContractChildren(ctx, factory.Contract, func(child sdk.AccAddress) bool {
+		children = append(children, child)
+		return false
+	})
+	require.Len(t, children, 2)
+	for _, child := range children {
+		assert.Equal(t, factory.Contract, k.GetContractParent(ctx, child))
+		assert.Equal(t, factory.Contract.String(), k.GetContractInfo(ctx, child).Creator)
+	}
+	// and externally created contracts have no parent
+	assert.Nil(t, k.GetContractParent(ctx, factory.Contract))
+	assert.Nil(t, k.GetContractParent(ctx, external.Contract))
+
+	// and queries return the relations
+	q := Querier(k)
+	childrenRsp, err := q.ContractChildren(ctx, &types.QueryContractChildrenRequest{Address: factory.Contract.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{children[0].String(), children[1].String()}, childrenRsp.Contracts)
+
+	childrenRsp, err = q.ContractChildren(ctx, &types.QueryContractChildrenRequest{Address: external.Contract.String()})
+	require.NoError(t, err)
+	assert.Empty(t, childrenRsp.Contracts)
+
+	parentRsp, err := q.ContractParent(ctx, &types.QueryContractParentRequest{Address: children[0].String()})
+	require.NoError(t, err)
+	assert.Equal(t, factory.Contract.String(), parentRsp.Parent)
+
+	parentRsp, err = q.ContractParent(ctx, &types.QueryContractParentRequest{Address: external.Contract.String()})
+	require.NoError(t, err)
+	assert.Empty(t, parentRsp.Parent)
+
+	_, err = q.ContractParent(ctx, &types.QueryContractParentRequest{Address: RandomBech32AccountAddress(t)})
+	assert.ErrorIs(t, err, types.ErrNoSuchContractFn(""))
+
+	// and the index is exported to genesis
+	genState := ExportGenesis(ctx, k)
+	parents := make(map[string]string)
+	for _, c := range genState.Contracts {
+		parents[c.ContractAddress] = c.ParentAddress
+	}
+	assert.Equal(t, factory.Contract.String(), parents[children[0].String()])
+	assert.Equal(t, factory.Contract.String(), parents[children[1].String()])
+	assert.Empty(t, parents[factory.Contract.String()])
+
+	// and imported again
+	importCtx, importKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	importKeepers.WasmKeeper.wasmVM = &mock
+	_, err = InitGenesis(importCtx, importKeepers.WasmKeeper, *genState)
+	require.NoError(t, err)
+	for _, child := range children {
+		assert.Equal(t, factory.Contract, importKeepers.WasmKeeper.GetContractParent(importCtx, child))
+	}
+	assert.Nil(t, importKeepers.WasmKeeper.GetContractParent(importCtx, external.Contract))
+}
+
+func TestInitGenesisRejectsUnknownParent(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	withInMemoryCodeStore(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	genState := ExportGenesis(ctx, keepers.WasmKeeper)
+	require.Len(t, genState.Contracts, 1)
+	genState.Contracts[0].ParentAddress = RandomBech32AccountAddress(t)
+
+	importCtx, importKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	importKeepers.WasmKeeper.wasmVM = &mock
+	_, err := InitGenesis(importCtx, importKeepers.WasmKeeper, *genState)
+	require.ErrorIs(t, err, types.ErrNotFound)
+	assert.Nil(t, importKeepers.WasmKeeper.GetContractParent(importCtx, example.Contract))
+}
+
+// withInMemoryCodeStore keeps the stored wasm code in the mock so that genesis can be exported and imported
+func withInMemoryCodeStore(m *wasmtesting.MockWasmEngine) {
+	codes := make(map[string]wasmvm.WasmCode)
+	m.StoreCodeFn = func(code wasmvm.WasmCode, gasLimit uint64) (wasmvm.Checksum, uint64, error) {
+		checksum, gas, err := wasmtesting.HashOnlyStoreCodeFn(code, gasLimit)
+		codes[string(checksum)] = code
+		return checksum, gas, err
+	}
+	m.StoreCodeUncheckedFn = func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+		checksum, _, err := m.StoreCodeFn(code, 0)
+		return checksum, err
+	}
+	m.GetCodeFn = func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+		return codes[string(checksum)], nil
+	}
+}
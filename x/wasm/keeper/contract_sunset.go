@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// scheduleContractSunset announces the end-of-life height of the contract. The contract is deactivated at the end
+// of the block with the given height. A previously scheduled sunset is replaced.
+func (k Keeper) scheduleContractSunset(ctx context.Context, contractAddress, caller sdk.AccAddress, height int64, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if contractInfo.Inactive {
+		return types.ContractSunsetError{SunsetHeight: contractInfo.SunsetHeight}
+	}
+	if height <= sdkCtx.BlockHeight() {
+		return errorsmod.Wrapf(types.ErrInvalid, "height %d must be after the current block %d", height, sdkCtx.BlockHeight())
+	}
+	if contractInfo.SunsetHeight != 0 {
+		if err := k.removeFromContractSunsetIndex(ctx, contractInfo.SunsetHeight, contractAddress); err != nil {
+			return err
+		}
+	}
+	if err := k.addToContractSunsetIndex(ctx, height, contractAddress); err != nil {
+		return err
+	}
+	contractInfo.SunsetHeight = height
+	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeScheduleContractSunset,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeySunsetHeight, strconv.FormatInt(height, 10)),
+	))
+	return nil
+}
+
+// cancelContractSunset removes the scheduled sunset of the contract. It must be called before the sunset height
+// was reached.
+func (k Keeper) cancelContractSunset(ctx context.Context, contractAddress, caller sdk.AccAddress, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if contractInfo.Inactive {
+		return types.ContractSunsetError{SunsetHeight: contractInfo.SunsetHeight}
+	}
+	if contractInfo.SunsetHeight == 0 {
+		return types.ErrNotFound.Wrapf("sunset of contract %s", contractAddress)
+	}
+	if err := k.removeFromContractSunsetIndex(ctx, contractInfo.SunsetHeight, contractAddress); err != nil {
+		return err
+	}
+	contractInfo.SunsetHeight = 0
+	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeCancelContractSunset,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+	))
+	return nil
+}
+
+// addToContractSunsetIndex adds the contract to the index of the sunsets that are processed in the end blocker
+func (k Keeper) addToContractSunsetIndex(ctx context.Context, height int64, contractAddress sdk.AccAddress) error {
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Set(types.GetContractSunsetKey(height, contractAddress), []byte{})
+}
+
+// removeFromContractSunsetIndex removes the contract from the index of the sunsets
+func (k Keeper) removeFromContractSunsetIndex(ctx context.Context, height int64, contractAddress sdk.AccAddress) error {
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Delete(types.GetContractSunsetKey(height, contractAddress))
+}
+
+// DeactivateSunsetContracts deactivates all contracts whose sunset height is the current block, ordered by contract
+// address. Sunsets for a past height, that can only exist after a genesis import, are processed as well.
+func (k Keeper) DeactivateSunsetContracts(ctx sdk.Context) error {
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	iter := store.Iterator(types.ContractSunsetPrefix, types.GetContractSunsetsPrefix(ctx.BlockHeight()+1))
+	var due []sdk.AccAddress
+	for ; iter.Valid(); iter.Next() {
+		// key: <prefix><height><contractAddr>
+		due = append(due, bytes.Clone(iter.Key()[len(types.ContractSunsetPrefix)+8:]))
+	}
+	iter.Close()
+
+	for _, contractAddress := range due {
+		contractInfo := k.GetContractInfo(ctx, contractAddress)
+		if contractInfo == nil {
+			return types.ErrNotFound.Wrapf("contract %s of sunset", contractAddress)
+		}
+		if err := k.removeFromContractSunsetIndex(ctx, contractInfo.SunsetHeight, contractAddress); err != nil {
+			return err
+		}
+		contractInfo.Inactive = true
+		k.mustStoreContractInfo(ctx, contractAddress, contractInfo)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeContractSunset,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+			sdk.NewAttribute(types.AttributeKeySunsetHeight, strconv.FormatInt(contractInfo.SunsetHeight, 10)),
+		))
+	}
+	return nil
+}
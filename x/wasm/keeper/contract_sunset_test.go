@@ -0,0 +1,297 @@
+package keeper
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestScheduleContractSunset(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	parentCtx = parentCtx.WithBlockHeight(100)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	specs := map[string]struct {
+		setup     func(ctx sdk.Context)
+		src       types.MsgScheduleContractSunset
+		expErr    error
+		expHeight int64
+	}{
+		"scheduled by admin": {
+			src:       types.MsgScheduleContractSunset{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Height: 200},
+			expHeight: 200,
+		},
+		"replaces scheduled sunset": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.scheduleContractSunset(ctx, example.Contract, example.CreatorAddr, 150, DefaultAuthorizationPolicy{}))
+			},
+			src:       types.MsgScheduleContractSunset{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Height: 200},
+			expHeight: 200,
+		},
+		"not admin": {
+			src:    types.MsgScheduleContractSunset{Sender: RandomBech32AccountAddress(t), Contract: example.Contract.String(), Height: 200},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"current height": {
+			src:    types.MsgScheduleContractSunset{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Height: 100},
+			expErr: types.ErrInvalid,
+		},
+		"unknown contract": {
+			src:    types.MsgScheduleContractSunset{Sender: example.CreatorAddr.String(), Contract: RandomBech32AccountAddress(t), Height: 200},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
+		"inactive contract": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.scheduleContractSunset(ctx, example.Contract, example.CreatorAddr, 101, DefaultAuthorizationPolicy{}))
+				require.NoError(t, k.DeactivateSunsetContracts(ctx.WithBlockHeight(101)))
+			},
+			src:    types.MsgScheduleContractSunset{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Height: 200},
+			expErr: types.ErrContractInactive,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			_, gotErr := msgServer.ScheduleContractSunset(ctx.WithEventManager(em), &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expHeight, k.GetContractInfo(ctx, example.Contract).SunsetHeight)
+			assert.Equal(t, []sdk.AccAddress{example.Contract}, contractSunsets(ctx, k, spec.expHeight))
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeScheduleContractSunset, em.Events()[0].Type)
+		})
+	}
+}
+
+func TestCancelContractSunset(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	parentCtx = parentCtx.WithBlockHeight(100)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	myMsg := types.MsgCancelContractSunset{Sender: example.CreatorAddr.String(), Contract: example.Contract.String()}
+
+	specs := map[string]struct {
+		setup  func(ctx sdk.Context)
+		src    types.MsgCancelContractSunset
+		expErr error
+	}{
+		"cancelled by admin": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.scheduleContractSunset(ctx, example.Contract, example.CreatorAddr, 200, DefaultAuthorizationPolicy{}))
+			},
+			src: myMsg,
+		},
+		"not scheduled": {
+			src:    myMsg,
+			expErr: types.ErrNotFound,
+		},
+		"not admin": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.scheduleContractSunset(ctx, example.Contract, example.CreatorAddr, 200, DefaultAuthorizationPolicy{}))
+			},
+			src:    types.MsgCancelContractSunset{Sender: RandomBech32AccountAddress(t), Contract: example.Contract.String()},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"after sunset": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.scheduleContractSunset(ctx, example.Contract, example.CreatorAddr, 101, DefaultAuthorizationPolicy{}))
+				require.NoError(t, k.DeactivateSunsetContracts(ctx.WithBlockHeight(101)))
+			},
+			src:    myMsg,
+			expErr: types.ErrContractInactive,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			_, gotErr := msgServer.CancelContractSunset(ctx.WithEventManager(em), &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Zero(t, k.GetContractInfo(ctx, example.Contract).SunsetHeight)
+			assert.Empty(t, contractSunsets(ctx, k, 200))
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeCancelContractSunset, em.Events()[0].Type)
+			// and the contract stays active
+			require.NoError(t, k.DeactivateSunsetContracts(ctx.WithBlockHeight(200)))
+			assert.False(t, k.GetContractInfo(ctx, example.Contract).Inactive)
+		})
+	}
+}
+
+func TestDeactivateSunsetContracts(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = ctx.WithBlockHeight(100)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	var sunsetContracts []sdk.AccAddress
+	for i := 0; i < 3; i++ {
+		example := SeedNewContractInstance(t, ctx, keepers, &mock)
+		require.NoError(t, k.scheduleContractSunset(ctx, example.Contract, example.CreatorAddr, 101, DefaultAuthorizationPolicy{}))
+		sunsetContracts = append(sunsetContracts, example.Contract)
+	}
+	later := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, k.scheduleContractSunset(ctx, later.Contract, later.CreatorAddr, 102, DefaultAuthorizationPolicy{}))
+
+	// when
+	em := sdk.NewEventManager()
+	ctx = ctx.WithBlockHeight(101).WithEventManager(em)
+	require.NoError(t, k.DeactivateSunsetContracts(ctx))
+
+	// then the contracts of the block are deactivated in address order
+	slices.SortFunc(sunsetContracts, func(a, b sdk.AccAddress) int { return bytes.Compare(a, b) })
+	var gotContracts []string
+	for _, e := range em.Events() {
+		require.Equal(t, types.EventTypeContractSunset, e.Type)
+		attr, ok := e.GetAttribute(types.AttributeKeyContractAddr)
+		require.True(t, ok)
+		gotContracts = append(gotContracts, attr.Value)
+		attr, ok = e.GetAttribute(types.AttributeKeySunsetHeight)
+		require.True(t, ok)
+		assert.Equal(t, "101", attr.Value)
+	}
+	var expContracts []string
+	for _, c := range sunsetContracts {
+		expContracts = append(expContracts, c.String())
+		assert.True(t, k.GetContractInfo(ctx, c).Inactive)
+	}
+	assert.Equal(t, expContracts, gotContracts)
+	assert.Empty(t, contractSunsets(ctx, k, 101))
+	// and executions of a deactivated contract fail with the sunset height
+	_, err := keepers.ContractKeeper.Execute(ctx, sunsetContracts[0], RandomAccountAddress(t), []byte(`{}`), nil)
+	var sunsetErr types.ContractSunsetError
+	require.ErrorAs(t, err, &sunsetErr)
+	assert.Equal(t, int64(101), sunsetErr.SunsetHeight)
+	assert.ErrorIs(t, err, types.ErrContractInactive)
+	// and other contracts are not affected
+	assert.False(t, k.GetContractInfo(ctx, later.Contract).Inactive)
+	_, err = keepers.ContractKeeper.Execute(ctx, later.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	// when the next block is reached
+	require.NoError(t, k.DeactivateSunsetContracts(ctx.WithBlockHeight(102)))
+	// then
+	assert.True(t, k.GetContractInfo(ctx, later.Contract).Inactive)
+	_, err = keepers.ContractKeeper.Execute(ctx, later.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+	require.ErrorIs(t, err, types.ErrContractInactive)
+}
+
+// contractSunsets returns the contracts in the sunset index at the height
+func contractSunsets(ctx sdk.Context, k *Keeper, height int64) []sdk.AccAddress {
+	var result []sdk.AccAddress
+	store := k.storeService.OpenKVStore(ctx)
+	iter, err := store.Iterator(types.GetContractSunsetsPrefix(height), types.GetContractSunsetsPrefix(height+1))
+	if err != nil {
+		panic(err)
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		result = append(result, iter.Key()[len(types.GetContractSunsetsPrefix(height)):])
+	}
+	return result
+}
+
+func TestSunsetContractEntryPoints(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = ctx.WithBlockHeight(100)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeIBCInstantiable(&mock)
+	mock.MigrateFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	mock.MigrateWithInfoFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ []byte, _ wasmvmtypes.MigrateInfo, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	mock.IBCPacketAckFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.IBCPacketAckMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+		return &wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{}}, 1, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	newCode := StoreRandomContract(t, ctx, keepers, &mock)
+	require.NoError(t, k.scheduleContractSunset(ctx, example.Contract, example.CreatorAddr, 101, DefaultAuthorizationPolicy{}))
+	ctx = ctx.WithBlockHeight(101)
+	require.NoError(t, k.DeactivateSunsetContracts(ctx))
+
+	specs := map[string]func(ctx sdk.Context) error{
+		"migrate": func(ctx sdk.Context) error {
+			_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, newCode.CodeID, []byte(`{}`))
+			return err
+		},
+		"ibc channel open": func(ctx sdk.Context) error {
+			_, err := k.OnOpenChannel(ctx, example.Contract, wasmvmtypes.IBCChannelOpenMsg{})
+			return err
+		},
+		"ibc channel connect": func(ctx sdk.Context) error {
+			return k.OnConnectChannel(ctx, example.Contract, wasmvmtypes.IBCChannelConnectMsg{})
+		},
+		"ibc packet receive": func(ctx sdk.Context) error {
+			_, err := k.OnRecvPacket(ctx, example.Contract, wasmvmtypes.IBCPacketReceiveMsg{})
+			return err
+		},
+		"ibc destination callback": func(ctx sdk.Context) error {
+			return k.IBCDestinationCallback(ctx, example.Contract, wasmvmtypes.IBCDestinationCallbackMsg{})
+		},
+	}
+	for name, call := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			err := call(ctx)
+			var sunsetErr types.ContractSunsetError
+			require.ErrorAs(t, err, &sunsetErr)
+			assert.Equal(t, int64(101), sunsetErr.SunsetHeight)
+		})
+	}
+	t.Run("ibc packet ack of a packet sent before the sunset", func(t *testing.T) {
+		ctx, _ := ctx.CacheContext()
+		require.NoError(t, k.OnAckPacket(ctx, example.Contract, wasmvmtypes.IBCPacketAckMsg{}))
+	})
+	t.Run("migrate by governance", func(t *testing.T) {
+		ctx, _ := ctx.CacheContext()
+		_, err := NewGovPermissionKeeper(k).Migrate(ctx, example.Contract, RandomAccountAddress(t), newCode.CodeID, []byte(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, newCode.CodeID, k.GetContractInfo(ctx, example.Contract).CodeID)
+	})
+}
@@ -0,0 +1,219 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSuspendAndResumeContract(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	admin, contract, authority := example.CreatorAddr.String(), example.Contract.String(), k.GetAuthority()
+	suspend := func(ctx sdk.Context) {
+		_, err := msgServer.SuspendContract(ctx, &types.MsgSuspendContract{Sender: admin, Contract: contract})
+		require.NoError(t, err)
+	}
+
+	specs := map[string]struct {
+		setup        func(ctx sdk.Context)
+		exec         func(ctx sdk.Context) error
+		expErr       error
+		expSuspended bool
+		expEvent     string
+	}{
+		"suspend by admin": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.SuspendContract(ctx, &types.MsgSuspendContract{Sender: admin, Contract: contract})
+				return err
+			},
+			expSuspended: true,
+			expEvent:     types.EventTypeSuspendContract,
+		},
+		"suspend by gov authority": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.SuspendContract(ctx, &types.MsgSuspendContract{Sender: authority, Contract: contract})
+				return err
+			},
+			expSuspended: true,
+			expEvent:     types.EventTypeSuspendContract,
+		},
+		"suspend by other": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.SuspendContract(ctx, &types.MsgSuspendContract{Sender: RandomBech32AccountAddress(t), Contract: contract})
+				return err
+			},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"suspend suspended contract": {
+			setup: suspend,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.SuspendContract(ctx, &types.MsgSuspendContract{Sender: admin, Contract: contract})
+				return err
+			},
+			expErr: types.ErrInvalid,
+		},
+		"suspend unknown contract": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.SuspendContract(ctx, &types.MsgSuspendContract{Sender: admin, Contract: RandomBech32AccountAddress(t)})
+				return err
+			},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
+		"resume by admin": {
+			setup: suspend,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ResumeContract(ctx, &types.MsgResumeContract{Sender: admin, Contract: contract})
+				return err
+			},
+			expEvent: types.EventTypeResumeContract,
+		},
+		"resume by gov authority": {
+			setup: suspend,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ResumeContract(ctx, &types.MsgResumeContract{Sender: authority, Contract: contract})
+				return err
+			},
+			expEvent: types.EventTypeResumeContract,
+		},
+		"resume by other": {
+			setup: suspend,
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ResumeContract(ctx, &types.MsgResumeContract{Sender: RandomBech32AccountAddress(t), Contract: contract})
+				return err
+			},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"resume not suspended contract": {
+			exec: func(ctx sdk.Context) error {
+				_, err := msgServer.ResumeContract(ctx, &types.MsgResumeContract{Sender: admin, Contract: contract})
+				return err
+			},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			gotErr := spec.exec(ctx.WithEventManager(em))
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expSuspended, k.GetContractInfo(ctx, example.Contract).Suspended)
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, spec.expEvent, em.Events()[0].Type)
+			// and the flag is returned by the contract info query
+			res, err := Querier(k).ContractInfo(ctx, &types.QueryContractInfoRequest{Address: contract})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expSuspended, res.Suspended)
+		})
+	}
+}
+
+func TestSuspendedContractOperations(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	okResult := func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	mock.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	mock.SudoFn = okResult
+	mock.MigrateFn = okResult
+	mock.MigrateWithInfoFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ []byte, _ wasmvmtypes.MigrateInfo, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	mock.QueryFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		return &wasmvmtypes.QueryResult{Ok: []byte(`{}`)}, 1, nil
+	}
+	mock.IBCPacketReceiveFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.IBCPacketReceiveMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCReceiveResult, uint64, error) {
+		return &wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{Acknowledgement: []byte(`{}`)}}, 1, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	other := SeedNewContractInstance(t, ctx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	admin := example.CreatorAddr.String()
+
+	// when
+	_, err := msgServer.SuspendContract(ctx, &types.MsgSuspendContract{Sender: admin, Contract: example.Contract.String()})
+	require.NoError(t, err)
+
+	// then executions fail
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+	require.ErrorIs(t, err, types.ErrContractSuspended)
+	// and sudo calls of other modules fail
+	_, err = NewDefaultPermissionKeeper(k).Sudo(ctx, example.Contract, []byte(`{}`))
+	require.ErrorIs(t, err, types.ErrContractSuspended)
+	// but gov sudo calls still work
+	_, err = msgServer.SudoContract(ctx, &types.MsgSudoContract{Authority: k.GetAuthority(), Contract: example.Contract.String(), Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	_, err = NewGovPermissionKeeper(k).Sudo(ctx, example.Contract, []byte(`{}`))
+	require.NoError(t, err)
+	// and privileged module callbacks, too
+	_, err = k.Sudo(ctx, example.Contract, []byte(`{}`))
+	require.NoError(t, err)
+	// and incoming IBC packets fail
+	_, err = k.OnRecvPacket(ctx, example.Contract, wasmvmtypes.IBCPacketReceiveMsg{})
+	require.ErrorIs(t, err, types.ErrContractSuspended)
+	// and other contracts are not affected
+	_, err = keepers.ContractKeeper.Execute(ctx, other.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+	require.NoError(t, err)
+	// and queries still work
+	_, err = k.QuerySmart(ctx, example.Contract, []byte(`{}`))
+	require.NoError(t, err)
+	// and migrations still work
+	_, err = msgServer.MigrateContract(ctx, &types.MsgMigrateContract{Sender: admin, Contract: example.Contract.String(), CodeID: example.CodeID, Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	// and admin operations still work
+	newAdmin := RandomBech32AccountAddress(t)
+	_, err = msgServer.UpdateAdmin(ctx, &types.MsgUpdateAdmin{Sender: admin, Contract: example.Contract.String(), NewAdmin: newAdmin})
+	require.NoError(t, err)
+	assert.True(t, k.GetContractInfo(ctx, example.Contract).Suspended)
+	// and the contract is listed with the suspended only filter
+	res, err := Querier(k).ContractsByCode(ctx, &types.QueryContractsByCodeRequest{CodeId: example.CodeID, SuspendedOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{example.Contract.String()}, res.Contracts)
+	byAdmin, err := Querier(k).ContractsByAdmin(ctx, &types.QueryContractsByAdminRequest{AdminAddress: newAdmin, SuspendedOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{example.Contract.String()}, byAdmin.ContractAddresses)
+	byCreator, err := Querier(k).ContractsByCreator(ctx, &types.QueryContractsByCreatorRequest{CreatorAddress: other.CreatorAddr.String(), SuspendedOnly: true})
+	require.NoError(t, err)
+	assert.Empty(t, byCreator.ContractAddresses)
+
+	// when resumed by the new admin
+	_, err = msgServer.ResumeContract(ctx, &types.MsgResumeContract{Sender: newAdmin, Contract: example.Contract.String()})
+	require.NoError(t, err)
+	// then executions work again
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+	require.NoError(t, err)
+	// and the contract is not listed as suspended anymore
+	res, err = Querier(k).ContractsByCode(ctx, &types.QueryContractsByCodeRequest{CodeId: example.CodeID, SuspendedOnly: true})
+	require.NoError(t, err)
+	assert.Empty(t, res.Contracts)
+}
@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// setContractTags replaces the tags of the contract and updates the tag index. The tags are stored sorted.
+func (k Keeper) setContractTags(ctx context.Context, contractAddress, caller sdk.AccAddress, tags []string, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if err := types.ValidateContractTags(tags); err != nil {
+		return err
+	}
+	newTags := slices.Clone(tags)
+	slices.Sort(newTags)
+	for _, t := range contractInfo.Tags {
+		if err := k.removeFromContractTagIndex(ctx, t, contractAddress); err != nil {
+			return err
+		}
+	}
+	if err := k.addToContractTagIndex(ctx, newTags, contractAddress); err != nil {
+		return err
+	}
+	contractInfo.Tags = newTags
+	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetContractTags,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyTags, strings.Join(newTags, ",")),
+	))
+	return nil
+}
+
+// addToContractTagIndex adds the contract to the index for contracts-by-tag lookups
+func (k Keeper) addToContractTagIndex(ctx context.Context, tags []string, contractAddress sdk.AccAddress) error {
+	store := k.storeService.OpenKVStore(ctx)
+	for _, t := range tags {
+		if err := store.Set(types.GetContractByTagKey(t, contractAddress), []byte{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFromContractTagIndex removes the contract from the index for contracts-by-tag lookups
+func (k Keeper) removeFromContractTagIndex(ctx context.Context, tag string, contractAddress sdk.AccAddress) error {
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Delete(types.GetContractByTagKey(tag, contractAddress))
+}
+
+// IterateContractsByTag iterates over all contracts with the tag ordered by address
+func (k Keeper) IterateContractsByTag(ctx context.Context, tag string, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractsByTagPrefix(tag))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			return
+		}
+	}
+}
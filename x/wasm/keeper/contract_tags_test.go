@@ -0,0 +1,185 @@
+package keeper
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSetContractTags(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	tooManyTags := make([]string, types.MaxContractTags+1)
+	for i := range tooManyTags {
+		tooManyTags[i] = fmt.Sprintf("tag-%d", i)
+	}
+
+	specs := map[string]struct {
+		setup   func(ctx sdk.Context)
+		src     types.MsgSetContractTags
+		expErr  error
+		expTags []string
+	}{
+		"set by admin": {
+			src:     types.MsgSetContractTags{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Tags: []string{"prod", "defi"}},
+			expTags: []string{"defi", "prod"},
+		},
+		"replace": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.setContractTags(ctx, example.Contract, example.CreatorAddr, []string{"prod", "deprecated"}, DefaultAuthorizationPolicy{}))
+			},
+			src:     types.MsgSetContractTags{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Tags: []string{"prod", "defi"}},
+			expTags: []string{"defi", "prod"},
+		},
+		"clear": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.setContractTags(ctx, example.Contract, example.CreatorAddr, []string{"prod"}, DefaultAuthorizationPolicy{}))
+			},
+			src: types.MsgSetContractTags{Sender: example.CreatorAddr.String(), Contract: example.Contract.String()},
+		},
+		"max tags": {
+			src:     types.MsgSetContractTags{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Tags: tooManyTags[:types.MaxContractTags]},
+			expTags: slices.Sorted(slices.Values(tooManyTags[:types.MaxContractTags])),
+		},
+		"too many tags": {
+			src:    types.MsgSetContractTags{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Tags: tooManyTags},
+			expErr: types.ErrLimit,
+		},
+		"not admin": {
+			src:    types.MsgSetContractTags{Sender: RandomBech32AccountAddress(t), Contract: example.Contract.String(), Tags: []string{"prod"}},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			src:    types.MsgSetContractTags{Sender: example.CreatorAddr.String(), Contract: RandomBech32AccountAddress(t), Tags: []string{"prod"}},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			_, gotErr := msgServer.SetContractTags(ctx.WithEventManager(em), &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expTags, k.GetContractInfo(ctx, example.Contract).Tags)
+			// and the index contains the new tags only
+			for _, tag := range append(tooManyTags, "defi", "deprecated", "prod") {
+				assert.Equal(t, slices.Contains(spec.expTags, tag), contractsByTag(t, ctx, k, tag).Has(example.Contract), tag)
+			}
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeSetContractTags, em.Events()[0].Type)
+		})
+	}
+}
+
+func TestContractTagsKeptOnClearAdmin(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, k.setContractTags(ctx, example.Contract, example.CreatorAddr, []string{"prod"}, DefaultAuthorizationPolicy{}))
+
+	// when
+	require.NoError(t, keepers.ContractKeeper.ClearContractAdmin(ctx, example.Contract, example.CreatorAddr))
+
+	// then
+	info := k.GetContractInfo(ctx, example.Contract)
+	assert.Empty(t, info.Admin)
+	assert.Equal(t, []string{"prod"}, info.Tags)
+	assert.True(t, contractsByTag(t, ctx, k, "prod").Has(example.Contract))
+	// and tags can not be modified anymore
+	err := k.setContractTags(ctx, example.Contract, example.CreatorAddr, nil, DefaultAuthorizationPolicy{})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+}
+
+func TestQueryContractsByTag(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	var prodContracts []string
+	for i := 0; i < 3; i++ {
+		example := SeedNewContractInstance(t, ctx, keepers, &mock)
+		require.NoError(t, k.setContractTags(ctx, example.Contract, example.CreatorAddr, []string{"prod"}, DefaultAuthorizationPolicy{}))
+		prodContracts = append(prodContracts, example.Contract.String())
+	}
+	other := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, k.setContractTags(ctx, other.Contract, other.CreatorAddr, []string{"defi"}, DefaultAuthorizationPolicy{}))
+	q := Querier(k)
+
+	// when
+	rsp, err := q.ContractsByTag(ctx, &types.QueryContractsByTagRequest{Tag: "prod", Pagination: &query.PageRequest{Limit: 2}})
+	// then
+	require.NoError(t, err)
+	require.Len(t, rsp.ContractAddresses, 2)
+	require.NotEmpty(t, rsp.Pagination.NextKey)
+	got := rsp.ContractAddresses
+
+	// when next page
+	rsp, err = q.ContractsByTag(ctx, &types.QueryContractsByTagRequest{Tag: "prod", Pagination: &query.PageRequest{Key: rsp.Pagination.NextKey}})
+	// then
+	require.NoError(t, err)
+	require.Len(t, rsp.ContractAddresses, 1)
+	assert.Empty(t, rsp.Pagination.NextKey)
+	got = append(got, rsp.ContractAddresses...)
+	assert.ElementsMatch(t, prodContracts, got)
+
+	// when other tag
+	rsp, err = q.ContractsByTag(ctx, &types.QueryContractsByTagRequest{Tag: "defi"})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{other.Contract.String()}, rsp.ContractAddresses)
+
+	// when unknown tag
+	rsp, err = q.ContractsByTag(ctx, &types.QueryContractsByTagRequest{Tag: "unknown"})
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, rsp.ContractAddresses)
+
+	// when invalid tag
+	_, err = q.ContractsByTag(ctx, &types.QueryContractsByTagRequest{Tag: ""})
+	// then
+	require.ErrorIs(t, err, types.ErrEmpty)
+}
+
+type addressSet map[string]struct{}
+
+func (s addressSet) Has(addr sdk.AccAddress) bool {
+	_, ok := s[addr.String()]
+	return ok
+}
+
+func contractsByTag(t *testing.T, ctx sdk.Context, k *Keeper, tag string) addressSet {
+	t.Helper()
+	r := make(addressSet)
+	k.IterateContractsByTag(ctx, tag, func(addr sdk.AccAddress) bool {
+		r[addr.String()] = struct{}{}
+		return false
+	})
+	return r
+}
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// trackUsage increments the invocation counter of the contract entry point.
+// The counter update is charged to the caller like any other state access of the contract call.
+// Queries are not tracked as they are executed on state branches that are discarded.
+// This is a noop when usage tracking is disabled in the params that were loaded for the call.
+func (k Keeper) trackUsage(ctx sdk.Context, params types.Params, contractAddress sdk.AccAddress, entryPoint string) {
+	if params.DisableUsageTracking {
+		return
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetContractUsageKey(contractAddress, entryPoint)
+	bz, err := store.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	var count uint64
+	if bz != nil {
+		count, _ = binary.Uvarint(bz)
+	}
+	if err := store.Set(key, binary.AppendUvarint(nil, count+1)); err != nil {
+		panic(err)
+	}
+}
+
+// IterateContractUsage iterates over the invocation counters of the contract entry points ordered by name
+func (k Keeper) IterateContractUsage(ctx context.Context, contractAddress sdk.AccAddress, cb func(types.EntryPointUsage) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractUsagePrefix(contractAddress))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		count, _ := binary.Uvarint(iter.Value())
+		if cb(types.EntryPointUsage{EntryPoint: string(iter.Key()), Count: count}) {
+			return
+		}
+	}
+}
+
+func (k Keeper) importContractUsage(ctx context.Context, contractAddress sdk.AccAddress, usage []types.EntryPointUsage) error {
+	store := k.storeService.OpenKVStore(ctx)
+	for _, u := range usage {
+		key := types.GetContractUsageKey(contractAddress, u.EntryPoint)
+		ok, err := store.Has(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errorsmod.Wrapf(types.ErrDuplicate, "entry point usage: %s", u.EntryPoint)
+		}
+		if err := store.Set(key, binary.AppendUvarint(nil, u.Count)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestTrackContractUsage(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		// query self and dispatch a sub message to trigger a reply
+		_, err := querier.Query(wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{ContractAddr: env.Contract.Address, Msg: []byte(`{}`)}}}, gasLimit)
+		require.NoError(t, err)
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{{
+				ID:      1,
+				ReplyOn: wasmvmtypes.ReplyAlways,
+				Msg:     wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{ToAddress: RandomBech32AccountAddress(t), Amount: []wasmvmtypes.Coin{}}}},
+			}},
+		}}, 0, nil
+	}
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		return &wasmvmtypes.QueryResult{Ok: []byte(`{}`)}, 0, nil
+	}
+	mock.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	mock.MigrateWithInfoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, migrateInfo wasmvmtypes.MigrateInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return mock.MigrateFn(codeID, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+	}
+
+	specs := map[string]struct {
+		disabled bool
+		exp      []types.EntryPointUsage
+	}{
+		"all entry points tracked": {
+			exp: []types.EntryPointUsage{
+				{EntryPoint: "execute", Count: 2},
+				{EntryPoint: "instantiate", Count: 1},
+				{EntryPoint: "migrate", Count: 1},
+				{EntryPoint: "reply", Count: 2},
+				{EntryPoint: "sudo", Count: 1},
+			},
+		},
+		"tracking disabled": {
+			disabled: true,
+			exp:      []types.EntryPointUsage{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+			k := keepers.WasmKeeper
+			params := types.DefaultParams()
+			params.DisableUsageTracking = spec.disabled
+			require.NoError(t, k.SetParams(ctx, params))
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+			// when
+			for i := 0; i < 2; i++ {
+				_, err := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+				require.NoError(t, err)
+			}
+			_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+			require.NoError(t, err)
+			_, err = k.migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{}`), DefaultAuthorizationPolicy{})
+			require.NoError(t, err)
+			_, err = k.QuerySmart(ctx, example.Contract, []byte(`{}`))
+			require.NoError(t, err)
+
+			// then queries are not tracked
+			var got []types.EntryPointUsage
+			k.IterateContractUsage(ctx, example.Contract, func(u types.EntryPointUsage) bool {
+				got = append(got, u)
+				return false
+			})
+			assert.ElementsMatch(t, spec.exp, got)
+
+			// and the query returns the same counters
+			res, err := Querier(k).ContractUsage(ctx, &types.QueryContractUsageRequest{Address: example.Contract.String()})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, spec.exp, res.EntryPoints)
+		})
+	}
+}
+
+func TestTrackContractUsageConsumesGas(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	gasUsed := func(disabled bool) uint64 {
+		params := types.DefaultParams()
+		params.DisableUsageTracking = disabled
+		require.NoError(t, k.SetParams(ctx, params))
+		before := ctx.GasMeter().GasConsumed()
+		_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+		require.NoError(t, err)
+		return ctx.GasMeter().GasConsumed() - before
+	}
+	// the counter update is charged to the caller
+	assert.Greater(t, gasUsed(false), gasUsed(true))
+}
+
+func TestQueryContractUsage(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	q := Querier(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		src    *types.QueryContractUsageRequest
+		exp    *types.QueryContractUsageResponse
+		expErr bool
+	}{
+		"query": {
+			src: &types.QueryContractUsageRequest{Address: example.Contract.String()},
+			exp: &types.QueryContractUsageResponse{EntryPoints: []types.EntryPointUsage{{EntryPoint: "instantiate", Count: 1}}},
+		},
+		"unknown contract": {
+			src:    &types.QueryContractUsageRequest{Address: RandomBech32AccountAddress(t)},
+			expErr: true,
+		},
+		"invalid address": {
+			src:    &types.QueryContractUsageRequest{Address: "foo"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ContractUsage(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestContractUsageGenesis(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	withInMemoryCodeStore(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	k.trackUsage(ctx, k.GetParams(ctx), example.Contract, "execute")
+	k.trackUsage(ctx, k.GetParams(ctx), example.Contract, "execute")
+	exp := []types.EntryPointUsage{{EntryPoint: "execute", Count: 2}, {EntryPoint: "instantiate", Count: 1}}
+
+	// when
+	genState := ExportGenesis(ctx, k)
+	// then
+	require.Len(t, genState.Contracts, 1)
+	assert.Equal(t, exp, genState.Contracts[0].Usage)
+
+	// when imported again
+	importCtx, importKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	importKeepers.WasmKeeper.wasmVM = &mock
+	_, err := InitGenesis(importCtx, importKeepers.WasmKeeper, *genState)
+	// then
+	require.NoError(t, err)
+	var got []types.EntryPointUsage
+	importKeepers.WasmKeeper.IterateContractUsage(importCtx, example.Contract, func(u types.EntryPointUsage) bool {
+		got = append(got, u)
+		return false
+	})
+	assert.Equal(t, exp, got)
+}
@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/json"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// newEnv is the single place to construct the env for a contract call.
+// Block and transaction info must be identical for the root call and all submessages, replies and queries that
+// follow within the same execution. The first env is recorded in the returned context and all later ones are
+// compared against it. A mismatch is reported via onEnvMismatch. The root call also sets the correlation id of the
+// execution logs.
+func (k Keeper) newEnv(ctx sdk.Context, contractAddr sdk.AccAddress) (sdk.Context, wasmvmtypes.Env) {
+	env := types.NewEnv(ctx, contractAddr)
+	bz := envFingerprint(env)
+	if exp, ok := types.TxEnvFromContext(ctx); ok {
+		if err := checkEnvFingerprint(exp, bz); err != nil {
+			onEnvMismatch(ctx, err)
+		}
+		return ctx, env
+	}
+	ctx = types.WithCorrelationID(ctx, k.nextCorrelationID(ctx))
+	return types.WithTxEnv(ctx, bz), env
+}
+
+// envFingerprint returns the json bytes of the env parts that must not change within an execution.
+// Json is used as this is the representation that the contract receives.
+func envFingerprint(env wasmvmtypes.Env) []byte {
+	bz, err := json.Marshal(struct {
+		Block       wasmvmtypes.BlockInfo        `json:"block"`
+		Transaction *wasmvmtypes.TransactionInfo `json:"transaction,omitempty"`
+	}{Block: env.Block, Transaction: env.Transaction})
+	if err != nil {
+		panic(err) // can not happen for these types
+	}
+	return bz
+}
+
+// checkEnvFingerprint returns an error when the env fingerprints differ
+func checkEnvFingerprint(exp, got []byte) error {
+	if !bytes.Equal(exp, got) {
+		return errorsmod.Wrapf(types.ErrInvalid, "env changed within execution: expected %s but got %s", exp, got)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build wasm_debug
+
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// onEnvMismatch panics on an env that differs from the first one in the execution.
+// This is enabled with the `wasm_debug` build tag only.
+func onEnvMismatch(_ sdk.Context, err error) {
+	panic(err)
+}
@@ -0,0 +1,15 @@
+//go:build !wasm_debug
+
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// onEnvMismatch counts and logs an env that differs from the first one in the execution.
+// Build with the `wasm_debug` tag to panic instead.
+func onEnvMismatch(ctx sdk.Context, err error) {
+	telemetry.IncrCounter(1, "wasm", "env", "mismatch")
+	moduleLogger(ctx).Error("non deterministic contract env", "err", err)
+}
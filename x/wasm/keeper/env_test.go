@@ -0,0 +1,154 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestNewEnvRecordsFirstEnv(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	ctx = types.WithTXCounter(ctx.WithBlockHeight(1).WithBlockTime(time.Unix(0, 1619700924259075001)).WithChainID("testing"), 2)
+	myContract, otherContract := RandomAccountAddress(t), RandomAccountAddress(t)
+
+	// when
+	rootCtx, rootEnv := k.newEnv(ctx, myContract)
+	// then
+	recorded, ok := types.TxEnvFromContext(rootCtx)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"block":{"height":1,"time":"1619700924259075001","chain_id":"testing"},"transaction":{"index":2}}`, string(recorded))
+	assert.Equal(t, envFingerprint(rootEnv), recorded)
+
+	// when called for another contract within the same execution
+	subCtx, subEnv := k.newEnv(rootCtx, otherContract)
+	// then the recorded env is kept
+	got, _ := types.TxEnvFromContext(subCtx)
+	assert.Equal(t, recorded, got)
+	assert.Equal(t, otherContract.String(), subEnv.Contract.Address)
+	assert.NoError(t, checkEnvFingerprint(recorded, envFingerprint(subEnv)))
+}
+
+func TestCheckEnvFingerprint(t *testing.T) {
+	myTime := time.Unix(0, 1619700924259075001)
+	myContract := RandomAccountAddress(t)
+	baseCtx := types.WithTXCounter(sdk.Context{}.WithBlockHeight(1).WithBlockTime(myTime).WithChainID("testing").WithContext(context.Background()), 2)
+	exp := envFingerprint(types.NewEnv(baseCtx, myContract))
+
+	specs := map[string]struct {
+		srcCtx sdk.Context
+		expErr bool
+	}{
+		"same block and tx": {
+			srcCtx: baseCtx,
+		},
+		"time truncated to microseconds": {
+			srcCtx: baseCtx.WithBlockTime(myTime.Truncate(time.Microsecond)),
+			expErr: true,
+		},
+		"time rounded to milliseconds": {
+			srcCtx: baseCtx.WithBlockTime(myTime.Round(time.Millisecond)),
+			expErr: true,
+		},
+		"other height": {
+			srcCtx: baseCtx.WithBlockHeight(2),
+			expErr: true,
+		},
+		"other chain id": {
+			srcCtx: baseCtx.WithChainID("other"),
+			expErr: true,
+		},
+		"other tx index": {
+			srcCtx: types.WithTXCounter(baseCtx, 3),
+			expErr: true,
+		},
+		"without tx index": {
+			srcCtx: sdk.Context{}.WithBlockHeight(1).WithBlockTime(myTime).WithChainID("testing").WithContext(context.Background()),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := checkEnvFingerprint(exp, envFingerprint(types.NewEnv(spec.srcCtx, RandomAccountAddress(t))))
+			if spec.expErr {
+				require.ErrorIs(t, gotErr, types.ErrInvalid)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestEnvIdenticalAtEveryDepth(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	parent := SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+	child := SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+
+	var captured []wasmvmtypes.Env
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		captured = append(captured, env)
+		if env.Contract.Address == child.String() {
+			// nested query back to the parent
+			_, err := querier.Query(wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{ContractAddr: parent.String(), Msg: []byte(`{}`)}}}, gasLimit)
+			require.NoError(t, err)
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{{
+				ID:      1,
+				ReplyOn: wasmvmtypes.ReplyAlways,
+				Msg:     wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: child.String(), Msg: []byte(`{}`)}}},
+			}},
+		}}, 0, nil
+	}
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		captured = append(captured, env)
+		return &wasmvmtypes.QueryResult{Ok: []byte(`{}`)}, 0, nil
+	}
+	mock.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		captured = append(captured, env)
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	// nanosecond precision must survive all levels
+	ctx = types.WithTXCounter(ctx.WithBlockHeight(1).WithBlockTime(time.Unix(1619700924, 259075001)).WithChainID("testing"), 1)
+
+	// when
+	_, err := k.execute(ctx, parent, RandomAccountAddress(t), []byte(`{}`), nil)
+
+	// then root execute, sub execute, nested query and reply were called
+	require.NoError(t, err)
+	require.Len(t, captured, 4)
+	assert.Equal(t, []string{parent.String(), child.String(), parent.String(), parent.String()}, contractAddresses(captured))
+	exp, err := json.Marshal(captured[0].Block)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"height":1,"time":"1619700924259075001","chain_id":"testing"}`, string(exp))
+	for i, env := range captured {
+		got, err := json.Marshal(env.Block)
+		require.NoError(t, err)
+		assert.Equal(t, exp, got, "depth %d", i)
+		require.NotNil(t, env.Transaction, "depth %d", i)
+		assert.Equal(t, uint32(1), env.Transaction.Index, "depth %d", i)
+	}
+}
+
+func contractAddresses(envs []wasmvmtypes.Env) []string {
+	r := make([]string, len(envs))
+	for i, env := range envs {
+		r[i] = env.Contract.Address
+	}
+	return r
+}
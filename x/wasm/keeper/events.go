@@ -3,6 +3,7 @@ package keeper
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 
@@ -16,7 +17,7 @@ import (
 // newWasmModuleEvent creates with wasm module event for interacting with the given contract. Adds custom attributes
 // to this event.
 func newWasmModuleEvent(customAttributes []wasmvmtypes.EventAttribute, contractAddr sdk.AccAddress) (sdk.Events, error) {
-	attrs, err := contractSDKEventAttributes(customAttributes, contractAddr)
+	attrs, err := contractSDKEventAttributes(customAttributes, contractAddr, types.EventIndexResponseAttributes)
 	if err != nil {
 		return nil, err
 	}
@@ -30,12 +31,12 @@ const eventTypeMinLength = 2
 // newCustomEvents converts wasmvm events from a contract response to sdk type events
 func newCustomEvents(evts wasmvmtypes.Array[wasmvmtypes.Event], contractAddr sdk.AccAddress) (sdk.Events, error) {
 	events := make(sdk.Events, 0, len(evts))
-	for _, e := range evts {
+	for i, e := range evts {
 		errType := strings.TrimSpace(e.Type)
 		if len(errType) <= eventTypeMinLength {
 			return nil, errorsmod.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Event type too short: '%s'", errType))
 		}
-		attributes, err := contractSDKEventAttributes(e.Attributes, contractAddr)
+		attributes, err := contractSDKEventAttributes(e.Attributes, contractAddr, i)
 		if err != nil {
 			return nil, err
 		}
@@ -44,17 +45,31 @@ func newCustomEvents(evts wasmvmtypes.Array[wasmvmtypes.Event], contractAddr sdk
 	return events, nil
 }
 
-// convert and add contract address issuing this event
-func contractSDKEventAttributes(customAttributes []wasmvmtypes.EventAttribute, contractAddr sdk.AccAddress) ([]sdk.Attribute, error) {
+// convert and add contract address issuing this event.
+// Keys and values must be valid utf-8 and within the size limits after trimming. Invalid attributes are rejected
+// with an InvalidEventAttributeError for the event index, never truncated.
+func contractSDKEventAttributes(customAttributes []wasmvmtypes.EventAttribute, contractAddr sdk.AccAddress, eventIndex int) ([]sdk.Attribute, error) {
 	attrs := []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String())}
 	// append attributes from wasm to the sdk.Event
 	for _, l := range customAttributes {
+		if !utf8.ValidString(l.Key) {
+			return nil, types.InvalidEventAttributeError{EventIndex: eventIndex, Key: l.Key, Reason: types.EventAttributeInvalidUTF8Key}
+		}
+		if !utf8.ValidString(l.Value) {
+			return nil, types.InvalidEventAttributeError{EventIndex: eventIndex, Key: l.Key, Reason: types.EventAttributeInvalidUTF8Value}
+		}
 		// ensure key and value are non-empty (and trim what is there)
 		key := strings.TrimSpace(l.Key)
 		if len(key) == 0 {
 			return nil, errorsmod.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Empty attribute key. Value: %s", l.Value))
 		}
+		if len(key) > types.MaxEventAttributeKeySize {
+			return nil, types.InvalidEventAttributeError{EventIndex: eventIndex, Key: l.Key, Reason: types.EventAttributeKeyTooLong}
+		}
 		value := strings.TrimSpace(l.Value)
+		if len(value) > types.MaxEventAttributeValueSize {
+			return nil, types.InvalidEventAttributeError{EventIndex: eventIndex, Key: l.Key, Reason: types.EventAttributeValueTooLong}
+		}
 		// and reserve all _* keys for our use (not contract)
 		if strings.HasPrefix(key, types.AttributeReservedPrefix) {
 			return nil, errorsmod.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Attribute key starts with reserved prefix %s: '%s'", types.AttributeReservedPrefix, key))
@@ -2,10 +2,14 @@ package keeper
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -281,6 +285,111 @@ func TestNewWasmModuleEvent(t *testing.T) {
 	}
 }
 
+func TestEventAttributeValidation(t *testing.T) {
+	myContract := RandomAccountAddress(t)
+	// 3 byte utf-8 char, so that the limit is hit in the middle of it
+	multiByte := strings.Repeat("€", types.MaxEventAttributeValueSize/3+1)
+	specs := map[string]struct {
+		src    wasmvmtypes.EventAttribute
+		expErr *types.InvalidEventAttributeError
+	}{
+		"multi byte utf-8": {
+			src: wasmvmtypes.EventAttribute{Key: "€", Value: "ünïcödé"},
+		},
+		"max key size": {
+			src: wasmvmtypes.EventAttribute{Key: strings.Repeat("k", types.MaxEventAttributeKeySize), Value: "v"},
+		},
+		"max value size": {
+			src: wasmvmtypes.EventAttribute{Key: "k", Value: strings.Repeat("v", types.MaxEventAttributeValueSize)},
+		},
+		"max value size after trim": {
+			src: wasmvmtypes.EventAttribute{Key: "k", Value: " " + strings.Repeat("v", types.MaxEventAttributeValueSize) + " "},
+		},
+		"invalid utf-8 key": {
+			src:    wasmvmtypes.EventAttribute{Key: "k\xff", Value: "v"},
+			expErr: &types.InvalidEventAttributeError{Key: "k\xff", Reason: types.EventAttributeInvalidUTF8Key},
+		},
+		"invalid utf-8 value": {
+			src:    wasmvmtypes.EventAttribute{Key: "k", Value: "\xe2\x82"},
+			expErr: &types.InvalidEventAttributeError{Key: "k", Reason: types.EventAttributeInvalidUTF8Value},
+		},
+		"key too long": {
+			src:    wasmvmtypes.EventAttribute{Key: strings.Repeat("k", types.MaxEventAttributeKeySize+1), Value: "v"},
+			expErr: &types.InvalidEventAttributeError{Key: strings.Repeat("k", types.MaxEventAttributeKeySize+1), Reason: types.EventAttributeKeyTooLong},
+		},
+		"multi byte value too long": {
+			src:    wasmvmtypes.EventAttribute{Key: "k", Value: multiByte},
+			expErr: &types.InvalidEventAttributeError{Key: "k", Reason: types.EventAttributeValueTooLong},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			evts := wasmvmtypes.Array[wasmvmtypes.Event]{
+				{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{{Key: "a", Value: "b"}}},
+				{Type: "bar", Attributes: []wasmvmtypes.EventAttribute{{Key: "a", Value: "b"}, spec.src}},
+			}
+			// when
+			_, gotErr := newCustomEvents(evts, myContract)
+			_, gotModuleErr := newWasmModuleEvent([]wasmvmtypes.EventAttribute{spec.src}, myContract)
+
+			// then
+			if spec.expErr == nil {
+				require.NoError(t, gotErr)
+				require.NoError(t, gotModuleErr)
+				return
+			}
+			require.ErrorIs(t, gotErr, types.ErrInvalidEvent)
+			var attrErr types.InvalidEventAttributeError
+			require.True(t, errors.As(gotErr, &attrErr))
+			exp := *spec.expErr
+			exp.EventIndex = 1
+			assert.Equal(t, exp, attrErr)
+			// and for the response attributes
+			require.True(t, errors.As(gotModuleErr, &attrErr))
+			exp.EventIndex = types.EventIndexResponseAttributes
+			assert.Equal(t, exp, attrErr)
+		})
+	}
+}
+
+// FuzzContractSDKEventAttributes ensures that the accept or reject decision for an attribute depends on the bytes only:
+// an attribute is accepted when key and value are valid utf-8, the trimmed key is not empty and not reserved and
+// the trimmed key and value are within the size limits. Accepted attributes are trimmed but never truncated.
+func FuzzContractSDKEventAttributes(f *testing.F) {
+	myContract := RandomAccountAddress(f)
+	f.Add("key", "value")
+	f.Add("€", "\xe2\x82\xac")
+	f.Add("key", "\xe2\x82")
+	f.Add("\xff", "value")
+	f.Add("  ", "value")
+	f.Add("_reserved", "value")
+	f.Add(strings.Repeat("k", types.MaxEventAttributeKeySize+1), "value")
+	f.Fuzz(func(t *testing.T, key, value string) {
+		src := []wasmvmtypes.EventAttribute{{Key: key, Value: value}}
+		got, err := contractSDKEventAttributes(src, myContract, 0)
+		// the decision is deterministic
+		got2, err2 := contractSDKEventAttributes(src, myContract, 0)
+		require.Equal(t, got, got2)
+		if err != nil {
+			require.Error(t, err2)
+			require.Equal(t, err.Error(), err2.Error())
+		}
+
+		trimmedKey, trimmedValue := strings.TrimSpace(key), strings.TrimSpace(value)
+		expAccepted := utf8.ValidString(key) && utf8.ValidString(value) &&
+			trimmedKey != "" && !strings.HasPrefix(trimmedKey, types.AttributeReservedPrefix) &&
+			len(trimmedKey) <= types.MaxEventAttributeKeySize && len(trimmedValue) <= types.MaxEventAttributeValueSize
+		if !expAccepted {
+			require.ErrorIs(t, err, types.ErrInvalidEvent)
+			return
+		}
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, trimmedKey, got[1].Key)
+		assert.Equal(t, trimmedValue, got[1].Value)
+	})
+}
+
 // returns true when a wasm module event was emitted for this contract already
 func hasWasmModuleEvent(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
 	for _, e := range ctx.EventManager().Events() {
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"cosmossdk.io/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// nextCorrelationID returns a new id for the logs of a root contract call and all calls that follow within the
+// same execution. The id is node local and not part of the state.
+func (k Keeper) nextCorrelationID(ctx sdk.Context) string {
+	var seq uint64
+	if k.execLogSeq != nil {
+		seq = k.execLogSeq.Add(1)
+	}
+	return fmt.Sprintf("%d-%d", ctx.BlockHeight(), seq)
+}
+
+// execLogger returns the module logger with the correlation id and call depth of the current execution
+func execLogger(ctx sdk.Context) log.Logger {
+	id, _ := types.CorrelationIDFromContext(ctx)
+	depth, _ := types.CallDepth(ctx)
+	return moduleLogger(ctx).With("correlation_id", id, "depth", depth)
+}
+
+// logContractCall logs a contract call with the level of the node config. The msg is hashed as is when it is bytes
+// and json encoded otherwise.
+func (k Keeper) logContractCall(ctx sdk.Context, contractAddress sdk.AccAddress, codeID uint64, entryPoint string, msg any, vmGasUsed uint64, success bool) {
+	var logFn func(msg string, keyVals ...any)
+	switch k.execLogLevel {
+	case types.ExecutionLogLevelNone:
+		return
+	case types.ExecutionLogLevelInfo:
+		logFn = execLogger(ctx).Info
+	default:
+		logFn = execLogger(ctx).Debug
+	}
+	msgHash := sha256.Sum256(vmMsgBytes(msg))
+	logFn("contract call",
+		"contract", contractAddress.String(),
+		"code_id", codeID,
+		"entrypoint", entryPoint,
+		"msg_hash", hex.EncodeToString(msgHash[:]),
+		"gas_used", vmGasUsed,
+		"success", success,
+	)
+}
+
+// vmMsgBytes returns the msg as is when it is bytes and json encoded otherwise
+func vmMsgBytes(msg any) []byte {
+	if bz, ok := msg.([]byte); ok {
+		return bz
+	}
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err) // not expected for vm types
+	}
+	return bz
+}
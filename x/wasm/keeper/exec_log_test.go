@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestLogContractCall(t *testing.T) {
+	// run executes a contract that calls another contract with a submessage and returns the logged contract calls
+	run := func(t *testing.T, level string, logLevel zerolog.Level, times int) [][]map[string]any {
+		t.Helper()
+		vm := wasmtesting.NewMockWasmVM()
+		nodeConfig := types.DefaultNodeConfig()
+		nodeConfig.ExecutionLogLevel = level
+		parentCtx, keepers := createTestInputWithVM(t, false, AvailableCapabilities, nodeConfig, types.VMConfig{}, dbm.NewMemDB(), vm)
+		caller := SeedNewContractInstance(t, parentCtx, keepers, vm)
+		callee := SeedNewContractInstance(t, parentCtx, keepers, vm)
+		subMsg := wasmvmtypes.SubMsg{ID: 1, ReplyOn: wasmvmtypes.ReplyAlways, Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{
+			ContractAddr: callee.Contract.String(),
+			Msg:          []byte(`{"callee":{}}`),
+			Funds:        wasmvmtypes.Array[wasmvmtypes.Coin]{},
+		}}}}
+		vm.SetResponse(caller.Checksum, "execute", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{subMsg}}})
+		vm.SetResponse(caller.Checksum, "reply", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}})
+		vm.SetResponse(callee.Checksum, "execute", &wasmvmtypes.ContractResult{Err: "callee failed"})
+
+		var result [][]map[string]any
+		for range times {
+			var buf bytes.Buffer
+			ctx, _ := parentCtx.CacheContext()
+			ctx = ctx.WithLogger(log.NewLogger(&buf, log.OutputJSONOption(), log.LevelOption(logLevel)))
+			_, err := keepers.ContractKeeper.Execute(ctx, caller.Contract, caller.CreatorAddr, []byte(`{}`), nil)
+			require.NoError(t, err)
+			calls := []map[string]any{}
+			scanner := bufio.NewScanner(&buf)
+			for scanner.Scan() {
+				var line map[string]any
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+				if line["message"] == "contract call" {
+					calls = append(calls, line)
+				}
+			}
+			result = append(result, calls)
+		}
+		return result
+	}
+
+	// when
+	runs := run(t, "", zerolog.DebugLevel, 2)
+
+	// then all calls are logged with the correlation id of the root call
+	calls := runs[0]
+	require.Len(t, calls, 3)
+	rootID := calls[0]["correlation_id"]
+	assert.NotEmpty(t, rootID)
+	for _, c := range calls {
+		assert.Equal(t, rootID, c["correlation_id"])
+		assert.Equal(t, "x/wasm", c["module"])
+		assert.Equal(t, "debug", c["level"])
+	}
+	// and in order of execution with depth, entrypoint and result
+	msgHash := sha256.Sum256([]byte(`{}`))
+	assert.Equal(t, "execute", calls[0]["entrypoint"])
+	assert.Equal(t, float64(0), calls[0]["depth"])
+	assert.Equal(t, float64(1), calls[0]["code_id"])
+	assert.Equal(t, hex.EncodeToString(msgHash[:]), calls[0]["msg_hash"])
+	assert.Equal(t, true, calls[0]["success"])
+	assert.Equal(t, "execute", calls[1]["entrypoint"])
+	assert.Equal(t, float64(1), calls[1]["depth"])
+	assert.Equal(t, float64(2), calls[1]["code_id"])
+	assert.Equal(t, false, calls[1]["success"])
+	assert.Equal(t, calls[0]["contract"], calls[2]["contract"])
+	assert.Equal(t, "reply", calls[2]["entrypoint"])
+	assert.Equal(t, float64(0), calls[2]["depth"])
+	assert.Equal(t, true, calls[2]["success"])
+
+	// and a new root call gets a new correlation id
+	require.Len(t, runs[1], 3)
+	assert.NotEqual(t, rootID, runs[1][0]["correlation_id"])
+	// and nothing is logged at debug level without global debug logging
+	assert.Empty(t, run(t, "", zerolog.InfoLevel, 1)[0])
+	assert.Empty(t, run(t, types.ExecutionLogLevelDebug, zerolog.InfoLevel, 1)[0])
+	// unless the wasm execution log level is info
+	infoCalls := run(t, types.ExecutionLogLevelInfo, zerolog.InfoLevel, 1)[0]
+	require.Len(t, infoCalls, 3)
+	assert.Equal(t, "info", infoCalls[0]["level"])
+	// and none disables the logs
+	assert.Empty(t, run(t, types.ExecutionLogLevelNone, zerolog.DebugLevel, 1)[0])
+}
@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"encoding/hex"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// startExecTrace adds a new execution trace to the context of a top level contract call.
+// Nested calls share the trace of the top level call and get a nil trace. Nil is also returned when the trace is
+// disabled in the params.
+func (k Keeper) startExecTrace(ctx sdk.Context) (sdk.Context, *types.ExecTrace) {
+	if _, ok := types.ExecTraceFromContext(ctx); ok {
+		return ctx, nil
+	}
+	if k.GetParams(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())).DisableExecTraceHash {
+		return ctx, nil
+	}
+	trace := types.NewExecTrace()
+	return types.WithExecTrace(ctx, trace), trace
+}
+
+// traceVMCall adds a successful contract call to the execution trace of the context. Calls within submessages that
+// are reverted later are included. The msg is hashed as is when it is bytes and json encoded otherwise.
+func (k Keeper) traceVMCall(ctx sdk.Context, entryPoint string, msg any, res *wasmvmtypes.Response) {
+	trace, ok := types.ExecTraceFromContext(ctx)
+	if !ok || res == nil {
+		return
+	}
+	trace.Add(entryPoint, vmMsgBytes(msg), res.Data, len(res.Events), len(res.Messages))
+}
+
+// emitExecTrace emits the hash of a trace that was returned by startExecTrace. Nil traces are ignored.
+func (k Keeper) emitExecTrace(ctx sdk.Context, contractAddress sdk.AccAddress, trace *types.ExecTrace) {
+	if trace == nil {
+		return
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeExecTrace,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyExecTraceHash, hex.EncodeToString(trace.Hash())),
+	))
+}
@@ -0,0 +1,116 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestExecTraceHash(t *testing.T) {
+	vm := wasmtesting.NewMockWasmVM()
+	parentCtx, keepers := CreateTestInputWithVM(t, false, AvailableCapabilities, vm)
+	caller := SeedNewContractInstance(t, parentCtx, keepers, vm)
+	callee := SeedNewContractInstance(t, parentCtx, keepers, vm)
+	subMsg := wasmvmtypes.SubMsg{ID: 1, ReplyOn: wasmvmtypes.ReplyAlways, Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{
+		ContractAddr: callee.Contract.String(),
+		Msg:          []byte(`{"callee":{}}`),
+		Funds:        wasmvmtypes.Array[wasmvmtypes.Coin]{},
+	}}}}
+	vm.SetResponse(caller.Checksum, "execute", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+		Messages: []wasmvmtypes.SubMsg{subMsg},
+		Events:   []wasmvmtypes.Event{{Type: "custom", Attributes: []wasmvmtypes.EventAttribute{{Key: "foo", Value: "bar"}}}},
+	}})
+	vm.SetResponse(caller.Checksum, "reply", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Data: []byte("reply data")}})
+	run := func(t *testing.T, calleeData string, disabled bool) []sdk.Event {
+		t.Helper()
+		ctx, _ := parentCtx.CacheContext()
+		em := sdk.NewEventManager()
+		ctx = ctx.WithEventManager(em)
+		params := keepers.WasmKeeper.GetParams(ctx)
+		params.DisableExecTraceHash = disabled
+		require.NoError(t, keepers.WasmKeeper.SetParams(ctx, params))
+		vm.SetResponse(callee.Checksum, "execute", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Data: []byte(calleeData)}})
+
+		_, err := keepers.ContractKeeper.Execute(ctx, caller.Contract, caller.CreatorAddr, []byte(`{}`), nil)
+		require.NoError(t, err)
+		var traces []sdk.Event
+		for _, e := range em.Events() {
+			if e.Type == types.EventTypeExecTrace {
+				traces = append(traces, e)
+			}
+		}
+		return traces
+	}
+
+	// when
+	first := run(t, "callee data", false)
+
+	// then only the top level call emits the trace
+	require.Len(t, first, 1)
+	assert.Equal(t, caller.Contract.String(), attrValue(first[0], types.AttributeKeyContractAddr))
+	// and all calls are included in order
+	replies := vm.CallsOf("reply")
+	require.Len(t, replies, 1)
+	exp := types.NewExecTrace()
+	exp.Add("execute", []byte(`{}`), nil, 1, 1)
+	exp.Add("execute", []byte(`{"callee":{}}`), []byte("callee data"), 0, 0)
+	exp.Add("reply", replies[0].Msg, []byte("reply data"), 0, 0)
+	assert.Equal(t, hex.EncodeToString(exp.Hash()), attrValue(first[0], types.AttributeKeyExecTraceHash))
+
+	// and a repeated run has the same hash
+	assert.Equal(t, first, run(t, "callee data", false))
+	// and a different contract output a different hash
+	other := run(t, "other data", false)
+	require.Len(t, other, 1)
+	assert.NotEqual(t, attrValue(first[0], types.AttributeKeyExecTraceHash), attrValue(other[0], types.AttributeKeyExecTraceHash))
+	// and disabled trace emits nothing
+	assert.Empty(t, run(t, "callee data", true))
+}
+
+func TestExecTraceAdd(t *testing.T) {
+	base := execTraceHash("execute", []byte(`{}`), []byte("data"), 1, 2)
+	specs := map[string]struct {
+		entryPoint  string
+		msg, data   []byte
+		events      int
+		subMsgCount int
+	}{
+		"entry point":  {entryPoint: "sudo", msg: []byte(`{}`), data: []byte("data"), events: 1, subMsgCount: 2},
+		"msg":          {entryPoint: "execute", msg: []byte(`{"a":1}`), data: []byte("data"), events: 1, subMsgCount: 2},
+		"data":         {entryPoint: "execute", msg: []byte(`{}`), data: []byte("other"), events: 1, subMsgCount: 2},
+		"event count":  {entryPoint: "execute", msg: []byte(`{}`), data: []byte("data"), events: 2, subMsgCount: 2},
+		"submsg count": {entryPoint: "execute", msg: []byte(`{}`), data: []byte("data"), events: 1, subMsgCount: 1},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := execTraceHash(spec.entryPoint, spec.msg, spec.data, spec.events, spec.subMsgCount)
+			assert.NotEqual(t, base, got)
+		})
+	}
+	// and the same input has the same hash
+	assert.Equal(t, base, execTraceHash("execute", []byte(`{}`), []byte("data"), 1, 2))
+}
+
+// execTraceHash returns the hex encoded trace hash of a single contract call
+func execTraceHash(entryPoint string, msg, data []byte, eventCount, subMsgCount int) string {
+	trace := types.NewExecTrace()
+	trace.Add(entryPoint, msg, data, eventCount, subMsgCount)
+	return hex.EncodeToString(trace.Hash())
+}
+
+func attrValue(e sdk.Event, key string) string {
+	for _, a := range e.Attributes {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return ""
+}
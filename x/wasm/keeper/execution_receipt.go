@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// executionReceiptLen is the size of a stored receipt: height, gas used, kind and success flag
+const executionReceiptLen = 8 + 8 + 1 + 1
+
+// recordExecutionReceipt overwrites the receipt of the last execution of the contract with the result of this call.
+// The write is charged to the caller. A failed call is only kept when the state of the call is not reverted,
+// for example on a failed wasm submessage that the caller handles with a reply. This is a noop unless execution
+// receipts are enabled in the params that were loaded for the call.
+func (k Keeper) recordExecutionReceipt(ctx sdk.Context, params types.Params, contractAddress sdk.AccAddress, kind types.ExecutionReceiptKind, vmGasUsed uint64, success bool) {
+	if !params.EnableExecutionReceipts {
+		return
+	}
+	k.mustStoreExecutionReceipt(ctx, contractAddress, types.ExecutionReceipt{
+		Height:  ctx.BlockHeight(),
+		Kind:    kind,
+		GasUsed: k.gasRegister.FromWasmVMGas(vmGasUsed),
+		Success: success,
+	})
+}
+
+// keepFailedExecutionReceipt copies the receipt of a failed call from the discarded state of an isolated call into
+// the state of the caller, so that the failure remains visible. Both the read and the write are charged to the caller.
+func (k Keeper) keepFailedExecutionReceipt(ctx, subCtx sdk.Context, contractAddress sdk.AccAddress) {
+	// the receipt only exists in the discarded state when receipts are enabled
+	receipt := k.GetExecutionReceipt(subCtx.WithGasMeter(ctx.GasMeter()), contractAddress)
+	if receipt == nil || receipt.Success || receipt.Height != ctx.BlockHeight() {
+		return
+	}
+	k.mustStoreExecutionReceipt(ctx, contractAddress, *receipt)
+}
+
+// GetExecutionReceipt returns the receipt of the last execution of the contract or nil when none was recorded
+func (k Keeper) GetExecutionReceipt(ctx context.Context, contractAddress sdk.AccAddress) *types.ExecutionReceipt {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetExecutionReceiptKey(contractAddress))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) != executionReceiptLen {
+		return nil
+	}
+	return &types.ExecutionReceipt{
+		Height:  int64(binary.BigEndian.Uint64(bz[0:8])),
+		GasUsed: binary.BigEndian.Uint64(bz[8:16]),
+		Kind:    types.ExecutionReceiptKind(bz[16]),
+		Success: bz[17] == 1,
+	}
+}
+
+// mustStoreExecutionReceipt stores the receipt in a fixed size encoding
+func (k Keeper) mustStoreExecutionReceipt(ctx context.Context, contractAddress sdk.AccAddress, receipt types.ExecutionReceipt) {
+	bz := make([]byte, executionReceiptLen)
+	binary.BigEndian.PutUint64(bz[0:8], uint64(receipt.Height))
+	binary.BigEndian.PutUint64(bz[8:16], receipt.GasUsed)
+	bz[16] = byte(receipt.Kind)
+	if receipt.Success {
+		bz[17] = 1
+	}
+	if err := k.storeService.OpenKVStore(ctx).Set(types.GetExecutionReceiptKey(contractAddress), bz); err != nil {
+		panic(err)
+	}
+}
+
+func (k Keeper) importExecutionReceipt(ctx context.Context, contractAddress sdk.AccAddress, receipt *types.ExecutionReceipt) error {
+	if receipt == nil {
+		return nil
+	}
+	if err := receipt.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "execution receipt")
+	}
+	k.mustStoreExecutionReceipt(ctx, contractAddress, *receipt)
+	return nil
+}
@@ -0,0 +1,255 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRecordExecutionReceipt(t *testing.T) {
+	const myVMGasUsed = 3 * types.DefaultGasMultiplier
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	var fail bool
+	result := func() (*wasmvmtypes.ContractResult, uint64, error) {
+		if fail {
+			return &wasmvmtypes.ContractResult{Err: "testing"}, myVMGasUsed, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, myVMGasUsed, nil
+	}
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return result()
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return result()
+	}
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return result()
+	}
+	mock.MigrateWithInfoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, migrateInfo wasmvmtypes.MigrateInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return mock.MigrateFn(codeID, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+	}
+	execute := func(ctx sdk.Context, k *Keeper, example ExampleContractInstance) error {
+		_, err := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+		return err
+	}
+
+	specs := map[string]struct {
+		disabled bool
+		fail     bool
+		call     func(ctx sdk.Context, k *Keeper, example ExampleContractInstance) error
+		expKind  types.ExecutionReceiptKind
+	}{
+		"execute": {
+			call:    execute,
+			expKind: types.ExecutionReceiptKindExecute,
+		},
+		"execute failed": {
+			fail:    true,
+			call:    execute,
+			expKind: types.ExecutionReceiptKindExecute,
+		},
+		"sudo": {
+			call: func(ctx sdk.Context, k *Keeper, example ExampleContractInstance) error {
+				_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+				return err
+			},
+			expKind: types.ExecutionReceiptKindSudo,
+		},
+		"migrate failed": {
+			fail: true,
+			call: func(ctx sdk.Context, k *Keeper, example ExampleContractInstance) error {
+				_, err := k.migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{}`), DefaultAuthorizationPolicy{})
+				return err
+			},
+			expKind: types.ExecutionReceiptKindMigrate,
+		},
+		"disabled": {
+			disabled: true,
+			call:     execute,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+			k := keepers.WasmKeeper
+			params := types.DefaultParams()
+			params.EnableExecutionReceipts = !spec.disabled
+			require.NoError(t, k.SetParams(ctx, params))
+			fail = false
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			fail = spec.fail
+			ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+
+			// when
+			gotErr := spec.call(ctx, k, example)
+
+			// then
+			if spec.fail {
+				require.Error(t, gotErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			got := k.GetExecutionReceipt(ctx, example.Contract)
+			if spec.disabled {
+				assert.Nil(t, got)
+				return
+			}
+			exp := &types.ExecutionReceipt{
+				Height:  ctx.BlockHeight(),
+				Kind:    spec.expKind,
+				GasUsed: 3,
+				Success: !spec.fail,
+			}
+			assert.Equal(t, exp, got)
+		})
+	}
+}
+
+func TestFailedSubmessageKeepsExecutionReceipt(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	params := types.DefaultParams()
+	params.EnableExecutionReceipts = true
+	require.NoError(t, k.SetParams(ctx, params))
+	caller := SeedNewContractInstance(t, ctx, keepers, &mock)
+	callee := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		if env.Contract.Address == callee.Contract.String() {
+			return &wasmvmtypes.ContractResult{Err: "testing"}, 0, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{{
+				ID:      1,
+				ReplyOn: wasmvmtypes.ReplyError,
+				Msg:     wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: callee.Contract.String(), Msg: []byte(`{}`), Funds: wasmvmtypes.Array[wasmvmtypes.Coin]{}}}},
+			}},
+		}}, 0, nil
+	}
+	mock.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+
+	// when
+	_, err := k.execute(ctx, caller.Contract, caller.CreatorAddr, []byte(`{}`), nil)
+
+	// then
+	require.NoError(t, err)
+	got := k.GetExecutionReceipt(ctx, callee.Contract)
+	require.NotNil(t, got)
+	assert.Equal(t, types.ExecutionReceipt{Height: ctx.BlockHeight(), Kind: types.ExecutionReceiptKindExecute}, *got)
+	got = k.GetExecutionReceipt(ctx, caller.Contract)
+	require.NotNil(t, got)
+	assert.True(t, got.Success)
+}
+
+func TestRecordExecutionReceiptConsumesGas(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	gasUsed := func(enabled bool) uint64 {
+		params := types.DefaultParams()
+		params.EnableExecutionReceipts = enabled
+		require.NoError(t, k.SetParams(ctx, params))
+		before := ctx.GasMeter().GasConsumed()
+		_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+		require.NoError(t, err)
+		return ctx.GasMeter().GasConsumed() - before
+	}
+	// the receipt write is charged to the caller
+	assert.Greater(t, gasUsed(true), gasUsed(false))
+}
+
+func TestQueryContractExecutionReceipt(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherExample := SeedNewContractInstance(t, ctx, keepers, &mock)
+	myReceipt := types.ExecutionReceipt{Height: 1, Kind: types.ExecutionReceiptKindIBC, GasUsed: 2, Success: true}
+	keepers.WasmKeeper.mustStoreExecutionReceipt(ctx, example.Contract, myReceipt)
+	q := Querier(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		src     *types.QueryContractExecutionReceiptRequest
+		exp     *types.QueryContractExecutionReceiptResponse
+		expCode codes.Code
+		expErr  bool
+	}{
+		"query": {
+			src: &types.QueryContractExecutionReceiptRequest{Address: example.Contract.String()},
+			exp: &types.QueryContractExecutionReceiptResponse{Receipt: myReceipt},
+		},
+		"no receipt": {
+			src:     &types.QueryContractExecutionReceiptRequest{Address: otherExample.Contract.String()},
+			expErr:  true,
+			expCode: codes.NotFound,
+		},
+		"unknown contract": {
+			src:    &types.QueryContractExecutionReceiptRequest{Address: RandomBech32AccountAddress(t)},
+			expErr: true,
+		},
+		"invalid address": {
+			src:    &types.QueryContractExecutionReceiptRequest{Address: "foo"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ContractExecutionReceipt(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				if spec.expCode != codes.OK {
+					assert.Equal(t, spec.expCode, status.Code(gotErr))
+				}
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestExecutionReceiptGenesis(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	withInMemoryCodeStore(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	exp := &types.ExecutionReceipt{Height: 7, Kind: types.ExecutionReceiptKindSudo, GasUsed: 100}
+	k.mustStoreExecutionReceipt(ctx, example.Contract, *exp)
+
+	// when
+	genState := ExportGenesis(ctx, k)
+	// then
+	require.Len(t, genState.Contracts, 1)
+	assert.Equal(t, exp, genState.Contracts[0].ExecutionReceipt)
+
+	// when imported again
+	importCtx, importKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	importKeepers.WasmKeeper.wasmVM = &mock
+	_, err := InitGenesis(importCtx, importKeepers.WasmKeeper, *genState)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, exp, importKeepers.WasmKeeper.GetExecutionReceipt(importCtx, example.Contract))
+}
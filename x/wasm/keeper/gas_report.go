@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"math"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// vmGasReport collects the gas usage of a single wasmvm call. It is only created in simulation mode
+// to give contract developers a breakdown of the gas consumed by the VM (instructions) and by the
+// host (storage, queries, api calls). All values are in sdk gas units.
+type vmGasReport struct {
+	contractAddr sdk.AccAddress
+	entryPoint   string
+	// limit is the gas available for the VM call, math.MaxUint64 for an infinite gas meter
+	limit uint64
+	// consumedBefore is the gas consumed by the sdk gas meter before the VM call
+	consumedBefore uint64
+}
+
+// newVMGasReport starts a report for the VM call. Returns nil when not in simulation mode.
+func (k Keeper) newVMGasReport(ctx sdk.Context, contractAddr sdk.AccAddress, entryPoint string, vmGasLimit uint64) *vmGasReport {
+	if ctx.ExecMode() != sdk.ExecModeSimulate {
+		return nil
+	}
+	limit := vmGasLimit
+	if limit != math.MaxUint64 {
+		limit = k.gasRegister.FromWasmVMGas(vmGasLimit)
+	}
+	return &vmGasReport{
+		contractAddr:   contractAddr,
+		entryPoint:     entryPoint,
+		limit:          limit,
+		consumedBefore: ctx.GasMeter().GasConsumed(),
+	}
+}
+
+// emit adds the report as event to the context. Must be called after the VM gas was consumed.
+// Emitting events does not consume gas so that the simulation result is not modified.
+func (r *vmGasReport) emit(ctx sdk.Context, gasRegister types.GasRegister, vmGasUsed uint64) {
+	if r == nil {
+		return
+	}
+	usedInternally := gasRegister.FromWasmVMGas(vmGasUsed)
+	var usedExternally uint64
+	if total := ctx.GasMeter().GasConsumed() - r.consumedBefore; total > usedInternally {
+		usedExternally = total - usedInternally
+	}
+	var remaining uint64
+	if used := usedInternally + usedExternally; r.limit > used {
+		remaining = r.limit - used
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeGasReport,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, r.contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyEntryPoint, r.entryPoint),
+		sdk.NewAttribute(types.AttributeKeyGasLimit, strconv.FormatUint(r.limit, 10)),
+		sdk.NewAttribute(types.AttributeKeyGasRemaining, strconv.FormatUint(remaining, 10)),
+		sdk.NewAttribute(types.AttributeKeyGasUsedExternally, strconv.FormatUint(usedExternally, 10)),
+		sdk.NewAttribute(types.AttributeKeyGasUsedInternally, strconv.FormatUint(usedInternally, 10)),
+	))
+}
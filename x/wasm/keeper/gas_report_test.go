@@ -0,0 +1,147 @@
+package keeper
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestGasReportInSimulation(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+
+	specs := map[string]struct {
+		execMode  sdk.ExecMode
+		expReport bool
+	}{
+		"simulation": {
+			execMode:  sdk.ExecModeSimulate,
+			expReport: true,
+		},
+		"finalize": {
+			execMode: sdk.ExecModeFinalize,
+		},
+		"check tx": {
+			execMode: sdk.ExecModeCheck,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			const gasLimit = 1_000_000
+			em := sdk.NewEventManager()
+			ctx, _ := parentCtx.CacheContext()
+			ctx = ctx.WithExecMode(spec.execMode).WithEventManager(em).WithGasMeter(storetypes.NewGasMeter(gasLimit))
+
+			// when release is executed, the contract loads its state and does some computation
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+			require.NoError(t, err)
+			totalGas := ctx.GasMeter().GasConsumed()
+
+			// then
+			var reports []map[string]string
+			for _, e := range em.Events() {
+				if e.Type != types.EventTypeGasReport {
+					continue
+				}
+				attrs := make(map[string]string, len(e.Attributes))
+				for _, a := range e.Attributes {
+					attrs[a.Key] = a.Value
+				}
+				reports = append(reports, attrs)
+			}
+			if !spec.expReport {
+				assert.Empty(t, reports)
+				return
+			}
+			require.Len(t, reports, 1)
+			report := reports[0]
+			assert.Equal(t, example.Contract.String(), report[types.AttributeKeyContractAddr])
+			assert.Equal(t, "execute", report[types.AttributeKeyEntryPoint])
+
+			limit := mustParseUint(t, report[types.AttributeKeyGasLimit])
+			remaining := mustParseUint(t, report[types.AttributeKeyGasRemaining])
+			usedInternally := mustParseUint(t, report[types.AttributeKeyGasUsedInternally])
+			usedExternally := mustParseUint(t, report[types.AttributeKeyGasUsedExternally])
+			// compute and storage work both reported
+			assert.NotZero(t, usedInternally)
+			assert.NotZero(t, usedExternally)
+			assert.Equal(t, limit, remaining+usedInternally+usedExternally)
+			// costs outside the VM (setup, bank send, events) are not part of the report
+			setupCosts := keepers.WasmKeeper.gasRegister.SetupContractCost(false, len(`{"release":{}}`))
+			assert.Less(t, usedInternally+usedExternally, totalGas-setupCosts)
+		})
+	}
+}
+
+func TestGasReportBreakdown(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+
+	const vmComputeGas = 50_000_000_000 // in CosmWasm gas units
+	var vmStorageGas uint64
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		// storage work is charged by the host
+		before := gasMeter.GasConsumed()
+		store.Set([]byte("foo"), bytes.Repeat([]byte{1}, 100))
+		_ = store.Get([]byte("foo"))
+		vmStorageGas = gasMeter.GasConsumed() - before
+		// compute work is reported by the VM
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, vmComputeGas, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	em := sdk.NewEventManager()
+	ctx = ctx.WithExecMode(sdk.ExecModeSimulate).WithEventManager(em).WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+	// when
+	msg := []byte(`{}`)
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, msg, nil)
+	require.NoError(t, err)
+	totalGas := ctx.GasMeter().GasConsumed()
+
+	// then
+	require.NotZero(t, vmStorageGas)
+	var report map[string]string
+	for _, e := range em.Events() {
+		if e.Type == types.EventTypeGasReport {
+			require.Nil(t, report, "only one report expected")
+			report = make(map[string]string, len(e.Attributes))
+			for _, a := range e.Attributes {
+				report[a.Key] = a.Value
+			}
+		}
+	}
+	require.NotNil(t, report)
+	usedInternally := mustParseUint(t, report[types.AttributeKeyGasUsedInternally])
+	usedExternally := mustParseUint(t, report[types.AttributeKeyGasUsedExternally])
+	assert.Equal(t, k.gasRegister.FromWasmVMGas(vmComputeGas), usedInternally)
+	assert.Equal(t, k.gasRegister.FromWasmVMGas(vmStorageGas), usedExternally)
+	assert.Equal(t, strconv.FormatUint(math.MaxUint64, 10), report[types.AttributeKeyGasLimit])
+
+	// and the breakdown sums up to the total gas without setup and contract loading costs
+	setupCosts := k.gasRegister.SetupContractCost(false, len(msg))
+	assert.Less(t, usedInternally+usedExternally, totalGas-setupCosts)
+	assert.InEpsilon(t, totalGas-setupCosts, usedInternally+usedExternally, 0.05)
+}
+
+func mustParseUint(t *testing.T, s string) uint64 {
+	t.Helper()
+	v, err := strconv.ParseUint(s, 10, 64)
+	require.NoError(t, err)
+	return v
+}
@@ -33,6 +33,12 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		if err != nil {
 			return nil, errorsmod.Wrapf(err, "code %d with id: %d", i, code.CodeID)
 		}
+		if err := keeper.importCodeAttestations(ctx, code.CodeID, code.Attestations); err != nil {
+			return nil, errorsmod.Wrapf(err, "code %d with id: %d", i, code.CodeID)
+		}
+		if err := keeper.importCodeVerificationVotes(ctx, code.CodeID, code.VerificationVotes); err != nil {
+			return nil, errorsmod.Wrapf(err, "code %d with id: %d", i, code.CodeID)
+		}
 		if code.CodeID > maxCodeID {
 			maxCodeID = code.CodeID
 		}
@@ -52,6 +58,41 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		if err != nil {
 			return nil, errorsmod.Wrapf(err, "contract number %d", i)
 		}
+		if err := keeper.importQueryAliases(ctx, contractAddr, contract.QueryAliases); err != nil {
+			return nil, errorsmod.Wrapf(err, "contract number %d", i)
+		}
+		if err := keeper.importIBCSendTimeout(ctx, contractAddr, contract.IBCSendTimeout); err != nil {
+			return nil, errorsmod.Wrapf(err, "contract number %d", i)
+		}
+		if err := keeper.importAdminChangeNotification(ctx, contractAddr, contract.NotifyAdminChange); err != nil {
+			return nil, errorsmod.Wrapf(err, "contract number %d", i)
+		}
+		if err := keeper.importContractUsage(ctx, contractAddr, contract.Usage); err != nil {
+			return nil, errorsmod.Wrapf(err, "contract number %d", i)
+		}
+		if err := keeper.importExecutionReceipt(ctx, contractAddr, contract.ExecutionReceipt); err != nil {
+			return nil, errorsmod.Wrapf(err, "contract number %d", i)
+		}
+		if err := keeper.importIgnoreReplyError(ctx, contractAddr, contract.IgnoreReplyError); err != nil {
+			return nil, errorsmod.Wrapf(err, "contract number %d", i)
+		}
+	}
+	// relations are imported after all contracts so that the parent order does not matter
+	for i, contract := range data.Contracts {
+		if contract.ParentAddress == "" {
+			continue
+		}
+		parentAddr, err := sdk.AccAddressFromBech32(contract.ParentAddress)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "parent address in contract number %d", i)
+		}
+		if !keeper.HasContractInfo(ctx, parentAddr) {
+			return nil, types.ErrNotFound.Wrapf("parent contract %s of contract number %d", contract.ParentAddress, i)
+		}
+		contractAddr := sdk.MustAccAddressFromBech32(contract.ContractAddress)
+		if err := keeper.storeContractRelation(ctx, parentAddr, contractAddr); err != nil {
+			return nil, errorsmod.Wrapf(err, "contract number %d", i)
+		}
 	}
 
 	for i, seq := range data.Sequences {
@@ -61,6 +102,28 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		}
 	}
 
+	for i, approval := range data.CodeUploadApprovals {
+		if err := keeper.storeCodeUploadApproval(ctx, approval); err != nil {
+			return nil, errorsmod.Wrapf(err, "code upload approval number %d", i)
+		}
+	}
+
+	maxScheduledSudoID, err := keeper.importScheduledSudos(ctx, data.ScheduledSudos)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := keeper.importParamsHistory(ctx, data.ParamsHistory); err != nil {
+		return nil, err
+	}
+
+	// the counters were maintained by the imports above
+	if data.Stats != nil {
+		if stats := keeper.GetWasmStats(ctx); !stats.Equal(data.Stats) {
+			return nil, errorsmod.Wrapf(types.ErrInvalid, "stats %s do not match imported state %s", data.Stats, &stats)
+		}
+	}
+
 	// sanity check seq values
 	seqVal, err := keeper.PeekAutoIncrementID(ctx, types.KeySequenceCodeID)
 	if err != nil {
@@ -69,6 +132,13 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 	if seqVal <= maxCodeID {
 		return nil, errorsmod.Wrapf(types.ErrInvalid, "seq %s with value: %d must be greater than: %d ", string(types.KeySequenceCodeID), seqVal, maxCodeID)
 	}
+	seqVal, err = keeper.PeekAutoIncrementID(ctx, types.KeySequenceScheduledSudoID)
+	if err != nil {
+		return nil, err
+	}
+	if seqVal <= maxScheduledSudoID {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "seq %s with value: %d must be greater than: %d ", string(types.KeySequenceScheduledSudoID), seqVal, maxScheduledSudoID)
+	}
 	// ensure next classic address is unused so that we know the sequence is good
 	rCtx, _ := ctx.CacheContext()
 	seqVal, err = keeper.PeekAutoIncrementID(rCtx, types.KeySequenceInstanceID)
@@ -93,11 +163,24 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 		if err != nil {
 			panic(err)
 		}
+		var attestations []types.CodeAttestation
+		keeper.IterateCodeAttestations(ctx, codeID, func(a types.CodeAttestation) bool {
+			attestations = append(attestations, a)
+			return false
+		})
+		var votes []types.CodeVerificationVote
+		keeper.IterateCodeVerificationVotes(ctx, codeID, func(v types.CodeVerificationVote) bool {
+			votes = append(votes, v)
+			return false
+		})
+
 		genState.Codes = append(genState.Codes, types.Code{
-			CodeID:    codeID,
-			CodeInfo:  info,
-			CodeBytes: bytecode,
-			Pinned:    keeper.IsPinnedCode(ctx, codeID),
+			CodeID:            codeID,
+			CodeInfo:          info,
+			CodeBytes:         bytecode,
+			Pinned:            keeper.IsPinnedCode(ctx, codeID),
+			Attestations:      attestations,
+			VerificationVotes: votes,
 		})
 		return false
 	})
@@ -111,16 +194,40 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 
 		contractCodeHistory := keeper.GetContractHistory(ctx, addr)
 
+		var queryAliases []types.QueryAlias
+		keeper.IterateQueryAliases(ctx, addr, func(a types.QueryAlias) bool {
+			queryAliases = append(queryAliases, a)
+			return false
+		})
+
+		var usage []types.EntryPointUsage
+		keeper.IterateContractUsage(ctx, addr, func(u types.EntryPointUsage) bool {
+			usage = append(usage, u)
+			return false
+		})
+
+		var parentAddress string
+		if parent := keeper.GetContractParent(ctx, addr); parent != nil {
+			parentAddress = parent.String()
+		}
+
 		genState.Contracts = append(genState.Contracts, types.Contract{
 			ContractAddress:     addr.String(),
 			ContractInfo:        contract,
 			ContractState:       state,
 			ContractCodeHistory: contractCodeHistory,
+			ParentAddress:       parentAddress,
+			QueryAliases:        queryAliases,
+			IBCSendTimeout:      keeper.GetIBCSendTimeout(ctx, addr),
+			Usage:               usage,
+			NotifyAdminChange:   keeper.HasAdminChangeNotification(ctx, addr),
+			ExecutionReceipt:    keeper.GetExecutionReceipt(ctx, addr),
+			IgnoreReplyError:    keeper.HasIgnoreReplyError(ctx, addr),
 		})
 		return false
 	})
 
-	for _, k := range [][]byte{types.KeySequenceCodeID, types.KeySequenceInstanceID} {
+	for _, k := range [][]byte{types.KeySequenceCodeID, types.KeySequenceInstanceID, types.KeySequenceScheduledSudoID} {
 		id, err := keeper.PeekAutoIncrementID(ctx, k)
 		if err != nil {
 			panic(err)
@@ -131,5 +238,23 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 		})
 	}
 
+	keeper.IterateCodeUploadApprovals(ctx, func(approval types.CodeUploadApproval) bool {
+		genState.CodeUploadApprovals = append(genState.CodeUploadApprovals, approval)
+		return false
+	})
+
+	keeper.IterateScheduledSudos(ctx, func(s types.ScheduledSudo) bool {
+		genState.ScheduledSudos = append(genState.ScheduledSudos, s)
+		return false
+	})
+
+	keeper.IterateParamsHistory(ctx, func(c types.ParamsChange) bool {
+		genState.ParamsHistory = append(genState.ParamsHistory, c)
+		return false
+	})
+
+	stats := keeper.GetWasmStats(ctx)
+	genState.Stats = &stats
+
 	return &genState
 }
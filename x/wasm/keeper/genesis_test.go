@@ -1,11 +1,13 @@
 package keeper
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
 	"math/rand"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -48,6 +50,10 @@ func TestGenesisExportImport(t *testing.T) {
 
 	err = wasmKeeper.SetParams(srcCtx, types.DefaultParams())
 	require.NoError(t, err)
+	changedParams := types.DefaultParams()
+	changedParams.MaxResponseEvents = 10
+	err = wasmKeeper.updateParams(srcCtx.WithBlockHeight(2), wasmKeeper.GetAuthority(), changedParams)
+	require.NoError(t, err)
 
 	for i := 0; i < 25; i++ {
 		var (
@@ -69,6 +75,8 @@ func TestGenesisExportImport(t *testing.T) {
 		require.NoError(t, err)
 		codeID, _, err := contractKeeper.Create(srcCtx, creatorAddr, wasmCode, &codeInfo.InstantiateConfig)
 		require.NoError(t, err)
+		err = wasmKeeper.attestCode(srcCtx, codeID, creatorAddr, "https://github.com/CosmWasm/cosmwasm", strings.Repeat("a", 40), "cosmwasm/optimizer:0.16.0")
+		require.NoError(t, err)
 		if pinned {
 			err = contractKeeper.PinCode(srcCtx, codeID)
 			require.NoError(t, err)
@@ -85,11 +93,32 @@ func TestGenesisExportImport(t *testing.T) {
 
 		contract.CodeID = codeID
 		contractAddr := wasmKeeper.ClassicAddressGenerator()(srcCtx, codeID, nil)
+		if i == 0 {
+			contract.SunsetHeight = 100
+			require.NoError(t, wasmKeeper.addToContractSunsetIndex(srcCtx, contract.SunsetHeight, contractAddr))
+			contract.PendingAdmin = RandomBech32AccountAddress(t)
+			contract.Suspended = true
+		}
 		wasmKeeper.mustStoreContractInfo(srcCtx, contractAddr, &contract)
+		wasmKeeper.incrementContractCount(srcCtx)
+		if i == 0 {
+			scheduled := types.ScheduledSudo{
+				ID:       wasmKeeper.mustAutoIncrementID(srcCtx, types.KeySequenceScheduledSudoID),
+				Contract: contractAddr.String(),
+				Height:   100,
+				Msg:      []byte(`{}`),
+				GasLimit: 100_000,
+			}
+			require.NoError(t, wasmKeeper.storeScheduledSudo(srcCtx, contractAddr, scheduled))
+		}
 		require.NoError(t, wasmKeeper.appendToContractHistory(srcCtx, contractAddr, history...))
 		err = wasmKeeper.importContractState(srcCtx, contractAddr, stateModels)
 		require.NoError(t, err)
 	}
+	for i := 0; i < 3; i++ {
+		approval := types.CodeUploadApproval{Checksum: bytes.Repeat([]byte{byte(i + 1)}, sha256.Size), ConsumeOnce: i%2 == 0}
+		require.NoError(t, wasmKeeper.addCodeUploadApproval(srcCtx, approval))
+	}
 	var wasmParams types.Params
 	f.NilChance(0).Fuzz(&wasmParams)
 	err = wasmKeeper.SetParams(srcCtx, wasmParams)
@@ -122,6 +151,12 @@ func TestGenesisExportImport(t *testing.T) {
 		require.NoError(t, err)
 		err = wasmKeeper.addToContractCreatorSecondaryIndex(srcCtx, creatorAddress, history[0].Updated, address)
 		require.NoError(t, err)
+		err = wasmKeeper.addToContractLabelIndex(srcCtx, creatorAddress, info.Label, address)
+		require.NoError(t, err)
+		err = wasmKeeper.addToContractTagIndex(srcCtx, info.Tags, address)
+		require.NoError(t, err)
+		err = wasmKeeper.addToContractAdminIndex(srcCtx, info.AdminAddr(), address)
+		require.NoError(t, err)
 		return false
 	})
 
@@ -198,6 +233,22 @@ func TestGenesisInit(t *testing.T) {
 			},
 			expSuccess: true,
 		},
+		"happy path: stats match": {
+			src: types.GenesisState{
+				Codes: []types.Code{{
+					CodeID:    1,
+					CodeInfo:  myCodeInfo,
+					CodeBytes: wasmCode,
+				}},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeySequenceCodeID, Value: 2},
+					{IDKey: types.KeySequenceInstanceID, Value: 1},
+				},
+				Params: types.DefaultParams(),
+				Stats:  &types.WasmStats{CodeCount: 1},
+			},
+			expSuccess: true,
+		},
 		"happy path: code ids can contain gaps": {
 			src: types.GenesisState{
 				Codes: []types.Code{{
@@ -486,6 +537,81 @@ func TestGenesisInit(t *testing.T) {
 				Params: types.DefaultParams(),
 			},
 		},
+		"happy path: scheduled sudo": {
+			src: types.GenesisState{
+				Codes: []types.Code{{
+					CodeID:    1,
+					CodeInfo:  myCodeInfo,
+					CodeBytes: wasmCode,
+				}},
+				Contracts: []types.Contract{
+					{
+						ContractAddress: BuildContractAddressClassic(1, 1).String(),
+						ContractInfo:    types.ContractInfoFixture(func(c *types.ContractInfo) { c.CodeID = 1 }, types.RandCreatedFields),
+						ContractCodeHistory: []types.ContractCodeHistoryEntry{
+							{
+								Operation: types.ContractCodeHistoryOperationTypeMigrate,
+								CodeID:    1,
+								Updated:   &types.AbsoluteTxPosition{BlockHeight: rand.Uint64(), TxIndex: rand.Uint64()},
+								Msg:       []byte(`{}`),
+							},
+						},
+					},
+				},
+				ScheduledSudos: []types.ScheduledSudo{
+					{ID: 1, Contract: BuildContractAddressClassic(1, 1).String(), Height: 10, Msg: []byte(`{}`), GasLimit: 1},
+				},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeySequenceCodeID, Value: 2},
+					{IDKey: types.KeySequenceInstanceID, Value: 2},
+					{IDKey: types.KeySequenceScheduledSudoID, Value: 2},
+				},
+				Params: types.DefaultParams(),
+			},
+			expSuccess: true,
+		},
+		"prevent scheduled sudo id seq init value not high enough": {
+			src: types.GenesisState{
+				Codes: []types.Code{{
+					CodeID:    1,
+					CodeInfo:  myCodeInfo,
+					CodeBytes: wasmCode,
+				}},
+				Contracts: []types.Contract{
+					{
+						ContractAddress: BuildContractAddressClassic(1, 1).String(),
+						ContractInfo:    types.ContractInfoFixture(func(c *types.ContractInfo) { c.CodeID = 1 }, types.RandCreatedFields),
+						ContractCodeHistory: []types.ContractCodeHistoryEntry{
+							{
+								Operation: types.ContractCodeHistoryOperationTypeMigrate,
+								CodeID:    1,
+								Updated:   &types.AbsoluteTxPosition{BlockHeight: rand.Uint64(), TxIndex: rand.Uint64()},
+								Msg:       []byte(`{}`),
+							},
+						},
+					},
+				},
+				ScheduledSudos: []types.ScheduledSudo{
+					{ID: 1, Contract: BuildContractAddressClassic(1, 1).String(), Height: 10, Msg: []byte(`{}`), GasLimit: 1},
+				},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeySequenceCodeID, Value: 2},
+					{IDKey: types.KeySequenceInstanceID, Value: 2},
+				},
+				Params: types.DefaultParams(),
+			},
+		},
+		"prevent scheduled sudo of unknown contract": {
+			src: types.GenesisState{
+				ScheduledSudos: []types.ScheduledSudo{
+					{ID: 1, Contract: BuildContractAddressClassic(1, 1).String(), Height: 10, Msg: []byte(`{}`), GasLimit: 1},
+				},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeySequenceScheduledSudoID, Value: 2},
+				},
+				Params: types.DefaultParams(),
+			},
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
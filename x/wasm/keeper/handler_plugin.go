@@ -3,6 +3,7 @@ package keeper
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 
@@ -187,7 +188,7 @@ func NewIBCRawPacketHandler(ics4Wrapper types.ICS4Wrapper, wasmKeeper types.IBCC
 }
 
 // DispatchMsg publishes a raw IBC packet onto the channel.
-func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, [][]*codectypes.Any, error) {
+func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, [][]*codectypes.Any, error) {
 	if msg.IBC == nil {
 		return nil, nil, nil, types.ErrUnknownMsg
 	}
@@ -201,7 +202,15 @@ func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, cont
 			return nil, nil, nil, errorsmod.Wrapf(types.ErrEmpty, "ibc channel")
 		}
 
-		seq, err := h.ics4Wrapper.SendPacket(ctx, contractIBCPortID, contractIBCChannelID, ConvertWasmIBCTimeoutHeightToCosmosHeight(msg.IBC.SendPacket.Timeout.Block), msg.IBC.SendPacket.Timeout.Timestamp, msg.IBC.SendPacket.Data)
+		timeoutHeight := ConvertWasmIBCTimeoutHeightToCosmosHeight(msg.IBC.SendPacket.Timeout.Block)
+		timeoutTimestamp := msg.IBC.SendPacket.Timeout.Timestamp
+		if timeoutHeight.IsZero() && timeoutTimestamp == 0 {
+			var err error
+			if timeoutTimestamp, err = h.defaultTimeoutTimestamp(ctx, contractAddr); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		seq, err := h.ics4Wrapper.SendPacket(ctx, contractIBCPortID, contractIBCChannelID, timeoutHeight, timeoutTimestamp, msg.IBC.SendPacket.Data)
 		if err != nil {
 			return nil, nil, nil, errorsmod.Wrap(err, "channel")
 		}
@@ -263,6 +272,19 @@ func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, cont
 
 var _ Messenger = MessageHandlerFunc(nil)
 
+// defaultTimeoutTimestamp returns the absolute timeout timestamp for a packet sent by the contract without timeout
+func (h IBCRawPacketHandler) defaultTimeoutTimestamp(ctx sdk.Context, contractAddr sdk.AccAddress) (uint64, error) {
+	relTimeout := h.wasmKeeper.GetIBCSendTimeout(ctx, contractAddr)
+	if relTimeout == 0 {
+		return 0, errorsmod.Wrap(types.ErrEmpty, "ibc timeout: height or timestamp required")
+	}
+	blockTime := uint64(ctx.BlockTime().UnixNano())
+	if relTimeout > math.MaxUint64-blockTime {
+		return 0, errorsmod.Wrap(types.ErrInvalid, "ibc timeout: default timeout overflows")
+	}
+	return blockTime + relTimeout, nil
+}
+
 // MessageHandlerFunc is a helper to construct a function based message handler.
 type MessageHandlerFunc func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error)
 
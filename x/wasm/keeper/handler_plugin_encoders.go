@@ -306,7 +306,7 @@ func EncodeIBCMsg(portSource types.ICS20TransferPortSource) func(ctx sdk.Context
 		switch {
 		case msg.CloseChannel != nil:
 			return []sdk.Msg{&channeltypes.MsgChannelCloseInit{
-				PortId:    PortIDForContract(sender),
+				PortId:    types.PortIDForContract(sender),
 				ChannelId: msg.CloseChannel.ChannelID,
 				Signer:    sender.String(),
 			}}, nil
@@ -1,8 +1,11 @@
 package keeper
 
 import (
+	"context"
 	"encoding/json"
+	"math"
 	"testing"
+	"time"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
@@ -232,7 +235,7 @@ func TestSDKMessageHandlerDispatch(t *testing.T) {
 
 func TestIBCRawPacketHandler(t *testing.T) {
 	ibcPort := "contractsIBCPort"
-	ctx := sdk.Context{}.WithLogger(log.NewTestLogger(t))
+	ctx := sdk.Context{}.WithLogger(log.NewTestLogger(t)).WithBlockTime(time.Unix(0, 1720000000000000000))
 
 	type CapturedPacket struct {
 		sourcePort       string
@@ -296,13 +299,14 @@ func TestIBCRawPacketHandler(t *testing.T) {
 	ackResponse := types.MsgIBCWriteAcknowledgementResponse{}
 
 	specs := map[string]struct {
-		srcMsg        wasmvmtypes.IBCMsg
-		chanKeeper    types.ChannelKeeper
-		expPacketSent *CapturedPacket
-		expPacketAck  *CapturedPacket
-		expAck        []byte
-		expErr        *errorsmod.Error
-		expResp       proto.Message
+		srcMsg         wasmvmtypes.IBCMsg
+		chanKeeper     types.ChannelKeeper
+		ibcSendTimeout uint64
+		expPacketSent  *CapturedPacket
+		expPacketAck   *CapturedPacket
+		expAck         []byte
+		expErr         *errorsmod.Error
+		expResp        proto.Message
 	}{
 		"send packet, all good": {
 			srcMsg: wasmvmtypes.IBCMsg{
@@ -321,6 +325,62 @@ func TestIBCRawPacketHandler(t *testing.T) {
 			},
 			expResp: &sendResponse,
 		},
+		"send packet, default timeout applied": {
+			srcMsg: wasmvmtypes.IBCMsg{
+				SendPacket: &wasmvmtypes.SendPacketMsg{
+					ChannelID: "channel-1",
+					Data:      []byte("myData"),
+				},
+			},
+			chanKeeper:     chanKeeper,
+			ibcSendTimeout: uint64(time.Hour),
+			expPacketSent: &CapturedPacket{
+				sourcePort:       ibcPort,
+				sourceChannel:    "channel-1",
+				timeoutTimestamp: 1720000000000000000 + uint64(time.Hour),
+				data:             []byte("myData"),
+			},
+			expResp: &sendResponse,
+		},
+		"send packet, default timeout not applied to packet with timeout": {
+			srcMsg: wasmvmtypes.IBCMsg{
+				SendPacket: &wasmvmtypes.SendPacketMsg{
+					ChannelID: "channel-1",
+					Data:      []byte("myData"),
+					Timeout:   wasmvmtypes.IBCTimeout{Timestamp: 1720000000000000001},
+				},
+			},
+			chanKeeper:     chanKeeper,
+			ibcSendTimeout: uint64(time.Hour),
+			expPacketSent: &CapturedPacket{
+				sourcePort:       ibcPort,
+				sourceChannel:    "channel-1",
+				timeoutTimestamp: 1720000000000000001,
+				data:             []byte("myData"),
+			},
+			expResp: &sendResponse,
+		},
+		"send packet, no timeout and no default": {
+			srcMsg: wasmvmtypes.IBCMsg{
+				SendPacket: &wasmvmtypes.SendPacketMsg{
+					ChannelID: "channel-1",
+					Data:      []byte("myData"),
+				},
+			},
+			chanKeeper: chanKeeper,
+			expErr:     types.ErrEmpty,
+		},
+		"send packet, default timeout overflows": {
+			srcMsg: wasmvmtypes.IBCMsg{
+				SendPacket: &wasmvmtypes.SendPacketMsg{
+					ChannelID: "channel-1",
+					Data:      []byte("myData"),
+				},
+			},
+			chanKeeper:     chanKeeper,
+			ibcSendTimeout: math.MaxUint64,
+			expErr:         types.ErrInvalid,
+		},
 		"async ack, all good": {
 			srcMsg: wasmvmtypes.IBCMsg{
 				WriteAcknowledgement: &wasmvmtypes.WriteAcknowledgementMsg{
@@ -346,6 +406,9 @@ func TestIBCRawPacketHandler(t *testing.T) {
 			capturedPacketSent = nil
 			capturedAck = nil
 			capturedPacketAck = nil
+			contractKeeper.GetIBCSendTimeoutFn = func(ctx context.Context, contractAddress sdk.AccAddress) uint64 {
+				return spec.ibcSendTimeout
+			}
 
 			// when
 			h := NewIBCRawPacketHandler(capturingICS4Mock, &contractKeeper, spec.chanKeeper)
@@ -1,24 +1,46 @@
 package keeper
 
 import (
-	"strings"
+	"context"
 
-	errorsmod "cosmossdk.io/errors"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
-const portIDPrefix = "wasm."
-
+// PortIDForContract derives the IBC port id for a contract. See types.PortIDForContract
 func PortIDForContract(addr sdk.AccAddress) string {
-	return portIDPrefix + addr.String()
+	return types.PortIDForContract(addr)
 }
 
+// ContractFromPortID returns the contract address for an IBC port id. See types.ContractFromPortID
 func ContractFromPortID(portID string) (sdk.AccAddress, error) {
-	if !strings.HasPrefix(portID, portIDPrefix) {
-		return nil, errorsmod.Wrapf(types.ErrInvalid, "without prefix")
+	return types.ContractFromPortID(portID)
+}
+
+// GetContractChannelStatus returns the packet sequences and the state of a channel of the contract port.
+// The receive and acknowledgement sequences are only set for ordered channels.
+func (k Keeper) GetContractChannelStatus(ctx context.Context, contractAddr sdk.AccAddress, channelID string) (*types.QueryContractChannelStatusResponse, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	portID := PortIDForContract(contractAddr)
+	channel, found := k.channelKeeper.GetChannel(sdkCtx, portID, channelID)
+	if !found {
+		return nil, types.ErrNotFound.Wrapf("channel %s of port %s", channelID, portID)
+	}
+	rsp := types.QueryContractChannelStatusResponse{
+		State:    channel.State.String(),
+		Ordering: channel.Ordering.String(),
+	}
+	rsp.NextSequenceSend, _ = k.channelKeeper.GetNextSequenceSend(sdkCtx, portID, channelID)
+	if channel.Ordering == channeltypes.ORDERED {
+		rsp.NextSequenceRecv, _ = k.channelKeeper.GetNextSequenceRecv(sdkCtx, portID, channelID)
+		rsp.NextSequenceAck, _ = k.channelKeeper.GetNextSequenceAck(sdkCtx, portID, channelID)
 	}
-	return sdk.AccAddressFromBech32(portID[len(portIDPrefix):])
+	k.channelKeeper.IteratePacketCommitmentAtChannel(sdkCtx, portID, channelID, func(_, _ string, _ uint64, _ []byte) bool {
+		rsp.PendingCommitments++
+		return false
+	})
+	return &rsp, nil
 }
@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// setIBCSendTimeout stores the default timeout (in nanoseconds) relative to the block time for IBC packets
+// that the contract sends without timeout. A zero timeout removes the default.
+func (k Keeper) setIBCSendTimeout(ctx context.Context, contractAddress, caller sdk.AccAddress, timeout uint64, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if err := k.importIBCSendTimeout(ctx, contractAddress, timeout); err != nil {
+		return err
+	}
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetIBCSendTimeout,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyTimeoutTimestamp, strconv.FormatUint(timeout, 10)),
+	))
+	return nil
+}
+
+// GetIBCSendTimeout returns the default timeout (in nanoseconds) relative to the block time for IBC packets
+// sent by the contract or 0 when not set
+func (k Keeper) GetIBCSendTimeout(ctx context.Context, contractAddress sdk.AccAddress) uint64 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetIBCSendTimeoutKey(contractAddress))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) importIBCSendTimeout(ctx context.Context, contractAddress sdk.AccAddress, timeout uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetIBCSendTimeoutKey(contractAddress)
+	if timeout == 0 {
+		return store.Delete(key)
+	}
+	return store.Set(key, sdk.Uint64ToBigEndian(timeout))
+}
@@ -0,0 +1,99 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSetIBCSendTimeout(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	specs := map[string]struct {
+		setup      func(ctx sdk.Context)
+		src        types.MsgSetIBCSendTimeout
+		expErr     error
+		expTimeout uint64
+	}{
+		"set": {
+			src:        types.MsgSetIBCSendTimeout{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), TimeoutTimestamp: uint64(time.Hour)},
+			expTimeout: uint64(time.Hour),
+		},
+		"update": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.setIBCSendTimeout(ctx, example.Contract, example.CreatorAddr, uint64(time.Minute), DefaultAuthorizationPolicy{}))
+			},
+			src:        types.MsgSetIBCSendTimeout{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), TimeoutTimestamp: uint64(time.Hour)},
+			expTimeout: uint64(time.Hour),
+		},
+		"remove": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.setIBCSendTimeout(ctx, example.Contract, example.CreatorAddr, uint64(time.Minute), DefaultAuthorizationPolicy{}))
+			},
+			src: types.MsgSetIBCSendTimeout{Sender: example.CreatorAddr.String(), Contract: example.Contract.String()},
+		},
+		"not admin": {
+			src:    types.MsgSetIBCSendTimeout{Sender: RandomBech32AccountAddress(t), Contract: example.Contract.String(), TimeoutTimestamp: uint64(time.Hour)},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			src:    types.MsgSetIBCSendTimeout{Sender: example.CreatorAddr.String(), Contract: RandomBech32AccountAddress(t), TimeoutTimestamp: uint64(time.Hour)},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+			_, gotErr := msgServer.SetIBCSendTimeout(ctx.WithEventManager(em), &spec.src)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expTimeout, k.GetIBCSendTimeout(ctx, example.Contract))
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeSetIBCSendTimeout, em.Events()[0].Type)
+		})
+	}
+}
+
+func TestIBCSendTimeoutGenesis(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	withInMemoryCodeStore(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, k.setIBCSendTimeout(ctx, example.Contract, example.CreatorAddr, uint64(time.Hour), DefaultAuthorizationPolicy{}))
+
+	// when
+	genState := ExportGenesis(ctx, k)
+	// then
+	require.Len(t, genState.Contracts, 1)
+	assert.Equal(t, uint64(time.Hour), genState.Contracts[0].IBCSendTimeout)
+
+	// when imported again
+	importCtx, importKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	importKeepers.WasmKeeper.wasmVM = &mock
+	_, err := InitGenesis(importCtx, importKeepers.WasmKeeper, *genState)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, uint64(time.Hour), importKeepers.WasmKeeper.GetIBCSendTimeout(importCtx, example.Contract))
+}
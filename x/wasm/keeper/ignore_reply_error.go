@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// setIgnoreReplyError enables or disables the ignore reply error policy of the contract. With the policy, a failing
+// reply to a successful submessage with reply on success is reverted and reported by an event instead of failing
+// the contract call.
+func (k Keeper) setIgnoreReplyError(ctx context.Context, contractAddress, caller sdk.AccAddress, enabled bool, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if err := k.importIgnoreReplyError(ctx, contractAddress, enabled); err != nil {
+		return err
+	}
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetIgnoreReplyError,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyEnabled, strconv.FormatBool(enabled)),
+	))
+	return nil
+}
+
+// HasIgnoreReplyError returns true when the contract opted into the ignore reply error policy
+func (k Keeper) HasIgnoreReplyError(ctx context.Context, contractAddress sdk.AccAddress) bool {
+	ok, err := k.storeService.OpenKVStore(ctx).Has(types.GetIgnoreReplyErrorKey(contractAddress))
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+func (k Keeper) importIgnoreReplyError(ctx context.Context, contractAddress sdk.AccAddress, enabled bool) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetIgnoreReplyErrorKey(contractAddress)
+	if !enabled {
+		return store.Delete(key)
+	}
+	return store.Set(key, []byte{1})
+}
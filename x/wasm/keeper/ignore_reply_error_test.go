@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSetIgnoreReplyError(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	specs := map[string]struct {
+		setup      func(ctx sdk.Context)
+		src        types.MsgSetIgnoreReplyError
+		expErr     error
+		expEnabled bool
+	}{
+		"enable": {
+			src:        types.MsgSetIgnoreReplyError{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Enabled: true},
+			expEnabled: true,
+		},
+		"enable by gov authority": {
+			src:        types.MsgSetIgnoreReplyError{Sender: k.GetAuthority(), Contract: example.Contract.String(), Enabled: true},
+			expEnabled: true,
+		},
+		"disable": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.importIgnoreReplyError(ctx, example.Contract, true))
+			},
+			src: types.MsgSetIgnoreReplyError{Sender: example.CreatorAddr.String(), Contract: example.Contract.String()},
+		},
+		"not admin": {
+			src:    types.MsgSetIgnoreReplyError{Sender: RandomBech32AccountAddress(t), Contract: example.Contract.String(), Enabled: true},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			src:    types.MsgSetIgnoreReplyError{Sender: example.CreatorAddr.String(), Contract: RandomBech32AccountAddress(t), Enabled: true},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			em := sdk.NewEventManager()
+			_, gotErr := msgServer.SetIgnoreReplyError(ctx.WithEventManager(em), &spec.src)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expEnabled, k.HasIgnoreReplyError(ctx, example.Contract))
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeSetIgnoreReplyError, em.Events()[0].Type)
+		})
+	}
+}
+
+func TestIgnoreReplyErrorGenesis(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	withInMemoryCodeStore(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, k.setIgnoreReplyError(ctx, example.Contract, example.CreatorAddr, true, DefaultAuthorizationPolicy{}))
+
+	// when
+	genState := ExportGenesis(ctx, k)
+	// then
+	require.Len(t, genState.Contracts, 1)
+	assert.True(t, genState.Contracts[0].IgnoreReplyError)
+
+	// when imported again
+	importCtx, importKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	importKeepers.WasmKeeper.wasmVM = &mock
+	_, err := InitGenesis(importCtx, importKeepers.WasmKeeper, *genState)
+	// then
+	require.NoError(t, err)
+	assert.True(t, importKeepers.WasmKeeper.HasIgnoreReplyError(importCtx, example.Contract))
+}
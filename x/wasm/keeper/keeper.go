@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
@@ -93,14 +94,38 @@ type Keeper struct {
 	wasmVMQueryHandler    WasmVMQueryHandler
 	wasmVMResponseHandler WasmVMResponseHandler
 	messenger             Messenger
+	channelKeeper         types.ChannelKeeper
+	queryRouter           GRPCQueryRouter
 	// queryGasLimit is the max wasmvm gas that can be spent on executing a query with a contract
-	queryGasLimit        uint64
+	queryGasLimit uint64
+	// queryDepthGasPercent is the max percentage of the remaining gas of the parent that a nested smart query can use
+	queryDepthGasPercent uint32
 	gasRegister          types.GasRegister
 	maxQueryStackSize    uint32
 	maxCallDepth         uint32
+	codeUploadTTL        int64
+	// subscriberGasLimit is the max gas of a single param subscriber call
+	subscriberGasLimit   uint64
 	acceptedAccountTypes map[reflect.Type]struct{}
 	accountPruner        AccountPruner
 	params               collections.Item[types.Params]
+	codeBlobSource       CodeBlobSource
+	// capabilities of the chain, used to check the required capabilities of a code on instantiate
+	availableCapabilities map[string]struct{}
+	versionedStore        VersionedMultiStore
+	versionedStoreKey     storetypes.StoreKey
+	// recentBlockHashes bound the replay of signed smart queries. Nil when signed queries are disabled
+	recentBlockHashes *recentBlockHashes
+	// transientStoreService collects the wasm activity of the current block. Nil when not set
+	transientStoreService corestoretypes.TransientStoreService
+	// storeWriteLog receives the contract store writes in FinalizeBlock for debugging. Nil when not set
+	storeWriteLog StoreWriteLog
+	// execLogLevel is the log level of the contract execution logs
+	execLogLevel string
+	// execLogSeq is the counter for the correlation ids of the execution logs
+	execLogSeq *atomic.Uint64
+	// blockExecTime accumulates the wall-clock time of the wasm operations in the current block for telemetry
+	blockExecTime *blockExecTime
 	// propagate gov authZ to sub-messages
 	propagateGovAuthorization map[types.AuthorizationPolicyAction]struct{}
 
@@ -116,8 +141,9 @@ func (k Keeper) getUploadAccessConfig(ctx context.Context) types.AccessConfig {
 	return k.GetParams(ctx).CodeUploadAccess
 }
 
-func (k Keeper) getInstantiateAccessConfig(ctx context.Context) types.AccessType {
-	return k.GetParams(ctx).InstantiateDefaultPermission
+// getInstantiateAccessConfig returns the instantiate default permission for codes uploaded through the origin
+func (k Keeper) getInstantiateAccessConfig(ctx context.Context, origin types.CodeOrigin) types.AccessType {
+	return k.GetParams(ctx).InstantiateDefaultPermissionFor(origin)
 }
 
 func (k Keeper) GetWasmLimits() wasmvmtypes.WasmLimits {
@@ -148,13 +174,13 @@ func (k Keeper) GetGasRegister() types.GasRegister {
 	return k.gasRegister
 }
 
-func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy) (codeID uint64, checksum []byte, err error) {
+func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, origin types.CodeOrigin, authZ types.AuthorizationPolicy) (codeID uint64, checksum []byte, err error) {
 	if creator == nil {
 		return 0, checksum, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "cannot be nil")
 	}
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 	// figure out proper instantiate access
-	defaultAccessConfig := k.getInstantiateAccessConfig(sdkCtx).With(creator)
+	defaultAccessConfig := k.getInstantiateAccessConfig(sdkCtx, origin).With(creator)
 	if instantiateAccess == nil {
 		instantiateAccess = &defaultAccessConfig
 	}
@@ -163,8 +189,13 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 		Upload:      k.getUploadAccessConfig(sdkCtx),
 	}
 
-	if !authZ.CanCreateCode(chainConfigs, creator, *instantiateAccess) {
-		return 0, checksum, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not create code")
+	requiresApproval := !authZ.CanCreateCode(chainConfigs, creator, *instantiateAccess)
+	if requiresApproval {
+		// code pre-approved by governance can be uploaded by anybody
+		chainConfigs.Upload = types.AllowEverybody
+		if !authZ.CanCreateCode(chainConfigs, creator, *instantiateAccess) {
+			return 0, checksum, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not create code")
+		}
 	}
 
 	if ioutils.IsGzip(wasmCode) {
@@ -175,6 +206,17 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 		}
 	}
 
+	if requiresApproval {
+		// approvals are stored by the checksum of the uncompressed code
+		approved, err := k.useCodeUploadApproval(sdkCtx, wasmCode)
+		if err != nil {
+			return 0, checksum, err
+		}
+		if !approved {
+			return 0, checksum, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "code upload not approved")
+		}
+	}
+
 	gasLeft := k.runtimeGasForContract(sdkCtx)
 	var gasUsed uint64
 	isSimulation := sdkCtx.ExecMode() == sdk.ExecModeSimulate
@@ -200,7 +242,16 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 	codeID = k.mustAutoIncrementID(sdkCtx, types.KeySequenceCodeID)
 	k.Logger(sdkCtx).Debug("storing new contract", "capabilities", requiredCapabilities, "code_id", codeID)
 	codeInfo := types.NewCodeInfo(checksum, creator, *instantiateAccess)
+	codeInfo.RequiredCapabilities = parseCapabilities(requiredCapabilities)
+	codeInfo.Origin = origin
 	k.mustStoreCodeInfo(sdkCtx, codeID, codeInfo)
+	k.incrementCodeCount(sdkCtx)
+	if err := k.addToCodeChecksumIndex(sdkCtx, checksum, codeID); err != nil {
+		return 0, checksum, err
+	}
+	if err := k.addToCodeCreatorIndex(sdkCtx, creator, codeID); err != nil {
+		return 0, checksum, err
+	}
 
 	evt := sdk.NewEvent(
 		types.EventTypeStoreCode,
@@ -215,6 +266,29 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 	return codeID, checksum, nil
 }
 
+// setCodeProvenance verifies the provenance signature of the creator and stores the provenance with the code info
+func (k Keeper) setCodeProvenance(ctx context.Context, codeID uint64, creator sdk.AccAddress, source, builder string, signature []byte) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil {
+		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	sdkCtx.GasMeter().ConsumeGas(types.DefaultProvenanceVerifyCost, "Verify code provenance signature")
+	// the checksum is not known in simulation, so that the signature can not be verified
+	if sdkCtx.ExecMode() != sdk.ExecModeSimulate {
+		acc := k.accountKeeper.GetAccount(ctx, creator)
+		if acc == nil || acc.GetPubKey() == nil {
+			return errorsmod.Wrap(types.ErrInvalidProvenance, "creator has no public key")
+		}
+		if !acc.GetPubKey().VerifySignature(types.CodeProvenanceSignBytes(codeInfo.CodeHash, source, builder), signature) {
+			return errorsmod.Wrap(types.ErrInvalidProvenance, "signature verification failed")
+		}
+	}
+	codeInfo.Provenance = &types.CodeProvenance{Source: source, Builder: builder, Signature: signature}
+	k.mustStoreCodeInfo(ctx, codeID, *codeInfo)
+	return nil
+}
+
 func (k Keeper) mustStoreCodeInfo(ctx context.Context, codeID uint64, codeInfo types.CodeInfo) {
 	store := k.storeService.OpenKVStore(ctx)
 	// 0x01 | codeID (uint64) -> ContractInfo
@@ -250,7 +324,18 @@ func (k Keeper) importCode(ctx context.Context, codeID uint64, codeInfo types.Co
 		return errorsmod.Wrapf(types.ErrDuplicate, "duplicate code: %d", codeID)
 	}
 	// 0x01 | codeID (uint64) -> ContractInfo
-	return store.Set(key, k.cdc.MustMarshal(&codeInfo))
+	if err := store.Set(key, k.cdc.MustMarshal(&codeInfo)); err != nil {
+		return err
+	}
+	k.incrementCodeCount(ctx)
+	if err := k.addToCodeChecksumIndex(ctx, codeInfo.CodeHash, codeID); err != nil {
+		return err
+	}
+	creator, err := sdk.AccAddressFromBech32(codeInfo.Creator)
+	if err != nil {
+		return errorsmod.Wrap(err, "creator")
+	}
+	return k.addToCodeCreatorIndex(ctx, creator, codeID)
 }
 
 func (k Keeper) instantiate(
@@ -268,7 +353,7 @@ func (k Keeper) instantiate(
 	if creator == nil {
 		return nil, nil, types.ErrEmpty.Wrap("creator")
 	}
-	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	sdkCtx, trace := k.startExecTrace(sdk.UnwrapSDKContext(ctx))
 
 	codeInfo := k.GetCodeInfo(ctx, codeID)
 	if codeInfo == nil {
@@ -283,6 +368,14 @@ func (k Keeper) instantiate(
 	if !authPolicy.CanInstantiateContract(codeInfo.InstantiateConfig, creator) {
 		return nil, nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not instantiate")
 	}
+	params := k.GetParams(sdkCtx)
+	// the capabilities of the chain may have changed since the code was stored
+	if missing := k.missingCapabilities(codeInfo.RequiredCapabilities); len(missing) != 0 && !params.DisableInstantiateCapabilityCheck {
+		return nil, nil, types.ErrMissingCapabilities.Wrapf("code id %d: %s", codeID, strings.Join(missing, ", "))
+	}
+	if err := k.checkUniqueLabel(ctx, creator, label, nil); err != nil {
+		return nil, nil, err
+	}
 	contractAddress := addressGenerator(ctx, codeID, codeInfo.CodeHash)
 	if k.HasContractInfo(ctx, contractAddress) {
 		// This case must only happen for instantiate2 because instantiate is based on a counter in state.
@@ -291,37 +384,8 @@ func (k Keeper) instantiate(
 		return nil, nil, types.ErrDuplicate.Wrap("contract address already exists, try a different combination of creator, checksum and salt")
 	}
 
-	// check account
-	// every cosmos module can define custom account types when needed. The cosmos-sdk comes with extension points
-	// to support this and a set of base and vesting account types that we integrated in our default lists.
-	// But not all account types of other modules are known or may make sense for contracts, therefore we kept this
-	// decision logic also very flexible and extendable. We provide new options to overwrite the default settings via WithAcceptedAccountTypesOnContractInstantiation and
-	// WithPruneAccountTypesOnContractInstantiation as constructor arguments
-	existingAcct := k.accountKeeper.GetAccount(sdkCtx, contractAddress)
-	if existingAcct != nil {
-		if existingAcct.GetSequence() != 0 || existingAcct.GetPubKey() != nil {
-			return nil, nil, types.ErrAccountExists.Wrap("address is claimed by external account")
-		}
-		if _, accept := k.acceptedAccountTypes[reflect.TypeOf(existingAcct)]; accept {
-			// keep account and balance as it is
-			k.Logger(sdkCtx).Info("instantiate contract with existing account", "address", contractAddress.String())
-		} else {
-			// consider an account in the wasmd namespace spam and overwrite it.
-			k.Logger(sdkCtx).Info("pruning existing account for contract instantiation", "address", contractAddress.String())
-			contractAccount := k.accountKeeper.NewAccountWithAddress(sdkCtx, contractAddress)
-			k.accountKeeper.SetAccount(sdkCtx, contractAccount)
-			// also handle balance to not open cases where these accounts are abused and become liquid
-			switch handled, err := k.accountPruner.CleanupExistingAccount(sdkCtx, existingAcct); {
-			case err != nil:
-				return nil, nil, errorsmod.Wrap(err, "prune balance")
-			case !handled:
-				return nil, nil, types.ErrAccountExists.Wrap("address is claimed by external account")
-			}
-		}
-	} else {
-		// create an empty account (so we don't have issues later)
-		contractAccount := k.accountKeeper.NewAccountWithAddress(sdkCtx, contractAddress)
-		k.accountKeeper.SetAccount(sdkCtx, contractAccount)
+	if err := k.claimContractAccount(sdkCtx, contractAddress); err != nil {
+		return nil, nil, err
 	}
 	// deposit initial contract funds
 	if !deposit.IsZero() {
@@ -331,21 +395,25 @@ func (k Keeper) instantiate(
 	}
 
 	// prepare params for contract instantiate call
-	env := types.NewEnv(sdkCtx, contractAddress)
+	sdkCtx, env := k.newEnv(sdkCtx, contractAddress)
 	info := types.NewInfo(creator, deposit)
 
 	// create prefixed data store
 	// 0x03 | BuildContractAddressClassic (sdk.AccAddress)
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	vmStore := types.NewStoreAdapter(prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(sdkCtx)), prefixStoreKey))
+	vmStore := k.contractVMStore(sdkCtx, contractAddress, *codeInfo)
 
 	// prepare querier
 	querier := k.newQueryHandler(sdkCtx, contractAddress)
 
 	// instantiate wasm contract
+	k.trackUsage(sdkCtx, params, contractAddress, "instantiate")
 	gasLeft := k.runtimeGasForContract(sdkCtx)
+	gasReport := k.newVMGasReport(sdkCtx, contractAddress, "instantiate", gasLeft)
 	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, vmStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	gasReport.emit(sdkCtx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(sdkCtx, params, contractAddress, "instantiate", gasUsed)
+	k.logContractCall(sdkCtx, contractAddress, codeID, "instantiate", initMsg, gasUsed, err == nil && res != nil && res.Err == "")
 	if err != nil {
 		return nil, nil, errorsmod.Wrap(types.ErrVMError, err.Error())
 	}
@@ -356,6 +424,7 @@ func (k Keeper) instantiate(
 	if res.Err != "" {
 		return nil, nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrInstantiateFailed, res.Err))
 	}
+	k.traceVMCall(sdkCtx, "instantiate", initMsg, res.Ok)
 
 	// persist instance first
 	createdAt := types.NewAbsoluteTxPosition(sdkCtx)
@@ -368,7 +437,7 @@ func (k Keeper) instantiate(
 	}
 	if report.HasIBCEntryPoints {
 		// register IBC port
-		ibcPort := PortIDForContract(contractAddress)
+		ibcPort := types.PortIDForContract(contractAddress)
 		contractInfo.IBCPortID = ibcPort
 	}
 
@@ -378,40 +447,103 @@ func (k Keeper) instantiate(
 	if err != nil {
 		return nil, nil, err
 	}
+	k.incrementContractCount(sdkCtx)
 	err = k.addToContractCreatorSecondaryIndex(sdkCtx, creator, historyEntry.Updated, contractAddress)
 	if err != nil {
 		return nil, nil, err
 	}
+	err = k.addToContractLabelIndex(sdkCtx, creator, label, contractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = k.addToContractAdminIndex(sdkCtx, admin, contractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
 	err = k.appendToContractHistory(sdkCtx, contractAddress, historyEntry)
 	if err != nil {
 		return nil, nil, err
 	}
+	// only creations via contract dispatch are tracked in the relations index
+	if k.HasContractInfo(sdkCtx, creator) {
+		if err := k.storeContractRelation(sdkCtx, creator, contractAddress); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	k.mustStoreContractInfo(sdkCtx, contractAddress, &contractInfo)
 
-	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+	instantiateEvent := sdk.NewEvent(
 		types.EventTypeInstantiate,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
-	))
+	)
+	if contractInfo.IBCPortID != "" {
+		instantiateEvent = instantiateEvent.AppendAttributes(sdk.NewAttribute(types.AttributeKeyIBCPortID, contractInfo.IBCPortID))
+	}
+	sdkCtx.EventManager().EmitEvent(instantiateEvent)
 
 	sdkCtx = types.WithSubMsgAuthzPolicy(sdkCtx, authPolicy.SubMessageAuthorizationPolicy(types.AuthZActionInstantiate))
-	data, err := k.handleContractResponse(sdkCtx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(sdkCtx, params, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, nil, errorsmod.Wrap(err, "dispatch")
 	}
+	k.emitExecTrace(sdkCtx, contractAddress, trace)
 
 	return contractAddress, data, nil
 }
 
+// claimContractAccount sets up the account for a new contract at the address. An account that is claimed by
+// an external actor is rejected.
+func (k Keeper) claimContractAccount(sdkCtx sdk.Context, contractAddress sdk.AccAddress) error {
+	// every cosmos module can define custom account types when needed. The cosmos-sdk comes with extension points
+	// to support this and a set of base and vesting account types that we integrated in our default lists.
+	// But not all account types of other modules are known or may make sense for contracts, therefore we kept this
+	// decision logic also very flexible and extendable. We provide new options to overwrite the default settings via WithAcceptedAccountTypesOnContractInstantiation and
+	// WithPruneAccountTypesOnContractInstantiation as constructor arguments
+	existingAcct := k.accountKeeper.GetAccount(sdkCtx, contractAddress)
+	if existingAcct != nil {
+		if existingAcct.GetSequence() != 0 || existingAcct.GetPubKey() != nil {
+			return types.ErrAccountExists.Wrap("address is claimed by external account")
+		}
+		if _, accept := k.acceptedAccountTypes[reflect.TypeOf(existingAcct)]; accept {
+			// keep account and balance as it is
+			k.Logger(sdkCtx).Info("instantiate contract with existing account", "address", contractAddress.String())
+		} else {
+			// consider an account in the wasmd namespace spam and overwrite it.
+			k.Logger(sdkCtx).Info("pruning existing account for contract instantiation", "address", contractAddress.String())
+			contractAccount := k.accountKeeper.NewAccountWithAddress(sdkCtx, contractAddress)
+			k.accountKeeper.SetAccount(sdkCtx, contractAccount)
+			// also handle balance to not open cases where these accounts are abused and become liquid
+			switch handled, err := k.accountPruner.CleanupExistingAccount(sdkCtx, existingAcct); {
+			case err != nil:
+				return errorsmod.Wrap(err, "prune balance")
+			case !handled:
+				return types.ErrAccountExists.Wrap("address is claimed by external account")
+			}
+		}
+	} else {
+		// create an empty account (so we don't have issues later)
+		contractAccount := k.accountKeeper.NewAccountWithAddress(sdkCtx, contractAddress)
+		k.accountKeeper.SetAccount(sdkCtx, contractAccount)
+	}
+	return nil
+}
+
 // Execute executes the contract instance
 func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "execute")
-	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	sdkCtx, trace := k.startExecTrace(sdk.UnwrapSDKContext(ctx))
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	if contractInfo.Inactive {
+		return nil, types.ContractSunsetError{SunsetHeight: contractInfo.SunsetHeight}
+	}
+	if contractInfo.Suspended {
+		return nil, types.ErrContractSuspended.Wrapf("contract %s", contractAddress)
+	}
 
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(ctx, contractInfo.CodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
@@ -425,14 +557,21 @@ func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddr
 		}
 	}
 
-	env := types.NewEnv(sdkCtx, contractAddress)
+	sdkCtx, env := k.newEnv(sdkCtx, contractAddress)
 	info := types.NewInfo(caller, coins)
 
 	// prepare querier
 	querier := k.newQueryHandler(sdkCtx, contractAddress)
+	params := k.GetParams(sdkCtx)
+	k.trackUsage(sdkCtx, params, contractAddress, "execute")
 	gasLeft := k.runtimeGasForContract(sdkCtx)
+	gasReport := k.newVMGasReport(sdkCtx, contractAddress, "execute", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	gasReport.emit(sdkCtx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(sdkCtx, params, contractAddress, "execute", gasUsed)
+	k.recordExecutionReceipt(sdkCtx, params, contractAddress, types.ExecutionReceiptKindExecute, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(sdkCtx, contractAddress, contractInfo.CodeID, "execute", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return nil, errorsmod.Wrap(types.ErrVMError, execErr.Error())
 	}
@@ -443,20 +582,23 @@ func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddr
 	if res.Err != "" {
 		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
+	k.traceVMCall(sdkCtx, "execute", msg, res.Ok)
 
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeExecute,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 	))
 
-	data, err := k.handleContractResponse(sdkCtx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(sdkCtx, params, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, err
 	}
+	k.emitExecTrace(sdkCtx, contractAddress, trace)
 
 	return data, nil
 }
 
+// migrate migrates the contract to the new code. Inactive contracts can only be migrated with the gov policy.
 func (k Keeper) migrate(
 	ctx context.Context,
 	contractAddress sdk.AccAddress,
@@ -464,10 +606,58 @@ func (k Keeper) migrate(
 	newCodeID uint64,
 	msg []byte,
 	authZ types.AuthorizationPolicy,
+) ([]byte, error) {
+	if _, ok := authZ.(GovAuthorizationPolicy); !ok {
+		if info := k.GetContractInfo(ctx, contractAddress); info != nil && info.Inactive {
+			return nil, types.ContractSunsetError{SunsetHeight: info.SunsetHeight}
+		}
+	}
+	return k.migrateWithOperation(ctx, contractAddress, caller, newCodeID, msg, authZ, types.ContractCodeHistoryOperationTypeMigrate)
+}
+
+// forceMigrate migrates the contract without the admin, for example when the admin key was lost.
+// It is reserved for the governance authority which is the higher authority, so that contracts with an
+// admin set can be force migrated, too. The history entry is recorded with the force migrate operation.
+func (k Keeper) forceMigrate(
+	ctx context.Context,
+	contractAddress sdk.AccAddress,
+	authority sdk.AccAddress,
+	newCodeID uint64,
+	msg []byte,
+	authZ types.AuthorizationPolicy,
+) ([]byte, error) {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	oldCodeID, admin := contractInfo.CodeID, contractInfo.Admin
+
+	data, err := k.migrateWithOperation(ctx, contractAddress, authority, newCodeID, msg, authZ, types.ContractCodeHistoryOperationTypeForceMigrate)
+	if err != nil {
+		return nil, err
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeForceMigrate,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyOldCodeID, strconv.FormatUint(oldCodeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(newCodeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAdmin, admin),
+	))
+	return data, nil
+}
+
+func (k Keeper) migrateWithOperation(
+	ctx context.Context,
+	contractAddress sdk.AccAddress,
+	caller sdk.AccAddress,
+	newCodeID uint64,
+	msg []byte,
+	authZ types.AuthorizationPolicy,
+	operation types.ContractCodeHistoryOperationType,
 ) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "migrate")
 
-	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	sdkCtx, trace := k.startExecTrace(sdk.UnwrapSDKContext(ctx))
 
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
 	if contractInfo == nil {
@@ -496,11 +686,14 @@ func (k Keeper) migrate(
 		return nil, errorsmod.Wrap(types.ErrMigrationFailed, "requires ibc callbacks")
 	case report.HasIBCEntryPoints && contractInfo.IBCPortID == "":
 		// add ibc port
-		ibcPort := PortIDForContract(contractAddress)
+		ibcPort := types.PortIDForContract(contractAddress)
 		contractInfo.IBCPortID = ibcPort
 	}
 
-	var response *wasmvmtypes.Response
+	var (
+		response *wasmvmtypes.Response
+		params   types.Params
+	)
 
 	// check for migrate version
 	oldCodeInfo := k.GetCodeInfo(ctx, contractInfo.CodeID)
@@ -513,7 +706,8 @@ func (k Keeper) migrate(
 	if report.ContractMigrateVersion == nil ||
 		oldReport.ContractMigrateVersion == nil ||
 		*report.ContractMigrateVersion != *oldReport.ContractMigrateVersion {
-		response, err = k.callMigrateEntrypoint(sdkCtx, contractAddress, wasmvmtypes.Checksum(newCodeInfo.CodeHash), msg, newCodeID, caller, oldReport.ContractMigrateVersion)
+		params = k.GetParams(sdkCtx)
+		response, err = k.callMigrateEntrypoint(sdkCtx, params, contractAddress, *newCodeInfo, msg, newCodeID, caller, oldReport.ContractMigrateVersion)
 		if err != nil {
 			return nil, err
 		}
@@ -526,6 +720,7 @@ func (k Keeper) migrate(
 	}
 	// persist migration updates
 	historyEntry := contractInfo.AddMigration(sdkCtx, newCodeID, msg)
+	historyEntry.Operation = operation
 	err = k.appendToContractHistory(ctx, contractAddress, historyEntry)
 	if err != nil {
 		return nil, err
@@ -549,6 +744,7 @@ func (k Keeper) migrate(
 		sdkCtx = types.WithSubMsgAuthzPolicy(sdkCtx, authZ.SubMessageAuthorizationPolicy(types.AuthZActionMigrateContract))
 		data, err = k.handleContractResponse(
 			sdkCtx,
+			params,
 			contractAddress,
 			contractInfo.IBCPortID,
 			response.Messages,
@@ -559,41 +755,47 @@ func (k Keeper) migrate(
 		if err != nil {
 			return nil, errorsmod.Wrap(err, "dispatch")
 		}
-		return data, nil
 	}
+	k.emitExecTrace(sdkCtx, contractAddress, trace)
 
 	return data, nil
 }
 
 func (k Keeper) callMigrateEntrypoint(
 	sdkCtx sdk.Context,
+	params types.Params,
 	contractAddress sdk.AccAddress,
-	newChecksum wasmvmtypes.Checksum,
+	newCodeInfo types.CodeInfo,
 	msg []byte,
 	newCodeID uint64,
 	senderAddress sdk.AccAddress,
 	oldMigrateVersion *uint64,
 ) (*wasmvmtypes.Response, error) {
-	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, newChecksum, k.IsPinnedCode(sdkCtx, newCodeID))
+	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, newCodeInfo.CodeHash, k.IsPinnedCode(sdkCtx, newCodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
 	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: migrate")
 
-	env := types.NewEnv(sdkCtx, contractAddress)
+	sdkCtx, env := k.newEnv(sdkCtx, contractAddress)
 
 	// prepare querier
 	querier := k.newQueryHandler(sdkCtx, contractAddress)
 
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	vmStore := types.NewStoreAdapter(prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(sdkCtx)), prefixStoreKey))
+	vmStore := k.contractVMStore(sdkCtx, contractAddress, newCodeInfo)
+	k.trackUsage(sdkCtx, params, contractAddress, "migrate")
 	gasLeft := k.runtimeGasForContract(sdkCtx)
+	gasReport := k.newVMGasReport(sdkCtx, contractAddress, "migrate", gasLeft)
 
 	migrateInfo := wasmvmtypes.MigrateInfo{
 		Sender:            senderAddress.String(),
 		OldMigrateVersion: oldMigrateVersion,
 	}
-	res, gasUsed, err := k.wasmVM.MigrateWithInfo(newChecksum, env, msg, migrateInfo, vmStore, cosmwasmAPI, &querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
+	res, gasUsed, err := k.wasmVM.MigrateWithInfo(newCodeInfo.CodeHash, env, msg, migrateInfo, vmStore, cosmwasmAPI, &querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
 
 	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	gasReport.emit(sdkCtx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(sdkCtx, params, contractAddress, "migrate", gasUsed)
+	k.recordExecutionReceipt(sdkCtx, params, contractAddress, types.ExecutionReceiptKindMigrate, gasUsed, err == nil && res != nil && res.Err == "")
+	k.logContractCall(sdkCtx, contractAddress, newCodeID, "migrate", msg, gasUsed, err == nil && res != nil && res.Err == "")
 	if err != nil {
 		return nil, errorsmod.Wrap(types.ErrVMError, err.Error())
 	}
@@ -604,6 +806,7 @@ func (k Keeper) callMigrateEntrypoint(
 	if res.Err != "" {
 		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrMigrationFailed, res.Err))
 	}
+	k.traceVMCall(sdkCtx, "migrate", msg, res.Ok)
 	return res.Ok, nil
 }
 
@@ -615,6 +818,9 @@ func (k Keeper) callMigrateEntrypoint(
 // customized though by passing a new policy with the context. See types.WithSubMsgAuthzPolicy.
 // The policy will be read in msgServer.selectAuthorizationPolicy and used for sub-message executions.
 // This is an extension point for some very advanced scenarios only. Use with care!
+//
+// Suspended contracts are not rejected here, so that governance and module callbacks still reach them.
+// See PermissionedKeeper.Sudo for the non-privileged entry point.
 func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "sudo")
 
@@ -622,19 +828,26 @@ func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []
 	if err != nil {
 		return nil, err
 	}
-	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	sdkCtx, trace := k.startExecTrace(sdk.UnwrapSDKContext(ctx))
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(ctx, contractInfo.CodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
 
 	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: sudo")
 
-	env := types.NewEnv(sdkCtx, contractAddress)
+	sdkCtx, env := k.newEnv(sdkCtx, contractAddress)
 
 	// prepare querier
 	querier := k.newQueryHandler(sdkCtx, contractAddress)
+	params := k.GetParams(sdkCtx)
+	k.trackUsage(sdkCtx, params, contractAddress, "sudo")
 	gasLeft := k.runtimeGasForContract(sdkCtx)
+	gasReport := k.newVMGasReport(sdkCtx, contractAddress, "sudo", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	gasReport.emit(sdkCtx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(sdkCtx, params, contractAddress, "sudo", gasUsed)
+	k.recordExecutionReceipt(sdkCtx, params, contractAddress, types.ExecutionReceiptKindSudo, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(sdkCtx, contractAddress, contractInfo.CodeID, "sudo", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return nil, errorsmod.Wrap(types.ErrVMError, execErr.Error())
 	}
@@ -645,6 +858,7 @@ func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []
 	if res.Err != "" {
 		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
+	k.traceVMCall(sdkCtx, "sudo", msg, res.Ok)
 
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeSudo,
@@ -652,10 +866,11 @@ func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []
 	))
 
 	// sudo submessages are executed with the default authorization policy
-	data, err := k.handleContractResponse(sdkCtx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(sdkCtx, params, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "dispatch")
 	}
+	k.emitExecTrace(sdkCtx, contractAddress, trace)
 
 	return data, nil
 }
@@ -670,14 +885,20 @@ func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply was
 	replyCosts := k.gasRegister.ReplyCosts(true, reply)
 	ctx.GasMeter().ConsumeGas(replyCosts, "Loading CosmWasm module: reply")
 
-	env := types.NewEnv(ctx, contractAddress)
+	ctx, env := k.newEnv(ctx, contractAddress)
 
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddress, "reply")
 	gasLeft := k.runtimeGasForContract(ctx)
+	gasReport := k.newVMGasReport(ctx, contractAddress, "reply", gasLeft)
 
 	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddress, "reply", gasUsed)
+	k.logContractCall(ctx, contractAddress, contractInfo.CodeID, "reply", reply, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return nil, errorsmod.Wrap(types.ErrVMError, execErr.Error())
 	}
@@ -688,13 +909,14 @@ func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply was
 	if res.Err != "" {
 		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
+	k.traceVMCall(ctx, "reply", reply, res.Ok)
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeReply,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 	))
 
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(ctx, params, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "dispatch")
 	}
@@ -703,14 +925,22 @@ func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply was
 }
 
 // addToContractCodeSecondaryIndex adds element to the index for contracts-by-codeid queries
+// and increments the contract counter of the code
 func (k Keeper) addToContractCodeSecondaryIndex(ctx context.Context, contractAddress sdk.AccAddress, entry types.ContractCodeHistoryEntry) error {
 	store := k.storeService.OpenKVStore(ctx)
-	return store.Set(types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry), []byte{})
+	if err := store.Set(types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry), []byte{}); err != nil {
+		return err
+	}
+	return k.adjustContractCountByCode(ctx, entry.CodeID, 1)
 }
 
 // removeFromContractCodeSecondaryIndex removes element to the index for contracts-by-codeid queries
+// and decrements the contract counter of the code
 func (k Keeper) removeFromContractCodeSecondaryIndex(ctx context.Context, contractAddress sdk.AccAddress, entry types.ContractCodeHistoryEntry) error {
-	return k.storeService.OpenKVStore(ctx).Delete(types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry))
+	if err := k.storeService.OpenKVStore(ctx).Delete(types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry)); err != nil {
+		return err
+	}
+	return k.adjustContractCountByCode(ctx, entry.CodeID, -1)
 }
 
 // addToContractCreatorSecondaryIndex adds element to the index for contracts-by-creator queries
@@ -753,18 +983,69 @@ func (k Keeper) setContractAdmin(ctx context.Context, contractAddress, caller, n
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
 	}
-	newAdminStr := newAdmin.String()
+	return k.changeContractAdmin(sdkCtx, contractAddress, contractInfo, newAdmin)
+}
+
+// changeContractAdmin sets the new admin of the contract and clears a pending admin transfer. The caller must be
+// authorized.
+func (k Keeper) changeContractAdmin(sdkCtx sdk.Context, contractAddress sdk.AccAddress, contractInfo *types.ContractInfo, newAdmin sdk.AccAddress) error {
+	oldAdminStr, newAdminStr := contractInfo.Admin, newAdmin.String()
+	if err := k.removeFromContractAdminIndex(sdkCtx, contractInfo.AdminAddr(), contractAddress); err != nil {
+		return err
+	}
+	if err := k.addToContractAdminIndex(sdkCtx, newAdmin, contractAddress); err != nil {
+		return err
+	}
 	contractInfo.Admin = newAdminStr
+	contractInfo.PendingAdmin = ""
 	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	// a failing notification fails the admin change, so that both views stay consistent.
+	// A suspended contract is not called so that the admin can still be replaced during an incident.
+	var notified bool
+	if !contractInfo.Suspended {
+		var err error
+		if notified, err = k.notifyAdminChange(sdkCtx, contractAddress, oldAdminStr, newAdminStr); err != nil {
+			return err
+		}
+	}
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeUpdateContractAdmin,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 		sdk.NewAttribute(types.AttributeKeyNewAdmin, newAdminStr),
+		sdk.NewAttribute(types.AttributeKeyAdminChangeNotified, strconv.FormatBool(notified)),
 	))
 
 	return nil
 }
 
+// setContractAdmins sets the new admins of the contracts. Either all or none of the updates are applied.
+// The existence of all contracts is verified before any change.
+func (k Keeper) setContractAdmins(ctx context.Context, caller sdk.AccAddress, updates []types.ContractAdminUpdate, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractAddrs := make([]sdk.AccAddress, len(updates))
+	newAdmins := make([]sdk.AccAddress, len(updates))
+	for i, u := range updates {
+		var err error
+		if contractAddrs[i], err = sdk.AccAddressFromBech32(u.Contract); err != nil {
+			return errorsmod.Wrap(err, "contract")
+		}
+		if newAdmins[i], err = sdk.AccAddressFromBech32(u.NewAdmin); err != nil {
+			return errorsmod.Wrap(err, "new admin")
+		}
+		if !k.HasContractInfo(sdkCtx, contractAddrs[i]) {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unknown contract: %s", u.Contract)
+		}
+	}
+	cacheCtx, commit := sdkCtx.CacheContext()
+	for i := range updates {
+		if err := k.setContractAdmin(cacheCtx, contractAddrs[i], caller, newAdmins[i], authZ); err != nil {
+			return errorsmod.Wrapf(err, "contract %s", updates[i].Contract)
+		}
+	}
+	commit()
+	return nil
+}
+
 func (k Keeper) setContractLabel(ctx context.Context, contractAddress, caller sdk.AccAddress, newLabel string, authZ types.AuthorizationPolicy) error {
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
@@ -774,6 +1055,21 @@ func (k Keeper) setContractLabel(ctx context.Context, contractAddress, caller sd
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
 	}
+	if newLabel != contractInfo.Label {
+		creator, err := sdk.AccAddressFromBech32(contractInfo.Creator)
+		if err != nil {
+			return err
+		}
+		if err := k.checkUniqueLabel(sdkCtx, creator, newLabel, contractAddress); err != nil {
+			return err
+		}
+		if err := k.removeFromContractLabelIndex(sdkCtx, creator, contractInfo.Label, contractAddress); err != nil {
+			return err
+		}
+		if err := k.addToContractLabelIndex(sdkCtx, creator, newLabel, contractAddress); err != nil {
+			return err
+		}
+	}
 	contractInfo.Label = newLabel
 	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
@@ -864,10 +1160,10 @@ func (k Keeper) QuerySmart(ctx context.Context, contractAddr sdk.AccAddress, req
 	setupCost := k.gasRegister.SetupContractCost(discount, len(req))
 	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: query")
 
+	sdkCtx, env := k.newEnv(sdkCtx, contractAddr)
 	// prepare querier
 	querier := k.newQueryHandler(sdkCtx, contractAddr)
 
-	env := types.NewEnv(sdkCtx, contractAddr)
 	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), k.runtimeGasForContract(sdkCtx), costJSONDeserialization)
 	k.consumeRuntimeGas(sdkCtx, gasUsed)
 	if qErr != nil {
@@ -952,9 +1248,22 @@ func (k Keeper) contractInstance(ctx context.Context, contractAddress sdk.AccAdd
 	}
 	var codeInfo types.CodeInfo
 	k.cdc.MustUnmarshal(codeInfoBz, &codeInfo)
+	return contractInfo, codeInfo, k.contractVMStore(ctx, contractAddress, codeInfo), nil
+}
+
+// contractVMStore returns the contract state for wasmvm with the iterator mode of the code. The writes in
+// FinalizeBlock are passed on to the store write log when set.
+func (k Keeper) contractVMStore(ctx context.Context, contractAddress sdk.AccAddress, codeInfo types.CodeInfo) wasmvm.KVStore {
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), prefixStoreKey)
-	return contractInfo, codeInfo, types.NewStoreAdapter(prefixStore), nil
+	var vmStore wasmvm.KVStore = types.NewStoreAdapter(prefixStore)
+	if codeInfo.LegacyReverseIterator {
+		vmStore = types.NewLegacyReverseIteratorStoreAdapter(prefixStore)
+	}
+	if sdkCtx := sdk.UnwrapSDKContext(ctx); k.storeWriteLog != nil && sdkCtx.ExecMode() == sdk.ExecModeFinalize {
+		vmStore = newWriteLoggingStore(vmStore, k.storeWriteLog, sdkCtx, contractAddress)
+	}
+	return vmStore
 }
 
 func (k Keeper) LoadAsyncAckPacket(ctx context.Context, portID, channelID string, sequence uint64) (channeltypes.Packet, error) {
@@ -1022,6 +1331,21 @@ func (k Keeper) HasContractInfo(ctx context.Context, contractAddress sdk.AccAddr
 	return ok
 }
 
+// GetAddressType classifies the address as contract, module account, other account or not found
+func (k Keeper) GetAddressType(ctx context.Context, addr sdk.AccAddress) types.AddressType {
+	if k.HasContractInfo(ctx, addr) {
+		return types.AddressTypeContract
+	}
+	switch k.accountKeeper.GetAccount(ctx, addr).(type) {
+	case nil:
+		return types.AddressTypeNotFound
+	case sdk.ModuleAccountI:
+		return types.AddressTypeModuleAccount
+	default:
+		return types.AddressTypeBaseAccount
+	}
+}
+
 // mustStoreContractInfo persists the ContractInfo. No secondary index updated here.
 func (k Keeper) mustStoreContractInfo(ctx context.Context, contractAddress sdk.AccAddress, contract *types.ContractInfo) {
 	store := k.storeService.OpenKVStore(ctx)
@@ -1240,7 +1564,7 @@ func (k Keeper) setAccessConfig(ctx context.Context, codeID uint64, caller sdk.A
 	if info == nil {
 		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
 	}
-	isSubset := newConfig.Permission.IsSubset(k.getInstantiateAccessConfig(ctx))
+	isSubset := newConfig.Permission.IsSubset(k.getInstantiateAccessConfig(ctx, info.Origin))
 	if !authz.CanModifyCodeAccessConfig(sdk.MustAccAddressFromBech32(info.Creator), caller, isSubset) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify code access config")
 	}
@@ -1260,9 +1584,26 @@ func (k Keeper) setAccessConfig(ctx context.Context, codeID uint64, caller sdk.A
 	return nil
 }
 
+// setLegacyReverseIterator enables or disables the deprecated legacy reverse iterator mode for the contracts of a code
+func (k Keeper) setLegacyReverseIterator(ctx context.Context, codeID uint64, enabled bool) error {
+	info := k.GetCodeInfo(ctx, codeID)
+	if info == nil {
+		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	info.LegacyReverseIterator = enabled
+	k.mustStoreCodeInfo(ctx, codeID, *info)
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetLegacyReverseIter,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyEnabled, strconv.FormatBool(enabled)),
+	))
+	return nil
+}
+
 // handleContractResponse processes the contract response data by emitting events and sending sub-/messages.
 func (k *Keeper) handleContractResponse(
 	ctx sdk.Context,
+	params types.Params,
 	contractAddr sdk.AccAddress,
 	ibcPort string,
 	msgs []wasmvmtypes.SubMsg,
@@ -1270,6 +1611,9 @@ func (k *Keeper) handleContractResponse(
 	data []byte,
 	evts wasmvmtypes.Array[wasmvmtypes.Event],
 ) ([]byte, error) {
+	if err := checkResponseLimits(params, attrs, data, evts); err != nil {
+		return nil, err
+	}
 	attributeGasCost := k.gasRegister.EventCosts(attrs, evts)
 	ctx.GasMeter().ConsumeGas(attributeGasCost, "Custom contract event attributes")
 	// emit all events from this contract itself
@@ -1290,6 +1634,27 @@ func (k *Keeper) handleContractResponse(
 	return k.wasmVMResponseHandler.Handle(ctx, contractAddr, ibcPort, msgs, data)
 }
 
+// checkResponseLimits ensures that the contract response is within the response limits of the module params.
+// The wasmvm rejects larger responses already with an untyped error, the params can set stricter caps.
+// The limits are taken from the params that were loaded for the contract call.
+func checkResponseLimits(params types.Params, attrs []wasmvmtypes.EventAttribute, data []byte, evts wasmvmtypes.Array[wasmvmtypes.Event]) error {
+	maxDataSize, maxEvents, maxAttributes := params.ResponseLimits()
+	if n := uint64(len(data)); n > maxDataSize {
+		return types.ResponseTooLargeError{Limit: types.ResponseLimitData, Max: maxDataSize, Actual: n}
+	}
+	if n := uint64(len(evts)); n > maxEvents {
+		return types.ResponseTooLargeError{Limit: types.ResponseLimitEvents, Max: maxEvents, Actual: n}
+	}
+	attrCount := uint64(len(attrs))
+	for _, e := range evts {
+		attrCount += uint64(len(e.Attributes))
+	}
+	if attrCount > maxAttributes {
+		return types.ResponseTooLargeError{Limit: types.ResponseLimitAttributes, Max: maxAttributes, Actual: attrCount}
+	}
+	return nil
+}
+
 func (k Keeper) runtimeGasForContract(ctx sdk.Context) uint64 {
 	meter := ctx.GasMeter()
 	if meter.IsOutOfGas() {
@@ -1303,6 +1668,9 @@ func (k Keeper) runtimeGasForContract(ctx sdk.Context) uint64 {
 
 func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gas uint64) {
 	consumed := k.gasRegister.FromWasmVMGas(gas)
+	if consumed >= ctx.GasMeter().GasRemaining() {
+		countVMOutOfGas(ctx)
+	}
 	ctx.GasMeter().ConsumeGas(consumed, "wasm contract")
 	// throw OutOfGas error if we ran out (got exactly to zero due to better limit enforcing)
 	if ctx.GasMeter().IsOutOfGas() {
@@ -1380,15 +1748,35 @@ func (k Keeper) importContract(ctx context.Context, contractAddr sdk.AccAddress,
 	if err != nil {
 		return err
 	}
+	k.incrementContractCount(ctx)
 	err = k.addToContractCreatorSecondaryIndex(ctx, creatorAddress, historyEntries[0].Updated, contractAddr)
 	if err != nil {
 		return err
 	}
+	err = k.addToContractLabelIndex(ctx, creatorAddress, c.Label, contractAddr)
+	if err != nil {
+		return err
+	}
+	err = k.addToContractTagIndex(ctx, c.Tags, contractAddr)
+	if err != nil {
+		return err
+	}
+	err = k.addToContractAdminIndex(ctx, c.AdminAddr(), contractAddr)
+	if err != nil {
+		return err
+	}
+	if c.SunsetHeight != 0 && !c.Inactive {
+		if err := k.addToContractSunsetIndex(ctx, c.SunsetHeight, contractAddr); err != nil {
+			return err
+		}
+	}
 	return k.importContractState(ctx, contractAddr, state)
 }
 
 func (k Keeper) newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress) QueryHandler {
-	return NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
+	h := NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
+	h.depthGasPercent = k.queryDepthGasPercent
+	return h
 }
 
 // MultipliedGasMeter wraps the GasMeter from context and multiplies all reads by out defined multiplier
@@ -1530,3 +1918,33 @@ func (h DefaultWasmVMContractResponseHandler) Handle(ctx sdk.Context, contractAd
 	}
 	return result, nil
 }
+
+// missingCapabilities returns the required capabilities that are not available on the chain
+func (k Keeper) missingCapabilities(required []string) []string {
+	var missing []string
+	for _, c := range required {
+		if _, ok := k.availableCapabilities[c]; !ok {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+// parseCapabilities converts the comma separated capabilities of a wasmvm analysis report
+func parseCapabilities(s string) []string {
+	var result []string
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func asCapabilitySet(capabilities []string) map[string]struct{} {
+	result := make(map[string]struct{}, len(capabilities))
+	for _, c := range capabilities {
+		result[c] = struct{}{}
+	}
+	return result
+}
@@ -8,7 +8,6 @@ import (
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 
-	"cosmossdk.io/collections"
 	corestoretypes "cosmossdk.io/core/store"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -29,7 +28,7 @@ func NewKeeper(
 	channelKeeper types.ChannelKeeper,
 	portSource types.ICS20TransferPortSource,
 	router MessageRouter,
-	_ GRPCQueryRouter,
+	queryRouter GRPCQueryRouter,
 	homeDir string,
 	nodeConfig types.NodeConfig,
 	vmConfig types.VMConfig,
@@ -37,56 +36,33 @@ func NewKeeper(
 	authority string,
 	opts ...Option,
 ) Keeper {
-	sb := collections.NewSchemaBuilder(storeService)
-	keeper := &Keeper{
-		storeService:         storeService,
-		cdc:                  cdc,
-		wasmVM:               nil,
-		accountKeeper:        accountKeeper,
-		bank:                 NewBankCoinTransferrer(bankKeeper),
-		accountPruner:        NewVestingCoinBurner(bankKeeper),
-		queryGasLimit:        nodeConfig.SmartQueryGasLimit,
-		gasRegister:          types.NewDefaultWasmGasRegister(),
-		maxQueryStackSize:    types.DefaultMaxQueryStackSize,
-		maxCallDepth:         types.DefaultMaxCallDepth,
-		acceptedAccountTypes: defaultAcceptedAccountTypes,
-		params:               collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
-		propagateGovAuthorization: map[types.AuthorizationPolicyAction]struct{}{
-			types.AuthZActionInstantiate: {},
+	return newKeeper(
+		cdc,
+		storeService,
+		accountKeeper,
+		bankKeeper,
+		stakingKeeper,
+		distrKeeper,
+		ics4Wrapper,
+		channelKeeper,
+		portSource,
+		router,
+		queryRouter,
+		nodeConfig,
+		vmConfig,
+		availableCapabilities,
+		authority,
+		func() (types.WasmEngine, error) {
+			return wasmvm.NewVMWithConfig(wasmvmtypes.VMConfig{
+				Cache: wasmvmtypes.CacheOptions{
+					BaseDir:                  filepath.Join(homeDir, "wasm"),
+					AvailableCapabilities:    availableCapabilities,
+					MemoryCacheSizeBytes:     wasmvmtypes.NewSizeMebi(nodeConfig.MemoryCacheSize),
+					InstanceMemoryLimitBytes: wasmvmtypes.NewSizeMebi(contractMemoryLimit),
+				},
+				WasmLimits: vmConfig.WasmLimits,
+			}, nodeConfig.ContractDebugMode)
 		},
-		authority:  authority,
-		wasmLimits: vmConfig.WasmLimits,
-	}
-	keeper.messenger = NewDefaultMessageHandler(keeper, router, ics4Wrapper, channelKeeper, bankKeeper, cdc, portSource)
-	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distrKeeper, channelKeeper, keeper)
-	preOpts, postOpts := splitOpts(opts)
-	for _, o := range preOpts {
-		o.apply(keeper)
-	}
-	// always wrap the messenger, even if it was replaced by an option
-	keeper.messenger = callDepthMessageHandler{keeper.messenger, keeper.maxCallDepth}
-	// only set the wasmvm if no one set this in the options
-	// NewVM does a lot, so better not to create it and silently drop it.
-	if keeper.wasmVM == nil {
-		var err error
-		keeper.wasmVM, err = wasmvm.NewVMWithConfig(wasmvmtypes.VMConfig{
-			Cache: wasmvmtypes.CacheOptions{
-				BaseDir:                  filepath.Join(homeDir, "wasm"),
-				AvailableCapabilities:    availableCapabilities,
-				MemoryCacheSizeBytes:     wasmvmtypes.NewSizeMebi(nodeConfig.MemoryCacheSize),
-				InstanceMemoryLimitBytes: wasmvmtypes.NewSizeMebi(contractMemoryLimit),
-			},
-			WasmLimits: vmConfig.WasmLimits,
-		}, nodeConfig.ContractDebugMode)
-		if err != nil {
-			panic(err)
-		}
-	}
-
-	for _, o := range postOpts {
-		o.apply(keeper)
-	}
-	// not updatable, yet
-	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
-	return *keeper
+		opts...,
+	)
 }
@@ -31,5 +31,5 @@ func NewKeeper(
 	authority string,
 	opts ...Option,
 ) Keeper {
-	panic("not implemented, please build with cgo enabled")
+	panic("not implemented, please build with cgo enabled or use NewKeeperWithVM")
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	stdrand "math/rand"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -398,6 +399,27 @@ func TestCreateWithBrokenGzippedPayload(t *testing.T) {
 	assert.GreaterOrEqual(t, gm.GasConsumed(), storetypes.Gas(121384)) // 809232 * 0.15 (default uncompress costs) = 121384
 }
 
+func TestCreateUnauthorizedGzippedPayload(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	params := types.DefaultParams()
+	params.CodeUploadAccess = types.AllowNobody
+	params.InstantiateDefaultPermission = types.AccessTypeNobody
+	require.NoError(t, keepers.WasmKeeper.SetParams(ctx, params))
+
+	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm.gzip")
+	require.NoError(t, err, "reading gzipped WASM code")
+
+	// when not allowed to set this instantiate permission even with an upload approval
+	instantiateAccess := types.AllowEverybody
+	gm := storetypes.NewInfiniteGasMeter()
+	_, _, err = keepers.ContractKeeper.Create(ctx.WithGasMeter(gm), RandomAccountAddress(t), wasmCode, &instantiateAccess)
+
+	// then
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	// and no uncompress costs were charged
+	assert.Less(t, gm.GasConsumed(), keepers.WasmKeeper.GetGasRegister().UncompressCosts(len(wasmCode)))
+}
+
 func TestInstantiate(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 
@@ -423,7 +445,7 @@ func TestInstantiate(t *testing.T) {
 
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x1bcb0), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x204fd), gasAfter-gasBefore)
 	}
 
 	// ensure it is stored properly
@@ -447,6 +469,8 @@ func TestInstantiate(t *testing.T) {
 			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("code_id", "1")),
 		sdk.NewEvent("wasm",
 			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("Let the", "hacking begin")),
+		sdk.NewEvent("exec_trace",
+			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("exec_trace_hash", execTraceHash("instantiate", initMsgBz, nil, 0, 0))),
 	}
 	assert.Equal(t, expEvt, em.Events())
 }
@@ -714,6 +738,52 @@ func TestInstantiateWithNonExistingCodeID(t *testing.T) {
 	require.Nil(t, addr)
 }
 
+func TestInstantiateWithMissingCapabilities(t *testing.T) {
+	specs := map[string]struct {
+		removeCapability bool
+		disableCheck     bool
+		expErr           *errorsmod.Error
+	}{
+		"all capabilities available": {},
+		"capability removed after store": {
+			removeCapability: true,
+			expErr:           types.ErrMissingCapabilities,
+		},
+		"capability removed after store - check disabled": {
+			removeCapability: true,
+			disableCheck:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+			k := keepers.WasmKeeper
+			creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+			codeID, _, err := keepers.ContractKeeper.Create(ctx, creator, testdata.ReflectContractWasm(), nil)
+			require.NoError(t, err)
+			require.Contains(t, k.GetCodeInfo(ctx, codeID).RequiredCapabilities, "staking")
+
+			if spec.removeCapability {
+				delete(k.availableCapabilities, "staking")
+			}
+			params := k.GetParams(ctx)
+			params.DisableInstantiateCapabilityCheck = spec.disableCheck
+			require.NoError(t, k.SetParams(ctx, params))
+
+			// when
+			_, _, gotErr := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, []byte("{}"), "reflect", nil)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.Contains(t, gotErr.Error(), "staking")
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
 func TestContractErrorRedacting(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 
@@ -960,7 +1030,7 @@ func TestExecute(t *testing.T) {
 	// make sure gas is properly deducted from ctx
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x1ace0), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x1c202), gasAfter-gasBefore)
 	}
 	// ensure bob now exists and got both payments released
 	bobAcct = accKeeper.GetAccount(ctx, bob)
@@ -974,7 +1044,7 @@ func TestExecute(t *testing.T) {
 	assert.Equal(t, sdk.Coins{}, bankKeeper.GetAllBalances(ctx, contractAcct.GetAddress()))
 
 	// and events emitted
-	require.Len(t, em.Events(), 9)
+	require.Len(t, em.Events(), 10)
 	expEvt := sdk.NewEvent("execute",
 		sdk.NewAttribute("_contract_address", addr.String()))
 	assert.Equal(t, expEvt, em.Events()[3], prettyEvents(t, em.Events()))
@@ -1562,6 +1632,13 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 				{"amount": "100000denom"},
 			},
 		},
+		{
+			"Type": "exec_trace",
+			"Attr": []dict{
+				{"_contract_address": contractAddr},
+				{"exec_trace_hash": execTraceHash("migrate", migMsgBz, nil, 0, 1)},
+			},
+		},
 	}
 	expJSONEvts := string(mustMarshal(t, expEvents))
 	assert.JSONEq(t, expJSONEvts, prettyEvents(t, ctx.EventManager().Events()), prettyEvents(t, ctx.EventManager().Events()))
@@ -1718,7 +1795,7 @@ func TestSudo(t *testing.T) {
 	balance := bankKeeper.GetBalance(ctx, comAcct.GetAddress(), "denom")
 	assert.Equal(t, sdk.NewInt64Coin("denom", 76543), balance)
 	// and events emitted
-	require.Len(t, em.Events(), 4, prettyEvents(t, em.Events()))
+	require.Len(t, em.Events(), 5, prettyEvents(t, em.Events()))
 	expEvt := sdk.NewEvent("sudo",
 		sdk.NewAttribute("_contract_address", addr.String()))
 	assert.Equal(t, expEvt, em.Events()[0])
@@ -1819,6 +1896,78 @@ func TestUpdateContractAdmin(t *testing.T) {
 	}
 }
 
+func TestSetContractAdmins(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+
+	creator := keepers.Faucet.NewFundedRandomAccount(parentCtx, sdk.NewInt64Coin("denom", 100000))
+	fred := RandomAccountAddress(t)
+	codeID, _, err := keepers.ContractKeeper.Create(parentCtx, creator, hackatomWasm, nil)
+	require.NoError(t, err)
+	initMsgBz := HackatomExampleInitMsg{Verifier: fred, Beneficiary: fred}.GetBytes(t)
+	myContract, _, err := keepers.ContractKeeper.Instantiate(parentCtx, codeID, creator, creator, initMsgBz, "my", nil)
+	require.NoError(t, err)
+	otherContract, _, err := keepers.ContractKeeper.Instantiate(parentCtx, codeID, creator, creator, initMsgBz, "other", nil)
+	require.NoError(t, err)
+	fredsContract, _, err := keepers.ContractKeeper.Instantiate(parentCtx, codeID, creator, fred, initMsgBz, "freds", nil)
+	require.NoError(t, err)
+	newAdmin := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		updates   []types.ContractAdminUpdate
+		expErr    *errorsmod.Error
+		expAdmins []sdk.AccAddress
+	}{
+		"all updated": {
+			updates: []types.ContractAdminUpdate{
+				{Contract: myContract.String(), NewAdmin: newAdmin.String()},
+				{Contract: otherContract.String(), NewAdmin: newAdmin.String()},
+			},
+			expAdmins: []sdk.AccAddress{newAdmin, newAdmin, fred},
+		},
+		"unknown contract": {
+			updates: []types.ContractAdminUpdate{
+				{Contract: myContract.String(), NewAdmin: newAdmin.String()},
+				{Contract: RandomBech32AccountAddress(t), NewAdmin: newAdmin.String()},
+			},
+			expErr:    sdkerrors.ErrInvalidRequest,
+			expAdmins: []sdk.AccAddress{creator, creator, fred},
+		},
+		"mid-list failure": {
+			updates: []types.ContractAdminUpdate{
+				{Contract: myContract.String(), NewAdmin: newAdmin.String()},
+				// creator is not the admin
+				{Contract: fredsContract.String(), NewAdmin: newAdmin.String()},
+				{Contract: otherContract.String(), NewAdmin: newAdmin.String()},
+			},
+			expErr:    sdkerrors.ErrUnauthorized,
+			expAdmins: []sdk.AccAddress{creator, creator, fred},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			// when the creator updates with the default policy
+			gotErr := k.setContractAdmins(ctx, creator, spec.updates, DefaultAuthorizationPolicy{})
+
+			// then
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), "expected %v but got %+v", spec.expErr, gotErr)
+				assert.Empty(t, em.Events())
+			} else {
+				require.NoError(t, gotErr)
+				assert.Len(t, em.Events(), len(spec.updates))
+			}
+			for i, c := range []sdk.AccAddress{myContract, otherContract, fredsContract} {
+				assert.Equal(t, spec.expAdmins[i].String(), k.GetContractInfo(ctx, c).Admin)
+			}
+		})
+	}
+}
+
 func TestClearContractAdmin(t *testing.T) {
 	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.ContractKeeper
@@ -2014,8 +2163,8 @@ func TestPinnedContractLoops(t *testing.T) {
 			},
 		}, 0, nil
 	}
-	ctx = ctx.WithGasMeter(storetypes.NewGasMeter(30_000))
-	require.PanicsWithValue(t, storetypes.ErrorOutOfGas{Descriptor: "ReadFlat"}, func() {
+	ctx = ctx.WithGasMeter(storetypes.NewGasMeter(40_000))
+	require.PanicsWithValue(t, storetypes.ErrorOutOfGas{Descriptor: "ReadPerByte"}, func() {
 		_, err := k.execute(ctx, example.Contract, RandomAccountAddress(t), anyMsg, nil)
 		require.NoError(t, err)
 	})
@@ -2754,8 +2903,9 @@ func TestSetContractAdmin(t *testing.T) {
 			require.Len(t, em.Events(), 1)
 			assert.Equal(t, "update_contract_admin", em.Events()[0].Type)
 			exp := map[string]string{
-				"_contract_address": example.Contract.String(),
-				"new_admin_address": spec.expAdmin,
+				"_contract_address":     example.Contract.String(),
+				"new_admin_address":     spec.expAdmin,
+				"admin_change_notified": "false",
 			}
 			assert.Equal(t, exp, attrsToStringMap(em.Events()[0].Attributes))
 		})
@@ -2984,3 +3134,54 @@ func TestCheckDiscountEligibility(t *testing.T) {
 		})
 	}
 }
+
+func TestSetLegacyReverseIterator(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	_, _, contractStore, err := k.contractInstance(ctx, example.Contract)
+	require.NoError(t, err)
+	contractStore.Set([]byte("x1"), []byte("1"))
+	contractStore.Set([]byte("x2"), []byte("2"))
+	reverseKeys := func() []string {
+		_, _, vmStore, err := k.contractInstance(ctx, example.Contract)
+		require.NoError(t, err)
+		iter := vmStore.ReverseIterator([]byte("x"), []byte("x2"))
+		defer iter.Close()
+		var res []string
+		for ; iter.Valid(); iter.Next() {
+			res = append(res, string(iter.Key()))
+		}
+		return res
+	}
+	// default: exclusive end
+	require.Equal(t, []string{"x1"}, reverseKeys())
+
+	// other addresses can not enable the mode
+	_, err = msgServer.SetLegacyReverseIterator(ctx, &types.MsgSetLegacyReverseIterator{Authority: example.CreatorAddr.String(), CodeID: example.CodeID, Enabled: true})
+	require.ErrorIs(t, err, types.ErrInvalid)
+	require.Equal(t, []string{"x1"}, reverseKeys())
+
+	// unknown code
+	_, err = msgServer.SetLegacyReverseIterator(ctx, &types.MsgSetLegacyReverseIterator{Authority: k.GetAuthority(), CodeID: 99, Enabled: true})
+	require.ErrorIs(t, err, types.ErrNoSuchCodeFn(99))
+
+	// when enabled by the authority
+	em := sdk.NewEventManager()
+	_, err = msgServer.SetLegacyReverseIterator(ctx.WithEventManager(em), &types.MsgSetLegacyReverseIterator{Authority: k.GetAuthority(), CodeID: example.CodeID, Enabled: true})
+	require.NoError(t, err)
+	// then the end is inclusive
+	assert.Equal(t, []string{"x2", "x1"}, reverseKeys())
+	assert.True(t, k.GetCodeInfo(ctx, example.CodeID).LegacyReverseIterator)
+	assert.Equal(t, sdk.Events{sdk.NewEvent(
+		types.EventTypeSetLegacyReverseIter,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(example.CodeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyEnabled, "true"),
+	)}, em.Events())
+
+	// and when disabled again
+	_, err = msgServer.SetLegacyReverseIterator(ctx, &types.MsgSetLegacyReverseIterator{Authority: k.GetAuthority(), CodeID: example.CodeID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x1"}, reverseKeys())
+}
@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"sync/atomic"
+
+	"cosmossdk.io/collections"
+	corestoretypes "cosmossdk.io/core/store"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// NewKeeperWithVM creates a new contract Keeper instance with the given wasm engine.
+// Unlike NewKeeper, this does not load the wasmvm library and works without cgo. For example in unit tests
+// with the wasmtesting.MockWasmVM.
+func NewKeeperWithVM(
+	cdc codec.Codec,
+	storeService corestoretypes.KVStoreService,
+	accountKeeper types.AccountKeeper,
+	bankKeeper types.BankKeeper,
+	stakingKeeper types.StakingKeeper,
+	distrKeeper types.DistributionKeeper,
+	ics4Wrapper types.ICS4Wrapper,
+	channelKeeper types.ChannelKeeper,
+	portSource types.ICS20TransferPortSource,
+	router MessageRouter,
+	queryRouter GRPCQueryRouter,
+	vm types.WasmEngine,
+	nodeConfig types.NodeConfig,
+	vmConfig types.VMConfig,
+	availableCapabilities []string,
+	authority string,
+	opts ...Option,
+) Keeper {
+	if vm == nil {
+		panic("wasm engine must not be nil")
+	}
+	return newKeeper(
+		cdc,
+		storeService,
+		accountKeeper,
+		bankKeeper,
+		stakingKeeper,
+		distrKeeper,
+		ics4Wrapper,
+		channelKeeper,
+		portSource,
+		router,
+		queryRouter,
+		nodeConfig,
+		vmConfig,
+		availableCapabilities,
+		authority,
+		func() (types.WasmEngine, error) { return vm, nil },
+		opts...,
+	)
+}
+
+// newKeeper creates a new contract Keeper instance. The wasm engine is created with newWasmEngine
+// unless it is set by an option.
+func newKeeper(
+	cdc codec.Codec,
+	storeService corestoretypes.KVStoreService,
+	accountKeeper types.AccountKeeper,
+	bankKeeper types.BankKeeper,
+	stakingKeeper types.StakingKeeper,
+	distrKeeper types.DistributionKeeper,
+	ics4Wrapper types.ICS4Wrapper,
+	channelKeeper types.ChannelKeeper,
+	portSource types.ICS20TransferPortSource,
+	router MessageRouter,
+	queryRouter GRPCQueryRouter,
+	nodeConfig types.NodeConfig,
+	vmConfig types.VMConfig,
+	availableCapabilities []string,
+	authority string,
+	newWasmEngine func() (types.WasmEngine, error),
+	opts ...Option,
+) Keeper {
+	sb := collections.NewSchemaBuilder(storeService)
+	keeper := &Keeper{
+		storeService:         storeService,
+		cdc:                  cdc,
+		wasmVM:               nil,
+		accountKeeper:        accountKeeper,
+		channelKeeper:        channelKeeper,
+		bank:                 NewBankCoinTransferrer(bankKeeper),
+		accountPruner:        NewVestingCoinBurner(bankKeeper),
+		queryGasLimit:        nodeConfig.SmartQueryGasLimit,
+		queryDepthGasPercent: nodeConfig.SmartQueryDepthGasPercent,
+		gasRegister:          types.NewDefaultWasmGasRegister(),
+		maxQueryStackSize:    types.DefaultMaxQueryStackSize,
+		maxCallDepth:         types.DefaultMaxCallDepth,
+		codeUploadTTL:        DefaultCodeUploadTTL,
+		subscriberGasLimit:   DefaultParamSubscriberGasLimit,
+		acceptedAccountTypes: defaultAcceptedAccountTypes,
+		params:               collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
+		propagateGovAuthorization: map[types.AuthorizationPolicyAction]struct{}{
+			types.AuthZActionInstantiate: {},
+		},
+		queryRouter:           queryRouter,
+		authority:             authority,
+		wasmLimits:            vmConfig.WasmLimits,
+		availableCapabilities: asCapabilitySet(availableCapabilities),
+		execLogLevel:          nodeConfig.ExecutionLogLevel,
+		execLogSeq:            &atomic.Uint64{},
+		blockExecTime:         newBlockExecTime(),
+	}
+	if nodeConfig.SignedQueryBlockWindow > 0 {
+		keeper.recentBlockHashes = newRecentBlockHashes(nodeConfig.SignedQueryBlockWindow)
+	}
+	keeper.messenger = NewDefaultMessageHandler(keeper, router, ics4Wrapper, channelKeeper, bankKeeper, cdc, portSource)
+	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distrKeeper, channelKeeper, keeper)
+	preOpts, postOpts := splitOpts(opts)
+	for _, o := range preOpts {
+		o.apply(keeper)
+	}
+	// always wrap the messenger, even if it was replaced by an option
+	keeper.messenger = callDepthMessageHandler{keeper.messenger, keeper.maxCallDepth}
+	// always meter the stargate and gRPC query responses, even if the plugins were replaced by an option
+	if q, ok := keeper.wasmVMQueryHandler.(QueryPlugins); ok {
+		keeper.wasmVMQueryHandler = q.Merge(&QueryPlugins{
+			Stargate: GasMeteredStargateQuerier(keeper.gasRegister, q.Stargate),
+			Grpc:     GasMeteredGrpcQuerier(keeper.gasRegister, q.Grpc),
+		})
+	}
+	// only set the wasmvm if no one set this in the options
+	// NewVM does a lot, so better not to create it and silently drop it.
+	if keeper.wasmVM == nil {
+		var err error
+		keeper.wasmVM, err = newWasmEngine()
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for _, o := range postOpts {
+		o.apply(keeper)
+	}
+	// not updatable, yet
+	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
+	return *keeper
+}
@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// feeScheduleSource provides the message fees of the wasm params
+type feeScheduleSource interface {
+	GetParams(ctx context.Context) types.Params
+}
+
+// FeeChargingMessageDecorator charges the flat message fees of the wasm params to the contract before
+// the message is dispatched.
+type FeeChargingMessageDecorator struct {
+	nested           Messenger
+	params           feeScheduleSource
+	bank             types.Burner
+	feeCollectorName string
+	encoders         msgEncoder
+}
+
+// NewFeeChargingMessageDecorator constructor to be used with the `WithMessageHandlerDecorator` option.
+// The fees are sent from the contract to the module account with the fee collector name. The encoders
+// resolve the sdk message type urls and should be the same that the message handler uses. Messages that
+// they can not encode are dispatched without a fee.
+func NewFeeChargingMessageDecorator(params feeScheduleSource, bank types.Burner, feeCollectorName string, encoders msgEncoder) func(old Messenger) Messenger {
+	return func(nested Messenger) Messenger {
+		return &FeeChargingMessageDecorator{
+			nested:           nested,
+			params:           params,
+			bank:             bank,
+			feeCollectorName: feeCollectorName,
+			encoders:         encoders,
+		}
+	}
+}
+
+// DispatchMsg charges the fees for the encoded messages and dispatches the message with the nested handler.
+func (d FeeChargingMessageDecorator) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, [][]*codectypes.Any, error) {
+	feeEvents, err := d.chargeFees(ctx, contractAddr, contractIBCPortID, msg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	events, data, msgResponses, err := d.nested.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+	if err != nil {
+		return events, data, msgResponses, err
+	}
+	return append(feeEvents, events...), data, msgResponses, nil
+}
+
+func (d FeeChargingMessageDecorator) chargeFees(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, error) {
+	params := d.params.GetParams(ctx)
+	if len(params.MessageFees) == 0 {
+		return nil, nil
+	}
+	sdkMsgs, err := d.encoders.Encode(ctx, contractAddr, contractIBCPortID, msg)
+	switch {
+	case errors.Is(err, types.ErrUnknownMsg):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	var events []sdk.Event
+	for _, sdkMsg := range sdkMsgs {
+		typeURL := sdk.MsgTypeURL(sdkMsg)
+		fee := params.MessageFeeFor(typeURL)
+		if fee.IsZero() {
+			continue
+		}
+		if err := d.bank.SendCoinsFromAccountToModule(ctx, contractAddr, d.feeCollectorName, fee); err != nil {
+			return nil, errorsmod.Wrapf(err, "message fee for %s", typeURL)
+		}
+		events = append(events, sdk.NewEvent(
+			types.EventTypeMessageFee,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyMsgTypeURL, typeURL),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, fee.String()),
+		))
+	}
+	return events, nil
+}
@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestFeeChargingMessageDecorator(t *testing.T) {
+	ctx, keepers := CreateDefaultTestInput(t)
+	k := keepers.WasmKeeper
+	params := k.GetParams(ctx)
+	params.MessageFees = []types.MessageFee{
+		{TypeURL: "/cosmos.bank.v1beta1.MsgSend", Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))},
+		{TypeURL: "/cosmos.distribution.v1beta1.MsgSetWithdrawAddress"},
+	}
+	require.NoError(t, k.SetParams(ctx, params))
+	feeCollector := keepers.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	otherAddr := RandomAccountAddress(t)
+
+	myEvent := sdk.NewEvent("nested")
+	nested := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(sdk.Context, sdk.AccAddress, string, wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, [][]*codectypes.Any, error) {
+			return []sdk.Event{myEvent}, nil, nil, nil
+		},
+	}
+	encoders := DefaultEncoders(keepers.EncodingConfig.Codec, wasmtesting.MockIBCTransferKeeper{})
+	decorator := NewFeeChargingMessageDecorator(k, keepers.BankKeeper, authtypes.FeeCollectorName, encoders)(nested)
+
+	specs := map[string]struct {
+		balance   sdk.Coins
+		msg       wasmvmtypes.CosmosMsg
+		expFee    sdk.Coins
+		expErr    error
+		expEvents []sdk.Event
+	}{
+		"fee charged": {
+			balance: sdk.NewCoins(sdk.NewInt64Coin("stake", 101)),
+			msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+				ToAddress: otherAddr.String(),
+				Amount:    wasmvmtypes.Array[wasmvmtypes.Coin]{{Denom: "stake", Amount: "1"}},
+			}}},
+			expFee: sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		},
+		"free type": {
+			balance: sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+			msg: wasmvmtypes.CosmosMsg{Distribution: &wasmvmtypes.DistributionMsg{SetWithdrawAddress: &wasmvmtypes.SetWithdrawAddressMsg{
+				Address: otherAddr.String(),
+			}}},
+			expEvents: []sdk.Event{myEvent},
+		},
+		"type not in schedule": {
+			balance: sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+			msg: wasmvmtypes.CosmosMsg{Distribution: &wasmvmtypes.DistributionMsg{WithdrawDelegatorReward: &wasmvmtypes.WithdrawDelegatorRewardMsg{
+				Validator: sdk.ValAddress(otherAddr).String(),
+			}}},
+			expEvents: []sdk.Event{myEvent},
+		},
+		"insufficient balance": {
+			balance: sdk.NewCoins(sdk.NewInt64Coin("stake", 99)),
+			msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+				ToAddress: otherAddr.String(),
+				Amount:    wasmvmtypes.Array[wasmvmtypes.Coin]{{Denom: "stake", Amount: "1"}},
+			}}},
+			expErr: sdkerrors.ErrInsufficientFunds,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			contractAddr := keepers.Faucet.NewFundedRandomAccount(ctx, spec.balance...)
+			feesBefore := keepers.BankKeeper.GetAllBalances(ctx, feeCollector)
+
+			// when
+			events, _, _, gotErr := decorator.DispatchMsg(ctx, contractAddr, "", spec.msg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.balance.Sub(spec.expFee...), keepers.BankKeeper.GetAllBalances(ctx, contractAddr))
+			assert.Equal(t, feesBefore.Add(spec.expFee...), keepers.BankKeeper.GetAllBalances(ctx, feeCollector))
+			expEvents := spec.expEvents
+			if !spec.expFee.IsZero() {
+				expEvents = []sdk.Event{sdk.NewEvent(
+					types.EventTypeMessageFee,
+					sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+					sdk.NewAttribute(types.AttributeKeyMsgTypeURL, "/cosmos.bank.v1beta1.MsgSend"),
+					sdk.NewAttribute(sdk.AttributeKeyAmount, spec.expFee.String()),
+				), myEvent}
+			}
+			assert.Equal(t, expEvents, events)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// The tests in this file use the MockWasmVM and run without cgo:
+// CGO_ENABLED=0 go test ./x/wasm/keeper/ -run TestMockVM
+
+func TestMockVMExecuteEmitsEvents(t *testing.T) {
+	vm := wasmtesting.NewMockWasmVM()
+	parentCtx, keepers := CreateTestInputWithVM(t, false, AvailableCapabilities, vm)
+	example := SeedNewContractInstance(t, parentCtx, keepers, vm)
+	vm.SetResponse(example.Checksum, "execute", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+		Attributes: []wasmvmtypes.EventAttribute{{Key: "action", Value: "mock"}},
+		Events:     []wasmvmtypes.Event{{Type: "custom", Attributes: []wasmvmtypes.EventAttribute{{Key: "foo", Value: "bar"}}}},
+		Data:       []byte("my data"),
+	}})
+	ctx := parentCtx.WithEventManager(sdk.NewEventManager())
+
+	// when
+	gotData, gotErr := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{"do":{}}`), nil)
+
+	// then
+	require.NoError(t, gotErr)
+	assert.Equal(t, []byte("my data"), gotData)
+	calls := vm.CallsOf("execute")
+	require.Len(t, calls, 1)
+	assert.Equal(t, []byte(`{"do":{}}`), calls[0].Msg)
+	assert.Equal(t, example.CreatorAddr.String(), calls[0].Info.Sender)
+	assert.Equal(t, example.Contract.String(), calls[0].Env.Contract.Address)
+
+	expEvents := sdk.Events{
+		sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", example.Contract.String())),
+		sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("action", "mock")),
+		sdk.NewEvent("wasm-custom", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("foo", "bar")),
+		sdk.NewEvent("exec_trace", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("exec_trace_hash", execTraceHash("execute", []byte(`{"do":{}}`), []byte("my data"), 1, 0))),
+	}
+	assert.Equal(t, expEvents, ctx.EventManager().Events())
+}
+
+func TestMockVMSubmessageReply(t *testing.T) {
+	specs := map[string]struct {
+		subMsg      wasmvmtypes.SubMsg
+		subMsgFails bool
+		expReply    bool
+		expErr      bool
+	}{
+		"success with reply": {
+			subMsg:   wasmvmtypes.SubMsg{ReplyOn: wasmvmtypes.ReplyAlways},
+			expReply: true,
+		},
+		"error with reply": {
+			subMsg:      wasmvmtypes.SubMsg{ReplyOn: wasmvmtypes.ReplyError},
+			subMsgFails: true,
+			expReply:    true,
+		},
+		"success without reply": {
+			subMsg: wasmvmtypes.SubMsg{ReplyOn: wasmvmtypes.ReplyError},
+		},
+		"error without reply": {
+			subMsg:      wasmvmtypes.SubMsg{ReplyOn: wasmvmtypes.ReplySuccess},
+			subMsgFails: true,
+			expErr:      true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			vm := wasmtesting.NewMockWasmVM()
+			parentCtx, keepers := CreateTestInputWithVM(t, false, AvailableCapabilities, vm)
+			caller := SeedNewContractInstance(t, parentCtx, keepers, vm)
+			callee := SeedNewContractInstance(t, parentCtx, keepers, vm)
+			subMsg := spec.subMsg
+			subMsg.ID = 1
+			subMsg.Payload = []byte("my payload")
+			subMsg.Msg = wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{
+				ContractAddr: callee.Contract.String(),
+				Msg:          []byte(`{"callee":{}}`),
+				Funds:        wasmvmtypes.Array[wasmvmtypes.Coin]{},
+			}}}
+			vm.SetResponse(caller.Checksum, "execute", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+				Messages: []wasmvmtypes.SubMsg{subMsg},
+			}})
+			if spec.subMsgFails {
+				vm.SetResponse(callee.Checksum, "execute", &wasmvmtypes.ContractResult{Err: "testing"})
+			} else {
+				vm.SetResponse(callee.Checksum, "execute", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Data: []byte("callee data")}})
+			}
+			vm.SetResponse(caller.Checksum, "reply", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Data: []byte("reply data")}})
+			ctx, _ := parentCtx.CacheContext()
+
+			// when
+			gotData, gotErr := keepers.ContractKeeper.Execute(ctx, caller.Contract, caller.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			executes := vm.CallsOf("execute")
+			require.Len(t, executes, 2)
+			assert.Equal(t, caller.Checksum, []byte(executes[0].Checksum))
+			assert.Equal(t, callee.Checksum, []byte(executes[1].Checksum))
+			assert.Equal(t, caller.Contract.String(), executes[1].Info.Sender)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Empty(t, vm.CallsOf("reply"))
+				return
+			}
+			require.NoError(t, gotErr)
+			replies := vm.CallsOf("reply")
+			if !spec.expReply {
+				assert.Empty(t, replies)
+				assert.Nil(t, gotData)
+				return
+			}
+			require.Len(t, replies, 1)
+			assert.Equal(t, []byte("reply data"), gotData)
+			var reply wasmvmtypes.Reply
+			require.NoError(t, json.Unmarshal(replies[0].Msg, &reply))
+			assert.Equal(t, uint64(1), reply.ID)
+			assert.Equal(t, []byte("my payload"), reply.Payload)
+			if spec.subMsgFails {
+				assert.Contains(t, reply.Result.Err, "testing")
+				return
+			}
+			require.NotNil(t, reply.Result.Ok)
+			assert.Contains(t, string(reply.Result.Ok.MsgResponses[0].Value), "callee data")
+		})
+	}
+}
+
+func TestMockVMVMError(t *testing.T) {
+	vm := wasmtesting.NewMockWasmVM()
+	parentCtx, keepers := CreateTestInputWithVM(t, false, AvailableCapabilities, vm)
+	example := SeedNewContractInstance(t, parentCtx, keepers, vm)
+	vm.SetResponse(nil, "execute", types.ErrInvalid)
+
+	// when
+	_, gotErr := keepers.ContractKeeper.Execute(parentCtx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+	// then
+	require.ErrorIs(t, gotErr, types.ErrVMError)
+}
+
+func TestMockVMReplyErrorPolicy(t *testing.T) {
+	bankSend := func(to sdk.AccAddress, amount int64) wasmvmtypes.CosmosMsg {
+		return wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+			ToAddress: to.String(),
+			Amount:    wasmvmtypes.Array[wasmvmtypes.Coin]{{Denom: "denom", Amount: strconv.FormatInt(amount, 10)}},
+		}}}
+	}
+	specs := map[string]struct {
+		ignoreReplyError bool
+		payload          []byte
+		replyFails       bool
+		expErr           bool
+		expReplyReverted bool
+	}{
+		"ignore reply error policy - failed reply": {
+			ignoreReplyError: true,
+			payload:          []byte("my payload"),
+			replyFails:       true,
+			expReplyReverted: true,
+		},
+		"ignore reply error policy - successful reply": {
+			ignoreReplyError: true,
+			payload:          []byte("my payload"),
+		},
+		"default policy - failed reply": {
+			payload:    []byte("my payload"),
+			replyFails: true,
+			expErr:     true,
+		},
+		"default policy - failed reply with payload of the former opt-in prefix": {
+			payload:    []byte("wasmd/ignore_reply_error my payload"),
+			replyFails: true,
+			expErr:     true,
+		},
+		"default policy - successful reply": {
+			payload: []byte("my payload"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			vm := wasmtesting.NewMockWasmVM()
+			parentCtx, keepers := CreateTestInputWithVM(t, false, AvailableCapabilities, vm)
+			example := SeedNewContractInstance(t, parentCtx, keepers, vm)
+			NewTestFaucet(t, parentCtx, keepers.BankKeeper, minttypes.ModuleName, sdk.NewInt64Coin("denom", 100)).
+				Fund(parentCtx, example.Contract, sdk.NewInt64Coin("denom", 100))
+			subMsgRecipient, replyRecipient := RandomAccountAddress(t), RandomAccountAddress(t)
+			require.NoError(t, keepers.WasmKeeper.importIgnoreReplyError(parentCtx, example.Contract, spec.ignoreReplyError))
+
+			vm.SetResponse(example.Checksum, "execute", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+				Messages: []wasmvmtypes.SubMsg{{
+					ID:      1,
+					Msg:     bankSend(subMsgRecipient, 10),
+					ReplyOn: wasmvmtypes.ReplySuccess,
+					Payload: spec.payload,
+				}},
+			}})
+			replyMsgs := []wasmvmtypes.SubMsg{{Msg: bankSend(replyRecipient, 5), ReplyOn: wasmvmtypes.ReplyNever}}
+			if spec.replyFails {
+				// the reply fails after a message with state changes was dispatched
+				replyMsgs = append(replyMsgs, wasmvmtypes.SubMsg{Msg: bankSend(replyRecipient, 1000), ReplyOn: wasmvmtypes.ReplyNever})
+			}
+			vm.SetResponse(example.Checksum, "reply", &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+				Messages: replyMsgs,
+				Data:     []byte("reply data"),
+			}})
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			// when
+			gotData, gotErr := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			require.Len(t, vm.CallsOf("reply"), 1)
+			var reply wasmvmtypes.Reply
+			require.NoError(t, json.Unmarshal(vm.CallsOf("reply")[0].Msg, &reply))
+			assert.Equal(t, spec.payload, reply.Payload)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			balance := func(addr sdk.AccAddress) int64 {
+				return keepers.BankKeeper.GetBalance(ctx, addr, "denom").Amount.Int64()
+			}
+			assert.Equal(t, int64(10), balance(subMsgRecipient))
+			var eventTypes []string
+			for _, e := range em.Events() {
+				eventTypes = append(eventTypes, e.Type)
+			}
+			if spec.expReplyReverted {
+				assert.Nil(t, gotData)
+				assert.Equal(t, int64(0), balance(replyRecipient))
+				assert.Equal(t, int64(90), balance(example.Contract))
+				assert.Contains(t, eventTypes, types.EventTypeReplyFailed)
+				assert.NotContains(t, eventTypes, types.EventTypeReply)
+				return
+			}
+			assert.Equal(t, []byte("reply data"), gotData)
+			assert.Equal(t, int64(5), balance(replyRecipient))
+			assert.Equal(t, int64(85), balance(example.Contract))
+			assert.Contains(t, eventTypes, types.EventTypeReply)
+			assert.NotContains(t, eventTypes, types.EventTypeReplyFailed)
+		})
+	}
+}
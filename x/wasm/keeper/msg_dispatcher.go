@@ -1,12 +1,13 @@
 package keeper
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
-	abci "github.com/cometbft/cometbft/abci/types"
 
 	errorsmod "cosmossdk.io/errors"
 	storetypes "cosmossdk.io/store/types"
@@ -15,6 +16,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/CosmWasm/wasmd/x/wasm/contractmsg"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -36,6 +38,16 @@ type replyer interface {
 	reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
 }
 
+// executionReceiptKeeper is a subset of keeper that keeps the execution receipts of failed submessages
+type executionReceiptKeeper interface {
+	keepFailedExecutionReceipt(ctx, subCtx sdk.Context, contractAddress sdk.AccAddress)
+}
+
+// replyErrorPolicyKeeper is a subset of keeper that knows the contracts with the ignore reply error policy
+type replyErrorPolicyKeeper interface {
+	HasIgnoreReplyError(ctx context.Context, contractAddress sdk.AccAddress) bool
+}
+
 // MessageDispatcher coordinates message sending and submessage reply/ state commits
 type MessageDispatcher struct {
 	messenger Messenger
@@ -71,7 +83,7 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 			// if it's not an OutOfGas error, raise it again
 			if _, ok := r.(storetypes.ErrorOutOfGas); !ok {
 				// log it to get the original stack trace somewhere (as panic(r) keeps message but stacktrace to here
-				moduleLogger(ctx).Info("SubMsg rethrowing panic: %#v", r)
+				execLogger(ctx).Info("SubMsg rethrowing panic", "panic", fmt.Sprintf("%#v", r))
 				panic(r)
 			}
 			ctx.GasMeter().ConsumeGas(gasLimit, "Sub-Message OutOfGas panic")
@@ -87,6 +99,27 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 	return events, data, msgResponses, err
 }
 
+// calledContract returns the address of the contract whose execute or migrate entry point is called by the message
+// or nil for other messages
+func calledContract(msg *wasmvmtypes.WasmMsg) sdk.AccAddress {
+	var contractAddr string
+	switch {
+	case msg == nil:
+		return nil
+	case msg.Execute != nil:
+		contractAddr = msg.Execute.ContractAddr
+	case msg.Migrate != nil:
+		contractAddr = msg.Migrate.ContractAddr
+	default:
+		return nil
+	}
+	addr, err := sdk.AccAddressFromBech32(contractAddr)
+	if err != nil {
+		return nil
+	}
+	return addr
+}
+
 // DispatchSubmessages builds a sandbox to execute these messages and returns the execution result to the contract
 // that dispatched them, both on success as well as failure
 func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msgs []wasmvmtypes.SubMsg) ([]byte, error) {
@@ -132,7 +165,13 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 					})
 				}
 			}
-		} // on failure, revert state from sandbox, and ignore events (just skip doing the above)
+		} else if r, ok := d.keeper.(executionReceiptKeeper); ok {
+			// on failure, revert state from sandbox, and ignore events (just skip doing the above)
+			// but keep the receipt of a failed contract call
+			if addr := calledContract(msg.Msg.Wasm); addr != nil {
+				r.keepFailedExecutionReceipt(ctx, subCtx, addr)
+			}
+		}
 
 		// we only callback if requested. Short-circuit here the cases we don't want to
 		if (msg.ReplyOn == wasmvmtypes.ReplySuccess || msg.ReplyOn == wasmvmtypes.ReplyNever) && err != nil {
@@ -151,41 +190,21 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 			if len(data) > 0 {
 				responseData = data[0]
 			}
-
-			// For msgResponses we flatten the nested list into a flat list. In the majority of cases
-			// we only expect one message to be emitted and one response per message. But it might be possible
-			// to create multiple SDK messages from one CosmWasm message or we have multiple responses for one message.
-			// See https://github.com/CosmWasm/cosmwasm/issues/2009 for more information.
-			var msgResponsesFlattened []wasmvmtypes.MsgResponse
-			for _, singleMsgResponses := range msgResponses {
-				for _, singleMsgResponse := range singleMsgResponses {
-					msgResponsesFlattened = append(msgResponsesFlattened, wasmvmtypes.MsgResponse{
-						TypeURL: singleMsgResponse.TypeUrl,
-						Value:   singleMsgResponse.Value,
-					})
-				}
-			}
-
-			result = wasmvmtypes.SubMsgResult{
-				Ok: &wasmvmtypes.SubMsgResponse{
-					Events:       sdkEventsToWasmVMEvents(filteredEvents),
-					Data:         responseData,
-					MsgResponses: msgResponsesFlattened,
-				},
-			}
+			result = contractmsg.SubMsgOk(filteredEvents, responseData, msgResponses)
 		} else {
 			// Issue #759 - we don't return error string for worries of non-determinism
-			moduleLogger(ctx).Debug("Redacting submessage error", "cause", err)
-			result = wasmvmtypes.SubMsgResult{
-				Err: redactError(err).Error(),
-			}
+			execLogger(ctx).Debug("Redacting submessage error", "cause", err)
+			result = contractmsg.SubMsgErr(redactError(err))
 		}
 
 		// now handle the reply, we use the parent context, and abort on error
-		reply := wasmvmtypes.Reply{
-			ID:      msg.ID,
-			Result:  result,
-			Payload: msg.Payload,
+		reply := contractmsg.Reply(msg.ID, result, msg.Payload)
+
+		if err == nil && msg.ReplyOn == wasmvmtypes.ReplySuccess && d.ignoresReplyError(ctx, contractAddr) {
+			if rspData := d.dispatchReplyIgnoringError(ctx, contractAddr, reply); rspData != nil {
+				rsp = rspData
+			}
+			continue
 		}
 
 		// we can ignore any result returned as there is nothing to do with the data
@@ -201,6 +220,36 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 	return rsp, nil
 }
 
+// ignoresReplyError returns true when the contract opted into the ignore reply error policy
+func (d MessageDispatcher) ignoresReplyError(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
+	k, ok := d.keeper.(replyErrorPolicyKeeper)
+	return ok && k.HasIgnoreReplyError(ctx, contractAddr)
+}
+
+// dispatchReplyIgnoringError runs the reply in a sandbox. On failure, the state changes and events of the reply are
+// discarded and a reply failed event is emitted instead of returning the error. The state changes of the submessage
+// were committed before and are kept.
+func (d MessageDispatcher) dispatchReplyIgnoringError(ctx sdk.Context, contractAddr sdk.AccAddress, reply wasmvmtypes.Reply) []byte {
+	replyCtx, commit := ctx.CacheContext()
+	em := sdk.NewEventManager()
+	replyCtx = replyCtx.WithEventManager(em)
+
+	rspData, err := d.keeper.reply(replyCtx, contractAddr, reply)
+	if err != nil {
+		execLogger(ctx).Info("Ignoring failed reply", "contract", contractAddr.String(), "id", reply.ID, "cause", err)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeReplyFailed,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyReplyID, strconv.FormatUint(reply.ID, 10)),
+			sdk.NewAttribute(types.AttributeKeyError, redactError(err).Error()),
+		))
+		return nil
+	}
+	commit()
+	ctx.EventManager().EmitEvents(em.Events())
+	return rspData
+}
+
 // Issue #759 - we don't return error string for worries of non-determinism
 func redactError(err error) error {
 	// Do not redact system errors
@@ -235,25 +284,3 @@ func filterEvents(events []sdk.Event) []sdk.Event {
 	}
 	return res
 }
-
-func sdkEventsToWasmVMEvents(events []sdk.Event) []wasmvmtypes.Event {
-	res := make([]wasmvmtypes.Event, len(events))
-	for i, ev := range events {
-		res[i] = wasmvmtypes.Event{
-			Type:       ev.Type,
-			Attributes: sdkAttributesToWasmVMAttributes(ev.Attributes),
-		}
-	}
-	return res
-}
-
-func sdkAttributesToWasmVMAttributes(attrs []abci.EventAttribute) []wasmvmtypes.EventAttribute {
-	res := make([]wasmvmtypes.EventAttribute, len(attrs))
-	for i, attr := range attrs {
-		res[i] = wasmvmtypes.EventAttribute{
-			Key:   attr.Key,
-			Value: attr.Value,
-		}
-	}
-	return res
-}
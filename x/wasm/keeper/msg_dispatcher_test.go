@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
 
@@ -18,11 +20,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 func TestDispatchSubmessages(t *testing.T) {
 	noReplyCalled := &mockReplyer{}
 	var anyGasLimit uint64 = 1
+	myContractAddr := RandomAccountAddress(t)
 	specs := map[string]struct {
 		msgs       []wasmvmtypes.SubMsg
 		replyer    *mockReplyer
@@ -113,6 +117,125 @@ func TestDispatchSubmessages(t *testing.T) {
 				sdk.NewEvent("wasm-reply"),
 			},
 		},
+		"reply on success with ignore reply error policy - failed reply reverted": {
+			msgs: []wasmvmtypes.SubMsg{{
+				ID:      1,
+				ReplyOn: wasmvmtypes.ReplySuccess,
+				Payload: []byte("my payload"),
+			}},
+			replyer: &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					ctx.EventManager().EmitEvent(sdk.NewEvent("wasm-reply"))
+					return []byte("myReplyData"), types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, "testing"))
+				},
+				ignoreReplyError: true,
+			},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					myEvents := []sdk.Event{{Type: "myEvent", Attributes: []abci.EventAttribute{{Key: "foo", Value: "bar"}}}}
+					return myEvents, [][]byte{[]byte("myData")}, [][]*codectypes.Any{}, nil
+				},
+			},
+			expCommits: []bool{true, false},
+			expEvents: []sdk.Event{
+				{
+					Type:       "myEvent",
+					Attributes: []abci.EventAttribute{{Key: "foo", Value: "bar"}},
+				},
+				sdk.NewEvent("reply_failed",
+					sdk.NewAttribute("_contract_address", myContractAddr.String()),
+					sdk.NewAttribute("reply_id", "1"),
+					sdk.NewAttribute("error", "testing: execute wasm contract failed"),
+				),
+			},
+		},
+		"reply on success with ignore reply error policy - successful reply committed": {
+			msgs: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplySuccess,
+				Payload: []byte("my payload"),
+			}},
+			replyer: &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					if string(reply.Payload) != "my payload" {
+						return nil, errors.New("unexpected payload")
+					}
+					ctx.EventManager().EmitEvent(sdk.NewEvent("wasm-reply"))
+					return []byte("myReplyData"), nil
+				},
+				ignoreReplyError: true,
+			},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					return nil, [][]byte{[]byte("myData")}, [][]*codectypes.Any{}, nil
+				},
+			},
+			expData:    []byte("myReplyData"),
+			expCommits: []bool{true, true},
+			expEvents:  []sdk.Event{sdk.NewEvent("wasm-reply")},
+		},
+		"reply on success with ignore reply error policy - failed submessage": {
+			msgs: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplySuccess,
+			}},
+			replyer: &mockReplyer{ignoreReplyError: true},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					return nil, nil, [][]*codectypes.Any{}, errors.New("test, ignore")
+				},
+			},
+			expErr: true,
+		},
+		"reply on success without ignore reply error policy - failed reply": {
+			msgs: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplySuccess,
+				Payload: []byte("my payload"),
+			}},
+			replyer: &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					return nil, errors.New("my error")
+				},
+			},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					return nil, [][]byte{[]byte("myData")}, [][]*codectypes.Any{}, nil
+				},
+			},
+			expErr: true,
+		},
+		"reply on success without ignore reply error policy - payload is not interpreted": {
+			msgs: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplySuccess,
+				Payload: []byte("wasmd/ignore_reply_error my payload"),
+			}},
+			replyer: &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					return nil, errors.New("my error")
+				},
+			},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					return nil, [][]byte{[]byte("myData")}, [][]*codectypes.Any{}, nil
+				},
+			},
+			expErr: true,
+		},
+		"reply always ignores the ignore reply error policy": {
+			msgs: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplyAlways,
+			}},
+			replyer: &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					return nil, errors.New("my error")
+				},
+				ignoreReplyError: true,
+			},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					return nil, [][]byte{[]byte("myData")}, [][]*codectypes.Any{}, nil
+				},
+			},
+			expErr: true,
+		},
 		"with context events - released on commit": {
 			msgs: []wasmvmtypes.SubMsg{{
 				ReplyOn: wasmvmtypes.ReplyNever,
@@ -242,6 +365,21 @@ func TestDispatchSubmessages(t *testing.T) {
 			expData:    []byte("myReplyData:2"),
 			expCommits: []bool{false, false},
 		},
+		"multiple msg - duplicate reply ids": {
+			msgs: []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyAlways}, {ID: 1, ReplyOn: wasmvmtypes.ReplyAlways}, {ID: 2, ReplyOn: wasmvmtypes.ReplyNever}},
+			replyer: &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					return []byte(fmt.Sprintf("myReplyData:%d", reply.ID)), nil
+				},
+			},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					return nil, nil, [][]*codectypes.Any{}, nil
+				},
+			},
+			expData:    []byte("myReplyData:1"),
+			expCommits: []bool{true, true, true},
+		},
 		"multiple msg - last non nil reply returned": {
 			msgs: []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyError}, {ID: 2, ReplyOn: wasmvmtypes.ReplyError}},
 			replyer: &mockReplyer{
@@ -420,13 +558,13 @@ func TestDispatchSubmessages(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			var mockStore wasmtesting.MockCommitMultiStore
 			em := sdk.NewEventManager()
-			ctx := sdk.Context{}.WithMultiStore(&mockStore).
+			ctx := sdk.Context{}.WithContext(context.Background()).WithMultiStore(&mockStore).
 				WithGasMeter(storetypes.NewGasMeter(100)).
 				WithEventManager(em).WithLogger(log.NewTestLogger(t))
 			d := NewMessageDispatcher(spec.msgHandler, spec.replyer)
 
 			// run the test
-			gotData, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", spec.msgs)
+			gotData, gotErr := d.DispatchSubmessages(ctx, myContractAddr, "any_port", spec.msgs)
 			if spec.expErr {
 				require.Error(t, gotErr)
 				assert.Empty(t, em.Events())
@@ -449,7 +587,12 @@ func TestDispatchSubmessages(t *testing.T) {
 }
 
 type mockReplyer struct {
-	replyFn func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
+	replyFn          func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
+	ignoreReplyError bool
+}
+
+func (m mockReplyer) HasIgnoreReplyError(_ context.Context, _ sdk.AccAddress) bool {
+	return m.ignoreReplyError
 }
 
 func (m mockReplyer) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
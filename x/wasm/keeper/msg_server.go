@@ -6,6 +6,7 @@ import (
 
 	errorsmod "cosmossdk.io/errors"
 
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -25,6 +26,8 @@ func NewMsgServerImpl(k *Keeper) types.MsgServer {
 
 // StoreCode stores a new wasm code on chain
 func (m msgServer) StoreCode(ctx context.Context, msg *types.MsgStoreCode) (*types.MsgStoreCodeResponse, error) {
+	defer m.keeper.blockExecTime.measureSince(ctx, telemetry.Now(), execOpStoreCode)
+
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
@@ -35,10 +38,15 @@ func (m msgServer) StoreCode(ctx context.Context, msg *types.MsgStoreCode) (*typ
 
 	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
 
-	codeID, checksum, err := m.keeper.create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission, policy)
+	codeID, checksum, err := m.keeper.create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission, m.selectCodeOrigin(ctx, senderAddr), policy)
 	if err != nil {
 		return nil, err
 	}
+	if len(msg.ProvenanceSignature) != 0 {
+		if err := m.keeper.setCodeProvenance(ctx, codeID, senderAddr, msg.Source, msg.Builder, msg.ProvenanceSignature); err != nil {
+			return nil, err
+		}
+	}
 
 	return &types.MsgStoreCodeResponse{
 		CodeID:   codeID,
@@ -48,6 +56,8 @@ func (m msgServer) StoreCode(ctx context.Context, msg *types.MsgStoreCode) (*typ
 
 // InstantiateContract instantiate a new contract with classic sequence based address generation
 func (m msgServer) InstantiateContract(ctx context.Context, msg *types.MsgInstantiateContract) (*types.MsgInstantiateContractResponse, error) {
+	defer m.keeper.blockExecTime.measureSince(ctx, telemetry.Now(), execOpInstantiate)
+
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
@@ -69,6 +79,11 @@ func (m msgServer) InstantiateContract(ctx context.Context, msg *types.MsgInstan
 	if err != nil {
 		return nil, err
 	}
+	if msg.NotifyAdminChange {
+		if err := m.keeper.importAdminChangeNotification(ctx, contractAddr, true); err != nil {
+			return nil, err
+		}
+	}
 
 	return &types.MsgInstantiateContractResponse{
 		Address: contractAddr.String(),
@@ -78,6 +93,8 @@ func (m msgServer) InstantiateContract(ctx context.Context, msg *types.MsgInstan
 
 // InstantiateContract2 instantiate a new contract with predicatable address generated
 func (m msgServer) InstantiateContract2(ctx context.Context, msg *types.MsgInstantiateContract2) (*types.MsgInstantiateContract2Response, error) {
+	defer m.keeper.blockExecTime.measureSince(ctx, telemetry.Now(), execOpInstantiate)
+
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
@@ -101,6 +118,11 @@ func (m msgServer) InstantiateContract2(ctx context.Context, msg *types.MsgInsta
 	if err != nil {
 		return nil, err
 	}
+	if msg.NotifyAdminChange {
+		if err := m.keeper.importAdminChangeNotification(ctx, contractAddr, true); err != nil {
+			return nil, err
+		}
+	}
 
 	return &types.MsgInstantiateContract2Response{
 		Address: contractAddr.String(),
@@ -109,6 +131,8 @@ func (m msgServer) InstantiateContract2(ctx context.Context, msg *types.MsgInsta
 }
 
 func (m msgServer) ExecuteContract(ctx context.Context, msg *types.MsgExecuteContract) (*types.MsgExecuteContractResponse, error) {
+	defer m.keeper.blockExecTime.measureSince(ctx, telemetry.Now(), execOpExecute)
+
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
@@ -133,6 +157,8 @@ func (m msgServer) ExecuteContract(ctx context.Context, msg *types.MsgExecuteCon
 }
 
 func (m msgServer) MigrateContract(ctx context.Context, msg *types.MsgMigrateContract) (*types.MsgMigrateContractResponse, error) {
+	defer m.keeper.blockExecTime.measureSince(ctx, telemetry.Now(), execOpMigrate)
+
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
@@ -236,7 +262,17 @@ func (m msgServer) UpdateParams(ctx context.Context, req *types.MsgUpdateParams)
 		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
 	}
 
-	if err := m.keeper.SetParams(ctx, req.Params); err != nil {
+	params := req.Params
+	if len(req.FieldMask) != 0 {
+		var err error
+		if params, err = m.keeper.GetParams(ctx).ApplyFieldMask(req.Params, req.FieldMask); err != nil {
+			return nil, err
+		}
+		if err := params.ValidateBasic(); err != nil {
+			return nil, errorsmod.Wrap(err, "params")
+		}
+	}
+	if err := m.keeper.updateParams(ctx, req.Authority, params); err != nil {
 		return nil, err
 	}
 
@@ -263,6 +299,165 @@ func (m msgServer) PinCodes(ctx context.Context, req *types.MsgPinCodes) (*types
 	return &types.MsgPinCodesResponse{}, nil
 }
 
+// UpdateContractAdmins sets new admins for multiple contracts at once. Either all or none of the updates are applied.
+func (m msgServer) UpdateContractAdmins(ctx context.Context, req *types.MsgUpdateContractAdmins) (*types.MsgUpdateContractAdminsResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "authority")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
+	if err := m.keeper.setContractAdmins(ctx, authorityAddr, req.Updates, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateContractAdminsResponse{}, nil
+}
+
+// SetLegacyReverseIterator enables or disables the deprecated legacy reverse iterator mode of a code
+func (m msgServer) SetLegacyReverseIterator(ctx context.Context, req *types.MsgSetLegacyReverseIterator) (*types.MsgSetLegacyReverseIteratorResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	if err := m.keeper.setLegacyReverseIterator(ctx, req.CodeID, req.Enabled); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetLegacyReverseIteratorResponse{}, nil
+}
+
+// AttestCode records a build attestation of the sender for a code
+func (m msgServer) AttestCode(ctx context.Context, req *types.MsgAttestCode) (*types.MsgAttestCodeResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	attester, err := sdk.AccAddressFromBech32(req.Attester)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "attester")
+	}
+
+	if err := m.keeper.attestCode(ctx, req.CodeID, attester, req.RepoURL, req.Commit, req.BuilderImage); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgAttestCodeResponse{}, nil
+}
+
+// VoteCodeVerification records the verdict of a code verifier on the build reproducibility of a code
+func (m msgServer) VoteCodeVerification(ctx context.Context, req *types.MsgVoteCodeVerification) (*types.MsgVoteCodeVerificationResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	voter, err := sdk.AccAddressFromBech32(req.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+
+	if err := m.keeper.voteCodeVerification(ctx, req.CodeID, voter, req.Verdict); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgVoteCodeVerificationResponse{}, nil
+}
+
+// ScheduleSudo schedules a one-shot sudo call of the sender contract on itself at the end of a future block
+func (m msgServer) ScheduleSudo(ctx context.Context, req *types.MsgScheduleSudo) (*types.MsgScheduleSudoResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+
+	id, err := m.keeper.scheduleSudo(ctx, contractAddr, req.Height, req.Msg, req.GasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgScheduleSudoResponse{ID: id}, nil
+}
+
+// CancelScheduledSudo removes a pending scheduled sudo call of the sender contract
+func (m msgServer) CancelScheduledSudo(ctx context.Context, req *types.MsgCancelScheduledSudo) (*types.MsgCancelScheduledSudoResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+
+	if err := m.keeper.cancelScheduledSudo(ctx, contractAddr, req.ID); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgCancelScheduledSudoResponse{}, nil
+}
+
+// ImportContract imports a contract with its state from another chain at an explicit address
+func (m msgServer) ImportContract(goCtx context.Context, req *types.MsgImportContract) (*types.MsgImportContractResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "authority")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "address")
+	}
+	creatorAddr, err := sdk.AccAddressFromBech32(req.Creator)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "creator")
+	}
+	var adminAddr sdk.AccAddress
+	if req.Admin != "" {
+		if adminAddr, err = sdk.AccAddressFromBech32(req.Admin); err != nil {
+			return nil, errorsmod.Wrap(err, "admin")
+		}
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	codeID := req.CodeID
+	if len(req.WASMByteCode) != 0 {
+		policy := m.selectAuthorizationPolicy(ctx, req.Authority)
+		if codeID, _, err = m.keeper.create(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission, m.selectCodeOrigin(ctx, authorityAddr), policy); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.keeper.importContractAt(ctx, contractAddr, codeID, creatorAddr, adminAddr, req.Label, req.State); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgImportContractResponse{CodeID: codeID}, nil
+}
+
 // UnpinCodes unpins a set of code ids in the wasmvm cache.
 func (m msgServer) UnpinCodes(ctx context.Context, req *types.MsgUnpinCodes) (*types.MsgUnpinCodesResponse, error) {
 	if err := req.ValidateBasic(); err != nil {
@@ -285,6 +480,8 @@ func (m msgServer) UnpinCodes(ctx context.Context, req *types.MsgUnpinCodes) (*t
 
 // SudoContract calls sudo on a contract.
 func (m msgServer) SudoContract(ctx context.Context, req *types.MsgSudoContract) (*types.MsgSudoContractResponse, error) {
+	defer m.keeper.blockExecTime.measureSince(ctx, telemetry.Now(), execOpSudo)
+
 	if err := req.ValidateBasic(); err != nil {
 		return nil, err
 	}
@@ -308,6 +505,8 @@ func (m msgServer) SudoContract(ctx context.Context, req *types.MsgSudoContract)
 
 // StoreAndInstantiateContract stores and instantiates the contract.
 func (m msgServer) StoreAndInstantiateContract(goCtx context.Context, req *types.MsgStoreAndInstantiateContract) (*types.MsgStoreAndInstantiateContractResponse, error) {
+	defer m.keeper.blockExecTime.measureSince(goCtx, telemetry.Now(), execOpInstantiate)
+
 	if err := req.ValidateBasic(); err != nil {
 		return nil, err
 	}
@@ -327,19 +526,28 @@ func (m msgServer) StoreAndInstantiateContract(goCtx context.Context, req *types
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
 
-	codeID, _, err := m.keeper.create(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission, policy)
+	codeID, _, err := m.keeper.create(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission, m.selectCodeOrigin(ctx, authorityAddr), policy)
 	if err != nil {
 		return nil, err
 	}
 
+	if !req.UnpinCode {
+		if err := m.keeper.pinCode(ctx, codeID); err != nil {
+			return nil, err
+		}
+	}
+
 	contractAddr, data, err := m.keeper.instantiate(ctx, codeID, authorityAddr, adminAddr, req.Msg, req.Label, req.Funds, m.keeper.ClassicAddressGenerator(), policy)
 	if err != nil {
 		return nil, err
 	}
 
+	// the port is bound to the contract on instantiation so that the channel handshake can start right away
+	contractInfo := m.keeper.GetContractInfo(ctx, contractAddr)
 	return &types.MsgStoreAndInstantiateContractResponse{
-		Address: contractAddr.String(),
-		Data:    data,
+		Address:   contractAddr.String(),
+		Data:      data,
+		IBCPortID: contractInfo.IBCPortID,
 	}, nil
 }
 
@@ -368,7 +576,7 @@ func (m msgServer) AddCodeUploadParamsAddresses(goCtx context.Context, req *type
 	}
 
 	params.CodeUploadAccess.Addresses = addresses
-	if err := m.keeper.SetParams(ctx, params); err != nil {
+	if err := m.keeper.updateParams(ctx, req.Authority, params); err != nil {
 		return nil, err
 	}
 
@@ -402,7 +610,7 @@ func (m msgServer) RemoveCodeUploadParamsAddresses(goCtx context.Context, req *t
 
 	params.CodeUploadAccess.Addresses = newAddresses
 
-	if err := m.keeper.SetParams(ctx, params); err != nil {
+	if err := m.keeper.updateParams(ctx, req.Authority, params); err != nil {
 		return nil, err
 	}
 
@@ -419,8 +627,28 @@ func (m msgServer) selectAuthorizationPolicy(ctx context.Context, actor string)
 	return DefaultAuthorizationPolicy{}
 }
 
+// selectCodeOrigin returns the channel that the uploader used. Uploads by the governance authority come from
+// governance. Uploads with a sender that did not sign the tx are executed by a grantee via authz.
+func (m msgServer) selectCodeOrigin(ctx context.Context, uploader sdk.AccAddress) types.CodeOrigin {
+	if uploader.String() == m.keeper.GetAuthority() {
+		return types.CodeOriginGovernance
+	}
+	signers, ok := types.TxSignersFromContext(ctx)
+	if !ok || m.keeper.HasContractInfo(ctx, uploader) {
+		return types.CodeOriginDirect
+	}
+	for _, s := range signers {
+		if s.Equals(uploader) {
+			return types.CodeOriginDirect
+		}
+	}
+	return types.CodeOriginAuthz
+}
+
 // StoreAndMigrateContract stores and migrates the contract.
 func (m msgServer) StoreAndMigrateContract(goCtx context.Context, req *types.MsgStoreAndMigrateContract) (*types.MsgStoreAndMigrateContractResponse, error) {
+	defer m.keeper.blockExecTime.measureSince(goCtx, telemetry.Now(), execOpMigrate)
+
 	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "authority")
@@ -433,7 +661,7 @@ func (m msgServer) StoreAndMigrateContract(goCtx context.Context, req *types.Msg
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
 
-	codeID, checksum, err := m.keeper.create(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission, policy)
+	codeID, checksum, err := m.keeper.create(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission, m.selectCodeOrigin(ctx, authorityAddr), policy)
 	if err != nil {
 		return nil, err
 	}
@@ -477,3 +705,468 @@ func (m msgServer) UpdateContractLabel(ctx context.Context, msg *types.MsgUpdate
 
 	return &types.MsgUpdateContractLabelResponse{}, nil
 }
+
+// RegisterParamSubscriber subscribes a contract to param changes of a set of modules.
+func (m msgServer) RegisterParamSubscriber(ctx context.Context, req *types.MsgRegisterParamSubscriber) (*types.MsgRegisterParamSubscriberResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.registerParamSubscriber(ctx, contractAddr, req.Subspaces); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRegisterParamSubscriberResponse{}, nil
+}
+
+// DeregisterParamSubscriber unsubscribes a contract from param changes of a set of modules.
+func (m msgServer) DeregisterParamSubscriber(ctx context.Context, req *types.MsgDeregisterParamSubscriber) (*types.MsgDeregisterParamSubscriberResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.deregisterParamSubscriber(ctx, contractAddr, req.Subspaces); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgDeregisterParamSubscriberResponse{}, nil
+}
+
+// StoreCodeChunk stores a part of a wasm code that is uploaded in multiple transactions
+func (m msgServer) StoreCodeChunk(ctx context.Context, msg *types.MsgStoreCodeChunk) (*types.MsgStoreCodeChunkResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+
+	if err := m.keeper.storeCodeChunk(ctx, senderAddr, msg.UploadID, msg.Index, msg.Total, msg.Data); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgStoreCodeChunkResponse{}, nil
+}
+
+// CompleteStoreCode assembles the chunks of an upload and stores the new wasm code on chain
+func (m msgServer) CompleteStoreCode(ctx context.Context, msg *types.MsgCompleteStoreCode) (*types.MsgCompleteStoreCodeResponse, error) {
+	defer m.keeper.blockExecTime.measureSince(ctx, telemetry.Now(), execOpStoreCode)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	codeID, checksum, err := m.keeper.completeStoreCode(ctx, senderAddr, msg.UploadID, msg.Checksum, msg.InstantiatePermission, m.selectCodeOrigin(ctx, senderAddr), policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgCompleteStoreCodeResponse{
+		CodeID:   codeID,
+		Checksum: checksum,
+	}, nil
+}
+
+// SetQueryAlias registers, updates or removes a named smart query of a contract
+func (m msgServer) SetQueryAlias(ctx context.Context, msg *types.MsgSetQueryAlias) (*types.MsgSetQueryAliasResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.setQueryAlias(ctx, contractAddr, senderAddr, msg.Name, msg.QueryData, msg.CacheTTLSeconds, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetQueryAliasResponse{}, nil
+}
+
+// SetIBCSendTimeout sets or removes the default timeout for IBC packets sent by a contract
+func (m msgServer) SetIBCSendTimeout(ctx context.Context, msg *types.MsgSetIBCSendTimeout) (*types.MsgSetIBCSendTimeoutResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.setIBCSendTimeout(ctx, contractAddr, senderAddr, msg.TimeoutTimestamp, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetIBCSendTimeoutResponse{}, nil
+}
+
+// SetAdminChangeNotification enables or disables the sudo notification of a contract on admin changes
+func (m msgServer) SetAdminChangeNotification(ctx context.Context, msg *types.MsgSetAdminChangeNotification) (*types.MsgSetAdminChangeNotificationResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.setAdminChangeNotification(ctx, contractAddr, senderAddr, msg.Enabled, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetAdminChangeNotificationResponse{}, nil
+}
+
+// SetContractTags replaces the tags of a contract
+func (m msgServer) SetContractTags(ctx context.Context, msg *types.MsgSetContractTags) (*types.MsgSetContractTagsResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.setContractTags(ctx, contractAddr, senderAddr, msg.Tags, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetContractTagsResponse{}, nil
+}
+
+// SetContractFeeSponsorship sets or removes the fee sponsorship budgets of a contract
+func (m msgServer) SetContractFeeSponsorship(ctx context.Context, msg *types.MsgSetContractFeeSponsorship) (*types.MsgSetContractFeeSponsorshipResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.setContractFeeSponsorship(ctx, contractAddr, senderAddr, msg.Sponsorship, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetContractFeeSponsorshipResponse{}, nil
+}
+
+// AddCodeUploadApproval pre-approves a code checksum that anybody can upload
+func (m msgServer) AddCodeUploadApproval(ctx context.Context, req *types.MsgAddCodeUploadApproval) (*types.MsgAddCodeUploadApprovalResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	approval := types.CodeUploadApproval{Checksum: req.Checksum, ConsumeOnce: req.ConsumeOnce}
+	if err := m.keeper.addCodeUploadApproval(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgAddCodeUploadApprovalResponse{}, nil
+}
+
+// RemoveCodeUploadApproval removes a pre-approved code checksum
+func (m msgServer) RemoveCodeUploadApproval(ctx context.Context, req *types.MsgRemoveCodeUploadApproval) (*types.MsgRemoveCodeUploadApprovalResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	if err := m.keeper.removeCodeUploadApproval(ctx, req.Checksum); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRemoveCodeUploadApprovalResponse{}, nil
+}
+
+// ForceMigrateContract migrates a contract by governance without the admin
+func (m msgServer) ForceMigrateContract(ctx context.Context, req *types.MsgForceMigrateContract) (*types.MsgForceMigrateContractResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "authority")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
+	data, err := m.keeper.forceMigrate(ctx, contractAddr, authorityAddr, req.CodeID, req.Msg, policy)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MsgForceMigrateContractResponse{Data: data}, nil
+}
+
+// ScheduleContractSunset announces the end-of-life height of a contract
+func (m msgServer) ScheduleContractSunset(ctx context.Context, msg *types.MsgScheduleContractSunset) (*types.MsgScheduleContractSunsetResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.scheduleContractSunset(ctx, contractAddr, senderAddr, msg.Height, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgScheduleContractSunsetResponse{}, nil
+}
+
+// CancelContractSunset removes the scheduled sunset of a contract
+func (m msgServer) CancelContractSunset(ctx context.Context, msg *types.MsgCancelContractSunset) (*types.MsgCancelContractSunsetResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.cancelContractSunset(ctx, contractAddr, senderAddr, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgCancelContractSunsetResponse{}, nil
+}
+
+// ProposeAdminTransfer records a pending admin for a contract
+func (m msgServer) ProposeAdminTransfer(ctx context.Context, msg *types.MsgProposeAdminTransfer) (*types.MsgProposeAdminTransferResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+	newAdminAddr, err := sdk.AccAddressFromBech32(msg.NewAdmin)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "new admin")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.proposeAdminTransfer(ctx, contractAddr, senderAddr, newAdminAddr, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgProposeAdminTransferResponse{}, nil
+}
+
+// AcceptAdminTransfer completes a proposed admin transfer
+func (m msgServer) AcceptAdminTransfer(ctx context.Context, msg *types.MsgAcceptAdminTransfer) (*types.MsgAcceptAdminTransferResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.acceptAdminTransfer(ctx, contractAddr, senderAddr); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgAcceptAdminTransferResponse{}, nil
+}
+
+// CancelAdminTransfer removes the pending admin of a contract
+func (m msgServer) CancelAdminTransfer(ctx context.Context, msg *types.MsgCancelAdminTransfer) (*types.MsgCancelAdminTransferResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.cancelAdminTransfer(ctx, contractAddr, senderAddr, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgCancelAdminTransferResponse{}, nil
+}
+
+// SuspendContract freezes the executions of a contract
+func (m msgServer) SuspendContract(ctx context.Context, msg *types.MsgSuspendContract) (*types.MsgSuspendContractResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.suspendContract(ctx, contractAddr, senderAddr, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSuspendContractResponse{}, nil
+}
+
+// ResumeContract lifts the suspension of a contract
+func (m msgServer) ResumeContract(ctx context.Context, msg *types.MsgResumeContract) (*types.MsgResumeContractResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.resumeContract(ctx, contractAddr, senderAddr, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgResumeContractResponse{}, nil
+}
+
+// SetIgnoreReplyError enables or disables the ignore reply error policy of a contract
+func (m msgServer) SetIgnoreReplyError(ctx context.Context, msg *types.MsgSetIgnoreReplyError) (*types.MsgSetIgnoreReplyErrorResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.setIgnoreReplyError(ctx, contractAddr, senderAddr, msg.Enabled, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetIgnoreReplyErrorResponse{}, nil
+}
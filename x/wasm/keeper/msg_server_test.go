@@ -1,11 +1,15 @@
 package keeper
 
 import (
+	"strconv"
 	"testing"
 
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"cosmossdk.io/log"
 	"cosmossdk.io/store"
@@ -13,6 +17,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -57,3 +62,266 @@ func TestSelectAuthorizationPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreCodeOrigin(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	params := types.DefaultParams()
+	params.InstantiateDefaultPermissionsByOrigin = []types.OriginAccessType{
+		{Origin: types.CodeOriginDirect, Permission: types.AccessTypeAnyOfAddresses},
+		{Origin: types.CodeOriginGovernance, Permission: types.AccessTypeEverybody},
+		{Origin: types.CodeOriginAuthz, Permission: types.AccessTypeNobody},
+	}
+	require.NoError(t, k.SetParams(parentCtx, params))
+	govAuthority := sdk.MustAccAddressFromBech32(k.GetAuthority())
+	myUploader := RandomAccountAddress(t)
+	myGrantee := RandomAccountAddress(t)
+	myContract := SeedNewContractInstance(t, parentCtx, keepers, &mock).Contract
+
+	specs := map[string]struct {
+		sender     sdk.AccAddress
+		signers    []sdk.AccAddress
+		permission *types.AccessConfig
+		expOrigin  types.CodeOrigin
+		expConfig  types.AccessConfig
+	}{
+		"direct upload": {
+			sender:    myUploader,
+			signers:   []sdk.AccAddress{myUploader},
+			expOrigin: types.CodeOriginDirect,
+			expConfig: types.AccessTypeAnyOfAddresses.With(myUploader),
+		},
+		"direct upload without signers in context": {
+			sender:    myUploader,
+			expOrigin: types.CodeOriginDirect,
+			expConfig: types.AccessTypeAnyOfAddresses.With(myUploader),
+		},
+		"governance upload": {
+			sender:    govAuthority,
+			expOrigin: types.CodeOriginGovernance,
+			expConfig: types.AllowEverybody,
+		},
+		"authz upload": {
+			sender:    myUploader,
+			signers:   []sdk.AccAddress{myGrantee},
+			expOrigin: types.CodeOriginAuthz,
+			expConfig: types.AllowNobody,
+		},
+		"contract upload": {
+			sender:    myContract,
+			signers:   []sdk.AccAddress{myGrantee},
+			expOrigin: types.CodeOriginDirect,
+			expConfig: types.AccessTypeAnyOfAddresses.With(myContract),
+		},
+		"explicit permission is kept": {
+			sender:     myUploader,
+			signers:    []sdk.AccAddress{myUploader},
+			permission: &types.AllowNobody,
+			expOrigin:  types.CodeOriginDirect,
+			expConfig:  types.AllowNobody,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.signers != nil {
+				ctx = types.WithTxSigners(ctx, spec.signers)
+			}
+			// when
+			res, err := NewMsgServerImpl(k).StoreCode(ctx, &types.MsgStoreCode{
+				Sender:                spec.sender.String(),
+				WASMByteCode:          append(wasmIdent, []byte("my code")...),
+				InstantiatePermission: spec.permission,
+			})
+			// then
+			require.NoError(t, err)
+			info := k.GetCodeInfo(ctx, res.CodeID)
+			require.NotNil(t, info)
+			assert.Equal(t, spec.expOrigin, info.Origin)
+			assert.Equal(t, spec.expConfig, info.InstantiateConfig)
+			// and the origin is exposed by the query
+			qRes, err := Querier(k).CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: res.CodeID})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expOrigin, qRes.Origin)
+		})
+	}
+}
+
+func TestForceMigrateContract(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Data: []byte("migrated")}}, 0, nil
+	}
+	mock.MigrateWithInfoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, migrateInfo wasmvmtypes.MigrateInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return mock.MigrateFn(codeID, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	newCodeID := StoreRandomContractWithAccessConfig(t, parentCtx, keepers, &mock, &types.AllowNobody).CodeID
+	// a second contract without admin
+	noAdminContract, _, err := keepers.ContractKeeper.Instantiate(parentCtx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "no admin", nil)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		authority string
+		contract  sdk.AccAddress
+		expAdmin  string
+		expErr    bool
+	}{
+		"admin set": {
+			authority: k.GetAuthority(),
+			contract:  example.Contract,
+			expAdmin:  example.CreatorAddr.String(),
+		},
+		"admin cleared": {
+			authority: k.GetAuthority(),
+			contract:  noAdminContract,
+		},
+		"not the authority": {
+			authority: example.CreatorAddr.String(),
+			contract:  example.Contract,
+			expErr:    true,
+		},
+		"unknown contract": {
+			authority: k.GetAuthority(),
+			contract:  RandomAccountAddress(t),
+			expErr:    true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+			// when
+			rsp, err := NewMsgServerImpl(k).ForceMigrateContract(ctx, &types.MsgForceMigrateContract{
+				Authority: spec.authority,
+				Contract:  spec.contract.String(),
+				CodeID:    newCodeID,
+				Msg:       []byte(`{}`),
+			})
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				assert.Equal(t, example.CodeID, k.GetContractInfo(ctx, example.Contract).CodeID)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []byte("migrated"), rsp.Data)
+			info := k.GetContractInfo(ctx, spec.contract)
+			assert.Equal(t, newCodeID, info.CodeID)
+			assert.Equal(t, spec.expAdmin, info.Admin)
+			history := k.GetContractHistory(ctx, spec.contract)
+			require.Len(t, history, 2)
+			assert.Equal(t, types.ContractCodeHistoryOperationTypeForceMigrate, history[1].Operation)
+			assert.Equal(t, newCodeID, history[1].CodeID)
+			// and the prominent event
+			exp := sdk.NewEvent(
+				types.EventTypeForceMigrate,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, spec.contract.String()),
+				sdk.NewAttribute(types.AttributeKeyOldCodeID, strconv.FormatUint(example.CodeID, 10)),
+				sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(newCodeID, 10)),
+				sdk.NewAttribute(types.AttributeKeyAdmin, spec.expAdmin),
+			)
+			assert.Contains(t, em.Events(), exp)
+		})
+	}
+}
+
+func TestUpdateParamsFieldMask(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+	authority := k.GetAuthority()
+	myAddr := RandomBech32AccountAddress(t)
+
+	currentParams := types.DefaultParams()
+	currentParams.MaxResponseEvents = 10
+	currentParams.DisableUsageTracking = true
+	require.NoError(t, k.SetParams(parentCtx, currentParams))
+
+	specs := map[string]struct {
+		src       types.MsgUpdateParams
+		expParams func(p *types.Params)
+		expErr    error
+	}{
+		"single field": {
+			src: types.MsgUpdateParams{
+				Authority: authority,
+				Params:    types.Params{CodeUploadAccess: types.AccessTypeAnyOfAddresses.With(sdk.MustAccAddressFromBech32(myAddr))},
+				FieldMask: []string{"code_upload_access"},
+			},
+			expParams: func(p *types.Params) {
+				p.CodeUploadAccess = types.AccessTypeAnyOfAddresses.With(sdk.MustAccAddressFromBech32(myAddr))
+			},
+		},
+		"multiple fields": {
+			src: types.MsgUpdateParams{
+				Authority: authority,
+				Params:    types.Params{MaxResponseEvents: 20, InstantiateDefaultPermission: types.AccessTypeNobody},
+				FieldMask: []string{"max_response_events", "instantiate_default_permission", "disable_usage_tracking"},
+			},
+			expParams: func(p *types.Params) {
+				p.MaxResponseEvents = 20
+				p.InstantiateDefaultPermission = types.AccessTypeNobody
+				p.DisableUsageTracking = false
+			},
+		},
+		"full replace without field mask": {
+			src: types.MsgUpdateParams{
+				Authority: authority,
+				Params:    types.DefaultParams(),
+			},
+			expParams: func(p *types.Params) {
+				*p = types.DefaultParams()
+			},
+		},
+		"unknown field": {
+			src: types.MsgUpdateParams{
+				Authority: authority,
+				Params:    types.DefaultParams(),
+				FieldMask: []string{"max_response_events", "not_a_param"},
+			},
+			expErr: types.ErrInvalid,
+		},
+		"patched params invalid": {
+			src: types.MsgUpdateParams{
+				Authority: authority,
+				Params:    types.Params{},
+				FieldMask: []string{"code_upload_access"},
+			},
+			expErr: types.ErrEmpty,
+		},
+		"unauthorized": {
+			src: types.MsgUpdateParams{
+				Authority: myAddr,
+				Params:    types.Params{MaxResponseEvents: 20},
+				FieldMask: []string{"max_response_events"},
+			},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+
+			// when
+			_, gotErr := msgServer.UpdateParams(ctx, &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.Equal(t, currentParams, k.GetParams(ctx))
+				return
+			}
+			require.NoError(t, gotErr)
+			exp := currentParams
+			spec.expParams(&exp)
+			assert.Equal(t, exp, k.GetParams(ctx))
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	corestoretypes "cosmossdk.io/core/store"
+	storetypes "cosmossdk.io/store/types"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -83,6 +86,56 @@ func WithQueryPlugins(x *QueryPlugins) Option {
 	})
 }
 
+// WithParamsQueryRoutes is an optional constructor parameter to enable the `params` custom query for contracts.
+// The modules must additionally be allowed via the `params_query_modules` wasm param. Other custom queries are
+// passed on to the custom querier that is set via Option `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithParamsQueryRoutes(routes ParamsQueryRoutes) Option {
+	return postOptsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{
+			Custom: ParamsQuerier(k, routes, k.queryRouter, k.cdc, q.Custom),
+		})
+	})
+}
+
+// WithDenomTraceQuerier is an optional constructor parameter to enable the `denom_trace` custom query for contracts
+// to resolve ibc denoms. Other custom queries are passed on to the custom querier that is set via Option `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithDenomTraceQuerier(x types.ICS20DenomSource) Option {
+	if x == nil {
+		panic("must not be nil")
+	}
+	return postOptsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{
+			Custom: DenomTraceQuerier(x, q.Custom),
+		})
+	})
+}
+
+// WithWasmParamsQuerier is an optional constructor parameter to enable the `wasm_params` custom query for contracts
+// to read the upload and instantiate permissions of this module. Other custom queries are passed on to the custom querier
+// that is set via Option `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithWasmParamsQuerier() Option {
+	return postOptsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{
+			Custom: WasmParamsQuerier(k, q.Custom),
+		})
+	})
+}
+
 // WithMessageEncoders is an optional constructor parameter to pass custom message encoder to the default wasm message handler.
 // This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
 func WithMessageEncoders(x *MessageEncoders) Option {
@@ -164,6 +217,71 @@ func WithMaxCallDepth(m uint32) Option {
 	})
 }
 
+// WithCodeUploadTTL overwrites the default number of blocks a chunked code upload is kept in state
+func WithCodeUploadTTL(blocks int64) Option {
+	if blocks <= 0 {
+		panic("code upload ttl must be positive")
+	}
+	return optsFn(func(k *Keeper) {
+		k.codeUploadTTL = blocks
+	})
+}
+
+// WithParamSubscriberGasLimit overwrites the default max gas of a single param subscriber call
+func WithParamSubscriberGasLimit(gas uint64) Option {
+	if gas == 0 {
+		panic("param subscriber gas limit must not be 0")
+	}
+	return optsFn(func(k *Keeper) {
+		k.subscriberGasLimit = gas
+	})
+}
+
+// WithCodeBlobSource sets a source for the original wasm code that is used to heal missing
+// entries in the VM cache on startup. See `Keeper.ValidateVMCache`
+func WithCodeBlobSource(x CodeBlobSource) Option {
+	if x == nil {
+		panic("must not be nil")
+	}
+	return optsFn(func(k *Keeper) {
+		k.codeBlobSource = x
+	})
+}
+
+// WithStoreWriteLog sets a node local debug log that receives all contract store writes and deletes
+// in FinalizeBlock. It has no effect on state or gas.
+func WithStoreWriteLog(x StoreWriteLog) Option {
+	if x == nil {
+		panic("must not be nil")
+	}
+	return optsFn(func(k *Keeper) {
+		k.storeWriteLog = x
+	})
+}
+
+// WithVersionedMultiStore sets the multistore with the historical state and the wasm store key
+// to support the node local contract state diff query. See `Keeper.ContractStateDiff`
+func WithVersionedMultiStore(x VersionedMultiStore, storeKey storetypes.StoreKey) Option {
+	if x == nil || storeKey == nil {
+		panic("must not be nil")
+	}
+	return optsFn(func(k *Keeper) {
+		k.versionedStore = x
+		k.versionedStoreKey = storeKey
+	})
+}
+
+// WithTransientStoreService sets the transient store that collects the wasm activity of a block for the
+// EventBlockWasmSummary. See `Keeper.EmitBlockSummary`
+func WithTransientStoreService(x corestoretypes.TransientStoreService) Option {
+	if x == nil {
+		panic("must not be nil")
+	}
+	return optsFn(func(k *Keeper) {
+		k.transientStoreService = x
+	})
+}
+
 // WithAcceptedAccountTypesOnContractInstantiation sets the accepted account types. Account types of this list won't be overwritten or cause a failure
 // when they exist for an address on contract instantiation.
 //
@@ -1,3 +1,5 @@
+//go:build cgo
+
 package keeper
 
 import (
@@ -122,6 +124,12 @@ func TestConstructorOptions(t *testing.T) {
 				assert.Equal(t, uint32(1), k.maxCallDepth)
 			},
 		},
+		"param subscriber gas limit": {
+			srcOpt: WithParamSubscriberGasLimit(1),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, uint64(1), k.subscriberGasLimit)
+			},
+		},
 		"accepted account types": {
 			srcOpt: WithAcceptedAccountTypesOnContractInstantiation(&authtypes.BaseAccount{}, &vestingtypes.ContinuousVestingAccount{}),
 			verify: func(t *testing.T, k Keeper) {
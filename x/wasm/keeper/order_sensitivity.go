@@ -0,0 +1,167 @@
+package keeper
+
+import (
+	"math"
+	"slices"
+
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// BlockExecuteMsg is a contract execution of a block together with the index of the tx it belongs to
+type BlockExecuteMsg struct {
+	TxIndex uint32
+	Msg     *types.MsgExecuteContract
+}
+
+// OrderSensitivityReport lists the contracts that ended with a different state when the
+// independent messages of a block were executed in reverse order
+type OrderSensitivityReport struct {
+	// Reordered contains the positions of the messages that were executed in reverse order
+	Reordered []int `json:"reordered"`
+	// Contracts contains the state diffs of block order (a) versus reverse order (b)
+	Contracts []ContractOrderDiff `json:"contracts"`
+	// Errors contains the messages that failed in one order but not the other
+	Errors []OrderSensitivityError `json:"errors,omitempty"`
+}
+
+// ContractOrderDiff is the contract state diff between both execution orders
+type ContractOrderDiff struct {
+	Contract string                 `json:"contract"`
+	Diffs    []types.StateDiffEntry `json:"diffs"`
+}
+
+// OrderSensitivityError is an execution result that differs between both execution orders
+type OrderSensitivityError struct {
+	Position int    `json:"position"`
+	ErrorA   string `json:"error_a,omitempty"`
+	ErrorB   string `json:"error_b,omitempty"`
+}
+
+// OrderSensitivity executes the messages in block order and again with all independent messages in reverse order.
+// Messages are independent when they do not touch an account that another message touches. Their results should
+// not depend on the order unless a contract uses the tx index. Both runs execute on branches of the given context
+// that are never written so that this can be used on a local read only store. This is not meant for consensus code.
+func (k Keeper) OrderSensitivity(ctx sdk.Context, msgs []BlockExecuteMsg) (*OrderSensitivityReport, error) {
+	senders := make([]sdk.AccAddress, len(msgs))
+	contracts := make([]sdk.AccAddress, len(msgs))
+	for i, m := range msgs {
+		var err error
+		if senders[i], err = sdk.AccAddressFromBech32(m.Msg.Sender); err != nil {
+			return nil, err
+		}
+		if contracts[i], err = sdk.AccAddressFromBech32(m.Msg.Contract); err != nil {
+			return nil, err
+		}
+	}
+
+	// run a: block order
+	ctxA, _ := ctx.CacheContext()
+	touched := make([]map[string]struct{}, len(msgs))
+	errsA := make([]error, len(msgs))
+	for i, m := range msgs {
+		touched[i], errsA[i] = k.executeIsolated(ctxA, m.TxIndex, senders[i], contracts[i], m.Msg)
+	}
+
+	// run b: independent messages in reverse order, keeping the tx index of the position
+	order := make([]int, len(msgs))
+	var independent []int
+	for i := range msgs {
+		order[i] = i
+		if !touchesOthers(touched, i) {
+			independent = append(independent, i)
+		}
+	}
+	for j, pos := range independent {
+		order[pos] = independent[len(independent)-1-j]
+	}
+	ctxB, _ := ctx.CacheContext()
+	errsB := make([]error, len(msgs))
+	for pos, i := range order {
+		_, errsB[i] = k.executeIsolated(ctxB, msgs[pos].TxIndex, senders[i], contracts[i], msgs[i].Msg)
+	}
+
+	report := OrderSensitivityReport{Reordered: []int{}, Contracts: []ContractOrderDiff{}}
+	if len(independent) > 1 {
+		report.Reordered = independent
+	}
+	for i := range msgs {
+		if (errsA[i] == nil) != (errsB[i] == nil) {
+			e := OrderSensitivityError{Position: i}
+			if errsA[i] != nil {
+				e.ErrorA = errsA[i].Error()
+			}
+			if errsB[i] != nil {
+				e.ErrorB = errsB[i].Error()
+			}
+			report.Errors = append(report.Errors, e)
+		}
+	}
+
+	var touchedContracts []string
+	for i := range msgs {
+		for addr := range touched[i] {
+			touchedContracts = append(touchedContracts, addr)
+		}
+	}
+	slices.Sort(touchedContracts)
+	for _, addr := range slices.Compact(touchedContracts) {
+		contractAddr := sdk.MustAccAddressFromBech32(addr)
+		if !k.HasContractInfo(ctx, contractAddr) {
+			continue
+		}
+		diffs, _ := diffKVStores(k.contractStore(ctxA, contractAddr), k.contractStore(ctxB, contractAddr), nil, math.MaxUint64, true)
+		if len(diffs) != 0 {
+			report.Contracts = append(report.Contracts, ContractOrderDiff{Contract: addr, Diffs: diffs})
+		}
+	}
+	return &report, nil
+}
+
+// executeIsolated executes the message on a branch of the context that is written on success only.
+// It returns the addresses of the sender and all contracts that emitted events.
+func (k Keeper) executeIsolated(ctx sdk.Context, txIndex uint32, sender, contract sdk.AccAddress, msg *types.MsgExecuteContract) (map[string]struct{}, error) {
+	touched := map[string]struct{}{sender.String(): {}, contract.String(): {}}
+	cacheCtx, commit := ctx.CacheContext()
+	em := sdk.NewEventManager()
+	cacheCtx = types.WithTXCounter(cacheCtx, txIndex).
+		WithEventManager(em).
+		WithGasMeter(storetypes.NewInfiniteGasMeter())
+	if _, err := k.execute(cacheCtx, contract, sender, msg.Msg, msg.Funds); err != nil {
+		return touched, err
+	}
+	commit()
+	for _, e := range em.Events() {
+		for _, a := range e.Attributes {
+			if a.Key == types.AttributeKeyContractAddr {
+				touched[a.Value] = struct{}{}
+			}
+		}
+	}
+	return touched, nil
+}
+
+// contractStore returns the raw contract state store
+func (k Keeper) contractStore(ctx sdk.Context, contractAddress sdk.AccAddress) storetypes.KVStore {
+	return prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractStorePrefix(contractAddress))
+}
+
+// touchesOthers returns true when any of the addresses touched at position i is touched by another position
+func touchesOthers(touched []map[string]struct{}, i int) bool {
+	for j := range touched {
+		if j == i {
+			continue
+		}
+		for addr := range touched[i] {
+			if _, ok := touched[j][addr]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
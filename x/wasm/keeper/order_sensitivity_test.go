@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestOrderSensitivity(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	// contract stores the tx index for an `"index"` msg and the msg otherwise
+	mock.ExecuteFn = func(_ wasmvm.Checksum, env wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		value := executeMsg
+		if string(executeMsg) == `"index"` {
+			value = binary.BigEndian.AppendUint32(nil, env.Transaction.Index)
+		}
+		store.Set([]byte("last"), value)
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	sensitive := SeedNewContractInstance(t, ctx, keepers, &mock)
+	insensitive := SeedNewContractInstance(t, ctx, keepers, &mock)
+	msgs := []BlockExecuteMsg{
+		{TxIndex: 0, Msg: &types.MsgExecuteContract{Sender: RandomBech32AccountAddress(t), Contract: sensitive.Contract.String(), Msg: []byte(`"index"`)}},
+		{TxIndex: 1, Msg: &types.MsgExecuteContract{Sender: RandomBech32AccountAddress(t), Contract: insensitive.Contract.String(), Msg: []byte(`"value"`)}},
+		// same contract as before so that it is not reordered
+		{TxIndex: 2, Msg: &types.MsgExecuteContract{Sender: RandomBech32AccountAddress(t), Contract: insensitive.Contract.String(), Msg: []byte(`"other"`)}},
+		{TxIndex: 3, Msg: &types.MsgExecuteContract{Sender: RandomBech32AccountAddress(t), Contract: RandomBech32AccountAddress(t), Msg: []byte(`"index"`)}},
+	}
+
+	// when
+	report, err := k.OrderSensitivity(ctx, msgs)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 3}, report.Reordered)
+	require.Len(t, report.Contracts, 1)
+	assert.Equal(t, sensitive.Contract.String(), report.Contracts[0].Contract)
+	assert.Equal(t, []types.StateDiffEntry{{
+		Key:    []byte("last"),
+		Type:   types.StateDiffTypeChanged,
+		SizeA:  4,
+		SizeB:  4,
+		ValueA: []byte{0, 0, 0, 0},
+		ValueB: []byte{0, 0, 0, 3},
+	}}, report.Contracts[0].Diffs)
+	assert.Empty(t, report.Errors)
+	// and the state was not modified
+	assert.Nil(t, keepers.WasmKeeper.QueryRaw(ctx, sensitive.Contract, []byte("last")))
+	assert.Nil(t, keepers.WasmKeeper.QueryRaw(ctx, insensitive.Contract, []byte("last")))
+}
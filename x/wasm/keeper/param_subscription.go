@@ -0,0 +1,142 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/contractmsg"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ types.ParamsChangeListener = Keeper{}
+
+// DefaultParamSubscriberGasLimit is the max gas of a single param subscriber call
+const DefaultParamSubscriberGasLimit uint64 = 1_000_000
+
+// registerParamSubscriber subscribes the contract to param changes of the given subspaces
+func (k Keeper) registerParamSubscriber(ctx context.Context, contractAddr sdk.AccAddress, subspaces []string) error {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	for _, subspace := range subspaces {
+		// store 1 byte to not run into `nil` debugging issues
+		if err := store.Set(types.GetParamSubscriberKey(subspace, contractAddr), []byte{1}); err != nil {
+			return err
+		}
+		sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeRegisterParamSubscriber,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyParamSubspace, subspace),
+		))
+	}
+	return nil
+}
+
+// deregisterParamSubscriber unsubscribes the contract from param changes of the given subspaces
+func (k Keeper) deregisterParamSubscriber(ctx context.Context, contractAddr sdk.AccAddress, subspaces []string) error {
+	store := k.storeService.OpenKVStore(ctx)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	for _, subspace := range subspaces {
+		if !k.IsParamSubscriber(ctx, subspace, contractAddr) {
+			return types.ErrNotFound.Wrapf("contract %s is not subscribed to %s", contractAddr, subspace)
+		}
+		if err := store.Delete(types.GetParamSubscriberKey(subspace, contractAddr)); err != nil {
+			return err
+		}
+		sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeDeregisterParamSub,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyParamSubspace, subspace),
+		))
+	}
+	return nil
+}
+
+// IsParamSubscriber returns true when the contract is subscribed to param changes of the subspace
+func (k Keeper) IsParamSubscriber(ctx context.Context, subspace string, contractAddr sdk.AccAddress) bool {
+	ok, err := k.storeService.OpenKVStore(ctx).Has(types.GetParamSubscriberKey(subspace, contractAddr))
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+// IterateParamSubscribers iterates over all contracts subscribed to param changes of the subspace ordered by address.
+func (k Keeper) IterateParamSubscribers(ctx context.Context, subspace string, cb func(contractAddr sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetParamSubscriberPrefix(subspace))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			return
+		}
+	}
+}
+
+// OnParamsChanged calls sudo on all contracts subscribed to the subspace with the new params.
+// Each contract is executed in an isolated cache context with its own gas limit. A failure, running
+// out of gas included, is emitted as an event and does not revert the param change or the state
+// changes of other subscribers.
+func (k Keeper) OnParamsChanged(ctx sdk.Context, subspace string, params json.RawMessage) {
+	var subscribers []sdk.AccAddress
+	k.IterateParamSubscribers(ctx, subspace, func(contractAddr sdk.AccAddress) bool {
+		subscribers = append(subscribers, contractAddr)
+		return false
+	})
+	if len(subscribers) == 0 {
+		return
+	}
+	msg, err := contractmsg.ParamsChanged(subspace, params)
+	if err != nil {
+		// can not happen with valid json params
+		panic(err)
+	}
+	for _, contractAddr := range subscribers {
+		cacheCtx, commit := ctx.CacheContext()
+		if err := k.sudoWithGasLimit(cacheCtx, contractAddr, msg, k.subscriberGasLimit); err != nil {
+			k.Logger(ctx).Info("param subscriber failed", "contract", contractAddr.String(), "subspace", subspace, "error", err)
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeParamSubscriberFailed,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyParamSubspace, subspace),
+				sdk.NewAttribute(types.AttributeKeyError, redactError(err).Error()),
+			))
+			k.keepFailedExecutionReceipt(ctx, cacheCtx, contractAddr)
+			continue
+		}
+		commit()
+	}
+}
+
+// updateParams sets all wasm parameters, records the change in the params history and notifies the subscribed contracts.
+// Code verification votes of addresses that are no longer code verifiers are dropped.
+func (k Keeper) updateParams(ctx context.Context, authority string, ps types.Params) error {
+	oldParams := k.GetParams(ctx)
+	if err := k.SetParams(ctx, ps); err != nil {
+		return err
+	}
+	if err := k.recordParamsChange(ctx, types.ParamsChange{
+		Height:    sdk.UnwrapSDKContext(ctx).BlockHeight(),
+		Authority: authority,
+		OldParams: oldParams,
+		NewParams: ps,
+	}); err != nil {
+		return err
+	}
+	k.pruneCodeVerificationVotes(ctx, ps)
+	bz, err := k.cdc.MarshalJSON(&ps)
+	if err != nil {
+		return err
+	}
+	k.OnParamsChanged(sdk.UnwrapSDKContext(ctx), types.ModuleName, bz)
+	return nil
+}
@@ -0,0 +1,275 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRegisterParamSubscriber(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	specs := map[string]struct {
+		src    types.MsgRegisterParamSubscriber
+		expErr bool
+	}{
+		"all good": {
+			src: types.MsgRegisterParamSubscriber{
+				Authority: k.GetAuthority(),
+				Contract:  example.Contract.String(),
+				Subspaces: []string{"wasm", "staking"},
+			},
+		},
+		"unauthorized": {
+			src: types.MsgRegisterParamSubscriber{
+				Authority: RandomBech32AccountAddress(t),
+				Contract:  example.Contract.String(),
+				Subspaces: []string{"wasm"},
+			},
+			expErr: true,
+		},
+		"unknown contract": {
+			src: types.MsgRegisterParamSubscriber{
+				Authority: k.GetAuthority(),
+				Contract:  RandomBech32AccountAddress(t),
+				Subspaces: []string{"wasm"},
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			_, gotErr := msgServer.RegisterParamSubscriber(ctx, &spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.False(t, k.IsParamSubscriber(ctx, "wasm", example.Contract))
+				return
+			}
+			require.NoError(t, gotErr)
+			for _, s := range spec.src.Subspaces {
+				assert.True(t, k.IsParamSubscriber(ctx, s, example.Contract))
+			}
+			assert.False(t, k.IsParamSubscriber(ctx, "bank", example.Contract))
+		})
+	}
+}
+
+func TestDeregisterParamSubscriber(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+	_, err := msgServer.RegisterParamSubscriber(parentCtx, &types.MsgRegisterParamSubscriber{
+		Authority: k.GetAuthority(),
+		Contract:  example.Contract.String(),
+		Subspaces: []string{"wasm", "staking"},
+	})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src           types.MsgDeregisterParamSubscriber
+		expErr        error
+		expSubscribed []string
+	}{
+		"all good": {
+			src: types.MsgDeregisterParamSubscriber{
+				Authority: k.GetAuthority(),
+				Contract:  example.Contract.String(),
+				Subspaces: []string{"wasm"},
+			},
+			expSubscribed: []string{"staking"},
+		},
+		"unauthorized": {
+			src: types.MsgDeregisterParamSubscriber{
+				Authority: RandomBech32AccountAddress(t),
+				Contract:  example.Contract.String(),
+				Subspaces: []string{"wasm"},
+			},
+			expErr: types.ErrInvalid,
+		},
+		"not subscribed": {
+			src: types.MsgDeregisterParamSubscriber{
+				Authority: k.GetAuthority(),
+				Contract:  example.Contract.String(),
+				Subspaces: []string{"bank"},
+			},
+			expErr: types.ErrNotFound,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+
+			// when
+			_, gotErr := msgServer.DeregisterParamSubscriber(ctx.WithEventManager(em), &spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			for _, s := range []string{"wasm", "staking"} {
+				assert.Equal(t, slices.Contains(spec.expSubscribed, s), k.IsParamSubscriber(ctx, s, example.Contract), s)
+			}
+			expEvt := sdk.NewEvent(types.EventTypeDeregisterParamSub,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+				sdk.NewAttribute(types.AttributeKeyParamSubspace, "wasm"),
+			)
+			assert.Equal(t, sdk.Events{expEvt}, em.Events())
+		})
+	}
+}
+
+func TestParamsChangedNotifiesSubscribers(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+
+	// recording contract that stores the last sudo message and fails for the contract with the failing code
+	type sudoCall struct {
+		contract string
+		msg      []byte
+	}
+	var recorded []sudoCall
+	var failingChecksum wasmvm.Checksum
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.SudoFn = func(checksum wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		store.Set([]byte("last"), sudoMsg)
+		recorded = append(recorded, sudoCall{contract: env.Contract.Address, msg: sudoMsg})
+		if bytes.Equal(failingChecksum, checksum) {
+			return &wasmvmtypes.ContractResult{Err: "testing"}, 1, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	subscriber := SeedNewContractInstance(t, ctx, keepers, &mock)
+	failingSubscriber := SeedNewContractInstance(t, ctx, keepers, &mock)
+	failingChecksum = failingSubscriber.Checksum
+	otherSubspaceSubscriber := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	msgServer := NewMsgServerImpl(k)
+	for contract, subspace := range map[string]string{
+		subscriber.Contract.String():              "wasm",
+		failingSubscriber.Contract.String():       "wasm",
+		otherSubspaceSubscriber.Contract.String(): "staking",
+	} {
+		_, err := msgServer.RegisterParamSubscriber(ctx, &types.MsgRegisterParamSubscriber{
+			Authority: k.GetAuthority(),
+			Contract:  contract,
+			Subspaces: []string{subspace},
+		})
+		require.NoError(t, err)
+	}
+
+	// when
+	newParams := types.Params{
+		CodeUploadAccess:             types.AllowNobody,
+		InstantiateDefaultPermission: types.AccessTypeNobody,
+	}
+	em := sdk.NewEventManager()
+	_, err := msgServer.UpdateParams(ctx.WithEventManager(em), &types.MsgUpdateParams{
+		Authority: k.GetAuthority(),
+		Params:    newParams,
+	})
+
+	// then params are updated
+	require.NoError(t, err)
+	assert.Equal(t, newParams, k.GetParams(ctx))
+
+	// and both wasm subscribers were called with the envelope
+	require.Len(t, recorded, 2)
+	for _, c := range recorded {
+		assert.NotEqual(t, otherSubspaceSubscriber.Contract.String(), c.contract)
+		var envelope types.ParamsChangedSudoMsg
+		require.NoError(t, json.Unmarshal(c.msg, &envelope))
+		assert.Equal(t, "wasm", envelope.ParamsChanged.Subspace)
+	}
+	assert.JSONEq(t, `{"params_changed":{"subspace":"wasm","params":{"code_upload_access":{"permission":"Nobody","addresses":[]},"instantiate_default_permission":"Nobody","disable_usage_tracking":false,"params_query_modules":[],"disable_instantiate_capability_check":false,"enable_execution_receipts":false,"enforce_unique_labels_per_creator":false,"disable_block_summary_events":false,"message_fees":[],"max_response_data_size":0,"max_response_events":0,"max_response_attributes":0,"disable_exec_trace_hash":false,"instantiate_default_permissions_by_origin":[],"code_verifiers":[],"code_verification_threshold":0,"max_scheduled_sudos_per_contract":0,"max_scheduled_sudo_gas_per_block":"0"}}}`, string(recorded[0].msg))
+
+	// and state changes of the failing subscriber are reverted
+	assert.NotEmpty(t, k.QueryRaw(ctx, subscriber.Contract, []byte("last")))
+	assert.Empty(t, k.QueryRaw(ctx, failingSubscriber.Contract, []byte("last")))
+
+	// and the failure was emitted as event
+	expEvt := sdk.NewEvent(types.EventTypeParamSubscriberFailed,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, failingSubscriber.Contract.String()),
+		sdk.NewAttribute(types.AttributeKeyParamSubspace, "wasm"),
+		sdk.NewAttribute(types.AttributeKeyError, "testing: execute wasm contract failed"),
+	)
+	assert.Contains(t, em.Events(), expEvt)
+
+	// and other modules can feed in param changes via the hook
+	recorded = nil
+	var listener types.ParamsChangeListener = k
+	listener.OnParamsChanged(ctx, "staking", json.RawMessage(`{"unbonding_time":"1s"}`))
+	require.Len(t, recorded, 1)
+	assert.Equal(t, otherSubspaceSubscriber.Contract.String(), recorded[0].contract)
+	assert.JSONEq(t, `{"params_changed":{"subspace":"staking","params":{"unbonding_time":"1s"}}}`, string(recorded[0].msg))
+}
+
+func TestParamsChangedSubscriberGasLimit(t *testing.T) {
+	const gasLimit = 100_000
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithParamSubscriberGasLimit(gasLimit))
+	k := keepers.WasmKeeper
+
+	var greedyChecksum wasmvm.Checksum
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.SudoFn = func(checksum wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLeft uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		store.Set([]byte("last"), sudoMsg)
+		if bytes.Equal(greedyChecksum, checksum) {
+			// more than the remaining gas
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, gasLeft + 1, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	subscriber := SeedNewContractInstance(t, ctx, keepers, &mock)
+	greedySubscriber := SeedNewContractInstance(t, ctx, keepers, &mock)
+	greedyChecksum = greedySubscriber.Checksum
+	for _, c := range []sdk.AccAddress{subscriber.Contract, greedySubscriber.Contract} {
+		require.NoError(t, k.registerParamSubscriber(ctx, c, []string{"staking"}))
+	}
+	gm := storetypes.NewGasMeter(10 * gasLimit)
+	em := sdk.NewEventManager()
+
+	// when
+	k.OnParamsChanged(ctx.WithGasMeter(gm).WithEventManager(em), "staking", json.RawMessage(`{}`))
+
+	// then the greedy subscriber ran out of gas and was reverted
+	assert.Empty(t, k.QueryRaw(ctx, greedySubscriber.Contract, []byte("last")))
+	var failed []string
+	for _, e := range em.Events() {
+		if e.Type == types.EventTypeParamSubscriberFailed {
+			attr, ok := e.GetAttribute(types.AttributeKeyContractAddr)
+			require.True(t, ok)
+			failed = append(failed, attr.Value)
+		}
+	}
+	assert.Equal(t, []string{greedySubscriber.Contract.String()}, failed)
+	// and the other subscriber is not affected
+	assert.NotEmpty(t, k.QueryRaw(ctx, subscriber.Contract, []byte("last")))
+	// and the parent gas meter did not run out of gas
+	assert.False(t, gm.IsOutOfGas())
+}
@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// recordParamsChange appends the change to the params history. Only the latest changes are kept,
+// the oldest entry is dropped when the history exceeds types.MaxParamsHistoryLen.
+func (k Keeper) recordParamsChange(ctx context.Context, c types.ParamsChange) error {
+	store := k.storeService.OpenKVStore(ctx)
+	seq := k.lastParamsChangeSeq(ctx) + 1
+	if err := store.Set(types.GetParamsChangeKey(seq), k.cdc.MustMarshal(&c)); err != nil {
+		return err
+	}
+	if seq > types.MaxParamsHistoryLen {
+		return store.Delete(types.GetParamsChangeKey(seq - types.MaxParamsHistoryLen))
+	}
+	return nil
+}
+
+// lastParamsChangeSeq returns the sequence of the latest params change or 0 when the history is empty
+func (k Keeper) lastParamsChangeSeq(ctx context.Context) uint64 {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.ParamsHistoryPrefix)
+	iter := prefixStore.ReverseIterator(nil, nil)
+	defer iter.Close()
+	if !iter.Valid() {
+		return 0
+	}
+	return sdk.BigEndianToUint64(iter.Key())
+}
+
+// IterateParamsHistory iterates over the latest params changes, the oldest first
+func (k Keeper) IterateParamsHistory(ctx context.Context, cb func(types.ParamsChange) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.ParamsHistoryPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var c types.ParamsChange
+		k.cdc.MustUnmarshal(iter.Value(), &c)
+		if cb(c) {
+			return
+		}
+	}
+}
+
+func (k Keeper) importParamsHistory(ctx context.Context, changes []types.ParamsChange) error {
+	for i, c := range changes {
+		if err := c.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "params change number %d", i)
+		}
+		if err := k.recordParamsChange(ctx, c); err != nil {
+			return errorsmod.Wrapf(err, "params change number %d", i)
+		}
+	}
+	return nil
+}
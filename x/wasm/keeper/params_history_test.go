@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestParamsHistory(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+
+	const updates = types.MaxParamsHistoryLen + 3
+	var expChanges []types.ParamsChange
+	for i := range updates {
+		height := int64(10 + i)
+		oldParams := k.GetParams(ctx)
+		newParams := types.DefaultParams()
+		newParams.MaxResponseEvents = uint32(i + 1)
+		_, err := msgServer.UpdateParams(ctx.WithBlockHeight(height), &types.MsgUpdateParams{
+			Authority: k.GetAuthority(),
+			Params:    newParams,
+		})
+		require.NoError(t, err)
+		expChanges = append(expChanges, types.ParamsChange{
+			Height:    height,
+			Authority: k.GetAuthority(),
+			OldParams: oldParams,
+			NewParams: newParams,
+		})
+	}
+
+	// when
+	var got []types.ParamsChange
+	k.IterateParamsHistory(ctx, func(c types.ParamsChange) bool {
+		got = append(got, c)
+		return false
+	})
+
+	// then the oldest changes were dropped
+	require.Len(t, got, types.MaxParamsHistoryLen)
+	assert.Equal(t, expChanges[updates-types.MaxParamsHistoryLen:], got)
+	// and the changes are ordered by height
+	heights := make([]int64, len(got))
+	for i, c := range got {
+		heights[i] = c.Height
+	}
+	assert.IsIncreasing(t, heights)
+	// and the latest change holds the current params
+	assert.Equal(t, k.GetParams(ctx), got[len(got)-1].NewParams)
+}
+
+func TestParamsHistoryCodeUploadAddresses(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+	myAddr := RandomBech32AccountAddress(t)
+	params := types.DefaultParams()
+	params.CodeUploadAccess = types.AccessTypeAnyOfAddresses.With(RandomAccountAddress(t))
+	require.NoError(t, k.SetParams(ctx, params))
+
+	// when
+	_, err := msgServer.AddCodeUploadParamsAddresses(ctx, &types.MsgAddCodeUploadParamsAddresses{
+		Authority: k.GetAuthority(),
+		Addresses: []string{myAddr},
+	})
+	require.NoError(t, err)
+
+	// then
+	var got []types.ParamsChange
+	k.IterateParamsHistory(ctx, func(c types.ParamsChange) bool {
+		got = append(got, c)
+		return false
+	})
+	require.Len(t, got, 1)
+	assert.Equal(t, params, got[0].OldParams)
+	assert.Contains(t, got[0].NewParams.CodeUploadAccess.Addresses, myAddr)
+}
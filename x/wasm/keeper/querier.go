@@ -1,13 +1,19 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"runtime/debug"
+	"strings"
+	"time"
 
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	host "github.com/cosmos/ibc-go/v10/modules/core/24-host"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -22,6 +28,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
 
+	"github.com/CosmWasm/wasmd/x/wasm/contractmsg"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -113,10 +120,23 @@ func (q GrpcQuerier) ContractsByCode(c context.Context, req *types.QueryContract
 	ctx := sdk.UnwrapSDKContext(c)
 	r := make([]string, 0)
 
+	if req.StartAfterAddress != "" {
+		if len(paginationParams.Key) != 0 {
+			return nil, errorsmod.Wrap(types.ErrInvalid, "start after address can not be combined with a page key")
+		}
+		startKey, err := contractByCodeStartAfterKey(ctx, q.keeper, req.CodeId, req.StartAfterAddress)
+		if err != nil {
+			return nil, err
+		}
+		paginationParams.Key = startKey
+	}
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractByCodeIDSecondaryIndexPrefix(req.CodeId))
 	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
+		if req.SuspendedOnly && !isSuspendedContract(ctx, q.keeper, contractAddr) {
+			return false, nil
+		}
 		if accumulate {
-			var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
 			r = append(r, contractAddr.String())
 		}
 		return true, nil
@@ -130,6 +150,35 @@ func (q GrpcQuerier) ContractsByCode(c context.Context, req *types.QueryContract
 	}, nil
 }
 
+// isSuspendedContract returns true for an existing contract that is suspended. Used to filter the contract listings.
+func isSuspendedContract(ctx sdk.Context, keeper types.ViewKeeper, contractAddr sdk.AccAddress) bool {
+	info := keeper.GetContractInfo(ctx, contractAddr)
+	return info != nil && info.Suspended
+}
+
+// contractByCodeStartAfterKey returns the page key of the entry that follows the contract in the
+// contracts by code index. The index position is the last code history entry of the contract.
+func contractByCodeStartAfterKey(ctx sdk.Context, keeper types.ViewKeeper, codeID uint64, startAfterAddress string) ([]byte, error) {
+	contractAddr, err := sdk.AccAddressFromBech32(startAfterAddress)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "start after address")
+	}
+	info := keeper.GetContractInfo(ctx, contractAddr)
+	if info == nil {
+		return nil, types.ErrNoSuchContractFn(startAfterAddress).Wrapf("address %s", startAfterAddress)
+	}
+	if info.CodeID != codeID {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "contract %s is not an instance of code %d", startAfterAddress, codeID)
+	}
+	history := keeper.GetContractHistory(ctx, contractAddr)
+	if len(history) == 0 {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "history of contract %s", startAfterAddress)
+	}
+	key := types.GetContractByCreatedSecondaryIndexKey(contractAddr, history[len(history)-1])
+	// strip the index prefix and append a zero byte for the smallest key after the contract
+	return append(key[len(types.GetContractByCodeIDSecondaryIndexPrefix(codeID)):], 0), nil
+}
+
 func (q GrpcQuerier) AllContractState(c context.Context, req *types.QueryAllContractStateRequest) (*types.QueryAllContractStateResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -151,16 +200,25 @@ func (q GrpcQuerier) AllContractState(c context.Context, req *types.QueryAllCont
 	}
 
 	r := make([]types.Model, 0)
-	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractStorePrefix(contractAddr))
-	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
-		if accumulate {
-			r = append(r, types.Model{
-				Key:   key,
-				Value: value,
-			})
+	onResult := func(key, value []byte) {
+		m := types.Model{Key: key}
+		if !req.KeysOnly {
+			m.Value = value
 		}
-		return true, nil
-	})
+		r = append(r, m)
+	}
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractStorePrefix(contractAddr))
+	var pageRes *query.PageResponse
+	if len(req.Prefix) != 0 {
+		pageRes, err = paginateKeyRange(prefixStore, req.Prefix, storetypes.PrefixEndBytes(req.Prefix), paginationParams, onResult)
+	} else {
+		pageRes, err = query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+			if accumulate {
+				onResult(key, value)
+			}
+			return true, nil
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -200,11 +258,20 @@ func (q GrpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 	if err != nil {
 		return nil, err
 	}
+	queryData := req.QueryData
+	switch {
+	case req.Signer != "" || len(req.Signature) != 0 || len(req.BlockHash) != 0:
+		if queryData, err = q.authenticatedQueryData(c, contractAddr, req); err != nil {
+			return nil, err
+		}
+	case types.IsAuthenticatedQuery(queryData):
+		return nil, status.Errorf(codes.InvalidArgument, "%s is reserved for signed queries", types.AuthenticatedQueryKey)
+	}
 
 	// limit the gas to the queryGasLimit or the remaining gas, whichever is smaller
 	ctx := sdk.UnwrapSDKContext(c)
 	gasLimit := min(ctx.GasMeter().GasRemaining(), q.queryGasLimit)
-	ctx = ctx.WithGasMeter(storetypes.NewGasMeter(gasLimit))
+	ctx = types.WithGRPCQuery(ctx.WithGasMeter(storetypes.NewGasMeter(gasLimit)))
 	// recover from out-of-gas panic
 	defer func() {
 		if r := recover(); r != nil {
@@ -226,7 +293,8 @@ func (q GrpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 		}
 	}()
 
-	bz, err := q.keeper.QuerySmart(ctx, contractAddr, req.QueryData)
+	start := time.Now()
+	bz, err := q.keeper.QuerySmart(ctx, contractAddr, queryData)
 	switch {
 	case err != nil:
 		return nil, err
@@ -234,7 +302,235 @@ func (q GrpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
 			Wrapf("address %s", contractAddr.String())
 	}
-	return &types.QuerySmartContractStateResponse{Data: bz}, nil
+	rsp = &types.QuerySmartContractStateResponse{Data: bz}
+	if req.WithDiagnostics {
+		rsp.Diagnostics = &types.SmartQueryDiagnostics{
+			GasUsed:        ctx.GasMeter().GasConsumed(),
+			WallTimeMicros: uint64(time.Since(start).Microseconds()),
+		}
+		if info := q.keeper.GetContractInfo(ctx, contractAddr); info != nil {
+			rsp.Diagnostics.Pinned = q.keeper.IsPinnedCode(ctx, info.CodeID)
+		}
+	}
+	return rsp, nil
+}
+
+// authenticatedQueryData verifies the signature of a signed smart query and returns the query data wrapped in
+// the authenticated query envelope
+func (q GrpcQuerier) authenticatedQueryData(c context.Context, contractAddr sdk.AccAddress, req *types.QuerySmartContractStateRequest) (types.RawContractMessage, error) {
+	if req.Signer == "" || len(req.Signature) == 0 || len(req.BlockHash) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "signer, signature and block hash must all be set")
+	}
+	signer, err := sdk.AccAddressFromBech32(req.Signer)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "signer: %s", err)
+	}
+	if err := q.keeper.AuthenticateSmartQuery(c, contractAddr, req.QueryData, signer, req.Signature, req.BlockHash); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	queryData, err := contractmsg.AuthenticatedSmartQuery(req.QueryData, signer)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return queryData, nil
+}
+
+// AliasedSmartContractState executes the smart query registered under the alias name
+func (q GrpcQuerier) AliasedSmartContractState(c context.Context, req *types.QueryAliasedSmartContractStateRequest) (*types.QueryAliasedSmartContractStateResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	queryData := q.keeper.GetQueryAlias(c, contractAddr, req.Name)
+	if queryData == nil {
+		return nil, types.ErrNotFound.Wrapf("query alias %s", req.Name)
+	}
+	rsp, err := q.SmartContractState(c, &types.QuerySmartContractStateRequest{Address: req.Address, QueryData: queryData})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryAliasedSmartContractStateResponse{
+		Data:            rsp.Data,
+		CacheTTLSeconds: q.keeper.GetQueryAliasCacheTTL(c, contractAddr, req.Name),
+	}, nil
+}
+
+// QueryAliases lists the query aliases of a contract
+func (q GrpcQuerier) QueryAliases(c context.Context, req *types.QueryQueryAliasesRequest) (*types.QueryQueryAliasesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	aliases := make([]types.QueryAlias, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetQueryAliasPrefix(contractAddr))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			name := string(key)
+			aliases = append(aliases, types.QueryAlias{Name: name, QueryData: value, CacheTTLSeconds: q.keeper.GetQueryAliasCacheTTL(ctx, contractAddr, name)})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryQueryAliasesResponse{
+		Aliases:    aliases,
+		Pagination: pageRes,
+	}, nil
+}
+
+// CodeAttestations returns the build attestations of a code. They are unverified claims of the attesters.
+func (q GrpcQuerier) CodeAttestations(c context.Context, req *types.QueryCodeAttestationsRequest) (*types.QueryCodeAttestationsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	attestations := make([]types.CodeAttestation, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetCodeAttestationPrefix(req.CodeId))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(_, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var a types.CodeAttestation
+			if err := q.cdc.Unmarshal(value, &a); err != nil {
+				return false, err
+			}
+			attestations = append(attestations, a)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodeAttestationsResponse{
+		Attestations: attestations,
+		Pagination:   pageRes,
+	}, nil
+}
+
+// CodeVerification returns the verification status of a code together with the votes of the code verifiers
+func (q GrpcQuerier) CodeVerification(c context.Context, req *types.QueryCodeVerificationRequest) (*types.QueryCodeVerificationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeID == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if q.keeper.GetCodeInfo(ctx, req.CodeID) == nil {
+		return nil, types.ErrNoSuchCodeFn(req.CodeID).Wrapf("code id %d", req.CodeID)
+	}
+	rsp := types.QueryCodeVerificationResponse{
+		Status: q.keeper.GetCodeVerificationStatus(ctx, req.CodeID),
+		Votes:  make([]types.CodeVerificationVote, 0),
+	}
+	q.keeper.IterateCodeVerificationVotes(ctx, req.CodeID, func(v types.CodeVerificationVote) bool {
+		switch v.Verdict {
+		case types.CodeVerificationVerdictReproducible:
+			rsp.ReproducibleVotes++
+		case types.CodeVerificationVerdictNotReproducible:
+			rsp.NotReproducibleVotes++
+		}
+		rsp.Votes = append(rsp.Votes, v)
+		return false
+	})
+	return &rsp, nil
+}
+
+func (q GrpcQuerier) ContractsByTag(c context.Context, req *types.QueryContractsByTagRequest) (*types.QueryContractsByTagResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := types.ValidateContractTag(req.Tag); err != nil {
+		return nil, err
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	contracts := make([]string, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractsByTagPrefix(req.Tag))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		if req.SuspendedOnly && !isSuspendedContract(ctx, q.keeper, key) {
+			return false, nil
+		}
+		if accumulate {
+			contracts = append(contracts, sdk.AccAddress(key).String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractsByTagResponse{
+		ContractAddresses: contracts,
+		Pagination:        pageRes,
+	}, nil
+}
+
+// ContractsByLabel returns the contracts with the label, or with a label that starts with it for prefix queries
+func (q GrpcQuerier) ContractsByLabel(c context.Context, req *types.QueryContractsByLabelRequest) (*types.QueryContractsByLabelResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.Label == "" {
+		return nil, errorsmod.Wrap(types.ErrEmpty, "label")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	contracts := make([]string, 0)
+	labels := make([]string, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractsByLabelPrefix(req.Label))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		// the label prefix is stripped from the key
+		labelSuffix, contractAddr, err := types.ParseContractByLabelKey(key)
+		if err != nil {
+			return false, err
+		}
+		if !req.Prefix && labelSuffix != "" {
+			return false, nil
+		}
+		if req.SuspendedOnly && !isSuspendedContract(ctx, q.keeper, contractAddr) {
+			return false, nil
+		}
+		if accumulate {
+			contracts = append(contracts, contractAddr.String())
+			labels = append(labels, req.Label+labelSuffix)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractsByLabelResponse{
+		ContractAddresses: contracts,
+		Labels:            labels,
+		Pagination:        pageRes,
+	}, nil
 }
 
 func (q GrpcQuerier) Code(c context.Context, req *types.QueryCodeRequest) (*types.QueryCodeResponse, error) {
@@ -265,24 +561,57 @@ func (q GrpcQuerier) Codes(c context.Context, req *types.QueryCodesRequest) (*ty
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := types.AccessType_name[int32(req.InstantiatePermission)]; !ok {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "instantiate permission")
+	}
 
 	ctx := sdk.UnwrapSDKContext(c)
 	r := make([]types.CodeInfoResponse, 0)
-	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.CodeKeyPrefix)
-	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+	// onCodeInfo adds the code when it matches the filters
+	onCodeInfo := func(codeID uint64, c types.CodeInfo, accumulate bool) bool {
+		if req.InstantiatePermission != types.AccessTypeUnspecified && c.InstantiateConfig.Permission != req.InstantiatePermission {
+			return false
+		}
 		if accumulate {
-			var c types.CodeInfo
-			if err := q.cdc.Unmarshal(value, &c); err != nil {
-				return false, err
-			}
 			r = append(r, types.CodeInfoResponse{
-				CodeID:                binary.BigEndian.Uint64(key),
+				CodeID:                codeID,
 				Creator:               c.Creator,
 				DataHash:              c.CodeHash,
 				InstantiatePermission: c.InstantiateConfig,
+				Provenance:            c.Provenance,
+				Origin:                c.Origin,
+				VerificationStatus:    q.keeper.GetCodeVerificationStatus(ctx, codeID),
 			})
 		}
-		return true, nil
+		return true
+	}
+	var pageRes *query.PageResponse
+	if req.Creator != "" {
+		creator, err := sdk.AccAddressFromBech32(req.Creator)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "creator")
+		}
+		prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetCodesByCreatorPrefix(creator))
+		pageRes, err = query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+			codeID := binary.BigEndian.Uint64(key)
+			info := q.keeper.GetCodeInfo(ctx, codeID)
+			if info == nil {
+				return false, types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+			}
+			return onCodeInfo(codeID, *info, accumulate), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &types.QueryCodesResponse{CodeInfos: r, Pagination: pageRes}, nil
+	}
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.CodeKeyPrefix)
+	pageRes, err = query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		var c types.CodeInfo
+		if err := q.cdc.Unmarshal(value, &c); err != nil {
+			return false, err
+		}
+		return onCodeInfo(binary.BigEndian.Uint64(key), c, accumulate), nil
 	})
 	if err != nil {
 		return nil, err
@@ -306,9 +635,59 @@ func (q GrpcQuerier) CodeInfo(c context.Context, req *types.QueryCodeInfoRequest
 		Creator:               info.Creator,
 		Checksum:              info.DataHash,
 		InstantiatePermission: info.InstantiatePermission,
+		Provenance:            info.Provenance,
+		Origin:                info.Origin,
+		VerificationStatus:    info.VerificationStatus,
+	}, nil
+}
+
+// CodeByChecksum returns the code info of the first code with the checksum and the ids of all codes with the checksum
+func (q GrpcQuerier) CodeByChecksum(c context.Context, req *types.QueryCodeByChecksumRequest) (*types.QueryCodeByChecksumResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	checksum, err := hex.DecodeString(req.Checksum)
+	if err != nil {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "checksum: %s", err)
+	}
+	if len(checksum) != sha256.Size {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "checksum must have %d bytes", sha256.Size)
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	var codeIDs []uint64
+	q.keeper.IterateCodesByChecksum(ctx, checksum, func(codeID uint64) bool {
+		codeIDs = append(codeIDs, codeID)
+		return false
+	})
+	if len(codeIDs) == 0 {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "code with checksum %s", req.Checksum)
+	}
+	info := queryCodeInfo(ctx, codeIDs[0], q.keeper)
+	if info == nil {
+		return nil, types.ErrNoSuchCodeFn(codeIDs[0]).Wrapf("code id %d", codeIDs[0])
+	}
+	return &types.QueryCodeByChecksumResponse{
+		CodeInfo: *info,
+		CodeIDs:  codeIDs,
 	}, nil
 }
 
+// WasmStats returns the number of stored codes and instantiated contracts and optionally the number of contracts of a code
+func (q GrpcQuerier) WasmStats(c context.Context, req *types.QueryWasmStatsRequest) (*types.QueryWasmStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	rsp := &types.QueryWasmStatsResponse{Stats: q.keeper.GetWasmStats(ctx)}
+	if req.CodeID != 0 {
+		if q.keeper.GetCodeInfo(ctx, req.CodeID) == nil {
+			return nil, types.ErrNoSuchCodeFn(req.CodeID).Wrapf("code id %d", req.CodeID)
+		}
+		rsp.CodeContractCount = q.keeper.GetContractCountByCode(ctx, req.CodeID)
+	}
+	return rsp, nil
+}
+
 func queryContractInfo(ctx sdk.Context, addr sdk.AccAddress, keeper types.ViewKeeper) (*types.QueryContractInfoResponse, error) {
 	info := keeper.GetContractInfo(ctx, addr)
 	if info == nil {
@@ -349,6 +728,9 @@ func queryCodeInfo(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) *typ
 		Creator:               res.Creator,
 		DataHash:              res.CodeHash,
 		InstantiatePermission: res.InstantiateConfig,
+		Provenance:            res.Provenance,
+		Origin:                res.Origin,
+		VerificationStatus:    keeper.GetCodeVerificationStatus(ctx, codeID),
 	}
 	return &info
 }
@@ -364,11 +746,17 @@ func (q GrpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesR
 
 	ctx := sdk.UnwrapSDKContext(c)
 	r := make([]uint64, 0)
+	var infos []types.PinnedCodeInfo
 
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.PinnedCodeIndexPrefix)
 	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
 		if accumulate {
-			r = append(r, sdk.BigEndianToUint64(key))
+			codeID := sdk.BigEndianToUint64(key)
+			r = append(r, codeID)
+			if req.Verbose {
+				// a missing code info is returned as null to not fail the whole query
+				infos = append(infos, types.PinnedCodeInfo{CodeID: codeID, CodeInfo: queryCodeInfo(ctx, codeID, q.keeper)})
+			}
 		}
 		return true, nil
 	})
@@ -378,6 +766,7 @@ func (q GrpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesR
 	return &types.QueryPinnedCodesResponse{
 		CodeIDs:    r,
 		Pagination: pageRes,
+		CodeInfos:  infos,
 	}, nil
 }
 
@@ -388,6 +777,19 @@ func (q GrpcQuerier) Params(c context.Context, _ *types.QueryParamsRequest) (*ty
 	return &types.QueryParamsResponse{Params: params}, nil
 }
 
+// ParamsHistory gets the latest changes of the wasm params, the oldest first
+func (q GrpcQuerier) ParamsHistory(c context.Context, req *types.QueryParamsHistoryRequest) (*types.QueryParamsHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	changes := make([]types.ParamsChange, 0)
+	q.keeper.IterateParamsHistory(sdk.UnwrapSDKContext(c), func(change types.ParamsChange) bool {
+		changes = append(changes, change)
+		return false
+	})
+	return &types.QueryParamsHistoryResponse{Changes: changes}, nil
+}
+
 func (q GrpcQuerier) ContractsByCreator(c context.Context, req *types.QueryContractsByCreatorRequest) (*types.QueryContractsByCreatorResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -399,6 +801,7 @@ func (q GrpcQuerier) ContractsByCreator(c context.Context, req *types.QueryContr
 
 	ctx := sdk.UnwrapSDKContext(c)
 	contracts := make([]string, 0)
+	var infos []types.ContractInfoWithAddress
 
 	creatorAddress, err := sdk.AccAddressFromBech32(req.CreatorAddress)
 	if err != nil {
@@ -406,9 +809,20 @@ func (q GrpcQuerier) ContractsByCreator(c context.Context, req *types.QueryContr
 	}
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractsByCreatorPrefix(creatorAddress))
 	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		accAddress := sdk.AccAddress(key[types.AbsoluteTxPositionLen:])
+		if req.SuspendedOnly && !isSuspendedContract(ctx, q.keeper, accAddress) {
+			return false, nil
+		}
 		if accumulate {
-			accAddress := sdk.AccAddress(key[types.AbsoluteTxPositionLen:])
 			contracts = append(contracts, accAddress.String())
+			if req.WithInfo {
+				// the number of lookups is bound by the page size
+				info := q.keeper.GetContractInfo(ctx, accAddress)
+				if info == nil {
+					return false, types.ErrNoSuchContractFn(accAddress.String())
+				}
+				infos = append(infos, types.ContractInfoWithAddress{Address: accAddress.String(), ContractInfo: *info})
+			}
 		}
 		return true, nil
 	})
@@ -419,9 +833,328 @@ func (q GrpcQuerier) ContractsByCreator(c context.Context, req *types.QueryContr
 	return &types.QueryContractsByCreatorResponse{
 		ContractAddresses: contracts,
 		Pagination:        pageRes,
+		ContractInfos:     infos,
+	}, nil
+}
+
+// ContractsByAdmin returns the contracts of the admin ordered by address
+func (q GrpcQuerier) ContractsByAdmin(c context.Context, req *types.QueryContractsByAdminRequest) (*types.QueryContractsByAdminResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	adminAddress, err := sdk.AccAddressFromBech32(req.AdminAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	contracts := make([]string, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractsByAdminPrefix(adminAddress))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		if req.SuspendedOnly && !isSuspendedContract(ctx, q.keeper, key) {
+			return false, nil
+		}
+		if accumulate {
+			contracts = append(contracts, sdk.AccAddress(key).String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractsByAdminResponse{
+		ContractAddresses: contracts,
+		Pagination:        pageRes,
+	}, nil
+}
+
+// ScheduledSudos returns the pending scheduled sudo calls of the contract ordered by id
+func (q GrpcQuerier) ScheduledSudos(c context.Context, req *types.QueryScheduledSudosRequest) (*types.QueryScheduledSudosResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	scheduled := make([]types.ScheduledSudo, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetScheduledSudosByContractPrefix(contractAddr))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			s := q.keeper.GetScheduledSudo(ctx, contractAddr, sdk.BigEndianToUint64(key))
+			if s == nil {
+				return false, types.ErrNotFound.Wrap("scheduled sudo")
+			}
+			scheduled = append(scheduled, *s)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryScheduledSudosResponse{
+		ScheduledSudos: scheduled,
+		Pagination:     pageRes,
+	}, nil
+}
+
+// ContractChannelStatus returns the packet sequences and the state of a channel of the contract
+func (q GrpcQuerier) ContractChannelStatus(c context.Context, req *types.QueryContractChannelStatusRequest) (*types.QueryContractChannelStatusResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	if err := host.ChannelIdentifierValidator(req.ChannelID); err != nil {
+		return nil, errorsmod.Wrap(types.ErrInvalid, err.Error())
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	return q.keeper.GetContractChannelStatus(ctx, contractAddr, req.ChannelID)
+}
+
+// ContractChildren returns the contracts that were instantiated by the given contract
+func (q GrpcQuerier) ContractChildren(c context.Context, req *types.QueryContractChildrenRequest) (*types.QueryContractChildrenResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	parentAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	contracts := make([]string, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractChildrenPrefix(parentAddr))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			contracts = append(contracts, sdk.AccAddress(key).String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractChildrenResponse{
+		Contracts:  contracts,
+		Pagination: pageRes,
+	}, nil
+}
+
+// ContractParent returns the contract that instantiated the given contract
+func (q GrpcQuerier) ContractParent(c context.Context, req *types.QueryContractParentRequest) (*types.QueryContractParentResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	var parent string
+	if addr := q.keeper.GetContractParent(ctx, contractAddr); addr != nil {
+		parent = addr.String()
+	}
+	return &types.QueryContractParentResponse{Parent: parent}, nil
+}
+
+// ContractUsage returns the invocation counters of the contract entry points
+func (q GrpcQuerier) ContractUsage(c context.Context, req *types.QueryContractUsageRequest) (*types.QueryContractUsageResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	entryPoints := make([]types.EntryPointUsage, 0)
+	q.keeper.IterateContractUsage(ctx, contractAddr, func(u types.EntryPointUsage) bool {
+		entryPoints = append(entryPoints, u)
+		return false
+	})
+	return &types.QueryContractUsageResponse{EntryPoints: entryPoints}, nil
+}
+
+// ContractFeeSponsorship returns the fee sponsorship budgets of the contract and the fees paid in the current block
+func (q GrpcQuerier) ContractFeeSponsorship(c context.Context, req *types.QueryContractFeeSponsorshipRequest) (*types.QueryContractFeeSponsorshipResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	contractInfo := q.keeper.GetContractInfo(ctx, contractAddr)
+	if contractInfo == nil {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	return &types.QueryContractFeeSponsorshipResponse{
+		Sponsorship: contractInfo.FeeSponsorship,
+		UsedInBlock: q.keeper.GetFeeSponsorshipUsedInBlock(ctx, contractAddr),
 	}, nil
 }
 
+// AddressType returns the classification of the address and the code id for contracts
+func (q GrpcQuerier) AddressType(c context.Context, req *types.QueryAddressTypeRequest) (*types.QueryAddressTypeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	rsp := &types.QueryAddressTypeResponse{AddressType: q.keeper.GetAddressType(ctx, addr)}
+	if rsp.AddressType == types.AddressTypeContract {
+		rsp.CodeID = q.keeper.GetContractInfo(ctx, addr).CodeID
+	}
+	return rsp, nil
+}
+
+// ContractExecutionReceipt returns the metadata of the last execution of a contract
+func (q GrpcQuerier) ContractExecutionReceipt(c context.Context, req *types.QueryContractExecutionReceiptRequest) (*types.QueryContractExecutionReceiptResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	receipt := q.keeper.GetExecutionReceipt(ctx, contractAddr)
+	if receipt == nil {
+		return nil, status.Error(codes.NotFound, "no execution receipt")
+	}
+	return &types.QueryContractExecutionReceiptResponse{Receipt: *receipt}, nil
+}
+
+// ContractStateDiff returns the changed keys of the contract state between two heights
+func (q GrpcQuerier) ContractStateDiff(c context.Context, req *types.QueryContractStateDiffRequest) (*types.QueryContractStateDiffResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	if paginationParams.Reverse {
+		return nil, status.Error(codes.InvalidArgument, "reverse pagination not supported")
+	}
+	entries, nextKey, err := q.keeper.ContractStateDiff(contractAddr, req.HeightA, req.HeightB, paginationParams.Key, paginationParams.Limit, req.IncludeValues)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractStateDiffResponse{
+		Entries:    entries,
+		Pagination: &query.PageResponse{NextKey: nextKey},
+	}, nil
+}
+
+// limits of the sizes in an event gas estimate request
+const (
+	maxEstimatedEvents          = 1_000
+	maxEstimatedEventAttributes = 10_000
+	maxEstimatedEventBytes      = 1 << 20 // 1 MiB
+)
+
+func (q GrpcQuerier) EstimateEventGas(c context.Context, req *types.QueryEstimateEventGasRequest) (*types.QueryEstimateEventGasResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if len(req.Events) > maxEstimatedEvents {
+		return nil, status.Errorf(codes.InvalidArgument, "max %d events", maxEstimatedEvents)
+	}
+	// the request values are checked on their own as well, so that an overflow of a sum is caught
+	attributeCount, totalBytes, maxLen := req.AttributeCount, req.AttributeBytes, req.AttributeBytes
+	for _, e := range req.Events {
+		for _, v := range []uint64{e.AttributeCount, e.AttributeBytes, e.TypeLength} {
+			if v > maxEstimatedEventBytes {
+				return nil, status.Errorf(codes.InvalidArgument, "max %d bytes", maxEstimatedEventBytes)
+			}
+		}
+		if e.AttributeCount == 0 && e.AttributeBytes != 0 {
+			return nil, status.Error(codes.InvalidArgument, "event attribute bytes without attributes")
+		}
+		attributeCount += e.AttributeCount
+		totalBytes += e.AttributeBytes + e.TypeLength
+		maxLen = max(maxLen, e.AttributeBytes, e.TypeLength)
+	}
+	switch {
+	case req.AttributeCount > maxEstimatedEventAttributes || attributeCount > maxEstimatedEventAttributes:
+		return nil, status.Errorf(codes.InvalidArgument, "max %d attributes", maxEstimatedEventAttributes)
+	case req.AttributeBytes > maxEstimatedEventBytes || totalBytes > maxEstimatedEventBytes:
+		return nil, status.Errorf(codes.InvalidArgument, "max %d bytes", maxEstimatedEventBytes)
+	case req.AttributeCount == 0 && req.AttributeBytes != 0:
+		return nil, status.Error(codes.InvalidArgument, "attribute bytes without attributes")
+	}
+
+	// the costs depend on the lengths only, so that the events are built from a shared filler
+	filler := strings.Repeat("x", int(maxLen))
+	attrs := sizedEventAttributes(filler, req.AttributeCount, req.AttributeBytes)
+	events := make(wasmvmtypes.Array[wasmvmtypes.Event], len(req.Events))
+	for i, e := range req.Events {
+		events[i] = wasmvmtypes.Event{
+			Type:       filler[:e.TypeLength],
+			Attributes: sizedEventAttributes(filler, e.AttributeCount, e.AttributeBytes),
+		}
+	}
+	gasRegister := q.keeper.GetGasRegister()
+	res := &types.QueryEstimateEventGasResponse{Gas: gasRegister.EventCosts(attrs, events)}
+	if source, ok := gasRegister.(types.EventGasConstantsSource); ok {
+		constants := source.EventGasConstants()
+		res.Constants = &constants
+	}
+	return res, nil
+}
+
+// sizedEventAttributes returns the number of attributes with the total length of keys and values
+func sizedEventAttributes(filler string, count, totalLen uint64) []wasmvmtypes.EventAttribute {
+	if count == 0 {
+		return nil
+	}
+	attrs := make([]wasmvmtypes.EventAttribute, count)
+	attrs[0].Key = filler[:totalLen]
+	return attrs
+}
+
 // max limit to pagination queries
 const maxResultEntries = 100
 
@@ -444,6 +1177,39 @@ func ensurePaginationParams(req *query.PageRequest) (*query.PageRequest, error)
 	return req, nil
 }
 
+// paginateKeyRange pages through the entries of the store with keys in the range [start, end). A nil end is
+// the end of the store. The page key of the request must be within the range. Unlike a prefix store, the
+// returned keys and page keys are never empty when the start key is not empty.
+func paginateKeyRange(store storetypes.KVStore, start, end []byte, pageReq *query.PageRequest, onResult func(key, value []byte)) (*query.PageResponse, error) {
+	if key := pageReq.Key; len(key) != 0 {
+		if bytes.Compare(key, start) < 0 || (end != nil && bytes.Compare(key, end) >= 0) {
+			return nil, errorsmod.Wrap(types.ErrInvalid, "page key out of range")
+		}
+		if pageReq.Reverse {
+			// the page key is the first entry to return
+			end = append(bytes.Clone(key), 0)
+		} else {
+			start = key
+		}
+	}
+	var iter storetypes.Iterator
+	if pageReq.Reverse {
+		iter = store.ReverseIterator(start, end)
+	} else {
+		iter = store.Iterator(start, end)
+	}
+	defer iter.Close()
+	var count uint64
+	for ; iter.Valid(); iter.Next() {
+		if count == pageReq.Limit {
+			return &query.PageResponse{NextKey: bytes.Clone(iter.Key())}, nil
+		}
+		onResult(iter.Key(), iter.Value())
+		count++
+	}
+	return &query.PageResponse{}, nil
+}
+
 func (q GrpcQuerier) WasmLimitsConfig(c context.Context, req *types.QueryWasmLimitsConfigRequest) (*types.QueryWasmLimitsConfigResponse, error) {
 	json, err := json.Marshal(q.keeper.GetWasmLimits())
 	if err != nil {
@@ -461,6 +1227,59 @@ func (q GrpcQuerier) BuildAddress(c context.Context, req *types.QueryBuildAddres
 	return BuildAddressPredictable(req)
 }
 
+// CodeUploadApprovals returns the code checksums pre-approved by governance ordered by checksum
+func (q GrpcQuerier) CodeUploadApprovals(c context.Context, req *types.QueryCodeUploadApprovalsRequest) (*types.QueryCodeUploadApprovalsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.CodeUploadApproval, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.CodeUploadApprovalPrefix)
+	pageRes, err := query.Paginate(prefixStore, paginationParams, func(_, value []byte) error {
+		var approval types.CodeUploadApproval
+		if err := q.cdc.Unmarshal(value, &approval); err != nil {
+			return err
+		}
+		r = append(r, approval)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodeUploadApprovalsResponse{Approvals: r, Pagination: pageRes}, nil
+}
+
+func (q GrpcQuerier) PredictPortID(c context.Context, req *types.QueryPredictPortIDRequest) (*types.QueryPredictPortIDResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	defer ctx.GasMeter().ConsumeGas(DefaultGasCostBuildAddress, "predict port id")
+	return PredictPortID(req)
+}
+
+// PredictPortID builds the instantiate2 contract address and derives the IBC port id from it.
+// The port is only bound when the contract has IBC entry points.
+func PredictPortID(req *types.QueryPredictPortIDRequest) (*types.QueryPredictPortIDResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	res, err := BuildAddressPredictable(&types.QueryBuildAddressRequest{
+		CodeHash:       req.CodeHash,
+		CreatorAddress: req.CreatorAddress,
+		Salt:           req.Salt,
+		InitArgs:       req.InitArgs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryPredictPortIDResponse{
+		Address: res.Address,
+		PortID:  types.PortIDForContract(sdk.MustAccAddressFromBech32(res.Address)),
+	}, nil
+}
+
 func BuildAddressPredictable(req *types.QueryBuildAddressRequest) (*types.QueryBuildAddressResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -477,8 +1296,8 @@ func BuildAddressPredictable(req *types.QueryBuildAddressRequest) (*types.QueryB
 	if err != nil {
 		return nil, fmt.Errorf("invalid salt: %w", err)
 	}
-	if len(salt) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "empty salt")
+	if err := types.ValidateSalt(salt); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	if req.InitArgs == nil {
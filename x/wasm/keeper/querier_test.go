@@ -1,11 +1,16 @@
 package keeper
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +29,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
@@ -105,6 +111,17 @@ func TestQueryAllContractState(t *testing.T) {
 				{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
 			},
 		},
+		"keys only": {
+			srcQuery: &types.QueryAllContractStateRequest{
+				Address:  contractAddr.String(),
+				KeysOnly: true,
+			},
+			expModelContains: []types.Model{
+				{Key: []byte{0x0, 0x1}},
+				{Key: []byte("foo")},
+			},
+			expModelContainsNot: contractModel,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -125,6 +142,130 @@ func TestQueryAllContractState(t *testing.T) {
 	}
 }
 
+func TestQueryAllContractStateKeysOnlyPagination(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractAddr := exampleContract.Contract
+	require.NoError(t, keeper.importContractState(ctx, contractAddr, []types.Model{
+		{Key: []byte("a"), Value: []byte(`1`)},
+		{Key: []byte("b"), Value: []byte(`2`)},
+		{Key: []byte("c"), Value: []byte(`3`)},
+	}))
+	q := Querier(keeper)
+	page := func(keysOnly bool, key []byte) *types.QueryAllContractStateResponse {
+		res, err := q.AllContractState(ctx, &types.QueryAllContractStateRequest{
+			Address:    contractAddr.String(),
+			Pagination: &query.PageRequest{Key: key, Limit: 1},
+			KeysOnly:   keysOnly,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Models, 1)
+		return res
+	}
+
+	var nextKey []byte
+	for i := 0; ; i++ {
+		full, keysOnly := page(false, nextKey), page(true, nextKey)
+		// then the same keys are returned without values
+		assert.Equal(t, full.Models[0].Key, keysOnly.Models[0].Key)
+		assert.NotEmpty(t, full.Models[0].Value)
+		assert.Empty(t, keysOnly.Models[0].Value)
+		// and the page keys are interchangeable
+		assert.Equal(t, full.Pagination.NextKey, keysOnly.Pagination.NextKey)
+		nextKey = keysOnly.Pagination.NextKey
+		if nextKey == nil {
+			break
+		}
+		require.Less(t, i, 10, "pagination does not terminate")
+	}
+}
+
+func TestQueryAllContractStateByPrefix(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractAddr := exampleContract.Contract
+	otherContract := InstantiateHackatomExampleContract(t, ctx, keepers).Contract
+	balanceA := types.Model{Key: []byte("\x00\x03balalice"), Value: []byte(`1`)}
+	balanceB := types.Model{Key: []byte("\x00\x03balbob"), Value: []byte(`2`)}
+	config := types.Model{Key: []byte("\x00\x03cfg"), Value: []byte(`3`)}
+	beforeFF := types.Model{Key: []byte{0xfe}, Value: []byte(`4`)}
+	onlyFF := types.Model{Key: []byte{0xff}, Value: []byte(`5`)}
+	ff00 := types.Model{Key: []byte{0xff, 0x00}, Value: []byte(`6`)}
+	ffff01 := types.Model{Key: []byte{0xff, 0xff, 0x01}, Value: []byte(`7`)}
+	require.NoError(t, keeper.importContractState(ctx, contractAddr, []types.Model{balanceA, balanceB, config, beforeFF, onlyFF, ff00, ffff01}))
+	require.NoError(t, keeper.importContractState(ctx, otherContract, []types.Model{{Key: []byte{0xff, 0xff, 0x02}, Value: []byte(`8`)}}))
+	q := Querier(keeper)
+
+	specs := map[string]struct {
+		prefix  []byte
+		reverse bool
+		exp     []types.Model
+	}{
+		"map namespace": {
+			prefix: []byte("\x00\x03bal"),
+			exp:    []types.Model{balanceA, balanceB},
+		},
+		"map namespace reverse": {
+			prefix:  []byte("\x00\x03bal"),
+			reverse: true,
+			exp:     []types.Model{balanceB, balanceA},
+		},
+		"prefix ending in 0xff": {
+			prefix: []byte{0xff},
+			exp:    []types.Model{onlyFF, ff00, ffff01},
+		},
+		"prefix of only 0xff": {
+			prefix: []byte{0xff, 0xff},
+			exp:    []types.Model{ffff01},
+		},
+		"prefix ending in 0xff reverse": {
+			prefix:  []byte{0xff},
+			reverse: true,
+			exp:     []types.Model{ffff01, ff00, onlyFF},
+		},
+		"unknown prefix": {
+			prefix: []byte("unknown"),
+			exp:    []types.Model{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// when all pages are crawled
+			var got []types.Model
+			var nextKey []byte
+			for i := 0; ; i++ {
+				res, err := q.AllContractState(ctx, &types.QueryAllContractStateRequest{
+					Address:    contractAddr.String(),
+					Pagination: &query.PageRequest{Key: nextKey, Limit: 1, Reverse: spec.reverse},
+					Prefix:     spec.prefix,
+				})
+				require.NoError(t, err)
+				got = append(got, res.Models...)
+				nextKey = res.Pagination.NextKey
+				if nextKey == nil {
+					break
+				}
+				require.Less(t, i, 10, "pagination does not terminate")
+			}
+			// then only the keys with the prefix are returned in full
+			if len(spec.exp) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+	// and a page key outside of the prefix is rejected
+	_, err := q.AllContractState(ctx, &types.QueryAllContractStateRequest{
+		Address:    contractAddr.String(),
+		Pagination: &query.PageRequest{Key: config.Key},
+		Prefix:     []byte("\x00\x03bal"),
+	})
+	require.ErrorIs(t, err, types.ErrInvalid)
+}
+
 func TestQuerySmartContractState(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.WasmKeeper
@@ -170,6 +311,52 @@ func TestQuerySmartContractState(t *testing.T) {
 	}
 }
 
+func TestQuerySmartContractStateDiagnostics(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	pinnedContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	require.NoError(t, keeper.pinCode(ctx, pinnedContract.CodeID))
+	unpinnedContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	q := Querier(keeper)
+	specs := map[string]struct {
+		contract        sdk.AccAddress
+		withDiagnostics bool
+		expPinned       bool
+	}{
+		"pinned code": {
+			contract:        pinnedContract.Contract,
+			withDiagnostics: true,
+			expPinned:       true,
+		},
+		"unpinned code": {
+			contract:        unpinnedContract.Contract,
+			withDiagnostics: true,
+		},
+		"not requested": {
+			contract: pinnedContract.Contract,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := q.SmartContractState(ctx, &types.QuerySmartContractStateRequest{
+				Address:         spec.contract.String(),
+				QueryData:       []byte(`{"verifier":{}}`),
+				WithDiagnostics: spec.withDiagnostics,
+			})
+			require.NoError(t, err)
+			if !spec.withDiagnostics {
+				assert.Nil(t, got.Diagnostics)
+				return
+			}
+			require.NotNil(t, got.Diagnostics)
+			assert.Equal(t, spec.expPinned, got.Diagnostics.Pinned)
+			assert.NotZero(t, got.Diagnostics.GasUsed)
+		})
+	}
+}
+
 func TestQuerySmartContractPanics(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	contractAddr := BuildContractAddressClassic(1, 1)
@@ -382,6 +569,50 @@ func TestQueryContractsByCode(t *testing.T) {
 			},
 			expAddr: contractAddrs[1:10],
 		},
+		"with start after address": {
+			req: &types.QueryContractsByCodeRequest{
+				CodeId:            codeID,
+				StartAfterAddress: contractAddrs[3],
+			},
+			expAddr: contractAddrs[4:10],
+		},
+		"with start after address and limit": {
+			req: &types.QueryContractsByCodeRequest{
+				CodeId:            codeID,
+				StartAfterAddress: contractAddrs[3],
+				Pagination:        &query.PageRequest{Limit: 2},
+			},
+			expAddr: contractAddrs[4:6],
+		},
+		"with start after last address": {
+			req: &types.QueryContractsByCodeRequest{
+				CodeId:            codeID,
+				StartAfterAddress: contractAddrs[9],
+			},
+			expAddr: []string{},
+		},
+		"with start after address and page key": {
+			req: &types.QueryContractsByCodeRequest{
+				CodeId:            codeID,
+				StartAfterAddress: contractAddrs[3],
+				Pagination:        &query.PageRequest{Key: fromBase64("AAAAAAAAAAoAAAAAAAOc/4cuhNIMvyvID4NhhfROlbQNuZ0fl0clmBPoWHtKYazH")},
+			},
+			expErr: errorsmod.Wrap(types.ErrInvalid, "start after address can not be combined with a page key"),
+		},
+		"with start after unknown address": {
+			req: &types.QueryContractsByCodeRequest{
+				CodeId:            codeID,
+				StartAfterAddress: anyAddr.String(),
+			},
+			expErr: types.ErrNoSuchContractFn(anyAddr.String()).Wrapf("address %s", anyAddr.String()),
+		},
+		"with start after address of other code": {
+			req: &types.QueryContractsByCodeRequest{
+				CodeId:            codeID + 1,
+				StartAfterAddress: contractAddrs[3],
+			},
+			expErr: errorsmod.Wrapf(types.ErrInvalid, "contract %s is not an instance of code %d", contractAddrs[3], codeID+1),
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -396,6 +627,24 @@ func TestQueryContractsByCode(t *testing.T) {
 			assert.Equal(t, spec.expAddr, got.Contracts)
 		})
 	}
+
+	// resume a crawl by the last address of every page without duplicates or gaps
+	var crawled []string
+	var startAfter string
+	for i := 0; i < len(contractAddrs); i++ {
+		got, err := q.ContractsByCode(ctx, &types.QueryContractsByCodeRequest{
+			CodeId:            codeID,
+			StartAfterAddress: startAfter,
+			Pagination:        &query.PageRequest{Limit: 3},
+		})
+		require.NoError(t, err)
+		if len(got.Contracts) == 0 {
+			break
+		}
+		crawled = append(crawled, got.Contracts...)
+		startAfter = got.Contracts[len(got.Contracts)-1]
+	}
+	assert.Equal(t, contractAddrs, crawled)
 }
 
 func TestQueryContractHistory(t *testing.T) {
@@ -623,6 +872,119 @@ func TestQueryCodeList(t *testing.T) {
 	}
 }
 
+func TestQueryCodeListFilters(t *testing.T) {
+	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	alice, bob := RandomAccountAddress(t), RandomAccountAddress(t)
+
+	codes := []struct {
+		creator sdk.AccAddress
+		cfg     types.AccessConfig
+	}{
+		1: {creator: alice, cfg: types.AllowEverybody},
+		2: {creator: bob, cfg: types.AllowEverybody},
+		3: {creator: alice, cfg: types.AllowNobody},
+		4: {creator: alice, cfg: types.AccessTypeAnyOfAddresses.With(bob)},
+		5: {creator: alice, cfg: types.AllowEverybody},
+	}
+	for codeID := uint64(1); codeID < uint64(len(codes)); codeID++ {
+		require.NoError(t, keeper.importCode(ctx, codeID,
+			types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode), func(info *types.CodeInfo) {
+				info.Creator = codes[codeID].creator.String()
+				info.InstantiateConfig = codes[codeID].cfg
+			}),
+			wasmCode),
+		)
+	}
+
+	specs := map[string]struct {
+		req        types.QueryCodesRequest
+		expCodeIDs []uint64
+		expErr     bool
+	}{
+		"by creator": {
+			req:        types.QueryCodesRequest{Creator: alice.String()},
+			expCodeIDs: []uint64{1, 3, 4, 5},
+		},
+		"by other creator": {
+			req:        types.QueryCodesRequest{Creator: bob.String()},
+			expCodeIDs: []uint64{2},
+		},
+		"by unknown creator": {
+			req: types.QueryCodesRequest{Creator: RandomBech32AccountAddress(t)},
+		},
+		"by permission": {
+			req:        types.QueryCodesRequest{InstantiatePermission: types.AccessTypeEverybody},
+			expCodeIDs: []uint64{1, 2, 5},
+		},
+		"by creator and permission": {
+			req:        types.QueryCodesRequest{Creator: alice.String(), InstantiatePermission: types.AccessTypeEverybody},
+			expCodeIDs: []uint64{1, 5},
+		},
+		"by creator with pagination limit": {
+			req: types.QueryCodesRequest{
+				Creator:    alice.String(),
+				Pagination: &query.PageRequest{Limit: 2},
+			},
+			expCodeIDs: []uint64{1, 3},
+		},
+		"by permission with pagination limit": {
+			req: types.QueryCodesRequest{
+				InstantiatePermission: types.AccessTypeAnyOfAddresses,
+				Pagination:            &query.PageRequest{Limit: 1},
+			},
+			expCodeIDs: []uint64{4},
+		},
+		"invalid creator": {
+			req:    types.QueryCodesRequest{Creator: "invalid"},
+			expErr: true,
+		},
+		"invalid permission": {
+			req:    types.QueryCodesRequest{InstantiatePermission: types.AccessType(99)},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := Querier(keeper).Codes(ctx, &spec.req) //nolint:gosec
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			gotIDs := make([]uint64, 0, len(got.CodeInfos))
+			for _, info := range got.CodeInfos {
+				gotIDs = append(gotIDs, info.CodeID)
+			}
+			if spec.expCodeIDs == nil {
+				spec.expCodeIDs = []uint64{}
+			}
+			assert.Equal(t, spec.expCodeIDs, gotIDs)
+		})
+	}
+
+	// page through the creator codes with the next key
+	var gotIDs []uint64
+	var nextKey []byte
+	for {
+		got, err := Querier(keeper).Codes(ctx, &types.QueryCodesRequest{
+			Creator:    alice.String(),
+			Pagination: &query.PageRequest{Key: nextKey, Limit: 3},
+		})
+		require.NoError(t, err)
+		for _, info := range got.CodeInfos {
+			gotIDs = append(gotIDs, info.CodeID)
+		}
+		if nextKey = got.Pagination.NextKey; nextKey == nil {
+			break
+		}
+	}
+	assert.Equal(t, []uint64{1, 3, 4, 5}, gotIDs)
+}
+
 func TestQueryContractInfo(t *testing.T) {
 	var (
 		contractAddr = RandomAccountAddress(t)
@@ -690,6 +1052,60 @@ func TestQueryContractInfo(t *testing.T) {
 	}
 }
 
+func TestQueryAddressType(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	querier := NewGrpcQuerier(k.cdc, k.storeService, k, k.queryGasLimit)
+	contractAddr := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("stake", 1))
+	contractInfo := types.ContractInfoFixture(func(info *types.ContractInfo) {
+		info.CodeID = 7
+	})
+	k.mustStoreContractInfo(ctx, contractAddr, &contractInfo)
+	baseAccountAddr := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("stake", 1))
+	moduleAccountAddr := keepers.AccountKeeper.GetModuleAccount(ctx, authtypes.FeeCollectorName).GetAddress()
+
+	specs := map[string]struct {
+		src    *types.QueryAddressTypeRequest
+		expRsp *types.QueryAddressTypeResponse
+		expErr bool
+	}{
+		"contract": {
+			src:    &types.QueryAddressTypeRequest{Address: contractAddr.String()},
+			expRsp: &types.QueryAddressTypeResponse{AddressType: types.AddressTypeContract, CodeID: 7},
+		},
+		"module account": {
+			src:    &types.QueryAddressTypeRequest{Address: moduleAccountAddr.String()},
+			expRsp: &types.QueryAddressTypeResponse{AddressType: types.AddressTypeModuleAccount},
+		},
+		"base account": {
+			src:    &types.QueryAddressTypeRequest{Address: baseAccountAddr.String()},
+			expRsp: &types.QueryAddressTypeResponse{AddressType: types.AddressTypeBaseAccount},
+		},
+		"not found": {
+			src:    &types.QueryAddressTypeRequest{Address: RandomBech32AccountAddress(t)},
+			expRsp: &types.QueryAddressTypeResponse{AddressType: types.AddressTypeNotFound},
+		},
+		"invalid address": {
+			src:    &types.QueryAddressTypeRequest{Address: "invalid"},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotRsp, gotErr := querier.AddressType(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expRsp, gotRsp)
+		})
+	}
+}
+
 func TestQueryWasmLimitsConfig(t *testing.T) {
 	cfg := types.VMConfig{}
 
@@ -795,6 +1211,103 @@ func TestQueryPinnedCodes(t *testing.T) {
 	}
 }
 
+func TestQueryPinnedCodesVerbose(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	require.NoError(t, keeper.pinCode(ctx, example.CodeID))
+	// pinned code id without code info
+	const prunedCodeID = 99
+	require.NoError(t, keeper.storeService.OpenKVStore(ctx).Set(types.GetPinnedCodeIndexPrefix(prunedCodeID), []byte{1}))
+
+	q := Querier(keeper)
+	got, err := q.PinnedCodes(ctx, &types.QueryPinnedCodesRequest{Verbose: true})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{example.CodeID, prunedCodeID}, got.CodeIDs)
+	require.Len(t, got.CodeInfos, 2)
+	assert.Equal(t, example.CodeID, got.CodeInfos[0].CodeID)
+	require.NotNil(t, got.CodeInfos[0].CodeInfo)
+	assert.Equal(t, example.CreatorAddr.String(), got.CodeInfos[0].CodeInfo.Creator)
+	assert.Equal(t, example.Checksum, []byte(got.CodeInfos[0].CodeInfo.DataHash))
+	assert.Equal(t, types.PinnedCodeInfo{CodeID: prunedCodeID}, got.CodeInfos[1])
+
+	// pagination over the pinned codes
+	got, err = q.PinnedCodes(ctx, &types.QueryPinnedCodesRequest{Verbose: true, Pagination: &query.PageRequest{Limit: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{example.CodeID}, got.CodeIDs)
+	require.Len(t, got.CodeInfos, 1)
+	got, err = q.PinnedCodes(ctx, &types.QueryPinnedCodesRequest{Verbose: true, Pagination: &query.PageRequest{Key: got.Pagination.NextKey}})
+	require.NoError(t, err)
+	assert.Equal(t, []types.PinnedCodeInfo{{CodeID: prunedCodeID}}, got.CodeInfos)
+
+	// not verbose
+	got, err = q.PinnedCodes(ctx, &types.QueryPinnedCodesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, got.CodeInfos)
+}
+
+func TestQueryCodeByChecksum(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 1000))
+	myCode := append(wasmIdent, []byte("my code")...)
+	codeID1, checksum, err := keepers.ContractKeeper.Create(ctx, creator, myCode, nil)
+	require.NoError(t, err)
+	otherCodeID, otherChecksum, err := keepers.ContractKeeper.Create(ctx, creator, append(wasmIdent, []byte("other code")...), nil)
+	require.NoError(t, err)
+	// the same code stored again
+	codeID2, _, err := keepers.ContractKeeper.Create(ctx, creator, myCode, nil)
+	require.NoError(t, err)
+	q := Querier(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		src        *types.QueryCodeByChecksumRequest
+		expCodeIDs []uint64
+		expErr     error
+	}{
+		"duplicate code": {
+			src:        &types.QueryCodeByChecksumRequest{Checksum: hex.EncodeToString(checksum)},
+			expCodeIDs: []uint64{codeID1, codeID2},
+		},
+		"single code": {
+			src:        &types.QueryCodeByChecksumRequest{Checksum: hex.EncodeToString(otherChecksum)},
+			expCodeIDs: []uint64{otherCodeID},
+		},
+		"upper case hex": {
+			src:        &types.QueryCodeByChecksumRequest{Checksum: strings.ToUpper(hex.EncodeToString(otherChecksum))},
+			expCodeIDs: []uint64{otherCodeID},
+		},
+		"unknown checksum": {
+			src:    &types.QueryCodeByChecksumRequest{Checksum: strings.Repeat("ab", 32)},
+			expErr: types.ErrNotFound,
+		},
+		"invalid hex": {
+			src:    &types.QueryCodeByChecksumRequest{Checksum: "xyz"},
+			expErr: types.ErrInvalid,
+		},
+		"invalid length": {
+			src:    &types.QueryCodeByChecksumRequest{Checksum: "abcd"},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.CodeByChecksum(ctx, spec.src)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCodeIDs, got.CodeIDs)
+			assert.Equal(t, spec.expCodeIDs[0], got.CodeInfo.CodeID)
+			assert.Equal(t, creator.String(), got.CodeInfo.Creator)
+			assert.Equal(t, strings.ToLower(spec.src.Checksum), hex.EncodeToString(got.CodeInfo.DataHash))
+		})
+	}
+}
+
 func TestQueryParams(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.WasmKeeper
@@ -824,6 +1337,34 @@ func TestQueryParams(t *testing.T) {
 	require.Equal(t, paramsResponse.Params.InstantiateDefaultPermission, types.AccessTypeNobody)
 }
 
+func TestQueryParamsHistory(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	q := Querier(keeper)
+
+	// when no params changed
+	rsp, err := q.ParamsHistory(ctx, &types.QueryParamsHistoryRequest{})
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, rsp.Changes)
+
+	// when params changed
+	oldParams := keeper.GetParams(ctx)
+	newParams := types.DefaultParams()
+	newParams.CodeUploadAccess = types.AllowNobody
+	require.NoError(t, keeper.updateParams(ctx.WithBlockHeight(7), keeper.GetAuthority(), newParams))
+	rsp, err = q.ParamsHistory(ctx, &types.QueryParamsHistoryRequest{})
+	// then
+	require.NoError(t, err)
+	exp := []types.ParamsChange{{Height: 7, Authority: keeper.GetAuthority(), OldParams: oldParams, NewParams: newParams}}
+	assert.Equal(t, exp, rsp.Changes)
+
+	// when request is nil
+	_, err = q.ParamsHistory(ctx, nil)
+	// then
+	require.Error(t, err)
+}
+
 func TestQueryCodeInfo(t *testing.T) {
 	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -1028,18 +1569,23 @@ func TestQueryContractsByCreatorList(t *testing.T) {
 	}
 
 	var allExpectedContracts []string
+	var allExpectedInfos []types.ContractInfoWithAddress
 	// create 10 contracts with real block/gas setup
 	for i := 0; i < 10; i++ {
 		ctx = setBlock(ctx, h)
 		h++
 		contract, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, initMsgBz, fmt.Sprintf("contract %d", i), topUp)
-		allExpectedContracts = append(allExpectedContracts, contract.String())
 		require.NoError(t, err)
+		allExpectedContracts = append(allExpectedContracts, contract.String())
+		info := keepers.WasmKeeper.GetContractInfo(ctx, contract)
+		require.Equal(t, uint64(h-1), info.Created.BlockHeight)
+		allExpectedInfos = append(allExpectedInfos, types.ContractInfoWithAddress{Address: contract.String(), ContractInfo: *info})
 	}
 
 	specs := map[string]struct {
 		srcQuery        *types.QueryContractsByCreatorRequest
 		expContractAddr []string
+		expInfos        []types.ContractInfoWithAddress
 		expErr          error
 	}{
 		"query all": {
@@ -1049,6 +1595,25 @@ func TestQueryContractsByCreatorList(t *testing.T) {
 			expContractAddr: allExpectedContracts,
 			expErr:          nil,
 		},
+		"query all with info": {
+			srcQuery: &types.QueryContractsByCreatorRequest{
+				CreatorAddress: creator.String(),
+				WithInfo:       true,
+			},
+			expContractAddr: allExpectedContracts,
+			expInfos:        allExpectedInfos,
+		},
+		"with info and pagination limit": {
+			srcQuery: &types.QueryContractsByCreatorRequest{
+				CreatorAddress: creator.String(),
+				Pagination: &query.PageRequest{
+					Limit: 2,
+				},
+				WithInfo: true,
+			},
+			expContractAddr: allExpectedContracts[0:2],
+			expInfos:        allExpectedInfos[0:2],
+		},
 		"with pagination offset": {
 			srcQuery: &types.QueryContractsByCreatorRequest{
 				CreatorAddress: creator.String(),
@@ -1094,6 +1659,155 @@ func TestQueryContractsByCreatorList(t *testing.T) {
 			require.NoError(t, gotErr)
 			require.NotNil(t, got)
 			assert.Equal(t, spec.expContractAddr, got.ContractAddresses)
+			assert.Equal(t, spec.expInfos, got.ContractInfos)
+		})
+	}
+}
+
+func TestQueryContractsByAdmin(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	admin, otherAdmin := RandomAccountAddress(t), RandomAccountAddress(t)
+
+	var allContracts []sdk.AccAddress
+	for i := 0; i < 5; i++ {
+		contract, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, admin, []byte(`{}`), fmt.Sprintf("contract %d", i), nil)
+		require.NoError(t, err)
+		allContracts = append(allContracts, contract)
+	}
+	// the index is ordered by address bytes
+	slices.SortFunc(allContracts, func(a, b sdk.AccAddress) int { return bytes.Compare(a, b) })
+	allExpectedContracts := make([]string, len(allContracts))
+	for i, a := range allContracts {
+		allExpectedContracts[i] = a.String()
+	}
+	// contracts without admin, with another admin, or moved away are not listed
+	_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "no admin", nil)
+	require.NoError(t, err)
+	updated, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, admin, []byte(`{}`), "updated", nil)
+	require.NoError(t, err)
+	require.NoError(t, keepers.ContractKeeper.UpdateContractAdmin(ctx, updated, admin, otherAdmin))
+	cleared, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, admin, []byte(`{}`), "cleared", nil)
+	require.NoError(t, err)
+	require.NoError(t, keepers.ContractKeeper.ClearContractAdmin(ctx, cleared, admin))
+
+	specs := map[string]struct {
+		srcQuery        *types.QueryContractsByAdminRequest
+		expContractAddr []string
+		expErr          error
+	}{
+		"query all": {
+			srcQuery:        &types.QueryContractsByAdminRequest{AdminAddress: admin.String()},
+			expContractAddr: allExpectedContracts,
+		},
+		"with pagination limit": {
+			srcQuery: &types.QueryContractsByAdminRequest{
+				AdminAddress: admin.String(),
+				Pagination:   &query.PageRequest{Limit: 2},
+			},
+			expContractAddr: allExpectedContracts[0:2],
+		},
+		"with pagination offset": {
+			srcQuery: &types.QueryContractsByAdminRequest{
+				AdminAddress: admin.String(),
+				Pagination:   &query.PageRequest{Offset: 1},
+			},
+			expErr: errLegacyPaginationUnsupported,
+		},
+		"new admin": {
+			srcQuery:        &types.QueryContractsByAdminRequest{AdminAddress: otherAdmin.String()},
+			expContractAddr: []string{updated.String()},
+		},
+		"unknown admin": {
+			srcQuery:        &types.QueryContractsByAdminRequest{AdminAddress: RandomBech32AccountAddress(t)},
+			expContractAddr: []string{},
+		},
+		"nil admin": {
+			srcQuery: &types.QueryContractsByAdminRequest{},
+			expErr:   errors.New("empty address string is not allowed"),
+		},
+		"nil req": {
+			expErr: status.Error(codes.InvalidArgument, "empty request"),
+		},
+	}
+	q := Querier(keepers.WasmKeeper)
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ContractsByAdmin(ctx, spec.srcQuery)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.ErrorContains(t, gotErr, spec.expErr.Error())
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expContractAddr, got.ContractAddresses)
+		})
+	}
+}
+
+func TestQueryScheduledSudos(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	ctx = ctx.WithBlockHeight(1)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	other := SeedNewContractInstance(t, ctx, keepers, &mock)
+	params := k.GetParams(ctx)
+	params.MaxScheduledSudosPerContract = 10
+	params.MaxScheduledSudoGasPerBlock = 10
+	require.NoError(t, k.SetParams(ctx, params))
+
+	var allExpected []types.ScheduledSudo
+	for _, height := range []int64{30, 10, 20} {
+		id, err := k.scheduleSudo(ctx, example.Contract, height, []byte(`{}`), 1)
+		require.NoError(t, err)
+		allExpected = append(allExpected, *k.GetScheduledSudo(ctx, example.Contract, id))
+	}
+	_, err := k.scheduleSudo(ctx, other.Contract, 10, []byte(`{}`), 1)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		srcQuery *types.QueryScheduledSudosRequest
+		exp      []types.ScheduledSudo
+		expErr   error
+	}{
+		"query all": {
+			srcQuery: &types.QueryScheduledSudosRequest{Address: example.Contract.String()},
+			exp:      allExpected,
+		},
+		"with pagination limit": {
+			srcQuery: &types.QueryScheduledSudosRequest{
+				Address:    example.Contract.String(),
+				Pagination: &query.PageRequest{Limit: 2},
+			},
+			exp: allExpected[0:2],
+		},
+		"unknown contract": {
+			srcQuery: &types.QueryScheduledSudosRequest{Address: RandomBech32AccountAddress(t)},
+			exp:      []types.ScheduledSudo{},
+		},
+		"nil address": {
+			srcQuery: &types.QueryScheduledSudosRequest{},
+			expErr:   errors.New("empty address string is not allowed"),
+		},
+		"nil req": {
+			expErr: status.Error(codes.InvalidArgument, "empty request"),
+		},
+	}
+	q := Querier(k)
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ScheduledSudos(ctx, spec.srcQuery)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.ErrorContains(t, gotErr, spec.expErr.Error())
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got.ScheduledSudos)
 		})
 	}
 }
@@ -1194,7 +1908,16 @@ func TestQueryBuildAddress(t *testing.T) {
 				Salt:           "",
 				InitArgs:       nil,
 			},
-			expErr: status.Error(codes.InvalidArgument, "empty salt"),
+			expErr: status.Error(codes.InvalidArgument, "salt must have 1 to 64 bytes: empty"),
+		},
+		"salt too long": {
+			src: &types.QueryBuildAddressRequest{
+				CodeHash:       "13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5",
+				CreatorAddress: "cosmos100dejzacpanrldpjjwksjm62shqhyss44jf5xz",
+				Salt:           strings.Repeat("61", types.MaxSaltSize+1),
+				InitArgs:       nil,
+			},
+			expErr: status.Error(codes.InvalidArgument, "salt must have 1 to 64 bytes, got 65: exceeds limit"),
 		},
 		"invalid init args": {
 			src: &types.QueryBuildAddressRequest{
@@ -1249,3 +1972,255 @@ func TestQueryBuildAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryPredictPortID(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	reflectID := StoreReflectContract(t, ctx, keepers).CodeID
+	example := StoreIBCReflectContract(t, ctx, keepers)
+	initMsg := IBCReflectInitMsg{ReflectCodeID: reflectID}.GetBytes(t)
+	salt := []byte("my-salt")
+
+	specs := map[string]struct {
+		fixMsg   bool
+		initArgs []byte
+	}{
+		"without init args": {},
+		"with fixed init args": {
+			fixMsg:   true,
+			initArgs: initMsg,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			xCtx, _ := ctx.CacheContext()
+			q := Querier(keepers.WasmKeeper)
+			got, err := q.PredictPortID(xCtx, &types.QueryPredictPortIDRequest{
+				CodeHash:       hex.EncodeToString(example.Checksum),
+				CreatorAddress: example.CreatorAddr.String(),
+				Salt:           hex.EncodeToString(salt),
+				InitArgs:       spec.initArgs,
+			})
+			require.NoError(t, err)
+
+			// when
+			contractAddr, _, err := keepers.ContractKeeper.Instantiate2(xCtx, example.CodeID, example.CreatorAddr, nil, initMsg, "ibc", nil, salt, spec.fixMsg)
+			require.NoError(t, err)
+
+			// then
+			info := keepers.WasmKeeper.GetContractInfo(xCtx, contractAddr)
+			require.NotNil(t, info)
+			require.NotEmpty(t, info.IBCPortID)
+			assert.Equal(t, info.IBCPortID, got.PortID)
+			assert.Equal(t, contractAddr.String(), got.Address)
+			gotAddr, err := types.ContractFromPortID(got.PortID)
+			require.NoError(t, err)
+			assert.Equal(t, contractAddr, gotAddr)
+		})
+	}
+}
+
+func TestQueryEstimateEventGas(t *testing.T) {
+	vm := wasmtesting.NewMockWasmVM()
+	parentCtx, keepers := CreateTestInputWithVM(t, false, AvailableCapabilities, vm)
+	example := SeedNewContractInstance(t, parentCtx, keepers, vm)
+	q := Querier(keepers.WasmKeeper)
+
+	execute := func(t *testing.T, res *wasmvmtypes.Response) storetypes.Gas {
+		t.Helper()
+		vm.SetResponse(example.Checksum, "execute", &wasmvmtypes.ContractResult{Ok: res})
+		ctx, _ := parentCtx.CacheContext()
+		ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+		require.NoError(t, err)
+		return ctx.GasMeter().GasConsumed()
+	}
+	baseGas := execute(t, &wasmvmtypes.Response{})
+
+	attrs := func(n int, valueLen int) []wasmvmtypes.EventAttribute {
+		r := make([]wasmvmtypes.EventAttribute, n)
+		for i := range r {
+			r[i] = wasmvmtypes.EventAttribute{Key: fmt.Sprintf("key%d", i), Value: strings.Repeat("v", valueLen)}
+		}
+		return r
+	}
+	sizeOf := func(attrs []wasmvmtypes.EventAttribute) uint64 {
+		var n uint64
+		for _, a := range attrs {
+			n += uint64(len(a.Key) + len(a.Value))
+		}
+		return n
+	}
+	specs := map[string]wasmvmtypes.Response{
+		"attributes within free tier": {
+			Attributes: attrs(2, 10),
+		},
+		"attributes exceeding free tier": {
+			Attributes: attrs(5, 100),
+		},
+		"custom events only": {
+			Events: []wasmvmtypes.Event{{Type: "transfer", Attributes: attrs(3, 50)}, {Type: "my-event", Attributes: attrs(1, 1)}},
+		},
+		"attributes and custom events": {
+			Attributes: attrs(4, 30),
+			Events:     []wasmvmtypes.Event{{Type: "foo", Attributes: attrs(10, 200)}},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			req := &types.QueryEstimateEventGasRequest{
+				AttributeCount: uint64(len(spec.Attributes)),
+				AttributeBytes: sizeOf(spec.Attributes),
+			}
+			for _, e := range spec.Events {
+				req.Events = append(req.Events, types.EventSize{
+					TypeLength:     uint64(len(e.Type)),
+					AttributeCount: uint64(len(e.Attributes)),
+					AttributeBytes: sizeOf(e.Attributes),
+				})
+			}
+
+			// when
+			got, err := q.EstimateEventGas(parentCtx, req)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, execute(t, &spec)-baseGas, got.Gas)
+			require.NotNil(t, got.Constants)
+			assert.Equal(t, types.EventGasConstants{
+				PerAttributeCost:   types.DefaultPerAttributeCost,
+				PerByteCost:        types.DefaultEventAttributeDataCost,
+				FreeTierBytes:      types.DefaultEventAttributeDataFreeTier,
+				PerCustomEventCost: types.DefaultPerCustomEventCost,
+			}, *got.Constants)
+		})
+	}
+}
+
+func TestQueryEstimateEventGasLimits(t *testing.T) {
+	q := Querier(&Keeper{gasRegister: types.NewDefaultWasmGasRegister()})
+	specs := map[string]struct {
+		src    *types.QueryEstimateEventGasRequest
+		expErr bool
+	}{
+		"empty": {
+			src: &types.QueryEstimateEventGasRequest{},
+		},
+		"max bytes": {
+			src: &types.QueryEstimateEventGasRequest{AttributeCount: 1, AttributeBytes: maxEstimatedEventBytes},
+		},
+		"nil request": {
+			expErr: true,
+		},
+		"bytes exceed max": {
+			src:    &types.QueryEstimateEventGasRequest{AttributeCount: 1, AttributeBytes: maxEstimatedEventBytes + 1},
+			expErr: true,
+		},
+		"total bytes exceed max": {
+			src: &types.QueryEstimateEventGasRequest{
+				AttributeCount: 1, AttributeBytes: maxEstimatedEventBytes,
+				Events: []types.EventSize{{TypeLength: 1}},
+			},
+			expErr: true,
+		},
+		"attributes exceed max": {
+			src:    &types.QueryEstimateEventGasRequest{AttributeCount: maxEstimatedEventAttributes + 1},
+			expErr: true,
+		},
+		"event attributes overflow": {
+			src: &types.QueryEstimateEventGasRequest{
+				AttributeCount: math.MaxUint64,
+				Events:         []types.EventSize{{AttributeCount: 1}},
+			},
+			expErr: true,
+		},
+		"bytes without attributes": {
+			src:    &types.QueryEstimateEventGasRequest{AttributeBytes: 1},
+			expErr: true,
+		},
+		"event bytes without attributes": {
+			src:    &types.QueryEstimateEventGasRequest{Events: []types.EventSize{{TypeLength: 1, AttributeBytes: 1}}},
+			expErr: true,
+		},
+		"events exceed max": {
+			src:    &types.QueryEstimateEventGasRequest{Events: make([]types.EventSize, maxEstimatedEvents+1)},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, gotErr := q.EstimateEventGas(sdk.Context{}, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Equal(t, codes.InvalidArgument, status.Code(gotErr))
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestQueryWasmStats(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	mock.MigrateWithInfoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, migrateInfo wasmvmtypes.MigrateInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return mock.MigrateFn(codeID, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 1000))
+	codeID, _, err := keepers.ContractKeeper.Create(ctx, creator, append(wasmIdent, []byte("my code")...), nil)
+	require.NoError(t, err)
+	otherCodeID, _, err := keepers.ContractKeeper.Create(ctx, creator, append(wasmIdent, []byte("other code")...), nil)
+	require.NoError(t, err)
+	unusedCodeID, _, err := keepers.ContractKeeper.Create(ctx, creator, append(wasmIdent, []byte("unused code")...), nil)
+	require.NoError(t, err)
+	var contracts []sdk.AccAddress
+	for i := 0; i < 3; i++ {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, creator, []byte(`{}`), fmt.Sprintf("contract %d", i), nil)
+		require.NoError(t, err)
+		contracts = append(contracts, addr)
+	}
+	// and one contract migrated to the other code
+	_, err = keepers.ContractKeeper.Migrate(ctx, contracts[0], creator, otherCodeID, []byte(`{}`))
+	require.NoError(t, err)
+	q := Querier(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		src              *types.QueryWasmStatsRequest
+		expContractCount uint64
+		expErr           error
+	}{
+		"without code id": {
+			src: &types.QueryWasmStatsRequest{},
+		},
+		"with code id": {
+			src:              &types.QueryWasmStatsRequest{CodeID: codeID},
+			expContractCount: 2,
+		},
+		"with migrated to code id": {
+			src:              &types.QueryWasmStatsRequest{CodeID: otherCodeID},
+			expContractCount: 1,
+		},
+		"code without contracts": {
+			src: &types.QueryWasmStatsRequest{CodeID: unusedCodeID},
+		},
+		"unknown code id": {
+			src:    &types.QueryWasmStatsRequest{CodeID: 99},
+			expErr: types.ErrNoSuchCodeFn(99),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.WasmStats(ctx, spec.src)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, types.WasmStats{CodeCount: 3, ContractCount: 3}, got.Stats)
+			assert.Equal(t, spec.expContractCount, got.CodeContractCount)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// setQueryAlias stores the smart query under the name for the contract. An empty query removes the alias.
+// Aliases are metadata only, the query is not validated against the contract. The cache ttl is a hint for
+// API nodes and is not used in consensus.
+func (k Keeper) setQueryAlias(ctx context.Context, contractAddress, caller sdk.AccAddress, name string, queryData []byte, cacheTTLSeconds uint32, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetQueryAliasKey(contractAddress, name)
+	exists, err := store.Has(key)
+	if err != nil {
+		return err
+	}
+	if len(queryData) == 0 {
+		if !exists {
+			return types.ErrNotFound.Wrapf("query alias %s", name)
+		}
+		if err := store.Delete(key); err != nil {
+			return err
+		}
+		if err := k.setQueryAliasCacheTTL(ctx, contractAddress, name, 0); err != nil {
+			return err
+		}
+		sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeRemoveQueryAlias,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyQueryAliasName, name),
+		))
+		return nil
+	}
+	if !exists {
+		var count int
+		k.IterateQueryAliases(ctx, contractAddress, func(types.QueryAlias) bool {
+			count++
+			return false
+		})
+		if count >= types.MaxQueryAliasesPerContract {
+			return types.ErrLimit.Wrapf("max %d query aliases per contract", types.MaxQueryAliasesPerContract)
+		}
+	}
+	if err := store.Set(key, queryData); err != nil {
+		return err
+	}
+	if err := k.setQueryAliasCacheTTL(ctx, contractAddress, name, cacheTTLSeconds); err != nil {
+		return err
+	}
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetQueryAlias,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyQueryAliasName, name),
+	))
+	return nil
+}
+
+// GetQueryAlias returns the smart query stored under the name for the contract or nil when not found
+func (k Keeper) GetQueryAlias(ctx context.Context, contractAddress sdk.AccAddress, name string) []byte {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetQueryAliasKey(contractAddress, name))
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// setQueryAliasCacheTTL stores the cache ttl of a query alias. 0 removes it.
+func (k Keeper) setQueryAliasCacheTTL(ctx context.Context, contractAddress sdk.AccAddress, name string, seconds uint32) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetQueryAliasCacheTTLKey(contractAddress, name)
+	if seconds == 0 {
+		return store.Delete(key)
+	}
+	return store.Set(key, binary.BigEndian.AppendUint32(nil, seconds))
+}
+
+// GetQueryAliasCacheTTL returns the cache ttl in seconds of a query alias or 0 when not set
+func (k Keeper) GetQueryAliasCacheTTL(ctx context.Context, contractAddress sdk.AccAddress, name string) uint32 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetQueryAliasCacheTTLKey(contractAddress, name))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(bz)
+}
+
+// IterateQueryAliases iterates over all query aliases of the contract ordered by name
+func (k Keeper) IterateQueryAliases(ctx context.Context, contractAddress sdk.AccAddress, cb func(types.QueryAlias) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetQueryAliasPrefix(contractAddress))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		name := string(iter.Key())
+		alias := types.QueryAlias{Name: name, QueryData: iter.Value(), CacheTTLSeconds: k.GetQueryAliasCacheTTL(ctx, contractAddress, name)}
+		if cb(alias) {
+			return
+		}
+	}
+}
+
+func (k Keeper) importQueryAliases(ctx context.Context, contractAddress sdk.AccAddress, aliases []types.QueryAlias) error {
+	store := k.storeService.OpenKVStore(ctx)
+	for _, a := range aliases {
+		key := types.GetQueryAliasKey(contractAddress, a.Name)
+		ok, err := store.Has(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errorsmod.Wrapf(types.ErrDuplicate, "query alias: %s", a.Name)
+		}
+		if err := store.Set(key, a.QueryData); err != nil {
+			return err
+		}
+		if err := k.setQueryAliasCacheTTL(ctx, contractAddress, a.Name, a.CacheTTLSeconds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
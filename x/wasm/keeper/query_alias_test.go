@@ -0,0 +1,168 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSetQueryAlias(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	myQuery := []byte(`{"config":{}}`)
+	specs := map[string]struct {
+		setup    func(ctx sdk.Context)
+		src      types.MsgSetQueryAlias
+		expErr   error
+		expAlias []byte
+		expTTL   uint32
+	}{
+		"create": {
+			src:      types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "config", QueryData: myQuery},
+			expAlias: myQuery,
+		},
+		"update": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.setQueryAlias(ctx, example.Contract, example.CreatorAddr, "config", []byte(`{"other":{}}`), 0, DefaultAuthorizationPolicy{}))
+			},
+			src:      types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "config", QueryData: myQuery},
+			expAlias: myQuery,
+		},
+		"create with cache ttl": {
+			src:      types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "config", QueryData: myQuery, CacheTTLSeconds: 60},
+			expAlias: myQuery,
+			expTTL:   60,
+		},
+		"update removes cache ttl": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.setQueryAlias(ctx, example.Contract, example.CreatorAddr, "config", myQuery, 60, DefaultAuthorizationPolicy{}))
+			},
+			src:      types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "config", QueryData: myQuery},
+			expAlias: myQuery,
+		},
+		"remove": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.setQueryAlias(ctx, example.Contract, example.CreatorAddr, "config", myQuery, 60, DefaultAuthorizationPolicy{}))
+			},
+			src: types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "config"},
+		},
+		"remove non existing": {
+			src:    types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "config"},
+			expErr: types.ErrNotFound,
+		},
+		"not admin": {
+			src:    types.MsgSetQueryAlias{Sender: RandomBech32AccountAddress(t), Contract: example.Contract.String(), Name: "config", QueryData: myQuery},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			src:    types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: RandomBech32AccountAddress(t), Name: "config", QueryData: myQuery},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
+		"max aliases reached": {
+			setup: func(ctx sdk.Context) {
+				for i := 0; i < types.MaxQueryAliasesPerContract; i++ {
+					require.NoError(t, k.setQueryAlias(ctx, example.Contract, example.CreatorAddr, fmt.Sprintf("alias%d", i), myQuery, 0, DefaultAuthorizationPolicy{}))
+				}
+			},
+			src:    types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "config", QueryData: myQuery},
+			expErr: types.ErrLimit,
+		},
+		"update with max aliases reached": {
+			setup: func(ctx sdk.Context) {
+				for i := 0; i < types.MaxQueryAliasesPerContract; i++ {
+					require.NoError(t, k.setQueryAlias(ctx, example.Contract, example.CreatorAddr, fmt.Sprintf("alias%d", i), []byte(`{}`), 0, DefaultAuthorizationPolicy{}))
+				}
+			},
+			src:      types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "alias0", QueryData: myQuery},
+			expAlias: myQuery,
+		},
+		"query too large": {
+			src:    types.MsgSetQueryAlias{Sender: example.CreatorAddr.String(), Contract: example.Contract.String(), Name: "config", QueryData: []byte(fmt.Sprintf(`{"a":%q}`, make([]byte, types.MaxQueryAliasSize)))},
+			expErr: types.ErrLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(ctx)
+			}
+			_, gotErr := msgServer.SetQueryAlias(ctx, &spec.src)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expAlias, k.GetQueryAlias(ctx, example.Contract, spec.src.Name))
+			assert.Equal(t, spec.expTTL, k.GetQueryAliasCacheTTL(ctx, example.Contract, spec.src.Name))
+		})
+	}
+}
+
+func TestAliasedSmartContractState(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	withInMemoryCodeStore(&mock)
+	// echo the query
+	mock.QueryFn = func(checksum wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		rsp, err := json.Marshal(map[string]json.RawMessage{"echo": queryMsg})
+		require.NoError(t, err)
+		return &wasmvmtypes.QueryResult{Ok: rsp}, 1, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, k.setQueryAlias(ctx, example.Contract, example.CreatorAddr, "config", []byte(`{"config":{}}`), 60, DefaultAuthorizationPolicy{}))
+	require.NoError(t, k.setQueryAlias(ctx, example.Contract, example.CreatorAddr, "balance", []byte(`{"balance":{}}`), 0, DefaultAuthorizationPolicy{}))
+	q := Querier(k)
+
+	// when
+	rsp, err := q.AliasedSmartContractState(ctx, &types.QueryAliasedSmartContractStateRequest{Address: example.Contract.String(), Name: "config"})
+	// then
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"echo":{"config":{}}}`, string(rsp.Data))
+	assert.Equal(t, uint32(60), rsp.CacheTTLSeconds)
+
+	// when unknown alias
+	_, err = q.AliasedSmartContractState(ctx, &types.QueryAliasedSmartContractStateRequest{Address: example.Contract.String(), Name: "unknown"})
+	// then
+	require.ErrorIs(t, err, types.ErrNotFound)
+
+	// when listed
+	listRsp, err := q.QueryAliases(ctx, &types.QueryQueryAliasesRequest{Address: example.Contract.String()})
+	// then ordered by name
+	require.NoError(t, err)
+	assert.Equal(t, []types.QueryAlias{
+		{Name: "balance", QueryData: []byte(`{"balance":{}}`)},
+		{Name: "config", QueryData: []byte(`{"config":{}}`), CacheTTLSeconds: 60},
+	}, listRsp.Aliases)
+
+	// and aliases are exported to genesis
+	genState := ExportGenesis(ctx, k)
+	require.Len(t, genState.Contracts, 1)
+	assert.Equal(t, listRsp.Aliases, genState.Contracts[0].QueryAliases)
+
+	// and imported again
+	importCtx, importKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	importKeepers.WasmKeeper.wasmVM = &mock
+	_, err = InitGenesis(importCtx, importKeepers.WasmKeeper, *genState)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"config":{}}`), importKeepers.WasmKeeper.GetQueryAlias(importCtx, example.Contract, "config"))
+	assert.Equal(t, uint32(60), importKeepers.WasmKeeper.GetQueryAliasCacheTTL(importCtx, example.Contract, "config"))
+}
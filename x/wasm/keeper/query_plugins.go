@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cosmos/gogoproto/proto"
+	ibctransfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
 	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 
 	errorsmod "cosmossdk.io/errors"
@@ -23,6 +27,7 @@ import (
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
+	"github.com/CosmWasm/wasmd/x/wasm/contractmsg"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -31,6 +36,8 @@ type QueryHandler struct {
 	Plugins     WasmVMQueryHandler
 	Caller      sdk.AccAddress
 	gasRegister types.GasRegister
+	// depthGasPercent is the max percentage of the remaining gas that a nested smart query can use. 0 to disable
+	depthGasPercent uint32
 }
 
 func NewQueryHandler(ctx sdk.Context, vmQueryHandler WasmVMQueryHandler, caller sdk.AccAddress, gasRegister types.GasRegister) QueryHandler {
@@ -53,6 +60,11 @@ var _ wasmvmtypes.Querier = QueryHandler{}
 func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
 	// set a limit for a subCtx
 	sdkGas := q.gasRegister.FromWasmVMGas(gasLimit)
+	if q.isDepthGasLimited(request) {
+		// the percent is below 100 so that the quotient never overflows
+		hi, lo := bits.Mul64(sdkGas, uint64(q.depthGasPercent))
+		sdkGas, _ = bits.Div64(hi, lo, 100)
+	}
 	// discard all changes/ events in subCtx by not committing the cached context
 	subCtx, _ := q.Ctx.WithGasMeter(storetypes.NewGasMeter(sdkGas)).CacheContext()
 
@@ -78,6 +90,15 @@ func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) (
 	return nil, redactError(err)
 }
 
+// isDepthGasLimited returns true when the gas of a nested smart query is limited by the depth gas schedule.
+// The schedule is a node local setting and therefore only applied to smart queries via the gRPC querier,
+// never in txs, simulations or proposal handling.
+func (q QueryHandler) isDepthGasLimited(request wasmvmtypes.QueryRequest) bool {
+	return q.depthGasPercent > 0 && q.depthGasPercent < 100 &&
+		request.Wasm != nil && request.Wasm.Smart != nil &&
+		types.IsGRPCQuery(q.Ctx)
+}
+
 func (q QueryHandler) GasConsumed() uint64 {
 	return q.gasRegister.ToWasmVMGas(q.Ctx.GasMeter().GasConsumed())
 }
@@ -437,6 +458,137 @@ func AcceptListStargateQuerier(acceptList AcceptedQueries, queryRouter GRPCQuery
 	}
 }
 
+// GasMeteredStargateQuerier charges gas proportional to the response size of the given Stargate querier,
+// on top of the gas that the querier consumed. Heavy queries, like the balances of an account with
+// thousands of denoms, are not for free then.
+func GasMeteredStargateQuerier(gasRegister types.GasRegister, querier stargateQuerierFn) stargateQuerierFn {
+	return func(ctx sdk.Context, request *wasmvmtypes.StargateQuery) ([]byte, error) {
+		res, err := querier(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		ctx.GasMeter().ConsumeGas(gasRegister.QueryResponseCosts(len(res)), "Stargate query response")
+		return res, nil
+	}
+}
+
+// GasMeteredGrpcQuerier charges gas proportional to the protobuf encoded response size of the given gRPC querier,
+// on top of the gas that the querier consumed.
+func GasMeteredGrpcQuerier(gasRegister types.GasRegister, querier grpcQuerierFn) grpcQuerierFn {
+	return func(ctx sdk.Context, request *wasmvmtypes.GrpcQuery) (proto.Message, error) {
+		res, err := querier(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		ctx.GasMeter().ConsumeGas(gasRegister.QueryResponseCosts(proto.Size(res)), "gRPC query response")
+		return res, nil
+	}
+}
+
+// ParamsQueryRoutes defines the Params queries of modules that can be routed from the `params` custom query
+// as a map where the key is the module name.
+type ParamsQueryRoutes map[string]ParamsQueryRoute
+
+// ParamsQueryRoute is the gRPC query path and response type of a module Params query
+type ParamsQueryRoute struct {
+	Path     string
+	Response func() proto.Message
+}
+
+// DefaultParamsQueryRoutes returns the Params query routes of the modules that x/wasm depends on
+func DefaultParamsQueryRoutes() ParamsQueryRoutes {
+	return ParamsQueryRoutes{
+		banktypes.ModuleName: {
+			Path:     "/cosmos.bank.v1beta1.Query/Params",
+			Response: func() proto.Message { return &banktypes.QueryParamsResponse{} },
+		},
+		distributiontypes.ModuleName: {
+			Path:     "/cosmos.distribution.v1beta1.Query/Params",
+			Response: func() proto.Message { return &distributiontypes.QueryParamsResponse{} },
+		},
+		stakingtypes.ModuleName: {
+			Path:     "/cosmos.staking.v1beta1.Query/Params",
+			Response: func() proto.Message { return &stakingtypes.QueryParamsResponse{} },
+		},
+		types.ModuleName: {
+			Path:     "/cosmwasm.wasm.v1.Query/Params",
+			Response: func() proto.Message { return &types.QueryParamsResponse{} },
+		},
+	}
+}
+
+type paramsQueryModulesSource interface {
+	GetParams(ctx context.Context) types.Params
+}
+
+// ParamsQuerier supports the `params` custom query for contracts to read the params of other modules as JSON.
+// Only modules in the `params_query_modules` allow list of the wasm params and with a route can be queried.
+// All other custom queries are passed to the next querier.
+//
+// This querier can be set via WithParamsQueryRoutes option in the wasm keeper constructor.
+func ParamsQuerier(k paramsQueryModulesSource, routes ParamsQueryRoutes, queryRouter GRPCQueryRouter, codec codec.Codec, next CustomQuerier) CustomQuerier {
+	acceptList := make(AcceptedQueries, len(routes))
+	for _, r := range routes {
+		acceptList[r.Path] = r.Response
+	}
+	stargateQuerier := AcceptListStargateQuerier(acceptList, queryRouter, codec)
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var query types.ParamsCustomQuery
+		if err := json.Unmarshal(request, &query); err != nil || query.Params == nil {
+			return next(ctx, request)
+		}
+		module := query.Params.Module
+		if !slices.Contains(k.GetParams(ctx).ParamsQueryModules, module) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("'%s' module params are not allowed from the contract", module)}
+		}
+		route, ok := routes[module]
+		if !ok {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("No params route for module '%s'", module)}
+		}
+		return stargateQuerier(ctx, &wasmvmtypes.StargateQuery{Path: route.Path})
+	}
+}
+
+// DenomTraceQuerier supports the `denom_trace` custom query for contracts to resolve the path and base denom
+// of an ibc denom hash. Unknown hashes return a not found error. All other custom queries are passed to the next querier.
+//
+// This querier can be set via WithDenomTraceQuerier option in the wasm keeper constructor.
+func DenomTraceQuerier(denomSource types.ICS20DenomSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var query types.DenomTraceCustomQuery
+		if err := json.Unmarshal(request, &query); err != nil || query.DenomTrace == nil {
+			return next(ctx, request)
+		}
+		hash, err := ibctransfertypes.ParseHexHash(strings.TrimPrefix(query.DenomTrace.Hash, ibctransfertypes.DenomPrefix+"/"))
+		if err != nil {
+			return nil, errorsmod.Wrap(types.ErrInvalid, "denom hash")
+		}
+		denom, found := denomSource.GetDenom(ctx, hash)
+		if !found {
+			return nil, errorsmod.Wrapf(types.ErrNotFound, "denom trace for hash %s", hash)
+		}
+		hops := make([]string, len(denom.Trace))
+		for i, h := range denom.Trace {
+			hops[i] = h.String()
+		}
+		return contractmsg.DenomTraceResponse(strings.Join(hops, "/"), denom.Base)
+	}
+}
+
+// WasmParamsQuerier supports the `wasm_params` custom query for contracts to read the code upload access and the
+// default instantiate permission of the wasm module params. All other custom queries are passed to the next querier.
+//
+// This querier can be set via WithWasmParamsQuerier option in the wasm keeper constructor.
+func WasmParamsQuerier(k paramsQueryModulesSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var query types.WasmParamsCustomQuery
+		if err := json.Unmarshal(request, &query); err != nil || query.WasmParams == nil {
+			return next(ctx, request)
+		}
+		return contractmsg.WasmParamsResponse(k.GetParams(ctx))
+	}
+}
+
 func StakingQuerier(keeper types.StakingKeeper, distKeeper types.DistributionKeeper) func(ctx sdk.Context, request *wasmvmtypes.StakingQuery) ([]byte, error) {
 	return func(ctx sdk.Context, request *wasmvmtypes.StakingQuery) ([]byte, error) {
 		if request.BondedDenom != nil {
@@ -662,6 +814,12 @@ func WasmQuerier(k wasmQueryKeeper) func(ctx sdk.Context, request *wasmvmtypes.W
 			if err := msg.ValidateBasic(); err != nil {
 				return nil, errorsmod.Wrap(err, "json msg")
 			}
+			// contracts can not act as the authenticated sender of a signed query. The envelope check
+			// decodes the message, which is charged like the deserialization of contract data.
+			ctx.GasMeter().ConsumeGas(uint64(len(msg))*DefaultDeserializationCostPerByte, "authenticated query check")
+			if types.IsAuthenticatedQuery(msg) {
+				return nil, errorsmod.Wrapf(types.ErrInvalid, "%s is reserved for signed queries", types.AuthenticatedQueryKey)
+			}
 			return k.QuerySmart(ctx, addr, msg)
 		case request.Raw != nil:
 			addr, err := sdk.AccAddressFromBech32(request.Raw.ContractAddr)
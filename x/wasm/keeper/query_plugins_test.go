@@ -1,6 +1,7 @@
 package keeper_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,11 +9,14 @@ import (
 	"sync/atomic"
 	"testing"
 
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/gogoproto/proto"
+	ibctransfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
 	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -852,3 +856,286 @@ func TestGRPCQuerier(t *testing.T) {
 	require.NoError(t, eg.Wait())
 	require.Zero(t, errorsCount.Load())
 }
+
+func TestGasMeteredQueriers(t *testing.T) {
+	_, keepers := keeper.CreateTestInput(t, false, keeper.AvailableCapabilities)
+	cdc := keepers.EncodingConfig.Codec
+	gasRegister := types.NewDefaultWasmGasRegister()
+
+	acceptedQueries := keeper.AcceptedQueries{
+		"/bank.AllBalances": func() proto.Message { return &banktypes.QueryAllBalancesResponse{} },
+	}
+	// returns as many balances as the page limit of the request
+	router := mockedQueryRouterFn(func(ctx sdk.Context, req *abci.RequestQuery) (*abci.ResponseQuery, error) {
+		allBalancesReq := &banktypes.QueryAllBalancesRequest{}
+		if err := cdc.Unmarshal(req.Data, allBalancesReq); err != nil {
+			return nil, err
+		}
+		var balances sdk.Coins
+		for i := range allBalancesReq.Pagination.Limit {
+			balances = append(balances, sdk.NewInt64Coin(fmt.Sprintf("denom%06d", i), 1))
+		}
+		resValue, err := cdc.Marshal(&banktypes.QueryAllBalancesResponse{Balances: balances})
+		if err != nil {
+			return nil, err
+		}
+		return &abci.ResponseQuery{Value: resValue}, nil
+	})
+	stargateQuerier := keeper.GasMeteredStargateQuerier(gasRegister, keeper.AcceptListStargateQuerier(acceptedQueries, router, cdc))
+	grpcQuerier := keeper.GasMeteredGrpcQuerier(gasRegister, keeper.AcceptListGrpcQuerier(acceptedQueries, router, cdc))
+
+	addr := keeper.RandomBech32AccountAddress(t)
+	queryGas := func(t *testing.T, denoms uint64) (storetypes.Gas, storetypes.Gas) {
+		data, err := cdc.Marshal(&banktypes.QueryAllBalancesRequest{Address: addr, Pagination: &query.PageRequest{Limit: denoms}})
+		require.NoError(t, err)
+
+		ctx := sdk.Context{}.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		stargateRes, err := stargateQuerier(ctx, &wasmvmtypes.StargateQuery{Path: "/bank.AllBalances", Data: data})
+		require.NoError(t, err)
+		stargateGas := ctx.GasMeter().GasConsumed()
+		assert.Equal(t, gasRegister.QueryResponseCosts(len(stargateRes)), stargateGas)
+
+		ctx = sdk.Context{}.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		grpcRes, err := grpcQuerier(ctx, &wasmvmtypes.GrpcQuery{Path: "/bank.AllBalances", Data: data})
+		require.NoError(t, err)
+		grpcGas := ctx.GasMeter().GasConsumed()
+		assert.Equal(t, gasRegister.QueryResponseCosts(proto.Size(grpcRes)), grpcGas)
+		return stargateGas, grpcGas
+	}
+
+	// when
+	smallStargateGas, smallGrpcGas := queryGas(t, 1)
+	hugeStargateGas, hugeGrpcGas := queryGas(t, 5_000)
+	// then
+	assert.Greater(t, hugeStargateGas, 1_000*smallStargateGas)
+	assert.Greater(t, hugeGrpcGas, 1_000*smallGrpcGas)
+
+	// and the gas is deterministic
+	for range 3 {
+		gotStargateGas, gotGrpcGas := queryGas(t, 5_000)
+		assert.Equal(t, hugeStargateGas, gotStargateGas)
+		assert.Equal(t, hugeGrpcGas, gotGrpcGas)
+	}
+
+	// when the query fails
+	ctx := sdk.Context{}.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	_, err := stargateQuerier(ctx, &wasmvmtypes.StargateQuery{Path: "/bank.Balance"})
+	// then no response gas is charged
+	require.Error(t, err)
+	assert.Zero(t, ctx.GasMeter().GasConsumed())
+}
+
+type mockedQueryRouterFn baseapp.GRPCQueryHandler
+
+func (m mockedQueryRouterFn) Route(_ string) baseapp.GRPCQueryHandler {
+	return baseapp.GRPCQueryHandler(m)
+}
+
+func TestParamsQuerier(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	// contract forwards the query msg as custom query to the chain
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		res, err := querier.Query(wasmvmtypes.QueryRequest{Custom: queryMsg}, gasLimit)
+		if err != nil {
+			return &wasmvmtypes.QueryResult{Err: err.Error()}, 0, nil
+		}
+		return &wasmvmtypes.QueryResult{Ok: res}, 0, nil
+	}
+	ctx, keepers := keeper.CreateTestInput(t, false, keeper.AvailableCapabilities,
+		keeper.WithWasmEngine(&mock),
+		keeper.WithParamsQueryRoutes(keeper.DefaultParamsQueryRoutes()),
+	)
+	k := keepers.WasmKeeper
+	params := types.DefaultParams()
+	params.ParamsQueryModules = []string{"staking", "wasm", "unknown"}
+	require.NoError(t, k.SetParams(ctx, params))
+	contractAddr := keeper.SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+
+	specs := map[string]struct {
+		src    string
+		exp    string
+		expErr string
+	}{
+		"staking params": {
+			src: `{"params":{"module":"staking"}}`,
+			exp: `{"params":{"unbonding_time":"0.000000100s","max_validators":10,"max_entries":10,"historical_entries":10,"bond_denom":"stake","min_commission_rate":"0.000000000000000000"}}`,
+		},
+		"wasm params": {
+			src: `{"params":{"module":"wasm"}}`,
+			exp: `{"params":{"code_upload_access":{"permission":"Everybody","addresses":[]},"instantiate_default_permission":"Everybody","disable_usage_tracking":false,"params_query_modules":["staking","wasm","unknown"],"disable_instantiate_capability_check":false,"enable_execution_receipts":false,"enforce_unique_labels_per_creator":false,"disable_block_summary_events":false,"message_fees":[],"max_response_data_size":0,"max_response_events":0,"max_response_attributes":0,"disable_exec_trace_hash":false,"instantiate_default_permissions_by_origin":[],"code_verifiers":[],"code_verification_threshold":0,"max_scheduled_sudos_per_contract":0,"max_scheduled_sudo_gas_per_block":"0"}}`,
+		},
+		"module not allowed": {
+			src:    `{"params":{"module":"bank"}}`,
+			expErr: "unsupported request: 'bank' module params are not allowed from the contract",
+		},
+		"module without route": {
+			src:    `{"params":{"module":"unknown"}}`,
+			expErr: "unsupported request: No params route for module 'unknown'",
+		},
+		"other custom query": {
+			src:    `{"ping":{}}`,
+			expErr: "unsupported request: custom",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := k.QuerySmart(ctx, contractAddr, []byte(spec.src))
+			if spec.expErr != "" {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.exp, string(got))
+		})
+	}
+}
+
+func TestDenomTraceQuerier(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	// contract forwards the query msg as custom query to the chain
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		res, err := querier.Query(wasmvmtypes.QueryRequest{Custom: queryMsg}, gasLimit)
+		if err != nil {
+			return &wasmvmtypes.QueryResult{Err: err.Error()}, 0, nil
+		}
+		return &wasmvmtypes.QueryResult{Ok: res}, 0, nil
+	}
+	registered := ibctransfertypes.NewDenom("uatom", ibctransfertypes.NewHop("transfer", "channel-1"), ibctransfertypes.NewHop("transfer", "channel-0"))
+	transferKeeper := wasmtesting.MockIBCTransferKeeper{
+		GetDenomFn: func(ctx sdk.Context, denomHash cmtbytes.HexBytes) (ibctransfertypes.Denom, bool) {
+			if bytes.Equal(denomHash, registered.Hash()) {
+				return registered, true
+			}
+			return ibctransfertypes.Denom{}, false
+		},
+	}
+	ctx, keepers := keeper.CreateTestInput(t, false, keeper.AvailableCapabilities,
+		keeper.WithWasmEngine(&mock),
+		keeper.WithDenomTraceQuerier(transferKeeper),
+	)
+	contractAddr := keeper.SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+	unknown := ibctransfertypes.NewDenom("uosmo", ibctransfertypes.NewHop("transfer", "channel-9"))
+
+	specs := map[string]struct {
+		src    string
+		exp    string
+		expErr string
+	}{
+		"registered hash": {
+			src: fmt.Sprintf(`{"denom_trace":{"hash":%q}}`, registered.Hash().String()),
+			exp: `{"path":"transfer/channel-1/transfer/channel-0","base_denom":"uatom"}`,
+		},
+		"registered ibc denom": {
+			src: fmt.Sprintf(`{"denom_trace":{"hash":%q}}`, registered.IBCDenom()),
+			exp: `{"path":"transfer/channel-1/transfer/channel-0","base_denom":"uatom"}`,
+		},
+		"unknown hash": {
+			src:    fmt.Sprintf(`{"denom_trace":{"hash":%q}}`, unknown.Hash().String()),
+			expErr: fmt.Sprintf("codespace: wasm, code: %d", types.ErrNotFound.ABCICode()),
+		},
+		"invalid hash": {
+			src:    `{"denom_trace":{"hash":"not-hex"}}`,
+			expErr: fmt.Sprintf("codespace: wasm, code: %d", types.ErrInvalid.ABCICode()),
+		},
+		"other custom query": {
+			src:    `{"ping":{}}`,
+			expErr: "unsupported request: custom",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := keepers.WasmKeeper.QuerySmart(ctx, contractAddr, []byte(spec.src))
+			if spec.expErr != "" {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.exp, string(got))
+		})
+	}
+}
+
+func TestWasmParamsQuerier(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	// contract forwards the query msg as custom query to the chain
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		res, err := querier.Query(wasmvmtypes.QueryRequest{Custom: queryMsg}, gasLimit)
+		if err != nil {
+			return &wasmvmtypes.QueryResult{Err: err.Error()}, 0, nil
+		}
+		return &wasmvmtypes.QueryResult{Ok: res}, 0, nil
+	}
+	parentCtx, keepers := keeper.CreateTestInput(t, false, keeper.AvailableCapabilities,
+		keeper.WithWasmEngine(&mock),
+		keeper.WithWasmParamsQuerier(),
+	)
+	contractAddr := keeper.SeedNewContractInstance(t, parentCtx, keepers, &mock).Contract
+	myAddr := keeper.RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		params types.Params
+		src    string
+		exp    string
+		expErr string
+	}{
+		"permissionless": {
+			params: types.Params{CodeUploadAccess: types.AllowEverybody, InstantiateDefaultPermission: types.AccessTypeEverybody},
+			src:    `{"wasm_params":{}}`,
+			exp:    `{"code_upload_access":{"permission":"Everybody"},"instantiate_default_permission":"Everybody"}`,
+		},
+		"permissioned": {
+			params: types.Params{CodeUploadAccess: types.AccessTypeAnyOfAddresses.With(myAddr), InstantiateDefaultPermission: types.AccessTypeNobody},
+			src:    `{"wasm_params":{}}`,
+			exp:    fmt.Sprintf(`{"code_upload_access":{"permission":"AnyOfAddresses","addresses":[%q]},"instantiate_default_permission":"Nobody"}`, myAddr.String()),
+		},
+		"other custom query": {
+			params: types.DefaultParams(),
+			src:    `{"ping":{}}`,
+			expErr: "unsupported request: custom",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			require.NoError(t, keepers.WasmKeeper.SetParams(ctx, spec.params))
+			got, gotErr := keepers.WasmKeeper.QuerySmart(ctx, contractAddr, []byte(spec.src))
+			if spec.expErr != "" {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.exp, string(got))
+		})
+	}
+}
+
+func TestSmartWasmQuerierRejectsAuthenticatedSender(t *testing.T) {
+	myContractAddr := keeper.RandomBech32AccountAddress(t)
+	var called bool
+	mock := mockWasmQueryKeeper{QuerySmartFn: func(ctx context.Context, contractAddr sdk.AccAddress, req types.RawContractMessage) ([]byte, error) {
+		called = true
+		return []byte(`{}`), nil
+	}}
+	q := keeper.WasmQuerier(mock)
+	ctx := sdk.Context{}.WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+	// when a contract sends the authenticated query envelope
+	myMsg := []byte(`{"\u0061uthenticated":{"sender":"` + keeper.RandomBech32AccountAddress(t) + `","msg":{}}}`)
+	_, gotErr := q(ctx, &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{ContractAddr: myContractAddr, Msg: myMsg}})
+	// then
+	require.ErrorIs(t, gotErr, types.ErrInvalid)
+	assert.False(t, called)
+	// and the decoding is charged
+	assert.Equal(t, uint64(len(myMsg))*keeper.DefaultDeserializationCostPerByte, ctx.GasMeter().GasConsumed())
+
+	// and other queries pass
+	_, gotErr = q(ctx, &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{ContractAddr: myContractAddr, Msg: []byte(`{"orders":{"authenticated":{}}}`)}})
+	require.NoError(t, gotErr)
+	assert.True(t, called)
+}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
@@ -56,14 +57,14 @@ func initRecurseContract(t *testing.T) (contract sdk.AccAddress, ctx sdk.Context
 
 func TestGasCostOnQuery(t *testing.T) {
 	const (
-		GasNoWork           uint64 = 63_988
-		GasNoWorkDiscounted uint64 = 5_971
+		GasNoWork           uint64 = 63_994
+		GasNoWorkDiscounted uint64 = 5_977
 		// Note: about 100 SDK gas (10k CosmWasm gas) for each round of sha256
-		GasWork50           uint64 = 64_236 // this is a little shy of 50k gas - to keep an eye on the limit
-		GasWork50Discounted uint64 = 6_210
+		GasWork50           uint64 = 64_242 // this is a little shy of 50k gas - to keep an eye on the limit
+		GasWork50Discounted uint64 = 6_216
 
-		GasReturnUnhashed uint64 = 89
-		GasReturnHashed   uint64 = 86
+		GasReturnUnhashed uint64 = 121
+		GasReturnHashed   uint64 = 119
 	)
 
 	cases := map[string]struct {
@@ -214,12 +215,12 @@ func TestLimitRecursiveQueryGas(t *testing.T) {
 
 	const (
 		// Note: about 100 SDK gas (10k CosmWasm gas) for each round of sha256
-		GasWork2k uint64 = 76_818 // = SetupContractCost + x // we have 6x gas used in cpu than in the instance
+		GasWork2k uint64 = 76_824 // = SetupContractCost + x // we have 6x gas used in cpu than in the instance
 
-		GasWork2kDiscounted uint64 = 18_264 + 436
+		GasWork2kDiscounted uint64 = 18_270 + 436
 
-		// This is overhead for calling into a sub-contract
-		GasReturnHashed uint64 = 48 + 132
+		// This is overhead for calling into a sub-contract, including the charged check of the query msg
+		GasReturnHashed uint64 = 48 + 132 + 35
 	)
 
 	cases := map[string]struct {
@@ -269,7 +270,7 @@ func TestLimitRecursiveQueryGas(t *testing.T) {
 			expectQueriesFromContract: 10,
 			expectOutOfGas:            false,
 			expectError:               "query wasm contract failed",                                                 // Error we get from the contract instance doing the failing query, not wasmd
-			expectedGas:               GasWork2k + GasReturnHashed + 9*(GasWork2kDiscounted+GasReturnHashed) + 3278, // lots of additional gas for long error message
+			expectedGas:               GasWork2k + GasReturnHashed + 9*(GasWork2kDiscounted+GasReturnHashed) + 3288, // lots of additional gas for long error message
 		},
 	}
 
@@ -314,3 +315,136 @@ func TestLimitRecursiveQueryGas(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryDepthGasSchedule(t *testing.T) {
+	// record the gas limit and consumption of each nested smart query level
+	var levelLimits, levelConsumed []uint64
+	recordingQuerierDec := func(realWasmQuerier WasmVMQueryHandler) WasmVMQueryHandler {
+		return WasmVMQueryHandlerFn(func(ctx sdk.Context, caller sdk.AccAddress, request wasmvmtypes.QueryRequest) ([]byte, error) {
+			pos := len(levelLimits)
+			levelLimits = append(levelLimits, ctx.GasMeter().Limit())
+			levelConsumed = append(levelConsumed, 0)
+			defer func() { levelConsumed[pos] = ctx.GasMeter().GasConsumed() }()
+			return realWasmQuerier.HandleQuery(ctx, caller, request)
+		})
+	}
+	const gasLimit uint64 = 400_000
+
+	specs := map[string]struct {
+		percent    uint32
+		grpcQuery  bool
+		execMode   sdk.ExecMode
+		expLimited bool
+		expErr     bool
+	}{
+		"disabled": {
+			grpcQuery: true,
+		},
+		"half of the parent gas per level": {
+			percent:    50,
+			grpcQuery:  true,
+			expLimited: true,
+		},
+		"not applied in block execution": {
+			percent:  50,
+			execMode: sdk.ExecModeFinalize,
+		},
+		"not applied in simulations": {
+			percent:  50,
+			execMode: sdk.ExecModeSimulate,
+		},
+		"not applied in check tx": {
+			percent:  50,
+			execMode: sdk.ExecModeCheck,
+		},
+		"not applied in proposal handling": {
+			percent:  50,
+			execMode: sdk.ExecModeProcessProposal,
+		},
+		"deep recursion fails early": {
+			percent:    10,
+			grpcQuery:  true,
+			expLimited: true,
+			expErr:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			setPercent := optsFn(func(k *Keeper) { k.queryDepthGasPercent = spec.percent })
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithQueryHandlerDecorator(recordingQuerierDec), setPercent)
+			keeper := keepers.WasmKeeper
+			contractAddr := InstantiateHackatomExampleContract(t, ctx, keepers).Contract
+			levelLimits, levelConsumed = nil, nil
+			ctx = ctx.WithGasMeter(storetypes.NewGasMeter(gasLimit)).WithExecMode(spec.execMode)
+			ctx = types.WithTxContracts(ctx, types.NewTxContracts())
+			if spec.grpcQuery {
+				ctx = types.WithGRPCQuery(ctx)
+			}
+
+			// when
+			_, gotErr := keeper.QuerySmart(ctx, contractAddr, buildRecurseQuery(t, Recurse{Depth: 4, Work: 50}))
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				// the root keeps most of its gas
+				assert.Less(t, ctx.GasMeter().GasConsumed(), gasLimit/2)
+			} else {
+				require.NoError(t, gotErr)
+				require.Len(t, levelLimits, 4)
+			}
+			parentLimit := gasLimit
+			for i, limit := range levelLimits {
+				if spec.expLimited {
+					assert.LessOrEqual(t, limit, parentLimit*uint64(spec.percent)/100, "level %d", i+1)
+				} else {
+					assert.Greater(t, limit, parentLimit/2, "level %d", i+1)
+				}
+				if !spec.expErr {
+					assert.LessOrEqual(t, levelConsumed[i], limit, "level %d", i+1)
+				}
+				parentLimit = limit
+			}
+		})
+	}
+}
+
+func TestQueryDepthGasLimitRounding(t *testing.T) {
+	ctx, _ := CreateTestInput(t, false, AvailableCapabilities)
+	gasRegisterConfig := types.DefaultGasRegisterConfig()
+	gasRegisterConfig.GasMultiplier = 1
+	var gotLimit uint64
+	mock := WasmVMQueryHandlerFn(func(ctx sdk.Context, _ sdk.AccAddress, _ wasmvmtypes.QueryRequest) ([]byte, error) {
+		gotLimit = ctx.GasMeter().Limit()
+		return nil, nil
+	})
+	specs := map[string]struct {
+		gasLimit uint64
+		percent  uint32
+		exp      uint64
+	}{
+		"not truncated before multiplication": {
+			gasLimit: 199,
+			percent:  50,
+			exp:      99,
+		},
+		"max gas does not overflow": {
+			gasLimit: math.MaxUint64,
+			percent:  99,
+			exp:      18262276632972456098, // floor((2^64-1) * 99 / 100)
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			h := NewQueryHandler(types.WithGRPCQuery(ctx), mock, RandomAccountAddress(t), types.NewWasmGasRegister(gasRegisterConfig))
+			h.depthGasPercent = spec.percent
+
+			// when
+			_, err := h.Query(wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{}}}, spec.gasLimit)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, gotLimit)
+		})
+	}
+}
@@ -29,17 +29,27 @@ func (k Keeper) OnOpenChannel(
 	msg wasmvmtypes.IBCChannelOpenMsg,
 ) (string, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-open-channel")
-	_, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return "", err
 	}
+	if contractInfo.Inactive {
+		return "", types.ContractSunsetError{SunsetHeight: contractInfo.SunsetHeight}
+	}
 
-	env := types.NewEnv(ctx, contractAddr)
+	ctx, env := k.newEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddr, "ibc_channel_open")
 	gasLeft := k.runtimeGasForContract(ctx)
+	gasReport := k.newVMGasReport(ctx, contractAddr, "ibc_channel_open", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.IBCChannelOpen(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddr, "ibc_channel_open", gasUsed)
+	k.recordExecutionReceipt(ctx, params, contractAddr, types.ExecutionReceiptKindIBC, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(ctx, contractAddr, contractInfo.CodeID, "ibc_channel_open", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return "", errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -66,13 +76,23 @@ func (k Keeper) OnConnectChannel(
 	if err != nil {
 		return err
 	}
+	if contractInfo.Inactive {
+		return types.ContractSunsetError{SunsetHeight: contractInfo.SunsetHeight}
+	}
 
-	env := types.NewEnv(ctx, contractAddr)
+	ctx, env := k.newEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddr, "ibc_channel_connect")
 	gasLeft := k.runtimeGasForContract(ctx)
+	gasReport := k.newVMGasReport(ctx, contractAddr, "ibc_channel_connect", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.IBCChannelConnect(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddr, "ibc_channel_connect", gasUsed)
+	k.recordExecutionReceipt(ctx, params, contractAddr, types.ExecutionReceiptKindIBC, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(ctx, contractAddr, contractInfo.CodeID, "ibc_channel_connect", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -84,7 +104,7 @@ func (k Keeper) OnConnectChannel(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
+	return k.handleIBCBasicContractResponse(ctx, params, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
 // OnCloseChannel calls the contract to let it know the IBC channel is closed.
@@ -105,12 +125,19 @@ func (k Keeper) OnCloseChannel(
 		return err
 	}
 
-	params := types.NewEnv(ctx, contractAddr)
+	ctx, env := k.newEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddr, "ibc_channel_close")
 	gasLeft := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, params, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
+	gasReport := k.newVMGasReport(ctx, contractAddr, "ibc_channel_close", gasLeft)
+	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddr, "ibc_channel_close", gasUsed)
+	k.recordExecutionReceipt(ctx, params, contractAddr, types.ExecutionReceiptKindIBC, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(ctx, contractAddr, contractInfo.CodeID, "ibc_channel_close", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -122,7 +149,7 @@ func (k Keeper) OnCloseChannel(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
+	return k.handleIBCBasicContractResponse(ctx, params, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
 // OnRecvPacket calls the contract to process the incoming IBC packet. The contract fully owns the data processing and
@@ -141,13 +168,27 @@ func (k Keeper) OnRecvPacket(
 	if err != nil {
 		return nil, err
 	}
+	// both result in an error acknowledgement
+	if contractInfo.Inactive {
+		return nil, types.ContractSunsetError{SunsetHeight: contractInfo.SunsetHeight}
+	}
+	if contractInfo.Suspended {
+		return nil, types.ErrContractSuspended.Wrapf("contract %s", contractAddr)
+	}
 
-	env := types.NewEnv(ctx, contractAddr)
+	ctx, env := k.newEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddr, "ibc_packet_receive")
 	gasLeft := k.runtimeGasForContract(ctx)
+	gasReport := k.newVMGasReport(ctx, contractAddr, "ibc_packet_receive", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.IBCPacketReceive(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddr, "ibc_packet_receive", gasUsed)
+	k.recordExecutionReceipt(ctx, params, contractAddr, types.ExecutionReceiptKindIBC, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(ctx, contractAddr, contractInfo.CodeID, "ibc_packet_receive", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		panic(execErr) // let the contract fully abort an IBC packet receive.
 		// Throwing a panic here instead of an error ack will revert
@@ -165,7 +206,7 @@ func (k Keeper) OnRecvPacket(
 		}, nil
 	}
 	// note submessage reply results can overwrite the `Acknowledgement` data
-	data, err := k.handleContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Acknowledgement, res.Ok.Events)
+	data, err := k.handleContractResponse(ctx, params, contractAddr, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Acknowledgement, res.Ok.Events)
 	if err != nil {
 		// submessage errors result in error ACK with state reverted. Error message is redacted
 		return nil, err
@@ -215,12 +256,19 @@ func (k Keeper) OnAckPacket(
 		return err
 	}
 
-	env := types.NewEnv(ctx, contractAddr)
+	ctx, env := k.newEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddr, "ibc_packet_ack")
 	gasLeft := k.runtimeGasForContract(ctx)
+	gasReport := k.newVMGasReport(ctx, contractAddr, "ibc_packet_ack", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.IBCPacketAck(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddr, "ibc_packet_ack", gasUsed)
+	k.recordExecutionReceipt(ctx, params, contractAddr, types.ExecutionReceiptKindIBC, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(ctx, contractAddr, contractInfo.CodeID, "ibc_packet_ack", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -232,7 +280,7 @@ func (k Keeper) OnAckPacket(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
+	return k.handleIBCBasicContractResponse(ctx, params, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
 // OnTimeoutPacket calls the contract to let it know the packet was never received on the destination chain within
@@ -250,12 +298,19 @@ func (k Keeper) OnTimeoutPacket(
 		return err
 	}
 
-	env := types.NewEnv(ctx, contractAddr)
+	ctx, env := k.newEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddr, "ibc_packet_timeout")
 	gasLeft := k.runtimeGasForContract(ctx)
+	gasReport := k.newVMGasReport(ctx, contractAddr, "ibc_packet_timeout", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.IBCPacketTimeout(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddr, "ibc_packet_timeout", gasUsed)
+	k.recordExecutionReceipt(ctx, params, contractAddr, types.ExecutionReceiptKindIBC, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(ctx, contractAddr, contractInfo.CodeID, "ibc_packet_timeout", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -267,7 +322,7 @@ func (k Keeper) OnTimeoutPacket(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
+	return k.handleIBCBasicContractResponse(ctx, params, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
 // IBCSourceCallback calls the contract to let it know the packet triggered by its
@@ -284,12 +339,19 @@ func (k Keeper) IBCSourceCallback(
 		return err
 	}
 
-	env := types.NewEnv(ctx, contractAddr)
+	ctx, env := k.newEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddr, "ibc_source_callback")
 	gasLeft := k.runtimeGasForContract(ctx)
+	gasReport := k.newVMGasReport(ctx, contractAddr, "ibc_source_callback", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.IBCSourceCallback(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddr, "ibc_source_callback", gasUsed)
+	k.recordExecutionReceipt(ctx, params, contractAddr, types.ExecutionReceiptKindIBC, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(ctx, contractAddr, contractInfo.CodeID, "ibc_source_callback", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -301,7 +363,7 @@ func (k Keeper) IBCSourceCallback(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
+	return k.handleIBCBasicContractResponse(ctx, params, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
 // IBCDestinationCallback calls the contract to let it know that it received a packet of an
@@ -317,13 +379,23 @@ func (k Keeper) IBCDestinationCallback(
 	if err != nil {
 		return err
 	}
+	if contractInfo.Inactive {
+		return types.ContractSunsetError{SunsetHeight: contractInfo.SunsetHeight}
+	}
 
-	env := types.NewEnv(ctx, contractAddr)
+	ctx, env := k.newEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
+	params := k.GetParams(ctx)
+	k.trackUsage(ctx, params, contractAddr, "ibc_destination_callback")
 	gasLeft := k.runtimeGasForContract(ctx)
+	gasReport := k.newVMGasReport(ctx, contractAddr, "ibc_destination_callback", gasLeft)
 	res, gasUsed, execErr := k.wasmVM.IBCDestinationCallback(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
+	gasReport.emit(ctx, k.gasRegister, gasUsed)
+	k.recordBlockSummary(ctx, params, contractAddr, "ibc_destination_callback", gasUsed)
+	k.recordExecutionReceipt(ctx, params, contractAddr, types.ExecutionReceiptKindIBC, gasUsed, execErr == nil && res != nil && res.Err == "")
+	k.logContractCall(ctx, contractAddr, contractInfo.CodeID, "ibc_destination_callback", msg, gasUsed, execErr == nil && res != nil && res.Err == "")
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -335,11 +407,11 @@ func (k Keeper) IBCDestinationCallback(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
+	return k.handleIBCBasicContractResponse(ctx, params, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
-func (k Keeper) handleIBCBasicContractResponse(ctx sdk.Context, addr sdk.AccAddress, id string, res *wasmvmtypes.IBCBasicResponse) error {
-	_, err := k.handleContractResponse(ctx, addr, id, res.Messages, res.Attributes, nil, res.Events)
+func (k Keeper) handleIBCBasicContractResponse(ctx sdk.Context, params types.Params, addr sdk.AccAddress, id string, res *wasmvmtypes.IBCBasicResponse) error {
+	_, err := k.handleContractResponse(ctx, params, addr, id, res.Messages, res.Attributes, nil, res.Events)
 	return err
 }
 
@@ -0,0 +1,120 @@
+package keeper
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestResponseLimits(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	var response wasmvmtypes.Response
+	mock.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &response}, 1, nil
+	}
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+
+	events := func(n, attrsPerEvent int) []wasmvmtypes.Event {
+		r := make([]wasmvmtypes.Event, n)
+		for i := range r {
+			r[i] = wasmvmtypes.Event{Type: "my-event", Attributes: attributes(attrsPerEvent)}
+		}
+		return r
+	}
+	specs := map[string]struct {
+		params   func(p *types.Params)
+		response wasmvmtypes.Response
+		expErr   *types.ResponseTooLargeError
+	}{
+		"within wasmvm limits": {
+			response: wasmvmtypes.Response{Data: make([]byte, types.VMResponseSizeLimit), Attributes: attributes(2), Events: events(2, 2)},
+		},
+		"data exceeds wasmvm limit": {
+			response: wasmvmtypes.Response{Data: make([]byte, types.VMResponseSizeLimit+1)},
+			expErr:   &types.ResponseTooLargeError{Limit: types.ResponseLimitData, Max: types.VMResponseSizeLimit, Actual: types.VMResponseSizeLimit + 1},
+		},
+		"events exceed wasmvm limit": {
+			response: wasmvmtypes.Response{Events: events(types.VMResponseSizeLimit+1, 0)},
+			expErr:   &types.ResponseTooLargeError{Limit: types.ResponseLimitEvents, Max: types.VMResponseSizeLimit, Actual: types.VMResponseSizeLimit + 1},
+		},
+		"attributes exceed wasmvm limit": {
+			response: wasmvmtypes.Response{Attributes: attributes(types.VMResponseSizeLimit + 1)},
+			expErr:   &types.ResponseTooLargeError{Limit: types.ResponseLimitAttributes, Max: types.VMResponseSizeLimit, Actual: types.VMResponseSizeLimit + 1},
+		},
+		"within param limits": {
+			params: func(p *types.Params) {
+				p.MaxResponseDataSize, p.MaxResponseEvents, p.MaxResponseAttributes = 10, 2, 6
+			},
+			response: wasmvmtypes.Response{Data: bytes.Repeat([]byte{0x1}, 10), Attributes: attributes(2), Events: events(2, 2)},
+		},
+		"data exceeds param limit": {
+			params: func(p *types.Params) {
+				p.MaxResponseDataSize = 10
+			},
+			response: wasmvmtypes.Response{Data: bytes.Repeat([]byte{0x1}, 11)},
+			expErr:   &types.ResponseTooLargeError{Limit: types.ResponseLimitData, Max: 10, Actual: 11},
+		},
+		"events exceed param limit": {
+			params: func(p *types.Params) {
+				p.MaxResponseEvents = 2
+			},
+			response: wasmvmtypes.Response{Events: events(3, 1)},
+			expErr:   &types.ResponseTooLargeError{Limit: types.ResponseLimitEvents, Max: 2, Actual: 3},
+		},
+		"attributes exceed param limit": {
+			params: func(p *types.Params) {
+				p.MaxResponseAttributes = 5
+			},
+			response: wasmvmtypes.Response{Attributes: attributes(2), Events: events(2, 2)},
+			expErr:   &types.ResponseTooLargeError{Limit: types.ResponseLimitAttributes, Max: 5, Actual: 6},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.params != nil {
+				params := k.GetParams(ctx)
+				spec.params(&params)
+				require.NoError(t, k.SetParams(ctx, params))
+			}
+			response = spec.response
+
+			// when
+			_, gotErr := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			if spec.expErr == nil {
+				require.NoError(t, gotErr)
+				return
+			}
+			require.ErrorIs(t, gotErr, types.ErrVMResponseTooLarge)
+			var limitErr types.ResponseTooLargeError
+			require.True(t, errors.As(gotErr, &limitErr))
+			assert.Equal(t, *spec.expErr, limitErr)
+			codespace, code, _ := errorsmod.ABCIInfo(gotErr, false)
+			assert.Equal(t, types.DefaultCodespace, codespace)
+			assert.Equal(t, types.ErrVMResponseTooLarge.ABCICode(), code)
+		})
+	}
+}
+
+func attributes(n int) []wasmvmtypes.EventAttribute {
+	r := make([]wasmvmtypes.EventAttribute, n)
+	for i := range r {
+		r[i] = wasmvmtypes.EventAttribute{Key: "my-key", Value: "my-value"}
+	}
+	return r
+}
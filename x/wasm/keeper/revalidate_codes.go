@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// CodeRevalidationFilter selects the codes of a re-validation
+type CodeRevalidationFilter struct {
+	// PinnedOnly selects the pinned codes only
+	PinnedOnly bool
+	// Sample is the max number of randomly selected codes. 0 selects all codes.
+	Sample int
+	// Rand is the source of the sample selection. Required with a sample.
+	Rand *rand.Rand
+}
+
+// CodeRevalidationReport is the result of the re-validation of the stored codes
+type CodeRevalidationReport struct {
+	Checked int                `json:"checked"`
+	Failed  int                `json:"failed"`
+	Codes   []CodeRevalidation `json:"codes"`
+}
+
+// CodeRevalidation is the re-validation result of a code. The error is empty when the code passed.
+type CodeRevalidation struct {
+	CodeID   uint64 `json:"code_id"`
+	Checksum string `json:"checksum"`
+	Pinned   bool   `json:"pinned"`
+	Error    string `json:"error,omitempty"`
+}
+
+// RevalidateCodes runs the static validation and analysis of the given wasm engine over the wasm code of the
+// selected codes. The engine should be the one of a new wasmvm version with a separate cache directory so that
+// codes that the new version rejects are found before the upgrade. Pinned codes are also pinned in the engine.
+// The store is only read. This is not meant for consensus code.
+func (k Keeper) RevalidateCodes(ctx context.Context, wasmVM types.WasmEngine, filter CodeRevalidationFilter) (*CodeRevalidationReport, error) {
+	var codes []CodeRevalidation
+	hashes := make(map[uint64][]byte)
+	k.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		pinned := k.IsPinnedCode(ctx, codeID)
+		if filter.PinnedOnly && !pinned {
+			return false
+		}
+		codes = append(codes, CodeRevalidation{CodeID: codeID, Checksum: hex.EncodeToString(info.CodeHash), Pinned: pinned})
+		hashes[codeID] = info.CodeHash
+		return false
+	})
+	if filter.Sample > 0 && filter.Sample < len(codes) {
+		if filter.Rand == nil {
+			return nil, types.ErrInvalid.Wrap("sample requires a random source")
+		}
+		filter.Rand.Shuffle(len(codes), func(i, j int) { codes[i], codes[j] = codes[j], codes[i] })
+		codes = codes[:filter.Sample]
+		slices.SortFunc(codes, func(a, b CodeRevalidation) int { return cmp.Compare(a.CodeID, b.CodeID) })
+	}
+
+	report := CodeRevalidationReport{Checked: len(codes), Codes: codes}
+	for i, c := range codes {
+		if err := k.revalidateCode(wasmVM, hashes[c.CodeID], c.Pinned); err != nil {
+			report.Codes[i].Error = err.Error()
+			report.Failed++
+		}
+	}
+	return &report, nil
+}
+
+func (k Keeper) revalidateCode(wasmVM types.WasmEngine, codeHash []byte, pinned bool) error {
+	code, err := k.wasmVM.GetCode(codeHash)
+	if err != nil {
+		return fmt.Errorf("load code: %w", err)
+	}
+	// no gas limit, this runs outside of a tx
+	checksum, _, err := wasmVM.StoreCode(code, math.MaxUint64)
+	if err != nil {
+		return fmt.Errorf("store code: %w", err)
+	}
+	if !bytes.Equal(checksum, codeHash) {
+		return fmt.Errorf("checksum mismatch: %x", checksum)
+	}
+	report, err := wasmVM.AnalyzeCode(checksum)
+	if err != nil {
+		return fmt.Errorf("analyze code: %w", err)
+	}
+	if missing := k.missingCapabilities(parseCapabilities(report.RequiredCapabilities)); len(missing) != 0 {
+		return fmt.Errorf("missing capabilities: %s", strings.Join(missing, ","))
+	}
+	if pinned {
+		if err := wasmVM.Pin(checksum); err != nil {
+			return fmt.Errorf("pin code: %w", err)
+		}
+	}
+	return nil
+}
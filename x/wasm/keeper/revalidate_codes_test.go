@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRevalidateCodes(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	withInMemoryCodeStore(&mock)
+	mock.PinFn = func(wasmvm.Checksum) error { return nil }
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	codes := [][]byte{
+		append(wasmIdent, []byte("pinned code")...),
+		append(wasmIdent, []byte("code rejected by strict validation")...),
+		append(wasmIdent, []byte("code with unknown capability")...),
+		append(wasmIdent, []byte("other code")...),
+	}
+	checksums := make([][]byte, len(codes))
+	for i, c := range codes {
+		var err error
+		checksums[i], err = mock.StoreCodeUnchecked(c)
+		require.NoError(t, err)
+		k.mustStoreCodeInfo(ctx, uint64(i+1), types.CodeInfo{CodeHash: checksums[i]})
+	}
+	require.NoError(t, k.pinCode(ctx, 1))
+
+	// the new engine rejects previously valid codes
+	newEngine := func(pinned *[][]byte) *wasmtesting.MockWasmEngine {
+		return &wasmtesting.MockWasmEngine{
+			StoreCodeFn: func(code wasmvm.WasmCode, gasLimit uint64) (wasmvm.Checksum, uint64, error) {
+				if bytes.Contains(code, []byte("strict")) {
+					return nil, 0, errors.New("stricter validation")
+				}
+				return wasmtesting.HashOnlyStoreCodeFn(code, gasLimit)
+			},
+			AnalyzeCodeFn: func(checksum wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error) {
+				if bytes.Equal(checksum, checksums[2]) {
+					return &wasmvmtypes.AnalysisReport{RequiredCapabilities: "iterator,unknown"}, nil
+				}
+				return &wasmvmtypes.AnalysisReport{}, nil
+			},
+			PinFn: func(checksum wasmvm.Checksum) error {
+				*pinned = append(*pinned, checksum)
+				return nil
+			},
+		}
+	}
+
+	specs := map[string]struct {
+		filter     CodeRevalidationFilter
+		expCodes   []uint64
+		expFailed  []uint64
+		expPinned  [][]byte
+		expSampled int
+		expErr     bool
+	}{
+		"all codes": {
+			expCodes:  []uint64{1, 2, 3, 4},
+			expFailed: []uint64{2, 3},
+			expPinned: [][]byte{checksums[0]},
+		},
+		"pinned only": {
+			filter:    CodeRevalidationFilter{PinnedOnly: true},
+			expCodes:  []uint64{1},
+			expPinned: [][]byte{checksums[0]},
+		},
+		"sample": {
+			filter:     CodeRevalidationFilter{Sample: 2, Rand: rand.New(rand.NewSource(1))},
+			expSampled: 2,
+		},
+		"sample larger than codes": {
+			filter:    CodeRevalidationFilter{Sample: 10},
+			expCodes:  []uint64{1, 2, 3, 4},
+			expFailed: []uint64{2, 3},
+			expPinned: [][]byte{checksums[0]},
+		},
+		"sample without random source": {
+			filter: CodeRevalidationFilter{Sample: 2},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotPinned [][]byte
+			// when
+			report, gotErr := k.RevalidateCodes(ctx, newEngine(&gotPinned), spec.filter)
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var gotCodes, gotFailed []uint64
+			for _, c := range report.Codes {
+				gotCodes = append(gotCodes, c.CodeID)
+				if c.Error != "" {
+					gotFailed = append(gotFailed, c.CodeID)
+				}
+			}
+			assert.Equal(t, len(gotCodes), report.Checked)
+			assert.Equal(t, len(gotFailed), report.Failed)
+			if spec.expSampled != 0 {
+				// random selection in code id order
+				assert.Len(t, gotCodes, spec.expSampled)
+				assert.IsIncreasing(t, gotCodes)
+				return
+			}
+			assert.Equal(t, spec.expCodes, gotCodes)
+			assert.Equal(t, spec.expFailed, gotFailed)
+			assert.Equal(t, spec.expPinned, gotPinned)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// scheduleSudo stores a one-shot sudo call of the contract on itself that is executed at the end of the block
+// with the given height. The gas limit of the call is consumed upfront from the gas meter of the scheduling tx.
+// The sum of the gas limits of all calls of a block is capped by the params so that the end blocker is bounded.
+func (k Keeper) scheduleSudo(ctx context.Context, contractAddr sdk.AccAddress, height int64, msg types.RawContractMessage, gasLimit uint64) (uint64, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return 0, errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "%s is not a contract", contractAddr)
+	}
+	if height <= sdkCtx.BlockHeight() {
+		return 0, errorsmod.Wrapf(types.ErrInvalid, "height %d must be after the current block %d", height, sdkCtx.BlockHeight())
+	}
+	params := k.GetParams(ctx)
+	maxPending, maxBlockGas := params.MaxScheduledSudosPerContract, params.MaxScheduledSudoGasPerBlock
+	if maxPending == 0 || maxBlockGas == 0 {
+		return 0, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "scheduled sudo calls are disabled")
+	}
+	if k.countScheduledSudos(ctx, contractAddr) >= maxPending {
+		return 0, types.ErrLimit.Wrapf("scheduled sudo calls: max %d", maxPending)
+	}
+	if blockGas := k.getScheduledSudoGas(ctx, height); gasLimit > maxBlockGas-blockGas {
+		return 0, types.ErrLimit.Wrapf("scheduled sudo gas of block %d: max %d, scheduled %d", height, maxBlockGas, blockGas)
+	}
+	sdkCtx.GasMeter().ConsumeGas(gasLimit, "scheduled sudo gas limit")
+
+	s := types.ScheduledSudo{
+		ID:       k.mustAutoIncrementID(ctx, types.KeySequenceScheduledSudoID),
+		Contract: contractAddr.String(),
+		Height:   height,
+		Msg:      msg,
+		GasLimit: gasLimit,
+	}
+	if err := k.storeScheduledSudo(ctx, contractAddr, s); err != nil {
+		return 0, err
+	}
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeScheduleSudo,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, s.Contract),
+		sdk.NewAttribute(types.AttributeKeyScheduledSudoID, strconv.FormatUint(s.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyScheduledHeight, strconv.FormatInt(height, 10)),
+		sdk.NewAttribute(types.AttributeKeyGasLimit, strconv.FormatUint(gasLimit, 10)),
+	))
+	return s.ID, nil
+}
+
+// cancelScheduledSudo removes a pending scheduled sudo call. Only the contract that scheduled the call can cancel it.
+func (k Keeper) cancelScheduledSudo(ctx context.Context, contractAddr sdk.AccAddress, id uint64) error {
+	s := k.GetScheduledSudo(ctx, contractAddr, id)
+	if s == nil {
+		return types.ErrNotFound.Wrapf("scheduled sudo %d of contract %s", id, contractAddr)
+	}
+	if err := k.deleteScheduledSudo(ctx, contractAddr, *s); err != nil {
+		return err
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeCancelScheduledSudo,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, s.Contract),
+		sdk.NewAttribute(types.AttributeKeyScheduledSudoID, strconv.FormatUint(id, 10)),
+	))
+	return nil
+}
+
+// GetScheduledSudo returns the pending scheduled sudo call of the contract with the id or nil when it does not exist
+func (k Keeper) GetScheduledSudo(ctx context.Context, contractAddr sdk.AccAddress, id uint64) *types.ScheduledSudo {
+	store := k.storeService.OpenKVStore(ctx)
+	heightBz, err := store.Get(types.GetScheduledSudoByContractKey(contractAddr, id))
+	if err != nil {
+		panic(err)
+	}
+	if heightBz == nil {
+		return nil
+	}
+	bz, err := store.Get(types.GetScheduledSudoKey(int64(sdk.BigEndianToUint64(heightBz)), id))
+	if err != nil {
+		panic(err)
+	}
+	var s types.ScheduledSudo
+	k.cdc.MustUnmarshal(bz, &s)
+	return &s
+}
+
+// IterateScheduledSudosByContract iterates over all pending scheduled sudo calls of the contract ordered by id
+func (k Keeper) IterateScheduledSudosByContract(ctx context.Context, contractAddr sdk.AccAddress, cb func(types.ScheduledSudo) bool) {
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	prefixStore := prefix.NewStore(store, types.GetScheduledSudosByContractPrefix(contractAddr))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		id := sdk.BigEndianToUint64(iter.Key())
+		height := int64(sdk.BigEndianToUint64(iter.Value()))
+		var s types.ScheduledSudo
+		k.cdc.MustUnmarshal(store.Get(types.GetScheduledSudoKey(height, id)), &s)
+		if cb(s) {
+			return
+		}
+	}
+}
+
+// IterateScheduledSudos iterates over all pending scheduled sudo calls ordered by height and id
+func (k Keeper) IterateScheduledSudos(ctx context.Context, cb func(types.ScheduledSudo) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.ScheduledSudoPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var s types.ScheduledSudo
+		k.cdc.MustUnmarshal(iter.Value(), &s)
+		if cb(s) {
+			return
+		}
+	}
+}
+
+func (k Keeper) countScheduledSudos(ctx context.Context, contractAddr sdk.AccAddress) uint32 {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetScheduledSudosByContractPrefix(contractAddr))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	var n uint32
+	for ; iter.Valid(); iter.Next() {
+		n++
+	}
+	return n
+}
+
+// getScheduledSudoGas returns the sum of the gas limits of the pending sudo calls scheduled at the height
+func (k Keeper) getScheduledSudoGas(ctx context.Context, height int64) uint64 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetScheduledSudoGasKey(height))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) setScheduledSudoGas(ctx context.Context, height int64, gas uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	if gas == 0 {
+		return store.Delete(types.GetScheduledSudoGasKey(height))
+	}
+	return store.Set(types.GetScheduledSudoGasKey(height), sdk.Uint64ToBigEndian(gas))
+}
+
+func (k Keeper) storeScheduledSudo(ctx context.Context, contractAddr sdk.AccAddress, s types.ScheduledSudo) error {
+	store := k.storeService.OpenKVStore(ctx)
+	if err := store.Set(types.GetScheduledSudoKey(s.Height, s.ID), k.cdc.MustMarshal(&s)); err != nil {
+		return err
+	}
+	if err := store.Set(types.GetScheduledSudoByContractKey(contractAddr, s.ID), sdk.Uint64ToBigEndian(uint64(s.Height))); err != nil {
+		return err
+	}
+	return k.setScheduledSudoGas(ctx, s.Height, k.getScheduledSudoGas(ctx, s.Height)+s.GasLimit)
+}
+
+func (k Keeper) deleteScheduledSudo(ctx context.Context, contractAddr sdk.AccAddress, s types.ScheduledSudo) error {
+	store := k.storeService.OpenKVStore(ctx)
+	if err := store.Delete(types.GetScheduledSudoKey(s.Height, s.ID)); err != nil {
+		return err
+	}
+	if err := store.Delete(types.GetScheduledSudoByContractKey(contractAddr, s.ID)); err != nil {
+		return err
+	}
+	return k.setScheduledSudoGas(ctx, s.Height, k.getScheduledSudoGas(ctx, s.Height)-s.GasLimit)
+}
+
+// ExecuteScheduledSudos calls all sudo messages that were scheduled for the current block in the order in which
+// they were scheduled. Each call is executed in an isolated cache context with its own gas limit. Calls are
+// removed before execution and never retried. A failure is emitted as an event and does not revert the state
+// changes of other calls. Calls for a past height, that can only exist after a genesis import, and calls of
+// suspended or deactivated contracts are dropped as missed.
+func (k Keeper) ExecuteScheduledSudos(ctx sdk.Context) error {
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	iter := store.Iterator(types.ScheduledSudoPrefix, types.GetScheduledSudosPrefix(ctx.BlockHeight()+1))
+	var due []types.ScheduledSudo
+	for ; iter.Valid(); iter.Next() {
+		var s types.ScheduledSudo
+		k.cdc.MustUnmarshal(iter.Value(), &s)
+		due = append(due, s)
+	}
+	iter.Close()
+
+	for _, s := range due {
+		contractAddr, err := sdk.AccAddressFromBech32(s.Contract)
+		if err != nil {
+			return errorsmod.Wrapf(err, "scheduled sudo %d", s.ID)
+		}
+		if err := k.deleteScheduledSudo(ctx, contractAddr, s); err != nil {
+			return err
+		}
+		if s.Height < ctx.BlockHeight() || !k.canRunScheduledSudo(ctx, contractAddr) {
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeScheduledSudoMissed,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, s.Contract),
+				sdk.NewAttribute(types.AttributeKeyScheduledSudoID, strconv.FormatUint(s.ID, 10)),
+				sdk.NewAttribute(types.AttributeKeyScheduledHeight, strconv.FormatInt(s.Height, 10)),
+			))
+			continue
+		}
+		cacheCtx, commit := ctx.CacheContext()
+		if err := k.sudoWithGasLimit(cacheCtx, contractAddr, s.Msg, s.GasLimit); err != nil {
+			k.Logger(ctx).Info("scheduled sudo failed", "contract", s.Contract, "id", s.ID, "error", err)
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeScheduledSudoFailed,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, s.Contract),
+				sdk.NewAttribute(types.AttributeKeyScheduledSudoID, strconv.FormatUint(s.ID, 10)),
+				sdk.NewAttribute(types.AttributeKeyError, redactError(err).Error()),
+			))
+			k.keepFailedExecutionReceipt(ctx, cacheCtx, contractAddr)
+			continue
+		}
+		commit()
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeScheduledSudoExecuted,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, s.Contract),
+			sdk.NewAttribute(types.AttributeKeyScheduledSudoID, strconv.FormatUint(s.ID, 10)),
+		))
+	}
+	return nil
+}
+
+// canRunScheduledSudo returns false for a contract that is suspended or was deactivated at its sunset height.
+// The scheduled call is made on behalf of the contract itself, not governance, so that the same rules apply as
+// for an execution.
+func (k Keeper) canRunScheduledSudo(ctx context.Context, contractAddr sdk.AccAddress) bool {
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	return contractInfo != nil && !contractInfo.Suspended && !contractInfo.Inactive
+}
+
+// sudoWithGasLimit calls sudo with a gas meter that is limited to the given gas. Running out of gas
+// is returned as an error.
+func (k Keeper) sudoWithGasLimit(ctx sdk.Context, contractAddr sdk.AccAddress, msg []byte, gasLimit uint64) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// if it's not an OutOfGas error, raise it again
+			if _, ok := r.(storetypes.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			err = errorsmod.Wrap(sdkerrors.ErrOutOfGas, "sudo hit gas limit")
+		}
+	}()
+	_, err = k.Sudo(ctx.WithGasMeter(storetypes.NewGasMeter(gasLimit)), contractAddr, msg)
+	return err
+}
+
+func (k Keeper) importScheduledSudos(ctx context.Context, scheduled []types.ScheduledSudo) (uint64, error) {
+	var maxID uint64
+	for i, s := range scheduled {
+		contractAddr, err := sdk.AccAddressFromBech32(s.Contract)
+		if err != nil {
+			return 0, errorsmod.Wrapf(err, "scheduled sudo number %d", i)
+		}
+		if !k.HasContractInfo(ctx, contractAddr) {
+			return 0, types.ErrNotFound.Wrapf("contract %s of scheduled sudo number %d", s.Contract, i)
+		}
+		if err := k.storeScheduledSudo(ctx, contractAddr, s); err != nil {
+			return 0, errorsmod.Wrapf(err, "scheduled sudo number %d", i)
+		}
+		if s.ID > maxID {
+			maxID = s.ID
+		}
+	}
+	return maxID, nil
+}
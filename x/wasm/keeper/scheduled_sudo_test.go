@@ -0,0 +1,294 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestScheduleSudo(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = ctx.WithBlockHeight(100)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherExample := SeedNewContractInstance(t, ctx, keepers, &mock)
+	myContract := example.Contract.String()
+	msgServer := NewMsgServerImpl(k)
+
+	specs := map[string]struct {
+		src         types.MsgScheduleSudo
+		maxPending  uint32
+		maxBlockGas uint64
+		preSetup    func(sdk.Context)
+		expErr      *errorsmod.Error
+	}{
+		"all good": {
+			src:         types.MsgScheduleSudo{Sender: myContract, Height: 101, Msg: []byte(`{}`), GasLimit: 10_000},
+			maxPending:  1,
+			maxBlockGas: 10_000,
+		},
+		"current height": {
+			src:         types.MsgScheduleSudo{Sender: myContract, Height: 100, Msg: []byte(`{}`), GasLimit: 10_000},
+			maxPending:  1,
+			maxBlockGas: 10_000,
+			expErr:      types.ErrInvalid,
+		},
+		"not a contract": {
+			src:         types.MsgScheduleSudo{Sender: RandomBech32AccountAddress(t), Height: 101, Msg: []byte(`{}`), GasLimit: 10_000},
+			maxPending:  1,
+			maxBlockGas: 10_000,
+			expErr:      sdkerrors.ErrUnauthorized,
+		},
+		"disabled": {
+			src:         types.MsgScheduleSudo{Sender: myContract, Height: 101, Msg: []byte(`{}`), GasLimit: 10_000},
+			maxBlockGas: 10_000,
+			expErr:      sdkerrors.ErrUnauthorized,
+		},
+		"block gas disabled": {
+			src:        types.MsgScheduleSudo{Sender: myContract, Height: 101, Msg: []byte(`{}`), GasLimit: 10_000},
+			maxPending: 1,
+			expErr:     sdkerrors.ErrUnauthorized,
+		},
+		"gas limit exceeds max block gas": {
+			src:         types.MsgScheduleSudo{Sender: myContract, Height: 101, Msg: []byte(`{}`), GasLimit: 10_001},
+			maxPending:  1,
+			maxBlockGas: 10_000,
+			expErr:      types.ErrLimit,
+		},
+		"max block gas reached": {
+			src:         types.MsgScheduleSudo{Sender: myContract, Height: 101, Msg: []byte(`{}`), GasLimit: 10_000},
+			maxPending:  1,
+			maxBlockGas: 10_000,
+			preSetup: func(ctx sdk.Context) {
+				_, err := k.scheduleSudo(ctx, otherExample.Contract, 101, []byte(`{}`), 1)
+				require.NoError(t, err)
+			},
+			expErr: types.ErrLimit,
+		},
+		"max block gas reached in other block": {
+			src:         types.MsgScheduleSudo{Sender: myContract, Height: 101, Msg: []byte(`{}`), GasLimit: 10_000},
+			maxPending:  1,
+			maxBlockGas: 10_000,
+			preSetup: func(ctx sdk.Context) {
+				_, err := k.scheduleSudo(ctx, otherExample.Contract, 102, []byte(`{}`), 10_000)
+				require.NoError(t, err)
+			},
+		},
+		"max pending reached": {
+			src:         types.MsgScheduleSudo{Sender: myContract, Height: 101, Msg: []byte(`{}`), GasLimit: 10_000},
+			maxPending:  1,
+			maxBlockGas: 10_000,
+			preSetup: func(ctx sdk.Context) {
+				_, err := k.scheduleSudo(ctx, example.Contract, 200, []byte(`{}`), 1)
+				require.NoError(t, err)
+			},
+			expErr: types.ErrLimit,
+		},
+		"invalid msg": {
+			src:         types.MsgScheduleSudo{Sender: myContract, Height: 101, Msg: []byte(`not json`), GasLimit: 10_000},
+			maxPending:  1,
+			maxBlockGas: 10_000,
+			expErr:      types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			params := k.GetParams(ctx)
+			params.MaxScheduledSudosPerContract = spec.maxPending
+			params.MaxScheduledSudoGasPerBlock = spec.maxBlockGas
+			require.NoError(t, k.SetParams(ctx, params))
+			if spec.preSetup != nil {
+				spec.preSetup(ctx)
+			}
+			ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+			// when
+			gotRsp, gotErr := msgServer.ScheduleSudo(ctx, &spec.src)
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed(), spec.src.GasLimit)
+			got := k.GetScheduledSudo(ctx, example.Contract, gotRsp.ID)
+			require.NotNil(t, got)
+			exp := types.ScheduledSudo{ID: gotRsp.ID, Contract: myContract, Height: 101, Msg: []byte(`{}`), GasLimit: 10_000}
+			assert.Equal(t, exp, *got)
+		})
+	}
+}
+
+func TestCancelScheduledSudo(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherExample := SeedNewContractInstance(t, ctx, keepers, &mock)
+	msgServer := NewMsgServerImpl(k)
+
+	params := k.GetParams(ctx)
+	params.MaxScheduledSudosPerContract = 10
+	params.MaxScheduledSudoGasPerBlock = 10_000_000
+	require.NoError(t, k.SetParams(ctx, params))
+	myID, err := k.scheduleSudo(ctx, example.Contract, ctx.BlockHeight()+1, []byte(`{}`), 1)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src    types.MsgCancelScheduledSudo
+		expErr *errorsmod.Error
+	}{
+		"all good": {
+			src: types.MsgCancelScheduledSudo{Sender: example.Contract.String(), ID: myID},
+		},
+		"other contract": {
+			src:    types.MsgCancelScheduledSudo{Sender: otherExample.Contract.String(), ID: myID},
+			expErr: types.ErrNotFound,
+		},
+		"unknown id": {
+			src:    types.MsgCancelScheduledSudo{Sender: example.Contract.String(), ID: myID + 1},
+			expErr: types.ErrNotFound,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			// when
+			_, gotErr := msgServer.CancelScheduledSudo(ctx, &spec.src)
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.NotNil(t, k.GetScheduledSudo(ctx, example.Contract, myID))
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Nil(t, k.GetScheduledSudo(ctx, example.Contract, myID))
+			assert.Zero(t, k.getScheduledSudoGas(ctx, ctx.BlockHeight()+1))
+			var count int
+			k.IterateScheduledSudos(ctx, func(types.ScheduledSudo) bool {
+				count++
+				return false
+			})
+			assert.Zero(t, count)
+		})
+	}
+}
+
+func TestExecuteScheduledSudos(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = ctx.WithBlockHeight(100)
+	k := keepers.WasmKeeper
+
+	var recorded []string
+	var failingChecksum wasmvm.Checksum
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.SudoFn = func(checksum wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		store.Set([]byte("last"), sudoMsg)
+		recorded = append(recorded, string(sudoMsg))
+		if bytes.Equal(failingChecksum, checksum) {
+			return &wasmvmtypes.ContractResult{Err: "testing"}, 1, nil
+		}
+		if bytes.Contains(sudoMsg, []byte("expensive")) {
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1_000_000_000_000, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	failingExample := SeedNewContractInstance(t, ctx, keepers, &mock)
+	failingChecksum = failingExample.Checksum
+	suspendedExample := SeedNewContractInstance(t, ctx, keepers, &mock)
+	inactiveExample := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	params := k.GetParams(ctx)
+	params.MaxScheduledSudosPerContract = 10
+	params.MaxScheduledSudoGasPerBlock = 10_000_000
+	require.NoError(t, k.SetParams(ctx, params))
+	for _, s := range []struct {
+		contract sdk.AccAddress
+		height   int64
+		msg      string
+		gasLimit uint64
+	}{
+		{example.Contract, 102, `{"step":3}`, 1_000_000},
+		{example.Contract, 101, `{"step":1}`, 1_000_000},
+		{failingExample.Contract, 101, `{"step":"failing"}`, 1_000_000},
+		{example.Contract, 101, `{"step":"expensive"}`, 1_000_000},
+		{example.Contract, 101, `{"step":2}`, 1_000_000},
+		{suspendedExample.Contract, 101, `{"step":"suspended"}`, 1_000_000},
+		{inactiveExample.Contract, 101, `{"step":"inactive"}`, 1_000_000},
+	} {
+		_, err := k.scheduleSudo(ctx, s.contract, s.height, []byte(s.msg), s.gasLimit)
+		require.NoError(t, err)
+	}
+	suspendedInfo := k.GetContractInfo(ctx, suspendedExample.Contract)
+	suspendedInfo.Suspended = true
+	k.mustStoreContractInfo(ctx, suspendedExample.Contract, suspendedInfo)
+	inactiveInfo := k.GetContractInfo(ctx, inactiveExample.Contract)
+	inactiveInfo.Inactive = true
+	k.mustStoreContractInfo(ctx, inactiveExample.Contract, inactiveInfo)
+
+	// when
+	em := sdk.NewEventManager()
+	ctx = ctx.WithBlockHeight(101).WithEventManager(em)
+	require.NoError(t, k.ExecuteScheduledSudos(ctx))
+
+	// then calls of the block are executed in the order in which they were scheduled
+	// and calls of suspended or inactive contracts are skipped
+	assert.Equal(t, []string{`{"step":1}`, `{"step":"failing"}`, `{"step":"expensive"}`, `{"step":2}`}, recorded)
+	var gotEventTypes []string
+	for _, e := range em.Events() {
+		switch e.Type {
+		case types.EventTypeScheduledSudoExecuted, types.EventTypeScheduledSudoFailed, types.EventTypeScheduledSudoMissed:
+			gotEventTypes = append(gotEventTypes, e.Type)
+		}
+	}
+	assert.Equal(t, []string{
+		types.EventTypeScheduledSudoExecuted,
+		types.EventTypeScheduledSudoFailed,
+		types.EventTypeScheduledSudoFailed,
+		types.EventTypeScheduledSudoExecuted,
+		types.EventTypeScheduledSudoMissed,
+		types.EventTypeScheduledSudoMissed,
+	}, gotEventTypes)
+	// and state changes of the failed calls are reverted
+	assert.Equal(t, []byte(`{"step":2}`), k.QueryRaw(ctx, example.Contract, []byte("last")))
+	assert.Empty(t, k.QueryRaw(ctx, failingExample.Contract, []byte("last")))
+	// and only the call of the next block is left
+	var pending []types.ScheduledSudo
+	k.IterateScheduledSudos(ctx, func(s types.ScheduledSudo) bool {
+		pending = append(pending, s)
+		return false
+	})
+	require.Len(t, pending, 1)
+	assert.Equal(t, int64(102), pending[0].Height)
+	assert.Zero(t, k.getScheduledSudoGas(ctx, 101))
+
+	// when the block of the call was missed
+	em = sdk.NewEventManager()
+	recorded = nil
+	ctx = ctx.WithBlockHeight(103).WithEventManager(em)
+	require.NoError(t, k.ExecuteScheduledSudos(ctx))
+
+	// then the call is dropped without execution
+	assert.Empty(t, recorded)
+	require.Len(t, em.Events(), 1)
+	assert.Equal(t, types.EventTypeScheduledSudoMissed, em.Events()[0].Type)
+	assert.Nil(t, k.GetScheduledSudo(ctx, example.Contract, pending[0].ID))
+}
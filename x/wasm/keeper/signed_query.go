@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"context"
+	"sync"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// recentBlockHashes is a node local record of the hashes of the last blocks. It bounds the replay of
+// signed smart queries and is not part of consensus. The record starts empty on node start.
+type recentBlockHashes struct {
+	window int64
+
+	mu      sync.RWMutex
+	latest  int64
+	heights map[string]int64
+}
+
+func newRecentBlockHashes(window uint32) *recentBlockHashes {
+	return &recentBlockHashes{window: int64(window), heights: make(map[string]int64)}
+}
+
+// add records the hash of the block and drops the hashes that fell out of the window
+func (r *recentBlockHashes) add(height int64, hash []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.heights[string(hash)] = height
+	r.latest = max(r.latest, height)
+	for h, blockHeight := range r.heights {
+		if blockHeight <= r.latest-r.window {
+			delete(r.heights, h)
+		}
+	}
+}
+
+// isRecent returns true when the hash belongs to one of the last blocks within the window
+func (r *recentBlockHashes) isRecent(hash []byte) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.heights[string(hash)]
+	return ok
+}
+
+// TrackBlockHash records the hash of the current block for the freshness check of signed smart queries.
+// This is a noop when signed queries are not enabled on the node.
+func (k Keeper) TrackBlockHash(ctx sdk.Context) {
+	if k.recentBlockHashes == nil || len(ctx.HeaderHash()) == 0 {
+		return
+	}
+	k.recentBlockHashes.add(ctx.BlockHeight(), ctx.HeaderHash())
+}
+
+// AuthenticateSmartQuery verifies the signature of the signer over the contract address, the query data and
+// the hash of a recent block. The freshness of the block hash is a node local setting, therefore signed
+// queries are not supported in block execution.
+func (k Keeper) AuthenticateSmartQuery(ctx context.Context, contractAddr sdk.AccAddress, queryData []byte, signer sdk.AccAddress, signature, blockHash []byte) error {
+	if k.recentBlockHashes == nil {
+		return errorsmod.Wrap(types.ErrInvalid, "signed queries are not enabled on this node")
+	}
+	if sdk.UnwrapSDKContext(ctx).ExecMode() == sdk.ExecModeFinalize {
+		return errorsmod.Wrap(types.ErrInvalid, "signed queries are not supported in block execution")
+	}
+	if !k.recentBlockHashes.isRecent(blockHash) {
+		return errorsmod.Wrap(types.ErrInvalid, "unknown or expired block hash")
+	}
+	acc := k.accountKeeper.GetAccount(ctx, signer)
+	if acc == nil || acc.GetPubKey() == nil {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidPubKey, "no public key for signer %s", signer)
+	}
+	if !acc.GetPubKey().VerifySignature(types.SignedQuerySignBytes(contractAddr, queryData, blockHash), signature) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "signature verification failed")
+	}
+	return nil
+}
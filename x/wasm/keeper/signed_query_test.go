@@ -0,0 +1,204 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRecentBlockHashes(t *testing.T) {
+	r := newRecentBlockHashes(2)
+	r.add(1, []byte("one"))
+	r.add(2, []byte("two"))
+	assert.True(t, r.isRecent([]byte("one")))
+	assert.True(t, r.isRecent([]byte("two")))
+	// when the window moves on
+	r.add(3, []byte("three"))
+	// then
+	assert.False(t, r.isRecent([]byte("one")))
+	assert.True(t, r.isRecent([]byte("two")))
+	assert.True(t, r.isRecent([]byte("three")))
+	assert.False(t, r.isRecent([]byte("unknown")))
+	assert.Len(t, r.heights, 2)
+}
+
+func TestSignedSmartQuery(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	// the contract returns the query data that it received
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		return &wasmvmtypes.QueryResult{Ok: queryMsg}, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+
+	signerKey := secp256k1.GenPrivKey()
+	signerAddr := sdk.AccAddress(signerKey.PubKey().Address())
+	acc := keepers.AccountKeeper.NewAccountWithAddress(parentCtx, signerAddr)
+	require.NoError(t, acc.SetPubKey(signerKey.PubKey()))
+	keepers.AccountKeeper.SetAccount(parentCtx, acc)
+	noPubKeyAddr := RandomAccountAddress(t)
+	keepers.AccountKeeper.SetAccount(parentCtx, keepers.AccountKeeper.NewAccountWithAddress(parentCtx, noPubKeyAddr))
+
+	recentHash, staleHash := []byte("recent block hash"), []byte("stale block hash")
+	myQuery := []byte(`{"orders":{"limit":1}}`)
+	sign := func(queryData, blockHash []byte) []byte {
+		sig, err := signerKey.Sign(types.SignedQuerySignBytes(example.Contract, queryData, blockHash))
+		require.NoError(t, err)
+		return sig
+	}
+	signedReq := func(mutators ...func(r *types.QuerySmartContractStateRequest)) *types.QuerySmartContractStateRequest {
+		r := &types.QuerySmartContractStateRequest{
+			Address:   example.Contract.String(),
+			QueryData: myQuery,
+			Signer:    signerAddr.String(),
+			Signature: sign(myQuery, recentHash),
+			BlockHash: recentHash,
+		}
+		for _, m := range mutators {
+			m(r)
+		}
+		return r
+	}
+
+	specs := map[string]struct {
+		src      *types.QuerySmartContractStateRequest
+		disabled bool
+		execMode sdk.ExecMode
+		exp      string
+		expCode  codes.Code
+	}{
+		"signed query": {
+			src: signedReq(),
+			exp: `{"authenticated":{"sender":"` + signerAddr.String() + `","msg":{"orders":{"limit":1}}}}`,
+		},
+		"unsigned query": {
+			src: &types.QuerySmartContractStateRequest{Address: example.Contract.String(), QueryData: myQuery},
+			exp: string(myQuery),
+		},
+		"unsigned query with envelope key": {
+			src: &types.QuerySmartContractStateRequest{
+				Address:   example.Contract.String(),
+				QueryData: []byte(`{"authenticated":{"sender":"` + signerAddr.String() + `","msg":{}}}`),
+			},
+			expCode: codes.InvalidArgument,
+		},
+		"signed query of unit variant": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.QueryData = []byte(`"orders"`)
+				r.Signature = sign(r.QueryData, recentHash)
+			}),
+			exp: `{"authenticated":{"sender":"` + signerAddr.String() + `","msg":"orders"}}`,
+		},
+		"stale block hash": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.BlockHash = staleHash
+				r.Signature = sign(myQuery, staleHash)
+			}),
+			expCode: codes.Unauthenticated,
+		},
+		"unknown block hash": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.BlockHash = []byte("unknown")
+				r.Signature = sign(myQuery, r.BlockHash)
+			}),
+			expCode: codes.Unauthenticated,
+		},
+		"signature over other query data": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.Signature = sign([]byte(`{"other":{}}`), recentHash)
+			}),
+			expCode: codes.Unauthenticated,
+		},
+		"signature over other block hash": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.Signature = sign(myQuery, staleHash)
+			}),
+			expCode: codes.Unauthenticated,
+		},
+		"signature of other key": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				sig, err := secp256k1.GenPrivKey().Sign(types.SignedQuerySignBytes(example.Contract, myQuery, recentHash))
+				require.NoError(t, err)
+				r.Signature = sig
+			}),
+			expCode: codes.Unauthenticated,
+		},
+		"signer without public key": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.Signer = noPubKeyAddr.String()
+			}),
+			expCode: codes.Unauthenticated,
+		},
+		"unknown signer": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.Signer = RandomBech32AccountAddress(t)
+			}),
+			expCode: codes.Unauthenticated,
+		},
+		"invalid signer address": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.Signer = "invalid"
+			}),
+			expCode: codes.InvalidArgument,
+		},
+		"signature without block hash": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.BlockHash = nil
+			}),
+			expCode: codes.InvalidArgument,
+		},
+		"signer without signature": {
+			src: signedReq(func(r *types.QuerySmartContractStateRequest) {
+				r.Signature = nil
+			}),
+			expCode: codes.InvalidArgument,
+		},
+		"disabled on node": {
+			src:      signedReq(),
+			disabled: true,
+			expCode:  codes.Unauthenticated,
+		},
+		"in block execution": {
+			src:      signedReq(),
+			execMode: sdk.ExecModeFinalize,
+			expCode:  codes.Unauthenticated,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			k.recentBlockHashes = nil
+			if !spec.disabled {
+				k.recentBlockHashes = newRecentBlockHashes(2)
+			}
+			for height, hash := range [][]byte{staleHash, []byte("other"), recentHash} {
+				k.TrackBlockHash(parentCtx.WithBlockHeight(int64(height + 1)).WithHeaderHash(hash))
+			}
+			ctx, _ := parentCtx.WithExecMode(spec.execMode).CacheContext()
+
+			// when
+			got, gotErr := Querier(k).SmartContractState(ctx, spec.src)
+
+			// then
+			if spec.expCode != codes.OK {
+				require.Error(t, gotErr)
+				assert.Equal(t, spec.expCode, status.Code(gotErr), gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.exp, string(got.Data))
+		})
+	}
+}
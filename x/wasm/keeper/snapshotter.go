@@ -9,9 +9,11 @@ import (
 
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
+	"cosmossdk.io/store/prefix"
 	snapshot "cosmossdk.io/store/snapshots/types"
 	storetypes "cosmossdk.io/store/types"
 
+	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
@@ -21,6 +23,7 @@ import (
 var _ snapshot.ExtensionSnapshotter = &WasmSnapshotter{}
 
 // SnapshotFormat format 1 is just gzipped wasm byte code for each item payload. No protobuf envelope, no metadata.
+// The payloads can come in any order. The codes of pinned code ids are written first.
 const SnapshotFormat = 1
 
 type WasmSnapshotter struct {
@@ -55,6 +58,22 @@ func (ws *WasmSnapshotter) SnapshotExtension(height uint64, payloadWriter snapsh
 	}
 
 	ctx := sdk.NewContext(cacheMS, tmproto.Header{}, false, log.NewNopLogger())
+	pinned, err := pinnedChecksums(ctx, ws.wasm)
+	if err != nil {
+		return err
+	}
+	// pinned codes first, then all others. The contract state is part of the multistore items that are always
+	// restored before the extension payloads.
+	for _, pinnedPass := range []bool{true, false} {
+		if err := ws.writeCodes(ctx, payloadWriter, func(hexHash string) bool { return pinned[hexHash] == pinnedPass }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCodes writes the gzipped wasm code of every distinct checksum that matches the filter in code id order
+func (ws *WasmSnapshotter) writeCodes(ctx sdk.Context, payloadWriter snapshot.ExtensionPayloadWriter, filter func(hexHash string) bool) error {
 	seenBefore := make(map[string]bool)
 	var rerr error
 
@@ -62,7 +81,7 @@ func (ws *WasmSnapshotter) SnapshotExtension(height uint64, payloadWriter snapsh
 		// Many code ids may point to the same code hash... only sync it once
 		hexHash := hex.EncodeToString(info.CodeHash)
 		// if seenBefore, just skip this one and move to the next
-		if seenBefore[hexHash] {
+		if seenBefore[hexHash] || !filter(hexHash) {
 			return false
 		}
 		seenBefore[hexHash] = true
@@ -99,7 +118,26 @@ func (ws *WasmSnapshotter) RestoreExtension(height uint64, format uint32, payloa
 	return snapshot.ErrUnknownFormat
 }
 
-func restoreV1(_ sdk.Context, k *Keeper, compressedCode []byte) error {
+// pinnedChecksums returns the hex encoded checksums of all pinned code ids
+func pinnedChecksums(ctx sdk.Context, k *Keeper) (map[string]bool, error) {
+	result := make(map[string]bool)
+	store := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.PinnedCodeIndexPrefix)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		codeID := types.ParsePinnedCodeIndex(iter.Key())
+		codeInfo := k.GetCodeInfo(ctx, codeID)
+		if codeInfo == nil {
+			return nil, types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+		}
+		result[hex.EncodeToString(codeInfo.CodeHash)] = true
+	}
+	return result, nil
+}
+
+// restoreV1 stores the code in the VM. The code is pinned right away when it belongs to a pinned code id, so that
+// the VM cache warms while the remaining items are restored.
+func restoreV1(_ sdk.Context, k *Keeper, pinned map[string]bool, compressedCode []byte) error {
 	if !ioutils.IsGzip(compressedCode) {
 		return types.ErrInvalid.Wrap("not a gzip")
 	}
@@ -109,25 +147,36 @@ func restoreV1(_ sdk.Context, k *Keeper, compressedCode []byte) error {
 	}
 
 	// FIXME: check which codeIDs the checksum matches??
-	_, err = k.wasmVM.StoreCodeUnchecked(wasmCode)
+	checksum, err := k.wasmVM.StoreCodeUnchecked(wasmCode)
 	if err != nil {
 		return errorsmod.Wrap(types.ErrCreateFailed, err.Error())
 	}
+	if pinned[hex.EncodeToString(checksum)] {
+		if err := k.wasmVM.Pin(checksum); err != nil {
+			return errorsmod.Wrap(types.ErrPinContractFailed, err.Error())
+		}
+	}
 	return nil
 }
 
 func finalizeV1(ctx sdk.Context, k *Keeper) error {
 	// FIXME: ensure all codes have been uploaded?
+	// codes that were pinned during the restore are not pinned again by the VM
 	return k.InitializePinnedCodes(ctx)
 }
 
 func (ws *WasmSnapshotter) processAllItems(
 	height uint64,
 	payloadReader snapshot.ExtensionPayloadReader,
-	cb func(sdk.Context, *Keeper, []byte) error,
+	cb func(sdk.Context, *Keeper, map[string]bool, []byte) error,
 	finalize func(sdk.Context, *Keeper) error,
 ) error {
 	ctx := sdk.NewContext(ws.cms, tmproto.Header{Height: int64(height)}, false, log.NewNopLogger())
+	// the multistore items with the pinned code index are restored before the extension payloads
+	pinned, err := pinnedChecksums(ctx, ws.wasm)
+	if err != nil {
+		return err
+	}
 	for {
 		payload, err := payloadReader()
 		if err == io.EOF {
@@ -136,7 +185,7 @@ func (ws *WasmSnapshotter) processAllItems(
 			return err
 		}
 
-		if err := cb(ctx, ws.wasm, payload); err != nil {
+		if err := cb(ctx, ws.wasm, pinned, payload); err != nil {
 			return errorsmod.Wrap(err, "processing snapshot item")
 		}
 	}
@@ -0,0 +1,136 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"io"
+	"math"
+	"slices"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	snapshot "cosmossdk.io/store/snapshots/types"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+)
+
+func TestSnapshotPinnedCodesFirst(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	codes := make(map[string][]byte)
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	mock.StoreCodeFn = func(code wasmvm.WasmCode, gasLimit uint64) (wasmvm.Checksum, uint64, error) {
+		checksum, gas, err := wasmtesting.HashOnlyStoreCodeFn(code, gasLimit)
+		codes[hex.EncodeToString(checksum)] = code
+		return checksum, gas, err
+	}
+	mock.GetCodeFn = func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+		return codes[hex.EncodeToString(checksum)], nil
+	}
+	mock.PinFn = func(wasmvm.Checksum) error { return nil }
+	var checksums []string
+	for range 4 {
+		example := StoreRandomContract(t, ctx, keepers, &mock)
+		checksums = append(checksums, hex.EncodeToString(example.Checksum))
+	}
+	// pin the codes with the highest ids
+	require.NoError(t, k.pinCode(ctx, 3))
+	require.NoError(t, k.pinCode(ctx, 4))
+	height := uint64(ctx.BlockHeight())
+	ws := NewWasmSnapshotter(uncommittedMultiStore{ctx.MultiStore()}, k)
+
+	// when
+	var payloads [][]byte
+	err := ws.SnapshotExtension(height, func(payload []byte) error {
+		payloads = append(payloads, payload)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// then the pinned codes come first
+	var gotChecksums []string
+	for _, p := range payloads {
+		code, err := ioutils.Uncompress(p, math.MaxInt64)
+		require.NoError(t, err)
+		checksum, err := wasmvm.CreateChecksum(code)
+		require.NoError(t, err)
+		gotChecksums = append(gotChecksums, hex.EncodeToString(checksum))
+	}
+	assert.Equal(t, []string{checksums[2], checksums[3], checksums[0], checksums[1]}, gotChecksums)
+
+	specs := map[string]struct {
+		format              uint32
+		payloads            [][]byte
+		expPinnedBeforeLast bool
+		expErr              error
+	}{
+		"pinned codes first": {
+			format:              SnapshotFormat,
+			payloads:            payloads,
+			expPinnedBeforeLast: true,
+		},
+		"pinned codes last as in older snapshots": {
+			format:   SnapshotFormat,
+			payloads: append(slices.Clone(payloads[2:]), payloads[:2]...),
+		},
+		"unknown format": {
+			format:   SnapshotFormat + 1,
+			payloads: payloads,
+			expErr:   snapshot.ErrUnknownFormat,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			stored, pinned := make(map[string]bool), make(map[string]bool)
+			mock.StoreCodeUncheckedFn = func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+				checksum, err := wasmvm.CreateChecksum(code)
+				stored[hex.EncodeToString(checksum)] = true
+				return checksum, err
+			}
+			mock.PinFn = func(checksum wasmvm.Checksum) error {
+				require.True(t, stored[hex.EncodeToString(checksum)], "pinned before stored")
+				pinned[hex.EncodeToString(checksum)] = true
+				return nil
+			}
+			var pos int
+			payloadReader := func() ([]byte, error) {
+				if pos == len(spec.payloads) {
+					return nil, io.EOF
+				}
+				if pos == len(spec.payloads)-1 && spec.expPinnedBeforeLast {
+					// the pinned codes are usable before the final item
+					assert.Equal(t, map[string]bool{checksums[2]: true, checksums[3]: true}, pinned)
+				}
+				pos++
+				return spec.payloads[pos-1], nil
+			}
+
+			// when
+			gotErr := ws.RestoreExtension(height, spec.format, payloadReader)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Len(t, stored, len(checksums))
+			assert.Equal(t, map[string]bool{checksums[2]: true, checksums[3]: true}, pinned)
+		})
+	}
+}
+
+// uncommittedMultiStore reads all versions from the current state of the multistore. The keeper test input mounts
+// all stores on the same db, which can not be committed.
+type uncommittedMultiStore struct {
+	storetypes.MultiStore
+}
+
+func (m uncommittedMultiStore) CacheMultiStoreWithVersion(int64) (storetypes.CacheMultiStore, error) {
+	return m.CacheMultiStore(), nil
+}
@@ -0,0 +1,98 @@
+package keeper
+
+import (
+	"bytes"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// VersionedMultiStore provides read access to the state of previous heights. This is usually the commit multistore of the app.
+type VersionedMultiStore interface {
+	CacheMultiStoreWithVersion(version int64) (storetypes.CacheMultiStore, error)
+}
+
+// ContractStateDiff compares the contract state at two heights and returns the changed entries ordered by key,
+// starting at the start key. At most limit entries are returned together with the key of the next changed entry.
+// This requires the historical state of both heights on the node and is not meant for consensus code.
+func (k Keeper) ContractStateDiff(contractAddress sdk.AccAddress, heightA, heightB int64, startKey []byte, limit uint64, includeValues bool) ([]types.StateDiffEntry, []byte, error) {
+	storeA, err := k.contractStoreAt(contractAddress, heightA)
+	if err != nil {
+		return nil, nil, err
+	}
+	storeB, err := k.contractStoreAt(contractAddress, heightB)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, nextKey := diffKVStores(storeA, storeB, startKey, limit, includeValues)
+	return entries, nextKey, nil
+}
+
+// diffKVStores returns the changed entries between both stores ordered by key, starting at the start key.
+// At most limit entries are returned together with the key of the next changed entry.
+func diffKVStores(storeA, storeB storetypes.KVStore, startKey []byte, limit uint64, includeValues bool) ([]types.StateDiffEntry, []byte) {
+	iterA, iterB := storeA.Iterator(startKey, nil), storeB.Iterator(startKey, nil)
+	defer iterA.Close()
+	defer iterB.Close()
+
+	entries := make([]types.StateDiffEntry, 0)
+	for iterA.Valid() || iterB.Valid() {
+		var entry types.StateDiffEntry
+		switch {
+		case !iterB.Valid() || (iterA.Valid() && bytes.Compare(iterA.Key(), iterB.Key()) < 0):
+			entry = newStateDiffEntry(types.StateDiffTypeRemoved, iterA.Key(), iterA.Value(), nil, includeValues)
+			iterA.Next()
+		case !iterA.Valid() || bytes.Compare(iterA.Key(), iterB.Key()) > 0:
+			entry = newStateDiffEntry(types.StateDiffTypeAdded, iterB.Key(), nil, iterB.Value(), includeValues)
+			iterB.Next()
+		default:
+			changed := !bytes.Equal(iterA.Value(), iterB.Value())
+			if changed {
+				entry = newStateDiffEntry(types.StateDiffTypeChanged, iterA.Key(), iterA.Value(), iterB.Value(), includeValues)
+			}
+			iterA.Next()
+			iterB.Next()
+			if !changed {
+				continue
+			}
+		}
+		if uint64(len(entries)) == limit {
+			return entries, entry.Key
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func newStateDiffEntry(diffType types.StateDiffType, key, valueA, valueB []byte, includeValues bool) types.StateDiffEntry {
+	entry := types.StateDiffEntry{
+		Key:   bytes.Clone(key),
+		Type:  diffType,
+		SizeA: uint64(len(valueA)),
+		SizeB: uint64(len(valueB)),
+	}
+	if includeValues {
+		entry.ValueA, entry.ValueB = bytes.Clone(valueA), bytes.Clone(valueB)
+	}
+	return entry
+}
+
+// contractStoreAt returns the read only contract prefix store at the given height
+func (k Keeper) contractStoreAt(contractAddress sdk.AccAddress, height int64) (storetypes.KVStore, error) {
+	if k.versionedStore == nil {
+		return nil, errorsmod.Wrap(types.ErrNotFound, "historical state not available on this node")
+	}
+	if height <= 0 {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "height %d", height)
+	}
+	ms, err := k.versionedStore.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "state at height %d not available on this node: %s", height, err)
+	}
+	return prefix.NewStore(ms.GetKVStore(k.versionedStoreKey), types.GetContractStorePrefix(contractAddress)), nil
+}
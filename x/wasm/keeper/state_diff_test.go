@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store"
+	storemetrics "cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractStateDiff(t *testing.T) {
+	// setup an in-memory multistore with two versions of the contract state
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ms := store.NewCommitMultiStore(dbm.NewMemDB(), log.NewNopLogger(), storemetrics.NewNoOpMetrics())
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	contractAddr := RandomAccountAddress(t)
+	otherContractAddr := RandomAccountAddress(t)
+	contractStore := func() storetypes.KVStore {
+		return prefix.NewStore(ms.GetKVStore(storeKey), types.GetContractStorePrefix(contractAddr))
+	}
+	// version 1
+	contractStore().Set([]byte("a"), []byte("1"))
+	contractStore().Set([]byte("b"), []byte("2"))
+	contractStore().Set([]byte("c"), []byte("3"))
+	prefix.NewStore(ms.GetKVStore(storeKey), types.GetContractStorePrefix(otherContractAddr)).Set([]byte("x"), []byte("1"))
+	ms.Commit()
+	// version 2
+	contractStore().Delete([]byte("a"))
+	contractStore().Set([]byte("b"), []byte("22"))
+	contractStore().Set([]byte("d"), []byte("4444"))
+	prefix.NewStore(ms.GetKVStore(storeKey), types.GetContractStorePrefix(otherContractAddr)).Set([]byte("x"), []byte("2"))
+	ms.Commit()
+
+	_, keepers := CreateTestInput(t, false, AvailableCapabilities, WithVersionedMultiStore(ms, storeKey))
+	k := keepers.WasmKeeper
+
+	allEntries := []types.StateDiffEntry{
+		{Key: []byte("a"), Type: types.StateDiffTypeRemoved, SizeA: 1},
+		{Key: []byte("b"), Type: types.StateDiffTypeChanged, SizeA: 1, SizeB: 2},
+		{Key: []byte("d"), Type: types.StateDiffTypeAdded, SizeB: 4},
+	}
+	specs := map[string]struct {
+		heightA, heightB int64
+		startKey         []byte
+		limit            uint64
+		includeValues    bool
+		exp              []types.StateDiffEntry
+		expNextKey       []byte
+		expErr           error
+	}{
+		"all changes": {
+			heightA: 1, heightB: 2, limit: 10,
+			exp: allEntries,
+		},
+		"reverse order": {
+			heightA: 2, heightB: 1, limit: 10,
+			exp: []types.StateDiffEntry{
+				{Key: []byte("a"), Type: types.StateDiffTypeAdded, SizeB: 1},
+				{Key: []byte("b"), Type: types.StateDiffTypeChanged, SizeA: 2, SizeB: 1},
+				{Key: []byte("d"), Type: types.StateDiffTypeRemoved, SizeA: 4},
+			},
+		},
+		"with values": {
+			heightA: 1, heightB: 2, limit: 10, includeValues: true,
+			exp: []types.StateDiffEntry{
+				{Key: []byte("a"), Type: types.StateDiffTypeRemoved, SizeA: 1, ValueA: []byte("1")},
+				{Key: []byte("b"), Type: types.StateDiffTypeChanged, SizeA: 1, SizeB: 2, ValueA: []byte("2"), ValueB: []byte("22")},
+				{Key: []byte("d"), Type: types.StateDiffTypeAdded, SizeB: 4, ValueB: []byte("4444")},
+			},
+		},
+		"first page": {
+			heightA: 1, heightB: 2, limit: 2,
+			exp:        allEntries[:2],
+			expNextKey: []byte("d"),
+		},
+		"next page": {
+			heightA: 1, heightB: 2, limit: 2, startKey: []byte("d"),
+			exp: allEntries[2:],
+		},
+		"same height": {
+			heightA: 2, heightB: 2, limit: 10,
+			exp: []types.StateDiffEntry{},
+		},
+		"height not available": {
+			heightA: 1, heightB: 3, limit: 10,
+			expErr: types.ErrNotFound,
+		},
+		"invalid height": {
+			heightA: 0, heightB: 2, limit: 10,
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotNextKey, gotErr := k.ContractStateDiff(contractAddr, spec.heightA, spec.heightB, spec.startKey, spec.limit, spec.includeValues)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+			assert.Equal(t, spec.expNextKey, gotNextKey)
+		})
+	}
+}
+
+func TestQueryContractStateDiff(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ms := store.NewCommitMultiStore(dbm.NewMemDB(), log.NewNopLogger(), storemetrics.NewNoOpMetrics())
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	contractAddr := RandomAccountAddress(t)
+	ms.Commit()
+	prefix.NewStore(ms.GetKVStore(storeKey), types.GetContractStorePrefix(contractAddr)).Set([]byte("a"), []byte("1"))
+	ms.Commit()
+
+	specs := map[string]struct {
+		opts   []Option
+		src    *types.QueryContractStateDiffRequest
+		exp    []types.StateDiffEntry
+		expErr bool
+	}{
+		"query": {
+			opts: []Option{WithVersionedMultiStore(ms, storeKey)},
+			src:  &types.QueryContractStateDiffRequest{Address: contractAddr.String(), HeightA: 1, HeightB: 2},
+			exp:  []types.StateDiffEntry{{Key: []byte("a"), Type: types.StateDiffTypeAdded, SizeB: 1}},
+		},
+		"offset pagination": {
+			opts:   []Option{WithVersionedMultiStore(ms, storeKey)},
+			src:    &types.QueryContractStateDiffRequest{Address: contractAddr.String(), HeightA: 1, HeightB: 2, Pagination: &query.PageRequest{Offset: 1}},
+			expErr: true,
+		},
+		"no historical state": {
+			src:    &types.QueryContractStateDiffRequest{Address: contractAddr.String(), HeightA: 1, HeightB: 2},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, spec.opts...)
+			got, gotErr := Querier(keepers.WasmKeeper).ContractStateDiff(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got.Entries)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"sync"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// DefaultStateWatchBufferSize is the default number of state changes that are buffered for a subscriber.
+// Subscribers that fall behind by more are dropped.
+const DefaultStateWatchBufferSize = 1024
+
+var _ storetypes.ABCIListener = &StateWatcher{}
+
+// StateWatcher is a node local store listener that passes the writes to contract state on to subscribers
+// after every committed block. It is not part of consensus. Delivery is at most once: a subscriber
+// that can not keep up is dropped and must subscribe again.
+type StateWatcher struct {
+	versionedStore VersionedMultiStore
+	storeKey       storetypes.StoreKey
+	bufferSize     int
+
+	mu     sync.Mutex
+	nextID uint64
+	subs   map[uint64]*stateSubscription
+}
+
+type stateSubscription struct {
+	// prefix is the full store key prefix of the contract state to watch
+	prefix []byte
+	// contractPrefixLen is the length of the contract store prefix that is removed from the keys
+	contractPrefixLen int
+	ch                chan *types.WatchContractStateResponse
+}
+
+// NewStateWatcher constructor. The versioned store and store key are used to read the previous value of a
+// changed key. The store must be registered for listening on the commit multistore.
+func NewStateWatcher(versionedStore VersionedMultiStore, storeKey storetypes.StoreKey, bufferSize int) *StateWatcher {
+	if bufferSize <= 0 {
+		panic("buffer size must be positive")
+	}
+	return &StateWatcher{
+		versionedStore: versionedStore,
+		storeKey:       storeKey,
+		bufferSize:     bufferSize,
+		subs:           make(map[uint64]*stateSubscription),
+	}
+}
+
+// Subscribe registers a subscriber for writes to the state of the contract with the optional key prefix.
+// The returned channel is closed when the subscriber was dropped because it fell behind. The cancel function
+// must be called when the subscriber is done.
+func (w *StateWatcher) Subscribe(contractAddr sdk.AccAddress, keyPrefix []byte) (<-chan *types.WatchContractStateResponse, func()) {
+	contractPrefix := types.GetContractStorePrefix(contractAddr)
+	sub := &stateSubscription{
+		prefix:            append(bytes.Clone(contractPrefix), keyPrefix...),
+		contractPrefixLen: len(contractPrefix),
+		ch:                make(chan *types.WatchContractStateResponse, w.bufferSize),
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = sub
+	return sub.ch, func() { w.unsubscribe(id) }
+}
+
+func (w *StateWatcher) unsubscribe(id uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if sub, ok := w.subs[id]; ok {
+		delete(w.subs, id)
+		close(sub.ch)
+	}
+}
+
+// ListenFinalizeBlock is a no-op. The state changes are only available on commit.
+func (w *StateWatcher) ListenFinalizeBlock(context.Context, abci.RequestFinalizeBlock, abci.ResponseFinalizeBlock) error {
+	return nil
+}
+
+// ListenCommit passes the writes to contract state of the committed block on to the subscribers.
+// Subscribers with a full buffer are dropped so that a slow client never blocks the node.
+func (w *StateWatcher) ListenCommit(ctx context.Context, _ abci.ResponseCommit, changeSet []*storetypes.StoreKVPair) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.subs) == 0 {
+		return nil
+	}
+	height := sdk.UnwrapSDKContext(ctx).BlockHeight()
+	changes := w.contractStateChanges(changeSet)
+	if len(changes) == 0 {
+		return nil
+	}
+	prevStore := w.storeAt(height - 1)
+	oldValueHashes := make(map[string][]byte)
+	for id, sub := range w.subs {
+		for _, c := range changes {
+			if !bytes.HasPrefix(c.Key, sub.prefix) {
+				continue
+			}
+			oldHash, ok := oldValueHashes[string(c.Key)]
+			if !ok {
+				if prevStore != nil {
+					if v := prevStore.Get(c.Key); v != nil {
+						h := sha256.Sum256(v)
+						oldHash = h[:]
+					}
+				}
+				oldValueHashes[string(c.Key)] = oldHash
+			}
+			e := &types.WatchContractStateResponse{
+				Height:       height,
+				Key:          bytes.Clone(c.Key[sub.contractPrefixLen:]),
+				OldValueHash: oldHash,
+				NewValue:     c.Value,
+				Deleted:      c.Delete,
+			}
+			select {
+			case sub.ch <- e:
+				continue
+			default:
+			}
+			// drop the subscriber when it can not keep up
+			delete(w.subs, id)
+			close(sub.ch)
+			break
+		}
+	}
+	return nil
+}
+
+// contractStateChanges returns the last write for every contract state key in the order of the first write
+func (w *StateWatcher) contractStateChanges(changeSet []*storetypes.StoreKVPair) []*storetypes.StoreKVPair {
+	pos := make(map[string]int)
+	result := make([]*storetypes.StoreKVPair, 0)
+	for _, c := range changeSet {
+		if c.StoreKey != w.storeKey.Name() || !bytes.HasPrefix(c.Key, types.ContractStorePrefix) {
+			continue
+		}
+		if i, ok := pos[string(c.Key)]; ok {
+			result[i] = c
+			continue
+		}
+		pos[string(c.Key)] = len(result)
+		result = append(result, c)
+	}
+	return result
+}
+
+// storeAt returns the wasm store at the given height or nil when the state is not available, for example due to pruning
+func (w *StateWatcher) storeAt(height int64) storetypes.KVStore {
+	if height <= 0 {
+		return nil
+	}
+	ms, err := w.versionedStore.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return nil
+	}
+	return ms.GetKVStore(w.storeKey)
+}
+
+var _ types.StateWatchServer = &StateWatchServer{}
+
+// StateWatchServer implements the node local gRPC service to stream contract state changes
+type StateWatchServer struct {
+	watcher *StateWatcher
+}
+
+// NewStateWatchServer constructor
+func NewStateWatchServer(watcher *StateWatcher) *StateWatchServer {
+	return &StateWatchServer{watcher: watcher}
+}
+
+// WatchContractState streams the writes to the contract state until the client disconnects.
+// A client that falls behind receives a ResourceExhausted error.
+func (s StateWatchServer) WatchContractState(req *types.WatchContractStateRequest, stream types.StateWatch_WatchContractStateServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ch, cancel := s.watcher.Subscribe(contractAddr, req.KeyPrefix)
+	defer cancel()
+	// the header signals the client that the subscription is active
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case e, ok := <-ch:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber fell behind, state changes were dropped")
+			}
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
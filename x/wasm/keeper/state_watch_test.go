@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store"
+	storemetrics "cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestStateWatcher(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ms := store.NewCommitMultiStore(dbm.NewMemDB(), log.NewNopLogger(), storemetrics.NewNoOpMetrics())
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	ms.AddListeners([]storetypes.StoreKey{storeKey})
+	require.NoError(t, ms.LoadLatestVersion())
+
+	contractAddr := RandomAccountAddress(t)
+	otherContractAddr := RandomAccountAddress(t)
+	contractStore := func(addr sdk.AccAddress) storetypes.KVStore {
+		return prefix.NewStore(ms.GetKVStore(storeKey), types.GetContractStorePrefix(addr))
+	}
+	commit := func(w *StateWatcher) {
+		cid := ms.Commit()
+		ctx := sdk.NewContext(ms, cmtproto.Header{Height: cid.Version}, false, log.NewNopLogger())
+		require.NoError(t, w.ListenCommit(ctx, abci.ResponseCommit{}, ms.PopStateCache()))
+	}
+	watcher := NewStateWatcher(ms, storeKey, 10)
+	// version 1 without subscribers
+	contractStore(contractAddr).Set([]byte("a"), []byte("1"))
+	contractStore(contractAddr).Set([]byte("b"), []byte("2"))
+	commit(watcher)
+
+	allCh, cancelAll := watcher.Subscribe(contractAddr, nil)
+	defer cancelAll()
+	prefixCh, cancelPrefix := watcher.Subscribe(contractAddr, []byte("b"))
+	defer cancelPrefix()
+
+	// version 2
+	contractStore(contractAddr).Delete([]byte("a"))
+	contractStore(contractAddr).Set([]byte("b"), []byte("22"))
+	contractStore(contractAddr).Set([]byte("b"), []byte("222"))
+	contractStore(contractAddr).Set([]byte("c"), []byte("3"))
+	contractStore(otherContractAddr).Set([]byte("a"), []byte("1"))
+	commit(watcher)
+
+	oldA, oldB := sha256.Sum256([]byte("1")), sha256.Sum256([]byte("2"))
+	exp := []*types.WatchContractStateResponse{
+		{Height: 2, Key: []byte("a"), OldValueHash: oldA[:], Deleted: true},
+		{Height: 2, Key: []byte("b"), OldValueHash: oldB[:], NewValue: []byte("222")},
+		{Height: 2, Key: []byte("c"), NewValue: []byte("3")},
+	}
+	assert.Equal(t, exp, drain(allCh))
+	assert.Equal(t, exp[1:2], drain(prefixCh))
+
+	// when a subscriber falls behind
+	slowWatcher := NewStateWatcher(ms, storeKey, 1)
+	slowCh, cancelSlow := slowWatcher.Subscribe(contractAddr, nil)
+	contractStore(contractAddr).Set([]byte("a"), []byte("11"))
+	contractStore(contractAddr).Set([]byte("b"), []byte("22"))
+	commit(slowWatcher)
+
+	// then it is dropped after the buffered events
+	require.Len(t, slowCh, 1)
+	<-slowCh
+	_, open := <-slowCh
+	assert.False(t, open)
+	assert.Empty(t, slowWatcher.subs)
+	cancelSlow() // no panic
+
+	// and unsubscribed channels are closed
+	cancelPrefix()
+	_, open = <-prefixCh
+	assert.False(t, open)
+	assert.Len(t, watcher.subs, 1)
+}
+
+func drain(ch <-chan *types.WatchContractStateResponse) []*types.WatchContractStateResponse {
+	var result []*types.WatchContractStateResponse
+	for {
+		select {
+		case e := <-ch:
+			result = append(result, e)
+		default:
+			return result
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	// DefaultStoreWriteLogMaxFileSize is the default size in bytes after which the store write log file is rotated
+	DefaultStoreWriteLogMaxFileSize = 64 << 20
+	// DefaultStoreWriteLogMaxBackups is the default number of rotated store write log files that are kept
+	DefaultStoreWriteLogMaxBackups = 10
+
+	storeWriteLogFileName = "store_writes.jsonl"
+)
+
+// StoreWriteLog receives the contract store writes and deletes that are performed in FinalizeBlock.
+// It is a node local debug facility for consensus failure forensics and must not fail or block the caller.
+type StoreWriteLog interface {
+	LogStoreWrite(entry StoreWriteLogEntry)
+}
+
+// StoreWriteLogEntry is a single contract store write or delete
+type StoreWriteLogEntry struct {
+	Height int64 `json:"height"`
+	// TxIndex is not set for writes outside of a transaction, like in begin or end block
+	TxIndex  *uint32 `json:"tx_index,omitempty"`
+	Contract string  `json:"contract"`
+	// Op is either "set" or "delete"
+	Op string `json:"op"`
+	// KeyHash is the hex encoded sha256 of the key in the contract store
+	KeyHash string `json:"key_hash"`
+	// ValueHash is the hex encoded sha256 of the value. Empty for a delete.
+	ValueHash string `json:"value_hash,omitempty"`
+}
+
+var _ wasmvm.KVStore = &writeLoggingStore{}
+
+// writeLoggingStore passes all operations on to the parent store and logs the writes and deletes.
+// It does not read from the parent store so that the gas consumption is not modified.
+type writeLoggingStore struct {
+	wasmvm.KVStore
+	log      StoreWriteLog
+	height   int64
+	txIndex  *uint32
+	contract string
+}
+
+func newWriteLoggingStore(parent wasmvm.KVStore, l StoreWriteLog, ctx sdk.Context, contractAddr sdk.AccAddress) *writeLoggingStore {
+	s := &writeLoggingStore{
+		KVStore:  parent,
+		log:      l,
+		height:   ctx.BlockHeight(),
+		contract: contractAddr.String(),
+	}
+	if txIndex, ok := types.TXCounter(ctx); ok {
+		s.txIndex = &txIndex
+	}
+	return s
+}
+
+func (s writeLoggingStore) Set(key, value []byte) {
+	s.KVStore.Set(key, value)
+	s.log.LogStoreWrite(s.entry("set", key, value))
+}
+
+func (s writeLoggingStore) Delete(key []byte) {
+	s.KVStore.Delete(key)
+	s.log.LogStoreWrite(s.entry("delete", key, nil))
+}
+
+func (s writeLoggingStore) entry(op string, key, value []byte) StoreWriteLogEntry {
+	e := StoreWriteLogEntry{
+		Height:   s.height,
+		TxIndex:  s.txIndex,
+		Contract: s.contract,
+		Op:       op,
+		KeyHash:  sha256Hex(key),
+	}
+	if value != nil {
+		e.ValueHash = sha256Hex(value)
+	}
+	return e
+}
+
+func sha256Hex(bz []byte) string {
+	h := sha256.Sum256(bz)
+	return hex.EncodeToString(h[:])
+}
+
+var _ StoreWriteLog = &FileStoreWriteLog{}
+
+// FileStoreWriteLog writes the entries as JSON lines to a file in a directory. The file is rotated when
+// it exceeds the max size and only the configured number of rotated files are kept. Errors are logged and
+// the entry is dropped so that the block execution is never affected.
+type FileStoreWriteLog struct {
+	dir         string
+	maxFileSize int64
+	maxBackups  int
+	logger      log.Logger
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileStoreWriteLog constructor. The directory is created when it does not exist and an existing
+// log file is appended to. A max backups of 0 keeps all rotated files.
+func NewFileStoreWriteLog(dir string, maxFileSize int64, maxBackups int, logger log.Logger) (*FileStoreWriteLog, error) {
+	if maxFileSize <= 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "max file size must be positive")
+	}
+	if maxBackups < 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "max backups must not be negative")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, errorsmod.Wrap(err, "store write log dir")
+	}
+	l := &FileStoreWriteLog{dir: dir, maxFileSize: maxFileSize, maxBackups: maxBackups, logger: logger}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// LogStoreWrite appends the entry to the log file
+func (l *FileStoreWriteLog) LogStoreWrite(entry StoreWriteLogEntry) {
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		l.logger.Error("store write log entry", "err", err)
+		return
+	}
+	bz = append(bz, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return // closed
+	}
+	if l.size > 0 && l.size+int64(len(bz)) > l.maxFileSize {
+		if err := l.rotate(); err != nil {
+			l.logger.Error("store write log rotation", "err", err)
+			return
+		}
+	}
+	n, err := l.file.Write(bz)
+	l.size += int64(n)
+	if err != nil {
+		l.logger.Error("store write log", "err", err)
+	}
+}
+
+// Close closes the log file. Entries logged afterwards are dropped.
+func (l *FileStoreWriteLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *FileStoreWriteLog) open() error {
+	f, err := os.OpenFile(filepath.Join(l.dir, storeWriteLogFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		return errorsmod.Wrap(err, "store write log file")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return errorsmod.Wrap(err, "store write log file")
+	}
+	l.file, l.size = f, info.Size()
+	return nil
+}
+
+// rotate renames the current file with a timestamp suffix, opens a new one and removes the oldest
+// rotated files above the max backups
+func (l *FileStoreWriteLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+	rotated := filepath.Join(l.dir, fmt.Sprintf("store_writes-%020d.jsonl", time.Now().UnixNano()))
+	if err := os.Rename(filepath.Join(l.dir, storeWriteLogFileName), rotated); err != nil {
+		_ = l.open() // continue with the current file
+		return err
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+	if l.maxBackups == 0 {
+		return nil
+	}
+	backups, err := filepath.Glob(filepath.Join(l.dir, "store_writes-*.jsonl"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(backups) // fixed width timestamps sort by age
+	for i := 0; i < len(backups)-l.maxBackups; i++ {
+		if err := os.Remove(backups[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
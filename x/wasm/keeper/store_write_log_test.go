@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestStoreWriteLog(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	// scripted contract execution
+	mock.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, _ []byte, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		store.Set([]byte("a"), []byte("1"))
+		_ = store.Get([]byte("a"))
+		store.Delete([]byte("b"))
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1, nil
+	}
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	parentCtx = types.WithTXCounter(parentCtx.WithBlockHeight(5), 2)
+
+	execute := func(ctx sdk.Context, writeLog StoreWriteLog) storetypes.Gas {
+		k.storeWriteLog = writeLog
+		t.Cleanup(func() { k.storeWriteLog = nil })
+		ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+		require.NoError(t, err)
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	// when executed in a block
+	ctx, _ := parentCtx.WithExecMode(sdk.ExecModeFinalize).CacheContext()
+	var capture storeWriteLogRecorder
+	gasWithLog := execute(ctx, &capture)
+
+	// then
+	txIndex := uint32(2)
+	assert.Equal(t, []StoreWriteLogEntry{
+		{Height: 5, TxIndex: &txIndex, Contract: example.Contract.String(), Op: "set", KeyHash: sha256Hex([]byte("a")), ValueHash: sha256Hex([]byte("1"))},
+		{Height: 5, TxIndex: &txIndex, Contract: example.Contract.String(), Op: "delete", KeyHash: sha256Hex([]byte("b"))},
+	}, capture.entries)
+
+	// and gas is the same without the log
+	ctx, _ = parentCtx.WithExecMode(sdk.ExecModeFinalize).CacheContext()
+	gasWithoutLog := execute(ctx, nil)
+	assert.Equal(t, gasWithoutLog, gasWithLog)
+
+	// when executed outside of a block
+	for _, mode := range []sdk.ExecMode{sdk.ExecModeCheck, sdk.ExecModeSimulate} {
+		ctx, _ := parentCtx.WithExecMode(mode).CacheContext()
+		var capture storeWriteLogRecorder
+		execute(ctx, &capture)
+		// then
+		assert.Empty(t, capture.entries, mode)
+	}
+}
+
+func TestFileStoreWriteLog(t *testing.T) {
+	dir := t.TempDir()
+	txIndex := uint32(1)
+	myEntry := StoreWriteLogEntry{Height: 1, TxIndex: &txIndex, Contract: "myContract", Op: "set", KeyHash: sha256Hex([]byte("a")), ValueHash: sha256Hex([]byte("1"))}
+	entryBz, err := json.Marshal(myEntry)
+	require.NoError(t, err)
+	entrySize := int64(len(entryBz) + 1)
+
+	// with room for 2 entries per file and 1 backup
+	writeLog, err := NewFileStoreWriteLog(dir, 2*entrySize, 1, log.NewTestLogger(t))
+	require.NoError(t, err)
+
+	// when
+	for i := 0; i < 7; i++ {
+		writeLog.LogStoreWrite(myEntry)
+	}
+	require.NoError(t, writeLog.Close())
+
+	// then the current file and 1 backup are kept
+	assert.Equal(t, []StoreWriteLogEntry{myEntry}, readStoreWriteLog(t, filepath.Join(dir, storeWriteLogFileName)))
+	backups, err := filepath.Glob(filepath.Join(dir, "store_writes-*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, []StoreWriteLogEntry{myEntry, myEntry}, readStoreWriteLog(t, backups[0]))
+
+	// when re-opened then appended to the existing file
+	writeLog, err = NewFileStoreWriteLog(dir, 2*entrySize, 1, log.NewTestLogger(t))
+	require.NoError(t, err)
+	writeLog.LogStoreWrite(myEntry)
+	require.NoError(t, writeLog.Close())
+	assert.Len(t, readStoreWriteLog(t, filepath.Join(dir, storeWriteLogFileName)), 2)
+
+	// and closed log drops entries
+	writeLog.LogStoreWrite(myEntry)
+	assert.Len(t, readStoreWriteLog(t, filepath.Join(dir, storeWriteLogFileName)), 2)
+}
+
+func readStoreWriteLog(t *testing.T, file string) []StoreWriteLogEntry {
+	t.Helper()
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+	var r []StoreWriteLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e StoreWriteLogEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		r = append(r, e)
+	}
+	require.NoError(t, scanner.Err())
+	return r
+}
+
+type storeWriteLogRecorder struct {
+	entries []StoreWriteLogEntry
+}
+
+func (r *storeWriteLogRecorder) LogStoreWrite(entry StoreWriteLogEntry) {
+	r.entries = append(r.entries, entry)
+}
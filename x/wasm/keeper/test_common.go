@@ -210,6 +210,12 @@ func CreateTestInput(t testing.TB, isCheckTx bool, availableCapabilities []strin
 	return createTestInput(t, isCheckTx, availableCapabilities, types.DefaultNodeConfig(), types.VMConfig{}, dbm.NewMemDB(), opts...)
 }
 
+// CreateTestInputWithVM is like CreateTestInput but uses the given wasm engine and does not load the wasmvm
+// library. Combined with the wasmtesting.MockWasmVM, tests run without cgo.
+func CreateTestInputWithVM(t testing.TB, isCheckTx bool, availableCapabilities []string, vm types.WasmEngine, opts ...Option) (sdk.Context, TestKeepers) {
+	return createTestInputWithVM(t, isCheckTx, availableCapabilities, types.DefaultNodeConfig(), types.VMConfig{}, dbm.NewMemDB(), vm, opts...)
+}
+
 // encoders can be nil to accept the defaults, or set it to override some of the message handlers (like default)
 func createTestInput(
 	t testing.TB,
@@ -219,6 +225,20 @@ func createTestInput(
 	vmConfig types.VMConfig,
 	db dbm.DB,
 	opts ...Option,
+) (sdk.Context, TestKeepers) {
+	return createTestInputWithVM(t, isCheckTx, availableCapabilities, nodeConfig, vmConfig, db, nil, opts...)
+}
+
+// createTestInputWithVM sets up the keepers. The wasmvm library is loaded unless a wasm engine is given.
+func createTestInputWithVM(
+	t testing.TB,
+	isCheckTx bool,
+	availableCapabilities []string,
+	nodeConfig types.NodeConfig,
+	vmConfig types.VMConfig,
+	db dbm.DB,
+	vm types.WasmEngine,
+	opts ...Option,
 ) (sdk.Context, TestKeepers) {
 	tempDir := t.TempDir()
 
@@ -235,7 +255,7 @@ func createTestInput(
 	for _, v := range keys {
 		ms.MountStoreWithDB(v, storetypes.StoreTypeIAVL, db)
 	}
-	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey, types.TStoreKey)
 	for _, v := range tkeys {
 		ms.MountStoreWithDB(v, storetypes.StoreTypeTransient, db)
 	}
@@ -370,25 +390,49 @@ func createTestInput(
 	cfg := sdk.GetConfig()
 	cfg.SetAddressVerifier(types.VerifyAddressLen())
 
-	keeper := NewKeeper(
-		appCodec,
-		runtime.NewKVStoreService(keys[types.StoreKey]),
-		accountKeeper,
-		bankKeeper,
-		stakingKeeper,
-		distributionkeeper.NewQuerier(distKeeper),
-		ibcKeeper.ChannelKeeper, // ICS4Wrapper
-		ibcKeeper.ChannelKeeper,
-		wasmtesting.MockIBCTransferKeeper{},
-		msgRouter,
-		querier,
-		tempDir,
-		nodeConfig,
-		vmConfig,
-		availableCapabilities,
-		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
-		opts...,
-	)
+	opts = append([]Option{WithTransientStoreService(runtime.NewTransientStoreService(tkeys[types.TStoreKey]))}, opts...)
+	var keeper Keeper
+	if vm != nil {
+		keeper = NewKeeperWithVM(
+			appCodec,
+			runtime.NewKVStoreService(keys[types.StoreKey]),
+			accountKeeper,
+			bankKeeper,
+			stakingKeeper,
+			distributionkeeper.NewQuerier(distKeeper),
+			ibcKeeper.ChannelKeeper, // ICS4Wrapper
+			ibcKeeper.ChannelKeeper,
+			wasmtesting.MockIBCTransferKeeper{},
+			msgRouter,
+			querier,
+			vm,
+			nodeConfig,
+			vmConfig,
+			availableCapabilities,
+			authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+			opts...,
+		)
+	} else {
+		keeper = NewKeeper(
+			appCodec,
+			runtime.NewKVStoreService(keys[types.StoreKey]),
+			accountKeeper,
+			bankKeeper,
+			stakingKeeper,
+			distributionkeeper.NewQuerier(distKeeper),
+			ibcKeeper.ChannelKeeper, // ICS4Wrapper
+			ibcKeeper.ChannelKeeper,
+			wasmtesting.MockIBCTransferKeeper{},
+			msgRouter,
+			querier,
+			tempDir,
+			nodeConfig,
+			vmConfig,
+			availableCapabilities,
+			authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+			opts...,
+		)
+	}
 	require.NoError(t, keeper.SetParams(ctx, types.DefaultParams()))
 
 	// add wasm handler so we can loop-back (contracts calling contracts)
@@ -35,6 +35,11 @@ func FuzzContractInfo(m *types.ContractInfo, c fuzz.Continue) {
 	FuzzAddrString(&m.Admin, c)
 	m.Label = c.RandString()
 	c.Fuzz(&m.Created)
+	for _, tag := range []string{"defi", "deprecated", "prod"} { // sorted
+		if c.RandBool() {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
 }
 
 func FuzzContractCodeHistory(m *types.ContractCodeHistoryEntry, c fuzz.Continue) {
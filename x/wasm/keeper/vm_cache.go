@@ -0,0 +1,167 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// CodeBlobSource provides the original wasm code for a checksum
+type CodeBlobSource interface {
+	GetCodeBlob(ctx context.Context, checksum []byte) ([]byte, error)
+}
+
+// ValidateVMCache ensures that the wasm code of every stored code info is available in the VM cache.
+// The cache directory may come from a different network or be incomplete which would otherwise only
+// surface mid-block. Missing entries are re-persisted from the CodeBlobSource when one is configured.
+// In strict mode any entry that can not be healed is returned as an error so that the node refuses to start.
+func (k Keeper) ValidateVMCache(ctx context.Context, strict bool) error {
+	var (
+		missing, healed int
+		failed          []string
+	)
+	logger := moduleLogger(sdk.UnwrapSDKContext(ctx))
+	seen := make(map[string]struct{})
+	k.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		if _, ok := seen[string(info.CodeHash)]; ok {
+			return false
+		}
+		seen[string(info.CodeHash)] = struct{}{}
+		if _, err := k.wasmVM.GetCode(info.CodeHash); err == nil {
+			return false
+		}
+		missing++
+		if err := k.healVMCache(ctx, info.CodeHash); err != nil {
+			logger.Error("can not heal wasm cache entry", "code_id", codeID, "checksum", hex.EncodeToString(info.CodeHash), "err", err)
+			failed = append(failed, hex.EncodeToString(info.CodeHash))
+			return false
+		}
+		healed++
+		return false
+	})
+	if missing == 0 {
+		return nil
+	}
+	logger.Info("wasm cache entries missing", "missing", missing, "healed", healed)
+	if strict && len(failed) != 0 {
+		return errorsmod.Wrapf(types.ErrNotFound, "wasm cache entries: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (k Keeper) healVMCache(ctx context.Context, checksum []byte) error {
+	if k.codeBlobSource == nil {
+		return errorsmod.Wrap(types.ErrNotFound, "no code blob source")
+	}
+	code, err := k.codeBlobSource.GetCodeBlob(ctx, checksum)
+	if err != nil {
+		return err
+	}
+	got, err := k.wasmVM.StoreCodeUnchecked(code)
+	if err != nil {
+		return errorsmod.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	if !bytes.Equal(checksum, got) {
+		return errorsmod.Wrapf(types.ErrInvalid, "checksum mismatch: %X", got)
+	}
+	return nil
+}
+
+// PrecompileCodes compiles the wasm code of all stored codes again so that the first execution of a code
+// does not pay the compile cost, for example after the compiled modules in the wasm cache were wiped.
+// The codes are compiled by the given number of concurrent workers, or the number of CPUs when not positive.
+// It waits up to the time budget and continues in the background afterwards. Only the VM cache is
+// touched so that it never interferes with consensus and can be interrupted at any time. Cancel the
+// context to stop the workers. The returned channel is closed when all workers have stopped.
+func (k Keeper) PrecompileCodes(ctx context.Context, workers int, budget time.Duration) <-chan struct{} {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	logger := moduleLogger(sdk.UnwrapSDKContext(ctx))
+	// collect upfront so that the store is not read from the background
+	var checksums [][]byte
+	seen := make(map[string]struct{})
+	k.IterateCodeInfos(ctx, func(_ uint64, info types.CodeInfo) bool {
+		if _, ok := seen[string(info.CodeHash)]; !ok {
+			seen[string(info.CodeHash)] = struct{}{}
+			checksums = append(checksums, info.CodeHash)
+		}
+		return false
+	})
+	total := len(checksums)
+	logger.Info("pre-compiling wasm codes", "codes", total, "workers", workers)
+
+	var (
+		done, failed atomic.Uint64
+		wg           sync.WaitGroup
+		start        = time.Now()
+		work         = make(chan []byte)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for checksum := range work {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := k.precompileCode(checksum); err != nil {
+					failed.Add(1)
+					logger.Error("can not pre-compile wasm code", "checksum", hex.EncodeToString(checksum), "err", err)
+				}
+				if n := done.Add(1); n%100 == 0 {
+					logger.Info("pre-compiling wasm codes", "done", n, "codes", total)
+				}
+			}
+		}()
+	}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+	feed:
+		for _, checksum := range checksums {
+			select {
+			case work <- checksum:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(work)
+		wg.Wait()
+		logger.Info("pre-compiled wasm codes", "done", done.Load(), "failed", failed.Load(), "codes", total, "duration", time.Since(start))
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(budget):
+		logger.Info("pre-compilation exceeds time budget, continuing in background", "done", done.Load(), "codes", total)
+	}
+	return finished
+}
+
+// precompileCode stores the wasm code of the VM cache again which compiles and persists the module
+func (k Keeper) precompileCode(checksum []byte) error {
+	code, err := k.wasmVM.GetCode(checksum)
+	if err != nil {
+		return errorsmod.Wrap(types.ErrNotFound, err.Error())
+	}
+	got, err := k.wasmVM.StoreCodeUnchecked(code)
+	if err != nil {
+		return errorsmod.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	if !bytes.Equal(checksum, got) {
+		return errorsmod.Wrapf(types.ErrInvalid, "checksum mismatch: %X", got)
+	}
+	return nil
+}
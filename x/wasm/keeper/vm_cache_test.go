@@ -0,0 +1,221 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestValidateVMCache(t *testing.T) {
+	myCode := append(wasmIdent, []byte("my code")...)
+	myChecksum, err := wasmvm.CreateChecksum(myCode)
+	require.NoError(t, err)
+	otherCode := append(wasmIdent, []byte("other code")...)
+	otherChecksum, err := wasmvm.CreateChecksum(otherCode)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		cached    [][]byte
+		source    CodeBlobSource
+		strict    bool
+		expErr    error
+		expHealed bool
+	}{
+		"all cached": {
+			cached: [][]byte{myCode, otherCode},
+			strict: true,
+		},
+		"missing entry healed": {
+			cached:    [][]byte{otherCode},
+			source:    mockCodeBlobSource{string(myChecksum): myCode},
+			strict:    true,
+			expHealed: true,
+		},
+		"missing entry without source": {
+			cached: [][]byte{otherCode},
+		},
+		"missing entry without source - strict": {
+			cached: [][]byte{otherCode},
+			strict: true,
+			expErr: types.ErrNotFound,
+		},
+		"missing entry not in source - strict": {
+			cached: [][]byte{otherCode},
+			source: mockCodeBlobSource{},
+			strict: true,
+			expErr: types.ErrNotFound,
+		},
+		"source with checksum mismatch - strict": {
+			cached: [][]byte{otherCode},
+			source: mockCodeBlobSource{string(myChecksum): otherCode},
+			strict: true,
+			expErr: types.ErrNotFound,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var mock wasmtesting.MockWasmEngine
+			withInMemoryCodeStore(&mock)
+			cache := make(map[string][]byte)
+			for _, c := range spec.cached {
+				checksum, err := mock.StoreCodeUnchecked(c)
+				require.NoError(t, err)
+				cache[string(checksum)] = c
+			}
+			mock.GetCodeFn = func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+				if c, ok := cache[string(checksum)]; ok {
+					return c, nil
+				}
+				return nil, errors.New("file not found")
+			}
+			storeFn := mock.StoreCodeUncheckedFn
+			mock.StoreCodeUncheckedFn = func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+				checksum, err := storeFn(code)
+				cache[string(checksum)] = code
+				return checksum, err
+			}
+			opts := []Option{WithWasmEngine(&mock)}
+			if spec.source != nil {
+				opts = append(opts, WithCodeBlobSource(spec.source))
+			}
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, opts...)
+			k := keepers.WasmKeeper
+			k.mustStoreCodeInfo(ctx, 1, types.CodeInfo{CodeHash: myChecksum})
+			k.mustStoreCodeInfo(ctx, 2, types.CodeInfo{CodeHash: otherChecksum})
+			k.mustStoreCodeInfo(ctx, 3, types.CodeInfo{CodeHash: myChecksum})
+
+			// when
+			gotErr := k.ValidateVMCache(ctx, spec.strict)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			_, healed := cache[string(myChecksum)]
+			assert.Equal(t, spec.expHealed || len(spec.cached) == 2, healed)
+		})
+	}
+}
+
+func TestPrecompileCodes(t *testing.T) {
+	const codeCount = 10
+	specs := map[string]struct {
+		workers int
+		budget  time.Duration
+	}{
+		"single worker":    {workers: 1, budget: time.Minute},
+		"multiple workers": {workers: 3, budget: time.Minute},
+		"default workers":  {budget: time.Minute},
+		"no time budget":   {workers: 2},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var mock wasmtesting.MockWasmEngine
+			withInMemoryCodeStore(&mock)
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+			k := keepers.WasmKeeper
+			var checksums [][]byte
+			for i := 0; i < codeCount; i++ {
+				checksum, err := mock.StoreCodeUnchecked(append(wasmIdent, []byte(fmt.Sprintf("code %d", i))...))
+				require.NoError(t, err)
+				checksums = append(checksums, checksum)
+				k.mustStoreCodeInfo(ctx, uint64(i+1), types.CodeInfo{CodeHash: checksum})
+			}
+			// same checksum is compiled once
+			k.mustStoreCodeInfo(ctx, codeCount+1, types.CodeInfo{CodeHash: checksums[0]})
+
+			var (
+				compiled                 sync.Map
+				calls, active, maxActive atomic.Int32
+			)
+			mock.StoreCodeUncheckedFn = func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+				calls.Add(1)
+				n := active.Add(1)
+				defer active.Add(-1)
+				for m := maxActive.Load(); n > m && !maxActive.CompareAndSwap(m, n); m = maxActive.Load() {
+				}
+				time.Sleep(time.Millisecond)
+				checksum, _, err := wasmtesting.HashOnlyStoreCodeFn(code, 0)
+				compiled.Store(string(checksum), struct{}{})
+				return checksum, err
+			}
+
+			// when
+			done := k.PrecompileCodes(ctx, spec.workers, spec.budget)
+
+			// then
+			select {
+			case <-done:
+			case <-time.After(10 * time.Second):
+				t.Fatal("timeout")
+			}
+			assert.Equal(t, int32(codeCount), calls.Load())
+			for _, checksum := range checksums {
+				_, ok := compiled.Load(string(checksum))
+				assert.True(t, ok)
+			}
+			if spec.workers != 0 {
+				assert.LessOrEqual(t, maxActive.Load(), int32(spec.workers))
+			}
+		})
+	}
+}
+
+func TestPrecompileCodesCancel(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	withInMemoryCodeStore(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	for i := 0; i < 3; i++ {
+		checksum, err := mock.StoreCodeUnchecked(append(wasmIdent, []byte(fmt.Sprintf("code %d", i))...))
+		require.NoError(t, err)
+		k.mustStoreCodeInfo(ctx, uint64(i+1), types.CodeInfo{CodeHash: checksum})
+	}
+	started, release := make(chan struct{}, 3), make(chan struct{})
+	var calls atomic.Int32
+	mock.StoreCodeUncheckedFn = func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+		calls.Add(1)
+		started <- struct{}{}
+		<-release
+		checksum, _, err := wasmtesting.HashOnlyStoreCodeFn(code, 0)
+		return checksum, err
+	}
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	done := k.PrecompileCodes(ctx.WithContext(cancelCtx), 1, 0)
+	<-started
+
+	// when
+	cancel()
+	close(release)
+
+	// then
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout")
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+type mockCodeBlobSource map[string][]byte
+
+func (m mockCodeBlobSource) GetCodeBlob(_ context.Context, checksum []byte) ([]byte, error) {
+	code, ok := m[string(checksum)]
+	if !ok {
+		return nil, types.ErrNotFound.Wrap("code blob")
+	}
+	return code, nil
+}
@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetWasmStats returns the number of stored codes and instantiated contracts
+func (k Keeper) GetWasmStats(ctx context.Context) types.WasmStats {
+	var stats types.WasmStats
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.WasmStatsKey)
+	if err != nil {
+		panic(err)
+	}
+	if bz != nil {
+		k.cdc.MustUnmarshal(bz, &stats)
+	}
+	return stats
+}
+
+func (k Keeper) mustStoreWasmStats(ctx context.Context, stats types.WasmStats) {
+	if err := k.storeService.OpenKVStore(ctx).Set(types.WasmStatsKey, k.cdc.MustMarshal(&stats)); err != nil {
+		panic(err)
+	}
+}
+
+func (k Keeper) incrementCodeCount(ctx context.Context) {
+	stats := k.GetWasmStats(ctx)
+	stats.CodeCount++
+	k.mustStoreWasmStats(ctx, stats)
+}
+
+func (k Keeper) incrementContractCount(ctx context.Context) {
+	stats := k.GetWasmStats(ctx)
+	stats.ContractCount++
+	k.mustStoreWasmStats(ctx, stats)
+}
+
+// GetContractCountByCode returns the number of contracts that currently run the code
+func (k Keeper) GetContractCountByCode(ctx context.Context, codeID uint64) uint64 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetContractCountByCodeKey(codeID))
+	if err != nil {
+		panic(err)
+	}
+	count, _ := binary.Uvarint(bz)
+	return count
+}
+
+// adjustContractCountByCode adds delta to the contract counter of the code. Counters that drop to zero are deleted.
+func (k Keeper) adjustContractCountByCode(ctx context.Context, codeID uint64, delta int64) error {
+	count := k.GetContractCountByCode(ctx, codeID)
+	if delta < 0 && count < uint64(-delta) {
+		return types.ErrInvalid.Wrapf("contract count of code %d must not be negative", codeID)
+	}
+	return k.setContractCountByCode(ctx, codeID, uint64(int64(count)+delta))
+}
+
+func (k Keeper) setContractCountByCode(ctx context.Context, codeID, count uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetContractCountByCodeKey(codeID)
+	if count == 0 {
+		return store.Delete(key)
+	}
+	return store.Set(key, binary.AppendUvarint(nil, count))
+}
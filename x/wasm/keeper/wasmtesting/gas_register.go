@@ -14,6 +14,7 @@ type MockGasRegister struct {
 	ToWasmVMGasFn       func(source storetypes.Gas) uint64
 	FromWasmVMGasFn     func(source uint64) storetypes.Gas
 	UncompressCostsFn   func(byteLength int) storetypes.Gas
+	QueryResponseCostFn func(byteLength int) storetypes.Gas
 }
 
 func (m MockGasRegister) UncompressCosts(byteLength int) storetypes.Gas {
@@ -44,6 +45,13 @@ func (m MockGasRegister) EventCosts(evts []wasmvmtypes.EventAttribute, _ wasmvmt
 	return m.EventCostsFn(evts)
 }
 
+func (m MockGasRegister) QueryResponseCosts(byteLength int) storetypes.Gas {
+	if m.QueryResponseCostFn == nil {
+		panic("not expected to be called")
+	}
+	return m.QueryResponseCostFn(byteLength)
+}
+
 func (m MockGasRegister) ToWasmVMGas(source storetypes.Gas) uint64 {
 	if m.ToWasmVMGasFn == nil {
 		panic("not expected to be called")
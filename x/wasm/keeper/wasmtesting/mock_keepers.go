@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	ibctransfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
 	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
 	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 	ibcexported "github.com/cosmos/ibc-go/v10/modules/core/exported"
@@ -18,6 +20,9 @@ import (
 type MockChannelKeeper struct {
 	GetChannelFn                   func(ctx sdk.Context, srcPort, srcChan string) (channel channeltypes.Channel, found bool)
 	GetNextSequenceSendFn          func(ctx sdk.Context, portID, channelID string) (uint64, bool)
+	GetNextSequenceRecvFn          func(ctx sdk.Context, portID, channelID string) (uint64, bool)
+	GetNextSequenceAckFn           func(ctx sdk.Context, portID, channelID string) (uint64, bool)
+	IteratePacketCommitmentFn      func(ctx sdk.Context, portID, channelID string, cb func(_, _ string, sequence uint64, hash []byte) bool)
 	ChanCloseInitFn                func(ctx sdk.Context, portID, channelID string) error
 	GetAllChannelsFn               func(ctx sdk.Context) []channeltypes.IdentifiedChannel
 	SetChannelFn                   func(ctx sdk.Context, portID, channelID string, channel channeltypes.Channel)
@@ -45,6 +50,27 @@ func (m *MockChannelKeeper) GetNextSequenceSend(ctx sdk.Context, portID, channel
 	return m.GetNextSequenceSendFn(ctx, portID, channelID)
 }
 
+func (m *MockChannelKeeper) GetNextSequenceRecv(ctx sdk.Context, portID, channelID string) (uint64, bool) {
+	if m.GetNextSequenceRecvFn == nil {
+		panic("not supposed to be called!")
+	}
+	return m.GetNextSequenceRecvFn(ctx, portID, channelID)
+}
+
+func (m *MockChannelKeeper) GetNextSequenceAck(ctx sdk.Context, portID, channelID string) (uint64, bool) {
+	if m.GetNextSequenceAckFn == nil {
+		panic("not supposed to be called!")
+	}
+	return m.GetNextSequenceAckFn(ctx, portID, channelID)
+}
+
+func (m *MockChannelKeeper) IteratePacketCommitmentAtChannel(ctx sdk.Context, portID, channelID string, cb func(_, _ string, sequence uint64, hash []byte) bool) {
+	if m.IteratePacketCommitmentFn == nil {
+		panic("not supposed to be called!")
+	}
+	m.IteratePacketCommitmentFn(ctx, portID, channelID, cb)
+}
+
 func (m *MockChannelKeeper) ChanCloseInit(ctx sdk.Context, portID, channelID string) error {
 	if m.ChanCloseInitFn == nil {
 		panic("not supposed to be called!")
@@ -105,7 +131,8 @@ func MockChannelKeeperIterator(s []channeltypes.IdentifiedChannel) func(ctx sdk.
 var _ types.ICS20TransferPortSource = &MockIBCTransferKeeper{}
 
 type MockIBCTransferKeeper struct {
-	GetPortFn func(ctx sdk.Context) string
+	GetPortFn  func(ctx sdk.Context) string
+	GetDenomFn func(ctx sdk.Context, denomHash cmtbytes.HexBytes) (ibctransfertypes.Denom, bool)
 }
 
 func (m MockIBCTransferKeeper) GetPort(ctx sdk.Context) string {
@@ -115,11 +142,21 @@ func (m MockIBCTransferKeeper) GetPort(ctx sdk.Context) string {
 	return m.GetPortFn(ctx)
 }
 
+var _ types.ICS20DenomSource = &MockIBCTransferKeeper{}
+
+func (m MockIBCTransferKeeper) GetDenom(ctx sdk.Context, denomHash cmtbytes.HexBytes) (ibctransfertypes.Denom, bool) {
+	if m.GetDenomFn == nil {
+		panic("not expected to be called")
+	}
+	return m.GetDenomFn(ctx, denomHash)
+}
+
 var _ types.IBCContractKeeper = &IBCContractKeeperMock{}
 
 type IBCContractKeeperMock struct {
 	types.IBCContractKeeper
-	OnRecvPacketFn func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCPacketReceiveMsg) (ibcexported.Acknowledgement, error)
+	OnRecvPacketFn      func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCPacketReceiveMsg) (ibcexported.Acknowledgement, error)
+	GetIBCSendTimeoutFn func(ctx context.Context, contractAddress sdk.AccAddress) uint64
 
 	packets map[string]channeltypes.Packet
 }
@@ -156,3 +193,10 @@ func (m *IBCContractKeeperMock) DeleteAsyncAckPacket(ctx context.Context, portID
 	key := portID + fmt.Sprint(len(channelID)) + channelID
 	delete(m.packets, key)
 }
+
+func (m *IBCContractKeeperMock) GetIBCSendTimeout(ctx context.Context, contractAddress sdk.AccAddress) uint64 {
+	if m.GetIBCSendTimeoutFn == nil {
+		panic("not expected to be called")
+	}
+	return m.GetIBCSendTimeoutFn(ctx, contractAddress)
+}
@@ -0,0 +1,307 @@
+package wasmtesting
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ types.WasmEngine = &MockWasmVM{}
+
+// MockWasmVM is a complete in-Go implementation of types.WasmEngine for unit tests that must not load
+// the wasmvm shared library. Code is stored by checksum without any validation.
+//
+// A contract call returns the canned response that was set for the checksum and entry point. When none
+// is set, the response for the entry point of any code or an empty success result is returned.
+// All contract calls are recorded.
+type MockWasmVM struct {
+	// GasUsed is the VM gas that every contract call reports
+	GasUsed uint64
+
+	mu        sync.Mutex
+	codes     map[string]wasmvm.WasmCode
+	pinned    map[string]struct{}
+	reports   map[string]wasmvmtypes.AnalysisReport
+	responses map[string]any
+	calls     []MockVMCall
+}
+
+// MockVMCall is a recorded contract call of the MockWasmVM
+type MockVMCall struct {
+	Checksum wasmvm.Checksum
+	// EntryPoint is the name of the entry point, for example "execute" or "ibc_packet_receive"
+	EntryPoint string
+	Env        wasmvmtypes.Env
+	// Info is set for instantiate and execute calls
+	Info *wasmvmtypes.MessageInfo
+	// Msg is the message of the call. Typed messages, like the reply, are json encoded
+	Msg []byte
+}
+
+// NewMockWasmVM constructor
+func NewMockWasmVM() *MockWasmVM {
+	return &MockWasmVM{
+		codes:     make(map[string]wasmvm.WasmCode),
+		pinned:    make(map[string]struct{}),
+		reports:   make(map[string]wasmvmtypes.AnalysisReport),
+		responses: make(map[string]any),
+	}
+}
+
+// SetResponse sets the canned response of the entry point. A nil checksum sets the response for all codes.
+// The response must be an error or the result type of the entry point:
+//   - *wasmvmtypes.QueryResult for "query"
+//   - *wasmvmtypes.IBCChannelOpenResult for "ibc_channel_open"
+//   - *wasmvmtypes.IBCReceiveResult for "ibc_packet_receive"
+//   - *wasmvmtypes.IBCBasicResult for the other ibc entry points
+//   - *wasmvmtypes.ContractResult for all others
+func (m *MockWasmVM) SetResponse(checksum wasmvm.Checksum, entryPoint string, response any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses[mockVMResponseKey(checksum, entryPoint)] = response
+}
+
+// SetAnalysisReport sets the report that is returned by AnalyzeCode for the checksum
+func (m *MockWasmVM) SetAnalysisReport(checksum wasmvm.Checksum, report wasmvmtypes.AnalysisReport) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reports[string(checksum)] = report
+}
+
+// Calls returns all recorded contract calls in the order of execution
+func (m *MockWasmVM) Calls() []MockVMCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockVMCall{}, m.calls...)
+}
+
+// CallsOf returns the recorded contract calls of the entry point in the order of execution
+func (m *MockWasmVM) CallsOf(entryPoint string) []MockVMCall {
+	var result []MockVMCall
+	for _, c := range m.Calls() {
+		if c.EntryPoint == entryPoint {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// IsPinned returns true when the code is pinned
+func (m *MockWasmVM) IsPinned(checksum wasmvm.Checksum) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.pinned[string(checksum)]
+	return ok
+}
+
+func (m *MockWasmVM) StoreCode(code wasmvm.WasmCode, gasLimit uint64) (wasmvm.Checksum, uint64, error) {
+	checksum, gasUsed, err := m.SimulateStoreCode(code, gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	m.storeCode(checksum, code)
+	return checksum, gasUsed, nil
+}
+
+func (m *MockWasmVM) StoreCodeUnchecked(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+	checksum, err := wasmvm.CreateChecksum(code)
+	if err != nil {
+		return nil, err
+	}
+	m.storeCode(checksum, code)
+	return checksum, nil
+}
+
+func (m *MockWasmVM) SimulateStoreCode(code wasmvm.WasmCode, gasLimit uint64) (wasmvm.Checksum, uint64, error) {
+	gasUsed := MockStoreCodeCostPerByte * uint64(len(code))
+	if gasUsed > gasLimit {
+		return nil, gasLimit, errorsmod.Wrap(types.ErrCreateFailed, "out of gas")
+	}
+	checksum, err := wasmvm.CreateChecksum(code)
+	return checksum, gasUsed, err
+}
+
+func (m *MockWasmVM) storeCode(checksum wasmvm.Checksum, code wasmvm.WasmCode) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.codes[string(checksum)] = code
+}
+
+func (m *MockWasmVM) GetCode(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code, ok := m.codes[string(checksum)]
+	if !ok {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "code %X", checksum)
+	}
+	return code, nil
+}
+
+func (m *MockWasmVM) AnalyzeCode(checksum wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error) {
+	if _, err := m.GetCode(checksum); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	report := m.reports[string(checksum)]
+	return &report, nil
+}
+
+func (m *MockWasmVM) Pin(checksum wasmvm.Checksum) error {
+	if _, err := m.GetCode(checksum); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pinned[string(checksum)] = struct{}{}
+	return nil
+}
+
+func (m *MockWasmVM) Unpin(checksum wasmvm.Checksum) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pinned, string(checksum))
+	return nil
+}
+
+func (m *MockWasmVM) GetMetrics() (*wasmvmtypes.Metrics, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &wasmvmtypes.Metrics{ElementsPinnedMemoryCache: uint64(len(m.pinned))}, nil
+}
+
+func (m *MockWasmVM) GetPinnedMetrics() (*wasmvmtypes.PinnedMetrics, error) {
+	return &wasmvmtypes.PinnedMetrics{}, nil
+}
+
+func (m *MockWasmVM) Cleanup() {}
+
+func (m *MockWasmVM) Instantiate(checksum wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	m.record(MockVMCall{Checksum: checksum, EntryPoint: "instantiate", Env: env, Info: &info, Msg: initMsg})
+	return mockVMContractResult(m, checksum, "instantiate")
+}
+
+func (m *MockWasmVM) Execute(checksum wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	m.record(MockVMCall{Checksum: checksum, EntryPoint: "execute", Env: env, Info: &info, Msg: executeMsg})
+	return mockVMContractResult(m, checksum, "execute")
+}
+
+func (m *MockWasmVM) Query(checksum wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+	m.record(MockVMCall{Checksum: checksum, EntryPoint: "query", Env: env, Msg: queryMsg})
+	return mockVMResult(m, checksum, "query", &wasmvmtypes.QueryResult{Ok: []byte(`{}`)})
+}
+
+func (m *MockWasmVM) Migrate(checksum wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	m.record(MockVMCall{Checksum: checksum, EntryPoint: "migrate", Env: env, Msg: migrateMsg})
+	return mockVMContractResult(m, checksum, "migrate")
+}
+
+func (m *MockWasmVM) MigrateWithInfo(checksum wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, _ wasmvmtypes.MigrateInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	return m.Migrate(checksum, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+func (m *MockWasmVM) Sudo(checksum wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	m.record(MockVMCall{Checksum: checksum, EntryPoint: "sudo", Env: env, Msg: sudoMsg})
+	return mockVMContractResult(m, checksum, "sudo")
+}
+
+func (m *MockWasmVM) Reply(checksum wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	m.recordTyped(checksum, "reply", env, reply)
+	return mockVMContractResult(m, checksum, "reply")
+}
+
+func (m *MockWasmVM) IBCChannelOpen(checksum wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCChannelOpenMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCChannelOpenResult, uint64, error) {
+	m.recordTyped(checksum, "ibc_channel_open", env, msg)
+	return mockVMResult(m, checksum, "ibc_channel_open", &wasmvmtypes.IBCChannelOpenResult{Ok: &wasmvmtypes.IBC3ChannelOpenResponse{}})
+}
+
+func (m *MockWasmVM) IBCChannelConnect(checksum wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCChannelConnectMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+	m.recordTyped(checksum, "ibc_channel_connect", env, msg)
+	return mockVMBasicResult(m, checksum, "ibc_channel_connect")
+}
+
+func (m *MockWasmVM) IBCChannelClose(checksum wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCChannelCloseMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+	m.recordTyped(checksum, "ibc_channel_close", env, msg)
+	return mockVMBasicResult(m, checksum, "ibc_channel_close")
+}
+
+func (m *MockWasmVM) IBCPacketReceive(checksum wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCPacketReceiveMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCReceiveResult, uint64, error) {
+	m.recordTyped(checksum, "ibc_packet_receive", env, msg)
+	return mockVMResult(m, checksum, "ibc_packet_receive", &wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{Acknowledgement: []byte(`{}`)}})
+}
+
+func (m *MockWasmVM) IBCPacketAck(checksum wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCPacketAckMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+	m.recordTyped(checksum, "ibc_packet_ack", env, msg)
+	return mockVMBasicResult(m, checksum, "ibc_packet_ack")
+}
+
+func (m *MockWasmVM) IBCPacketTimeout(checksum wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCPacketTimeoutMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+	m.recordTyped(checksum, "ibc_packet_timeout", env, msg)
+	return mockVMBasicResult(m, checksum, "ibc_packet_timeout")
+}
+
+func (m *MockWasmVM) IBCSourceCallback(checksum wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCSourceCallbackMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+	m.recordTyped(checksum, "ibc_source_callback", env, msg)
+	return mockVMBasicResult(m, checksum, "ibc_source_callback")
+}
+
+func (m *MockWasmVM) IBCDestinationCallback(checksum wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCDestinationCallbackMsg, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+	m.recordTyped(checksum, "ibc_destination_callback", env, msg)
+	return mockVMBasicResult(m, checksum, "ibc_destination_callback")
+}
+
+func (m *MockWasmVM) record(c MockVMCall) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, c)
+}
+
+func (m *MockWasmVM) recordTyped(checksum wasmvm.Checksum, entryPoint string, env wasmvmtypes.Env, msg any) {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	m.record(MockVMCall{Checksum: checksum, EntryPoint: entryPoint, Env: env, Msg: bz})
+}
+
+func mockVMResponseKey(checksum wasmvm.Checksum, entryPoint string) string {
+	return hex.EncodeToString(checksum) + "/" + entryPoint
+}
+
+func mockVMContractResult(m *MockWasmVM, checksum wasmvm.Checksum, entryPoint string) (*wasmvmtypes.ContractResult, uint64, error) {
+	return mockVMResult(m, checksum, entryPoint, &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}})
+}
+
+func mockVMBasicResult(m *MockWasmVM, checksum wasmvm.Checksum, entryPoint string) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+	return mockVMResult(m, checksum, entryPoint, &wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{}})
+}
+
+// mockVMResult returns the canned response for the checksum and entry point, the response for the entry point
+// of any code or the default
+func mockVMResult[T any](m *MockWasmVM, checksum wasmvm.Checksum, entryPoint string, defaultResponse *T) (*T, uint64, error) {
+	m.mu.Lock()
+	response, ok := m.responses[mockVMResponseKey(checksum, entryPoint)]
+	if !ok {
+		response, ok = m.responses[mockVMResponseKey(nil, entryPoint)]
+	}
+	m.mu.Unlock()
+	if !ok {
+		return defaultResponse, m.GasUsed, nil
+	}
+	switch r := response.(type) {
+	case error:
+		return nil, m.GasUsed, r
+	case *T:
+		return r, m.GasUsed, nil
+	default:
+		panic(fmt.Sprintf("unexpected response type %T for entry point %s", response, entryPoint))
+	}
+}
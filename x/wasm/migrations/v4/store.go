@@ -0,0 +1,107 @@
+package v4
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// AddToLabelIndexFn creates a label index entry for the contract and its creator
+type AddToLabelIndexFn func(ctx context.Context, creatorAddress sdk.AccAddress, label string, contractAddress sdk.AccAddress) error
+
+// AddToChecksumIndexFn creates a checksum index entry for the code
+type AddToChecksumIndexFn func(ctx context.Context, checksum []byte, codeID uint64) error
+
+// AddToCodeCreatorIndexFn creates a creator index entry for the code
+type AddToCodeCreatorIndexFn func(ctx context.Context, creator sdk.AccAddress, codeID uint64) error
+
+// StoreStatsFn persists the module wide counters
+type StoreStatsFn func(ctx context.Context, stats types.WasmStats)
+
+// SetContractCountFn persists the number of contracts of a code
+type SetContractCountFn func(ctx context.Context, codeID, count uint64) error
+
+// AddToAdminIndexFn creates an admin index entry for the contract
+type AddToAdminIndexFn func(ctx context.Context, admin, contractAddress sdk.AccAddress) error
+
+// StoreFns are the keeper functions that write the secondary indexes and counters
+type StoreFns struct {
+	AddToLabelIndex       AddToLabelIndexFn
+	AddToChecksumIndex    AddToChecksumIndexFn
+	AddToCodeCreatorIndex AddToCodeCreatorIndexFn
+	StoreStats            StoreStatsFn
+	SetContractCount      SetContractCountFn
+	AddToAdminIndex       AddToAdminIndexFn
+}
+
+// wasmKeeper abstract keeper
+type wasmKeeper interface {
+	IterateCodeInfos(ctx context.Context, cb func(uint64, types.CodeInfo) bool)
+	IterateContractInfo(ctx context.Context, cb func(sdk.AccAddress, types.ContractInfo) bool)
+}
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper wasmKeeper
+	fns    StoreFns
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(k wasmKeeper, fns StoreFns) Migrator {
+	return Migrator{keeper: k, fns: fns}
+}
+
+// Migrate4to5 migrates from version 4 to 5. It builds the secondary indexes and counters for all
+// existing codes and contracts:
+//   - the checksum and creator indexes of the codes
+//   - the label and admin indexes of the contracts
+//   - the code and contract counters and the number of contracts per code
+//
+// Contracts of the same creator that share a label already are kept as they are.
+func (m Migrator) Migrate4to5(ctx sdk.Context) error {
+	var (
+		err     error
+		stats   types.WasmStats
+		codeIDs []uint64
+	)
+	m.keeper.IterateCodeInfos(ctx, func(codeID uint64, codeInfo types.CodeInfo) bool {
+		stats.CodeCount++
+		codeIDs = append(codeIDs, codeID)
+		if err = m.fns.AddToChecksumIndex(ctx, codeInfo.CodeHash, codeID); err != nil {
+			return true
+		}
+		err = m.fns.AddToCodeCreatorIndex(ctx, sdk.MustAccAddressFromBech32(codeInfo.Creator), codeID)
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+
+	contractCounts := make(map[uint64]uint64, len(codeIDs))
+	m.keeper.IterateContractInfo(ctx, func(contractAddr sdk.AccAddress, contractInfo types.ContractInfo) bool {
+		stats.ContractCount++
+		contractCounts[contractInfo.CodeID]++
+		creator := sdk.MustAccAddressFromBech32(contractInfo.Creator)
+		if err = m.fns.AddToLabelIndex(ctx, creator, contractInfo.Label, contractAddr); err != nil {
+			return true
+		}
+		if contractInfo.Admin != "" {
+			err = m.fns.AddToAdminIndex(ctx, contractInfo.AdminAddr(), contractAddr)
+		}
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.fns.StoreStats(ctx, stats)
+	// iterate the ordered code ids instead of the map for deterministic writes
+	for _, codeID := range codeIDs {
+		if err := m.fns.SetContractCount(ctx, codeID, contractCounts[codeID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package v4_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	v4 "github.com/CosmWasm/wasmd/x/wasm/migrations/v4"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate4To5(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := keeper.CreateTestInput(t, false, []string{"iterator"}, keeper.WithWasmEngine(&mock))
+	wasmKeeper := keepers.WasmKeeper
+	example := keeper.StoreRandomContract(t, ctx, keepers, &mock)
+	other := keeper.StoreRandomContract(t, ctx, keepers, &mock)
+	unused := keeper.StoreRandomContract(t, ctx, keepers, &mock)
+	creator, admin := keeper.RandomAccountAddress(t), keeper.RandomAccountAddress(t)
+
+	gotContractAddr1, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, admin, []byte(`{}`), "demo contract", nil)
+	require.NoError(t, err)
+	// a duplicate label of the same creator is kept
+	gotContractAddr2, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, admin, []byte(`{}`), "demo contract", nil)
+	require.NoError(t, err)
+	gotContractAddr3, _, err := keepers.ContractKeeper.Instantiate(ctx, other.CodeID, creator, nil, []byte(`{}`), "other contract", nil)
+	require.NoError(t, err)
+
+	// remove keys
+	store := ctx.KVStore(keepers.WasmStoreKey)
+	for _, code := range []keeper.ExampleContract{example, other, unused} {
+		store.Delete(types.GetCodeByChecksumKey(code.Checksum, code.CodeID))
+		store.Delete(types.GetCodeByCreatorKey(code.CreatorAddr, code.CodeID))
+		store.Delete(types.GetContractCountByCodeKey(code.CodeID))
+	}
+	for addr, label := range map[string]string{
+		string(gotContractAddr1): "demo contract",
+		string(gotContractAddr2): "demo contract",
+		string(gotContractAddr3): "other contract",
+	} {
+		store.Delete(types.GetContractByLabelKey(label, creator, sdk.AccAddress(addr)))
+	}
+	store.Delete(types.GetContractByAdminKey(admin, gotContractAddr1))
+	store.Delete(types.GetContractByAdminKey(admin, gotContractAddr2))
+	store.Delete(types.WasmStatsKey)
+
+	// migrator
+	err = keeper.NewMigrator(*wasmKeeper, nil).Migrate4to5(ctx)
+	require.NoError(t, err)
+
+	// check new store
+	for _, code := range []keeper.ExampleContract{example, other, unused} {
+		var got []uint64
+		wasmKeeper.IterateCodesByChecksum(ctx, code.Checksum, func(codeID uint64) bool {
+			got = append(got, codeID)
+			return false
+		})
+		assert.Equal(t, []uint64{code.CodeID}, got)
+		got = nil
+		wasmKeeper.IterateCodesByCreator(ctx, code.CreatorAddr, func(codeID uint64) bool {
+			got = append(got, codeID)
+			return false
+		})
+		assert.Equal(t, []uint64{code.CodeID}, got)
+	}
+
+	var gotByLabel []sdk.AccAddress
+	wasmKeeper.IterateContractsByCreatorLabel(ctx, creator, "demo contract", func(addr sdk.AccAddress) bool {
+		gotByLabel = append(gotByLabel, addr)
+		return false
+	})
+	assert.ElementsMatch(t, []sdk.AccAddress{gotContractAddr1, gotContractAddr2}, gotByLabel)
+	assert.True(t, store.Has(types.GetContractByLabelKey("other contract", creator, gotContractAddr3)))
+
+	var gotByAdmin []sdk.AccAddress
+	wasmKeeper.IterateContractsByAdmin(ctx, admin, func(addr sdk.AccAddress) bool {
+		gotByAdmin = append(gotByAdmin, addr)
+		return false
+	})
+	assert.ElementsMatch(t, []sdk.AccAddress{gotContractAddr1, gotContractAddr2}, gotByAdmin)
+	// contracts without admin are not indexed
+	iter := storetypes.KVStorePrefixIterator(store, types.ContractsByAdminPrefix)
+	defer iter.Close()
+	var total int
+	for ; iter.Valid(); iter.Next() {
+		total++
+	}
+	assert.Equal(t, 2, total)
+
+	assert.Equal(t, types.WasmStats{CodeCount: 3, ContractCount: 3}, wasmKeeper.GetWasmStats(ctx))
+	assert.Equal(t, uint64(2), wasmKeeper.GetContractCountByCode(ctx, example.CodeID))
+	assert.Equal(t, uint64(1), wasmKeeper.GetContractCountByCode(ctx, other.CodeID))
+	assert.Equal(t, uint64(0), wasmKeeper.GetContractCountByCode(ctx, unused.CodeID))
+	assert.False(t, store.Has(types.GetContractCountByCodeKey(unused.CodeID)))
+}
+
+func TestMigrate4To5SetsContractCountsInCodeIDOrder(t *testing.T) {
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := keeper.CreateTestInput(t, false, []string{"iterator"}, keeper.WithWasmEngine(&mock))
+	var expCodeIDs []uint64
+	for range 10 {
+		example := keeper.StoreRandomContract(t, ctx, keepers, &mock)
+		expCodeIDs = append(expCodeIDs, example.CodeID)
+	}
+	var gotCodeIDs []uint64
+	fns := v4.StoreFns{
+		AddToChecksumIndex:    func(context.Context, []byte, uint64) error { return nil },
+		AddToCodeCreatorIndex: func(context.Context, sdk.AccAddress, uint64) error { return nil },
+		StoreStats:            func(context.Context, types.WasmStats) {},
+		SetContractCount: func(_ context.Context, codeID, _ uint64) error {
+			gotCodeIDs = append(gotCodeIDs, codeID)
+			return nil
+		},
+	}
+
+	// when
+	err := v4.NewMigrator(keepers.WasmKeeper, fns).Migrate4to5(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expCodeIDs, gotCodeIDs)
+}
@@ -44,6 +44,16 @@ const (
 	flagWasmQueryGasLimit          = "wasm.query_gas_limit"
 	flagWasmSimulationGasLimit     = "wasm.simulation_gas_limit"
 	flagWasmSkipWasmVMVersionCheck = "wasm.skip_wasmvm_version_check"
+	flagWasmStrictVMCacheCheck     = "wasm.strict_vm_cache_check"
+	flagWasmQueryDepthGasPercent   = "wasm.query_depth_gas_percent"
+	flagWasmEnableStateWatch       = "wasm.enable_state_watch"
+	flagWasmSignedQueryBlockWindow = "wasm.signed_query_block_window"
+	flagWasmStoreWriteLogDir       = "wasm.store_write_log_dir"
+	flagWasmExecutionLogLevel      = "wasm.execution_log_level"
+	flagWasmPrecompileOnStart      = "wasm.precompile_on_start"
+	flagWasmPrecompileWorkers      = "wasm.precompile_workers"
+	flagWasmPrecompileTimeBudget   = "wasm.precompile_time_budget"
+	flagWasmAliasQueryCacheSize    = "wasm.alias_query_cache_size"
 )
 
 // AppModuleBasic defines the basic application module used by the wasm module.
@@ -99,7 +109,10 @@ func (b AppModuleBasic) RegisterInterfaces(registry cdctypes.InterfaceRegistry)
 }
 
 // ____________________________________________________________________________
-var _ appmodule.AppModule = AppModule{}
+var (
+	_ appmodule.AppModule     = AppModule{}
+	_ appmodule.HasEndBlocker = AppModule{}
+)
 
 // AppModule implements an application module for the wasm module.
 type AppModule struct {
@@ -148,7 +161,7 @@ func (am AppModule) IsAppModule() { // marker
 // module. It should be incremented on each consensus-breaking change
 // introduced by the module. To avoid wrong/empty versions, the initial version
 // should be set to 1.
-func (AppModule) ConsensusVersion() uint64 { return 4 }
+func (AppModule) ConsensusVersion() uint64 { return 5 }
 
 func (am AppModule) RegisterServices(cfg module.Configurator) {
 	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(am.keeper))
@@ -167,6 +180,30 @@ func (am AppModule) RegisterServices(cfg module.Configurator) {
 	if err != nil {
 		panic(err)
 	}
+	err = cfg.RegisterMigration(types.ModuleName, 4, m.Migrate4to5)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// EndBlock prunes the chunked code uploads that expired, executes the sudo calls that contracts scheduled
+// for this block, deactivates the contracts whose sunset height is reached, records the block hash for signed
+// smart queries and emits the summary of the wasm activity in the block. The wall-clock time of the wasm
+// operations in the block is observed in the telemetry histogram.
+func (am AppModule) EndBlock(ctx context.Context) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	am.keeper.ObserveBlockExecTime()
+	am.keeper.TrackBlockHash(sdkCtx)
+	if err := am.keeper.PruneExpiredCodeUploads(sdkCtx); err != nil {
+		return err
+	}
+	if err := am.keeper.ExecuteScheduledSudos(sdkCtx); err != nil {
+		return err
+	}
+	if err := am.keeper.DeactivateSunsetContracts(sdkCtx); err != nil {
+		return err
+	}
+	return am.keeper.EmitBlockSummary(sdkCtx)
 }
 
 // RegisterInvariants registers the wasm module invariants.
@@ -228,6 +265,16 @@ func AddModuleInitFlags(startCmd *cobra.Command) {
 	startCmd.Flags().Uint64(flagWasmQueryGasLimit, defaults.SmartQueryGasLimit, "Set the max gas that can be spent on executing a query with a Wasm contract")
 	startCmd.Flags().String(flagWasmSimulationGasLimit, "", "Set the max gas that can be spent when executing a simulation TX")
 	startCmd.Flags().Bool(flagWasmSkipWasmVMVersionCheck, false, "Skip check that ensures that libwasmvm version (the Rust project) and wasmvm version (the Go project) match")
+	startCmd.Flags().Bool(flagWasmStrictVMCacheCheck, defaults.StrictVMCacheCheck, "Refuse to start when stored codes are missing in the wasm cache and can not be healed")
+	startCmd.Flags().Uint32(flagWasmQueryDepthGasPercent, defaults.SmartQueryDepthGasPercent, "Set the max percentage of the remaining query gas of the parent that a nested smart query can use. Set to 0 to disable.")
+	startCmd.Flags().Bool(flagWasmEnableStateWatch, defaults.EnableStateWatch, "Enable the node local gRPC service to stream contract state changes")
+	startCmd.Flags().Uint32(flagWasmSignedQueryBlockWindow, defaults.SignedQueryBlockWindow, "Set the number of recent blocks whose hash is accepted in a signed smart query. Set to 0 to disable signed queries.")
+	startCmd.Flags().String(flagWasmStoreWriteLogDir, defaults.StoreWriteLogDir, "Set a directory to log all contract store writes in FinalizeBlock for debugging. Relative to the node home when not absolute")
+	startCmd.Flags().String(flagWasmExecutionLogLevel, defaults.ExecutionLogLevel, "Set the log level of the contract execution logs: debug, info or none. Defaults to debug")
+	startCmd.Flags().Bool(flagWasmPrecompileOnStart, defaults.PrecompileOnStart, "Compile the wasm code of all stored codes at startup so that the first executions are not slowed down")
+	startCmd.Flags().Uint32(flagWasmPrecompileWorkers, defaults.PrecompileWorkers, "Set the number of codes compiled concurrently at startup. Defaults to the number of CPUs")
+	startCmd.Flags().Duration(flagWasmPrecompileTimeBudget, defaults.PrecompileTimeBudget, "Set the max time the startup waits for the pre-compilation before it continues in the background")
+	startCmd.Flags().Uint32(flagWasmAliasQueryCacheSize, defaults.AliasQueryCacheSize, "Set the max number of aliased smart query responses that the REST API caches. Set to 0 to disable.")
 
 	preCheck := func(cmd *cobra.Command, _ []string) error {
 		skip, err := cmd.Flags().GetBool(flagWasmSkipWasmVMVersionCheck)
@@ -266,6 +313,65 @@ func ReadNodeConfig(opts servertypes.AppOptions) (types.NodeConfig, error) {
 			cfg.SimulationGasLimit = &limit
 		}
 	}
+	if v := opts.Get(flagWasmStrictVMCacheCheck); v != nil {
+		if cfg.StrictVMCacheCheck, err = cast.ToBoolE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmQueryDepthGasPercent); v != nil {
+		if cfg.SmartQueryDepthGasPercent, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+		if cfg.SmartQueryDepthGasPercent > 100 {
+			return cfg, fmt.Errorf("query depth gas percent must not be greater than 100: %d", cfg.SmartQueryDepthGasPercent)
+		}
+	}
+	if v := opts.Get(flagWasmEnableStateWatch); v != nil {
+		if cfg.EnableStateWatch, err = cast.ToBoolE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmSignedQueryBlockWindow); v != nil {
+		if cfg.SignedQueryBlockWindow, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmStoreWriteLogDir); v != nil {
+		if cfg.StoreWriteLogDir, err = cast.ToStringE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmExecutionLogLevel); v != nil {
+		if cfg.ExecutionLogLevel, err = cast.ToStringE(v); err != nil {
+			return cfg, err
+		}
+		if err := types.ValidateExecutionLogLevel(cfg.ExecutionLogLevel); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmPrecompileOnStart); v != nil {
+		if cfg.PrecompileOnStart, err = cast.ToBoolE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmPrecompileWorkers); v != nil {
+		if cfg.PrecompileWorkers, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmPrecompileTimeBudget); v != nil {
+		if cfg.PrecompileTimeBudget, err = cast.ToDurationE(v); err != nil {
+			return cfg, err
+		}
+		if cfg.PrecompileTimeBudget < 0 {
+			return cfg, fmt.Errorf("precompile time budget must not be negative: %s", cfg.PrecompileTimeBudget)
+		}
+	}
+	if v := opts.Get(flagWasmAliasQueryCacheSize); v != nil {
+		if cfg.AliasQueryCacheSize, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
 	// attach contract debugging to global "trace" flag
 	if v := opts.Get(server.FlagTrace); v != nil {
 		if cfg.ContractDebugMode, err = cast.ToBoolE(v); err != nil {
@@ -0,0 +1,15 @@
+package types
+
+// AdminChangedSudoMsg is the sudo message that is sent to a contract with admin change notification enabled
+// after the admin was updated or cleared
+type AdminChangedSudoMsg struct {
+	AdminChanged AdminChanged `json:"admin_changed"`
+}
+
+// AdminChanged contains the previous and the new admin of the contract
+type AdminChanged struct {
+	// Old is the bech32 address of the previous admin or empty when there was none
+	Old string `json:"old,omitempty"`
+	// New is the bech32 address of the new admin or empty when the admin was cleared
+	New string `json:"new,omitempty"`
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"context"
+	"slices"
 	"strings"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
@@ -128,7 +129,9 @@ func (g CodeGrant) ValidateBasic() error {
 	return nil
 }
 
-// Accept checks if checksum and permission match the grant
+// Accept checks if checksum and permission match the grant. With an instantiate permission in the grant,
+// the code must declare an instantiate permission that is a subset of it. The chain default is not accepted
+// as it can change with the params.
 func (g CodeGrant) Accept(checksum []byte, permission *AccessConfig) bool {
 	if !strings.EqualFold(string(g.CodeHash), CodehashWildcard) && !bytes.EqualFold(g.CodeHash, checksum) {
 		return false
@@ -136,6 +139,9 @@ func (g CodeGrant) Accept(checksum []byte, permission *AccessConfig) bool {
 	if g.InstantiatePermission == nil {
 		return true
 	}
+	if permission == nil {
+		return false
+	}
 	return permission.IsSubset(*g.InstantiatePermission)
 }
 
@@ -171,7 +177,15 @@ func (a *ContractExecutionAuthorization) Accept(goCtx context.Context, msg sdk.M
 
 // ValidateBasic implements Authorization.ValidateBasic.
 func (a ContractExecutionAuthorization) ValidateBasic() error {
-	return validateGrants(a.Grants)
+	if err := validateGrants(a.Grants); err != nil {
+		return err
+	}
+	for i, g := range a.Grants {
+		if len(g.AllowedCodeIDs) != 0 {
+			return ErrInvalid.Wrapf("position %d: allowed code ids are for migrations only", i)
+		}
+	}
+	return nil
 }
 
 // UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
@@ -208,7 +222,19 @@ func (a ContractMigrationAuthorization) NewAuthz(g []ContractGrant) authztypes.A
 
 // ValidateBasic implements Authorization.ValidateBasic.
 func (a ContractMigrationAuthorization) ValidateBasic() error {
-	return validateGrants(a.Grants)
+	if err := validateGrants(a.Grants); err != nil {
+		return err
+	}
+	// unlike executions, a contract has a single migration grant so that the
+	// code id restrictions can not be bypassed by another grant
+	contracts := make(map[string]struct{}, len(a.Grants))
+	for i, g := range a.Grants {
+		if _, exists := contracts[g.Contract]; exists {
+			return ErrDuplicate.Wrapf("position %d: contract %s", i, g.Contract)
+		}
+		contracts[g.Contract] = struct{}{}
+	}
+	return nil
 }
 
 // UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
@@ -256,7 +282,7 @@ func AcceptGrantedMessage[T AuthzableWasmMsg](ctx sdk.Context, grants []Contract
 
 	// iterate though all grants
 	for i, g := range grants {
-		if g.Contract != exec.GetContract() {
+		if g.Contract != exec.GetContract() || !g.acceptCodeID(exec) {
 			continue
 		}
 
@@ -367,12 +393,28 @@ func (g ContractGrant) WithNewLimits(limit ContractAuthzLimitX) (*ContractGrant,
 	}
 
 	return &ContractGrant{
-		Contract: g.Contract,
-		Limit:    anyLimit,
-		Filter:   g.Filter,
+		Contract:       g.Contract,
+		Limit:          anyLimit,
+		Filter:         g.Filter,
+		AllowedCodeIDs: g.AllowedCodeIDs,
 	}, nil
 }
 
+// WithAllowedCodeIDs factory method to create a new grant that only permits migrations to the given code ids
+func (g ContractGrant) WithAllowedCodeIDs(codeIDs ...uint64) *ContractGrant {
+	g.AllowedCodeIDs = codeIDs
+	return &g
+}
+
+// acceptCodeID returns true when the grant has no code id restriction or the message migrates to an allowed code
+func (g ContractGrant) acceptCodeID(msg AuthzableWasmMsg) bool {
+	if len(g.AllowedCodeIDs) == 0 {
+		return true
+	}
+	migrateMsg, ok := msg.(*MsgMigrateContract)
+	return ok && slices.Contains(g.AllowedCodeIDs, migrateMsg.CodeID)
+}
+
 // UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
 func (g ContractGrant) UnpackInterfaces(unpacker cdctypes.AnyUnpacker) error {
 	var f ContractAuthzFilterX
@@ -423,6 +465,16 @@ func (g ContractGrant) ValidateBasic() error {
 	if err := g.GetFilter().ValidateBasic(); err != nil {
 		return errorsmod.Wrap(err, "filter")
 	}
+	seen := make(map[uint64]struct{}, len(g.AllowedCodeIDs))
+	for _, codeID := range g.AllowedCodeIDs {
+		if codeID == 0 {
+			return ErrEmpty.Wrap("allowed code id")
+		}
+		if _, exists := seen[codeID]; exists {
+			return ErrDuplicate.Wrapf("allowed code id %d", codeID)
+		}
+		seen[codeID] = struct{}{}
+	}
 	return nil
 }
 
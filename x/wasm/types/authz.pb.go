@@ -227,6 +227,9 @@ type ContractGrant struct {
 	// to the contract in the operation. When no filter applies on execution, the
 	// operation is prohibited.
 	Filter *types.Any `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// AllowedCodeIDs restricts the new code ids of a contract migration. Empty
+	// allows any code. Must be empty for contract executions.
+	AllowedCodeIDs []uint64 `protobuf:"varint,4,rep,packed,name=allowed_code_ids,json=allowedCodeIds,proto3" json:"allowed_code_ids,omitempty"`
 }
 
 func (m *ContractGrant) Reset()         { *m = ContractGrant{} }
@@ -557,59 +560,59 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/authz.proto", fileDescriptor_36ff3a20cf32b258) }
 
 var fileDescriptor_36ff3a20cf32b258 = []byte{
-	// 817 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0xcf, 0x4f, 0x33, 0x45,
-	0x18, 0xee, 0x7e, 0xdf, 0x27, 0xd2, 0x81, 0xcf, 0x1f, 0x1b, 0x6c, 0x5a, 0x20, 0x5b, 0xb2, 0x2a,
-	0x56, 0x92, 0xee, 0xa6, 0xe8, 0xa9, 0x07, 0x4d, 0xb7, 0x5a, 0x35, 0x82, 0x31, 0x8b, 0x06, 0xe2,
-	0xa5, 0x99, 0xee, 0x0e, 0xdb, 0x91, 0xdd, 0x99, 0x66, 0x67, 0x0a, 0x14, 0x63, 0xbc, 0x7b, 0xf2,
-	0xec, 0xc9, 0x9b, 0xc6, 0x13, 0x87, 0xfe, 0x11, 0x84, 0xc4, 0x84, 0x78, 0xf2, 0x84, 0x0a, 0x07,
-	0xfe, 0x01, 0xe3, 0xc1, 0x93, 0x99, 0x1f, 0x6d, 0x69, 0x29, 0x04, 0x39, 0xf9, 0x5d, 0xa6, 0x9d,
-	0xf7, 0x9d, 0xf7, 0x7d, 0x9f, 0xe7, 0x9d, 0x67, 0xde, 0x05, 0xcb, 0x01, 0x65, 0xc9, 0x01, 0x64,
-	0x89, 0x2b, 0x97, 0xfd, 0x8a, 0x0b, 0xbb, 0xbc, 0x7d, 0xe4, 0x74, 0x52, 0xca, 0xa9, 0xf9, 0xd2,
-	0xc0, 0xeb, 0xc8, 0x65, 0xbf, 0xb2, 0xb8, 0x10, 0xd1, 0x88, 0x4a, 0xa7, 0x2b, 0xfe, 0xa9, 0x73,
-	0x8b, 0x05, 0x71, 0x8e, 0xb2, 0xa6, 0x72, 0xa8, 0x8d, 0x76, 0x59, 0x6a, 0xe7, 0xb6, 0x20, 0x43,
-	0xee, 0x7e, 0xa5, 0x85, 0x38, 0xac, 0xb8, 0x01, 0xc5, 0x44, 0xfb, 0x6f, 0x02, 0xe0, 0xbd, 0x0e,
-	0x1a, 0x44, 0x17, 0x22, 0x4a, 0xa3, 0x18, 0xb9, 0x72, 0xd7, 0xea, 0xee, 0xba, 0x90, 0xf4, 0xb4,
-	0xeb, 0x65, 0x98, 0x60, 0x42, 0x5d, 0xb9, 0x2a, 0x93, 0xfd, 0x83, 0x01, 0x72, 0x5b, 0x9c, 0xa6,
-	0xa8, 0x4e, 0x43, 0x54, 0xeb, 0xf2, 0x36, 0x4d, 0xf1, 0x11, 0xe4, 0x98, 0x12, 0xf3, 0x1d, 0x30,
-	0x13, 0xa5, 0x90, 0x70, 0x96, 0x37, 0x56, 0x1e, 0x97, 0xe6, 0xd6, 0x97, 0x9c, 0x49, 0x6a, 0x8e,
-	0x08, 0xfa, 0x40, 0x9c, 0xf1, 0xb2, 0x27, 0xe7, 0xc5, 0xcc, 0x4f, 0x57, 0xc7, 0x6b, 0x86, 0xaf,
-	0xa3, 0xaa, 0x8d, 0xd3, 0x7e, 0xd9, 0xd6, 0xc4, 0x54, 0x87, 0x34, 0x17, 0x67, 0xac, 0xce, 0xb7,
-	0x57, 0xc7, 0x6b, 0x4b, 0x92, 0xc8, 0x74, 0x1c, 0x76, 0xdf, 0x00, 0x56, 0x9d, 0x12, 0x9e, 0xc2,
-	0x80, 0xbf, 0x7f, 0x88, 0x82, 0xae, 0xb0, 0x8e, 0x43, 0xf5, 0x26, 0xa0, 0x16, 0xa7, 0x41, 0x55,
-	0x19, 0x6e, 0x85, 0xfb, 0xc9, 0xfd, 0xe1, 0xbe, 0x2a, 0xe1, 0xde, 0x8d, 0x69, 0x0c, 0xf6, 0x26,
-	0x8e, 0x52, 0xf8, 0x3f, 0x83, 0x3d, 0x1d, 0x93, 0xfd, 0x0d, 0xc8, 0x0e, 0x6f, 0xd5, 0x5c, 0x02,
-	0xd9, 0x80, 0x86, 0xa8, 0xd9, 0x86, 0xac, 0x9d, 0x37, 0x56, 0x8c, 0xd2, 0xbc, 0x3f, 0x2b, 0x0c,
-	0x1f, 0x42, 0xd6, 0x36, 0x3f, 0x07, 0x39, 0x4c, 0x18, 0x87, 0x84, 0x63, 0xc8, 0x51, 0xb3, 0x83,
-	0xd2, 0x04, 0x33, 0x86, 0x29, 0xc9, 0x3f, 0x5a, 0x31, 0x4a, 0x73, 0xeb, 0xd6, 0x4d, 0x36, 0xb5,
-	0x20, 0x40, 0x8c, 0xd5, 0x29, 0xd9, 0xc5, 0x91, 0xff, 0xca, 0xb5, 0xe8, 0x4f, 0x87, 0xc1, 0xf6,
-	0x5f, 0x06, 0x78, 0x3a, 0xc6, 0xda, 0x7c, 0x1b, 0xcc, 0x06, 0xda, 0x20, 0x41, 0x64, 0xbd, 0xfc,
-	0xaf, 0xfd, 0xf2, 0x82, 0x26, 0x5d, 0x0b, 0xc3, 0x14, 0x31, 0xb6, 0xc5, 0x53, 0x4c, 0x22, 0x7f,
-	0x78, 0xd2, 0xfc, 0x0c, 0x3c, 0x17, 0xe3, 0x04, 0x73, 0x8d, 0x66, 0xc1, 0x51, 0xef, 0xc2, 0x19,
-	0xbc, 0x0b, 0xa7, 0x46, 0x7a, 0x5e, 0xe9, 0xb4, 0x5f, 0x7e, 0xed, 0xd6, 0xa6, 0x8b, 0xce, 0x1c,
-	0x6d, 0x88, 0x24, 0x3b, 0xbe, 0x4a, 0x66, 0x6e, 0x83, 0x99, 0x5d, 0x1c, 0x73, 0x94, 0xe6, 0x1f,
-	0xdf, 0x91, 0xf6, 0xcd, 0xd3, 0x7e, 0xf9, 0xf5, 0xbb, 0xd3, 0x36, 0x64, 0x96, 0x1d, 0x5f, 0xa7,
-	0xb3, 0x09, 0x78, 0xba, 0x09, 0x0f, 0xeb, 0x30, 0x8e, 0x99, 0xac, 0x68, 0x2e, 0x83, 0x6c, 0x8a,
-	0x12, 0x88, 0x09, 0x26, 0x91, 0xa4, 0xfd, 0xc4, 0x1f, 0x19, 0xaa, 0xef, 0xde, 0x17, 0xb8, 0xb8,
-	0x78, 0x53, 0x5e, 0xfc, 0x58, 0x7a, 0xfb, 0x17, 0x43, 0x16, 0x6c, 0x74, 0x49, 0xa8, 0x0b, 0x7e,
-	0x05, 0x9e, 0x87, 0x09, 0xed, 0x8e, 0xe4, 0x58, 0x70, 0x74, 0x8b, 0xc5, 0x20, 0x1a, 0xca, 0xaa,
-	0x4e, 0x31, 0xf1, 0x1a, 0x42, 0x88, 0x3f, 0xff, 0x5e, 0x2c, 0x45, 0x98, 0xb7, 0xbb, 0x2d, 0x27,
-	0xa0, 0x89, 0x9e, 0x61, 0xfa, 0xa7, 0xcc, 0xc2, 0x3d, 0x3d, 0x96, 0x44, 0x00, 0xfb, 0xfe, 0xea,
-	0x78, 0x6d, 0x3e, 0x46, 0x11, 0x0c, 0x7a, 0x4d, 0x31, 0xca, 0x98, 0x52, 0xf1, 0xa0, 0xe2, 0x03,
-	0xf9, 0x8c, 0xd0, 0xdb, 0x7f, 0x4b, 0xd9, 0x24, 0x2d, 0x4c, 0x50, 0xa8, 0xf8, 0xbc, 0x01, 0x5e,
-	0x0c, 0x04, 0xdf, 0xe6, 0x64, 0x1b, 0x5f, 0x90, 0x66, 0x7f, 0x60, 0xbd, 0x4e, 0xfc, 0xd1, 0xb3,
-	0x40, 0x7c, 0x8c, 0xa6, 0x1d, 0x80, 0x5c, 0x2d, 0x8e, 0xe9, 0x41, 0x2d, 0x8e, 0x37, 0x11, 0x63,
-	0x30, 0x42, 0x4c, 0x69, 0xab, 0xfa, 0xd1, 0xbd, 0x55, 0x38, 0x9a, 0xc1, 0xd3, 0x53, 0xd9, 0x5f,
-	0x83, 0x82, 0x78, 0xbb, 0x1d, 0x8e, 0x42, 0xed, 0xf9, 0x18, 0xf5, 0xb4, 0xd3, 0x34, 0xc1, 0x93,
-	0x3d, 0xd4, 0x53, 0xaa, 0xc9, 0xfa, 0xf2, 0x7f, 0x75, 0xe3, 0x3f, 0xd5, 0xb6, 0x54, 0xed, 0xdb,
-	0x2a, 0xd8, 0x3f, 0x1a, 0x20, 0x37, 0xe1, 0x1d, 0x14, 0xf7, 0xc0, 0x6c, 0xa2, 0x2d, 0x12, 0xc0,
-	0xbc, 0xb7, 0xfa, 0xcf, 0x79, 0xd1, 0xf4, 0xe1, 0xc1, 0x70, 0xd0, 0x29, 0xb7, 0xb8, 0x88, 0x39,
-	0x4c, 0x62, 0x4c, 0x50, 0xf3, 0x4b, 0x46, 0x89, 0x3f, 0x8c, 0x7b, 0x58, 0xa3, 0xa6, 0xc2, 0xf1,
-	0xde, 0x3b, 0xf9, 0xd3, 0xca, 0x9c, 0x5c, 0x58, 0xc6, 0xd9, 0x85, 0x65, 0xfc, 0x71, 0x61, 0x19,
-	0xdf, 0x5d, 0x5a, 0x99, 0xb3, 0x4b, 0x2b, 0xf3, 0xdb, 0xa5, 0x95, 0xf9, 0x62, 0xf5, 0x9a, 0x6a,
-	0xea, 0x94, 0x25, 0xdb, 0x83, 0x8f, 0x78, 0xe8, 0x1e, 0xaa, 0x8f, 0xb9, 0x54, 0x4e, 0x6b, 0x46,
-	0x4e, 0x93, 0xb7, 0xfe, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x6c, 0xf0, 0x2f, 0x8c, 0x6b, 0x08, 0x00,
-	0x00,
+	// 830 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xd5, 0x56, 0x4d, 0x4f, 0x13, 0x41,
+	0x18, 0xa6, 0x50, 0x91, 0x0e, 0x1f, 0xe2, 0x06, 0x49, 0x0b, 0xa4, 0x35, 0xab, 0x62, 0x35, 0x61,
+	0x1b, 0xd0, 0x53, 0x63, 0x34, 0xdd, 0x62, 0x95, 0x08, 0xc6, 0x2c, 0x1a, 0x88, 0x97, 0x66, 0xba,
+	0x3b, 0x6c, 0x47, 0xba, 0x3b, 0xcd, 0xce, 0x16, 0x28, 0xc6, 0x78, 0xf7, 0xe4, 0xd9, 0x93, 0x37,
+	0x8d, 0x27, 0x63, 0xf8, 0x11, 0xc4, 0xc4, 0x84, 0x78, 0xf2, 0x84, 0x8a, 0x07, 0xff, 0x81, 0x07,
+	0x4f, 0xce, 0x57, 0x0b, 0x85, 0x42, 0x90, 0x93, 0x1e, 0x76, 0xba, 0xf3, 0x7e, 0x3e, 0xcf, 0x3b,
+	0xef, 0xbc, 0x5d, 0x30, 0x66, 0x13, 0xea, 0xad, 0x42, 0xea, 0x65, 0xc4, 0xb2, 0x32, 0x99, 0x81,
+	0xb5, 0xb0, 0xbc, 0x6e, 0x54, 0x03, 0x12, 0x12, 0x6d, 0xb0, 0xa1, 0x35, 0xc4, 0xb2, 0x32, 0x39,
+	0x32, 0xe4, 0x12, 0x97, 0x08, 0x65, 0x86, 0xbf, 0x49, 0xbb, 0x91, 0x04, 0xb7, 0x23, 0xb4, 0x28,
+	0x15, 0x72, 0xa3, 0x54, 0x49, 0xb9, 0xcb, 0x94, 0x20, 0x45, 0x2c, 0x7c, 0x09, 0x85, 0x70, 0x92,
+	0x59, 0x60, 0x5f, 0xe9, 0x0f, 0x02, 0x08, 0xeb, 0x55, 0xd4, 0xf0, 0x4e, 0xb8, 0x84, 0xb8, 0x15,
+	0x94, 0x11, 0xbb, 0x52, 0x6d, 0x29, 0x03, 0xfd, 0xba, 0x52, 0x9d, 0x85, 0x1e, 0xf6, 0x49, 0x46,
+	0xac, 0x52, 0xa4, 0xbf, 0x8e, 0x80, 0xe1, 0xf9, 0x90, 0x04, 0x28, 0x4f, 0x1c, 0x94, 0x63, 0x3c,
+	0x48, 0x80, 0xd7, 0x61, 0x88, 0x89, 0xaf, 0xdd, 0x04, 0xdd, 0x6e, 0x00, 0xfd, 0x90, 0xc6, 0x23,
+	0xe7, 0xbb, 0xd2, 0xbd, 0x53, 0xa3, 0xc6, 0x7e, 0x6a, 0x06, 0x77, 0xba, 0xc3, 0x6d, 0xcc, 0xd8,
+	0xe6, 0x76, 0xaa, 0xe3, 0xed, 0xcf, 0xf7, 0x57, 0x23, 0x96, 0xf2, 0xca, 0x16, 0x3e, 0x6e, 0x4c,
+	0xe8, 0x8a, 0x98, 0xac, 0x90, 0xe2, 0x62, 0xb4, 0xe4, 0x79, 0xc1, 0x9c, 0x46, 0x05, 0x91, 0xf6,
+	0x38, 0xf4, 0x8d, 0x08, 0x48, 0xe6, 0x89, 0x1f, 0x06, 0xd0, 0x0e, 0x6f, 0xaf, 0x21, 0xbb, 0xc6,
+	0xa5, 0xad, 0x50, 0xcd, 0x7d, 0x50, 0x53, 0xed, 0xa0, 0xca, 0x08, 0x87, 0xc2, 0xbd, 0x7f, 0x7c,
+	0xb8, 0x17, 0x04, 0xdc, 0xa3, 0x31, 0xb5, 0xc0, 0x9e, 0xc3, 0x2c, 0xc9, 0x3f, 0x06, 0xbb, 0x3d,
+	0x26, 0xfd, 0x39, 0x88, 0x35, 0x4f, 0x55, 0x1b, 0x05, 0x31, 0x9b, 0x6d, 0x8a, 0x65, 0x48, 0xcb,
+	0x0c, 0x63, 0x24, 0xdd, 0x67, 0xf5, 0x70, 0xc1, 0x5d, 0xb6, 0xd7, 0x1e, 0x81, 0x61, 0xec, 0xd3,
+	0x90, 0xd9, 0x61, 0x18, 0xa2, 0x62, 0x15, 0x05, 0x1e, 0xa6, 0x94, 0xc5, 0x88, 0x77, 0x32, 0xcb,
+	0xde, 0xa9, 0xe4, 0x41, 0x36, 0x39, 0xdb, 0x46, 0x94, 0x32, 0x04, 0x4b, 0xd8, 0xb5, 0xce, 0xed,
+	0xf1, 0x7e, 0xd0, 0x74, 0xd6, 0x3f, 0x74, 0x82, 0xfe, 0x16, 0xd6, 0xda, 0x75, 0xc0, 0x92, 0x4a,
+	0x81, 0x00, 0x11, 0x33, 0xe3, 0x9f, 0x37, 0x26, 0x86, 0x14, 0xe9, 0x9c, 0xe3, 0x04, 0x2c, 0xe8,
+	0x7c, 0x18, 0x60, 0xdf, 0xb5, 0x9a, 0x96, 0xda, 0x43, 0x70, 0xaa, 0x82, 0x3d, 0x1c, 0x2a, 0x34,
+	0x43, 0x86, 0xbc, 0x17, 0x46, 0xe3, 0x5e, 0x18, 0x39, 0xbf, 0x6e, 0xa6, 0x59, 0xf5, 0x2e, 0x1e,
+	0x5a, 0x74, 0x5e, 0x99, 0xf5, 0x59, 0x1e, 0x64, 0xd1, 0x92, 0xc1, 0xb4, 0x05, 0xd0, 0xbd, 0x84,
+	0x2b, 0x21, 0x0a, 0xe2, 0x5d, 0x47, 0x84, 0xbd, 0xc2, 0xc2, 0x5e, 0x3a, 0x3a, 0x6c, 0x41, 0x44,
+	0x59, 0xb4, 0x54, 0x38, 0xed, 0x06, 0x18, 0x84, 0x95, 0x0a, 0x59, 0x45, 0x4e, 0x51, 0x94, 0x1c,
+	0x3b, 0x34, 0x1e, 0x65, 0x5d, 0x11, 0x35, 0xb5, 0x9d, 0xed, 0xd4, 0x40, 0x4e, 0xea, 0xf8, 0xd1,
+	0xcc, 0x4c, 0x53, 0x6b, 0x00, 0xee, 0xd9, 0x3b, 0x54, 0xf7, 0x41, 0xff, 0x1c, 0x5c, 0xcb, 0x33,
+	0x29, 0x15, 0x78, 0xb5, 0x31, 0x10, 0x0b, 0x90, 0x07, 0xb1, 0xcf, 0x8a, 0x22, 0x8a, 0x16, 0xb5,
+	0x76, 0x05, 0xd9, 0x5b, 0xc7, 0xa5, 0xcd, 0xdb, 0x46, 0x13, 0x6d, 0xd3, 0x12, 0x5e, 0xff, 0x14,
+	0x11, 0x09, 0x0b, 0x35, 0xdf, 0x51, 0x09, 0x9f, 0x82, 0xd3, 0xd0, 0x23, 0xb5, 0xdd, 0x66, 0x4e,
+	0x18, 0xea, 0x80, 0xf8, 0x18, 0x6b, 0x36, 0x65, 0x9e, 0x8d, 0x31, 0xb3, 0xc0, 0xdb, 0xf8, 0xdd,
+	0xd7, 0x54, 0xda, 0xc5, 0x61, 0xb9, 0x56, 0x62, 0x86, 0x9e, 0x9a, 0x80, 0xea, 0x67, 0x82, 0x3a,
+	0xcb, 0x6a, 0xa8, 0x71, 0x07, 0xfa, 0x8a, 0xc1, 0xe8, 0xab, 0x20, 0x17, 0xda, 0xf5, 0x22, 0x1f,
+	0x84, 0x54, 0xde, 0x81, 0x46, 0xc6, 0x13, 0xf2, 0xd9, 0x45, 0xaf, 0xff, 0x8a, 0xf0, 0xa6, 0xf3,
+	0x4a, 0xd8, 0x47, 0x8e, 0xe4, 0x73, 0x19, 0x9c, 0xb1, 0x39, 0xdf, 0xe2, 0xfe, 0x32, 0x0e, 0x08,
+	0xb1, 0xd5, 0x90, 0xee, 0x25, 0xde, 0xf9, 0x3f, 0x10, 0x6f, 0xa1, 0xa9, 0xdb, 0x60, 0x58, 0xb4,
+	0x16, 0x5b, 0xe6, 0xd8, 0x2d, 0x82, 0x2e, 0xa2, 0xb2, 0x33, 0xb3, 0x33, 0xc7, 0xee, 0xe1, 0xdd,
+	0x09, 0xde, 0x3e, 0x94, 0xfe, 0x0c, 0x24, 0xf8, 0xcd, 0xaf, 0x86, 0xc8, 0x51, 0x9a, 0x7b, 0xa8,
+	0xae, 0x94, 0x9a, 0x06, 0xa2, 0xcb, 0x6c, 0x27, 0xba, 0x26, 0x66, 0x89, 0xf7, 0xec, 0xec, 0x5f,
+	0xe5, 0x4e, 0xca, 0xdc, 0x87, 0x65, 0xd0, 0xdf, 0xb0, 0xff, 0xb8, 0x7d, 0xda, 0x46, 0x72, 0x13,
+	0xf4, 0x78, 0x4a, 0x22, 0x00, 0xf4, 0x99, 0xe3, 0xbf, 0xb7, 0x53, 0x9a, 0x05, 0x57, 0x9b, 0x63,
+	0x52, 0xaa, 0xf9, 0x41, 0xf4, 0x62, 0xbf, 0xc2, 0x2a, 0x57, 0x7c, 0x42, 0x89, 0x6f, 0x35, 0xfd,
+	0x4e, 0x56, 0xa8, 0xb6, 0x70, 0xcc, 0xe9, 0xcd, 0xef, 0xc9, 0x8e, 0xcd, 0x9d, 0x64, 0x64, 0x8b,
+	0x3d, 0xdf, 0xd8, 0xf3, 0xf2, 0x47, 0xb2, 0x63, 0x8b, 0x3d, 0x5f, 0xd8, 0xf3, 0x78, 0x7c, 0x4f,
+	0xd7, 0xe4, 0x59, 0xbe, 0x85, 0xc6, 0x27, 0x80, 0x93, 0x59, 0x93, 0x9f, 0x02, 0xa2, 0x73, 0x4a,
+	0xdd, 0x62, 0x16, 0x5d, 0xfb, 0x03, 0xbf, 0xc9, 0x1d, 0xd1, 0xa9, 0x08, 0x00, 0x00,
 }
 
 func (m *StoreCodeAuthorization) Marshal() (dAtA []byte, err error) {
@@ -785,6 +788,24 @@ func (m *ContractGrant) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AllowedCodeIDs) > 0 {
+		dAtA3 := make([]byte, len(m.AllowedCodeIDs)*10)
+		var j2 int
+		for _, num := range m.AllowedCodeIDs {
+			for num >= 1<<7 {
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j2++
+			}
+			dAtA3[j2] = uint8(num)
+			j2++
+		}
+		i -= j2
+		copy(dAtA[i:], dAtA3[:j2])
+		i = encodeVarintAuthz(dAtA, i, uint64(j2))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.Filter != nil {
 		{
 			size, err := m.Filter.MarshalToSizedBuffer(dAtA[:i])
@@ -1105,6 +1126,13 @@ func (m *ContractGrant) Size() (n int) {
 		l = m.Filter.Size()
 		n += 1 + l + sovAuthz(uint64(l))
 	}
+	if len(m.AllowedCodeIDs) > 0 {
+		l = 0
+		for _, e := range m.AllowedCodeIDs {
+			l += sovAuthz(uint64(e))
+		}
+		n += 1 + sovAuthz(uint64(l)) + l
+	}
 	return n
 }
 
@@ -1709,6 +1737,82 @@ func (m *ContractGrant) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAuthz
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.AllowedCodeIDs = append(m.AllowedCodeIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAuthz
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthAuthz
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthAuthz
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.AllowedCodeIDs) == 0 {
+					m.AllowedCodeIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAuthz
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.AllowedCodeIDs = append(m.AllowedCodeIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedCodeIDs", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuthz(dAtA[iNdEx:])
@@ -457,6 +457,23 @@ func TestValidateContractGrant(t *testing.T) {
 			},
 			expErr: true,
 		},
+		"allowed code ids": {
+			setup: func(t *testing.T) ContractGrant {
+				return *mustGrant(randBytes(ContractAddrLen), NewMaxCallsLimit(1), NewAllowAllMessagesFilter()).WithAllowedCodeIDs(5, 7)
+			},
+		},
+		"allowed code id zero": {
+			setup: func(t *testing.T) ContractGrant {
+				return *mustGrant(randBytes(ContractAddrLen), NewMaxCallsLimit(1), NewAllowAllMessagesFilter()).WithAllowedCodeIDs(0)
+			},
+			expErr: true,
+		},
+		"duplicate allowed code ids": {
+			setup: func(t *testing.T) ContractGrant {
+				return *mustGrant(randBytes(ContractAddrLen), NewMaxCallsLimit(1), NewAllowAllMessagesFilter()).WithAllowedCodeIDs(5, 5)
+			},
+			expErr: true,
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
@@ -476,6 +493,9 @@ func TestValidateContractAuthorization(t *testing.T) {
 	invalidGrant, err := NewContractGrant(randBytes(SDKAddrLen), NewMaxCallsLimit(1), NewAllowAllMessagesFilter())
 	require.NoError(t, err)
 	invalidGrant.Limit = nil
+	otherGrant, err := NewContractGrant(randBytes(SDKAddrLen), NewMaxCallsLimit(1), NewAllowAllMessagesFilter())
+	require.NoError(t, err)
+	codeIDsGrant := validGrant.WithAllowedCodeIDs(5, 7)
 
 	specs := map[string]struct {
 		setup  func(t *testing.T) validatable
@@ -503,15 +523,38 @@ func TestValidateContractAuthorization(t *testing.T) {
 			},
 			expErr: true,
 		},
+		"contract execution - allowed code ids": {
+			setup: func(t *testing.T) validatable {
+				return NewContractExecutionAuthorization(*codeIDsGrant)
+			},
+			expErr: true,
+		},
 		"contract migration": {
 			setup: func(t *testing.T) validatable {
 				return NewContractMigrationAuthorization(*validGrant)
 			},
 		},
+		"contract migration - multiple contracts": {
+			setup: func(t *testing.T) validatable {
+				return NewContractMigrationAuthorization(*validGrant, *otherGrant)
+			},
+		},
+		"contract migration - allowed code ids": {
+			setup: func(t *testing.T) validatable {
+				return NewContractMigrationAuthorization(*codeIDsGrant)
+			},
+		},
 		"contract migration - duplicate grants": {
 			setup: func(t *testing.T) validatable {
 				return NewContractMigrationAuthorization(*validGrant, *validGrant)
 			},
+			expErr: true,
+		},
+		"contract migration - duplicate contracts": {
+			setup: func(t *testing.T) validatable {
+				return NewContractMigrationAuthorization(*validGrant, *codeIDsGrant)
+			},
+			expErr: true,
 		},
 		"contract migration - invalid grant": {
 			setup: func(t *testing.T) validatable {
@@ -710,6 +753,29 @@ func TestAcceptGrantedMessage(t *testing.T) {
 				Updated: NewContractMigrationAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
 			},
 		},
+		"accepted and updated - contract migration to allowed code": {
+			auth: NewContractMigrationAuthorization(*mustGrant(myContractAddr, NewMaxCallsLimit(2), NewAllowAllMessagesFilter()).WithAllowedCodeIDs(5, 7)),
+			msg: &MsgMigrateContract{
+				Sender:   sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				Contract: myContractAddr.String(),
+				CodeID:   7,
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept:  true,
+				Updated: NewContractMigrationAuthorization(*mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter()).WithAllowedCodeIDs(5, 7)),
+			},
+		},
+		"not accepted - contract migration to other code": {
+			auth: NewContractMigrationAuthorization(*mustGrant(myContractAddr, NewMaxCallsLimit(2), NewAllowAllMessagesFilter()).WithAllowedCodeIDs(5, 7)),
+			msg: &MsgMigrateContract{
+				Sender:   sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				Contract: myContractAddr.String(),
+				CodeID:   6,
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
@@ -873,6 +939,10 @@ func TestStoreCodeAuthorizationAccept(t *testing.T) {
 	emptyPermissionReflectCodeGrant, err := NewCodeGrant(reflectCodeHash, nil)
 	require.NoError(t, err)
 
+	myAddr, otherAddr := sdk.AccAddress(randBytes(SDKAddrLen)).String(), sdk.AccAddress(randBytes(SDKAddrLen)).String()
+	grantWildcardAnyOfAddresses, err := NewCodeGrant([]byte("*"), &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{myAddr}})
+	require.NoError(t, err)
+
 	specs := map[string]struct {
 		auth      authztypes.Authorization
 		msg       sdk.Msg
@@ -945,6 +1015,70 @@ func TestStoreCodeAuthorizationAccept(t *testing.T) {
 				Accept: false,
 			},
 		},
+		"accepted wildcard - any of addresses": {
+			auth: NewStoreCodeAuthorization(*grantWildcardAnyOfAddresses),
+			msg: &MsgStoreCode{
+				Sender:                sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode:          reflectWasmCode,
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{myAddr}},
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: true,
+			},
+		},
+		"accepted wildcard - any of addresses with nobody": {
+			auth: NewStoreCodeAuthorization(*grantWildcardAnyOfAddresses),
+			msg: &MsgStoreCode{
+				Sender:                sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode:          reflectWasmCode,
+				InstantiatePermission: &AllowNobody,
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: true,
+			},
+		},
+		"not accepted wildcard - any of addresses not a subset": {
+			auth: NewStoreCodeAuthorization(*grantWildcardAnyOfAddresses),
+			msg: &MsgStoreCode{
+				Sender:                sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode:          reflectWasmCode,
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{myAddr, otherAddr}},
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: false,
+			},
+		},
+		"not accepted wildcard - any of addresses with everybody": {
+			auth: NewStoreCodeAuthorization(*grantWildcardAnyOfAddresses),
+			msg: &MsgStoreCode{
+				Sender:                sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode:          reflectWasmCode,
+				InstantiatePermission: &AllowEverybody,
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: false,
+			},
+		},
+		"not accepted wildcard - no permission declared": {
+			auth: NewStoreCodeAuthorization(*grantWildcardAnyOfAddresses),
+			msg: &MsgStoreCode{
+				Sender:       sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode: reflectWasmCode,
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: false,
+			},
+		},
+		"accepted reflect code - empty grant permission and no permission declared": {
+			auth: NewStoreCodeAuthorization(*emptyPermissionReflectCodeGrant),
+			msg: &MsgStoreCode{
+				Sender:       sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode: reflectWasmCode,
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: true,
+			},
+		},
 		"invalid msg type": {
 			auth: NewStoreCodeAuthorization(*grantWildcard),
 			msg: &MsgMigrateContract{
@@ -28,6 +28,33 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgRemoveCodeUploadParamsAddresses{}, "wasm/MsgRemoveCodeUploadParamsAddresses", nil)
 	cdc.RegisterConcrete(&MsgStoreAndMigrateContract{}, "wasm/MsgStoreAndMigrateContract", nil)
 	cdc.RegisterConcrete(&MsgUpdateContractLabel{}, "wasm/MsgUpdateContractLabel", nil)
+	cdc.RegisterConcrete(&MsgRegisterParamSubscriber{}, "wasm/MsgRegisterParamSubscriber", nil)
+	cdc.RegisterConcrete(&MsgStoreCodeChunk{}, "wasm/MsgStoreCodeChunk", nil)
+	cdc.RegisterConcrete(&MsgCompleteStoreCode{}, "wasm/MsgCompleteStoreCode", nil)
+	cdc.RegisterConcrete(&MsgSetQueryAlias{}, "wasm/MsgSetQueryAlias", nil)
+	cdc.RegisterConcrete(&MsgSetIBCSendTimeout{}, "wasm/MsgSetIBCSendTimeout", nil)
+	cdc.RegisterConcrete(&MsgSetAdminChangeNotification{}, "wasm/MsgSetAdminChangeNotification", nil)
+	cdc.RegisterConcrete(&MsgSetContractTags{}, "wasm/MsgSetContractTags", nil)
+	cdc.RegisterConcrete(&MsgSetContractFeeSponsorship{}, "wasm/MsgSetContractFeeSponsorship", nil)
+	cdc.RegisterConcrete(&MsgUpdateContractAdmins{}, "wasm/MsgUpdateContractAdmins", nil)
+	cdc.RegisterConcrete(&MsgSetLegacyReverseIterator{}, "wasm/MsgSetLegacyReverseIterator", nil)
+	cdc.RegisterConcrete(&MsgAttestCode{}, "wasm/MsgAttestCode", nil)
+	cdc.RegisterConcrete(&MsgVoteCodeVerification{}, "wasm/MsgVoteCodeVerification", nil)
+	cdc.RegisterConcrete(&MsgImportContract{}, "wasm/MsgImportContract", nil)
+	cdc.RegisterConcrete(&MsgAddCodeUploadApproval{}, "wasm/MsgAddCodeUploadApproval", nil)
+	cdc.RegisterConcrete(&MsgRemoveCodeUploadApproval{}, "wasm/MsgRemoveCodeUploadApproval", nil)
+	cdc.RegisterConcrete(&MsgForceMigrateContract{}, "wasm/MsgForceMigrateContract", nil)
+	cdc.RegisterConcrete(&MsgScheduleSudo{}, "wasm/MsgScheduleSudo", nil)
+	cdc.RegisterConcrete(&MsgCancelScheduledSudo{}, "wasm/MsgCancelScheduledSudo", nil)
+	cdc.RegisterConcrete(&MsgScheduleContractSunset{}, "wasm/MsgScheduleContractSunset", nil)
+	cdc.RegisterConcrete(&MsgCancelContractSunset{}, "wasm/MsgCancelContractSunset", nil)
+	cdc.RegisterConcrete(&MsgProposeAdminTransfer{}, "wasm/MsgProposeAdminTransfer", nil)
+	cdc.RegisterConcrete(&MsgAcceptAdminTransfer{}, "wasm/MsgAcceptAdminTransfer", nil)
+	cdc.RegisterConcrete(&MsgCancelAdminTransfer{}, "wasm/MsgCancelAdminTransfer", nil)
+	cdc.RegisterConcrete(&MsgSuspendContract{}, "wasm/MsgSuspendContract", nil)
+	cdc.RegisterConcrete(&MsgResumeContract{}, "wasm/MsgResumeContract", nil)
+	cdc.RegisterConcrete(&MsgSetIgnoreReplyError{}, "wasm/MsgSetIgnoreReplyError", nil)
+	cdc.RegisterConcrete(&MsgDeregisterParamSubscriber{}, "wasm/MsgDeregisterParamSubscriber", nil)
 
 	cdc.RegisterInterface((*ContractInfoExtension)(nil), nil)
 
@@ -83,6 +110,33 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgRemoveCodeUploadParamsAddresses{},
 		&MsgStoreAndMigrateContract{},
 		&MsgUpdateContractLabel{},
+		&MsgRegisterParamSubscriber{},
+		&MsgStoreCodeChunk{},
+		&MsgCompleteStoreCode{},
+		&MsgSetQueryAlias{},
+		&MsgSetIBCSendTimeout{},
+		&MsgSetAdminChangeNotification{},
+		&MsgSetContractTags{},
+		&MsgSetContractFeeSponsorship{},
+		&MsgUpdateContractAdmins{},
+		&MsgSetLegacyReverseIterator{},
+		&MsgAttestCode{},
+		&MsgVoteCodeVerification{},
+		&MsgImportContract{},
+		&MsgAddCodeUploadApproval{},
+		&MsgRemoveCodeUploadApproval{},
+		&MsgForceMigrateContract{},
+		&MsgScheduleSudo{},
+		&MsgCancelScheduledSudo{},
+		&MsgScheduleContractSunset{},
+		&MsgCancelContractSunset{},
+		&MsgProposeAdminTransfer{},
+		&MsgAcceptAdminTransfer{},
+		&MsgCancelAdminTransfer{},
+		&MsgSuspendContract{},
+		&MsgResumeContract{},
+		&MsgSetIgnoreReplyError{},
+		&MsgDeregisterParamSubscriber{},
 	)
 	registry.RegisterInterface("cosmwasm.wasm.v1.ContractInfoExtension", (*ContractInfoExtension)(nil))
 
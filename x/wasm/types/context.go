@@ -24,6 +24,21 @@ const (
 	// contracts in the current tx
 	contextKeyTxContracts contextKey = iota
 
+	// block and transaction info of the first contract env in the current execution
+	contextKeyTxEnv contextKey = iota
+
+	// running hash over the contract calls of the current message
+	contextKeyExecTrace contextKey = iota
+
+	// signers of the current tx
+	contextKeyTxSigners contextKey = iota
+
+	// id of the root contract call to correlate the execution logs
+	contextKeyCorrelationID contextKey = iota
+
+	// marks a smart query via the gRPC querier
+	contextKeyGRPCQuery contextKey = iota
+
 	// contextKeyExecModeSimulation contextKey = iota
 	_
 )
@@ -101,3 +116,62 @@ func TxContractsFromContext(ctx context.Context) (TxContracts, bool) {
 	val, ok := ctx.Value(contextKeyTxContracts).(TxContracts)
 	return val, ok
 }
+
+// WithTxEnv stores the serialized block and transaction info of a contract env into the context returned
+func WithTxEnv(ctx sdk.Context, env []byte) sdk.Context {
+	return ctx.WithValue(contextKeyTxEnv, env)
+}
+
+// TxEnvFromContext reads the serialized block and transaction info of the first contract env from the context
+func TxEnvFromContext(ctx context.Context) ([]byte, bool) {
+	val, ok := ctx.Value(contextKeyTxEnv).([]byte)
+	return val, ok
+}
+
+// WithExecTrace stores the execution trace into the context returned
+func WithExecTrace(ctx sdk.Context, trace *ExecTrace) sdk.Context {
+	if trace == nil {
+		panic("exec trace must not be nil")
+	}
+	return ctx.WithValue(contextKeyExecTrace, trace)
+}
+
+// ExecTraceFromContext reads the execution trace from the context
+func ExecTraceFromContext(ctx context.Context) (*ExecTrace, bool) {
+	val, ok := ctx.Value(contextKeyExecTrace).(*ExecTrace)
+	return val, ok
+}
+
+// WithTxSigners stores the signers of the current tx into the context returned
+func WithTxSigners(ctx sdk.Context, signers []sdk.AccAddress) sdk.Context {
+	return ctx.WithValue(contextKeyTxSigners, signers)
+}
+
+// TxSignersFromContext reads the signers of the current tx from the context
+func TxSignersFromContext(ctx context.Context) ([]sdk.AccAddress, bool) {
+	val, ok := ctx.Value(contextKeyTxSigners).([]sdk.AccAddress)
+	return val, ok
+}
+
+// WithCorrelationID stores the id of the root contract call into the context returned
+func WithCorrelationID(ctx sdk.Context, id string) sdk.Context {
+	return ctx.WithValue(contextKeyCorrelationID, id)
+}
+
+// CorrelationIDFromContext reads the id of the root contract call from the context
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	val, ok := ctx.Value(contextKeyCorrelationID).(string)
+	return val, ok
+}
+
+// WithGRPCQuery marks the context returned as a smart query via the gRPC querier. Node local query
+// settings are only applied with this marker.
+func WithGRPCQuery(ctx sdk.Context) sdk.Context {
+	return ctx.WithValue(contextKeyGRPCQuery, true)
+}
+
+// IsGRPCQuery returns true when the context is marked as a smart query via the gRPC querier
+func IsGRPCQuery(ctx context.Context) bool {
+	val, _ := ctx.Value(contextKeyGRPCQuery).(bool)
+	return val
+}
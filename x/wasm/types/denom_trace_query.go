@@ -0,0 +1,20 @@
+package types
+
+// DenomTraceCustomQuery is the custom query that contracts send to resolve an ibc denom
+type DenomTraceCustomQuery struct {
+	DenomTrace *DenomTraceQuery `json:"denom_trace,omitempty"`
+}
+
+// DenomTraceQuery selects the ibc denom to resolve
+type DenomTraceQuery struct {
+	// Hash is the hex encoded hash of the ibc denom with or without the "ibc/" prefix
+	Hash string `json:"hash"`
+}
+
+// DenomTraceResponse is the response to a DenomTraceQuery
+type DenomTraceResponse struct {
+	// Path is the chain of port/channel identifiers the token was sent through, for example "transfer/channel-0"
+	Path string `json:"path"`
+	// BaseDenom is the denom on the origin chain
+	BaseDenom string `json:"base_denom"`
+}
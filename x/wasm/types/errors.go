@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 
 	errorsmod "cosmossdk.io/errors"
@@ -92,6 +94,21 @@ var (
 
 	// ErrExceedMaxCallDepth error if max message stack size is exceeded
 	ErrExceedMaxCallDepth = errorsmod.Register(DefaultCodespace, 30, "max call depth exceeded")
+
+	// ErrMissingCapabilities error if a code requires capabilities that are not available on the chain
+	ErrMissingCapabilities = errorsmod.Register(DefaultCodespace, 32, "missing capabilities")
+
+	// ErrInvalidProvenance error for a code provenance signature that does not verify
+	ErrInvalidProvenance = errorsmod.Register(DefaultCodespace, 33, "invalid code provenance")
+
+	// ErrVMResponseTooLarge error for a contract response that exceeds a response limit
+	ErrVMResponseTooLarge = errorsmod.Register(DefaultCodespace, 34, "contract response too large")
+
+	// ErrContractInactive error for a contract that was deactivated
+	ErrContractInactive = errorsmod.Register(DefaultCodespace, 35, "contract inactive")
+
+	// ErrContractSuspended error for a contract that is suspended by the admin or the governance authority
+	ErrContractSuspended = errorsmod.Register(DefaultCodespace, 36, "contract suspended")
 )
 
 // WasmVMErrorable mapped error type in wasmvm and are not redacted
@@ -186,3 +203,102 @@ func (e DeterministicError) Unwrap() error {
 func (e DeterministicError) Cause() error {
 	return e.Unwrap()
 }
+
+// Response limit names of the ResponseTooLargeError
+const (
+	ResponseLimitData       = "data"
+	ResponseLimitEvents     = "events"
+	ResponseLimitAttributes = "attributes"
+)
+
+var _ error = ResponseTooLargeError{}
+
+// ResponseTooLargeError is the typed ErrVMResponseTooLarge that carries the limit that was hit.
+// The message is deterministic.
+type ResponseTooLargeError struct {
+	// Limit is the name of the limit, one of the ResponseLimit constants
+	Limit string
+	// Max is the effective cap
+	Max uint64
+	// Actual is the size or count of the response
+	Actual uint64
+}
+
+func (e ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s: %s %d exceeds limit %d", ErrVMResponseTooLarge.Error(), e.Limit, e.Actual, e.Max)
+}
+
+// Unwrap implements the built-in errors.Unwrap
+func (e ResponseTooLargeError) Unwrap() error {
+	return ErrVMResponseTooLarge
+}
+
+// Cause is the same as unwrap but used by ABCIInfo
+func (e ResponseTooLargeError) Cause() error {
+	return e.Unwrap()
+}
+
+var _ error = ContractSunsetError{}
+
+// ContractSunsetError is the typed ErrContractInactive for a contract that was deactivated at its sunset height.
+// The message is deterministic.
+type ContractSunsetError struct {
+	// SunsetHeight is the block height at whose end the contract was deactivated
+	SunsetHeight int64
+}
+
+func (e ContractSunsetError) Error() string {
+	return fmt.Sprintf("%s: sunset at height %d", ErrContractInactive.Error(), e.SunsetHeight)
+}
+
+// Unwrap implements the built-in errors.Unwrap
+func (e ContractSunsetError) Unwrap() error {
+	return ErrContractInactive
+}
+
+// Cause is the same as unwrap but used by ABCIInfo
+func (e ContractSunsetError) Cause() error {
+	return e.Unwrap()
+}
+
+// EventIndexResponseAttributes is the event index of the InvalidEventAttributeError for the attributes of
+// the contract response that are emitted with the wasm module event.
+const EventIndexResponseAttributes = -1
+
+// Rejection reasons of the InvalidEventAttributeError
+const (
+	EventAttributeInvalidUTF8Key   = "key is not valid utf-8"
+	EventAttributeInvalidUTF8Value = "value is not valid utf-8"
+	EventAttributeKeyTooLong       = "key exceeds size limit"
+	EventAttributeValueTooLong     = "value exceeds size limit"
+)
+
+var _ error = InvalidEventAttributeError{}
+
+// InvalidEventAttributeError is the typed ErrInvalidEvent for a contract event attribute that was rejected.
+// Attributes are never truncated so that all nodes and indexers see the same bytes. The message is deterministic.
+type InvalidEventAttributeError struct {
+	// EventIndex is the position of the event in the contract response or EventIndexResponseAttributes
+	EventIndex int
+	// Key is the attribute key as returned by the contract
+	Key string
+	// Reason is one of the EventAttribute constants
+	Reason string
+}
+
+func (e InvalidEventAttributeError) Error() string {
+	if e.EventIndex == EventIndexResponseAttributes {
+		return fmt.Sprintf("%s: response attribute %q: %s", ErrInvalidEvent.Error(), e.Key, e.Reason)
+	}
+	return fmt.Sprintf("%s: event %d attribute %q: %s", ErrInvalidEvent.Error(), e.EventIndex, e.Key, e.Reason)
+}
+
+// Unwrap implements the built-in errors.Unwrap
+func (e InvalidEventAttributeError) Unwrap() error {
+	return ErrInvalidEvent
+}
+
+// Cause is the same as unwrap but used by ABCIInfo
+func (e InvalidEventAttributeError) Cause() error {
+	return e.Unwrap()
+}
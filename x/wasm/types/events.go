@@ -14,19 +14,55 @@ const (
 	// CustomContractEventPrefix contracts can create custom events. To not mix them with other system events they got the `wasm-` prefix.
 	CustomContractEventPrefix = "wasm-"
 
-	EventTypeStoreCode              = "store_code"
-	EventTypeInstantiate            = "instantiate"
-	EventTypeExecute                = "execute"
-	EventTypeMigrate                = "migrate"
-	EventTypePinCode                = "pin_code"
-	EventTypeUnpinCode              = "unpin_code"
-	EventTypeSudo                   = "sudo"
-	EventTypeReply                  = "reply"
-	EventTypeGovContractResult      = "gov_contract_result"
-	EventTypeUpdateContractAdmin    = "update_contract_admin"
-	EventTypeUpdateContractLabel    = "update_contract_label"
-	EventTypeUpdateCodeAccessConfig = "update_code_access_config"
-	EventTypePacketRecv             = "ibc_packet_received"
+	EventTypeStoreCode               = "store_code"
+	EventTypeInstantiate             = "instantiate"
+	EventTypeExecute                 = "execute"
+	EventTypeMigrate                 = "migrate"
+	EventTypePinCode                 = "pin_code"
+	EventTypeUnpinCode               = "unpin_code"
+	EventTypeSudo                    = "sudo"
+	EventTypeReply                   = "reply"
+	EventTypeGovContractResult       = "gov_contract_result"
+	EventTypeUpdateContractAdmin     = "update_contract_admin"
+	EventTypeUpdateContractLabel     = "update_contract_label"
+	EventTypeUpdateCodeAccessConfig  = "update_code_access_config"
+	EventTypePacketRecv              = "ibc_packet_received"
+	EventTypeRegisterParamSubscriber = "register_param_subscriber"
+	EventTypeParamSubscriberFailed   = "param_subscriber_failed"
+	EventTypeDeregisterParamSub      = "deregister_param_subscriber"
+	EventTypeGasReport               = "gas_report"
+	EventTypeStoreCodeChunk          = "store_code_chunk"
+	EventTypeCodeUploadExpired       = "code_upload_expired"
+	EventTypeSetQueryAlias           = "set_query_alias"
+	EventTypeRemoveQueryAlias        = "remove_query_alias"
+	EventTypeSetIBCSendTimeout       = "set_ibc_send_timeout"
+	EventTypeSetAdminChangeNotify    = "set_admin_change_notification"
+	EventTypeReplyFailed             = "reply_failed"
+	EventTypeSetIgnoreReplyError     = "set_ignore_reply_error"
+	EventTypeSetLegacyReverseIter    = "set_legacy_reverse_iterator"
+	EventTypeMessageFee              = "message_fee"
+	EventTypeAttestCode              = "attest_code"
+	EventTypeImportContract          = "import_contract"
+	EventTypeSetContractTags         = "set_contract_tags"
+	EventTypeSetFeeSponsorship       = "set_contract_fee_sponsorship"
+	EventTypeFeeSponsored            = "contract_fee_sponsored"
+	EventTypeExecTrace               = "exec_trace"
+	EventTypeAddUploadApproval       = "add_code_upload_approval"
+	EventTypeRemoveUploadApproval    = "remove_code_upload_approval"
+	EventTypeForceMigrate            = "force_migrate_contract"
+	EventTypeVoteCodeVerification    = "vote_code_verification"
+	EventTypeScheduleSudo            = "schedule_sudo"
+	EventTypeCancelScheduledSudo     = "cancel_scheduled_sudo"
+	EventTypeScheduledSudoExecuted   = "scheduled_sudo_executed"
+	EventTypeScheduledSudoFailed     = "scheduled_sudo_failed"
+	EventTypeScheduledSudoMissed     = "scheduled_sudo_missed"
+	EventTypeScheduleContractSunset  = "schedule_contract_sunset"
+	EventTypeCancelContractSunset    = "cancel_contract_sunset"
+	EventTypeContractSunset          = "contract_sunset"
+	EventTypeProposeAdminTransfer    = "propose_admin_transfer"
+	EventTypeCancelAdminTransfer     = "cancel_admin_transfer"
+	EventTypeSuspendContract         = "suspend_contract"
+	EventTypeResumeContract          = "resume_contract"
 	// add new types to IsAcceptedEventOnRecvPacketErrorAck
 )
 
@@ -67,4 +103,36 @@ const (
 	AttributeKeyAuthorizedAddresses = "authorized_addresses"
 	AttributeKeyAckSuccess          = "success"
 	AttributeKeyAckError            = "error"
+	AttributeKeyParamSubspace       = "param_subspace"
+	AttributeKeyError               = "error"
+	AttributeKeyEntryPoint          = "entry_point"
+	AttributeKeyGasLimit            = "gas_limit"
+	AttributeKeyGasRemaining        = "gas_remaining"
+	AttributeKeyGasUsedExternally   = "gas_used_externally"
+	AttributeKeyGasUsedInternally   = "gas_used_internally"
+	AttributeKeyUploadID            = "upload_id"
+	AttributeKeyChunkIndex          = "chunk_index"
+	AttributeKeyQueryAliasName      = "query_alias_name"
+	AttributeKeyTimeoutTimestamp    = "timeout_timestamp"
+	AttributeKeyEnabled             = "enabled"
+	AttributeKeyAdminChangeNotified = "admin_change_notified"
+	AttributeKeyReplyID             = "reply_id"
+	AttributeKeyMsgTypeURL          = "msg_type_url"
+	AttributeKeyAttester            = "attester"
+	AttributeKeyCommit              = "commit"
+	AttributeKeyTags                = "tags"
+	AttributeKeyIBCPortID           = "ibc_port_id"
+	AttributeKeyMaxFeePerTx         = "max_fee_per_tx"
+	AttributeKeyMaxFeePerBlock      = "max_fee_per_block"
+	AttributeKeyExecTraceHash       = "exec_trace_hash"
+	AttributeKeyConsumeOnce         = "consume_once"
+	AttributeKeyOldCodeID           = "old_code_id"
+	AttributeKeyAdmin               = "admin_address"
+	AttributeKeyVoter               = "voter"
+	AttributeKeyVerdict             = "verdict"
+	AttributeKeyVerificationStatus  = "verification_status"
+	AttributeKeyScheduledSudoID     = "scheduled_sudo_id"
+	AttributeKeyScheduledHeight     = "scheduled_height"
+	AttributeKeySunsetHeight        = "sunset_height"
+	AttributeKeyPendingAdmin        = "pending_admin"
 )
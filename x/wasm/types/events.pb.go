@@ -0,0 +1,503 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmwasm/wasm/v1/events.proto
+
+package types
+
+import (
+	fmt "fmt"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = proto.Marshal
+	_ = fmt.Errorf
+	_ = math.Inf
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// EventBlockWasmSummary is emitted at the end of every block with the
+// aggregated wasm activity of the successful transactions in the block
+type EventBlockWasmSummary struct {
+	// Height is the block height
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// Instantiates is the number of contract instantiations
+	Instantiates uint64 `protobuf:"varint,2,opt,name=instantiates,proto3" json:"instantiates,omitempty"`
+	// Executes is the number of contract executions
+	Executes uint64 `protobuf:"varint,3,opt,name=executes,proto3" json:"executes,omitempty"`
+	// Migrates is the number of contract migrations
+	Migrates uint64 `protobuf:"varint,4,opt,name=migrates,proto3" json:"migrates,omitempty"`
+	// VMGasUsed is the gas consumed by all contract calls in the VM, in sdk gas
+	// units
+	VMGasUsed uint64 `protobuf:"varint,5,opt,name=vm_gas_used,json=vmGasUsed,proto3" json:"vm_gas_used,omitempty"`
+	// ContractsTouched is the number of unique contracts that were called
+	ContractsTouched uint64 `protobuf:"varint,6,opt,name=contracts_touched,json=contractsTouched,proto3" json:"contracts_touched,omitempty"`
+}
+
+func (m *EventBlockWasmSummary) Reset()         { *m = EventBlockWasmSummary{} }
+func (m *EventBlockWasmSummary) String() string { return proto.CompactTextString(m) }
+func (*EventBlockWasmSummary) ProtoMessage()    {}
+func (*EventBlockWasmSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_30d8967b22c1787d, []int{0}
+}
+
+func (m *EventBlockWasmSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *EventBlockWasmSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventBlockWasmSummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *EventBlockWasmSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventBlockWasmSummary.Merge(m, src)
+}
+
+func (m *EventBlockWasmSummary) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *EventBlockWasmSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventBlockWasmSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventBlockWasmSummary proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*EventBlockWasmSummary)(nil), "cosmwasm.wasm.v1.EventBlockWasmSummary")
+}
+
+func init() { proto.RegisterFile("cosmwasm/wasm/v1/events.proto", fileDescriptor_30d8967b22c1787d) }
+
+var fileDescriptor_30d8967b22c1787d = []byte{
+	// 307 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0x4f, 0x4a, 0xc3, 0x40,
+	0x18, 0xc5, 0x33, 0xb6, 0x16, 0x3b, 0x2a, 0xd4, 0x41, 0x25, 0x14, 0x1c, 0x4b, 0x17, 0x52, 0x10,
+	0x1b, 0x8a, 0x37, 0xa8, 0x88, 0x6e, 0xdc, 0xd4, 0x3f, 0x05, 0x37, 0x65, 0x3a, 0x19, 0x26, 0x41,
+	0x27, 0x53, 0xf2, 0x4d, 0x62, 0x7b, 0x0b, 0x8f, 0xe1, 0x51, 0xba, 0xec, 0xd2, 0x95, 0x68, 0xb2,
+	0xf0, 0x1a, 0x92, 0x49, 0x53, 0x70, 0xf3, 0xf1, 0xde, 0xfb, 0xcd, 0x9b, 0xc5, 0xc3, 0x27, 0x5c,
+	0x83, 0x7a, 0x63, 0xa0, 0x3c, 0x7b, 0xd2, 0x81, 0x27, 0x52, 0x11, 0x19, 0xe8, 0xcf, 0x62, 0x6d,
+	0x34, 0x69, 0x55, 0xb8, 0x6f, 0x4f, 0x3a, 0x68, 0x1f, 0x4a, 0x2d, 0xb5, 0x85, 0x5e, 0xa1, 0xca,
+	0x77, 0xdd, 0x5f, 0x84, 0x8f, 0xae, 0x8b, 0xe2, 0xf0, 0x55, 0xf3, 0x97, 0x31, 0x03, 0x75, 0x9f,
+	0x28, 0xc5, 0xe2, 0x05, 0x39, 0xc6, 0x8d, 0x40, 0x84, 0x32, 0x30, 0x2e, 0xea, 0xa0, 0x5e, 0x6d,
+	0xb4, 0x76, 0xa4, 0x8b, 0xf7, 0xc2, 0x08, 0x0c, 0x8b, 0x4c, 0xc8, 0x8c, 0x00, 0x77, 0xab, 0x83,
+	0x7a, 0xf5, 0xd1, 0xbf, 0x8c, 0xb4, 0xf1, 0x8e, 0x98, 0x0b, 0x9e, 0x14, 0xbc, 0x66, 0xf9, 0xc6,
+	0x17, 0x4c, 0x85, 0x32, 0xb6, 0xdd, 0x7a, 0xc9, 0x2a, 0x4f, 0x2e, 0xf0, 0x6e, 0xaa, 0x26, 0x92,
+	0xc1, 0x24, 0x01, 0xe1, 0xbb, 0xdb, 0x05, 0x1e, 0xee, 0x67, 0x5f, 0xa7, 0xcd, 0xa7, 0xbb, 0x1b,
+	0x06, 0x8f, 0x20, 0xfc, 0x51, 0x33, 0x55, 0x6b, 0x49, 0xce, 0xf1, 0x01, 0xd7, 0x91, 0x89, 0x19,
+	0x37, 0x30, 0x31, 0x3a, 0xe1, 0x81, 0xf0, 0xdd, 0x86, 0xfd, 0xb3, 0xb5, 0x01, 0x0f, 0x65, 0x3e,
+	0xbc, 0x5d, 0xfe, 0x50, 0xe7, 0x23, 0xa3, 0x68, 0x99, 0x51, 0xb4, 0xca, 0x28, 0xfa, 0xce, 0x28,
+	0x7a, 0xcf, 0xa9, 0xb3, 0xca, 0xa9, 0xf3, 0x99, 0x53, 0xe7, 0xf9, 0x4c, 0x86, 0x26, 0x48, 0xa6,
+	0x7d, 0xae, 0x95, 0x77, 0xa5, 0x41, 0x8d, 0xab, 0x75, 0x7d, 0x6f, 0x5e, 0xae, 0x6c, 0x16, 0x33,
+	0x01, 0xd3, 0x86, 0x9d, 0xee, 0xf2, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x51, 0x25, 0x47, 0xaa, 0x83,
+	0x01, 0x00, 0x00,
+}
+
+func (this *EventBlockWasmSummary) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EventBlockWasmSummary)
+	if !ok {
+		that2, ok := that.(EventBlockWasmSummary)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Height != that1.Height {
+		return false
+	}
+	if this.Instantiates != that1.Instantiates {
+		return false
+	}
+	if this.Executes != that1.Executes {
+		return false
+	}
+	if this.Migrates != that1.Migrates {
+		return false
+	}
+	if this.VMGasUsed != that1.VMGasUsed {
+		return false
+	}
+	if this.ContractsTouched != that1.ContractsTouched {
+		return false
+	}
+	return true
+}
+
+func (m *EventBlockWasmSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventBlockWasmSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventBlockWasmSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ContractsTouched != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.ContractsTouched))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.VMGasUsed != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.VMGasUsed))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Migrates != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.Migrates))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Executes != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.Executes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Instantiates != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.Instantiates))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintEvents(dAtA []byte, offset int, v uint64) int {
+	offset -= sovEvents(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+
+func (m *EventBlockWasmSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovEvents(uint64(m.Height))
+	}
+	if m.Instantiates != 0 {
+		n += 1 + sovEvents(uint64(m.Instantiates))
+	}
+	if m.Executes != 0 {
+		n += 1 + sovEvents(uint64(m.Executes))
+	}
+	if m.Migrates != 0 {
+		n += 1 + sovEvents(uint64(m.Migrates))
+	}
+	if m.VMGasUsed != 0 {
+		n += 1 + sovEvents(uint64(m.VMGasUsed))
+	}
+	if m.ContractsTouched != 0 {
+		n += 1 + sovEvents(uint64(m.ContractsTouched))
+	}
+	return n
+}
+
+func sovEvents(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+
+func sozEvents(x uint64) (n int) {
+	return sovEvents(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+
+func (m *EventBlockWasmSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventBlockWasmSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventBlockWasmSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Instantiates", wireType)
+			}
+			m.Instantiates = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Instantiates |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Executes", wireType)
+			}
+			m.Executes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Executes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Migrates", wireType)
+			}
+			m.Migrates = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Migrates |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VMGasUsed", wireType)
+			}
+			m.VMGasUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VMGasUsed |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractsTouched", wireType)
+			}
+			m.ContractsTouched = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContractsTouched |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func skipEvents(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthEvents
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupEvents
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthEvents
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthEvents        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowEvents          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupEvents = fmt.Errorf("proto: unexpected end of group")
+)
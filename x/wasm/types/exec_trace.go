@@ -0,0 +1,38 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// ExecTrace is a running hash over the contract calls of a message. Every call adds the entry point, the hash of
+// the message, the hash of the returned data, the number of events and the number of submessages.
+// All inputs are consensus data so that the hash is the same on all nodes for a deterministic execution.
+type ExecTrace struct {
+	hash []byte
+}
+
+// NewExecTrace constructor
+func NewExecTrace() *ExecTrace {
+	return &ExecTrace{hash: make([]byte, sha256.Size)}
+}
+
+// Add updates the running hash with the contract call
+func (t *ExecTrace) Add(entryPoint string, msg, data []byte, eventCount, subMsgCount int) {
+	msgHash := sha256.Sum256(msg)
+	dataHash := sha256.Sum256(data)
+	h := sha256.New()
+	h.Write(t.hash)
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(entryPoint))))
+	h.Write([]byte(entryPoint))
+	h.Write(msgHash[:])
+	h.Write(dataHash[:])
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(eventCount)))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(subMsgCount)))
+	t.hash = h.Sum(nil)
+}
+
+// Hash returns the current hash
+func (t ExecTrace) Hash() []byte {
+	return t.hash
+}
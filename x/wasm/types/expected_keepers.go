@@ -3,6 +3,8 @@ package types
 import (
 	"context"
 
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	ibctransfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
 	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
 	connectiontypes "github.com/cosmos/ibc-go/v10/modules/core/03-connection/types"
 	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
@@ -78,6 +80,9 @@ type StakingKeeper interface {
 type ChannelKeeper interface {
 	GetChannel(ctx sdk.Context, srcPort, srcChan string) (channel channeltypes.Channel, found bool)
 	GetNextSequenceSend(ctx sdk.Context, portID, channelID string) (uint64, bool)
+	GetNextSequenceRecv(ctx sdk.Context, portID, channelID string) (uint64, bool)
+	GetNextSequenceAck(ctx sdk.Context, portID, channelID string) (uint64, bool)
+	IteratePacketCommitmentAtChannel(ctx sdk.Context, portID, channelID string, cb func(_, _ string, sequence uint64, hash []byte) bool)
 	ChanCloseInit(ctx sdk.Context, portID, channelID string) error
 	GetAllChannels(ctx sdk.Context) (channels []channeltypes.IdentifiedChannel)
 	SetChannel(ctx sdk.Context, portID, channelID string, channel channeltypes.Channel)
@@ -125,3 +130,8 @@ type ConnectionKeeper interface {
 type ICS20TransferPortSource interface {
 	GetPort(ctx sdk.Context) string
 }
+
+// ICS20DenomSource is a subset of the ibc transfer keeper to resolve ibc denoms.
+type ICS20DenomSource interface {
+	GetDenom(ctx sdk.Context, denomHash cmtbytes.HexBytes) (ibctransfertypes.Denom, bool)
+}
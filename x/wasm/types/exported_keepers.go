@@ -14,19 +14,39 @@ import (
 type ViewKeeper interface {
 	GetContractHistory(ctx context.Context, contractAddr sdk.AccAddress) []ContractCodeHistoryEntry
 	QuerySmart(ctx context.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error)
+	AuthenticateSmartQuery(ctx context.Context, contractAddr sdk.AccAddress, queryData []byte, signer sdk.AccAddress, signature, blockHash []byte) error
 	QueryRaw(ctx context.Context, contractAddress sdk.AccAddress, key []byte) []byte
 	HasContractInfo(ctx context.Context, contractAddress sdk.AccAddress) bool
+	GetAddressType(ctx context.Context, addr sdk.AccAddress) AddressType
 	GetContractInfo(ctx context.Context, contractAddress sdk.AccAddress) *ContractInfo
 	IterateContractInfo(ctx context.Context, cb func(sdk.AccAddress, ContractInfo) bool)
 	IterateContractsByCreator(ctx context.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool)
+	IterateContractsByAdmin(ctx context.Context, admin sdk.AccAddress, cb func(address sdk.AccAddress) bool)
 	IterateContractsByCode(ctx context.Context, codeID uint64, cb func(address sdk.AccAddress) bool)
+	GetContractParent(ctx context.Context, contractAddress sdk.AccAddress) sdk.AccAddress
+	GetQueryAlias(ctx context.Context, contractAddress sdk.AccAddress, name string) []byte
+	GetQueryAliasCacheTTL(ctx context.Context, contractAddress sdk.AccAddress, name string) uint32
+	IterateContractUsage(ctx context.Context, contractAddress sdk.AccAddress, cb func(EntryPointUsage) bool)
+	GetExecutionReceipt(ctx context.Context, contractAddress sdk.AccAddress) *ExecutionReceipt
+	GetScheduledSudo(ctx context.Context, contractAddress sdk.AccAddress, id uint64) *ScheduledSudo
+	GetContractChannelStatus(ctx context.Context, contractAddress sdk.AccAddress, channelID string) (*QueryContractChannelStatusResponse, error)
+	GetFeeSponsorshipUsedInBlock(ctx context.Context, contractAddress sdk.AccAddress) sdk.Coins
 	IterateContractState(ctx context.Context, contractAddress sdk.AccAddress, cb func(key, value []byte) bool)
+	ContractStateDiff(contractAddress sdk.AccAddress, heightA, heightB int64, startKey []byte, limit uint64, includeValues bool) ([]StateDiffEntry, []byte, error)
 	GetCodeInfo(ctx context.Context, codeID uint64) *CodeInfo
 	IterateCodeInfos(ctx context.Context, cb func(uint64, CodeInfo) bool)
+	IterateCodesByChecksum(ctx context.Context, checksum []byte, cb func(codeID uint64) bool)
+	IterateCodesByCreator(ctx context.Context, creator sdk.AccAddress, cb func(codeID uint64) bool)
 	GetByteCode(ctx context.Context, codeID uint64) ([]byte, error)
 	IsPinnedCode(ctx context.Context, codeID uint64) bool
+	GetCodeVerificationStatus(ctx context.Context, codeID uint64) CodeVerificationStatus
+	IterateCodeVerificationVotes(ctx context.Context, codeID uint64, cb func(CodeVerificationVote) bool)
+	GetWasmStats(ctx context.Context) WasmStats
+	GetContractCountByCode(ctx context.Context, codeID uint64) uint64
 	GetParams(ctx context.Context) Params
+	IterateParamsHistory(ctx context.Context, cb func(ParamsChange) bool)
 	GetWasmLimits() wasmvmtypes.WasmLimits
+	GetGasRegister() GasRegister
 }
 
 // ContractOpsKeeper contains mutable operations on a contract.
@@ -136,4 +156,7 @@ type IBCContractKeeper interface {
 	StoreAsyncAckPacket(ctx context.Context, packet channeltypes.Packet) error
 	// DeleteAsyncAckPacket deletes a previously stored packet. See StoreAsyncAckPacket for more details.
 	DeleteAsyncAckPacket(ctx context.Context, portID, channelID string, sequence uint64)
+	// GetIBCSendTimeout returns the default timeout (in nanoseconds) relative to the block time for IBC packets
+	// sent by the contract or 0 when not set.
+	GetIBCSendTimeout(ctx context.Context, contractAddress sdk.AccAddress) uint64
 }
@@ -59,6 +59,15 @@ const (
 	DefaultPerCustomEventCost uint64 = 20
 	// DefaultEventAttributeDataFreeTier number of bytes of total attribute data we do not charge.
 	DefaultEventAttributeDataFreeTier = 100
+	// DefaultProvenanceVerifyCost is how much SDK gas we charge to verify a code provenance signature.
+	// This matches the default secp256k1 signature verification costs of the auth module.
+	DefaultProvenanceVerifyCost uint64 = 1_000
+	// DefaultAttestCodeCost is how much SDK gas we charge for a code attestation, on top of the storage costs.
+	// This bounds the spam of the permissionless registry.
+	DefaultAttestCodeCost uint64 = 20_000
+	// DefaultQueryResponseDataCost is how much SDK gas is charged *per byte* of a Stargate or gRPC query response
+	// on top of the gas that the query handler consumed. This is used with len(response).
+	DefaultQueryResponseDataCost uint64 = 1
 )
 
 // default: 0.15 gas.
@@ -84,6 +93,8 @@ type GasRegister interface {
 	ReplyCosts(discount bool, reply wasmvmtypes.Reply) storetypes.Gas
 	// EventCosts costs to persist an event
 	EventCosts(attrs []wasmvmtypes.EventAttribute, events wasmvmtypes.Array[wasmvmtypes.Event]) storetypes.Gas
+	// QueryResponseCosts costs for the response of a Stargate or gRPC query of a contract
+	QueryResponseCosts(byteLength int) storetypes.Gas
 	// ToWasmVMGas converts from Cosmos SDK gas units to [CosmWasm gas] (aka. wasmvm gas)
 	//
 	// [CosmWasm gas]: https://github.com/CosmWasm/cosmwasm/blob/v1.3.1/docs/GAS.md
@@ -94,6 +105,12 @@ type GasRegister interface {
 	FromWasmVMGas(source uint64) storetypes.Gas
 }
 
+// EventGasConstantsSource is implemented by gas registers that expose the constants of their event costs
+type EventGasConstantsSource interface {
+	// EventGasConstants returns the constants that EventCosts is calculated with
+	EventGasConstants() EventGasConstants
+}
+
 // WasmGasRegisterConfig config type
 type WasmGasRegisterConfig struct {
 	// InstanceCost are charged when interacting with a Wasm contract.
@@ -126,6 +143,9 @@ type WasmGasRegisterConfig struct {
 	ContractMessageDataCost storetypes.Gas
 	// CustomEventCost cost per custom event
 	CustomEventCost uint64
+	// QueryResponseDataCost SDK gas charged *per byte* of a Stargate or gRPC query response
+	// This is used with len(response)
+	QueryResponseDataCost storetypes.Gas
 }
 
 // DefaultGasRegisterConfig default values
@@ -141,6 +161,7 @@ func DefaultGasRegisterConfig() WasmGasRegisterConfig {
 		EventAttributeDataFreeTier: DefaultEventAttributeDataFreeTier,
 		ContractMessageDataCost:    DefaultContractMessageDataCost,
 		UncompressCost:             DefaultPerByteUncompressCost(),
+		QueryResponseDataCost:      DefaultQueryResponseDataCost,
 	}
 }
 
@@ -219,6 +240,29 @@ func (g WasmGasRegister) EventCosts(attrs []wasmvmtypes.EventAttribute, events w
 	return gas
 }
 
+// QueryResponseCosts costs for the response of a Stargate or gRPC query of a contract.
+// The response size is deterministic so that the costs can be charged in consensus code.
+func (g WasmGasRegister) QueryResponseCosts(byteLength int) storetypes.Gas {
+	if byteLength < 0 {
+		panic(errorsmod.Wrap(ErrInvalid, "negative length"))
+	}
+	r := sdkmath.NewIntFromUint64(g.c.QueryResponseDataCost).Mul(sdkmath.NewInt(int64(byteLength)))
+	if !r.IsUint64() {
+		panic(storetypes.ErrorOutOfGas{Descriptor: "overflow"})
+	}
+	return r.Uint64()
+}
+
+// EventGasConstants returns the constants that EventCosts is calculated with
+func (g WasmGasRegister) EventGasConstants() EventGasConstants {
+	return EventGasConstants{
+		PerAttributeCost:   g.c.EventPerAttributeCost,
+		PerByteCost:        g.c.EventAttributeDataCost,
+		FreeTierBytes:      g.c.EventAttributeDataFreeTier,
+		PerCustomEventCost: g.c.CustomEventCost,
+	}
+}
+
 func (g WasmGasRegister) eventAttributeCosts(attrs []wasmvmtypes.EventAttribute, freeTier uint64) (storetypes.Gas, uint64) {
 	if len(attrs) == 0 {
 		return 0, freeTier
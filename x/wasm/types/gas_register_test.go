@@ -367,3 +367,47 @@ func TestUncompressCosts(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryResponseCosts(t *testing.T) {
+	specs := map[string]struct {
+		srcConfig WasmGasRegisterConfig
+		lenIn     int
+		exp       storetypes.Gas
+		expPanic  bool
+	}{
+		"0": {
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       0,
+		},
+		"with default costs": {
+			srcConfig: DefaultGasRegisterConfig(),
+			lenIn:     100,
+			exp:       100 * DefaultQueryResponseDataCost,
+		},
+		"with custom costs": {
+			srcConfig: WasmGasRegisterConfig{GasMultiplier: 1, QueryResponseDataCost: 3},
+			lenIn:     100,
+			exp:       300,
+		},
+		"overflow": {
+			srcConfig: WasmGasRegisterConfig{GasMultiplier: 1, QueryResponseDataCost: math.MaxUint64},
+			lenIn:     2,
+			expPanic:  true,
+		},
+		"invalid len": {
+			srcConfig: DefaultGasRegisterConfig(),
+			lenIn:     -1,
+			expPanic:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expPanic {
+				assert.Panics(t, func() { NewWasmGasRegister(spec.srcConfig).QueryResponseCosts(spec.lenIn) })
+				return
+			}
+			got := NewWasmGasRegister(spec.srcConfig).QueryResponseCosts(spec.lenIn)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
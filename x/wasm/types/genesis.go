@@ -1,6 +1,9 @@
 package types
 
 import (
+	"bytes"
+	"slices"
+
 	errorsmod "cosmossdk.io/errors"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -22,6 +25,11 @@ func (s GenesisState) ValidateBasic() error {
 		if err := s.Codes[i].ValidateBasic(); err != nil {
 			return errorsmod.Wrapf(err, "code: %d", i)
 		}
+		for _, v := range s.Codes[i].VerificationVotes {
+			if !s.Params.IsCodeVerifier(sdk.MustAccAddressFromBech32(v.Voter)) {
+				return errorsmod.Wrapf(ErrInvalid, "code: %d: verification vote by %s who is not a code verifier", i, v.Voter)
+			}
+		}
 	}
 	for i := range s.Contracts {
 		if err := s.Contracts[i].ValidateBasic(); err != nil {
@@ -33,6 +41,45 @@ func (s GenesisState) ValidateBasic() error {
 			return errorsmod.Wrapf(err, "sequence: %d", i)
 		}
 	}
+	approvals := make(map[string]struct{}, len(s.CodeUploadApprovals))
+	for i, a := range s.CodeUploadApprovals {
+		if err := a.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "code upload approval: %d", i)
+		}
+		if _, exists := approvals[string(a.Checksum)]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "code upload approval: %d", i)
+		}
+		approvals[string(a.Checksum)] = struct{}{}
+	}
+	scheduledIDs := make(map[uint64]struct{}, len(s.ScheduledSudos))
+	for i, ss := range s.ScheduledSudos {
+		if err := ss.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "scheduled sudo: %d", i)
+		}
+		if _, exists := scheduledIDs[ss.ID]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "scheduled sudo: %d", i)
+		}
+		scheduledIDs[ss.ID] = struct{}{}
+	}
+	if len(s.ParamsHistory) > MaxParamsHistoryLen {
+		return errorsmod.Wrapf(ErrLimit, "params history: max %d", MaxParamsHistoryLen)
+	}
+	for i, c := range s.ParamsHistory {
+		if err := c.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "params change: %d", i)
+		}
+		if i > 0 && c.Height < s.ParamsHistory[i-1].Height {
+			return errorsmod.Wrapf(ErrInvalid, "params change: %d: not ordered by height", i)
+		}
+	}
+	if s.Stats != nil {
+		if s.Stats.CodeCount != uint64(len(s.Codes)) {
+			return errorsmod.Wrapf(ErrInvalid, "stats code count %d does not match %d codes", s.Stats.CodeCount, len(s.Codes))
+		}
+		if s.Stats.ContractCount != uint64(len(s.Contracts)) {
+			return errorsmod.Wrapf(ErrInvalid, "stats contract count %d does not match %d contracts", s.Stats.ContractCount, len(s.Contracts))
+		}
+	}
 
 	return nil
 }
@@ -47,6 +94,29 @@ func (c Code) ValidateBasic() error {
 	if err := validateWasmCode(c.CodeBytes, MaxProposalWasmSize); err != nil {
 		return errorsmod.Wrap(err, "code bytes")
 	}
+	if len(c.Attestations) > MaxAttestationsPerCode {
+		return ErrLimit.Wrapf("attestations: max %d", MaxAttestationsPerCode)
+	}
+	attesters := make(map[string]struct{}, len(c.Attestations))
+	for i, a := range c.Attestations {
+		if err := a.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "attestation %d", i)
+		}
+		if _, exists := attesters[a.Attester]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "attestation by %s", a.Attester)
+		}
+		attesters[a.Attester] = struct{}{}
+	}
+	voters := make(map[string]struct{}, len(c.VerificationVotes))
+	for i, v := range c.VerificationVotes {
+		if err := v.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "verification vote %d", i)
+		}
+		if _, exists := voters[v.Voter]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "verification vote by %s", v.Voter)
+		}
+		voters[v.Voter] = struct{}{}
+	}
 	return nil
 }
 
@@ -61,11 +131,47 @@ func (c Contract) ValidateBasic() error {
 	if c.ContractInfo.Created == nil {
 		return errorsmod.Wrap(ErrInvalid, "created must not be empty")
 	}
+	if c.ParentAddress != "" {
+		if _, err := sdk.AccAddressFromBech32(c.ParentAddress); err != nil {
+			return errorsmod.Wrap(err, "parent address")
+		}
+		if c.ParentAddress == c.ContractAddress {
+			return errorsmod.Wrap(ErrInvalid, "parent address must not be the contract itself")
+		}
+	}
 	for i := range c.ContractState {
 		if err := c.ContractState[i].ValidateBasic(); err != nil {
 			return errorsmod.Wrapf(err, "contract state %d", i)
 		}
 	}
+	if len(c.QueryAliases) > MaxQueryAliasesPerContract {
+		return ErrLimit.Wrapf("query aliases: max %d", MaxQueryAliasesPerContract)
+	}
+	aliasNames := make(map[string]struct{}, len(c.QueryAliases))
+	for i, a := range c.QueryAliases {
+		if err := a.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "query alias %d", i)
+		}
+		if _, exists := aliasNames[a.Name]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "query alias %s", a.Name)
+		}
+		aliasNames[a.Name] = struct{}{}
+	}
+	entryPoints := make(map[string]struct{}, len(c.Usage))
+	for i, u := range c.Usage {
+		if u.EntryPoint == "" {
+			return errorsmod.Wrapf(ErrEmpty, "usage %d: entry point", i)
+		}
+		if _, exists := entryPoints[u.EntryPoint]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "usage %s", u.EntryPoint)
+		}
+		entryPoints[u.EntryPoint] = struct{}{}
+	}
+	if c.ExecutionReceipt != nil {
+		if err := c.ExecutionReceipt.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "execution receipt")
+		}
+	}
 	if len(c.ContractCodeHistory) == 0 {
 		return ErrEmpty.Wrap("code history")
 	}
@@ -83,6 +189,45 @@ func ValidateGenesis(data GenesisState) error {
 	return data.ValidateBasic()
 }
 
+// MergeFragment adds the codes, contracts and sequences of a genesis fragment, like a dev snapshot of another chain.
+// The params of the fragment are ignored. Codes and contracts that exist already are rejected. Sequences are set to
+// the higher value of both. The stats are dropped as they are recomputed on import.
+func (s *GenesisState) MergeFragment(fragment GenesisState) error {
+	codeIDs := make(map[uint64]struct{}, len(s.Codes))
+	for _, c := range s.Codes {
+		codeIDs[c.CodeID] = struct{}{}
+	}
+	for _, c := range fragment.Codes {
+		if _, exists := codeIDs[c.CodeID]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "code %d", c.CodeID)
+		}
+		codeIDs[c.CodeID] = struct{}{}
+	}
+	addrs := make(map[string]struct{}, len(s.Contracts))
+	for _, c := range s.Contracts {
+		addrs[c.ContractAddress] = struct{}{}
+	}
+	for _, c := range fragment.Contracts {
+		if _, exists := addrs[c.ContractAddress]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "contract %s", c.ContractAddress)
+		}
+		addrs[c.ContractAddress] = struct{}{}
+	}
+	s.Codes = append(s.Codes, fragment.Codes...)
+	s.Contracts = append(s.Contracts, fragment.Contracts...)
+	for _, seq := range fragment.Sequences {
+		i := slices.IndexFunc(s.Sequences, func(o Sequence) bool { return bytes.Equal(o.IDKey, seq.IDKey) })
+		switch {
+		case i < 0:
+			s.Sequences = append(s.Sequences, seq)
+		case s.Sequences[i].Value < seq.Value:
+			s.Sequences[i].Value = seq.Value
+		}
+	}
+	s.Stats = nil
+	return nil
+}
+
 var _ codectypes.UnpackInterfacesMessage = GenesisState{}
 
 // UnpackInterfaces implements codectypes.UnpackInterfaces
@@ -101,3 +246,14 @@ var _ codectypes.UnpackInterfacesMessage = &Contract{}
 func (c *Contract) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
 	return c.ContractInfo.UnpackInterfaces(unpacker)
 }
+
+// ValidateBasic syntax checks
+func (r ExecutionReceipt) ValidateBasic() error {
+	if r.Height < 0 {
+		return errorsmod.Wrap(ErrInvalid, "height")
+	}
+	if _, ok := ExecutionReceiptKind_name[int32(r.Kind)]; !ok || r.Kind == ExecutionReceiptKindUnspecified {
+		return errorsmod.Wrap(ErrInvalid, "kind")
+	}
+	return nil
+}
@@ -30,10 +30,19 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // GenesisState - genesis state of x/wasm
 type GenesisState struct {
-	Params    Params     `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
-	Codes     []Code     `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
-	Contracts []Contract `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
-	Sequences []Sequence `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
+	Params              Params               `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+	Codes               []Code               `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
+	Contracts           []Contract           `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	Sequences           []Sequence           `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
+	CodeUploadApprovals []CodeUploadApproval `protobuf:"bytes,5,rep,name=code_upload_approvals,json=codeUploadApprovals,proto3" json:"code_upload_approvals,omitempty"`
+	// Stats are the module counters at export. When set, they must match the
+	// imported codes and contracts.
+	Stats *WasmStats `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`
+	// ScheduledSudos are the pending sudo calls that contracts scheduled on
+	// themselves
+	ScheduledSudos []ScheduledSudo `protobuf:"bytes,7,rep,name=scheduled_sudos,json=scheduledSudos,proto3" json:"scheduled_sudos,omitempty"`
+	// ParamsHistory are the latest params changes ordered by height
+	ParamsHistory []ParamsChange `protobuf:"bytes,8,rep,name=params_history,json=paramsHistory,proto3" json:"params_history,omitempty"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -102,6 +111,13 @@ func (m *GenesisState) GetSequences() []Sequence {
 	return nil
 }
 
+func (m *GenesisState) GetCodeUploadApprovals() []CodeUploadApproval {
+	if m != nil {
+		return m.CodeUploadApprovals
+	}
+	return nil
+}
+
 // Code struct encompasses CodeInfo and CodeBytes
 type Code struct {
 	CodeID    uint64   `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
@@ -109,6 +125,10 @@ type Code struct {
 	CodeBytes []byte   `protobuf:"bytes,3,opt,name=code_bytes,json=codeBytes,proto3" json:"code_bytes,omitempty"`
 	// Pinned to wasmvm cache
 	Pinned bool `protobuf:"varint,4,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// Attestations are the unverified build claims for the code
+	Attestations []CodeAttestation `protobuf:"bytes,5,rep,name=attestations,proto3" json:"attestations"`
+	// VerificationVotes are the build reproducibility votes of the verifiers
+	VerificationVotes []CodeVerificationVote `protobuf:"bytes,6,rep,name=verification_votes,json=verificationVotes,proto3" json:"verification_votes"`
 }
 
 func (m *Code) Reset()         { *m = Code{} }
@@ -177,12 +197,37 @@ func (m *Code) GetPinned() bool {
 	return false
 }
 
+func (m *Code) GetAttestations() []CodeAttestation {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
 // Contract struct encompasses ContractAddress, ContractInfo, and ContractState
 type Contract struct {
 	ContractAddress     string                     `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
 	ContractInfo        ContractInfo               `protobuf:"bytes,2,opt,name=contract_info,json=contractInfo,proto3" json:"contract_info"`
 	ContractState       []Model                    `protobuf:"bytes,3,rep,name=contract_state,json=contractState,proto3" json:"contract_state"`
 	ContractCodeHistory []ContractCodeHistoryEntry `protobuf:"bytes,4,rep,name=contract_code_history,json=contractCodeHistory,proto3" json:"contract_code_history"`
+	// ParentAddress is the address of the contract that instantiated this
+	// contract, empty when not instantiated by a contract
+	ParentAddress string `protobuf:"bytes,5,opt,name=parent_address,json=parentAddress,proto3" json:"parent_address,omitempty"`
+	// QueryAliases are the named smart queries registered for the contract
+	QueryAliases []QueryAlias `protobuf:"bytes,6,rep,name=query_aliases,json=queryAliases,proto3" json:"query_aliases"`
+	// IBCSendTimeout is the default timeout (in nanoseconds) relative to the
+	// block time for IBC packets sent by the contract without timeout
+	IBCSendTimeout uint64 `protobuf:"varint,7,opt,name=ibc_send_timeout,json=ibcSendTimeout,proto3" json:"ibc_send_timeout,omitempty"`
+	// Usage are the invocation counters of the contract entry points
+	Usage []EntryPointUsage `protobuf:"bytes,8,rep,name=usage,proto3" json:"usage"`
+	// NotifyAdminChange is set when the contract is notified on admin changes
+	NotifyAdminChange bool `protobuf:"varint,9,opt,name=notify_admin_change,json=notifyAdminChange,proto3" json:"notify_admin_change,omitempty"`
+	// ExecutionReceipt is the metadata of the last execution of the contract,
+	// empty when receipts are disabled or the contract was not executed
+	ExecutionReceipt *ExecutionReceipt `protobuf:"bytes,10,opt,name=execution_receipt,json=executionReceipt,proto3" json:"execution_receipt,omitempty"`
+	// IgnoreReplyError is set when failing replies to successful submessages are
+	// reverted instead of failing the contract call
+	IgnoreReplyError bool `protobuf:"varint,11,opt,name=ignore_reply_error,json=ignoreReplyError,proto3" json:"ignore_reply_error,omitempty"`
 }
 
 func (m *Contract) Reset()         { *m = Contract{} }
@@ -251,6 +296,48 @@ func (m *Contract) GetContractCodeHistory() []ContractCodeHistoryEntry {
 	return nil
 }
 
+func (m *Contract) GetParentAddress() string {
+	if m != nil {
+		return m.ParentAddress
+	}
+	return ""
+}
+
+func (m *Contract) GetQueryAliases() []QueryAlias {
+	if m != nil {
+		return m.QueryAliases
+	}
+	return nil
+}
+
+func (m *Contract) GetIBCSendTimeout() uint64 {
+	if m != nil {
+		return m.IBCSendTimeout
+	}
+	return 0
+}
+
+func (m *Contract) GetUsage() []EntryPointUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+func (m *Contract) GetNotifyAdminChange() bool {
+	if m != nil {
+		return m.NotifyAdminChange
+	}
+	return false
+}
+
+func (m *Contract) GetExecutionReceipt() *ExecutionReceipt {
+	if m != nil {
+		return m.ExecutionReceipt
+	}
+	return nil
+}
+
 // Sequence key and value of an id generation counter
 type Sequence struct {
 	IDKey []byte `protobuf:"bytes,1,opt,name=id_key,json=idKey,proto3" json:"id_key,omitempty"`
@@ -319,44 +406,68 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/genesis.proto", fileDescriptor_2ab3f539b23472a6) }
 
 var fileDescriptor_2ab3f539b23472a6 = []byte{
-	// 590 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0x9b, 0xae, 0x0d, 0xad, 0x57, 0xd8, 0x30, 0x63, 0x84, 0x6a, 0xa4, 0x55, 0x91, 0x50,
-	0x35, 0x41, 0xa3, 0x8d, 0x23, 0x17, 0xc8, 0x86, 0xa0, 0x4c, 0x20, 0x94, 0x1e, 0x90, 0x76, 0xa9,
-	0xd2, 0xd8, 0xeb, 0x2c, 0x96, 0xb8, 0xc4, 0x6e, 0x21, 0xdf, 0x82, 0x4f, 0x81, 0x38, 0x72, 0xe0,
-	0x43, 0xec, 0xc6, 0x84, 0x84, 0xc4, 0xa9, 0x42, 0xed, 0x01, 0x89, 0x4f, 0x81, 0xfc, 0x27, 0x59,
-	0xd4, 0xae, 0x17, 0xab, 0xf6, 0xf3, 0xbe, 0xbf, 0xbe, 0xef, 0xe3, 0x37, 0x06, 0x76, 0x40, 0x59,
-	0xf8, 0xd1, 0x67, 0xa1, 0x23, 0x97, 0xc9, 0x9e, 0x33, 0xc4, 0x11, 0x66, 0x84, 0x75, 0x46, 0x31,
-	0xe5, 0x14, 0x6e, 0xa6, 0x7a, 0x47, 0x2e, 0x93, 0xbd, 0xfa, 0xd6, 0x90, 0x0e, 0xa9, 0x14, 0x1d,
-	0xf1, 0x4b, 0xc5, 0xd5, 0x77, 0x96, 0x38, 0x3c, 0x19, 0x61, 0x4d, 0xa9, 0xdf, 0xf4, 0x43, 0x12,
-	0x51, 0x47, 0xae, 0xfa, 0xe8, 0xae, 0x48, 0xa0, 0xac, 0xaf, 0x48, 0x6a, 0xa3, 0xa4, 0xd6, 0x8f,
-	0x22, 0xa8, 0xbd, 0x50, 0x55, 0xf4, 0xb8, 0xcf, 0x31, 0x7c, 0x02, 0xcc, 0x91, 0x1f, 0xfb, 0x21,
-	0xb3, 0x8c, 0xa6, 0xd1, 0x5e, 0xdf, 0xb7, 0x3a, 0x8b, 0x55, 0x75, 0xde, 0x4a, 0xdd, 0xad, 0x9e,
-	0x4f, 0x1b, 0x85, 0xaf, 0x7f, 0xbf, 0xed, 0x1a, 0x9e, 0x4e, 0x81, 0xaf, 0x40, 0x39, 0xa0, 0x08,
-	0x33, 0xab, 0xd8, 0x5c, 0x6b, 0xaf, 0xef, 0x6f, 0x2f, 0xe7, 0x1e, 0x50, 0x84, 0xdd, 0x1d, 0x91,
-	0xf9, 0x6f, 0xda, 0xd8, 0x90, 0xc1, 0x0f, 0x69, 0x48, 0x38, 0x0e, 0x47, 0x3c, 0x51, 0x30, 0x85,
-	0x80, 0xc7, 0xa0, 0x1a, 0xd0, 0x88, 0xc7, 0x7e, 0xc0, 0x99, 0xb5, 0x26, 0x79, 0xf5, 0xab, 0x78,
-	0x2a, 0xc4, 0x6d, 0x6a, 0xe6, 0xad, 0x2c, 0x69, 0x91, 0x7b, 0x89, 0x13, 0x6c, 0x86, 0x3f, 0x8c,
-	0x71, 0x14, 0x60, 0x66, 0x95, 0x56, 0xb1, 0x7b, 0x3a, 0xe4, 0x92, 0x9d, 0x25, 0x2d, 0xb1, 0x33,
-	0xa5, 0xf5, 0xc5, 0x00, 0x25, 0xd1, 0x25, 0xbc, 0x0f, 0xae, 0x89, 0x4e, 0xfa, 0x04, 0x49, 0x2b,
-	0x4b, 0x2e, 0x98, 0x4d, 0x1b, 0xa6, 0x90, 0xba, 0x87, 0x9e, 0x29, 0xa4, 0x2e, 0x82, 0xae, 0xe8,
-	0x52, 0x04, 0x45, 0x27, 0xd4, 0x2a, 0x4a, 0xc7, 0xeb, 0x57, 0xbb, 0xd6, 0x8d, 0x4e, 0x68, 0xde,
-	0xf3, 0x4a, 0xa0, 0x0f, 0xe1, 0x3d, 0x00, 0x24, 0x63, 0x90, 0x70, 0x2c, 0xac, 0x32, 0xda, 0x35,
-	0x4f, 0x52, 0x5d, 0x71, 0x00, 0xb7, 0x81, 0x39, 0x22, 0x51, 0x84, 0x91, 0x55, 0x6a, 0x1a, 0xed,
-	0x8a, 0xa7, 0x77, 0xad, 0x5f, 0x45, 0x50, 0x49, 0xed, 0x83, 0x07, 0x60, 0x33, 0xb5, 0xa7, 0xef,
-	0x23, 0x14, 0x63, 0xa6, 0x06, 0xa0, 0xea, 0x5a, 0x3f, 0xbf, 0x3f, 0xda, 0xd2, 0x33, 0xf3, 0x4c,
-	0x29, 0x3d, 0x1e, 0x93, 0x68, 0xe8, 0x6d, 0xa4, 0x19, 0xfa, 0x18, 0xbe, 0x01, 0xd7, 0x33, 0x48,
-	0xae, 0x21, 0x7b, 0xf5, 0xb5, 0x2d, 0x36, 0x55, 0x0b, 0x72, 0x02, 0xec, 0x82, 0x1b, 0x19, 0x8f,
-	0x89, 0xe9, 0xd4, 0x73, 0x70, 0x67, 0x19, 0xf8, 0x9a, 0x22, 0x7c, 0x96, 0x27, 0x65, 0x95, 0xa8,
-	0xb1, 0x26, 0xe0, 0x76, 0x86, 0x92, 0x66, 0x9d, 0x12, 0xc6, 0x69, 0x9c, 0xe8, 0xdb, 0xdf, 0x5d,
-	0x5d, 0xa2, 0xf0, 0xfe, 0xa5, 0x0a, 0x7e, 0x1e, 0xf1, 0x38, 0xc9, 0xff, 0x49, 0x36, 0x6c, 0xb9,
-	0xa0, 0x96, 0x0b, 0x2a, 0xe9, 0xe4, 0xc0, 0x26, 0x30, 0x09, 0xea, 0xbf, 0xc7, 0x89, 0x34, 0xb3,
-	0xe6, 0x56, 0x67, 0xd3, 0x46, 0xb9, 0x7b, 0x78, 0x84, 0x13, 0xaf, 0x4c, 0xd0, 0x11, 0x4e, 0xe0,
-	0x16, 0x28, 0x4f, 0xfc, 0xb3, 0x31, 0x96, 0x5e, 0x95, 0x3c, 0xb5, 0x71, 0x9f, 0x9e, 0xcf, 0x6c,
-	0xe3, 0x62, 0x66, 0x1b, 0x7f, 0x66, 0xb6, 0xf1, 0x79, 0x6e, 0x17, 0x2e, 0xe6, 0x76, 0xe1, 0xf7,
-	0xdc, 0x2e, 0x1c, 0x3f, 0x18, 0x12, 0x7e, 0x3a, 0x1e, 0x74, 0x02, 0x1a, 0x3a, 0x07, 0x94, 0x85,
-	0xef, 0xd2, 0x77, 0x00, 0x39, 0x9f, 0xd4, 0x7b, 0x20, 0x1f, 0x83, 0x81, 0x29, 0xbf, 0xef, 0xc7,
-	0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x86, 0xe3, 0xf2, 0x58, 0x75, 0x04, 0x00, 0x00,
+	// 971 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x85, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x8e, 0x12, 0x53, 0x91, 0x36, 0xb2, 0x2c, 0xaf, 0xdd, 0x94, 0x71, 0x5d, 0xc9, 0x55, 0x1a,
+	0xc3, 0x08, 0x5c, 0x09, 0x4e, 0x8e, 0x2d, 0xd0, 0x8a, 0x4a, 0xd0, 0xaa, 0xbf, 0x2e, 0x95, 0x1f,
+	0x20, 0x17, 0x96, 0x26, 0xd7, 0xf2, 0x22, 0x12, 0x97, 0xe1, 0x2e, 0xd5, 0xf0, 0x05, 0x7a, 0xee,
+	0xa5, 0xef, 0xd0, 0x63, 0x0f, 0x7d, 0x88, 0x1c, 0x83, 0x9e, 0x8a, 0x1e, 0x8c, 0x22, 0x3d, 0x14,
+	0x68, 0x5f, 0xa2, 0xb3, 0xbb, 0x24, 0x4d, 0x53, 0x12, 0x7a, 0x20, 0x25, 0xee, 0xf7, 0xcd, 0x37,
+	0xb3, 0xb3, 0x33, 0x43, 0xa2, 0xb6, 0xc7, 0xf8, 0xec, 0x7b, 0x97, 0xcf, 0xfa, 0xea, 0x36, 0x3f,
+	0xea, 0x4f, 0x48, 0x40, 0x38, 0xe5, 0xbd, 0x30, 0x62, 0x82, 0xe1, 0x56, 0x86, 0xf7, 0xd4, 0x6d,
+	0x7e, 0xb4, 0xb3, 0x3d, 0x61, 0x13, 0xa6, 0xc0, 0xbe, 0xfc, 0xa7, 0x79, 0x3b, 0xbb, 0x0b, 0x3a,
+	0x22, 0x09, 0x49, 0xaa, 0xb2, 0xb3, 0xe9, 0xce, 0x68, 0xc0, 0xfa, 0xea, 0x9e, 0x2e, 0xdd, 0x92,
+	0x06, 0x8c, 0x3b, 0x5a, 0x49, 0x3f, 0x68, 0xa8, 0xfb, 0xaf, 0x81, 0x1a, 0x9f, 0xea, 0x28, 0xc6,
+	0xc2, 0x15, 0x04, 0x7f, 0x88, 0xaa, 0xa1, 0x1b, 0xb9, 0x33, 0x6e, 0x56, 0xf6, 0x2a, 0x07, 0x37,
+	0xee, 0x99, 0xbd, 0x72, 0x54, 0xbd, 0x63, 0x85, 0x5b, 0xf5, 0x57, 0xe7, 0x9d, 0x2b, 0x3f, 0xff,
+	0xfd, 0xcb, 0xdd, 0x8a, 0x9d, 0x9a, 0xe0, 0xcf, 0x91, 0xe1, 0x31, 0x9f, 0x70, 0xf3, 0xea, 0xde,
+	0x35, 0xb0, 0xbd, 0xb9, 0x68, 0x3b, 0x04, 0xd8, 0xda, 0x95, 0x96, 0xff, 0x9c, 0x77, 0x36, 0x14,
+	0xf9, 0x90, 0xcd, 0xa8, 0x20, 0xb3, 0x50, 0x24, 0x5a, 0x4c, 0x4b, 0xe0, 0x67, 0xa8, 0xee, 0xb1,
+	0x40, 0x44, 0xae, 0x27, 0xb8, 0x79, 0x4d, 0xe9, 0xed, 0x2c, 0xd3, 0xd3, 0x14, 0x6b, 0x2f, 0xd5,
+	0xdc, 0xca, 0x8d, 0xca, 0xba, 0x17, 0x72, 0x52, 0x9b, 0x93, 0x17, 0x31, 0x09, 0x3c, 0x88, 0x75,
+	0x6d, 0x95, 0xf6, 0x38, 0xa5, 0x5c, 0x68, 0xe7, 0x46, 0x0b, 0xda, 0x39, 0x82, 0x7f, 0xa8, 0xa0,
+	0xb7, 0xe4, 0x0e, 0x9c, 0x38, 0x9c, 0x32, 0xd7, 0x77, 0xdc, 0x10, 0x32, 0x3d, 0x77, 0xa7, 0xdc,
+	0x34, 0x94, 0xa3, 0xf7, 0x97, 0x27, 0xe5, 0xb1, 0x62, 0x0f, 0x52, 0xb2, 0x75, 0x98, 0xba, 0xec,
+	0x2c, 0x95, 0x2a, 0xbb, 0xdf, 0xf2, 0x16, 0x14, 0x38, 0x3e, 0x42, 0x06, 0x87, 0x23, 0xe5, 0x66,
+	0x55, 0x1d, 0xe4, 0x3b, 0x8b, 0x7e, 0x9f, 0xc2, 0xaf, 0x3c, 0x75, 0x6e, 0x6b, 0x26, 0x0e, 0xd1,
+	0x06, 0xf7, 0xce, 0x88, 0x1f, 0x4f, 0x89, 0xef, 0xf0, 0xd8, 0x67, 0xdc, 0xbc, 0xae, 0x82, 0xee,
+	0x2c, 0xc9, 0x4e, 0x46, 0x1c, 0x03, 0xcf, 0xda, 0x4f, 0xe3, 0xbd, 0x55, 0xb2, 0x2f, 0x47, 0xda,
+	0xe4, 0x45, 0x33, 0x8e, 0xa7, 0xa8, 0xa9, 0x6b, 0xc7, 0x39, 0xa3, 0x5c, 0xb0, 0x28, 0x31, 0x6b,
+	0xca, 0x61, 0x7b, 0x55, 0xd9, 0x0d, 0xcf, 0xdc, 0x60, 0x42, 0xac, 0x3b, 0xa9, 0x3f, 0xf3, 0xb2,
+	0x75, 0xd9, 0xdd, 0xba, 0x86, 0x3f, 0xd3, 0x68, 0xf7, 0x8f, 0xab, 0x68, 0x4d, 0x26, 0x1b, 0xdf,
+	0x46, 0xd7, 0x55, 0x62, 0xa9, 0xaf, 0xca, 0x7c, 0xcd, 0x42, 0x6f, 0xce, 0x3b, 0x55, 0x09, 0x8d,
+	0x1e, 0xd8, 0x55, 0x09, 0x8d, 0x7c, 0x6c, 0xc9, 0x0a, 0x94, 0xa4, 0xe0, 0x94, 0x41, 0x45, 0x57,
+	0x56, 0x55, 0x20, 0x90, 0x81, 0x51, 0xec, 0x87, 0x9a, 0x97, 0x2e, 0xe2, 0x77, 0x11, 0x52, 0x1a,
+	0x27, 0x89, 0x20, 0xb2, 0x8c, 0x2b, 0x07, 0x0d, 0x5b, 0xa9, 0x5a, 0x72, 0x01, 0xdf, 0x84, 0x6e,
+	0xa3, 0x41, 0x40, 0x7c, 0xa8, 0xc2, 0xca, 0x41, 0xcd, 0x4e, 0x9f, 0xf0, 0x31, 0x6a, 0xb8, 0x02,
+	0x08, 0x70, 0x2a, 0x94, 0x05, 0x59, 0xe9, 0xbc, 0xb7, 0xdc, 0xfb, 0xe0, 0x82, 0x59, 0x0c, 0xe2,
+	0x92, 0x02, 0xfe, 0x0e, 0xe1, 0x39, 0x89, 0xe8, 0x29, 0xf5, 0xd4, 0x82, 0x33, 0x67, 0x32, 0xa0,
+	0xaa, 0xd2, 0xdd, 0x5f, 0xae, 0xfb, 0xa4, 0xc0, 0x7f, 0x02, 0xf4, 0xa2, 0xf8, 0xe6, 0xbc, 0x04,
+	0xf2, 0xee, 0x4f, 0x55, 0x54, 0xcb, 0xda, 0x11, 0x0f, 0x51, 0x2b, 0x6b, 0x37, 0xc7, 0xf5, 0xfd,
+	0x88, 0x70, 0x3d, 0x50, 0xea, 0x96, 0xf9, 0xdb, 0xaf, 0x1f, 0x6c, 0xa7, 0x33, 0x68, 0xa0, 0x91,
+	0xb1, 0x88, 0x68, 0x30, 0xb1, 0x37, 0x32, 0x8b, 0x74, 0x19, 0x7f, 0x8d, 0xd6, 0x73, 0x91, 0xc2,
+	0x21, 0xb4, 0x57, 0x8f, 0x81, 0xf2, 0x41, 0x34, 0xbc, 0x02, 0x80, 0x47, 0xa8, 0x99, 0xeb, 0xc9,
+	0xc4, 0x90, 0x74, 0xae, 0xbc, 0xbd, 0x28, 0xf8, 0x15, 0xec, 0x7f, 0x5a, 0x54, 0xca, 0x23, 0xd1,
+	0x63, 0x92, 0xca, 0x26, 0x4f, 0xa5, 0xd4, 0x01, 0x67, 0xe5, 0xab, 0xa7, 0xc9, 0xdd, 0xd5, 0x21,
+	0xca, 0xcc, 0xa6, 0xf5, 0xf8, 0x10, 0x56, 0x92, 0xa2, 0x93, 0x7c, 0x78, 0x15, 0x48, 0xf8, 0x63,
+	0xd5, 0x22, 0x24, 0xb8, 0x48, 0xa4, 0xf1, 0x3f, 0x89, 0x5c, 0xd7, 0xfc, 0x2c, 0x8d, 0x5f, 0xa2,
+	0x75, 0x18, 0x4e, 0x51, 0xe2, 0xb8, 0x53, 0xea, 0xf2, 0xfc, 0xd4, 0x77, 0x17, 0x63, 0xfc, 0x56,
+	0xd2, 0x06, 0x92, 0x75, 0x29, 0x89, 0x2f, 0xf2, 0x65, 0x28, 0xd9, 0x8f, 0x50, 0x8b, 0x9e, 0x78,
+	0x0e, 0x27, 0x81, 0xef, 0x08, 0x3a, 0x23, 0x2c, 0x16, 0x30, 0x24, 0x64, 0x0f, 0x61, 0xe8, 0xa1,
+	0xe6, 0xc8, 0x1a, 0x8e, 0x01, 0x7a, 0xa4, 0x11, 0xbb, 0x09, 0xdc, 0xc2, 0x33, 0xf4, 0x94, 0x11,
+	0x73, 0x77, 0x42, 0xd2, 0x36, 0x5f, 0x52, 0xd1, 0x2a, 0x29, 0xc7, 0x8c, 0x06, 0xe2, 0xb1, 0x24,
+	0x16, 0x03, 0xd1, 0xa6, 0xb8, 0x87, 0xb6, 0x02, 0x26, 0xe8, 0x29, 0x6c, 0xc8, 0x87, 0xb7, 0x9c,
+	0xe3, 0xa9, 0x91, 0x60, 0xd6, 0x55, 0x07, 0x6d, 0x6a, 0x68, 0x20, 0x11, 0x3d, 0x2b, 0xf0, 0x37,
+	0x68, 0x93, 0xbc, 0x24, 0x5e, 0xac, 0xea, 0x3e, 0x22, 0x1e, 0xa1, 0xa1, 0x30, 0x91, 0x2a, 0xa5,
+	0xee, 0x12, 0xff, 0x19, 0xd5, 0xd6, 0x4c, 0xbb, 0x45, 0x4a, 0x2b, 0xf8, 0x10, 0x61, 0x3a, 0x09,
+	0x58, 0x44, 0x40, 0x2d, 0x9c, 0x26, 0x0e, 0x89, 0x22, 0x16, 0x99, 0x37, 0x94, 0xff, 0x96, 0x46,
+	0x6c, 0x09, 0x3c, 0x94, 0xeb, 0x5d, 0x0b, 0xd5, 0xb2, 0x37, 0x09, 0xde, 0x43, 0x55, 0xea, 0x3b,
+	0xcf, 0x49, 0xa2, 0x9a, 0xa1, 0x61, 0xd5, 0x21, 0x65, 0xc6, 0xe8, 0xc1, 0x17, 0x24, 0xb1, 0x0d,
+	0xea, 0xc3, 0x0f, 0xde, 0x46, 0x06, 0x4c, 0xef, 0x98, 0xa8, 0x5a, 0x5f, 0xb3, 0xf5, 0x83, 0xf5,
+	0xc9, 0xab, 0x37, 0xed, 0xca, 0x6b, 0xb8, 0xfe, 0x84, 0xeb, 0xc7, 0xbf, 0xda, 0x57, 0x5e, 0xc3,
+	0xf5, 0x3b, 0x5c, 0xcf, 0xf6, 0x27, 0x54, 0x9c, 0xc5, 0x27, 0xb0, 0x8f, 0x59, 0x7f, 0x08, 0x7b,
+	0x79, 0x9a, 0x7d, 0x17, 0xf8, 0xfd, 0x97, 0xfa, 0xfb, 0x40, 0x7d, 0x1c, 0x9c, 0x54, 0xd5, 0xfb,
+	0xfe, 0xfe, 0x7f, 0xa9, 0x0b, 0xe7, 0x10, 0x85, 0x08, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -379,6 +490,60 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ParamsHistory) > 0 {
+		for iNdEx := len(m.ParamsHistory) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ParamsHistory[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.ScheduledSudos) > 0 {
+		for iNdEx := len(m.ScheduledSudos) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ScheduledSudos[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.Stats != nil {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenesis(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.CodeUploadApprovals) > 0 {
+		for iNdEx := len(m.CodeUploadApprovals) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CodeUploadApprovals[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if len(m.Sequences) > 0 {
 		for iNdEx := len(m.Sequences) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -454,6 +619,34 @@ func (m *Code) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.VerificationVotes) > 0 {
+		for iNdEx := len(m.VerificationVotes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.VerificationVotes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Attestations) > 0 {
+		for iNdEx := len(m.Attestations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attestations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if m.Pinned {
 		i--
 		if m.Pinned {
@@ -509,6 +702,78 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IgnoreReplyError {
+		i--
+		if m.IgnoreReplyError {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.ExecutionReceipt != nil {
+		{
+			size, err := m.ExecutionReceipt.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenesis(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.NotifyAdminChange {
+		i--
+		if m.NotifyAdminChange {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Usage) > 0 {
+		for iNdEx := len(m.Usage) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Usage[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.IBCSendTimeout != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.IBCSendTimeout))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.QueryAliases) > 0 {
+		for iNdEx := len(m.QueryAliases) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.QueryAliases[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.ParentAddress) > 0 {
+		i -= len(m.ParentAddress)
+		copy(dAtA[i:], m.ParentAddress)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.ParentAddress)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.ContractCodeHistory) > 0 {
 		for iNdEx := len(m.ContractCodeHistory) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -630,6 +895,28 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.CodeUploadApprovals) > 0 {
+		for _, e := range m.CodeUploadApprovals {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if len(m.ScheduledSudos) > 0 {
+		for _, e := range m.ScheduledSudos {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.ParamsHistory) > 0 {
+		for _, e := range m.ParamsHistory {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -651,6 +938,18 @@ func (m *Code) Size() (n int) {
 	if m.Pinned {
 		n += 2
 	}
+	if len(m.Attestations) > 0 {
+		for _, e := range m.Attestations {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.VerificationVotes) > 0 {
+		for _, e := range m.VerificationVotes {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -678,6 +977,35 @@ func (m *Contract) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	l = len(m.ParentAddress)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if len(m.QueryAliases) > 0 {
+		for _, e := range m.QueryAliases {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.IBCSendTimeout != 0 {
+		n += 1 + sovGenesis(uint64(m.IBCSendTimeout))
+	}
+	if len(m.Usage) > 0 {
+		for _, e := range m.Usage {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.NotifyAdminChange {
+		n += 2
+	}
+	if m.ExecutionReceipt != nil {
+		l = m.ExecutionReceipt.Size()
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if m.IgnoreReplyError {
+		n += 2
+	}
 	return n
 }
 
@@ -869,62 +1197,11 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenesis(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthGenesis
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-
-func (m *Code) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowGenesis
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Code: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Code: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadApprovals", wireType)
 			}
-			m.CodeID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -934,14 +1211,29 @@ func (m *Code) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CodeID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeUploadApprovals = append(m.CodeUploadApprovals, CodeUploadApproval{})
+			if err := m.CodeUploadApprovals[len(m.CodeUploadApprovals)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -968,11 +1260,185 @@ func (m *Code) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CodeInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Stats == nil {
+				m.Stats = &WasmStats{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledSudos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScheduledSudos = append(m.ScheduledSudos, ScheduledSudo{})
+			if err := m.ScheduledSudos[len(m.ScheduledSudos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParamsHistory", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ParamsHistory = append(m.ParamsHistory, ParamsChange{})
+			if err := m.ParamsHistory[len(m.ParamsHistory)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *Code) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Code: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Code: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CodeInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field CodeBytes", wireType)
 			}
@@ -1026,6 +1492,74 @@ func (m *Code) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Pinned = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attestations = append(m.Attestations, CodeAttestation{})
+			if err := m.Attestations[len(m.Attestations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerificationVotes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VerificationVotes = append(m.VerificationVotes, CodeVerificationVote{})
+			if err := m.VerificationVotes[len(m.VerificationVotes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1210,6 +1744,201 @@ func (m *Contract) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParentAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ParentAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueryAliases", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QueryAliases = append(m.QueryAliases, QueryAlias{})
+			if err := m.QueryAliases[len(m.QueryAliases)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IBCSendTimeout", wireType)
+			}
+			m.IBCSendTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IBCSendTimeout |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Usage = append(m.Usage, EntryPointUsage{})
+			if err := m.Usage[len(m.Usage)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotifyAdminChange", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NotifyAdminChange = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutionReceipt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExecutionReceipt == nil {
+				m.ExecutionReceipt = &ExecutionReceipt{}
+			}
+			if err := m.ExecutionReceipt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreReplyError", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IgnoreReplyError = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
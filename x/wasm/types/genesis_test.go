@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 	"time"
 
@@ -49,6 +50,113 @@ func TestValidateGenesisState(t *testing.T) {
 			},
 			expError: true,
 		},
+		"code upload approvals": {
+			srcMutator: func(s *GenesisState) {
+				s.CodeUploadApprovals = []CodeUploadApproval{
+					{Checksum: bytes.Repeat([]byte{1}, 32), ConsumeOnce: true},
+					{Checksum: bytes.Repeat([]byte{2}, 32)},
+				}
+			},
+		},
+		"code upload approval invalid checksum": {
+			srcMutator: func(s *GenesisState) {
+				s.CodeUploadApprovals = []CodeUploadApproval{{Checksum: []byte{1}}}
+			},
+			expError: true,
+		},
+		"code upload approval duplicate": {
+			srcMutator: func(s *GenesisState) {
+				s.CodeUploadApprovals = []CodeUploadApproval{
+					{Checksum: bytes.Repeat([]byte{1}, 32)},
+					{Checksum: bytes.Repeat([]byte{1}, 32), ConsumeOnce: true},
+				}
+			},
+			expError: true,
+		},
+		"verification vote by code verifier": {
+			srcMutator: func(s *GenesisState) {
+				vote := CodeVerificationVoteFixture()
+				s.Params.CodeVerifiers = []string{vote.Voter}
+				s.Codes[0].VerificationVotes = []CodeVerificationVote{vote}
+			},
+		},
+		"verification vote by non code verifier": {
+			srcMutator: func(s *GenesisState) {
+				s.Codes[0].VerificationVotes = []CodeVerificationVote{CodeVerificationVoteFixture()}
+			},
+			expError: true,
+		},
+		"scheduled sudos": {
+			srcMutator: func(s *GenesisState) {
+				s.ScheduledSudos = []ScheduledSudo{
+					{ID: 1, Contract: s.Contracts[0].ContractAddress, Height: 10, Msg: []byte(`{}`), GasLimit: 1},
+					{ID: 2, Contract: s.Contracts[0].ContractAddress, Height: 10, Msg: []byte(`{}`), GasLimit: 1},
+				}
+			},
+		},
+		"scheduled sudo invalid": {
+			srcMutator: func(s *GenesisState) {
+				s.ScheduledSudos = []ScheduledSudo{{ID: 1, Contract: s.Contracts[0].ContractAddress, Height: 10, Msg: []byte(`{}`)}}
+			},
+			expError: true,
+		},
+		"scheduled sudo duplicate id": {
+			srcMutator: func(s *GenesisState) {
+				s.ScheduledSudos = []ScheduledSudo{
+					{ID: 1, Contract: s.Contracts[0].ContractAddress, Height: 10, Msg: []byte(`{}`), GasLimit: 1},
+					{ID: 1, Contract: s.Contracts[0].ContractAddress, Height: 11, Msg: []byte(`{}`), GasLimit: 1},
+				}
+			},
+			expError: true,
+		},
+		"params history": {
+			srcMutator: func(s *GenesisState) {
+				s.ParamsHistory = []ParamsChange{
+					{Height: 1, Authority: s.Contracts[0].ContractAddress, OldParams: DefaultParams(), NewParams: s.Params},
+					{Height: 1, Authority: s.Contracts[0].ContractAddress, OldParams: s.Params, NewParams: DefaultParams()},
+				}
+			},
+		},
+		"params history invalid entry": {
+			srcMutator: func(s *GenesisState) {
+				s.ParamsHistory = []ParamsChange{{Height: 1, Authority: invalidAddress, OldParams: DefaultParams(), NewParams: s.Params}}
+			},
+			expError: true,
+		},
+		"params history not ordered by height": {
+			srcMutator: func(s *GenesisState) {
+				s.ParamsHistory = []ParamsChange{
+					{Height: 2, Authority: s.Contracts[0].ContractAddress, OldParams: DefaultParams(), NewParams: s.Params},
+					{Height: 1, Authority: s.Contracts[0].ContractAddress, OldParams: s.Params, NewParams: DefaultParams()},
+				}
+			},
+			expError: true,
+		},
+		"params history exceeds limit": {
+			srcMutator: func(s *GenesisState) {
+				for i := 0; i <= MaxParamsHistoryLen; i++ {
+					s.ParamsHistory = append(s.ParamsHistory, ParamsChange{Height: int64(i), Authority: s.Contracts[0].ContractAddress, OldParams: DefaultParams(), NewParams: s.Params})
+				}
+			},
+			expError: true,
+		},
+		"stats match": {
+			srcMutator: func(s *GenesisState) {
+				s.Stats = &WasmStats{CodeCount: uint64(len(s.Codes)), ContractCount: uint64(len(s.Contracts))}
+			},
+		},
+		"stats code count mismatch": {
+			srcMutator: func(s *GenesisState) {
+				s.Stats = &WasmStats{CodeCount: uint64(len(s.Codes)) + 1, ContractCount: uint64(len(s.Contracts))}
+			},
+			expError: true,
+		},
+		"stats contract count mismatch": {
+			srcMutator: func(s *GenesisState) {
+				s.Stats = &WasmStats{CodeCount: uint64(len(s.Codes)), ContractCount: uint64(len(s.Contracts)) + 1}
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -99,6 +207,52 @@ func TestCodeValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"with attestation": {
+			srcMutator: func(c *Code) {
+				c.Attestations = []CodeAttestation{CodeAttestationFixture()}
+			},
+		},
+		"attestation invalid": {
+			srcMutator: func(c *Code) {
+				c.Attestations = []CodeAttestation{CodeAttestationFixture(func(a *CodeAttestation) {
+					a.Commit = "main"
+				})}
+			},
+			expError: true,
+		},
+		"duplicate attester": {
+			srcMutator: func(c *Code) {
+				c.Attestations = []CodeAttestation{CodeAttestationFixture(), CodeAttestationFixture()}
+			},
+			expError: true,
+		},
+		"with verification vote": {
+			srcMutator: func(c *Code) {
+				c.VerificationVotes = []CodeVerificationVote{CodeVerificationVoteFixture()}
+			},
+		},
+		"verification vote without verdict": {
+			srcMutator: func(c *Code) {
+				c.VerificationVotes = []CodeVerificationVote{CodeVerificationVoteFixture(func(v *CodeVerificationVote) {
+					v.Verdict = CodeVerificationVerdictUnspecified
+				})}
+			},
+			expError: true,
+		},
+		"verification vote invalid voter": {
+			srcMutator: func(c *Code) {
+				c.VerificationVotes = []CodeVerificationVote{CodeVerificationVoteFixture(func(v *CodeVerificationVote) {
+					v.Voter = invalidAddress
+				})}
+			},
+			expError: true,
+		},
+		"duplicate voter": {
+			srcMutator: func(c *Code) {
+				c.VerificationVotes = []CodeVerificationVote{CodeVerificationVoteFixture(), CodeVerificationVoteFixture()}
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -149,6 +303,105 @@ func TestContractValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"parent address set": {
+			srcMutator: func(c *Contract) {
+				c.ParentAddress = sdk.AccAddress(rand.Bytes(ContractAddrLen)).String()
+			},
+		},
+		"parent address invalid": {
+			srcMutator: func(c *Contract) {
+				c.ParentAddress = invalidAddress
+			},
+			expError: true,
+		},
+		"parent address is contract": {
+			srcMutator: func(c *Contract) {
+				c.ParentAddress = c.ContractAddress
+			},
+			expError: true,
+		},
+		"query aliases set": {
+			srcMutator: func(c *Contract) {
+				c.QueryAliases = []QueryAlias{{Name: "config", QueryData: []byte(`{"config":{}}`)}, {Name: "balance", QueryData: []byte(`{}`)}}
+			},
+		},
+		"query alias invalid": {
+			srcMutator: func(c *Contract) {
+				c.QueryAliases = []QueryAlias{{Name: "my alias", QueryData: []byte(`{}`)}}
+			},
+			expError: true,
+		},
+		"query alias with cache ttl": {
+			srcMutator: func(c *Contract) {
+				c.QueryAliases = []QueryAlias{{Name: "config", QueryData: []byte(`{}`), CacheTTLSeconds: 60}}
+			},
+		},
+		"query alias cache ttl exceeds limit": {
+			srcMutator: func(c *Contract) {
+				c.QueryAliases = []QueryAlias{{Name: "config", QueryData: []byte(`{}`), CacheTTLSeconds: MaxQueryAliasCacheTTLSeconds + 1}}
+			},
+			expError: true,
+		},
+		"query alias without data": {
+			srcMutator: func(c *Contract) {
+				c.QueryAliases = []QueryAlias{{Name: "config"}}
+			},
+			expError: true,
+		},
+		"duplicate query alias": {
+			srcMutator: func(c *Contract) {
+				c.QueryAliases = []QueryAlias{{Name: "config", QueryData: []byte(`{}`)}, {Name: "config", QueryData: []byte(`{}`)}}
+			},
+			expError: true,
+		},
+		"query aliases exceed limit": {
+			srcMutator: func(c *Contract) {
+				for i := 0; i <= MaxQueryAliasesPerContract; i++ {
+					c.QueryAliases = append(c.QueryAliases, QueryAlias{Name: fmt.Sprintf("alias%d", i), QueryData: []byte(`{}`)})
+				}
+			},
+			expError: true,
+		},
+		"with usage": {
+			srcMutator: func(c *Contract) {
+				c.Usage = []EntryPointUsage{{EntryPoint: "execute", Count: 2}, {EntryPoint: "instantiate", Count: 1}}
+			},
+		},
+		"usage with empty entry point": {
+			srcMutator: func(c *Contract) {
+				c.Usage = []EntryPointUsage{{Count: 1}}
+			},
+			expError: true,
+		},
+		"duplicate usage entry point": {
+			srcMutator: func(c *Contract) {
+				c.Usage = []EntryPointUsage{{EntryPoint: "execute", Count: 1}, {EntryPoint: "execute", Count: 2}}
+			},
+			expError: true,
+		},
+		"with execution receipt": {
+			srcMutator: func(c *Contract) {
+				c.ExecutionReceipt = &ExecutionReceipt{Height: 1, Kind: ExecutionReceiptKindIBC, GasUsed: 1}
+			},
+		},
+		"execution receipt with negative height": {
+			srcMutator: func(c *Contract) {
+				c.ExecutionReceipt = &ExecutionReceipt{Height: -1, Kind: ExecutionReceiptKindExecute}
+			},
+			expError: true,
+		},
+		"execution receipt with unspecified kind": {
+			srcMutator: func(c *Contract) {
+				c.ExecutionReceipt = &ExecutionReceipt{Height: 1}
+			},
+			expError: true,
+		},
+		"execution receipt with unknown kind": {
+			srcMutator: func(c *Contract) {
+				c.ExecutionReceipt = &ExecutionReceipt{Height: 1, Kind: 99}
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -211,3 +464,85 @@ func TestGenesisContractInfoMarshalUnmarshal(t *testing.T) {
 	require.NoError(t, dest.ReadExtension(&destExt))
 	assert.Equal(t, destExt.GetTitle(), "bar")
 }
+
+func TestGenesisMergeFragment(t *testing.T) {
+	codeSeq := func(v uint64) Sequence { return Sequence{IDKey: KeySequenceCodeID, Value: v} }
+	instanceSeq := func(v uint64) Sequence { return Sequence{IDKey: KeySequenceInstanceID, Value: v} }
+	specs := map[string]struct {
+		src      GenesisState
+		fragment GenesisState
+		exp      GenesisState
+		expErr   bool
+	}{
+		"merged into empty genesis": {
+			fragment: GenesisState{
+				Codes:     []Code{{CodeID: 3}},
+				Contracts: []Contract{{ContractAddress: "contract1"}},
+				Sequences: []Sequence{codeSeq(4)},
+			},
+			exp: GenesisState{
+				Codes:     []Code{{CodeID: 3}},
+				Contracts: []Contract{{ContractAddress: "contract1"}},
+				Sequences: []Sequence{codeSeq(4)},
+			},
+		},
+		"appended with higher sequences": {
+			src: GenesisState{
+				Params:    DefaultParams(),
+				Codes:     []Code{{CodeID: 1}},
+				Contracts: []Contract{{ContractAddress: "contract1"}},
+				Sequences: []Sequence{codeSeq(2), instanceSeq(5)},
+			},
+			fragment: GenesisState{
+				Params:    Params{},
+				Codes:     []Code{{CodeID: 3}},
+				Contracts: []Contract{{ContractAddress: "contract2"}},
+				Sequences: []Sequence{codeSeq(4), instanceSeq(1)},
+			},
+			exp: GenesisState{
+				Params:    DefaultParams(),
+				Codes:     []Code{{CodeID: 1}, {CodeID: 3}},
+				Contracts: []Contract{{ContractAddress: "contract1"}, {ContractAddress: "contract2"}},
+				Sequences: []Sequence{codeSeq(4), instanceSeq(5)},
+			},
+		},
+		"stats dropped": {
+			src: GenesisState{
+				Codes: []Code{{CodeID: 1}},
+				Stats: &WasmStats{CodeCount: 1},
+			},
+			fragment: GenesisState{
+				Codes: []Code{{CodeID: 3}},
+				Stats: &WasmStats{CodeCount: 1},
+			},
+			exp: GenesisState{
+				Codes: []Code{{CodeID: 1}, {CodeID: 3}},
+			},
+		},
+		"duplicate code": {
+			src:      GenesisState{Codes: []Code{{CodeID: 1}}},
+			fragment: GenesisState{Codes: []Code{{CodeID: 1}}},
+			expErr:   true,
+		},
+		"duplicate code in fragment": {
+			fragment: GenesisState{Codes: []Code{{CodeID: 1}, {CodeID: 1}}},
+			expErr:   true,
+		},
+		"duplicate contract": {
+			src:      GenesisState{Contracts: []Contract{{ContractAddress: "contract1"}}},
+			fragment: GenesisState{Contracts: []Contract{{ContractAddress: "contract1"}}},
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := spec.src.MergeFragment(spec.fragment)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, spec.src)
+		})
+	}
+}
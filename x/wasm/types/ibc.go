@@ -0,0 +1,27 @@
+package types
+
+import (
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PortIDPrefix is the prefix of all contract IBC port ids
+const PortIDPrefix = "wasm."
+
+// PortIDForContract derives the IBC port id that is bound for a contract with IBC entry points.
+// This is the only place where the derivation is defined so that it can be computed offline
+// before the contract exists.
+func PortIDForContract(addr sdk.AccAddress) string {
+	return PortIDPrefix + addr.String()
+}
+
+// ContractFromPortID returns the contract address for an IBC port id
+func ContractFromPortID(portID string) (sdk.AccAddress, error) {
+	if !strings.HasPrefix(portID, PortIDPrefix) {
+		return nil, errorsmod.Wrapf(ErrInvalid, "without prefix")
+	}
+	return sdk.AccAddressFromBech32(portID[len(PortIDPrefix):])
+}
@@ -1,8 +1,11 @@
 package types
 
 import (
+	"bytes"
 	"encoding/binary"
 
+	errorsmod "cosmossdk.io/errors"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
 )
@@ -36,9 +39,45 @@ var (
 	ContractsByCreatorPrefix                       = []byte{0x09}
 	ParamsKey                                      = []byte{0x10}
 	AsyncAckKeyPrefix                              = []byte{0x11}
+	ParamSubscriberPrefix                          = []byte{0x12}
+	ContractChildrenPrefix                         = []byte{0x13}
+	ContractParentPrefix                           = []byte{0x14}
+	CodeUploadPrefix                               = []byte{0x15}
+	CodeUploadChunkPrefix                          = []byte{0x16}
+	CodeUploadExpiryPrefix                         = []byte{0x17}
+	QueryAliasPrefix                               = []byte{0x18}
+	IBCSendTimeoutPrefix                           = []byte{0x19}
+	ContractUsagePrefix                            = []byte{0x1a}
+	AdminChangeNotificationPrefix                  = []byte{0x1b}
+	ExecutionReceiptPrefix                         = []byte{0x1c}
+	CodeAttestationPrefix                          = []byte{0x1e}
+	ContractsByTagPrefix                           = []byte{0x1f}
+	FeeSponsorshipUsagePrefix                      = []byte{0x20}
+	ContractsByLabelPrefix                         = []byte{0x21}
+	CodesByChecksumPrefix                          = []byte{0x22}
+	CodesByCreatorPrefix                           = []byte{0x23}
+	CodeUploadApprovalPrefix                       = []byte{0x24}
+	WasmStatsKey                                   = []byte{0x25}
+	ContractCountByCodePrefix                      = []byte{0x26}
+	ContractsByAdminPrefix                         = []byte{0x27}
+	CodeVerificationVotePrefix                     = []byte{0x28}
+	ScheduledSudoPrefix                            = []byte{0x29}
+	ScheduledSudosByContractPrefix                 = []byte{0x2a}
+	QueryAliasCacheTTLPrefix                       = []byte{0x2b}
+	ParamsHistoryPrefix                            = []byte{0x2c}
+	ContractSunsetPrefix                           = []byte{0x2d}
+	IgnoreReplyErrorPrefix                         = []byte{0x2e}
+	ScheduledSudoGasPrefix                         = []byte{0x2f}
+
+	KeySequenceCodeID          = append(SequenceKeyPrefix, []byte("lastCodeId")...)
+	KeySequenceInstanceID      = append(SequenceKeyPrefix, []byte("lastContractId")...)
+	KeySequenceScheduledSudoID = append(SequenceKeyPrefix, []byte("lastScheduledSudoId")...)
+)
 
-	KeySequenceCodeID     = append(SequenceKeyPrefix, []byte("lastCodeId")...)
-	KeySequenceInstanceID = append(SequenceKeyPrefix, []byte("lastContractId")...)
+// keys of the transient store that are reset after every block
+var (
+	BlockSummaryCountersKey    = []byte{0x01}
+	BlockSummaryContractPrefix = []byte{0x02}
 )
 
 // GetCodeKey constructs the key for retrieving the ID for the WASM code
@@ -47,6 +86,93 @@ func GetCodeKey(codeID uint64) []byte {
 	return append(CodeKeyPrefix, contractIDBz...)
 }
 
+// GetCodesByChecksumPrefix returns the key prefix for all codes with the checksum: `<prefix><checksum length><checksum>`
+func GetCodesByChecksumPrefix(checksum []byte) []byte {
+	return append(bytes.Clone(CodesByChecksumPrefix), address.MustLengthPrefix(checksum)...)
+}
+
+// GetCodeByChecksumKey returns the key of the code in the checksum index: `<prefix><checksum length><checksum><codeID>`
+func GetCodeByChecksumKey(checksum []byte, codeID uint64) []byte {
+	return append(GetCodesByChecksumPrefix(checksum), sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodesByCreatorPrefix returns the key prefix for all codes of the creator: `<prefix><creator length><creator>`
+func GetCodesByCreatorPrefix(creator sdk.AccAddress) []byte {
+	return append(bytes.Clone(CodesByCreatorPrefix), address.MustLengthPrefix(creator)...)
+}
+
+// GetCodeByCreatorKey returns the key of the code in the creator index: `<prefix><creator length><creator><codeID>`
+func GetCodeByCreatorKey(creator sdk.AccAddress, codeID uint64) []byte {
+	return append(GetCodesByCreatorPrefix(creator), sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeUploadApprovalKey returns the key of the upload approval for a code checksum: `<prefix><checksum>`
+func GetCodeUploadApprovalKey(checksum []byte) []byte {
+	return append(bytes.Clone(CodeUploadApprovalPrefix), checksum...)
+}
+
+// GetContractCountByCodeKey returns the key of the number of contracts of a code: `<prefix><codeID>`
+func GetContractCountByCodeKey(codeID uint64) []byte {
+	return append(bytes.Clone(ContractCountByCodePrefix), sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetContractsByAdminPrefix returns the key prefix for all contracts of the admin: `<prefix><admin length><admin>`
+func GetContractsByAdminPrefix(admin sdk.AccAddress) []byte {
+	return append(bytes.Clone(ContractsByAdminPrefix), address.MustLengthPrefix(admin)...)
+}
+
+// GetContractByAdminKey returns the key of the contract in the admin index: `<prefix><admin length><admin><contractAddr>`
+func GetContractByAdminKey(admin, contractAddr sdk.AccAddress) []byte {
+	return append(GetContractsByAdminPrefix(admin), contractAddr...)
+}
+
+// GetScheduledSudosPrefix returns the key prefix for all sudo calls scheduled at or before the height:
+// `<prefix><height+1>`. It is the exclusive end of an iteration from ScheduledSudoPrefix.
+func GetScheduledSudosPrefix(height int64) []byte {
+	return append(bytes.Clone(ScheduledSudoPrefix), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetScheduledSudoKey returns the key of a scheduled sudo call: `<prefix><height><id>`.
+// The calls of a block are ordered by id which is the order in which they were scheduled.
+func GetScheduledSudoKey(height int64, id uint64) []byte {
+	return append(GetScheduledSudosPrefix(height), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetScheduledSudoGasKey returns the key of the sum of the gas limits of the sudo calls scheduled at the height:
+// `<prefix><height>`
+func GetScheduledSudoGasKey(height int64) []byte {
+	return append(bytes.Clone(ScheduledSudoGasPrefix), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetScheduledSudosByContractPrefix returns the key prefix for all scheduled sudo calls of the contract:
+// `<prefix><contractAddr length><contractAddr>`
+func GetScheduledSudosByContractPrefix(contractAddr sdk.AccAddress) []byte {
+	return append(bytes.Clone(ScheduledSudosByContractPrefix), address.MustLengthPrefix(contractAddr)...)
+}
+
+// GetScheduledSudoByContractKey returns the key of the scheduled sudo call in the contract index:
+// `<prefix><contractAddr length><contractAddr><id>`
+func GetScheduledSudoByContractKey(contractAddr sdk.AccAddress, id uint64) []byte {
+	return append(GetScheduledSudosByContractPrefix(contractAddr), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetParamsChangeKey returns the key of an entry of the params history: `<prefix><seq>`
+func GetParamsChangeKey(seq uint64) []byte {
+	return append(bytes.Clone(ParamsHistoryPrefix), sdk.Uint64ToBigEndian(seq)...)
+}
+
+// GetContractSunsetsPrefix returns the key prefix for all contract sunsets at or before the height:
+// `<prefix><height+1>`. It is the exclusive end of an iteration from ContractSunsetPrefix.
+func GetContractSunsetsPrefix(height int64) []byte {
+	return append(bytes.Clone(ContractSunsetPrefix), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetContractSunsetKey returns the key of a contract sunset: `<prefix><height><contractAddr>`.
+// The sunsets of a block are ordered by contract address.
+func GetContractSunsetKey(height int64, contractAddr sdk.AccAddress) []byte {
+	return append(GetContractSunsetsPrefix(height), contractAddr...)
+}
+
 // GetContractAddressKey returns the key for the WASM contract instance
 func GetContractAddressKey(addr sdk.AccAddress) []byte {
 	return append(ContractKeyPrefix, addr...)
@@ -58,6 +184,55 @@ func GetContractsByCreatorPrefix(addr sdk.AccAddress) []byte {
 	return append(ContractsByCreatorPrefix, bz...)
 }
 
+// GetContractsByLabelPrefix returns the key prefix for all contracts with a label that starts with the given label:
+// `<prefix><label>`
+func GetContractsByLabelPrefix(label string) []byte {
+	return append(bytes.Clone(ContractsByLabelPrefix), label...)
+}
+
+// GetContractsByCreatorLabelPrefix returns the key prefix for all contracts of the creator with the label:
+// `<prefix><label><0x00><creatorAddress length><creatorAddress>`
+func GetContractsByCreatorLabelPrefix(creator sdk.AccAddress, label string) []byte {
+	r := append(GetContractsByLabelPrefix(label), 0)
+	return append(r, address.MustLengthPrefix(creator)...)
+}
+
+// GetContractByLabelKey returns the key of the contract in the label index:
+// `<prefix><label><0x00><creatorAddress length><creatorAddress><contractAddr>`.
+// The label is not length prefixed so that it can be iterated by prefix. Labels have printable characters
+// only so that the separator orders the contracts by label.
+func GetContractByLabelKey(label string, creator, contractAddr sdk.AccAddress) []byte {
+	return append(GetContractsByCreatorLabelPrefix(creator, label), contractAddr...)
+}
+
+// ParseContractByLabelKey returns the label and contract address from a key of the label index without the prefix
+func ParseContractByLabelKey(key []byte) (string, sdk.AccAddress, error) {
+	pos := bytes.IndexByte(key, 0)
+	if pos < 0 {
+		return "", nil, ErrInvalid.Wrap("label separator not found")
+	}
+	creatorPos := pos + 1
+	if creatorPos >= len(key) || creatorPos+1+int(key[creatorPos]) > len(key) {
+		return "", nil, ErrInvalid.Wrap("creator address")
+	}
+	return string(key[:pos]), sdk.AccAddress(key[creatorPos+1+int(key[creatorPos]):]), nil
+}
+
+// GetContractsByTagPrefix returns the key prefix for all contracts with the tag: `<prefix><tag length><tag>`
+func GetContractsByTagPrefix(tag string) []byte {
+	return append(bytes.Clone(ContractsByTagPrefix), address.MustLengthPrefix([]byte(tag))...)
+}
+
+// GetContractByTagKey returns the key of the contract in the tag index: `<prefix><tag length><tag><contractAddr>`
+func GetContractByTagKey(tag string, contractAddr sdk.AccAddress) []byte {
+	return append(GetContractsByTagPrefix(tag), contractAddr...)
+}
+
+// GetFeeSponsorshipUsageKey returns the key of the fees that the contract paid in the last block: `<prefix><contractAddr>`
+func GetFeeSponsorshipUsageKey(contractAddr sdk.AccAddress) []byte {
+	return append(bytes.Clone(FeeSponsorshipUsagePrefix), contractAddr...)
+}
+
 // GetContractStorePrefix returns the store prefix for the WASM contract instance
 func GetContractStorePrefix(addr sdk.AccAddress) []byte {
 	return append(ContractStorePrefix, addr...)
@@ -149,3 +324,161 @@ func GetPinnedCodeIndexPrefix(codeID uint64) []byte {
 func ParsePinnedCodeIndex(s []byte) uint64 {
 	return sdk.BigEndianToUint64(s)
 }
+
+// GetParamSubscriberPrefix returns the key prefix for all contracts subscribed to a param subspace: `<prefix><subspace length><subspace>`
+func GetParamSubscriberPrefix(subspace string) []byte {
+	bz := address.MustLengthPrefix([]byte(subspace))
+	return append(append([]byte{}, ParamSubscriberPrefix...), bz...)
+}
+
+// GetParamSubscriberKey returns the key for a contract subscribed to a param subspace: `<prefix><subspace length><subspace><contractAddr>`
+func GetParamSubscriberKey(subspace string, contractAddr sdk.AccAddress) []byte {
+	return append(GetParamSubscriberPrefix(subspace), contractAddr...)
+}
+
+// GetContractChildrenPrefix returns the key prefix for all contracts instantiated by the parent contract: `<prefix><parent length><parent>`
+func GetContractChildrenPrefix(parent sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(parent)
+	return append(append([]byte{}, ContractChildrenPrefix...), bz...)
+}
+
+// GetContractChildKey returns the key for a contract instantiated by the parent contract: `<prefix><parent length><parent><child>`
+func GetContractChildKey(parent, child sdk.AccAddress) []byte {
+	return append(GetContractChildrenPrefix(parent), child...)
+}
+
+// GetContractParentKey returns the key for the parent contract of a contract: `<prefix><child>`
+func GetContractParentKey(child sdk.AccAddress) []byte {
+	return append(append([]byte{}, ContractParentPrefix...), child...)
+}
+
+// GetCodeUploadKey returns the key for a chunked code upload: `<prefix><sender length><sender><upload id length><upload id>`
+func GetCodeUploadKey(sender sdk.AccAddress, uploadID string) []byte {
+	return append(append([]byte{}, CodeUploadPrefix...), codeUploadSuffix(sender, uploadID)...)
+}
+
+// GetCodeUploadChunkPrefix returns the key prefix for all chunks of a code upload: `<prefix><sender length><sender><upload id length><upload id>`
+func GetCodeUploadChunkPrefix(sender sdk.AccAddress, uploadID string) []byte {
+	return append(append([]byte{}, CodeUploadChunkPrefix...), codeUploadSuffix(sender, uploadID)...)
+}
+
+// GetCodeUploadChunkKey returns the key for a chunk of a code upload: `<chunk prefix><index>`
+func GetCodeUploadChunkKey(sender sdk.AccAddress, uploadID string, index uint32) []byte {
+	indexBz := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBz, index)
+	return append(GetCodeUploadChunkPrefix(sender, uploadID), indexBz...)
+}
+
+// GetCodeUploadExpiryPrefix returns the key prefix for all code uploads that expire at the given height: `<prefix><height>`
+func GetCodeUploadExpiryPrefix(height int64) []byte {
+	return append(append([]byte{}, CodeUploadExpiryPrefix...), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetCodeUploadExpiryKey returns the key for a code upload in the expiry index: `<prefix><height><sender length><sender><upload id length><upload id>`
+func GetCodeUploadExpiryKey(height int64, sender sdk.AccAddress, uploadID string) []byte {
+	return append(GetCodeUploadExpiryPrefix(height), codeUploadSuffix(sender, uploadID)...)
+}
+
+// ParseCodeUploadExpiryKey returns the sender and upload id from a key of the expiry index without the `<prefix><height>`
+func ParseCodeUploadExpiryKey(key []byte) (sdk.AccAddress, string, error) {
+	sender, rest, err := parseLengthPrefixed(key)
+	if err != nil {
+		return nil, "", errorsmod.Wrap(err, "sender")
+	}
+	uploadID, rest, err := parseLengthPrefixed(rest)
+	if err != nil {
+		return nil, "", errorsmod.Wrap(err, "upload id")
+	}
+	if len(rest) != 0 {
+		return nil, "", ErrInvalid.Wrap("unexpected trailing bytes")
+	}
+	return sender, string(uploadID), nil
+}
+
+// GetQueryAliasPrefix returns the key prefix for all query aliases of a contract: `<prefix><contract length><contract>`
+func GetQueryAliasPrefix(contractAddr sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(contractAddr)
+	return append(append([]byte{}, QueryAliasPrefix...), bz...)
+}
+
+// GetQueryAliasKey returns the key for a query alias of a contract: `<prefix><contract length><contract><name>`
+func GetQueryAliasKey(contractAddr sdk.AccAddress, name string) []byte {
+	return append(GetQueryAliasPrefix(contractAddr), name...)
+}
+
+// GetQueryAliasCacheTTLKey returns the key for the cache ttl of a query alias: `<prefix><contract length><contract><name>`
+func GetQueryAliasCacheTTLKey(contractAddr sdk.AccAddress, name string) []byte {
+	bz := address.MustLengthPrefix(contractAddr)
+	return append(append(append([]byte{}, QueryAliasCacheTTLPrefix...), bz...), name...)
+}
+
+// GetCodeAttestationPrefix returns the key prefix for all attestations of a code: `<prefix><codeID>`
+func GetCodeAttestationPrefix(codeID uint64) []byte {
+	return append(append([]byte{}, CodeAttestationPrefix...), sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeAttestationKey returns the key for the attestation of a code by the attester: `<prefix><codeID><attester>`
+func GetCodeAttestationKey(codeID uint64, attester sdk.AccAddress) []byte {
+	return append(GetCodeAttestationPrefix(codeID), attester...)
+}
+
+// GetCodeVerificationVotePrefix returns the key prefix for all verification votes of a code: `<prefix><codeID>`
+func GetCodeVerificationVotePrefix(codeID uint64) []byte {
+	return append(bytes.Clone(CodeVerificationVotePrefix), sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeVerificationVoteKey returns the key for the verification vote of a code by the voter: `<prefix><codeID><voter>`
+func GetCodeVerificationVoteKey(codeID uint64, voter sdk.AccAddress) []byte {
+	return append(GetCodeVerificationVotePrefix(codeID), voter...)
+}
+
+// GetIBCSendTimeoutKey returns the key for the default IBC send timeout of a contract
+func GetIBCSendTimeoutKey(contractAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, IBCSendTimeoutPrefix...), contractAddr...)
+}
+
+// GetContractUsagePrefix returns the key prefix for all entry point counters of a contract: `<prefix><contract length><contract>`
+func GetContractUsagePrefix(contractAddr sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(contractAddr)
+	return append(append([]byte{}, ContractUsagePrefix...), bz...)
+}
+
+// GetContractUsageKey returns the key for an entry point counter of a contract: `<prefix><contract length><contract><entry point>`
+func GetContractUsageKey(contractAddr sdk.AccAddress, entryPoint string) []byte {
+	return append(GetContractUsagePrefix(contractAddr), entryPoint...)
+}
+
+// GetAdminChangeNotificationKey returns the key for the admin change notification flag of a contract
+func GetAdminChangeNotificationKey(contractAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, AdminChangeNotificationPrefix...), contractAddr...)
+}
+
+// GetIgnoreReplyErrorKey returns the key for the ignore reply error policy flag of a contract
+func GetIgnoreReplyErrorKey(contractAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, IgnoreReplyErrorPrefix...), contractAddr...)
+}
+
+// GetExecutionReceiptKey returns the key for the last execution receipt of a contract
+func GetExecutionReceiptKey(contractAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, ExecutionReceiptPrefix...), contractAddr...)
+}
+
+// GetBlockSummaryContractKey returns the transient store key that marks the contract as touched in the current block
+func GetBlockSummaryContractKey(contractAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, BlockSummaryContractPrefix...), contractAddr...)
+}
+
+func codeUploadSuffix(sender sdk.AccAddress, uploadID string) []byte {
+	return append(address.MustLengthPrefix(sender), address.MustLengthPrefix([]byte(uploadID))...)
+}
+
+func parseLengthPrefixed(bz []byte) ([]byte, []byte, error) {
+	if len(bz) == 0 {
+		return nil, nil, ErrInvalid.Wrap("empty key")
+	}
+	n := int(bz[0])
+	if len(bz) < n+1 {
+		return nil, nil, ErrInvalid.Wrapf("key too short for length %d", n)
+	}
+	return bz[1 : n+1], bz[n+1:], nil
+}
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func TestGetContractByCodeIDSecondaryIndexPrefix(t *testing.T) {
@@ -146,3 +149,37 @@ func TestGetContractByCreatorSecondaryIndexKey(t *testing.T) {
 	}
 	assert.Equal(t, exp, got)
 }
+
+func TestContractByLabelKey(t *testing.T) {
+	creatorAddr := bytes.Repeat([]byte{3}, 20)
+	contractAddr := bytes.Repeat([]byte{4}, 20)
+	got := GetContractByLabelKey("ab", creatorAddr, contractAddr)
+	exp := []byte{
+		0x21,     // prefix
+		'a', 'b', // label
+		0,                            // separator
+		20,                           // creator length
+		3, 3, 3, 3, 3, 3, 3, 3, 3, 3, // creator 20 bytes
+		3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4, // address 20 bytes
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+	}
+	assert.Equal(t, exp, got)
+	assert.True(t, bytes.HasPrefix(got, GetContractsByLabelPrefix("a")))
+	assert.True(t, bytes.HasPrefix(got, GetContractsByCreatorLabelPrefix(creatorAddr, "ab")))
+	assert.False(t, bytes.HasPrefix(GetContractByLabelKey("abc", creatorAddr, contractAddr), GetContractsByCreatorLabelPrefix(creatorAddr, "ab")))
+	// and ordered by label before creator and address
+	assert.Negative(t, bytes.Compare(GetContractByLabelKey("ab", bytes.Repeat([]byte{0xff}, 32), contractAddr), GetContractByLabelKey("ab c", creatorAddr, []byte{0})))
+
+	// and parsed back without prefix
+	for _, addr := range [][]byte{contractAddr, bytes.Repeat([]byte{0}, 32)} {
+		label, gotAddr, err := ParseContractByLabelKey(GetContractByLabelKey("my label", creatorAddr, addr)[1:])
+		require.NoError(t, err)
+		assert.Equal(t, "my label", label)
+		assert.Equal(t, sdk.AccAddress(addr), gotAddr)
+	}
+	for _, key := range [][]byte{[]byte("no separator"), []byte("no creator\x00"), []byte("short creator\x00\x14\x03")} {
+		_, _, err := ParseContractByLabelKey(key)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ParamsChangeListener is the hook that modules call after their params were changed.
+// The wasm keeper implements it and forwards the new values to all contracts that were
+// subscribed to the subspace via MsgRegisterParamSubscriber.
+type ParamsChangeListener interface {
+	// OnParamsChanged is called with the module name as subspace and the new params as json.
+	// Failures are not returned but emitted as events so that the param change is never reverted.
+	OnParamsChanged(ctx sdk.Context, subspace string, params json.RawMessage)
+}
+
+// ParamsChangedSudoMsg is the sudo message that is sent to a subscribed contract on a param change
+type ParamsChangedSudoMsg struct {
+	ParamsChanged ParamsChanged `json:"params_changed"`
+}
+
+// ParamsChanged contains the subspace and the new params of a module
+type ParamsChanged struct {
+	// Subspace is the name of the module that changed params
+	Subspace string `json:"subspace"`
+	// Params are the json encoded new params of the module
+	Params json.RawMessage `json:"params"`
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"slices"
+	"strings"
 
 	"github.com/cosmos/gogoproto/jsonpb"
 	"github.com/pkg/errors"
@@ -18,6 +20,9 @@ var AllAccessTypes = []AccessType{
 	AccessTypeEverybody,
 }
 
+// MaxParamsHistoryLen is the number of the latest params changes that are kept in the history
+const MaxParamsHistoryLen = 20
+
 func (a AccessType) With(addrs ...sdk.AccAddress) AccessConfig {
 	switch a {
 	case AccessTypeNobody:
@@ -72,6 +77,13 @@ func (a *AccessType) UnmarshalJSONPB(_ *jsonpb.Unmarshaler, data []byte) error {
 	return json.Unmarshal(data, a)
 }
 
+// AllCodeOrigins are the channels that a code can be uploaded through
+var AllCodeOrigins = []CodeOrigin{
+	CodeOriginDirect,
+	CodeOriginGovernance,
+	CodeOriginAuthz,
+}
+
 func (a AccessConfig) Equals(o AccessConfig) bool {
 	return a.Permission == o.Permission
 }
@@ -106,9 +118,167 @@ func (p Params) ValidateBasic() error {
 	if err := p.CodeUploadAccess.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "upload access")
 	}
+	uniqueModules := make(map[string]struct{}, len(p.ParamsQueryModules))
+	for _, m := range p.ParamsQueryModules {
+		if strings.TrimSpace(m) == "" {
+			return errorsmod.Wrap(ErrEmpty, "params query module")
+		}
+		if _, exists := uniqueModules[m]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "params query module: %s", m)
+		}
+		uniqueModules[m] = struct{}{}
+	}
+	uniqueFees := make(map[string]struct{}, len(p.MessageFees))
+	for _, f := range p.MessageFees {
+		if err := f.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "message fee")
+		}
+		if _, exists := uniqueFees[f.TypeURL]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "message fee: %s", f.TypeURL)
+		}
+		uniqueFees[f.TypeURL] = struct{}{}
+	}
+	uniqueOrigins := make(map[CodeOrigin]struct{}, len(p.InstantiateDefaultPermissionsByOrigin))
+	for _, o := range p.InstantiateDefaultPermissionsByOrigin {
+		if err := o.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "instantiate default permission by origin")
+		}
+		if _, exists := uniqueOrigins[o.Origin]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "instantiate default permission by origin: %s", o.Origin)
+		}
+		uniqueOrigins[o.Origin] = struct{}{}
+	}
+	for _, l := range []struct {
+		name string
+		v    uint32
+	}{
+		{"max response data size", p.MaxResponseDataSize},
+		{"max response events", p.MaxResponseEvents},
+		{"max response attributes", p.MaxResponseAttributes},
+	} {
+		if l.v > VMResponseSizeLimit {
+			return errorsmod.Wrapf(ErrInvalid, "%s must not exceed the wasmvm limit %d", l.name, VMResponseSizeLimit)
+		}
+	}
+	if len(p.CodeVerifiers) != 0 {
+		if err := validateBech32Addresses(p.CodeVerifiers); err != nil {
+			return errorsmod.Wrap(err, "code verifiers")
+		}
+	}
+	if int(p.CodeVerificationThreshold) > len(p.CodeVerifiers) {
+		return errorsmod.Wrapf(ErrInvalid, "code verification threshold %d exceeds %d code verifiers", p.CodeVerificationThreshold, len(p.CodeVerifiers))
+	}
 	return nil
 }
 
+// IsCodeVerifier returns true when the address is in the code verifier set
+func (p Params) IsCodeVerifier(addr sdk.AccAddress) bool {
+	return slices.ContainsFunc(p.CodeVerifiers, func(v string) bool {
+		verifier, err := sdk.AccAddressFromBech32(v)
+		return err == nil && verifier.Equals(addr)
+	})
+}
+
+// CodeVerificationStatusFor derives the build reproducibility status of a code from the number of
+// reproducible and not reproducible verifier votes. A code is verified when the threshold is met
+// by reproducible votes and nobody disagrees. It is contested when either verdict meets the threshold
+// otherwise. A threshold of 0 disables the status.
+func (p Params) CodeVerificationStatusFor(reproducible, notReproducible uint32) CodeVerificationStatus {
+	threshold := p.CodeVerificationThreshold
+	switch {
+	case threshold == 0:
+		return CodeVerificationStatusUnverified
+	case reproducible >= threshold && notReproducible == 0:
+		return CodeVerificationStatusVerified
+	case reproducible >= threshold || notReproducible >= threshold:
+		return CodeVerificationStatusContested
+	default:
+		return CodeVerificationStatusUnverified
+	}
+}
+
+// ResponseLimits returns the effective data size, events and attributes caps of a contract response.
+// Unset params fall back to the wasmvm limit.
+func (p Params) ResponseLimits() (maxDataSize, maxEvents, maxAttributes uint64) {
+	effective := func(v uint32) uint64 {
+		if v == 0 || v > VMResponseSizeLimit {
+			return VMResponseSizeLimit
+		}
+		return uint64(v)
+	}
+	return effective(p.MaxResponseDataSize), effective(p.MaxResponseEvents), effective(p.MaxResponseAttributes)
+}
+
+// ValidateBasic performs basic validation
+func (f MessageFee) ValidateBasic() error {
+	if !strings.HasPrefix(f.TypeURL, "/") || strings.TrimSpace(f.TypeURL) != f.TypeURL || len(f.TypeURL) == 1 {
+		return errorsmod.Wrapf(ErrInvalid, "type url: %q", f.TypeURL)
+	}
+	if !f.Amount.IsValid() {
+		return errorsmod.Wrapf(ErrInvalid, "amount: %s", f.Amount)
+	}
+	return nil
+}
+
+// MessageFeeFor returns the fee for the message type url. Nil when the message is free.
+func (p Params) MessageFeeFor(typeURL string) sdk.Coins {
+	for _, f := range p.MessageFees {
+		if f.TypeURL == typeURL {
+			return f.Amount
+		}
+	}
+	return nil
+}
+
+// InstantiateDefaultPermissionFor returns the instantiate default permission for codes uploaded through the origin.
+// Falls back to the instantiate default permission when the origin has no entry. Legacy codes that were
+// stored before the origin was recorded have the unspecified origin and always use the fallback.
+func (p Params) InstantiateDefaultPermissionFor(origin CodeOrigin) AccessType {
+	if origin == CodeOriginUnspecified {
+		return p.InstantiateDefaultPermission
+	}
+	for _, o := range p.InstantiateDefaultPermissionsByOrigin {
+		if o.Origin == origin {
+			return o.Permission
+		}
+	}
+	return p.InstantiateDefaultPermission
+}
+
+// ValidateBasic performs basic validation
+func (o OriginAccessType) ValidateBasic() error {
+	if err := validateCodeOrigin(o.Origin); err != nil {
+		return errorsmod.Wrap(err, "origin")
+	}
+	return errorsmod.Wrap(validateAccessType(o.Permission), "permission")
+}
+
+// ValidateBasic performs basic validation
+func (c ParamsChange) ValidateBasic() error {
+	if c.Height < 0 {
+		return errorsmod.Wrap(ErrInvalid, "height must not be negative")
+	}
+	if _, err := sdk.AccAddressFromBech32(c.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if err := c.OldParams.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "old params")
+	}
+	return errorsmod.Wrap(c.NewParams.ValidateBasic(), "new params")
+}
+
+func validateCodeOrigin(o CodeOrigin) error {
+	if o == CodeOriginUnspecified {
+		return errorsmod.Wrap(ErrEmpty, "origin")
+	}
+	for _, v := range AllCodeOrigins {
+		if v == o {
+			return nil
+		}
+	}
+	return errorsmod.Wrapf(ErrInvalid, "unknown origin: %q", o)
+}
+
 func validateAccessType(a AccessType) error {
 	if a == AccessTypeUnspecified {
 		return errorsmod.Wrap(ErrEmpty, "type")
@@ -0,0 +1,62 @@
+package types
+
+import (
+	errorsmod "cosmossdk.io/errors"
+)
+
+// paramsFieldSetters copy a single field from the source params by its proto name.
+// New params fields must be added here to be updatable with a field mask.
+var paramsFieldSetters = map[string]func(dst *Params, src Params){
+	"code_upload_access":             func(dst *Params, src Params) { dst.CodeUploadAccess = src.CodeUploadAccess },
+	"instantiate_default_permission": func(dst *Params, src Params) { dst.InstantiateDefaultPermission = src.InstantiateDefaultPermission },
+	"disable_usage_tracking":         func(dst *Params, src Params) { dst.DisableUsageTracking = src.DisableUsageTracking },
+	"params_query_modules":           func(dst *Params, src Params) { dst.ParamsQueryModules = src.ParamsQueryModules },
+	"disable_instantiate_capability_check": func(dst *Params, src Params) {
+		dst.DisableInstantiateCapabilityCheck = src.DisableInstantiateCapabilityCheck
+	},
+	"enable_execution_receipts":         func(dst *Params, src Params) { dst.EnableExecutionReceipts = src.EnableExecutionReceipts },
+	"enforce_unique_labels_per_creator": func(dst *Params, src Params) { dst.EnforceUniqueLabelsPerCreator = src.EnforceUniqueLabelsPerCreator },
+	"disable_block_summary_events":      func(dst *Params, src Params) { dst.DisableBlockSummaryEvents = src.DisableBlockSummaryEvents },
+	"message_fees":                      func(dst *Params, src Params) { dst.MessageFees = src.MessageFees },
+	"max_response_data_size":            func(dst *Params, src Params) { dst.MaxResponseDataSize = src.MaxResponseDataSize },
+	"max_response_events":               func(dst *Params, src Params) { dst.MaxResponseEvents = src.MaxResponseEvents },
+	"max_response_attributes":           func(dst *Params, src Params) { dst.MaxResponseAttributes = src.MaxResponseAttributes },
+	"disable_exec_trace_hash":           func(dst *Params, src Params) { dst.DisableExecTraceHash = src.DisableExecTraceHash },
+	"instantiate_default_permissions_by_origin": func(dst *Params, src Params) {
+		dst.InstantiateDefaultPermissionsByOrigin = src.InstantiateDefaultPermissionsByOrigin
+	},
+	"code_verifiers":                   func(dst *Params, src Params) { dst.CodeVerifiers = src.CodeVerifiers },
+	"code_verification_threshold":      func(dst *Params, src Params) { dst.CodeVerificationThreshold = src.CodeVerificationThreshold },
+	"max_scheduled_sudos_per_contract": func(dst *Params, src Params) { dst.MaxScheduledSudosPerContract = src.MaxScheduledSudosPerContract },
+	"max_scheduled_sudo_gas_per_block": func(dst *Params, src Params) { dst.MaxScheduledSudoGasPerBlock = src.MaxScheduledSudoGasPerBlock },
+}
+
+// ValidateParamsFieldMask returns an error when the field mask is empty or has unknown or duplicate field names
+func ValidateParamsFieldMask(mask []string) error {
+	if len(mask) == 0 {
+		return ErrEmpty.Wrap("field mask")
+	}
+	unique := make(map[string]struct{}, len(mask))
+	for _, name := range mask {
+		if _, ok := paramsFieldSetters[name]; !ok {
+			return errorsmod.Wrapf(ErrInvalid, "unknown params field: %q", name)
+		}
+		if _, exists := unique[name]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "params field: %s", name)
+		}
+		unique[name] = struct{}{}
+	}
+	return nil
+}
+
+// ApplyFieldMask returns a copy of the params with the fields of the mask set from src. The resulting
+// params are not validated.
+func (p Params) ApplyFieldMask(src Params, mask []string) (Params, error) {
+	if err := ValidateParamsFieldMask(mask); err != nil {
+		return Params{}, err
+	}
+	for _, name := range mask {
+		paramsFieldSetters[name](&p, src)
+	}
+	return p, nil
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsApplyFieldMask(t *testing.T) {
+	current := DefaultParams()
+	patch := Params{
+		CodeUploadAccess:     AllowNobody,
+		MaxResponseEvents:    7,
+		DisableUsageTracking: true,
+	}
+
+	specs := map[string]struct {
+		mask   []string
+		exp    func(p *Params)
+		expErr error
+	}{
+		"single field": {
+			mask: []string{"code_upload_access"},
+			exp: func(p *Params) {
+				p.CodeUploadAccess = AllowNobody
+			},
+		},
+		"multiple fields": {
+			mask: []string{"max_response_events", "disable_usage_tracking"},
+			exp: func(p *Params) {
+				p.MaxResponseEvents = 7
+				p.DisableUsageTracking = true
+			},
+		},
+		"field reset to zero value": {
+			mask: []string{"max_response_data_size"},
+			exp: func(p *Params) {
+				p.MaxResponseDataSize = 0
+			},
+		},
+		"empty mask": {
+			expErr: ErrEmpty,
+		},
+		"unknown field": {
+			mask:   []string{"code_upload_access", "unknown"},
+			expErr: ErrInvalid,
+		},
+		"go field name": {
+			mask:   []string{"CodeUploadAccess"},
+			expErr: ErrInvalid,
+		},
+		"duplicate field": {
+			mask:   []string{"max_response_events", "max_response_events"},
+			expErr: ErrDuplicate,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := current.ApplyFieldMask(patch, spec.mask)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			exp := DefaultParams()
+			spec.exp(&exp)
+			assert.Equal(t, exp, got)
+			// and the current params are not modified
+			assert.Equal(t, DefaultParams(), current)
+		})
+	}
+}
+
+func TestParamsFieldSettersCoverAllFields(t *testing.T) {
+	typ := reflect.TypeOf(Params{})
+	require.Len(t, paramsFieldSetters, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		var protoName string
+		for _, part := range strings.Split(typ.Field(i).Tag.Get("protobuf"), ",") {
+			if v, ok := strings.CutPrefix(part, "name="); ok {
+				protoName = v
+			}
+		}
+		require.NotEmpty(t, protoName, typ.Field(i).Name)
+		assert.Contains(t, paramsFieldSetters, protoName)
+	}
+}
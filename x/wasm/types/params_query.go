@@ -0,0 +1,12 @@
+package types
+
+// ParamsCustomQuery is the custom query that contracts send to read the params of another module
+type ParamsCustomQuery struct {
+	Params *ParamsQuery `json:"params,omitempty"`
+}
+
+// ParamsQuery selects the module to read the params from
+type ParamsQuery struct {
+	// Module is the name of the module, for example "staking"
+	Module string `json:"module"`
+}
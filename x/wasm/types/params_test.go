@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	sdkmath "cosmossdk.io/math"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -105,6 +107,183 @@ func TestValidateParams(t *testing.T) {
 			},
 			expErr: true,
 		},
+		"all good with params query modules": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ParamsQueryModules:           []string{"staking", "wasm"},
+			},
+		},
+		"reject empty params query module": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ParamsQueryModules:           []string{" "},
+			},
+			expErr: true,
+		},
+		"reject duplicate params query module": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ParamsQueryModules:           []string{"staking", "staking"},
+			},
+			expErr: true,
+		},
+		"all good with message fees": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MessageFees: []MessageFee{
+					{TypeURL: "/osmosis.tokenfactory.v1beta1.MsgCreateDenom", Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))},
+					{TypeURL: "/cosmos.bank.v1beta1.MsgSend"},
+				},
+			},
+		},
+		"reject message fee without type url": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MessageFees:                  []MessageFee{{Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))}},
+			},
+			expErr: true,
+		},
+		"reject message fee with invalid type url": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MessageFees:                  []MessageFee{{TypeURL: "cosmos.bank.v1beta1.MsgSend"}},
+			},
+			expErr: true,
+		},
+		"reject message fee with invalid amount": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MessageFees:                  []MessageFee{{TypeURL: "/cosmos.bank.v1beta1.MsgSend", Amount: sdk.Coins{{Denom: "stake", Amount: sdkmath.NewInt(0)}}}},
+			},
+			expErr: true,
+		},
+		"reject duplicate message fee": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MessageFees:                  []MessageFee{{TypeURL: "/cosmos.bank.v1beta1.MsgSend"}, {TypeURL: "/cosmos.bank.v1beta1.MsgSend"}},
+			},
+			expErr: true,
+		},
+		"all good with response limits": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxResponseDataSize:          VMResponseSizeLimit,
+				MaxResponseEvents:            10,
+				MaxResponseAttributes:        100,
+			},
+		},
+		"reject response data size above wasmvm limit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxResponseDataSize:          VMResponseSizeLimit + 1,
+			},
+			expErr: true,
+		},
+		"reject response events above wasmvm limit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxResponseEvents:            VMResponseSizeLimit + 1,
+			},
+			expErr: true,
+		},
+		"reject response attributes above wasmvm limit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxResponseAttributes:        VMResponseSizeLimit + 1,
+			},
+			expErr: true,
+		},
+		"all good with instantiate default permissions by origin": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				InstantiateDefaultPermissionsByOrigin: []OriginAccessType{
+					{Origin: CodeOriginDirect, Permission: AccessTypeAnyOfAddresses},
+					{Origin: CodeOriginGovernance, Permission: AccessTypeEverybody},
+					{Origin: CodeOriginAuthz, Permission: AccessTypeNobody},
+				},
+			},
+		},
+		"reject unspecified origin": {
+			src: Params{
+				CodeUploadAccess:                      AllowEverybody,
+				InstantiateDefaultPermission:          AccessTypeEverybody,
+				InstantiateDefaultPermissionsByOrigin: []OriginAccessType{{Permission: AccessTypeEverybody}},
+			},
+			expErr: true,
+		},
+		"reject unknown origin": {
+			src: Params{
+				CodeUploadAccess:                      AllowEverybody,
+				InstantiateDefaultPermission:          AccessTypeEverybody,
+				InstantiateDefaultPermissionsByOrigin: []OriginAccessType{{Origin: 999, Permission: AccessTypeEverybody}},
+			},
+			expErr: true,
+		},
+		"reject unspecified permission by origin": {
+			src: Params{
+				CodeUploadAccess:                      AllowEverybody,
+				InstantiateDefaultPermission:          AccessTypeEverybody,
+				InstantiateDefaultPermissionsByOrigin: []OriginAccessType{{Origin: CodeOriginDirect}},
+			},
+			expErr: true,
+		},
+		"all good with code verifiers": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				CodeVerifiers:                []string{anyAddress.String(), otherAddress.String()},
+				CodeVerificationThreshold:    2,
+			},
+		},
+		"reject invalid code verifier": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				CodeVerifiers:                []string{invalidAddress},
+			},
+			expErr: true,
+		},
+		"reject duplicate code verifier": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				CodeVerifiers:                []string{anyAddress.String(), anyAddress.String()},
+			},
+			expErr: true,
+		},
+		"reject code verification threshold above verifiers": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				CodeVerifiers:                []string{anyAddress.String()},
+				CodeVerificationThreshold:    2,
+			},
+			expErr: true,
+		},
+		"reject duplicate origin": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				InstantiateDefaultPermissionsByOrigin: []OriginAccessType{
+					{Origin: CodeOriginDirect, Permission: AccessTypeAnyOfAddresses},
+					{Origin: CodeOriginDirect, Permission: AccessTypeEverybody},
+				},
+			},
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -169,6 +348,26 @@ func TestParamsUnmarshalJson(t *testing.T) {
 				"instantiate_default_permission": "Everybody"}`,
 			exp: DefaultParams(),
 		},
+		"usage tracking disabled": {
+			src: `{"code_upload_access": {"permission": "Everybody"},
+				"instantiate_default_permission": "Everybody",
+				"disable_usage_tracking": true}`,
+			exp: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				DisableUsageTracking:         true,
+			},
+		},
+		"instantiate default permissions by origin": {
+			src: `{"code_upload_access": {"permission": "Everybody"},
+				"instantiate_default_permission": "Everybody",
+				"instantiate_default_permissions_by_origin": [{"origin": "CODE_ORIGIN_DIRECT", "permission": "AnyOfAddresses"}]}`,
+			exp: Params{
+				CodeUploadAccess:                      AllowEverybody,
+				InstantiateDefaultPermission:          AccessTypeEverybody,
+				InstantiateDefaultPermissionsByOrigin: []OriginAccessType{{Origin: CodeOriginDirect, Permission: AccessTypeAnyOfAddresses}},
+			},
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -183,6 +382,86 @@ func TestParamsUnmarshalJson(t *testing.T) {
 	}
 }
 
+func TestInstantiateDefaultPermissionFor(t *testing.T) {
+	params := Params{
+		InstantiateDefaultPermission: AccessTypeNobody,
+		InstantiateDefaultPermissionsByOrigin: []OriginAccessType{
+			{Origin: CodeOriginDirect, Permission: AccessTypeAnyOfAddresses},
+			{Origin: CodeOriginGovernance, Permission: AccessTypeEverybody},
+		},
+	}
+	assert.Equal(t, AccessTypeAnyOfAddresses, params.InstantiateDefaultPermissionFor(CodeOriginDirect))
+	assert.Equal(t, AccessTypeEverybody, params.InstantiateDefaultPermissionFor(CodeOriginGovernance))
+	// and origins without an entry fall back to the instantiate default permission
+	assert.Equal(t, AccessTypeNobody, params.InstantiateDefaultPermissionFor(CodeOriginAuthz))
+	// legacy codes without origin use the instantiate default permission
+	assert.Equal(t, AccessTypeNobody, params.InstantiateDefaultPermissionFor(CodeOriginUnspecified))
+}
+
+func TestCodeVerificationStatusFor(t *testing.T) {
+	specs := map[string]struct {
+		threshold       uint32
+		reproducible    uint32
+		notReproducible uint32
+		exp             CodeVerificationStatus
+	}{
+		"disabled": {
+			reproducible: 3,
+			exp:          CodeVerificationStatusUnverified,
+		},
+		"no votes": {
+			threshold: 2,
+			exp:       CodeVerificationStatusUnverified,
+		},
+		"below threshold": {
+			threshold:    2,
+			reproducible: 1,
+			exp:          CodeVerificationStatusUnverified,
+		},
+		"below threshold with disagreement": {
+			threshold:       2,
+			reproducible:    1,
+			notReproducible: 1,
+			exp:             CodeVerificationStatusUnverified,
+		},
+		"at threshold": {
+			threshold:    2,
+			reproducible: 2,
+			exp:          CodeVerificationStatusVerified,
+		},
+		"above threshold": {
+			threshold:    2,
+			reproducible: 3,
+			exp:          CodeVerificationStatusVerified,
+		},
+		"at threshold with disagreement": {
+			threshold:       2,
+			reproducible:    2,
+			notReproducible: 1,
+			exp:             CodeVerificationStatusContested,
+		},
+		"not reproducible at threshold": {
+			threshold:       2,
+			notReproducible: 2,
+			exp:             CodeVerificationStatusContested,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			p := Params{CodeVerificationThreshold: spec.threshold}
+			assert.Equal(t, spec.exp, p.CodeVerificationStatusFor(spec.reproducible, spec.notReproducible))
+		})
+	}
+}
+
+func TestIsCodeVerifier(t *testing.T) {
+	myAddr, otherAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20)), sdk.AccAddress(bytes.Repeat([]byte{2}, 20))
+	p := Params{CodeVerifiers: []string{myAddr.String()}}
+	assert.True(t, p.IsCodeVerifier(myAddr))
+	assert.False(t, p.IsCodeVerifier(otherAddr))
+	assert.False(t, Params{}.IsCodeVerifier(myAddr))
+}
+
 func TestAccessTypeWith(t *testing.T) {
 	myAddress := sdk.AccAddress(randBytes(SDKAddrLen))
 	myOtherAddress := sdk.AccAddress(randBytes(SDKAddrLen))
@@ -271,8 +271,8 @@ func (p InstantiateContract2Proposal) ValidateBasic() error {
 		return sdkerrors.ErrInvalidCoins
 	}
 	// Validate salt
-	if len(p.Salt) == 0 {
-		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "salt is required")
+	if err := ValidateSalt(p.Salt); err != nil {
+		return err
 	}
 	return nil
 }
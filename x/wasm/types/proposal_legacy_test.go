@@ -368,6 +368,12 @@ func TestValidateInstantiateContract2Proposal(t *testing.T) {
 			}),
 			expErr: true,
 		},
+		"init with salt too long": {
+			src: InstantiateContract2ProposalFixture(func(p *InstantiateContract2Proposal) {
+				p.Salt = bytes.Repeat([]byte{1}, MaxSaltSize+1)
+			}),
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
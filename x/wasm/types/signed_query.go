@@ -0,0 +1,49 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AuthenticatedQueryKey is the variant of the envelope that passes a signed smart query to the contract.
+// Contracts that want authenticated reads add this variant to their query message.
+const AuthenticatedQueryKey = "authenticated"
+
+// AuthenticatedQuery is the envelope of a signed smart query as the contract receives it
+type AuthenticatedQuery struct {
+	Authenticated AuthenticatedQueryMsg `json:"authenticated"`
+}
+
+// AuthenticatedQueryMsg contains the verified signer address and the original query data
+type AuthenticatedQueryMsg struct {
+	Sender string             `json:"sender"`
+	Msg    RawContractMessage `json:"msg"`
+}
+
+// signedQueryDomain separates the sign bytes of smart queries from other signed data
+var signedQueryDomain = []byte("cosmwasm/signed-smart-query")
+
+// SignedQuerySignBytes returns the bytes that the signer of a smart query signs.
+// All parts are length prefixed so that the encoding is unambiguous.
+func SignedQuerySignBytes(contractAddr sdk.AccAddress, queryData, blockHash []byte) []byte {
+	result := bytes.Clone(signedQueryDomain)
+	for _, part := range [][]byte{contractAddr, queryData, blockHash} {
+		result = binary.BigEndian.AppendUint64(result, uint64(len(part)))
+		result = append(result, part...)
+	}
+	return result
+}
+
+// IsAuthenticatedQuery returns true when the query data is a json object with the authenticated query
+// envelope key on the top level. The data is decoded so that escaped keys are detected, too.
+func IsAuthenticatedQuery(queryData RawContractMessage) bool {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(queryData, &obj); err != nil {
+		return false
+	}
+	_, ok := obj[AuthenticatedQueryKey]
+	return ok
+}
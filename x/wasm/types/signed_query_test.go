@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSignedQuerySignBytes(t *testing.T) {
+	contractAddr := sdk.AccAddress(make([]byte, ContractAddrLen))
+	// then parts can not be shifted between fields
+	assert.NotEqual(t,
+		SignedQuerySignBytes(contractAddr, []byte(`{}`), []byte("hash")),
+		SignedQuerySignBytes(contractAddr, []byte(`{}h`), []byte("ash")),
+	)
+	assert.Equal(t,
+		SignedQuerySignBytes(contractAddr, []byte(`{}`), []byte("hash")),
+		SignedQuerySignBytes(contractAddr, []byte(`{}`), []byte("hash")),
+	)
+}
+
+func TestIsAuthenticatedQuery(t *testing.T) {
+	specs := map[string]struct {
+		src RawContractMessage
+		exp bool
+	}{
+		"envelope": {
+			src: []byte(`{"authenticated":{"sender":"foo","msg":{}}}`),
+			exp: true,
+		},
+		"escaped envelope key": {
+			src: []byte(`{"\u0061uthenticated":{"sender":"foo","msg":{}}}`),
+			exp: true,
+		},
+		"nested key": {
+			src: []byte(`{"orders":{"authenticated":{}}}`),
+		},
+		"value only": {
+			src: []byte(`{"orders":"authenticated"}`),
+		},
+		"unit variant": {
+			src: []byte(`"authenticated"`),
+		},
+		"without key": {
+			src: []byte(`{"orders":{}}`),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, IsAuthenticatedQuery(spec.src))
+		})
+	}
+}
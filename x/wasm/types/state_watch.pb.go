@@ -0,0 +1,845 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmwasm/wasm/v1/state_watch.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+
+	github_com_cometbft_cometbft_libs_bytes "github.com/cometbft/cometbft/libs/bytes"
+	_ "github.com/cosmos/cosmos-proto"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = proto.Marshal
+	_ = fmt.Errorf
+	_ = math.Inf
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// WatchContractStateRequest is the request type for the
+// StateWatch/WatchContractState RPC method
+type WatchContractStateRequest struct {
+	// address is the address of the contract
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// key_prefix is an optional prefix of the contract state keys to watch
+	KeyPrefix []byte `protobuf:"bytes,2,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+}
+
+func (m *WatchContractStateRequest) Reset()         { *m = WatchContractStateRequest{} }
+func (m *WatchContractStateRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchContractStateRequest) ProtoMessage()    {}
+func (*WatchContractStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b347854f70345e4e, []int{0}
+}
+
+func (m *WatchContractStateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *WatchContractStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchContractStateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *WatchContractStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchContractStateRequest.Merge(m, src)
+}
+
+func (m *WatchContractStateRequest) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *WatchContractStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchContractStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchContractStateRequest proto.InternalMessageInfo
+
+// WatchContractStateResponse is a single write to the contract state
+type WatchContractStateResponse struct {
+	// height is the block height of the write
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// key is the key in the contract state
+	Key github_com_cometbft_cometbft_libs_bytes.HexBytes `protobuf:"bytes,2,opt,name=key,proto3,casttype=github.com/cometbft/cometbft/libs/bytes.HexBytes" json:"key,omitempty"`
+	// old_value_hash is the sha256 hash of the value before the block.
+	// Empty when the key did not exist or the previous state is not available
+	OldValueHash []byte `protobuf:"bytes,3,opt,name=old_value_hash,json=oldValueHash,proto3" json:"old_value_hash,omitempty"`
+	// new_value is the value after the block. Empty for deletes
+	NewValue []byte `protobuf:"bytes,4,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	// deleted is set when the key was removed
+	Deleted bool `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
+}
+
+func (m *WatchContractStateResponse) Reset()         { *m = WatchContractStateResponse{} }
+func (m *WatchContractStateResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchContractStateResponse) ProtoMessage()    {}
+func (*WatchContractStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b347854f70345e4e, []int{1}
+}
+
+func (m *WatchContractStateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *WatchContractStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchContractStateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *WatchContractStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchContractStateResponse.Merge(m, src)
+}
+
+func (m *WatchContractStateResponse) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *WatchContractStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchContractStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchContractStateResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*WatchContractStateRequest)(nil), "cosmwasm.wasm.v1.WatchContractStateRequest")
+	proto.RegisterType((*WatchContractStateResponse)(nil), "cosmwasm.wasm.v1.WatchContractStateResponse")
+}
+
+func init() {
+	proto.RegisterFile("cosmwasm/wasm/v1/state_watch.proto", fileDescriptor_b347854f70345e4e)
+}
+
+var fileDescriptor_b347854f70345e4e = []byte{
+	// 424 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcd, 0x8a, 0xd4, 0x40,
+	0x10, 0xc7, 0xd3, 0x8e, 0xee, 0xee, 0x34, 0x8b, 0x48, 0xb3, 0x48, 0x36, 0x62, 0x3b, 0x0c, 0x22,
+	0x03, 0x6a, 0xb2, 0xbb, 0xfa, 0x02, 0x66, 0x41, 0xe6, 0x28, 0x59, 0x70, 0xc1, 0x4b, 0xe8, 0x24,
+	0x35, 0x49, 0x98, 0x24, 0x9d, 0x49, 0xf7, 0x4c, 0x26, 0x27, 0x5f, 0xc1, 0xc7, 0xf0, 0x01, 0x7c,
+	0x88, 0x39, 0x0e, 0x9e, 0xf4, 0x22, 0x9a, 0x79, 0x0b, 0x4f, 0x92, 0xee, 0x09, 0x8a, 0x1f, 0xe0,
+	0xa5, 0xa8, 0xaa, 0xff, 0x2f, 0x55, 0xa9, 0xea, 0xc2, 0xe3, 0x90, 0x8b, 0xbc, 0x66, 0x22, 0x77,
+	0x94, 0x59, 0x9d, 0x3b, 0x42, 0x32, 0x09, 0x7e, 0xcd, 0x64, 0x98, 0xd8, 0x65, 0xc5, 0x25, 0x27,
+	0x77, 0x7a, 0xc6, 0x56, 0x66, 0x75, 0x6e, 0x9d, 0xc4, 0x3c, 0xe6, 0x4a, 0x74, 0x3a, 0x4f, 0x73,
+	0xd6, 0x69, 0xc7, 0x71, 0xe1, 0x6b, 0x41, 0x07, 0x5a, 0x1a, 0x17, 0xf8, 0xf4, 0xba, 0xab, 0x78,
+	0xc9, 0x0b, 0x59, 0xb1, 0x50, 0x5e, 0x75, 0x4d, 0x3c, 0x58, 0x2c, 0x41, 0x48, 0x72, 0x81, 0x0f,
+	0x59, 0x14, 0x55, 0x20, 0x84, 0x89, 0x46, 0x68, 0x32, 0x74, 0xcd, 0x8f, 0x1f, 0x9e, 0x9e, 0xec,
+	0xbf, 0x7f, 0xa1, 0x95, 0x2b, 0x59, 0xa5, 0x45, 0xec, 0xf5, 0x20, 0xb9, 0x8f, 0xf1, 0x1c, 0x1a,
+	0xbf, 0xac, 0x60, 0x96, 0xae, 0xcd, 0x1b, 0x23, 0x34, 0x39, 0xf6, 0x86, 0x73, 0x68, 0x5e, 0xa9,
+	0xc4, 0xf8, 0x33, 0xc2, 0xd6, 0xdf, 0x1a, 0x8a, 0x92, 0x17, 0x02, 0xc8, 0x5d, 0x7c, 0x90, 0x40,
+	0x1a, 0x27, 0x52, 0x35, 0x1c, 0x78, 0xfb, 0x88, 0xbc, 0xc4, 0x83, 0x39, 0x34, 0xba, 0x9c, 0xfb,
+	0xfc, 0xfb, 0x97, 0x07, 0x67, 0x71, 0x2a, 0x93, 0x65, 0x60, 0x87, 0x3c, 0x77, 0x42, 0x9e, 0x83,
+	0x0c, 0x66, 0xf2, 0xa7, 0x93, 0xa5, 0x81, 0x70, 0x82, 0x46, 0x82, 0xb0, 0xa7, 0xb0, 0x76, 0x3b,
+	0xc7, 0xeb, 0x0a, 0x90, 0x87, 0xf8, 0x36, 0xcf, 0x22, 0x7f, 0xc5, 0xb2, 0x25, 0xf8, 0x09, 0x13,
+	0x89, 0x39, 0x50, 0x7f, 0x78, 0xcc, 0xb3, 0xe8, 0x75, 0x97, 0x9c, 0x32, 0x91, 0x90, 0x7b, 0x78,
+	0x58, 0x40, 0xad, 0x29, 0xf3, 0xa6, 0x02, 0x8e, 0x0a, 0xa8, 0x15, 0x40, 0x4c, 0x7c, 0x18, 0x41,
+	0x06, 0x12, 0x22, 0xf3, 0xd6, 0x08, 0x4d, 0x8e, 0xbc, 0x3e, 0xbc, 0x78, 0x8b, 0xb1, 0x9a, 0x46,
+	0xcd, 0x47, 0x16, 0x98, 0xfc, 0x39, 0x28, 0x79, 0x6c, 0xff, 0xfe, 0x66, 0xf6, 0x3f, 0xf7, 0x6f,
+	0x3d, 0xf9, 0x3f, 0x58, 0xef, 0xee, 0x0c, 0xb9, 0xd3, 0xcd, 0x37, 0x6a, 0xbc, 0x6f, 0xa9, 0xb1,
+	0x69, 0x29, 0xda, 0xb6, 0x14, 0x7d, 0x6d, 0x29, 0x7a, 0xb7, 0xa3, 0xc6, 0x76, 0x47, 0x8d, 0x4f,
+	0x3b, 0x6a, 0xbc, 0x79, 0xf4, 0xcb, 0xda, 0x2e, 0xb9, 0xc8, 0xaf, 0xfb, 0x03, 0x8b, 0x9c, 0xb5,
+	0x3e, 0x34, 0xd9, 0x94, 0x20, 0x82, 0x03, 0x75, 0x1d, 0xcf, 0x7e, 0x04, 0x00, 0x00, 0xff, 0xff,
+	0xfb, 0xdd, 0xe4, 0xc2, 0x86, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ context.Context
+	_ grpc.ClientConn
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// StateWatchClient is the client API for StateWatch service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StateWatchClient interface {
+	// WatchContractState streams the writes to the state of a contract after
+	// every committed block. Delivery is at most once: events of blocks that are
+	// committed while a client is disconnected or too slow are not sent.
+	WatchContractState(ctx context.Context, in *WatchContractStateRequest, opts ...grpc.CallOption) (StateWatch_WatchContractStateClient, error)
+}
+
+type stateWatchClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewStateWatchClient(cc grpc1.ClientConn) StateWatchClient {
+	return &stateWatchClient{cc}
+}
+
+func (c *stateWatchClient) WatchContractState(ctx context.Context, in *WatchContractStateRequest, opts ...grpc.CallOption) (StateWatch_WatchContractStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StateWatch_serviceDesc.Streams[0], "/cosmwasm.wasm.v1.StateWatch/WatchContractState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateWatchWatchContractStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateWatch_WatchContractStateClient interface {
+	Recv() (*WatchContractStateResponse, error)
+	grpc.ClientStream
+}
+
+type stateWatchWatchContractStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateWatchWatchContractStateClient) Recv() (*WatchContractStateResponse, error) {
+	m := new(WatchContractStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StateWatchServer is the server API for StateWatch service.
+type StateWatchServer interface {
+	// WatchContractState streams the writes to the state of a contract after
+	// every committed block. Delivery is at most once: events of blocks that are
+	// committed while a client is disconnected or too slow are not sent.
+	WatchContractState(*WatchContractStateRequest, StateWatch_WatchContractStateServer) error
+}
+
+// UnimplementedStateWatchServer can be embedded to have forward compatible implementations.
+type UnimplementedStateWatchServer struct{}
+
+func (*UnimplementedStateWatchServer) WatchContractState(req *WatchContractStateRequest, srv StateWatch_WatchContractStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchContractState not implemented")
+}
+
+func RegisterStateWatchServer(s grpc1.Server, srv StateWatchServer) {
+	s.RegisterService(&_StateWatch_serviceDesc, srv)
+}
+
+func _StateWatch_WatchContractState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchContractStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateWatchServer).WatchContractState(m, &stateWatchWatchContractStateServer{stream})
+}
+
+type StateWatch_WatchContractStateServer interface {
+	Send(*WatchContractStateResponse) error
+	grpc.ServerStream
+}
+
+type stateWatchWatchContractStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateWatchWatchContractStateServer) Send(m *WatchContractStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var (
+	StateWatch_serviceDesc  = _StateWatch_serviceDesc
+	_StateWatch_serviceDesc = grpc.ServiceDesc{
+		ServiceName: "cosmwasm.wasm.v1.StateWatch",
+		HandlerType: (*StateWatchServer)(nil),
+		Methods:     []grpc.MethodDesc{},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "WatchContractState",
+				Handler:       _StateWatch_WatchContractState_Handler,
+				ServerStreams: true,
+			},
+		},
+		Metadata: "cosmwasm/wasm/v1/state_watch.proto",
+	}
+)
+
+func (m *WatchContractStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchContractStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchContractStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KeyPrefix) > 0 {
+		i -= len(m.KeyPrefix)
+		copy(dAtA[i:], m.KeyPrefix)
+		i = encodeVarintStateWatch(dAtA, i, uint64(len(m.KeyPrefix)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintStateWatch(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchContractStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchContractStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchContractStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Deleted {
+		i--
+		if m.Deleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.NewValue) > 0 {
+		i -= len(m.NewValue)
+		copy(dAtA[i:], m.NewValue)
+		i = encodeVarintStateWatch(dAtA, i, uint64(len(m.NewValue)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.OldValueHash) > 0 {
+		i -= len(m.OldValueHash)
+		copy(dAtA[i:], m.OldValueHash)
+		i = encodeVarintStateWatch(dAtA, i, uint64(len(m.OldValueHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintStateWatch(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintStateWatch(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintStateWatch(dAtA []byte, offset int, v uint64) int {
+	offset -= sovStateWatch(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+
+func (m *WatchContractStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovStateWatch(uint64(l))
+	}
+	l = len(m.KeyPrefix)
+	if l > 0 {
+		n += 1 + l + sovStateWatch(uint64(l))
+	}
+	return n
+}
+
+func (m *WatchContractStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovStateWatch(uint64(m.Height))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovStateWatch(uint64(l))
+	}
+	l = len(m.OldValueHash)
+	if l > 0 {
+		n += 1 + l + sovStateWatch(uint64(l))
+	}
+	l = len(m.NewValue)
+	if l > 0 {
+		n += 1 + l + sovStateWatch(uint64(l))
+	}
+	if m.Deleted {
+		n += 2
+	}
+	return n
+}
+
+func sovStateWatch(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+
+func sozStateWatch(x uint64) (n int) {
+	return sovStateWatch(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+
+func (m *WatchContractStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStateWatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchContractStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchContractStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyPrefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyPrefix = append(m.KeyPrefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.KeyPrefix == nil {
+				m.KeyPrefix = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStateWatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *WatchContractStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStateWatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchContractStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchContractStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldValueHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldValueHash = append(m.OldValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.OldValueHash == nil {
+				m.OldValueHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewValue", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewValue = append(m.NewValue[:0], dAtA[iNdEx:postIndex]...)
+			if m.NewValue == nil {
+				m.NewValue = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deleted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStateWatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStateWatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func skipStateWatch(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowStateWatch
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStateWatch
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthStateWatch
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupStateWatch
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthStateWatch
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthStateWatch        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowStateWatch          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupStateWatch = fmt.Errorf("proto: unexpected end of group")
+)
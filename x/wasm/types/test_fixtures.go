@@ -3,6 +3,7 @@ package types
 import (
 	_ "embed"
 	"math/rand"
+	"strings"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 
@@ -66,6 +67,34 @@ func CodeFixture(mutators ...func(*Code)) Code {
 	return fixture
 }
 
+func CodeAttestationFixture(mutators ...func(*CodeAttestation)) CodeAttestation {
+	const anyAddress = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4"
+	fixture := CodeAttestation{
+		Attester:     anyAddress,
+		RepoURL:      "https://github.com/CosmWasm/cw-plus",
+		Commit:       strings.Repeat("a", 40),
+		BuilderImage: "cosmwasm/optimizer:0.16.0",
+		Height:       1,
+	}
+	for _, m := range mutators {
+		m(&fixture)
+	}
+	return fixture
+}
+
+func CodeVerificationVoteFixture(mutators ...func(*CodeVerificationVote)) CodeVerificationVote {
+	const anyAddress = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4"
+	fixture := CodeVerificationVote{
+		Voter:   anyAddress,
+		Verdict: CodeVerificationVerdictReproducible,
+		Height:  1,
+	}
+	for _, m := range mutators {
+		m(&fixture)
+	}
+	return fixture
+}
+
 func CodeInfoFixture(mutators ...func(*CodeInfo)) CodeInfo {
 	codeHash, err := wasmvm.CreateChecksum(reflectWasmCode)
 	if err != nil {
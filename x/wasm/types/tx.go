@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"strings"
@@ -73,6 +74,11 @@ func (msg MsgStoreCode) ValidateBasic() error {
 			return errorsmod.Wrap(err, "instantiate permission")
 		}
 	}
+	if msg.Source != "" || msg.Builder != "" || len(msg.ProvenanceSignature) != 0 {
+		if err := ValidateProvenance(msg.Source, msg.Builder, msg.ProvenanceSignature); err != nil {
+			return errorsmod.Wrap(err, "provenance")
+		}
+	}
 	return nil
 }
 
@@ -295,7 +301,7 @@ func (msg MsgInstantiateContract2) ValidateBasic() error {
 		return errorsmod.Wrap(err, "payload msg")
 	}
 	if err := ValidateSalt(msg.Salt); err != nil {
-		return errorsmod.Wrap(err, "salt")
+		return err
 	}
 	return nil
 }
@@ -340,6 +346,10 @@ func (msg MsgUpdateParams) ValidateBasic() error {
 	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
 		return errorsmod.Wrap(err, "authority")
 	}
+	if len(msg.FieldMask) != 0 {
+		// the params are validated after the fields were applied over the current params
+		return ValidateParamsFieldMask(msg.FieldMask)
+	}
 	return msg.Params.ValidateBasic()
 }
 
@@ -553,3 +563,625 @@ func (msg MsgUpdateContractLabel) ValidateBasic() error {
 	}
 	return nil
 }
+
+func (msg MsgRegisterParamSubscriber) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRegisterParamSubscriber) Type() string {
+	return "register-param-subscriber"
+}
+
+func (msg MsgRegisterParamSubscriber) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if len(msg.Subspaces) == 0 {
+		return errorsmod.Wrap(ErrEmpty, "subspaces")
+	}
+	for _, s := range msg.Subspaces {
+		if err := ValidateParamSubspace(s); err != nil {
+			return errorsmod.Wrap(err, "subspace")
+		}
+	}
+	if hasDuplicates(msg.Subspaces) {
+		return errorsmod.Wrap(ErrDuplicate, "subspaces")
+	}
+	return nil
+}
+
+func (msg MsgStoreCodeChunk) Route() string {
+	return RouterKey
+}
+
+func (msg MsgStoreCodeChunk) Type() string {
+	return "store-code-chunk"
+}
+
+func (msg MsgStoreCodeChunk) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if err := ValidateUploadID(msg.UploadID); err != nil {
+		return errorsmod.Wrap(err, "upload id")
+	}
+	if msg.Total == 0 {
+		return errorsmod.Wrap(ErrEmpty, "total")
+	}
+	if msg.Index >= msg.Total {
+		return errorsmod.Wrapf(ErrInvalid, "index %d must be lower than total %d", msg.Index, msg.Total)
+	}
+	if err := validateWasmCode(msg.Data, MaxWasmSize); err != nil {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "chunk data %s", err.Error())
+	}
+	return nil
+}
+
+func (msg MsgCompleteStoreCode) Route() string {
+	return RouterKey
+}
+
+func (msg MsgCompleteStoreCode) Type() string {
+	return "complete-store-code"
+}
+
+func (msg MsgCompleteStoreCode) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if err := ValidateUploadID(msg.UploadID); err != nil {
+		return errorsmod.Wrap(err, "upload id")
+	}
+	if len(msg.Checksum) != sha256.Size {
+		return errorsmod.Wrapf(ErrInvalid, "checksum must be %d bytes", sha256.Size)
+	}
+	if msg.InstantiatePermission != nil {
+		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "instantiate permission")
+		}
+	}
+	return nil
+}
+
+func (msg MsgSetQueryAlias) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetQueryAlias) Type() string {
+	return "set-query-alias"
+}
+
+func (msg MsgSetQueryAlias) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if err := ValidateQueryAliasName(msg.Name); err != nil {
+		return errorsmod.Wrap(err, "name")
+	}
+	// empty query data removes the alias
+	if len(msg.QueryData) == 0 {
+		if msg.CacheTTLSeconds != 0 {
+			return errorsmod.Wrap(ErrInvalid, "cache ttl of removed alias")
+		}
+		return nil
+	}
+	if err := validateQueryAliasData(msg.QueryData); err != nil {
+		return errorsmod.Wrap(err, "query data")
+	}
+	if err := validateQueryAliasCacheTTL(msg.CacheTTLSeconds); err != nil {
+		return errorsmod.Wrap(err, "cache ttl")
+	}
+	return nil
+}
+
+func (msg MsgSetIBCSendTimeout) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetIBCSendTimeout) Type() string {
+	return "set-ibc-send-timeout"
+}
+
+func (msg MsgSetIBCSendTimeout) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgSetAdminChangeNotification) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetAdminChangeNotification) Type() string {
+	return "set-admin-change-notification"
+}
+
+func (msg MsgSetAdminChangeNotification) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgSetContractTags) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractTags) Type() string {
+	return "set-contract-tags"
+}
+
+func (msg MsgSetContractTags) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if err := ValidateContractTags(msg.Tags); err != nil {
+		return errorsmod.Wrap(err, "tags")
+	}
+	return nil
+}
+
+func (msg MsgSetContractFeeSponsorship) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractFeeSponsorship) Type() string {
+	return "set-contract-fee-sponsorship"
+}
+
+func (msg MsgSetContractFeeSponsorship) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if msg.Sponsorship != nil {
+		if err := msg.Sponsorship.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "sponsorship")
+		}
+	}
+	return nil
+}
+
+func (msg MsgUpdateContractAdmins) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateContractAdmins) Type() string {
+	return "update-contract-admins"
+}
+
+func (msg MsgUpdateContractAdmins) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if len(msg.Updates) == 0 {
+		return errorsmod.Wrap(ErrEmpty, "updates")
+	}
+	seen := make(map[string]struct{}, len(msg.Updates))
+	for i, u := range msg.Updates {
+		contractAddr, err := sdk.AccAddressFromBech32(u.Contract)
+		if err != nil {
+			return errorsmod.Wrapf(err, "contract %d", i)
+		}
+		if _, err := sdk.AccAddressFromBech32(u.NewAdmin); err != nil {
+			return errorsmod.Wrapf(err, "new admin %d", i)
+		}
+		if _, exists := seen[string(contractAddr)]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "contract %s", u.Contract)
+		}
+		seen[string(contractAddr)] = struct{}{}
+	}
+	return nil
+}
+
+func (msg MsgSetLegacyReverseIterator) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetLegacyReverseIterator) Type() string {
+	return "set-legacy-reverse-iterator"
+}
+
+func (msg MsgSetLegacyReverseIterator) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if msg.CodeID == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
+	}
+	return nil
+}
+
+func (msg MsgAttestCode) Route() string {
+	return RouterKey
+}
+
+func (msg MsgAttestCode) Type() string {
+	return "attest-code"
+}
+
+func (msg MsgAttestCode) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Attester); err != nil {
+		return errorsmod.Wrap(err, "attester")
+	}
+	if msg.CodeID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "code id")
+	}
+	return ValidateAttestation(msg.RepoURL, msg.Commit, msg.BuilderImage)
+}
+
+func (msg MsgVoteCodeVerification) Route() string {
+	return RouterKey
+}
+
+func (msg MsgVoteCodeVerification) Type() string {
+	return "vote-code-verification"
+}
+
+func (msg MsgVoteCodeVerification) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if msg.CodeID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "code id")
+	}
+	return errorsmod.Wrap(validateCodeVerificationVerdict(msg.Verdict), "verdict")
+}
+
+func (msg MsgScheduleSudo) Route() string {
+	return RouterKey
+}
+
+func (msg MsgScheduleSudo) Type() string {
+	return "schedule-sudo"
+}
+
+func (msg MsgScheduleSudo) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if msg.Height <= 0 {
+		return errorsmod.Wrap(ErrInvalid, "height must be positive")
+	}
+	if msg.GasLimit == 0 {
+		return errorsmod.Wrap(ErrEmpty, "gas limit")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+func (msg MsgCancelScheduledSudo) Route() string {
+	return RouterKey
+}
+
+func (msg MsgCancelScheduledSudo) Type() string {
+	return "cancel-scheduled-sudo"
+}
+
+func (msg MsgCancelScheduledSudo) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if msg.ID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "id")
+	}
+	return nil
+}
+
+func (msg MsgScheduleContractSunset) Route() string {
+	return RouterKey
+}
+
+func (msg MsgScheduleContractSunset) Type() string {
+	return "schedule-contract-sunset"
+}
+
+func (msg MsgScheduleContractSunset) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if msg.Height <= 0 {
+		return errorsmod.Wrap(ErrInvalid, "height must be positive")
+	}
+	return nil
+}
+
+func (msg MsgCancelContractSunset) Route() string {
+	return RouterKey
+}
+
+func (msg MsgCancelContractSunset) Type() string {
+	return "cancel-contract-sunset"
+}
+
+func (msg MsgCancelContractSunset) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgProposeAdminTransfer) Route() string {
+	return RouterKey
+}
+
+func (msg MsgProposeAdminTransfer) Type() string {
+	return "propose-admin-transfer"
+}
+
+func (msg MsgProposeAdminTransfer) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.NewAdmin); err != nil {
+		return errorsmod.Wrap(err, "new admin")
+	}
+	return nil
+}
+
+func (msg MsgAcceptAdminTransfer) Route() string {
+	return RouterKey
+}
+
+func (msg MsgAcceptAdminTransfer) Type() string {
+	return "accept-admin-transfer"
+}
+
+func (msg MsgAcceptAdminTransfer) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgCancelAdminTransfer) Route() string {
+	return RouterKey
+}
+
+func (msg MsgCancelAdminTransfer) Type() string {
+	return "cancel-admin-transfer"
+}
+
+func (msg MsgCancelAdminTransfer) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgSuspendContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSuspendContract) Type() string {
+	return "suspend-contract"
+}
+
+func (msg MsgSuspendContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgResumeContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgResumeContract) Type() string {
+	return "resume-contract"
+}
+
+func (msg MsgResumeContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgImportContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgImportContract) Type() string {
+	return "import-contract"
+}
+
+func (msg MsgImportContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Address); err != nil {
+		return errorsmod.Wrap(err, "address")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Creator); err != nil {
+		return errorsmod.Wrap(err, "creator")
+	}
+	if len(msg.Admin) != 0 {
+		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
+			return errorsmod.Wrap(err, "admin")
+		}
+	}
+	if err := ValidateLabel(msg.Label); err != nil {
+		return errorsmod.Wrap(err, "label")
+	}
+	switch {
+	case msg.CodeID == 0 && len(msg.WASMByteCode) == 0:
+		return errorsmod.Wrap(ErrEmpty, "code id or wasm byte code is required")
+	case msg.CodeID != 0 && len(msg.WASMByteCode) != 0:
+		return errorsmod.Wrap(ErrInvalid, "code id and wasm byte code can not be combined")
+	case msg.CodeID != 0 && msg.InstantiatePermission != nil:
+		return errorsmod.Wrap(ErrInvalid, "instantiate permission requires wasm byte code")
+	case len(msg.WASMByteCode) != 0:
+		if err := validateWasmCode(msg.WASMByteCode, MaxProposalWasmSize); err != nil {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %s", err.Error())
+		}
+	}
+	if msg.InstantiatePermission != nil {
+		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "instantiate permission")
+		}
+	}
+	return validateImportState(msg.State)
+}
+
+func (msg MsgAddCodeUploadApproval) Route() string {
+	return RouterKey
+}
+
+func (msg MsgAddCodeUploadApproval) Type() string {
+	return "add-code-upload-approval"
+}
+
+func (msg MsgAddCodeUploadApproval) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	return ValidateChecksum(msg.Checksum)
+}
+
+func (msg MsgRemoveCodeUploadApproval) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRemoveCodeUploadApproval) Type() string {
+	return "remove-code-upload-approval"
+}
+
+func (msg MsgRemoveCodeUploadApproval) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	return ValidateChecksum(msg.Checksum)
+}
+
+// validateImportState ensures that the keys are not empty and unique and that the total size is within the limit
+func validateImportState(state []Model) error {
+	var size int
+	keys := make(map[string]struct{}, len(state))
+	for i, m := range state {
+		if len(m.Key) == 0 {
+			return errorsmod.Wrapf(ErrEmpty, "state key %d", i)
+		}
+		if _, exists := keys[string(m.Key)]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "state key %X", m.Key)
+		}
+		keys[string(m.Key)] = struct{}{}
+		if size += len(m.Key) + len(m.Value); size > MaxImportContractStateSize {
+			return ErrLimit.Wrapf("state cannot be larger than %d bytes", MaxImportContractStateSize)
+		}
+	}
+	return nil
+}
+
+func (msg MsgForceMigrateContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgForceMigrateContract) Type() string {
+	return "force-migrate-contract"
+}
+
+func (msg MsgForceMigrateContract) ValidateBasic() error {
+	if msg.CodeID == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+func (msg MsgSetIgnoreReplyError) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetIgnoreReplyError) Type() string {
+	return "set-ignore-reply-error"
+}
+
+func (msg MsgSetIgnoreReplyError) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgDeregisterParamSubscriber) Route() string {
+	return RouterKey
+}
+
+func (msg MsgDeregisterParamSubscriber) Type() string {
+	return "deregister-param-subscriber"
+}
+
+func (msg MsgDeregisterParamSubscriber) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if len(msg.Subspaces) == 0 {
+		return errorsmod.Wrap(ErrEmpty, "subspaces")
+	}
+	for _, s := range msg.Subspaces {
+		if err := ValidateParamSubspace(s); err != nil {
+			return errorsmod.Wrap(err, "subspace")
+		}
+	}
+	if hasDuplicates(msg.Subspaces) {
+		return errorsmod.Wrap(ErrDuplicate, "subspaces")
+	}
+	return nil
+}
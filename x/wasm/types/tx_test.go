@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -65,6 +66,63 @@ func TestStoreCodeValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"with provenance": {
+			msg: MsgStoreCode{
+				Sender:              goodAddress,
+				WASMByteCode:        []byte("foo"),
+				Source:              "https://example.com/",
+				Builder:             "cosmwasm/optimizer:0.16.0",
+				ProvenanceSignature: []byte("signature"),
+			},
+			valid: true,
+		},
+		"provenance without signature": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "https://example.com/",
+				Builder:      "cosmwasm/optimizer:0.16.0",
+			},
+			valid: false,
+		},
+		"provenance signature only": {
+			msg: MsgStoreCode{
+				Sender:              goodAddress,
+				WASMByteCode:        []byte("foo"),
+				ProvenanceSignature: []byte("signature"),
+			},
+			valid: false,
+		},
+		"provenance with invalid source": {
+			msg: MsgStoreCode{
+				Sender:              goodAddress,
+				WASMByteCode:        []byte("foo"),
+				Source:              "example.com",
+				Builder:             "cosmwasm/optimizer:0.16.0",
+				ProvenanceSignature: []byte("signature"),
+			},
+			valid: false,
+		},
+		"provenance with invalid builder": {
+			msg: MsgStoreCode{
+				Sender:              goodAddress,
+				WASMByteCode:        []byte("foo"),
+				Source:              "https://example.com/",
+				Builder:             "-invalid-",
+				ProvenanceSignature: []byte("signature"),
+			},
+			valid: false,
+		},
+		"provenance signature too long": {
+			msg: MsgStoreCode{
+				Sender:              goodAddress,
+				WASMByteCode:        []byte("foo"),
+				Source:              "https://example.com/",
+				Builder:             "cosmwasm/optimizer:0.16.0",
+				ProvenanceSignature: make([]byte, MaxProvenanceSignatureSize+1),
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -79,6 +137,12 @@ func TestStoreCodeValidation(t *testing.T) {
 	}
 }
 
+func TestCodeProvenanceSignBytes(t *testing.T) {
+	assert.Equal(t, []byte{0x1, 0x2, 0x0, 0x0, 0x0, 0x1, 'a', 'b', 'c'}, CodeProvenanceSignBytes([]byte{0x1, 0x2}, "a", "bc"))
+	// the source length prevents that bytes can be shifted between source and builder
+	assert.NotEqual(t, CodeProvenanceSignBytes([]byte{0x1}, "ab", "c"), CodeProvenanceSignBytes([]byte{0x1}, "a", "bc"))
+}
+
 func TestInstantiateContractValidation(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
@@ -746,6 +810,36 @@ func TestMsgUpdateParamsValidation(t *testing.T) {
 			},
 			expErr: true,
 		},
+		"field mask with partial params": {
+			src: MsgUpdateParams{
+				Authority: goodAddress,
+				Params:    Params{MaxResponseEvents: 1},
+				FieldMask: []string{"max_response_events"},
+			},
+		},
+		"field mask with unknown field": {
+			src: MsgUpdateParams{
+				Authority: goodAddress,
+				Params:    DefaultParams(),
+				FieldMask: []string{"unknown"},
+			},
+			expErr: true,
+		},
+		"field mask with duplicate field": {
+			src: MsgUpdateParams{
+				Authority: goodAddress,
+				Params:    DefaultParams(),
+				FieldMask: []string{"max_response_events", "max_response_events"},
+			},
+			expErr: true,
+		},
+		"invalid params without field mask": {
+			src: MsgUpdateParams{
+				Authority: goodAddress,
+				Params:    Params{MaxResponseEvents: 1},
+			},
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -1053,73 +1147,126 @@ func TestMsgUnpinCodesValidation(t *testing.T) {
 	}
 }
 
-func genCodeIDs(max int) []uint64 {
-	r := make([]uint64, max)
-	for i := 0; i < max; i++ {
-		r[i] = uint64(i)
-	}
-	return r
-}
-
-func TestMsgSudoContractValidation(t *testing.T) {
-	badAddress := "abcd"
+func TestMsgAttestCodeValidation(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
-	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+	sha1Commit := strings.Repeat("a", 40)
 
 	specs := map[string]struct {
-		src    MsgSudoContract
+		src    MsgAttestCode
 		expErr bool
 	}{
 		"all good": {
-			src: MsgSudoContract{
-				Authority: goodAddress,
-				Contract:  anotherGoodAddress,
-				Msg:       []byte("{}"),
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				RepoURL:      "https://github.com/CosmWasm/cw-plus",
+				Commit:       sha1Commit,
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
+			},
+		},
+		"sha256 commit": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				RepoURL:      "https://github.com/CosmWasm/cw-plus",
+				Commit:       strings.Repeat("0", 64),
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
+			},
+		},
+		"image with digest": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				RepoURL:      "https://github.com/CosmWasm/cw-plus",
+				Commit:       sha1Commit,
+				BuilderImage: "cosmwasm/optimizer@sha256:" + strings.Repeat("0", 64),
+			},
+		},
+		"bad attester": {
+			src: MsgAttestCode{
+				Attester:     badAddress,
+				CodeID:       1,
+				RepoURL:      "https://github.com/CosmWasm/cw-plus",
+				Commit:       sha1Commit,
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
 			},
+			expErr: true,
 		},
-		"bad authority": {
-			src: MsgSudoContract{
-				Authority: badAddress,
-				Contract:  anotherGoodAddress,
-				Msg:       []byte("{}"),
+		"empty code id": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				RepoURL:      "https://github.com/CosmWasm/cw-plus",
+				Commit:       sha1Commit,
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
 			},
 			expErr: true,
 		},
-		"empty authority": {
-			src: MsgSudoContract{
-				Contract: anotherGoodAddress,
-				Msg:      []byte("{}"),
+		"empty repo url": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				Commit:       sha1Commit,
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
 			},
 			expErr: true,
 		},
-		"bad contract addr": {
-			src: MsgSudoContract{
-				Authority: goodAddress,
-				Contract:  badAddress,
-				Msg:       []byte("{}"),
+		"non https repo url": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				RepoURL:      "http://github.com/CosmWasm/cw-plus",
+				Commit:       sha1Commit,
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
 			},
 			expErr: true,
 		},
-		"empty contract addr": {
-			src: MsgSudoContract{
-				Authority: goodAddress,
-				Msg:       []byte("{}"),
+		"repo url exceeds limit": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				RepoURL:      "https://github.com/" + strings.Repeat("a", MaxAttestationInfoSize),
+				Commit:       sha1Commit,
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
 			},
 			expErr: true,
 		},
-		"non json sudoMsg": {
-			src: MsgSudoContract{
-				Authority: goodAddress,
-				Contract:  anotherGoodAddress,
-				Msg:       []byte("invalid json"),
+		"short commit": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				RepoURL:      "https://github.com/CosmWasm/cw-plus",
+				Commit:       "aaaaaaa",
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
 			},
 			expErr: true,
 		},
-		"empty sudoMsg": {
-			src: MsgSudoContract{
-				Authority: goodAddress,
-				Contract:  anotherGoodAddress,
+		"upper case commit": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				RepoURL:      "https://github.com/CosmWasm/cw-plus",
+				Commit:       strings.Repeat("A", 40),
+				BuilderImage: "cosmwasm/optimizer:0.16.0",
+			},
+			expErr: true,
+		},
+		"empty builder image": {
+			src: MsgAttestCode{
+				Attester: goodAddress,
+				CodeID:   1,
+				RepoURL:  "https://github.com/CosmWasm/cw-plus",
+				Commit:   sha1Commit,
+			},
+			expErr: true,
+		},
+		"invalid builder image": {
+			src: MsgAttestCode{
+				Attester:     goodAddress,
+				CodeID:       1,
+				RepoURL:      "https://github.com/CosmWasm/cw-plus",
+				Commit:       sha1Commit,
+				BuilderImage: "Cosmwasm/Optimizer",
 			},
 			expErr: true,
 		},
@@ -1136,340 +1283,1590 @@ func TestMsgSudoContractValidation(t *testing.T) {
 	}
 }
 
-func TestMsgStoreAndInstantiateContractValidation(t *testing.T) {
+func TestMsgVoteCodeVerificationValidation(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
-	sdk.GetConfig().SetAddressVerifier(VerifyAddressLen())
 
-	cases := map[string]struct {
-		msg   MsgStoreAndInstantiateContract
-		valid bool
+	specs := map[string]struct {
+		src    MsgVoteCodeVerification
+		expErr bool
 	}{
-		"empty": {
-			msg:   MsgStoreAndInstantiateContract{},
-			valid: false,
-		},
-		"correct minimal": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Label:        "foo",
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-			},
-			valid: true,
+		"reproducible": {
+			src: MsgVoteCodeVerification{Sender: goodAddress, CodeID: 1, Verdict: CodeVerificationVerdictReproducible},
 		},
-		"missing byte code": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority: goodAddress,
-				Label:     "foo",
-				Msg:       []byte("{}"),
-			},
-			valid: false,
+		"not reproducible": {
+			src: MsgVoteCodeVerification{Sender: goodAddress, CodeID: 1, Verdict: CodeVerificationVerdictNotReproducible},
 		},
-		"missing label": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-			},
-			valid: false,
+		"bad sender": {
+			src:    MsgVoteCodeVerification{Sender: badAddress, CodeID: 1, Verdict: CodeVerificationVerdictReproducible},
+			expErr: true,
 		},
-		"label too long": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Label:        strings.Repeat("food", 33),
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-			},
-			valid: false,
+		"empty code id": {
+			src:    MsgVoteCodeVerification{Sender: goodAddress, Verdict: CodeVerificationVerdictReproducible},
+			expErr: true,
 		},
-		"bad sender minimal": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    badAddress,
-				Label:        "foo",
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-			},
-			valid: false,
+		"unspecified verdict": {
+			src:    MsgVoteCodeVerification{Sender: goodAddress, CodeID: 1},
+			expErr: true,
 		},
-		"bad admin": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Label:        "foo",
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-				Admin:        badAddress,
-			},
-			valid: false,
+		"unknown verdict": {
+			src:    MsgVoteCodeVerification{Sender: goodAddress, CodeID: 1, Verdict: 99},
+			expErr: true,
 		},
-		"correct maximal": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:             goodAddress,
-				Label:                 "foo",
-				Msg:                   []byte(`{"some": "data"}`),
-				Funds:                 sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(200)}},
-				WASMByteCode:          []byte("foo"),
-				InstantiatePermission: &AllowEverybody,
-				UnpinCode:             true,
-				Admin:                 goodAddress,
-				Source:                "http://source.com",
-				Builder:               "builder",
-				CodeHash:              []byte("{}"),
-			},
-			valid: true,
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgScheduleSudoValidation(t *testing.T) {
+	badAddress := "abcd"
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgScheduleSudo
+		expErr bool
+	}{
+		"all good": {
+			src: MsgScheduleSudo{Sender: goodAddress, Height: 1, Msg: []byte(`{}`), GasLimit: 1},
 		},
-		"invalid source": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Label:        "foo",
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-				Admin:        goodAddress,
-				Source:       "source",
-			},
-			valid: false,
+		"bad sender": {
+			src:    MsgScheduleSudo{Sender: badAddress, Height: 1, Msg: []byte(`{}`), GasLimit: 1},
+			expErr: true,
 		},
-		"empty builder": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Label:        "foo",
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-				Admin:        goodAddress,
-				Source:       "http://source.com",
-			},
-			valid: false,
+		"zero height": {
+			src:    MsgScheduleSudo{Sender: goodAddress, Msg: []byte(`{}`), GasLimit: 1},
+			expErr: true,
 		},
-		"empty code hash": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Label:        "foo",
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-				Admin:        goodAddress,
-				Source:       "http://source.com",
-				Builder:      "builder",
-			},
-			valid: false,
+		"negative height": {
+			src:    MsgScheduleSudo{Sender: goodAddress, Height: -1, Msg: []byte(`{}`), GasLimit: 1},
+			expErr: true,
 		},
-		"negative funds": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority: goodAddress,
-				Label:     "foo",
-				Msg:       []byte(`{"some": "data"}`),
-				// we cannot use sdk.NewCoin() constructors as they panic on creating invalid data (before we can test)
-				Funds:        sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(-200)}},
-				WASMByteCode: []byte("foo"),
-			},
-			valid: false,
+		"zero gas limit": {
+			src:    MsgScheduleSudo{Sender: goodAddress, Height: 1, Msg: []byte(`{}`)},
+			expErr: true,
 		},
-		"non json init msg": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Label:        "foo",
-				Msg:          []byte("invalid-json"),
-				WASMByteCode: []byte("foo"),
-			},
-			valid: false,
+		"invalid msg": {
+			src:    MsgScheduleSudo{Sender: goodAddress, Height: 1, Msg: []byte(`not json`), GasLimit: 1},
+			expErr: true,
 		},
-		"empty init msg": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:    goodAddress,
-				Label:        "foo",
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgScheduleContractSunsetValidation(t *testing.T) {
+	badAddress := "abcd"
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgScheduleContractSunset
+		expErr bool
+	}{
+		"all good": {
+			src: MsgScheduleContractSunset{Sender: goodAddress, Contract: goodAddress, Height: 1},
+		},
+		"bad sender": {
+			src:    MsgScheduleContractSunset{Sender: badAddress, Contract: goodAddress, Height: 1},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgScheduleContractSunset{Sender: goodAddress, Contract: badAddress, Height: 1},
+			expErr: true,
+		},
+		"zero height": {
+			src:    MsgScheduleContractSunset{Sender: goodAddress, Contract: goodAddress},
+			expErr: true,
+		},
+		"negative height": {
+			src:    MsgScheduleContractSunset{Sender: goodAddress, Contract: goodAddress, Height: -1},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgCancelContractSunsetValidation(t *testing.T) {
+	badAddress := "abcd"
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgCancelContractSunset
+		expErr bool
+	}{
+		"all good": {
+			src: MsgCancelContractSunset{Sender: goodAddress, Contract: goodAddress},
+		},
+		"bad sender": {
+			src:    MsgCancelContractSunset{Sender: badAddress, Contract: goodAddress},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgCancelContractSunset{Sender: goodAddress, Contract: badAddress},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgProposeAdminTransferValidation(t *testing.T) {
+	badAddress := "abcd"
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgProposeAdminTransfer
+		expErr bool
+	}{
+		"all good": {
+			src: MsgProposeAdminTransfer{Sender: goodAddress, Contract: goodAddress, NewAdmin: otherGoodAddress},
+		},
+		"bad sender": {
+			src:    MsgProposeAdminTransfer{Sender: badAddress, Contract: goodAddress, NewAdmin: otherGoodAddress},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgProposeAdminTransfer{Sender: goodAddress, Contract: badAddress, NewAdmin: otherGoodAddress},
+			expErr: true,
+		},
+		"bad new admin": {
+			src:    MsgProposeAdminTransfer{Sender: goodAddress, Contract: goodAddress, NewAdmin: badAddress},
+			expErr: true,
+		},
+		"empty new admin": {
+			src:    MsgProposeAdminTransfer{Sender: goodAddress, Contract: goodAddress},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgAcceptAdminTransferValidation(t *testing.T) {
+	badAddress := "abcd"
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgAcceptAdminTransfer
+		expErr bool
+	}{
+		"all good": {
+			src: MsgAcceptAdminTransfer{Sender: goodAddress, Contract: goodAddress},
+		},
+		"bad sender": {
+			src:    MsgAcceptAdminTransfer{Sender: badAddress, Contract: goodAddress},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgAcceptAdminTransfer{Sender: goodAddress, Contract: badAddress},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSuspendContractValidation(t *testing.T) {
+	badAddress := "abcd"
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSuspendContract
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSuspendContract{Sender: goodAddress, Contract: goodAddress},
+		},
+		"bad sender": {
+			src:    MsgSuspendContract{Sender: badAddress, Contract: goodAddress},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgSuspendContract{Sender: goodAddress, Contract: badAddress},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgResumeContractValidation(t *testing.T) {
+	badAddress := "abcd"
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgResumeContract
+		expErr bool
+	}{
+		"all good": {
+			src: MsgResumeContract{Sender: goodAddress, Contract: goodAddress},
+		},
+		"bad sender": {
+			src:    MsgResumeContract{Sender: badAddress, Contract: goodAddress},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgResumeContract{Sender: goodAddress, Contract: badAddress},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgCancelScheduledSudoValidation(t *testing.T) {
+	badAddress := "abcd"
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgCancelScheduledSudo
+		expErr bool
+	}{
+		"all good": {
+			src: MsgCancelScheduledSudo{Sender: goodAddress, ID: 1},
+		},
+		"bad sender": {
+			src:    MsgCancelScheduledSudo{Sender: badAddress, ID: 1},
+			expErr: true,
+		},
+		"empty id": {
+			src:    MsgCancelScheduledSudo{Sender: goodAddress},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func genCodeIDs(max int) []uint64 {
+	r := make([]uint64, max)
+	for i := 0; i < max; i++ {
+		r[i] = uint64(i)
+	}
+	return r
+}
+
+func TestMsgSudoContractValidation(t *testing.T) {
+	badAddress := "abcd"
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSudoContract
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Contract:  anotherGoodAddress,
+				Msg:       []byte("{}"),
+			},
+		},
+		"bad authority": {
+			src: MsgSudoContract{
+				Authority: badAddress,
+				Contract:  anotherGoodAddress,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"empty authority": {
+			src: MsgSudoContract{
+				Contract: anotherGoodAddress,
+				Msg:      []byte("{}"),
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Contract:  badAddress,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"empty contract addr": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"non json sudoMsg": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Contract:  anotherGoodAddress,
+				Msg:       []byte("invalid json"),
+			},
+			expErr: true,
+		},
+		"empty sudoMsg": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Contract:  anotherGoodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgStoreAndInstantiateContractValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	sdk.GetConfig().SetAddressVerifier(VerifyAddressLen())
+
+	cases := map[string]struct {
+		msg   MsgStoreAndInstantiateContract
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgStoreAndInstantiateContract{},
+			valid: false,
+		},
+		"correct minimal": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Label:        "foo",
+				Msg:          []byte("{}"),
+				WASMByteCode: []byte("foo"),
+			},
+			valid: true,
+		},
+		"missing byte code": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority: goodAddress,
+				Label:     "foo",
+				Msg:       []byte("{}"),
+			},
+			valid: false,
+		},
+		"missing label": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Msg:          []byte("{}"),
+				WASMByteCode: []byte("foo"),
+			},
+			valid: false,
+		},
+		"label too long": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Label:        strings.Repeat("food", 33),
+				Msg:          []byte("{}"),
+				WASMByteCode: []byte("foo"),
+			},
+			valid: false,
+		},
+		"bad sender minimal": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    badAddress,
+				Label:        "foo",
+				Msg:          []byte("{}"),
+				WASMByteCode: []byte("foo"),
+			},
+			valid: false,
+		},
+		"bad admin": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Label:        "foo",
+				Msg:          []byte("{}"),
+				WASMByteCode: []byte("foo"),
+				Admin:        badAddress,
+			},
+			valid: false,
+		},
+		"correct maximal": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:             goodAddress,
+				Label:                 "foo",
+				Msg:                   []byte(`{"some": "data"}`),
+				Funds:                 sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(200)}},
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AllowEverybody,
+				UnpinCode:             true,
+				Admin:                 goodAddress,
+				Source:                "http://source.com",
+				Builder:               "builder",
+				CodeHash:              []byte("{}"),
+			},
+			valid: true,
+		},
+		"invalid source": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Label:        "foo",
+				Msg:          []byte("{}"),
+				WASMByteCode: []byte("foo"),
+				Admin:        goodAddress,
+				Source:       "source",
+			},
+			valid: false,
+		},
+		"empty builder": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Label:        "foo",
+				Msg:          []byte("{}"),
+				WASMByteCode: []byte("foo"),
+				Admin:        goodAddress,
+				Source:       "http://source.com",
+			},
+			valid: false,
+		},
+		"empty code hash": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Label:        "foo",
+				Msg:          []byte("{}"),
+				WASMByteCode: []byte("foo"),
+				Admin:        goodAddress,
+				Source:       "http://source.com",
+				Builder:      "builder",
+			},
+			valid: false,
+		},
+		"negative funds": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority: goodAddress,
+				Label:     "foo",
+				Msg:       []byte(`{"some": "data"}`),
+				// we cannot use sdk.NewCoin() constructors as they panic on creating invalid data (before we can test)
+				Funds:        sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(-200)}},
+				WASMByteCode: []byte("foo"),
+			},
+			valid: false,
+		},
+		"non json init msg": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Label:        "foo",
+				Msg:          []byte("invalid-json"),
+				WASMByteCode: []byte("foo"),
+			},
+			valid: false,
+		},
+		"empty init msg": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				Label:        "foo",
+				WASMByteCode: []byte("foo"),
+			},
+			valid: false,
+		},
+		"invalid InstantiatePermission": {
+			msg: MsgStoreAndInstantiateContract{
+				Authority:             goodAddress,
+				WASMByteCode:          []byte("foo"),
+				Label:                 "foo",
+				Msg:                   []byte(`{"some": "data"}`),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{badAddress}},
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMsgStoreAndMigrateContractValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	sdk.GetConfig().SetAddressVerifier(VerifyAddressLen())
+
+	cases := map[string]struct {
+		msg   MsgStoreAndMigrateContract
+		valid bool
+	}{
+		"all good": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:             goodAddress,
+				Contract:              goodAddress,
+				Msg:                   []byte("{}"),
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
+			},
+			valid: true,
+		},
+		"empty InstantiatePermission": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:    goodAddress,
+				Contract:     goodAddress,
+				Msg:          []byte("{}"),
 				WASMByteCode: []byte("foo"),
 			},
-			valid: false,
+			valid: true,
+		},
+		"empty": {
+			msg:   MsgStoreAndMigrateContract{},
+			valid: false,
+		},
+		"missing byte code": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:             goodAddress,
+				Contract:              goodAddress,
+				Msg:                   []byte("{}"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
+			},
+			valid: false,
+		},
+		"missing contract": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:             goodAddress,
+				Msg:                   []byte("{}"),
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
+			},
+			valid: false,
+		},
+		"bad contract": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:             goodAddress,
+				Contract:              badAddress,
+				Msg:                   []byte("{}"),
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
+			},
+			valid: false,
+		},
+		"bad authority": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:             badAddress,
+				Contract:              goodAddress,
+				Msg:                   []byte("{}"),
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
+			},
+			valid: false,
+		},
+		"non json msg": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:             goodAddress,
+				Contract:              goodAddress,
+				Msg:                   []byte("invalid-json"),
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
+			},
+			valid: false,
+		},
+		"empty msg": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:             goodAddress,
+				Contract:              goodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
+			},
+			valid: false,
+		},
+		"invalid InstantiatePermission": {
+			msg: MsgStoreAndMigrateContract{
+				Authority:             goodAddress,
+				Contract:              goodAddress,
+				Msg:                   []byte("{}"),
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{badAddress}},
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMsgUpdateContractLabel(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateContractLabel
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				NewLabel: "new label",
+				Contract: otherGoodAddress,
+			},
+		},
+		"new label required": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				Contract: otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad sender": {
+			src: MsgUpdateContractLabel{
+				Sender:   badAddress,
+				NewLabel: "new label",
+				Contract: otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"empty new label": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				NewLabel: "",
+				Contract: otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad new label": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				NewLabel: " start with space ",
+				Contract: otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				NewLabel: "new label",
+				Contract: badAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgRegisterParamSubscriberValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgRegisterParamSubscriber
+		expErr bool
+	}{
+		"all good": {
+			src: MsgRegisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{"wasm", "staking"},
+			},
+		},
+		"bad authority": {
+			src: MsgRegisterParamSubscriber{
+				Authority: badAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{"wasm"},
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgRegisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  badAddress,
+				Subspaces: []string{"wasm"},
+			},
+			expErr: true,
+		},
+		"empty subspaces": {
+			src: MsgRegisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"empty subspace": {
+			src: MsgRegisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{""},
+			},
+			expErr: true,
+		},
+		"invalid subspace": {
+			src: MsgRegisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{"Wasm "},
+			},
+			expErr: true,
+		},
+		"subspace exceeds limit": {
+			src: MsgRegisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{strings.Repeat("a", MaxParamSubspaceSize+1)},
+			},
+			expErr: true,
+		},
+		"duplicate subspaces": {
+			src: MsgRegisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{"wasm", "wasm"},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgDeregisterParamSubscriberValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgDeregisterParamSubscriber
+		expErr bool
+	}{
+		"all good": {
+			src: MsgDeregisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{"wasm", "staking"},
+			},
+		},
+		"bad authority": {
+			src: MsgDeregisterParamSubscriber{
+				Authority: badAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{"wasm"},
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgDeregisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  badAddress,
+				Subspaces: []string{"wasm"},
+			},
+			expErr: true,
+		},
+		"empty subspaces": {
+			src: MsgDeregisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"invalid subspace": {
+			src: MsgDeregisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{"Wasm "},
+			},
+			expErr: true,
+		},
+		"duplicate subspaces": {
+			src: MsgDeregisterParamSubscriber{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Subspaces: []string{"wasm", "wasm"},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgStoreCodeChunkValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgStoreCodeChunk
+		expErr bool
+	}{
+		"all good": {
+			src: MsgStoreCodeChunk{Sender: goodAddress, UploadID: "my-upload.1", Index: 1, Total: 2, Data: []byte{1}},
+		},
+		"bad sender": {
+			src:    MsgStoreCodeChunk{Sender: badAddress, UploadID: "my-upload", Index: 0, Total: 1, Data: []byte{1}},
+			expErr: true,
+		},
+		"empty upload id": {
+			src:    MsgStoreCodeChunk{Sender: goodAddress, Index: 0, Total: 1, Data: []byte{1}},
+			expErr: true,
+		},
+		"invalid upload id": {
+			src:    MsgStoreCodeChunk{Sender: goodAddress, UploadID: "my upload", Index: 0, Total: 1, Data: []byte{1}},
+			expErr: true,
+		},
+		"upload id exceeds limit": {
+			src:    MsgStoreCodeChunk{Sender: goodAddress, UploadID: strings.Repeat("a", MaxUploadIDSize+1), Index: 0, Total: 1, Data: []byte{1}},
+			expErr: true,
+		},
+		"zero total": {
+			src:    MsgStoreCodeChunk{Sender: goodAddress, UploadID: "my-upload", Index: 0, Total: 0, Data: []byte{1}},
+			expErr: true,
+		},
+		"index out of range": {
+			src:    MsgStoreCodeChunk{Sender: goodAddress, UploadID: "my-upload", Index: 2, Total: 2, Data: []byte{1}},
+			expErr: true,
+		},
+		"empty data": {
+			src:    MsgStoreCodeChunk{Sender: goodAddress, UploadID: "my-upload", Index: 0, Total: 1},
+			expErr: true,
+		},
+		"data exceeds limit": {
+			src:    MsgStoreCodeChunk{Sender: goodAddress, UploadID: "my-upload", Index: 0, Total: 1, Data: make([]byte, MaxWasmSize+1)},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgCompleteStoreCodeValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	checksum := make([]byte, 32)
+
+	specs := map[string]struct {
+		src    MsgCompleteStoreCode
+		expErr bool
+	}{
+		"all good": {
+			src: MsgCompleteStoreCode{Sender: goodAddress, UploadID: "my-upload", Checksum: checksum},
+		},
+		"with instantiate permission": {
+			src: MsgCompleteStoreCode{Sender: goodAddress, UploadID: "my-upload", Checksum: checksum, InstantiatePermission: &AllowEverybody},
+		},
+		"bad sender": {
+			src:    MsgCompleteStoreCode{Sender: badAddress, UploadID: "my-upload", Checksum: checksum},
+			expErr: true,
+		},
+		"empty upload id": {
+			src:    MsgCompleteStoreCode{Sender: goodAddress, Checksum: checksum},
+			expErr: true,
+		},
+		"empty checksum": {
+			src:    MsgCompleteStoreCode{Sender: goodAddress, UploadID: "my-upload"},
+			expErr: true,
+		},
+		"invalid checksum length": {
+			src:    MsgCompleteStoreCode{Sender: goodAddress, UploadID: "my-upload", Checksum: make([]byte, 31)},
+			expErr: true,
+		},
+		"invalid instantiate permission": {
+			src:    MsgCompleteStoreCode{Sender: goodAddress, UploadID: "my-upload", Checksum: checksum, InstantiatePermission: &AccessConfig{Permission: AccessTypeUnspecified}},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetQueryAliasValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	contractAddress := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+
+	specs := map[string]struct {
+		src    MsgSetQueryAlias
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: "my_alias-1.0", QueryData: []byte(`{"config":{}}`)},
+		},
+		"remove": {
+			src: MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: "my_alias"},
+		},
+		"bad sender": {
+			src:    MsgSetQueryAlias{Sender: badAddress, Contract: contractAddress, Name: "my_alias", QueryData: []byte(`{}`)},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgSetQueryAlias{Sender: goodAddress, Contract: badAddress, Name: "my_alias", QueryData: []byte(`{}`)},
+			expErr: true,
+		},
+		"empty name": {
+			src:    MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, QueryData: []byte(`{}`)},
+			expErr: true,
+		},
+		"invalid name": {
+			src:    MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: "my alias", QueryData: []byte(`{}`)},
+			expErr: true,
+		},
+		"name exceeds limit": {
+			src:    MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: strings.Repeat("a", MaxQueryAliasNameSize+1), QueryData: []byte(`{}`)},
+			expErr: true,
+		},
+		"invalid json": {
+			src:    MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: "my_alias", QueryData: []byte(`not json`)},
+			expErr: true,
+		},
+		"query exceeds limit": {
+			src:    MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: "my_alias", QueryData: []byte(`"` + strings.Repeat("a", MaxQueryAliasSize) + `"`)},
+			expErr: true,
+		},
+		"with cache ttl": {
+			src: MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: "my_alias", QueryData: []byte(`{}`), CacheTTLSeconds: MaxQueryAliasCacheTTLSeconds},
+		},
+		"cache ttl exceeds limit": {
+			src:    MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: "my_alias", QueryData: []byte(`{}`), CacheTTLSeconds: MaxQueryAliasCacheTTLSeconds + 1},
+			expErr: true,
+		},
+		"remove with cache ttl": {
+			src:    MsgSetQueryAlias{Sender: goodAddress, Contract: contractAddress, Name: "my_alias", CacheTTLSeconds: 60},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetIBCSendTimeoutValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	contractAddress := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+
+	specs := map[string]struct {
+		src    MsgSetIBCSendTimeout
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSetIBCSendTimeout{Sender: goodAddress, Contract: contractAddress, TimeoutTimestamp: 1},
+		},
+		"remove": {
+			src: MsgSetIBCSendTimeout{Sender: goodAddress, Contract: contractAddress},
+		},
+		"bad sender": {
+			src:    MsgSetIBCSendTimeout{Sender: badAddress, Contract: contractAddress, TimeoutTimestamp: 1},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgSetIBCSendTimeout{Sender: goodAddress, Contract: badAddress, TimeoutTimestamp: 1},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetAdminChangeNotificationValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	contractAddress := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+
+	specs := map[string]struct {
+		src    MsgSetAdminChangeNotification
+		expErr bool
+	}{
+		"enable": {
+			src: MsgSetAdminChangeNotification{Sender: goodAddress, Contract: contractAddress, Enabled: true},
+		},
+		"disable": {
+			src: MsgSetAdminChangeNotification{Sender: goodAddress, Contract: contractAddress},
+		},
+		"bad sender": {
+			src:    MsgSetAdminChangeNotification{Sender: badAddress, Contract: contractAddress, Enabled: true},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgSetAdminChangeNotification{Sender: goodAddress, Contract: badAddress, Enabled: true},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateContractAdminsValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	contractAddress := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+	otherContractAddress := sdk.AccAddress(bytes.Repeat([]byte{1}, ContractAddrLen)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateContractAdmins
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateContractAdmins{Authority: goodAddress, Updates: []ContractAdminUpdate{
+				{Contract: contractAddress, NewAdmin: goodAddress},
+				{Contract: otherContractAddress, NewAdmin: goodAddress},
+			}},
+		},
+		"bad authority": {
+			src:    MsgUpdateContractAdmins{Authority: badAddress, Updates: []ContractAdminUpdate{{Contract: contractAddress, NewAdmin: goodAddress}}},
+			expErr: true,
+		},
+		"empty updates": {
+			src:    MsgUpdateContractAdmins{Authority: goodAddress},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgUpdateContractAdmins{Authority: goodAddress, Updates: []ContractAdminUpdate{{Contract: badAddress, NewAdmin: goodAddress}}},
+			expErr: true,
+		},
+		"bad new admin": {
+			src:    MsgUpdateContractAdmins{Authority: goodAddress, Updates: []ContractAdminUpdate{{Contract: contractAddress, NewAdmin: badAddress}}},
+			expErr: true,
+		},
+		"duplicate contract": {
+			src: MsgUpdateContractAdmins{Authority: goodAddress, Updates: []ContractAdminUpdate{
+				{Contract: contractAddress, NewAdmin: goodAddress},
+				{Contract: contractAddress, NewAdmin: otherContractAddress},
+			}},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetLegacyReverseIteratorValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSetLegacyReverseIterator
+		expErr bool
+	}{
+		"enable": {
+			src: MsgSetLegacyReverseIterator{Authority: goodAddress, CodeID: 1, Enabled: true},
+		},
+		"disable": {
+			src: MsgSetLegacyReverseIterator{Authority: goodAddress, CodeID: 1},
+		},
+		"bad authority": {
+			src:    MsgSetLegacyReverseIterator{Authority: badAddress, CodeID: 1, Enabled: true},
+			expErr: true,
+		},
+		"empty code id": {
+			src:    MsgSetLegacyReverseIterator{Authority: goodAddress, Enabled: true},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgCodeUploadApprovalValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	goodChecksum := bytes.Repeat([]byte{1}, 32)
+
+	specs := map[string]struct {
+		src    sdk.HasValidateBasic
+		expErr bool
+	}{
+		"add": {
+			src: MsgAddCodeUploadApproval{Authority: goodAddress, Checksum: goodChecksum, ConsumeOnce: true},
+		},
+		"add - bad authority": {
+			src:    MsgAddCodeUploadApproval{Authority: badAddress, Checksum: goodChecksum},
+			expErr: true,
+		},
+		"add - empty checksum": {
+			src:    MsgAddCodeUploadApproval{Authority: goodAddress},
+			expErr: true,
+		},
+		"add - checksum too short": {
+			src:    MsgAddCodeUploadApproval{Authority: goodAddress, Checksum: goodChecksum[1:]},
+			expErr: true,
+		},
+		"remove": {
+			src: MsgRemoveCodeUploadApproval{Authority: goodAddress, Checksum: goodChecksum},
+		},
+		"remove - bad authority": {
+			src:    MsgRemoveCodeUploadApproval{Authority: badAddress, Checksum: goodChecksum},
+			expErr: true,
+		},
+		"remove - empty checksum": {
+			src:    MsgRemoveCodeUploadApproval{Authority: goodAddress},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgImportContractValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+	myState := []Model{{Key: []byte("a"), Value: []byte("1")}, {Key: []byte("b"), Value: []byte("2")}}
+
+	specs := map[string]struct {
+		src    MsgImportContract
+		expErr bool
+	}{
+		"all good with code id": {
+			src: MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, Creator: goodAddress, Label: "foo", State: myState},
+		},
+		"all good with wasm": {
+			src: MsgImportContract{
+				Authority:             goodAddress,
+				Address:               otherGoodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AllowEverybody,
+				Creator:               goodAddress,
+				Admin:                 goodAddress,
+				Label:                 "foo",
+				State:                 myState,
+			},
+		},
+		"empty state": {
+			src: MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, Creator: goodAddress, Label: "foo"},
+		},
+		"bad authority": {
+			src:    MsgImportContract{Authority: badAddress, Address: otherGoodAddress, CodeID: 1, Creator: goodAddress, Label: "foo"},
+			expErr: true,
+		},
+		"bad address": {
+			src:    MsgImportContract{Authority: goodAddress, Address: badAddress, CodeID: 1, Creator: goodAddress, Label: "foo"},
+			expErr: true,
+		},
+		"bad creator": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, Creator: badAddress, Label: "foo"},
+			expErr: true,
+		},
+		"bad admin": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, Creator: goodAddress, Admin: badAddress, Label: "foo"},
+			expErr: true,
+		},
+		"empty label": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, Creator: goodAddress},
+			expErr: true,
+		},
+		"neither code id nor wasm": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, Creator: goodAddress, Label: "foo"},
+			expErr: true,
+		},
+		"code id and wasm": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, WASMByteCode: []byte("foo"), Creator: goodAddress, Label: "foo"},
+			expErr: true,
 		},
-		"invalid InstantiatePermission": {
-			msg: MsgStoreAndInstantiateContract{
-				Authority:             goodAddress,
-				WASMByteCode:          []byte("foo"),
-				Label:                 "foo",
-				Msg:                   []byte(`{"some": "data"}`),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{badAddress}},
+		"instantiate permission without wasm": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, InstantiatePermission: &AllowEverybody, Creator: goodAddress, Label: "foo"},
+			expErr: true,
+		},
+		"wasm exceeds limit": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, WASMByteCode: bytes.Repeat([]byte{0x1}, MaxProposalWasmSize+1), Creator: goodAddress, Label: "foo"},
+			expErr: true,
+		},
+		"empty state key": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, Creator: goodAddress, Label: "foo", State: []Model{{Value: []byte("1")}}},
+			expErr: true,
+		},
+		"duplicate state key": {
+			src:    MsgImportContract{Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, Creator: goodAddress, Label: "foo", State: append(myState, myState[0])},
+			expErr: true,
+		},
+		"state exceeds limit": {
+			src: MsgImportContract{
+				Authority: goodAddress, Address: otherGoodAddress, CodeID: 1, Creator: goodAddress, Label: "foo",
+				State: []Model{{Key: []byte("a"), Value: bytes.Repeat([]byte{0x1}, MaxImportContractStateSize)}},
 			},
-			valid: false,
+			expErr: true,
 		},
 	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			err := tc.msg.ValidateBasic()
-			if tc.valid {
-				assert.NoError(t, err)
-			} else {
-				assert.Error(t, err)
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
 			}
+			require.NoError(t, err)
 		})
 	}
 }
 
-func TestMsgStoreAndMigrateContractValidation(t *testing.T) {
+func TestMsgSetContractTagsValidation(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
-	sdk.GetConfig().SetAddressVerifier(VerifyAddressLen())
+	tooManyTags := make([]string, MaxContractTags+1)
+	for i := range tooManyTags {
+		tooManyTags[i] = fmt.Sprintf("tag-%d", i)
+	}
 
-	cases := map[string]struct {
-		msg   MsgStoreAndMigrateContract
-		valid bool
+	specs := map[string]struct {
+		src    MsgSetContractTags
+		expErr bool
 	}{
 		"all good": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:             goodAddress,
-				Contract:              goodAddress,
-				Msg:                   []byte("{}"),
-				WASMByteCode:          []byte("foo"),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
-			},
-			valid: true,
+			src: MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: []string{"prod", "defi", "v1.0_beta"}},
 		},
-		"empty InstantiatePermission": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:    goodAddress,
-				Contract:     goodAddress,
-				Msg:          []byte("{}"),
-				WASMByteCode: []byte("foo"),
-			},
-			valid: true,
+		"empty tags": {
+			src: MsgSetContractTags{Sender: goodAddress, Contract: goodAddress},
 		},
-		"empty": {
-			msg:   MsgStoreAndMigrateContract{},
-			valid: false,
+		"max tags": {
+			src: MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: tooManyTags[:MaxContractTags]},
 		},
-		"missing byte code": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:             goodAddress,
-				Contract:              goodAddress,
-				Msg:                   []byte("{}"),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
-			},
-			valid: false,
+		"max tag size": {
+			src: MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: []string{strings.Repeat("a", MaxContractTagSize)}},
 		},
-		"missing contract": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:             goodAddress,
-				Msg:                   []byte("{}"),
-				WASMByteCode:          []byte("foo"),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
-			},
-			valid: false,
+		"bad sender": {
+			src:    MsgSetContractTags{Sender: badAddress, Contract: goodAddress, Tags: []string{"prod"}},
+			expErr: true,
 		},
 		"bad contract": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:             goodAddress,
-				Contract:              badAddress,
-				Msg:                   []byte("{}"),
-				WASMByteCode:          []byte("foo"),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
-			},
-			valid: false,
+			src:    MsgSetContractTags{Sender: goodAddress, Contract: badAddress, Tags: []string{"prod"}},
+			expErr: true,
 		},
-		"bad authority": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:             badAddress,
-				Contract:              goodAddress,
-				Msg:                   []byte("{}"),
-				WASMByteCode:          []byte("foo"),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
-			},
-			valid: false,
+		"too many tags": {
+			src:    MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: tooManyTags},
+			expErr: true,
 		},
-		"non json msg": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:             goodAddress,
-				Contract:              goodAddress,
-				Msg:                   []byte("invalid-json"),
-				WASMByteCode:          []byte("foo"),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
-			},
-			valid: false,
+		"tag exceeds limit": {
+			src:    MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: []string{strings.Repeat("a", MaxContractTagSize+1)}},
+			expErr: true,
 		},
-		"empty msg": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:             goodAddress,
-				Contract:              goodAddress,
-				WASMByteCode:          []byte("foo"),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
-			},
-			valid: false,
+		"empty tag": {
+			src:    MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: []string{""}},
+			expErr: true,
 		},
-		"invalid InstantiatePermission": {
-			msg: MsgStoreAndMigrateContract{
-				Authority:             goodAddress,
-				Contract:              goodAddress,
-				Msg:                   []byte("{}"),
-				WASMByteCode:          []byte("foo"),
-				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{badAddress}},
-			},
-			valid: false,
+		"duplicate tag": {
+			src:    MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: []string{"prod", "prod"}},
+			expErr: true,
+		},
+		"upper case tag": {
+			src:    MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: []string{"Prod"}},
+			expErr: true,
+		},
+		"tag with whitespace": {
+			src:    MsgSetContractTags{Sender: goodAddress, Contract: goodAddress, Tags: []string{"my tag"}},
+			expErr: true,
 		},
 	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			err := tc.msg.ValidateBasic()
-			if tc.valid {
-				assert.NoError(t, err)
-			} else {
-				assert.Error(t, err)
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
 			}
+			require.NoError(t, err)
 		})
 	}
 }
 
-func TestMsgUpdateContractLabel(t *testing.T) {
+func TestMsgSetContractFeeSponsorshipValidation(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
-	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+	coins := func(s string) sdk.Coins {
+		c, err := sdk.ParseCoinsNormalized(s)
+		require.NoError(t, err)
+		return c
+	}
 
 	specs := map[string]struct {
-		src    MsgUpdateContractLabel
+		src    MsgSetContractFeeSponsorship
 		expErr bool
 	}{
 		"all good": {
-			src: MsgUpdateContractLabel{
-				Sender:   goodAddress,
-				NewLabel: "new label",
-				Contract: otherGoodAddress,
-			},
+			src: MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: goodAddress, Sponsorship: &FeeSponsorship{MaxFeePerTx: coins("10stake"), MaxFeePerBlock: coins("100stake,1other")}},
 		},
-		"new label required": {
-			src: MsgUpdateContractLabel{
-				Sender:   goodAddress,
-				Contract: otherGoodAddress,
-			},
-			expErr: true,
+		"same budgets": {
+			src: MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: goodAddress, Sponsorship: &FeeSponsorship{MaxFeePerTx: coins("10stake"), MaxFeePerBlock: coins("10stake")}},
+		},
+		"remove sponsorship": {
+			src: MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: goodAddress},
 		},
 		"bad sender": {
-			src: MsgUpdateContractLabel{
-				Sender:   badAddress,
-				NewLabel: "new label",
-				Contract: otherGoodAddress,
-			},
+			src:    MsgSetContractFeeSponsorship{Sender: badAddress, Contract: goodAddress},
 			expErr: true,
 		},
-		"empty new label": {
-			src: MsgUpdateContractLabel{
-				Sender:   goodAddress,
-				NewLabel: "",
-				Contract: otherGoodAddress,
-			},
+		"bad contract": {
+			src:    MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: badAddress},
 			expErr: true,
 		},
-		"bad new label": {
-			src: MsgUpdateContractLabel{
-				Sender:   goodAddress,
-				NewLabel: " start with space ",
-				Contract: otherGoodAddress,
-			},
+		"empty max fee per tx": {
+			src:    MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: goodAddress, Sponsorship: &FeeSponsorship{MaxFeePerBlock: coins("100stake")}},
+			expErr: true,
+		},
+		"empty max fee per block": {
+			src:    MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: goodAddress, Sponsorship: &FeeSponsorship{MaxFeePerTx: coins("10stake")}},
+			expErr: true,
+		},
+		"invalid coins": {
+			src:    MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: goodAddress, Sponsorship: &FeeSponsorship{MaxFeePerTx: sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdkmath.NewInt(-1)}}, MaxFeePerBlock: coins("100stake")}},
+			expErr: true,
+		},
+		"max fee per tx exceeds max fee per block": {
+			src:    MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: goodAddress, Sponsorship: &FeeSponsorship{MaxFeePerTx: coins("101stake"), MaxFeePerBlock: coins("100stake")}},
+			expErr: true,
+		},
+		"max fee per tx denom not in max fee per block": {
+			src:    MsgSetContractFeeSponsorship{Sender: goodAddress, Contract: goodAddress, Sponsorship: &FeeSponsorship{MaxFeePerTx: coins("1other"), MaxFeePerBlock: coins("100stake")}},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgForceMigrateContractValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgForceMigrateContract
+		expErr bool
+	}{
+		"all good": {
+			src: MsgForceMigrateContract{Authority: goodAddress, Contract: anotherGoodAddress, CodeID: firstCodeID, Msg: []byte("{}")},
+		},
+		"bad authority": {
+			src:    MsgForceMigrateContract{Authority: badAddress, Contract: anotherGoodAddress, CodeID: firstCodeID, Msg: []byte("{}")},
+			expErr: true,
+		},
+		"empty authority": {
+			src:    MsgForceMigrateContract{Contract: anotherGoodAddress, CodeID: firstCodeID, Msg: []byte("{}")},
+			expErr: true,
+		},
+		"empty code": {
+			src:    MsgForceMigrateContract{Authority: goodAddress, Contract: anotherGoodAddress, Msg: []byte("{}")},
 			expErr: true,
 		},
 		"bad contract addr": {
-			src: MsgUpdateContractLabel{
-				Sender:   goodAddress,
-				NewLabel: "new label",
-				Contract: badAddress,
-			},
+			src:    MsgForceMigrateContract{Authority: goodAddress, Contract: badAddress, CodeID: firstCodeID, Msg: []byte("{}")},
+			expErr: true,
+		},
+		"non json migrateMsg": {
+			src:    MsgForceMigrateContract{Authority: goodAddress, Contract: anotherGoodAddress, CodeID: firstCodeID, Msg: []byte("invalid json")},
+			expErr: true,
+		},
+		"empty migrateMsg": {
+			src:    MsgForceMigrateContract{Authority: goodAddress, Contract: anotherGoodAddress, CodeID: firstCodeID},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetIgnoreReplyErrorValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	contractAddress := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+
+	specs := map[string]struct {
+		src    MsgSetIgnoreReplyError
+		expErr bool
+	}{
+		"enable": {
+			src: MsgSetIgnoreReplyError{Sender: goodAddress, Contract: contractAddress, Enabled: true},
+		},
+		"disable": {
+			src: MsgSetIgnoreReplyError{Sender: goodAddress, Contract: contractAddress},
+		},
+		"bad sender": {
+			src:    MsgSetIgnoreReplyError{Sender: badAddress, Contract: contractAddress, Enabled: true},
+			expErr: true,
+		},
+		"bad contract": {
+			src:    MsgSetIgnoreReplyError{Sender: goodAddress, Contract: badAddress, Enabled: true},
 			expErr: true,
 		},
 	}
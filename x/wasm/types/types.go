@@ -1,9 +1,12 @@
 package types
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"reflect"
+	"slices"
+	"time"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	"github.com/cosmos/gogoproto/proto"
@@ -16,9 +19,10 @@ import (
 )
 
 const (
-	defaultMemoryCacheSize    uint32 = 100 // in MiB
-	defaultSmartQueryGasLimit uint64 = 3_000_000
-	defaultContractDebugMode         = false
+	defaultMemoryCacheSize      uint32 = 100 // in MiB
+	defaultSmartQueryGasLimit   uint64 = 3_000_000
+	defaultContractDebugMode           = false
+	defaultPrecompileTimeBudget        = time.Minute
 
 	// SDKAddrLen defines a valid address length that was used in sdk address generation
 	SDKAddrLen = 20
@@ -34,6 +38,22 @@ func (m Model) ValidateBasic() error {
 	return nil
 }
 
+func (a QueryAlias) ValidateBasic() error {
+	if err := ValidateQueryAliasName(a.Name); err != nil {
+		return errorsmod.Wrap(err, "name")
+	}
+	if len(a.QueryData) == 0 {
+		return errorsmod.Wrap(ErrEmpty, "query data")
+	}
+	if err := validateQueryAliasData(a.QueryData); err != nil {
+		return errorsmod.Wrap(err, "query data")
+	}
+	if err := validateQueryAliasCacheTTL(a.CacheTTLSeconds); err != nil {
+		return errorsmod.Wrap(err, "cache ttl")
+	}
+	return nil
+}
+
 func (c CodeInfo) ValidateBasic() error {
 	if len(c.CodeHash) == 0 {
 		return errorsmod.Wrap(ErrEmpty, "code hash")
@@ -44,9 +64,79 @@ func (c CodeInfo) ValidateBasic() error {
 	if err := c.InstantiateConfig.ValidateBasic(); err != nil {
 		return errorsmod.Wrap(err, "instantiate config")
 	}
+	if c.Provenance != nil {
+		if err := ValidateProvenance(c.Provenance.Source, c.Provenance.Builder, c.Provenance.Signature); err != nil {
+			return errorsmod.Wrap(err, "provenance")
+		}
+	}
 	return nil
 }
 
+// ValidateBasic performs basic validation
+func (a CodeAttestation) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(a.Attester); err != nil {
+		return errorsmod.Wrap(err, "attester")
+	}
+	if a.Height < 0 {
+		return errorsmod.Wrap(ErrInvalid, "height")
+	}
+	return ValidateAttestation(a.RepoURL, a.Commit, a.BuilderImage)
+}
+
+// ValidateBasic performs basic validation
+func (a CodeUploadApproval) ValidateBasic() error {
+	return ValidateChecksum(a.Checksum)
+}
+
+// ValidateBasic performs basic validation
+func (v CodeVerificationVote) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(v.Voter); err != nil {
+		return errorsmod.Wrap(err, "voter")
+	}
+	if v.Height < 0 {
+		return errorsmod.Wrap(ErrInvalid, "height")
+	}
+	return errorsmod.Wrap(validateCodeVerificationVerdict(v.Verdict), "verdict")
+}
+
+// ValidateBasic performs basic validation
+func (s ScheduledSudo) ValidateBasic() error {
+	if s.ID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "id")
+	}
+	if _, err := sdk.AccAddressFromBech32(s.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if s.Height <= 0 {
+		return errorsmod.Wrap(ErrInvalid, "height must be positive")
+	}
+	if s.GasLimit == 0 {
+		return errorsmod.Wrap(ErrEmpty, "gas limit")
+	}
+	return errorsmod.Wrap(s.Msg.ValidateBasic(), "payload msg")
+}
+
+func validateCodeVerificationVerdict(v CodeVerificationVerdict) error {
+	switch v {
+	case CodeVerificationVerdictReproducible, CodeVerificationVerdictNotReproducible:
+		return nil
+	case CodeVerificationVerdictUnspecified:
+		return ErrEmpty
+	default:
+		return errorsmod.Wrapf(ErrInvalid, "unknown verdict: %d", v)
+	}
+}
+
+// CodeProvenanceSignBytes returns the bytes that the creator signs for a code provenance. These are the checksum,
+// the big endian uint32 length of the source, the source and the builder.
+func CodeProvenanceSignBytes(checksum []byte, source, builder string) []byte {
+	r := make([]byte, 0, len(checksum)+4+len(source)+len(builder))
+	r = append(r, checksum...)
+	r = binary.BigEndian.AppendUint32(r, uint32(len(source)))
+	r = append(r, source...)
+	return append(r, builder...)
+}
+
 // NewCodeInfo fills a new CodeInfo struct
 func NewCodeInfo(codeHash []byte, creator sdk.AccAddress, instantiatePermission AccessConfig) CodeInfo {
 	return CodeInfo{
@@ -56,7 +146,7 @@ func NewCodeInfo(codeHash []byte, creator sdk.AccAddress, instantiatePermission
 	}
 }
 
-var AllCodeHistoryTypes = []ContractCodeHistoryOperationType{ContractCodeHistoryOperationTypeGenesis, ContractCodeHistoryOperationTypeInit, ContractCodeHistoryOperationTypeMigrate}
+var AllCodeHistoryTypes = []ContractCodeHistoryOperationType{ContractCodeHistoryOperationTypeGenesis, ContractCodeHistoryOperationTypeInit, ContractCodeHistoryOperationTypeMigrate, ContractCodeHistoryOperationTypeImport, ContractCodeHistoryOperationTypeForceMigrate}
 
 // NewContractInfo creates a new instance of a given WASM contract info
 func NewContractInfo(codeID uint64, creator, admin sdk.AccAddress, label string, createdAt *AbsoluteTxPosition) ContractInfo {
@@ -96,6 +186,25 @@ func (c *ContractInfo) ValidateBasic() error {
 	if err := ValidateLabel(c.Label); err != nil {
 		return errorsmod.Wrap(err, "label")
 	}
+	if err := ValidateContractTags(c.Tags); err != nil {
+		return errorsmod.Wrap(err, "tags")
+	}
+	if !slices.IsSorted(c.Tags) {
+		return errorsmod.Wrap(ErrInvalid, "tags must be sorted")
+	}
+	if c.FeeSponsorship != nil {
+		if err := c.FeeSponsorship.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "fee sponsorship")
+		}
+	}
+	if c.SunsetHeight < 0 {
+		return errorsmod.Wrap(ErrInvalid, "sunset height must not be negative")
+	}
+	if len(c.PendingAdmin) != 0 {
+		if _, err := sdk.AccAddressFromBech32(c.PendingAdmin); err != nil {
+			return errorsmod.Wrap(err, "pending admin")
+		}
+	}
 	if c.Extension == nil {
 		return nil
 	}
@@ -110,6 +219,26 @@ func (c *ContractInfo) ValidateBasic() error {
 	return nil
 }
 
+// ValidateBasic ensures that both budgets are set and that the fee of a single tx fits into the block budget
+func (f FeeSponsorship) ValidateBasic() error {
+	if f.MaxFeePerTx.Empty() {
+		return errorsmod.Wrap(ErrEmpty, "max fee per tx")
+	}
+	if err := f.MaxFeePerTx.Validate(); err != nil {
+		return errorsmod.Wrap(err, "max fee per tx")
+	}
+	if f.MaxFeePerBlock.Empty() {
+		return errorsmod.Wrap(ErrEmpty, "max fee per block")
+	}
+	if err := f.MaxFeePerBlock.Validate(); err != nil {
+		return errorsmod.Wrap(err, "max fee per block")
+	}
+	if !f.MaxFeePerTx.IsAllLTE(f.MaxFeePerBlock) {
+		return errorsmod.Wrap(ErrInvalid, "max fee per tx exceeds max fee per block")
+	}
+	return nil
+}
+
 // SetExtension set new extension data. Calls `ValidateBasic() error` on non nil values when method is implemented by
 // the extension.
 func (c *ContractInfo) SetExtension(ext ContractInfoExtension) error {
@@ -333,14 +462,60 @@ type NodeConfig struct {
 	MemoryCacheSize uint32 `mapstructure:"memory_cache_size"`
 	// ContractDebugMode log what contract print
 	ContractDebugMode bool
+	// StrictVMCacheCheck refuses to start the node when missing wasm cache entries can not be healed
+	StrictVMCacheCheck bool `mapstructure:"strict_vm_cache_check"`
+	// SmartQueryDepthGasPercent is the max percentage of the remaining query gas of the parent that a nested
+	// smart query can use. It is only applied to smart queries via gRPC. Set to 0 to disable.
+	SmartQueryDepthGasPercent uint32 `mapstructure:"query_depth_gas_percent"`
+	// EnableStateWatch enables the node local gRPC service to stream contract state changes
+	EnableStateWatch bool `mapstructure:"enable_state_watch"`
+	// SignedQueryBlockWindow is the number of recent blocks whose hash is accepted in a signed smart query.
+	// Set to 0 to disable signed queries.
+	SignedQueryBlockWindow uint32 `mapstructure:"signed_query_block_window"`
+	// StoreWriteLogDir is the directory of a debug log of all contract store writes and deletes in FinalizeBlock.
+	// Relative paths are resolved against the node home. When not set nothing is logged.
+	StoreWriteLogDir string `mapstructure:"store_write_log_dir"`
+	// ExecutionLogLevel is the log level of the contract execution logs. One of "debug", "info" or "none".
+	// When not set "debug" is used.
+	ExecutionLogLevel string `mapstructure:"execution_log_level"`
+	// PrecompileOnStart compiles the wasm code of all stored codes at node startup so that the first
+	// execution of a code does not pay the compile cost, for example after the wasm cache was wiped.
+	PrecompileOnStart bool `mapstructure:"precompile_on_start"`
+	// PrecompileWorkers is the number of codes compiled concurrently at startup.
+	// When not set the number of CPUs is used.
+	PrecompileWorkers uint32 `mapstructure:"precompile_workers"`
+	// PrecompileTimeBudget is the max time the startup waits for the pre-compilation. The remaining codes are
+	// compiled in the background afterwards. Set to 0 to compile all codes in the background.
+	PrecompileTimeBudget time.Duration `mapstructure:"precompile_time_budget"`
+	// AliasQueryCacheSize is the max number of aliased smart query responses that the REST API caches for the
+	// cache ttl of the query alias. Set to 0 to disable the cache.
+	AliasQueryCacheSize uint32 `mapstructure:"alias_query_cache_size"`
+}
+
+// log levels of the contract execution logs
+const (
+	ExecutionLogLevelDebug = "debug"
+	ExecutionLogLevelInfo  = "info"
+	ExecutionLogLevelNone  = "none"
+)
+
+// ValidateExecutionLogLevel returns an error when the level is not empty and not a known execution log level
+func ValidateExecutionLogLevel(level string) error {
+	switch level {
+	case "", ExecutionLogLevelDebug, ExecutionLogLevelInfo, ExecutionLogLevelNone:
+		return nil
+	default:
+		return fmt.Errorf("unknown execution log level: %q", level)
+	}
 }
 
 // DefaultNodeConfig returns the default settings for NodeConfig
 func DefaultNodeConfig() NodeConfig {
 	return NodeConfig{
-		SmartQueryGasLimit: defaultSmartQueryGasLimit,
-		MemoryCacheSize:    defaultMemoryCacheSize,
-		ContractDebugMode:  defaultContractDebugMode,
+		SmartQueryGasLimit:   defaultSmartQueryGasLimit,
+		MemoryCacheSize:      defaultMemoryCacheSize,
+		ContractDebugMode:    defaultContractDebugMode,
+		PrecompileTimeBudget: defaultPrecompileTimeBudget,
 	}
 }
 
@@ -368,7 +543,12 @@ memory_cache_size = %d
 # Simulation gas limit is the max gas to be used in a tx simulation call.
 # When not set the consensus max block gas is used instead
 %s
-`, c.SmartQueryGasLimit, c.MemoryCacheSize, simGasLimit)
+
+# Max percentage of the remaining query gas of the parent that a nested smart query
+# can use, so that deep query chains can not starve the root. Only applied to smart
+# queries via gRPC, not in txs or simulations. Set to 0 to disable.
+query_depth_gas_percent = %d
+`, c.SmartQueryGasLimit, c.MemoryCacheSize, simGasLimit, c.SmartQueryDepthGasPercent)
 }
 
 // VerifyAddressLen ensures that the address matches the expected length
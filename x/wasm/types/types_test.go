@@ -24,6 +24,23 @@ func TestContractInfoValidateBasic(t *testing.T) {
 		expError   bool
 	}{
 		"all good": {srcMutator: func(_ *ContractInfo) {}},
+		"with sunset height": {
+			srcMutator: func(c *ContractInfo) { c.SunsetHeight = 1 },
+		},
+		"inactive": {
+			srcMutator: func(c *ContractInfo) { c.SunsetHeight, c.Inactive = 1, true },
+		},
+		"negative sunset height": {
+			srcMutator: func(c *ContractInfo) { c.SunsetHeight = -1 },
+			expError:   true,
+		},
+		"with pending admin": {
+			srcMutator: func(c *ContractInfo) { c.PendingAdmin = c.Creator },
+		},
+		"invalid pending admin": {
+			srcMutator: func(c *ContractInfo) { c.PendingAdmin = "invalid" },
+			expError:   true,
+		},
 		"code id empty": {
 			srcMutator: func(c *ContractInfo) { c.CodeID = 0 },
 			expError:   true,
@@ -52,6 +69,17 @@ func TestContractInfoValidateBasic(t *testing.T) {
 			srcMutator: func(c *ContractInfo) { c.Label = strings.Repeat("a", MaxLabelSize+1) },
 			expError:   true,
 		},
+		"with tags": {
+			srcMutator: func(c *ContractInfo) { c.Tags = []string{"defi", "prod"} },
+		},
+		"unsorted tags": {
+			srcMutator: func(c *ContractInfo) { c.Tags = []string{"prod", "defi"} },
+			expError:   true,
+		},
+		"invalid tag": {
+			srcMutator: func(c *ContractInfo) { c.Tags = []string{"Prod"} },
+			expError:   true,
+		},
 		"invalid extension": {
 			srcMutator: func(c *ContractInfo) {
 				// any protobuf type with ValidateBasic method
@@ -338,6 +366,53 @@ func TestNewEnv(t *testing.T) {
 	}
 }
 
+func TestNewEnvBlockTime(t *testing.T) {
+	var myContractAddr sdk.AccAddress = randBytes(ContractAddrLen)
+	specs := map[string]struct {
+		src      time.Time
+		exp      wasmvmtypes.Uint64
+		expPanic bool
+	}{
+		"nanoseconds kept": {
+			src: time.Unix(1619700924, 259075001),
+			exp: 1619700924259075001,
+		},
+		"max nanoseconds": {
+			src: time.Unix(1619700924, 999999999),
+			exp: 1619700924999999999,
+		},
+		"other timezone": {
+			src: time.Unix(1619700924, 259075001).In(time.FixedZone("UTC+5", 5*60*60)),
+			exp: 1619700924259075001,
+		},
+		"min": {
+			src: time.Unix(0, 1),
+			exp: 1,
+		},
+		"zero": {
+			src:      time.Unix(0, 0),
+			expPanic: true,
+		},
+		"negative": {
+			src:      time.Unix(-1, 0),
+			expPanic: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.WithBlockHeight(1).WithBlockTime(spec.src).WithChainID("testing").WithContext(context.Background())
+			if spec.expPanic {
+				assert.Panics(t, func() { NewEnv(ctx, myContractAddr) })
+				return
+			}
+			got := NewEnv(ctx, myContractAddr)
+			assert.Equal(t, spec.exp, got.Block.Time)
+			// same result on every call
+			assert.Equal(t, got, NewEnv(ctx, myContractAddr))
+		})
+	}
+}
+
 func TestVerifyAddressLen(t *testing.T) {
 	specs := map[string]struct {
 		src    []byte
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
@@ -18,6 +20,13 @@ import (
 // MaxSaltSize is the longest salt that can be used when instantiating a contract
 const MaxSaltSize = 64
 
+// VMResponseSizeLimit is the wasmvm limit of the serialized contract result in bytes. The data, events and
+// attributes of a contract response are bounded by it, so that the response limit params can only be stricter.
+const VMResponseSizeLimit = 256 * 1024
+
+// MaxQueryAliasCacheTTLSeconds is the longest time that a query alias response can be cached
+const MaxQueryAliasCacheTTLSeconds uint32 = 24 * 60 * 60
+
 var (
 	// MaxLabelSize is the longest label that can be used when instantiating a contract
 	MaxLabelSize = 128 // extension point for chains to customize via compile flag.
@@ -30,6 +39,48 @@ var (
 
 	// MaxAddressCount is the maximum number of addresses allowed within a message
 	MaxAddressCount = 50
+
+	// MaxParamSubspaceSize is the longest module name that can be subscribed to for param changes
+	MaxParamSubspaceSize = 64
+
+	// MaxUploadIDSize is the longest upload id that can be used for a chunked code upload
+	MaxUploadIDSize = 64
+
+	// MaxQueryAliasNameSize is the longest name that can be used for a query alias
+	MaxQueryAliasNameSize = 64
+
+	// MaxQueryAliasSize is the largest smart query that can be stored as query alias
+	MaxQueryAliasSize = 4 * 1024
+
+	// MaxQueryAliasesPerContract is the maximum number of query aliases a contract can have
+	MaxQueryAliasesPerContract = 32
+
+	// MaxProvenanceInfoSize is the longest source or builder of a code provenance
+	MaxProvenanceInfoSize = 512
+
+	// MaxProvenanceSignatureSize is the largest signature of a code provenance
+	MaxProvenanceSignatureSize = 256
+
+	// MaxAttestationInfoSize is the longest repo url or builder image of a code attestation
+	MaxAttestationInfoSize = 512
+
+	// MaxAttestationsPerCode is the maximum number of attesters per code
+	MaxAttestationsPerCode = 100
+
+	// MaxContractTags is the maximum number of tags a contract can have
+	MaxContractTags = 10
+
+	// MaxContractTagSize is the longest tag that can be set on a contract
+	MaxContractTagSize = 32
+
+	// MaxImportContractStateSize is the largest total size of the keys and values of an imported contract state
+	MaxImportContractStateSize = 4 * 1024 * 1024 // extension point for chains to customize via compile flag.
+
+	// MaxEventAttributeKeySize is the longest key of a contract event attribute in bytes after trimming
+	MaxEventAttributeKeySize = 256 // extension point for chains to customize via compile flag.
+
+	// MaxEventAttributeValueSize is the longest value of a contract event attribute in bytes after trimming
+	MaxEventAttributeValueSize = 64 * 1024 // extension point for chains to customize via compile flag.
 )
 
 func validateWasmCode(s []byte, maxSize int) error {
@@ -43,6 +94,41 @@ func validateWasmCode(s []byte, maxSize int) error {
 }
 
 // ValidateLabel ensure label constraints
+// ValidateContractTags ensures the tags are within the count limit, valid and unique
+func ValidateContractTags(tags []string) error {
+	if len(tags) > MaxContractTags {
+		return ErrLimit.Wrapf("cannot have more than %d tags", MaxContractTags)
+	}
+	seen := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		if err := ValidateContractTag(t); err != nil {
+			return err
+		}
+		if _, exists := seen[t]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "tag %q", t)
+		}
+		seen[t] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateContractTag ensures the tag is not empty, within the size limit and contains only
+// lower case ascii letters, digits, '-', '_' and '.'
+func ValidateContractTag(tag string) error {
+	if tag == "" {
+		return errorsmod.Wrap(ErrEmpty, "tag")
+	}
+	if len(tag) > MaxContractTagSize {
+		return ErrLimit.Wrapf("tag cannot be longer than %d characters", MaxContractTagSize)
+	}
+	for _, r := range tag {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return ErrInvalid.Wrapf("tag %q contains invalid character %q", tag, r)
+		}
+	}
+	return nil
+}
+
 func ValidateLabel(label string) error {
 	if label == "" {
 		return errorsmod.Wrap(ErrEmpty, "is required")
@@ -65,17 +151,90 @@ func ValidateLabel(label string) error {
 	return nil
 }
 
-// ValidateSalt ensure salt constraints
+// ValidateSalt ensures that the salt has 1 to MaxSaltSize bytes. This is the single salt check used
+// by the messages, the build address query and the CLI so that they accept the same salts.
+// ValidateChecksum ensures that the checksum has the size of a sha256 hash
+func ValidateChecksum(checksum []byte) error {
+	if len(checksum) != sha256.Size {
+		return errorsmod.Wrapf(ErrInvalid, "checksum must be %d bytes", sha256.Size)
+	}
+	return nil
+}
+
 func ValidateSalt(salt []byte) error {
 	switch n := len(salt); {
 	case n == 0:
-		return errorsmod.Wrap(ErrEmpty, "is required")
+		return ErrEmpty.Wrapf("salt must have 1 to %d bytes", MaxSaltSize)
 	case n > MaxSaltSize:
-		return ErrLimit.Wrapf("cannot be longer than %d characters", MaxSaltSize)
+		return ErrLimit.Wrapf("salt must have 1 to %d bytes, got %d", MaxSaltSize, n)
+	}
+	return nil
+}
+
+// ValidateParamSubspace ensure param subspace constraints
+func ValidateParamSubspace(subspace string) error {
+	switch n := len(subspace); {
+	case n == 0:
+		return errorsmod.Wrap(ErrEmpty, "is required")
+	case n > MaxParamSubspaceSize:
+		return ErrLimit.Wrapf("cannot be longer than %d characters", MaxParamSubspaceSize)
+	}
+	for _, r := range subspace {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return ErrInvalid.Wrap("subspace must contain lower case alphanumeric characters, '_' or '-' only")
+		}
+	}
+	return nil
+}
+
+// ValidateUploadID ensure upload id constraints of a chunked code upload
+func ValidateUploadID(uploadID string) error {
+	switch n := len(uploadID); {
+	case n == 0:
+		return errorsmod.Wrap(ErrEmpty, "is required")
+	case n > MaxUploadIDSize:
+		return ErrLimit.Wrapf("cannot be longer than %d characters", MaxUploadIDSize)
+	}
+	for _, r := range uploadID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return ErrInvalid.Wrap("upload id must contain alphanumeric characters, '_', '-' or '.' only")
+		}
+	}
+	return nil
+}
+
+// ValidateQueryAliasName ensure query alias name constraints
+func ValidateQueryAliasName(name string) error {
+	switch n := len(name); {
+	case n == 0:
+		return errorsmod.Wrap(ErrEmpty, "is required")
+	case n > MaxQueryAliasNameSize:
+		return ErrLimit.Wrapf("cannot be longer than %d characters", MaxQueryAliasNameSize)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return ErrInvalid.Wrap("name must contain alphanumeric characters, '_', '-' or '.' only")
+		}
+	}
+	return nil
+}
+
+// validateQueryAliasCacheTTL ensure query alias cache ttl constraints
+func validateQueryAliasCacheTTL(seconds uint32) error {
+	if seconds > MaxQueryAliasCacheTTLSeconds {
+		return ErrLimit.Wrapf("cannot be longer than %d seconds", MaxQueryAliasCacheTTLSeconds)
 	}
 	return nil
 }
 
+// validateQueryAliasData ensure query alias data constraints
+func validateQueryAliasData(queryData RawContractMessage) error {
+	if len(queryData) > MaxQueryAliasSize {
+		return ErrLimit.Wrapf("cannot be longer than %d bytes", MaxQueryAliasSize)
+	}
+	return queryData.ValidateBasic()
+}
+
 // ValidateVerificationInfo ensure source, builder and checksum constraints
 func ValidateVerificationInfo(source, builder string, codeHash []byte) error {
 	// if any set require others to be set
@@ -100,6 +259,61 @@ func ValidateVerificationInfo(source, builder string, codeHash []byte) error {
 	return nil
 }
 
+// ValidateProvenance ensure source, builder and signature constraints of a code provenance
+func ValidateProvenance(source, builder string, signature []byte) error {
+	switch {
+	case source == "":
+		return errorsmod.Wrap(ErrEmpty, "source")
+	case len(source) > MaxProvenanceInfoSize:
+		return ErrLimit.Wrapf("source cannot be longer than %d characters", MaxProvenanceInfoSize)
+	case builder == "":
+		return errorsmod.Wrap(ErrEmpty, "builder")
+	case len(builder) > MaxProvenanceInfoSize:
+		return ErrLimit.Wrapf("builder cannot be longer than %d characters", MaxProvenanceInfoSize)
+	case len(signature) == 0:
+		return errorsmod.Wrap(ErrEmpty, "signature")
+	case len(signature) > MaxProvenanceSignatureSize:
+		return ErrLimit.Wrapf("signature cannot be longer than %d bytes", MaxProvenanceSignatureSize)
+	}
+	if _, err := url.ParseRequestURI(source); err != nil {
+		return ErrInvalid.Wrapf("source: %s", err)
+	}
+	if _, err := reference.ParseDockerRef(builder); err != nil {
+		return ErrInvalid.Wrapf("builder: %s", err)
+	}
+	return nil
+}
+
+// ValidateAttestation ensure repo url, commit and builder image constraints of a code attestation
+func ValidateAttestation(repoURL, commit, builderImage string) error {
+	switch {
+	case repoURL == "":
+		return errorsmod.Wrap(ErrEmpty, "repo url")
+	case len(repoURL) > MaxAttestationInfoSize:
+		return ErrLimit.Wrapf("repo url cannot be longer than %d characters", MaxAttestationInfoSize)
+	case builderImage == "":
+		return errorsmod.Wrap(ErrEmpty, "builder image")
+	case len(builderImage) > MaxAttestationInfoSize:
+		return ErrLimit.Wrapf("builder image cannot be longer than %d characters", MaxAttestationInfoSize)
+	case len(commit) != 40 && len(commit) != 64: // sha1 or sha256 git object names
+		return ErrInvalid.Wrap("commit must be a full 40 or 64 characters hash")
+	}
+	if _, err := hex.DecodeString(commit); err != nil || strings.ToLower(commit) != commit {
+		return ErrInvalid.Wrap("commit must be lower case hex")
+	}
+	u, err := url.ParseRequestURI(repoURL)
+	if err != nil {
+		return ErrInvalid.Wrapf("repo url: %s", err)
+	}
+	if u.Scheme != "https" {
+		return ErrInvalid.Wrap("repo url must use https")
+	}
+	if _, err := reference.ParseDockerRef(builderImage); err != nil {
+		return ErrInvalid.Wrapf("builder image: %s", err)
+	}
+	return nil
+}
+
 // validateBech32Addresses ensures the list is not empty, has no duplicates
 // and does not exceed the max number of addresses
 func validateBech32Addresses(addresses []string) error {
@@ -0,0 +1,15 @@
+package types
+
+// WasmParamsCustomQuery is the custom query that contracts send to read the upload and instantiate
+// permissions of the wasm module params
+type WasmParamsCustomQuery struct {
+	WasmParams *struct{} `json:"wasm_params,omitempty"`
+}
+
+// WasmParamsResponse is the response to a WasmParamsCustomQuery
+type WasmParamsResponse struct {
+	// CodeUploadAccess is the access config for code uploads
+	CodeUploadAccess AccessConfig `json:"code_upload_access"`
+	// InstantiateDefaultPermission is the default instantiate permission of new codes
+	InstantiateDefaultPermission AccessType `json:"instantiate_default_permission"`
+}
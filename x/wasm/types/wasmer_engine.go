@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bytes"
+
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 
@@ -314,7 +316,8 @@ var _ wasmvm.KVStore = &StoreAdapter{}
 
 // StoreAdapter adapter to bridge SDK store impl to wasmvm
 type StoreAdapter struct {
-	parent storetypes.KVStore
+	parent                storetypes.KVStore
+	legacyReverseIterator bool
 }
 
 // NewStoreAdapter constructor
@@ -325,6 +328,15 @@ func NewStoreAdapter(s storetypes.KVStore) *StoreAdapter {
 	return &StoreAdapter{parent: s}
 }
 
+// NewLegacyReverseIteratorStoreAdapter constructor for the deprecated legacy reverse iterator mode.
+// Reverse iterators include the entry at the exclusive end key, like an old wasmd fork did. This mode
+// only exists for contracts that depend on that behavior and should not be used otherwise.
+func NewLegacyReverseIteratorStoreAdapter(s storetypes.KVStore) *StoreAdapter {
+	a := NewStoreAdapter(s)
+	a.legacyReverseIterator = true
+	return a
+}
+
 func (s StoreAdapter) Get(key []byte) []byte {
 	return s.parent.Get(key)
 }
@@ -342,5 +354,9 @@ func (s StoreAdapter) Iterator(start, end []byte) wasmvmtypes.Iterator {
 }
 
 func (s StoreAdapter) ReverseIterator(start, end []byte) wasmvmtypes.Iterator {
+	if s.legacyReverseIterator && end != nil {
+		// the smallest key after end makes the end inclusive
+		end = append(bytes.Clone(end), 0)
+	}
 	return s.parent.ReverseIterator(start, end)
 }
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	iavl2 "github.com/cosmos/iavl"
+	dbm "github.com/cosmos/iavl/db"
+	"github.com/stretchr/testify/assert"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/iavl"
+	"cosmossdk.io/store/prefix"
+)
+
+func TestStoreAdapterIteratorOrder(t *testing.T) {
+	tree := iavl2.NewMutableTree(dbm.NewMemDB(), 50, false, log.NewTestLogger(t))
+	// contract state is always a prefix store. Entries of the neighbour prefixes must not show up
+	kvstore := iavl.UnsafeNewStore(tree)
+	kvstore.Set([]byte("a"), []byte("other"))
+	kvstore.Set([]byte("c"), []byte("other"))
+	contractStore := prefix.NewStore(kvstore, []byte("b"))
+
+	ascending := []KV{
+		{[]byte("bar"), []byte("1")},
+		{[]byte("ra"), []byte("2")},
+		{[]byte("zi"), []byte("3")},
+	}
+	for _, kv := range ascending {
+		contractStore.Set(kv.Key, kv.Value)
+	}
+	descending := []KV{ascending[2], ascending[1], ascending[0]}
+
+	specs := map[string]struct {
+		start, end []byte
+		reverse    bool
+		exp        []KV
+		expLegacy  []KV
+	}{
+		"all ascending":                   {exp: ascending},
+		"ascending start inclusive":       {start: []byte("ra"), exp: ascending[1:]},
+		"ascending end exclusive":         {end: []byte("ra"), exp: ascending[:1]},
+		"ascending both points":           {start: []byte("bar"), end: []byte("zi"), exp: ascending[:2]},
+		"ascending bounds between keys":   {start: []byte("c"), end: []byte("s"), exp: ascending[1:2]},
+		"ascending empty range":           {start: []byte("s"), end: []byte("z"), exp: nil},
+		"ascending start equals end":      {start: []byte("ra"), end: []byte("ra"), exp: nil},
+		"all descending":                  {reverse: true, exp: descending},
+		"descending start inclusive":      {start: []byte("ra"), reverse: true, exp: descending[:2]},
+		"descending end exclusive":        {end: []byte("ra"), reverse: true, exp: descending[2:], expLegacy: descending[1:]},
+		"descending both points":          {start: []byte("bar"), end: []byte("zi"), reverse: true, exp: descending[1:], expLegacy: descending},
+		"descending bounds between keys":  {start: []byte("c"), end: []byte("s"), reverse: true, exp: descending[1:2]},
+		"descending empty range":          {start: []byte("s"), end: []byte("z"), reverse: true, exp: nil},
+		"descending start equals end":     {start: []byte("ra"), end: []byte("ra"), reverse: true, exp: nil, expLegacy: descending[1:2]},
+		"descending end after last key":   {start: []byte("ra"), end: []byte("zz"), reverse: true, exp: descending[:2]},
+		"descending end before first key": {end: []byte("b"), reverse: true, exp: nil},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			iterate := func(s *StoreAdapter) []KV {
+				var iter wasmvmtypes.Iterator
+				if spec.reverse {
+					iter = s.ReverseIterator(spec.start, spec.end)
+				} else {
+					iter = s.Iterator(spec.start, spec.end)
+				}
+				defer iter.Close()
+				var res []KV
+				for ; iter.Valid(); iter.Next() {
+					res = append(res, KV{iter.Key(), iter.Value()})
+				}
+				return res
+			}
+			// default behavior
+			assert.Equal(t, spec.exp, iterate(NewStoreAdapter(contractStore)))
+
+			// deprecated legacy mode only differs for reverse iterators with an existing end key
+			expLegacy := spec.exp
+			if spec.expLegacy != nil {
+				expLegacy = spec.expLegacy
+			}
+			assert.Equal(t, expLegacy, iterate(NewLegacyReverseIteratorStoreAdapter(contractStore)))
+		})
+	}
+}